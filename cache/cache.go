@@ -0,0 +1,667 @@
+// Package cache provides a read-through caching decorator for a
+// domain.Repository.
+//
+// Records fetched by id and the relationship lists (the authors of an agent
+// or a book, the books of an author) are cached in an in-process LRU with a
+// TTL. Every mutation invalidates exactly the entries it may have changed and
+// publishes the invalidation, so that other instances can apply it too.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain"       // update the username
+	"github.com/fwojciec/litag-example/internal/lru" // update the username
+)
+
+// Publisher shares the tags invalidated by mutations with the other instances
+// of the application.
+type Publisher interface {
+	Publish(ctx context.Context, tags []string) error
+}
+
+// Repository is a domain.Repository that caches reads of the repository it
+// wraps. Methods it does not override are passed through uncached.
+type Repository struct {
+	domain.Repository
+
+	// Publisher, if set, receives the tags invalidated by every mutation.
+	Publisher Publisher
+	// Primary, if set, returns a context whose reads go to the primary
+	// rather than to a replica. Misses within ReplicationLag of an
+	// invalidation read through it, so that a lagging replica cannot put
+	// back the records that were just invalidated.
+	Primary func(ctx context.Context) context.Context
+	// ReplicationLag bounds the replication lag of the replicas.
+	ReplicationLag time.Duration
+
+	c *lru.LRU
+}
+
+// NewRepository returns a new instance of Repository holding at most size
+// entries, each for at most ttl.
+func NewRepository(repo domain.Repository, size int, ttl time.Duration) *Repository {
+	return &Repository{
+		Repository:     repo,
+		ReplicationLag: 5 * time.Second,
+		c:              lru.New(size, ttl),
+	}
+}
+
+var _ domain.Repository = (*Repository)(nil)
+
+// Invalidate removes the entries carrying any of the tags without publishing
+// the invalidation; it applies invalidations received from other instances.
+func (r *Repository) Invalidate(tags ...string) {
+	r.c.Invalidate(tags...)
+}
+
+// Purge removes all entries, for when invalidations may have been missed.
+func (r *Repository) Purge() {
+	r.c.Purge()
+}
+
+// Stats are the counters of the cache.
+type Stats = lru.Stats
+
+// Stats returns the hit, miss and eviction counters of the cache.
+func (r *Repository) Stats() Stats {
+	return r.c.Stats()
+}
+
+// invalidate removes the entries carrying any of the tags and publishes the
+// invalidation. A failure to publish does not fail the mutation; other
+// instances serve stale entries until they expire.
+func (r *Repository) invalidate(ctx context.Context, tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+	r.c.Invalidate(tags...)
+	if r.Publisher != nil {
+		if err := r.Publisher.Publish(ctx, tags); err != nil {
+			log.Printf("cache: failed to publish invalidation: %s", err)
+		}
+	}
+}
+
+// fill returns the context of a read that fills the cache on a miss.
+func (r *Repository) fill(ctx context.Context) context.Context {
+	if r.Primary != nil && r.c.InvalidatedWithin(r.ReplicationLag) {
+		return r.Primary(ctx)
+	}
+	return ctx
+}
+
+// tags
+
+func agencyKey(id int64) string {
+	return fmt.Sprintf("agency:%d", id)
+}
+
+// employsTag is carried by every cached agent with an agency, so that
+// deleting the agency, which leaves its agents without one, invalidates them.
+func employsTag(agencyID int64) string {
+	return fmt.Sprintf("agency:%d:employs", agencyID)
+}
+
+func agentKey(id int64) string {
+	return fmt.Sprintf("agent:%d", id)
+}
+
+func agentAuthorsKey(id int64) string {
+	return fmt.Sprintf("agent:%d:authors", id)
+}
+
+// representedByTag is carried by every cached author represented by an agent,
+// so that reassigning the agent's authors invalidates them.
+func representedByTag(agentID int64) string {
+	return fmt.Sprintf("agent:%d:represents", agentID)
+}
+
+func authorKey(id int64) string {
+	return fmt.Sprintf("author:%d", id)
+}
+
+func authorBooksKey(id int64) string {
+	return fmt.Sprintf("author:%d:books", id)
+}
+
+func bookKey(id int64) string {
+	return fmt.Sprintf("book:%d", id)
+}
+
+func bookAuthorsKey(id int64) string {
+	return fmt.Sprintf("book:%d:authors", id)
+}
+
+func publisherKey(id int64) string {
+	return fmt.Sprintf("publisher:%d", id)
+}
+
+// publishesTag is carried by every cached book with a publisher, so that
+// deleting the publisher, which leaves its books without one, invalidates
+// them.
+func publishesTag(publisherID int64) string {
+	return fmt.Sprintf("publisher:%d:publishes", publisherID)
+}
+
+func seriesKey(id int64) string {
+	return fmt.Sprintf("series:%d", id)
+}
+
+// seriesBooksTag is carried by every cached book in a series, so that
+// reordering or deleting the series, which moves its books, invalidates them.
+func seriesBooksTag(seriesID int64) string {
+	return fmt.Sprintf("series:%d:books", seriesID)
+}
+
+func webhookKey(id int64) string {
+	return fmt.Sprintf("webhook:%d", id)
+}
+
+func agentTags(a domain.Agent) []string {
+	if a.AgencyID == nil {
+		return nil
+	}
+	return []string{employsTag(*a.AgencyID)}
+}
+
+func authorsTags(authors []domain.Author) []string {
+	tags := make([]string, 0, 2*len(authors))
+	for _, a := range authors {
+		tags = append(tags, authorKey(a.ID), representedByTag(a.AgentID))
+	}
+	return tags
+}
+
+func bookTags(b domain.Book) []string {
+	var tags []string
+	if b.PublisherID != nil {
+		tags = append(tags, publishesTag(*b.PublisherID))
+	}
+	if b.SeriesID != nil {
+		tags = append(tags, seriesBooksTag(*b.SeriesID))
+	}
+	return tags
+}
+
+func booksTags(books []domain.Book) []string {
+	tags := make([]string, 0, 2*len(books))
+	for _, b := range books {
+		tags = append(tags, bookKey(b.ID))
+		tags = append(tags, bookTags(b)...)
+	}
+	return tags
+}
+
+// reads
+
+// GetAgency returns an agency.
+func (r *Repository) GetAgency(ctx context.Context, id int64) (domain.Agency, error) {
+	key := agencyKey(id)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return v.(domain.Agency), nil
+	}
+	agency, err := r.Repository.GetAgency(r.fill(ctx), id)
+	if err != nil {
+		return domain.Agency{}, err
+	}
+	r.c.Set(version, key, agency)
+	return agency, nil
+}
+
+// GetAgent returns an agent.
+func (r *Repository) GetAgent(ctx context.Context, id int64) (domain.Agent, error) {
+	key := agentKey(id)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return v.(domain.Agent), nil
+	}
+	agent, err := r.Repository.GetAgent(r.fill(ctx), id)
+	if err != nil {
+		return domain.Agent{}, err
+	}
+	r.c.Set(version, key, agent, agentTags(agent)...)
+	return agent, nil
+}
+
+// GetAuthor returns an author.
+func (r *Repository) GetAuthor(ctx context.Context, id int64) (domain.Author, error) {
+	key := authorKey(id)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return v.(domain.Author), nil
+	}
+	author, err := r.Repository.GetAuthor(r.fill(ctx), id)
+	if err != nil {
+		return domain.Author{}, err
+	}
+	r.c.Set(version, key, author, representedByTag(author.AgentID))
+	return author, nil
+}
+
+// GetBook returns a book.
+func (r *Repository) GetBook(ctx context.Context, id int64) (domain.Book, error) {
+	key := bookKey(id)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return v.(domain.Book), nil
+	}
+	book, err := r.Repository.GetBook(r.fill(ctx), id)
+	if err != nil {
+		return domain.Book{}, err
+	}
+	r.c.Set(version, key, book, bookTags(book)...)
+	return book, nil
+}
+
+// GetPublisher returns a publisher.
+func (r *Repository) GetPublisher(ctx context.Context, id int64) (domain.Publisher, error) {
+	key := publisherKey(id)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return v.(domain.Publisher), nil
+	}
+	publisher, err := r.Repository.GetPublisher(r.fill(ctx), id)
+	if err != nil {
+		return domain.Publisher{}, err
+	}
+	r.c.Set(version, key, publisher)
+	return publisher, nil
+}
+
+// GetSeries returns a series.
+func (r *Repository) GetSeries(ctx context.Context, id int64) (domain.Series, error) {
+	key := seriesKey(id)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return v.(domain.Series), nil
+	}
+	series, err := r.Repository.GetSeries(r.fill(ctx), id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	r.c.Set(version, key, series)
+	return series, nil
+}
+
+// GetWebhook returns a webhook.
+func (r *Repository) GetWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	key := webhookKey(id)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return v.(domain.Webhook), nil
+	}
+	webhook, err := r.Repository.GetWebhook(r.fill(ctx), id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	r.c.Set(version, key, webhook)
+	return webhook, nil
+}
+
+// ListAuthorsByAgentID returns the authors represented by an agent.
+func (r *Repository) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]domain.Author, error) {
+	key := agentAuthorsKey(agentID)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return copyAuthors(v.([]domain.Author)), nil
+	}
+	authors, err := r.Repository.ListAuthorsByAgentID(r.fill(ctx), agentID)
+	if err != nil {
+		return nil, err
+	}
+	r.c.Set(version, key, copyAuthors(authors), authorsTags(authors)...)
+	return authors, nil
+}
+
+// ListAuthorsByBookID returns the authors of a book.
+func (r *Repository) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]domain.Author, error) {
+	key := bookAuthorsKey(bookID)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return copyAuthors(v.([]domain.Author)), nil
+	}
+	authors, err := r.Repository.ListAuthorsByBookID(r.fill(ctx), bookID)
+	if err != nil {
+		return nil, err
+	}
+	r.c.Set(version, key, copyAuthors(authors), authorsTags(authors)...)
+	return authors, nil
+}
+
+// ListBooksByAuthorID returns the books of an author.
+func (r *Repository) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]domain.Book, error) {
+	key := authorBooksKey(authorID)
+	v, version, ok := r.c.Get(key)
+	if ok {
+		return copyBooks(v.([]domain.Book)), nil
+	}
+	books, err := r.Repository.ListBooksByAuthorID(r.fill(ctx), authorID)
+	if err != nil {
+		return nil, err
+	}
+	r.c.Set(version, key, copyBooks(books), booksTags(books)...)
+	return books, nil
+}
+
+func copyAuthors(authors []domain.Author) []domain.Author {
+	return append(make([]domain.Author, 0, len(authors)), authors...)
+}
+
+func copyBooks(books []domain.Book) []domain.Book {
+	return append(make([]domain.Book, 0, len(books)), books...)
+}
+
+// agency mutations
+
+// UpdateAgency updates an agency.
+func (r *Repository) UpdateAgency(ctx context.Context, args domain.UpdateAgencyParams) (domain.Agency, error) {
+	agency, err := r.Repository.UpdateAgency(ctx, args)
+	if err != nil {
+		return domain.Agency{}, err
+	}
+	r.invalidate(ctx, agencyKey(args.ID))
+	return agency, nil
+}
+
+// DeleteAgency deletes an agency. Its agents are invalidated because they are
+// left without an agency.
+func (r *Repository) DeleteAgency(ctx context.Context, id int64) (domain.Agency, error) {
+	agency, err := r.Repository.DeleteAgency(ctx, id)
+	if err != nil {
+		return domain.Agency{}, err
+	}
+	r.invalidate(ctx, agencyKey(id), employsTag(id))
+	return agency, nil
+}
+
+// agent mutations
+
+// UpdateAgent updates an agent.
+func (r *Repository) UpdateAgent(ctx context.Context, args domain.UpdateAgentParams) (domain.Agent, error) {
+	agent, err := r.Repository.UpdateAgent(ctx, args)
+	if err != nil {
+		return domain.Agent{}, err
+	}
+	r.invalidate(ctx, agentKey(args.ID))
+	return agent, nil
+}
+
+// DeleteAgent deletes an agent.
+func (r *Repository) DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+	agent, err := r.Repository.DeleteAgent(ctx, id, reassignAuthorsTo)
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{agentKey(id), agentAuthorsKey(id), representedByTag(id)}
+	if reassignAuthorsTo != nil {
+		tags = append(tags, agentAuthorsKey(*reassignAuthorsTo))
+	}
+	r.invalidate(ctx, tags...)
+	return agent, nil
+}
+
+// author mutations
+
+// CreateAuthor creates an author.
+func (r *Repository) CreateAuthor(ctx context.Context, args domain.CreateAuthorParams) (*domain.Author, error) {
+	author, err := r.Repository.CreateAuthor(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, agentAuthorsKey(author.AgentID))
+	return author, nil
+}
+
+// UpdateAuthor updates an author. The lists of the author's previous agent
+// and books are invalidated because they contain the author.
+func (r *Repository) UpdateAuthor(ctx context.Context, args domain.UpdateAuthorParams) (*domain.Author, error) {
+	author, err := r.Repository.UpdateAuthor(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, authorKey(args.ID), agentAuthorsKey(args.AgentID))
+	return author, nil
+}
+
+// DeleteAuthor deletes an author.
+func (r *Repository) DeleteAuthor(ctx context.Context, id int64, orphanedBooks domain.OrphanedBooksPolicy) (*domain.Author, error) {
+	// the books deleted along with the author are not known afterwards
+	var books []domain.Book
+	if orphanedBooks == domain.OrphanedBooksDelete {
+		var err error
+		books, err = r.Repository.ListBooksByAuthorID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	author, err := r.Repository.DeleteAuthor(ctx, id, orphanedBooks)
+	if err != nil {
+		return nil, err
+	}
+	tags := []string{authorKey(id), authorBooksKey(id)}
+	for _, b := range books {
+		tags = append(tags, bookKey(b.ID), bookAuthorsKey(b.ID))
+	}
+	r.invalidate(ctx, tags...)
+	return author, nil
+}
+
+// CreateAuthors creates authors in bulk.
+func (r *Repository) CreateAuthors(ctx context.Context, args []domain.CreateAuthorParams, mode domain.BulkMode) (*domain.BulkAuthorsResult, error) {
+	res, err := r.Repository.CreateAuthors(ctx, args, mode)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, author := range res.Authors {
+		if author != nil {
+			tags = append(tags, agentAuthorsKey(author.AgentID))
+		}
+	}
+	r.invalidate(ctx, tags...)
+	return res, nil
+}
+
+// book mutations
+
+// CreateBook creates a book.
+func (r *Repository) CreateBook(ctx context.Context, args domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+	book, err := r.Repository.CreateBook(ctx, args, contributors, genreIDs, tags)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, createBookTags(contributors)...)
+	return book, nil
+}
+
+// UpdateBook updates a book. The lists of the book's previous authors are
+// invalidated because they contain the book.
+func (r *Repository) UpdateBook(ctx context.Context, args domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+	book, err := r.Repository.UpdateBook(ctx, args, contributors, genreIDs, tags)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, updateBookTags(args.ID, contributors)...)
+	return book, nil
+}
+
+// DeleteBook deletes a book.
+func (r *Repository) DeleteBook(ctx context.Context, id int64) (*domain.Book, error) {
+	book, err := r.Repository.DeleteBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, bookKey(id), bookAuthorsKey(id))
+	return book, nil
+}
+
+// CreateBooks creates books in bulk.
+func (r *Repository) CreateBooks(ctx context.Context, args []domain.BulkCreateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+	res, err := r.Repository.CreateBooks(ctx, args, mode)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for i, book := range res.Books {
+		if book != nil {
+			tags = append(tags, createBookTags(args[i].Contributors)...)
+		}
+	}
+	r.invalidate(ctx, tags...)
+	return res, nil
+}
+
+// UpdateBooks updates books in bulk.
+func (r *Repository) UpdateBooks(ctx context.Context, args []domain.BulkUpdateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+	res, err := r.Repository.UpdateBooks(ctx, args, mode)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for i, book := range res.Books {
+		if book != nil {
+			tags = append(tags, updateBookTags(args[i].Book.ID, args[i].Contributors)...)
+		}
+	}
+	r.invalidate(ctx, tags...)
+	return res, nil
+}
+
+func createBookTags(contributors []domain.Contributor) []string {
+	tags := make([]string, 0, len(contributors))
+	for _, c := range contributors {
+		tags = append(tags, authorBooksKey(c.AuthorID))
+	}
+	return tags
+}
+
+func updateBookTags(id int64, contributors []domain.Contributor) []string {
+	return append([]string{bookKey(id), bookAuthorsKey(id)}, createBookTags(contributors)...)
+}
+
+// publisher mutations
+
+// UpdatePublisher updates a publisher.
+func (r *Repository) UpdatePublisher(ctx context.Context, args domain.UpdatePublisherParams) (domain.Publisher, error) {
+	publisher, err := r.Repository.UpdatePublisher(ctx, args)
+	if err != nil {
+		return domain.Publisher{}, err
+	}
+	r.invalidate(ctx, publisherKey(args.ID))
+	return publisher, nil
+}
+
+// DeletePublisher deletes a publisher. Its books are invalidated because they
+// are left without a publisher.
+func (r *Repository) DeletePublisher(ctx context.Context, id int64) (domain.Publisher, error) {
+	publisher, err := r.Repository.DeletePublisher(ctx, id)
+	if err != nil {
+		return domain.Publisher{}, err
+	}
+	r.invalidate(ctx, publisherKey(id), publishesTag(id))
+	return publisher, nil
+}
+
+// representation mutations
+
+// CreateRepresentation creates a co-representation. The author and the
+// authors of the agent are invalidated because representations decide who
+// represents an author.
+func (r *Repository) CreateRepresentation(ctx context.Context, args domain.CreateRepresentationParams) (domain.Representation, error) {
+	representation, err := r.Repository.CreateRepresentation(ctx, args)
+	if err != nil {
+		return domain.Representation{}, err
+	}
+	r.invalidate(ctx, authorKey(args.AuthorID), agentAuthorsKey(args.AgentID))
+	return representation, nil
+}
+
+// EndRepresentation ends a co-representation, invalidating the author and the
+// authors of the agent like CreateRepresentation.
+func (r *Repository) EndRepresentation(ctx context.Context, id int64, endedAt domain.Date) (domain.Representation, error) {
+	representation, err := r.Repository.EndRepresentation(ctx, id, endedAt)
+	if err != nil {
+		return domain.Representation{}, err
+	}
+	r.invalidate(ctx, authorKey(representation.AuthorID), agentAuthorsKey(representation.AgentID))
+	return representation, nil
+}
+
+// series mutations
+
+// UpdateSeries updates a series.
+func (r *Repository) UpdateSeries(ctx context.Context, args domain.UpdateSeriesParams) (domain.Series, error) {
+	series, err := r.Repository.UpdateSeries(ctx, args)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	r.invalidate(ctx, seriesKey(args.ID))
+	return series, nil
+}
+
+// ReorderSeries reorders the books of a series, invalidating them.
+func (r *Repository) ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+	series, err := r.Repository.ReorderSeries(ctx, id, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, seriesBooksTag(id))
+	return series, nil
+}
+
+// DeleteSeries deletes a series. Its books are invalidated because they are
+// left outside of any series.
+func (r *Repository) DeleteSeries(ctx context.Context, id int64) (*domain.Series, error) {
+	series, err := r.Repository.DeleteSeries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, seriesKey(id), seriesBooksTag(id))
+	return series, nil
+}
+
+// submission mutations
+
+// ConvertSubmission converts a submission into a book by a new author. The
+// authors of the agent of the submission, which never changes, are invalidated
+// because they now include the new author.
+func (r *Repository) ConvertSubmission(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+	submission, err := r.Repository.GetSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	book, err := r.Repository.ConvertSubmission(ctx, id, cover)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, agentAuthorsKey(submission.AgentID))
+	return book, nil
+}
+
+// webhook mutations
+
+// UpdateWebhook updates a webhook.
+func (r *Repository) UpdateWebhook(ctx context.Context, args domain.UpdateWebhookParams) (domain.Webhook, error) {
+	webhook, err := r.Repository.UpdateWebhook(ctx, args)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	r.invalidate(ctx, webhookKey(args.ID))
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook.
+func (r *Repository) DeleteWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	webhook, err := r.Repository.DeleteWebhook(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+	r.invalidate(ctx, webhookKey(id))
+	return webhook, nil
+}
@@ -0,0 +1,388 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/litag-example/cache"
+	"github.com/fwojciec/litag-example/domain"
+	"github.com/fwojciec/litag-example/generated/mocks"
+)
+
+type primaryKey struct{}
+
+type publisherFunc func(ctx context.Context, tags []string) error
+
+func (f publisherFunc) Publish(ctx context.Context, tags []string) error {
+	return f(ctx, tags)
+}
+
+// newRepositoryMock returns a mock serving one agency, agent, author, book,
+// publisher and series and counting the calls of every read.
+func newRepositoryMock(calls map[string]int) *mocks.RepositoryMock {
+	agencyID := int64(8)
+	author := domain.Author{ID: 2, Name: "author", AgentID: 1}
+	publisherID, seriesID, seriesPosition := int64(6), int64(7), 1
+	book := domain.Book{
+		ID:             3,
+		Title:          "book",
+		PublisherID:    &publisherID,
+		SeriesID:       &seriesID,
+		SeriesPosition: &seriesPosition,
+	}
+	return &mocks.RepositoryMock{
+		GetAgencyFunc: func(ctx context.Context, id int64) (domain.Agency, error) {
+			calls["GetAgency"]++
+			return domain.Agency{ID: id, Name: "agency"}, nil
+		},
+		GetAgentFunc: func(ctx context.Context, id int64) (domain.Agent, error) {
+			calls["GetAgent"]++
+			if id != 1 {
+				return domain.Agent{}, domain.ErrNotFound
+			}
+			return domain.Agent{ID: 1, Name: "agent", AgencyID: &agencyID}, nil
+		},
+		GetAuthorFunc: func(ctx context.Context, id int64) (domain.Author, error) {
+			calls["GetAuthor"]++
+			return author, nil
+		},
+		GetBookFunc: func(ctx context.Context, id int64) (domain.Book, error) {
+			calls["GetBook"]++
+			return book, nil
+		},
+		GetPublisherFunc: func(ctx context.Context, id int64) (domain.Publisher, error) {
+			calls["GetPublisher"]++
+			return domain.Publisher{ID: id, Name: "publisher"}, nil
+		},
+		GetSeriesFunc: func(ctx context.Context, id int64) (domain.Series, error) {
+			calls["GetSeries"]++
+			return domain.Series{ID: id, Title: "series"}, nil
+		},
+		ListAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Author, error) {
+			calls["ListAuthorsByAgentID"]++
+			return []domain.Author{author}, nil
+		},
+		ListAuthorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Author, error) {
+			calls["ListAuthorsByBookID"]++
+			return []domain.Author{author}, nil
+		},
+		ListBooksByAuthorIDFunc: func(ctx context.Context, authorID int64) ([]domain.Book, error) {
+			calls["ListBooksByAuthorID"]++
+			return []domain.Book{book}, nil
+		},
+		UpdateAgencyFunc: func(ctx context.Context, args domain.UpdateAgencyParams) (domain.Agency, error) {
+			return domain.Agency{ID: args.ID}, nil
+		},
+		DeleteAgencyFunc: func(ctx context.Context, id int64) (domain.Agency, error) {
+			return domain.Agency{ID: id}, nil
+		},
+		UpdateAgentFunc: func(ctx context.Context, args domain.UpdateAgentParams) (domain.Agent, error) {
+			return domain.Agent{ID: args.ID}, nil
+		},
+		DeleteAgentFunc: func(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+			return &domain.Agent{ID: id}, nil
+		},
+		CreateAuthorFunc: func(ctx context.Context, args domain.CreateAuthorParams) (*domain.Author, error) {
+			return &domain.Author{ID: 20, AgentID: args.AgentID}, nil
+		},
+		UpdateAuthorFunc: func(ctx context.Context, args domain.UpdateAuthorParams) (*domain.Author, error) {
+			return &domain.Author{ID: args.ID, AgentID: args.AgentID}, nil
+		},
+		DeleteAuthorFunc: func(ctx context.Context, id int64, orphanedBooks domain.OrphanedBooksPolicy) (*domain.Author, error) {
+			return &domain.Author{ID: id}, nil
+		},
+		CreateBookFunc: func(ctx context.Context, args domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+			return &domain.Book{ID: 30}, nil
+		},
+		UpdateBookFunc: func(ctx context.Context, args domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+			return &domain.Book{ID: args.ID}, nil
+		},
+		DeleteBookFunc: func(ctx context.Context, id int64) (*domain.Book, error) {
+			return &domain.Book{ID: id}, nil
+		},
+		UpdatePublisherFunc: func(ctx context.Context, args domain.UpdatePublisherParams) (domain.Publisher, error) {
+			return domain.Publisher{ID: args.ID}, nil
+		},
+		DeletePublisherFunc: func(ctx context.Context, id int64) (domain.Publisher, error) {
+			return domain.Publisher{ID: id}, nil
+		},
+		CreateRepresentationFunc: func(ctx context.Context, args domain.CreateRepresentationParams) (domain.Representation, error) {
+			return domain.Representation{ID: 40, AuthorID: args.AuthorID, AgentID: args.AgentID}, nil
+		},
+		EndRepresentationFunc: func(ctx context.Context, id int64, endedAt domain.Date) (domain.Representation, error) {
+			return domain.Representation{ID: id, AuthorID: 2, AgentID: 4, EndedAt: &endedAt}, nil
+		},
+		UpdateSeriesFunc: func(ctx context.Context, args domain.UpdateSeriesParams) (domain.Series, error) {
+			return domain.Series{ID: args.ID}, nil
+		},
+		ReorderSeriesFunc: func(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+			return &domain.Series{ID: id}, nil
+		},
+		DeleteSeriesFunc: func(ctx context.Context, id int64) (*domain.Series, error) {
+			return &domain.Series{ID: id}, nil
+		},
+		GetSubmissionFunc: func(ctx context.Context, id int64) (domain.Submission, error) {
+			return domain.Submission{ID: id, AgentID: 1}, nil
+		},
+		ConvertSubmissionFunc: func(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+			return &domain.Book{ID: 31}, nil
+		},
+	}
+}
+
+// readAll performs every cached read once.
+func readAll(t *testing.T, r *cache.Repository) {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := r.GetAgency(ctx, 8); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetAgent(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetAuthor(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetBook(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetPublisher(ctx, 6); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetSeries(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ListAuthorsByAgentID(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ListAuthorsByBookID(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ListBooksByAuthorID(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Read through", func(t *testing.T) {
+		t.Parallel()
+		calls := map[string]int{}
+		r := cache.NewRepository(newRepositoryMock(calls), 100, time.Minute)
+		readAll(t, r)
+		readAll(t, r)
+		exp := map[string]int{
+			"GetAgency":            1,
+			"GetAgent":             1,
+			"GetAuthor":            1,
+			"GetBook":              1,
+			"GetPublisher":         1,
+			"GetSeries":            1,
+			"ListAuthorsByAgentID": 1,
+			"ListAuthorsByBookID":  1,
+			"ListBooksByAuthorID":  1,
+		}
+		if !reflect.DeepEqual(calls, exp) {
+			t.Errorf("expected calls %v, received %v", exp, calls)
+		}
+		stats := r.Stats()
+		if stats.Hits != 9 || stats.Misses != 9 || stats.Entries != 9 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		t.Parallel()
+		calls := map[string]int{}
+		r := cache.NewRepository(newRepositoryMock(calls), 100, time.Minute)
+		for i := 0; i < 2; i++ {
+			if _, err := r.GetAgent(context.Background(), 99); !errors.Is(err, domain.ErrNotFound) {
+				t.Errorf("expected not found, received %v", err)
+			}
+		}
+		if calls["GetAgent"] != 2 {
+			t.Errorf("expected 2 calls, received %d", calls["GetAgent"])
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		t.Parallel()
+		calls := map[string]int{}
+		r := cache.NewRepository(newRepositoryMock(calls), 100, time.Millisecond)
+		r.GetAgent(context.Background(), 1)
+		time.Sleep(5 * time.Millisecond)
+		r.GetAgent(context.Background(), 1)
+		if calls["GetAgent"] != 2 {
+			t.Errorf("expected 2 calls, received %d", calls["GetAgent"])
+		}
+	})
+
+	t.Run("Eviction", func(t *testing.T) {
+		t.Parallel()
+		calls := map[string]int{}
+		r := cache.NewRepository(newRepositoryMock(calls), 2, time.Minute)
+		ctx := context.Background()
+		r.GetAgent(ctx, 1)
+		r.GetAuthor(ctx, 2)
+		r.GetAgent(ctx, 1) // the author is now least recently used
+		r.GetBook(ctx, 3)
+		r.GetAgent(ctx, 1)
+		r.GetAuthor(ctx, 2)
+		if calls["GetAgent"] != 1 || calls["GetAuthor"] != 2 {
+			t.Errorf("expected the author to be evicted, received calls %v", calls)
+		}
+		if stats := r.Stats(); stats.Entries != 2 || stats.Evictions != 2 {
+			t.Errorf("unexpected stats: %+v", stats)
+		}
+	})
+
+	t.Run("Invalidation", func(t *testing.T) {
+		t.Parallel()
+		reassignTo := int64(4)
+		tests := []struct {
+			name        string
+			mutate      func(r *cache.Repository)
+			invalidated []string
+		}{
+			{"UpdateAgency", func(r *cache.Repository) {
+				r.UpdateAgency(context.Background(), domain.UpdateAgencyParams{ID: 8})
+			}, []string{"GetAgency"}},
+			{"DeleteAgency", func(r *cache.Repository) {
+				r.DeleteAgency(context.Background(), 8)
+			}, []string{"GetAgency", "GetAgent"}},
+			{"UpdateAgent", func(r *cache.Repository) {
+				r.UpdateAgent(context.Background(), domain.UpdateAgentParams{ID: 1})
+			}, []string{"GetAgent"}},
+			{"DeleteAgent", func(r *cache.Repository) {
+				r.DeleteAgent(context.Background(), 1, &reassignTo)
+			}, []string{"GetAgent", "GetAuthor", "ListAuthorsByAgentID", "ListAuthorsByBookID"}},
+			{"CreateAuthor", func(r *cache.Repository) {
+				r.CreateAuthor(context.Background(), domain.CreateAuthorParams{AgentID: 1})
+			}, []string{"ListAuthorsByAgentID"}},
+			{"UpdateAuthor", func(r *cache.Repository) {
+				r.UpdateAuthor(context.Background(), domain.UpdateAuthorParams{ID: 2, AgentID: 4})
+			}, []string{"GetAuthor", "ListAuthorsByAgentID", "ListAuthorsByBookID"}},
+			{"DeleteAuthor", func(r *cache.Repository) {
+				r.DeleteAuthor(context.Background(), 2, domain.OrphanedBooksKeep)
+			}, []string{"GetAuthor", "ListAuthorsByAgentID", "ListAuthorsByBookID", "ListBooksByAuthorID"}},
+			{"DeleteAuthor deleting books", func(r *cache.Repository) {
+				r.DeleteAuthor(context.Background(), 2, domain.OrphanedBooksDelete)
+			}, []string{"GetAuthor", "GetBook", "ListAuthorsByAgentID", "ListAuthorsByBookID", "ListBooksByAuthorID"}},
+			{"CreateBook", func(r *cache.Repository) {
+				r.CreateBook(context.Background(), domain.CreateBookParams{}, []domain.Contributor{{AuthorID: 2}}, nil, nil)
+			}, []string{"ListBooksByAuthorID"}},
+			{"CreateBook of another author", func(r *cache.Repository) {
+				r.CreateBook(context.Background(), domain.CreateBookParams{}, []domain.Contributor{{AuthorID: 5}}, nil, nil)
+			}, []string{}},
+			{"UpdateBook", func(r *cache.Repository) {
+				r.UpdateBook(context.Background(), domain.UpdateBookParams{ID: 3}, []domain.Contributor{{AuthorID: 5}}, nil, nil)
+			}, []string{"GetBook", "ListAuthorsByBookID", "ListBooksByAuthorID"}},
+			{"DeleteBook", func(r *cache.Repository) {
+				r.DeleteBook(context.Background(), 3)
+			}, []string{"GetBook", "ListAuthorsByBookID", "ListBooksByAuthorID"}},
+			{"UpdatePublisher", func(r *cache.Repository) {
+				r.UpdatePublisher(context.Background(), domain.UpdatePublisherParams{ID: 6})
+			}, []string{"GetPublisher"}},
+			{"DeletePublisher", func(r *cache.Repository) {
+				r.DeletePublisher(context.Background(), 6)
+			}, []string{"GetBook", "GetPublisher", "ListBooksByAuthorID"}},
+			{"CreateRepresentation", func(r *cache.Repository) {
+				r.CreateRepresentation(context.Background(), domain.CreateRepresentationParams{AuthorID: 2, AgentID: 1})
+			}, []string{"GetAuthor", "ListAuthorsByAgentID", "ListAuthorsByBookID"}},
+			{"CreateRepresentation of another author", func(r *cache.Repository) {
+				r.CreateRepresentation(context.Background(), domain.CreateRepresentationParams{AuthorID: 5, AgentID: 4})
+			}, []string{}},
+			{"EndRepresentation", func(r *cache.Repository) {
+				r.EndRepresentation(context.Background(), 40, domain.Date{})
+			}, []string{"GetAuthor", "ListAuthorsByAgentID", "ListAuthorsByBookID"}},
+			{"UpdateSeries", func(r *cache.Repository) {
+				r.UpdateSeries(context.Background(), domain.UpdateSeriesParams{ID: 7})
+			}, []string{"GetSeries"}},
+			{"ReorderSeries", func(r *cache.Repository) {
+				r.ReorderSeries(context.Background(), 7, []int64{3})
+			}, []string{"GetBook", "ListBooksByAuthorID"}},
+			{"DeleteSeries", func(r *cache.Repository) {
+				r.DeleteSeries(context.Background(), 7)
+			}, []string{"GetBook", "GetSeries", "ListBooksByAuthorID"}},
+			{"ConvertSubmission", func(r *cache.Repository) {
+				r.ConvertSubmission(context.Background(), 9, "cover.jpg")
+			}, []string{"ListAuthorsByAgentID"}},
+			{"Invalidate", func(r *cache.Repository) {
+				r.Invalidate("agent:1")
+			}, []string{"GetAgent"}},
+			{"Purge", func(r *cache.Repository) {
+				r.Purge()
+			}, []string{"GetAgency", "GetAgent", "GetAuthor", "GetBook", "GetPublisher", "GetSeries", "ListAuthorsByAgentID", "ListAuthorsByBookID", "ListBooksByAuthorID"}},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				calls := map[string]int{}
+				r := cache.NewRepository(newRepositoryMock(calls), 100, time.Minute)
+				readAll(t, r)
+				for k := range calls {
+					calls[k] = 0
+				}
+				tc.mutate(r)
+				readAll(t, r)
+				received := []string{}
+				for k, n := range calls {
+					if n > 0 {
+						received = append(received, k)
+					}
+				}
+				sort.Strings(received)
+				if !reflect.DeepEqual(received, tc.invalidated) {
+					t.Errorf("expected %v to be refetched, received %v", tc.invalidated, received)
+				}
+			})
+		}
+	})
+
+	t.Run("Publisher", func(t *testing.T) {
+		t.Parallel()
+		var published []string
+		r := cache.NewRepository(newRepositoryMock(map[string]int{}), 100, time.Minute)
+		r.Publisher = publisherFunc(func(ctx context.Context, tags []string) error {
+			published = append(published, tags...)
+			return nil
+		})
+		r.UpdateBook(context.Background(), domain.UpdateBookParams{ID: 3}, []domain.Contributor{{AuthorID: 2}, {AuthorID: 5}}, nil, nil)
+		exp := []string{"book:3", "book:3:authors", "author:2:books", "author:5:books"}
+		if !reflect.DeepEqual(published, exp) {
+			t.Errorf("expected %v, received %v", exp, published)
+		}
+	})
+	t.Run("Primary after invalidation", func(t *testing.T) {
+		t.Parallel()
+		var fromPrimary []bool
+		mock := newRepositoryMock(map[string]int{})
+		getAgent := mock.GetAgentFunc
+		mock.GetAgentFunc = func(ctx context.Context, id int64) (domain.Agent, error) {
+			fromPrimary = append(fromPrimary, ctx.Value(primaryKey{}) != nil)
+			return getAgent(ctx, id)
+		}
+		r := cache.NewRepository(mock, 100, time.Minute)
+		r.Primary = func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, primaryKey{}, true)
+		}
+		r.ReplicationLag = 20 * time.Millisecond
+		ctx := context.Background()
+		r.GetAgent(ctx, 1)
+		r.Invalidate("agent:1")
+		r.GetAgent(ctx, 1)
+		r.Invalidate("agent:1")
+		time.Sleep(40 * time.Millisecond)
+		r.GetAgent(ctx, 1)
+		exp := []bool{false, true, false}
+		if !reflect.DeepEqual(fromPrimary, exp) {
+			t.Errorf("expected reads from the primary %v, received %v", exp, fromPrimary)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fwojciec/litag-example/exporter" // update your username
+	"github.com/fwojciec/litag-example/postgres" // update your username
+)
+
+// runExport implements the export subcommand:
+//
+//	litag-example export --format csv|json|ndjson --entity agents|authors|books|deletions [--updated-since TIME] [FILE]
+//
+// The records are written to FILE, or to the standard output if FILE is
+// omitted or "-".
+func runExport(db *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "json", "output format: csv, json or ndjson")
+	entity := fs.String("entity", "", "exported records: agents, authors, books or deletions")
+	updatedSince := fs.String("updated-since", "", "only export records updated at or after this RFC 3339 timestamp")
+	fs.Parse(args)
+	if *entity == "" {
+		fs.Usage()
+		return errors.New("the --entity flag is required")
+	}
+	var since time.Time
+	if *updatedSince != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, *updatedSince); err != nil {
+			return errors.New("--updated-since must be an RFC 3339 timestamp")
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if path := fs.Arg(0); path != "" && path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return exporter.Run(
+		context.Background(),
+		postgres.NewExporter(db),
+		w,
+		exporter.Format(*format),
+		exporter.Entity(*entity),
+		since,
+	)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fwojciec/litag-example/importer" // update your username
+	"github.com/fwojciec/litag-example/postgres" // update your username
+)
+
+// runImport implements the import subcommand:
+//
+//	litag-example import --format csv|json|ndjson --entity agents|authors|books [--dry-run] [FILE]
+//
+// The records are read from FILE, or from the standard input if FILE is
+// omitted or "-".
+func runImport(db *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "csv", "input format: csv, json or ndjson")
+	entity := fs.String("entity", "", "imported records: agents, authors or books")
+	dryRun := fs.Bool("dry-run", false, "report the changes without applying them")
+	fs.Parse(args)
+	if *entity == "" {
+		fs.Usage()
+		return errors.New("the --entity flag is required")
+	}
+
+	var r io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	s, err := importer.Run(
+		context.Background(),
+		postgres.NewImporter(db),
+		r,
+		importer.Format(*format),
+		importer.Entity(*entity),
+		*dryRun,
+	)
+	if err != nil {
+		return err
+	}
+	printImportSummary(os.Stdout, *entity, s, *dryRun)
+	if len(s.Errors) > 0 {
+		return fmt.Errorf("%d rows could not be imported", len(s.Errors))
+	}
+	return nil
+}
+
+func printImportSummary(w io.Writer, entity string, s *postgres.ImportSummary, dryRun bool) {
+	for _, c := range s.Changes {
+		sign := "+"
+		if c.Action == postgres.ImportUpdate {
+			sign = "~"
+		}
+		fmt.Fprintf(w, "%s line %d: %s\n", sign, c.Line, c.Key)
+	}
+	for _, e := range s.Errors {
+		fmt.Fprintf(w, "! %s\n", e)
+	}
+	mode := ""
+	if dryRun {
+		mode = " (dry run, nothing was changed)"
+	}
+	fmt.Fprintf(
+		w,
+		"%s: %d inserted, %d updated, %d unchanged, %d rejected%s\n",
+		entity, s.Inserted, s.Updated, s.Unchanged, len(s.Errors), mode,
+	)
+}
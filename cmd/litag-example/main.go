@@ -1,42 +1,232 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"expvar"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/99designs/gqlgen/handler"
+	"github.com/fwojciec/litag-example/cache"            // update your username
+	"github.com/fwojciec/litag-example/domain"           // update your username
+	"github.com/fwojciec/litag-example/exporter"         // update your username
 	"github.com/fwojciec/litag-example/generated/gqlgen" // update your username
+	"github.com/fwojciec/litag-example/memory"           // update your username
+	"github.com/fwojciec/litag-example/persisted"        // update your username
 	"github.com/fwojciec/litag-example/postgres"         // update your username
 	"github.com/fwojciec/litag-example/resolvers"        // update your username
+	"github.com/fwojciec/litag-example/webhooks"         // update your username
 )
 
+const dsn = "dbname=litag_db sslmode=disable"
+
 func main() {
 	// initialize the db
-	db, err := sql.Open("postgres", "dbname=litag_db sslmode=disable")
+	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		panic(err)
 	}
 	defer db.Close()
 
+	// run the import or export subcommand instead of the server if requested
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(db, os.Args[2:]); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(db, os.Args[2:]); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
+	store := flag.String("store", "postgres", "datalayer: postgres or memory")
+	seed := flag.String("seed", "", "JSON file to seed the memory store with")
+	var replicaDSNs stringsFlag
+	flag.Var(&replicaDSNs, "replica", "connection string of a read replica; may be repeated")
+	readYourWrites := flag.Bool("read-your-writes", true, "send the reads of a request to the primary after it writes")
+	maxOpenConns := flag.Int("max-open-conns", 20, "maximum number of open connections per pool; 0 means unlimited")
+	maxIdleConns := flag.Int("max-idle-conns", 10, "maximum number of idle connections per pool")
+	connMaxLifetime := flag.Duration("conn-max-lifetime", 30*time.Minute, "maximum time a connection is reused; 0 means forever")
+	operationTimeout := flag.Duration("operation-timeout", 10*time.Second, "deadline of a GraphQL operation; 0 means none")
+	queryTimeout := flag.Duration("query-timeout", 5*time.Second, "deadline of a single query; 0 means none")
+	statementTimeout := flag.Duration("statement-timeout", 5*time.Second, "statement_timeout of write transactions; 0 means the server default")
+	cacheSize := flag.Int("cache-size", 0, "maximum number of cached records and relationship lists; 0 disables the cache")
+	cacheTTL := flag.Duration("cache-ttl", time.Minute, "time a record or relationship list stays cached")
+	apqCacheSize := flag.Int("apq-cache-size", 1000, "maximum number of automatic persisted queries kept; 0 disables them")
+	allowlistPath := flag.String("allowlist", "", "JSON file of the only operations to execute, by sha256 hash (production mode)")
+	adminAddr := flag.String("admin-addr", "localhost:6060", "address of the admin listener serving /debug/vars; empty disables it")
+	flag.Parse()
+
+	configurePool := func(db *sql.DB) {
+		db.SetMaxOpenConns(*maxOpenConns)
+		db.SetMaxIdleConns(*maxIdleConns)
+		db.SetConnMaxLifetime(*connMaxLifetime)
+	}
+
 	// initialize the repo
-	repo := postgres.NewRepo(db)
+	var repo domain.Repository
+	var deliveries webhooks.Store
+	switch *store {
+	case "postgres":
+		configurePool(db)
+		replicas := make([]*sql.DB, 0, len(replicaDSNs))
+		for _, dsn := range replicaDSNs {
+			replica, err := sql.Open("postgres", dsn)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			defer replica.Close()
+			configurePool(replica)
+			replicas = append(replicas, replica)
+		}
+		pgRepo := postgres.NewRepoWithConfig(db, postgres.Config{
+			Replicas:         replicas,
+			QueryTimeout:     *queryTimeout,
+			StatementTimeout: *statementTimeout,
+		})
+		if pgRepo.Replicas != nil {
+			go pgRepo.Replicas.Run(context.Background())
+		}
+		adapter := postgres.NewAdapter(pgRepo)
+		repo, deliveries = adapter, adapter
+	case "memory":
+		m := memory.New()
+		if *seed != "" {
+			if err := seedMemoryStore(m, *seed); err != nil {
+				log.Fatalln(err)
+			}
+		}
+		repo, deliveries = m, m
+	default:
+		log.Fatalf("unknown store %q\n", *store)
+	}
+
+	// deliver webhooks in the background
+	go webhooks.NewDispatcher(deliveries).Run(context.Background())
+
+	// cache reads if requested; with PostgreSQL invalidations are shared
+	// with the other instances
+	domainRepo := repo
+	if *cacheSize > 0 {
+		cached := cache.NewRepository(domainRepo, *cacheSize, *cacheTTL)
+		if *store == "postgres" {
+			cached.Publisher = postgres.NewNotifier(db)
+			cached.Primary = postgres.ReadFromPrimary
+			go func() {
+				if err := postgres.ListenInvalidations(context.Background(), dsn, cached); err != nil {
+					log.Fatalln(err)
+				}
+			}()
+		}
+		expvar.Publish("cache", expvar.Func(func() interface{} { return cached.Stats() }))
+		domainRepo = cached
+	}
+
+	// serve persisted queries from the allowlist in production mode and from
+	// the queries registered by clients otherwise
+	gqlOptions := []handler.Option{handler.ErrorPresenter(resolvers.ErrorPresenter)}
+	if *allowlistPath != "" {
+		allowlist, err := persisted.LoadAllowlist(*allowlistPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		gqlOptions = append(gqlOptions,
+			handler.EnablePersistedQueryCache(allowlist),
+			handler.RequestMiddleware(allowlist.Middleware),
+		)
+	} else if *apqCacheSize > 0 {
+		apqCache, err := persisted.NewCache(*apqCacheSize)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		gqlOptions = append(gqlOptions, handler.EnablePersistedQueryCache(apqCache))
+	}
 
 	// initialize the GraphQL handler
-	gqlHandler := handler.GraphQL(gqlgen.NewExecutableSchema(gqlgen.Config{
-		Resolvers: &resolvers.Resolver{
-			Repo: repo,
-		},
-	}))
+	gqlHandler := handler.GraphQL(
+		gqlgen.NewExecutableSchema(gqlgen.Config{
+			Resolvers: &resolvers.Resolver{
+				Repo: domainRepo,
+			},
+		}),
+		gqlOptions...,
+	)
 
 	// configure the server
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", handler.Playground("GraphQL Playground", "/query"))
+	if *readYourWrites {
+		gqlHandler = readYourWritesHandler(gqlHandler)
+	}
+	if *operationTimeout > 0 {
+		gqlHandler = timeoutHandler(gqlHandler, *operationTimeout)
+	}
 	mux.HandleFunc("/query", gqlHandler)
 
+	// serve exports only if a token to authenticate them is configured; they
+	// stream directly from PostgreSQL
+	if token := os.Getenv("LITAG_EXPORT_TOKEN"); token != "" && *store == "postgres" {
+		mux.Handle("/export/", exporter.Handler(postgres.NewExporter(db), token))
+	}
+
+	// serve the expvar counters, which include the command line and so the
+	// connection strings, only on the admin listener
+	if *adminAddr != "" {
+		adminMux := http.NewServeMux()
+		adminMux.Handle("/debug/vars", expvar.Handler())
+		go func() {
+			log.Fatalln(http.ListenAndServe(*adminAddr, adminMux))
+		}()
+	}
+
 	// run the server
 	port := ":8080"
 	fmt.Printf("🚀 Server ready at http://localhost%s\n", port)
 	log.Fatalln(http.ListenAndServe(port, mux))
 }
+
+func seedMemoryStore(m *memory.Store, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.Seed(context.Background(), f)
+}
+
+// readYourWritesHandler pins each request to the primary once it writes.
+func readYourWritesHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(postgres.ReadYourWrites(r.Context())))
+	}
+}
+
+// timeoutHandler gives each request a deadline.
+func timeoutHandler(next http.HandlerFunc, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// stringsFlag is a flag that may be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
@@ -0,0 +1,983 @@
+// Package domain defines the catalog's records and the Repository the API
+// layer uses to read and change them, independently of how they are stored.
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Agency is a literary agency the agents work for.
+type Agency struct {
+	ID   int64
+	Name string
+}
+
+// Agent is a literary agent, optionally working for an agency. DeletedAt is
+// set once an agent that is part of the history of authors or deals has been
+// deleted; such an agent is kept for that history only.
+type Agent struct {
+	ID        int64
+	Name      string
+	Email     string
+	AgencyID  *int64
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// Author is an author represented by an agent.
+type Author struct {
+	ID        int64
+	Name      string
+	Website   *string
+	AgentID   int64
+	UpdatedAt time.Time
+}
+
+// Book is a book written by one or more authors. A book in a series has a
+// position in it.
+type Book struct {
+	ID             int64
+	Title          string
+	Description    string
+	Cover          string
+	PublisherID    *int64
+	SeriesID       *int64
+	SeriesPosition *int
+	ISBN           *ISBN
+	PublishedOn    *Date
+	PageCount      *int
+	Language       *LanguageCode
+	UpdatedAt      time.Time
+}
+
+// Contributor is an author credited on a book in a role. Position orders the
+// contributors of a book, starting from 1.
+type Contributor struct {
+	AuthorID int64
+	Role     ContributorRole
+	Position int
+}
+
+// Deal is the sale of the rights to a book in a territory, language and
+// format to a publisher, negotiated by an agent. The royalty rate on sales
+// and the commission of the agent on royalties are in basis points.
+type Deal struct {
+	ID             int64
+	BookID         int64
+	PublisherID    int64
+	AgentID        int64
+	Territory      string
+	Language       LanguageCode
+	Format         EditionFormat
+	Advance        Money
+	SignedOn       *Date
+	Status         DealStatus
+	RoyaltyRate    int
+	CommissionRate int
+}
+
+// RoyaltyRate overrides the royalty rate of a deal for the sales of an
+// edition.
+type RoyaltyRate struct {
+	DealID    int64
+	EditionID int64
+	Rate      int
+}
+
+// Sales are the units of an edition sold under a deal in a quarter, such as
+// 2024-Q1, and the revenue from them.
+type Sales struct {
+	ID        int64
+	DealID    int64
+	EditionID int64
+	Period    string
+	Units     int
+	Revenue   Money
+}
+
+// AvailableRights are the formats of a book whose rights in a territory and
+// language no deal holds.
+type AvailableRights struct {
+	BookID  int64
+	Formats []EditionFormat
+}
+
+// Edition is a published form of a book, such as its paperback or ebook.
+type Edition struct {
+	ID          int64
+	BookID      int64
+	Format      EditionFormat
+	ISBN        *ISBN
+	PublishedOn *Date
+	Price       *Price
+}
+
+// Price is an amount in the minor units of an ISO 4217 currency, such as
+// cents.
+type Price struct {
+	Amount   int
+	Currency string
+}
+
+// Genre classifies books. Genres form a tree; a book classified in a genre
+// also belongs to its ancestors.
+type Genre struct {
+	ID       int64
+	Name     string
+	ParentID *int64
+}
+
+// Publisher is a publisher of books.
+type Publisher struct {
+	ID   int64
+	Name string
+}
+
+// Representation is an agent representing an author in a territory over a
+// period. An author has exactly one current primary representation; its agent
+// is the author's agent, and changing that agent ends it and starts another.
+type Representation struct {
+	ID        int64
+	AuthorID  int64
+	AgentID   int64
+	Territory string
+	StartedAt Date
+	EndedAt   *Date
+	Primary   bool
+}
+
+// Series is an ordered sequence of books.
+type Series struct {
+	ID    int64
+	Title string
+}
+
+// Submission is a manuscript an author sent to an agent, before there is a
+// book. BookID is set once an offered submission is converted into a book.
+type Submission struct {
+	ID            int64
+	AgentID       int64
+	AuthorName    string
+	AuthorWebsite *string
+	Title         string
+	Synopsis      string
+	Status        SubmissionStatus
+	ReceivedAt    time.Time
+	BookID        *int64
+}
+
+// SubmissionTransition records a change of the status of a submission.
+type SubmissionTransition struct {
+	ID             int64
+	SubmissionID   int64
+	From           SubmissionStatus
+	To             SubmissionStatus
+	Note           *string
+	TransitionedAt time.Time
+}
+
+// Webhook is a subscription to catalog change events.
+type Webhook struct {
+	ID         int64
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// WebhookDelivery is a single event queued for delivery to a webhook.
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	ResponseStatus *int
+	LastError      *string
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+}
+
+// CreateAgencyParams are the fields of a new agency.
+type CreateAgencyParams struct {
+	Name string
+}
+
+// UpdateAgencyParams are the fields of an updated agency.
+type UpdateAgencyParams struct {
+	ID   int64
+	Name string
+}
+
+// CreateAgentParams are the fields of a new agent.
+type CreateAgentParams struct {
+	Name     string
+	Email    string
+	AgencyID *int64
+}
+
+// UpdateAgentParams are the fields of an updated agent.
+type UpdateAgentParams struct {
+	ID       int64
+	Name     string
+	Email    string
+	AgencyID *int64
+}
+
+// CreateAuthorParams are the fields of a new author.
+type CreateAuthorParams struct {
+	Name    string
+	Website *string
+	AgentID int64
+}
+
+// UpdateAuthorParams are the fields of an updated author.
+type UpdateAuthorParams struct {
+	ID      int64
+	Name    string
+	Website *string
+	AgentID int64
+}
+
+// CreateBookParams are the fields of a new book.
+type CreateBookParams struct {
+	Title          string
+	Description    string
+	Cover          string
+	PublisherID    *int64
+	SeriesID       *int64
+	SeriesPosition *int
+	ISBN           *ISBN
+	PublishedOn    *Date
+	PageCount      *int
+	Language       *LanguageCode
+}
+
+// UpdateBookParams are the fields of an updated book.
+type UpdateBookParams struct {
+	ID             int64
+	Title          string
+	Description    string
+	Cover          string
+	PublisherID    *int64
+	SeriesID       *int64
+	SeriesPosition *int
+	ISBN           *ISBN
+	PublishedOn    *Date
+	PageCount      *int
+	Language       *LanguageCode
+}
+
+// CreateDealParams are the fields of a new deal.
+type CreateDealParams struct {
+	BookID         int64
+	PublisherID    int64
+	AgentID        int64
+	Territory      string
+	Language       LanguageCode
+	Format         EditionFormat
+	Advance        Money
+	SignedOn       *Date
+	Status         DealStatus
+	RoyaltyRate    int
+	CommissionRate int
+}
+
+// UpdateDealParams are the fields of an updated deal.
+type UpdateDealParams struct {
+	ID             int64
+	BookID         int64
+	PublisherID    int64
+	AgentID        int64
+	Territory      string
+	Language       LanguageCode
+	Format         EditionFormat
+	Advance        Money
+	SignedOn       *Date
+	Status         DealStatus
+	RoyaltyRate    int
+	CommissionRate int
+}
+
+// RecordSalesParams are the sales of an edition under a deal in a quarter.
+// They replace any sales recorded earlier for the same quarter.
+type RecordSalesParams struct {
+	DealID    int64
+	EditionID int64
+	Period    string
+	Units     int
+	Revenue   Money
+}
+
+// CreateEditionParams are the fields of a new edition.
+type CreateEditionParams struct {
+	BookID      int64
+	Format      EditionFormat
+	ISBN        *ISBN
+	PublishedOn *Date
+	Price       *Price
+}
+
+// UpdateEditionParams are the fields of an updated edition.
+type UpdateEditionParams struct {
+	ID          int64
+	BookID      int64
+	Format      EditionFormat
+	ISBN        *ISBN
+	PublishedOn *Date
+	Price       *Price
+}
+
+// ListEditionsParams select the editions matching all of the set fields.
+// The publication dates are inclusive.
+type ListEditionsParams struct {
+	BookID        *int64
+	Format        *EditionFormat
+	PublishedFrom *Date
+	PublishedTo   *Date
+}
+
+// CreateGenreParams are the fields of a new genre.
+type CreateGenreParams struct {
+	Name     string
+	ParentID *int64
+}
+
+// UpdateGenreParams are the fields of an updated genre.
+type UpdateGenreParams struct {
+	ID       int64
+	Name     string
+	ParentID *int64
+}
+
+// CreatePublisherParams are the fields of a new publisher.
+type CreatePublisherParams struct {
+	Name string
+}
+
+// UpdatePublisherParams are the fields of an updated publisher.
+type UpdatePublisherParams struct {
+	ID   int64
+	Name string
+}
+
+// CreateRepresentationParams are the fields of a new co-representation.
+type CreateRepresentationParams struct {
+	AuthorID  int64
+	AgentID   int64
+	Territory string
+	StartedAt Date
+}
+
+// CreateSeriesParams are the fields of a new series.
+type CreateSeriesParams struct {
+	Title string
+}
+
+// UpdateSeriesParams are the fields of an updated series.
+type UpdateSeriesParams struct {
+	ID    int64
+	Title string
+}
+
+// CreateSubmissionParams are the fields of a new submission.
+type CreateSubmissionParams struct {
+	AgentID       int64
+	AuthorName    string
+	AuthorWebsite *string
+	Title         string
+	Synopsis      string
+}
+
+// ListSubmissionsByAgentIDAndStatusParams select the submissions to an agent
+// with a status.
+type ListSubmissionsByAgentIDAndStatusParams struct {
+	AgentID int64
+	Status  SubmissionStatus
+}
+
+// CreateWebhookParams are the fields of a new webhook.
+type CreateWebhookParams struct {
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// UpdateWebhookParams are the fields of an updated webhook.
+type UpdateWebhookParams struct {
+	ID         int64
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// ListWebhookDeliveriesByStatusParams select the deliveries of a webhook
+// with a status.
+type ListWebhookDeliveriesByStatusParams struct {
+	WebhookID int64
+	Status    string
+}
+
+// Repository represents the datalayer methods used by the API. Methods that
+// change more than one record do so atomically.
+type Repository interface {
+	// agencies
+	CreateAgency(ctx context.Context, args CreateAgencyParams) (Agency, error)
+	GetAgency(ctx context.Context, id int64) (Agency, error)
+	ListAgencies(ctx context.Context) ([]Agency, error)
+	UpdateAgency(ctx context.Context, args UpdateAgencyParams) (Agency, error)
+	DeleteAgency(ctx context.Context, id int64) (Agency, error)
+
+	// agents
+	CreateAgent(ctx context.Context, args CreateAgentParams) (Agent, error)
+	GetAgent(ctx context.Context, id int64) (Agent, error)
+	ListAgents(ctx context.Context) ([]Agent, error)
+	ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]Agent, error)
+	UpdateAgent(ctx context.Context, args UpdateAgentParams) (Agent, error)
+	DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*Agent, error)
+
+	// authors
+	CreateAuthor(ctx context.Context, args CreateAuthorParams) (*Author, error)
+	GetAuthor(ctx context.Context, id int64) (Author, error)
+	ListAuthors(ctx context.Context) ([]Author, error)
+	ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]Author, error)
+	ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]Author, error)
+	ListAuthorsByBookID(ctx context.Context, bookID int64) ([]Author, error)
+	ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]Author, error)
+	UpdateAuthor(ctx context.Context, args UpdateAuthorParams) (*Author, error)
+	DeleteAuthor(ctx context.Context, id int64, orphanedBooks OrphanedBooksPolicy) (*Author, error)
+
+	// books
+	CreateBook(ctx context.Context, args CreateBookParams, contributors []Contributor, genreIDs []int64, tags []string) (*Book, error)
+	GetBook(ctx context.Context, id int64) (Book, error)
+	GetBookByISBN(ctx context.Context, isbn ISBN) (Book, error)
+	ListBooks(ctx context.Context) ([]Book, error)
+	ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]Book, error)
+	ListBooksByAuthorID(ctx context.Context, authorID int64) ([]Book, error)
+	ListBooksByGenreID(ctx context.Context, genreID int64, includeSubgenres bool) ([]Book, error)
+	ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]Book, error)
+	ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]Book, error)
+	ListBooksByTag(ctx context.Context, tag string) ([]Book, error)
+	ListContributorsByBookID(ctx context.Context, bookID int64) ([]Contributor, error)
+	ListOrphanBooks(ctx context.Context) ([]Book, error)
+	ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error)
+	UpdateBook(ctx context.Context, args UpdateBookParams, contributors []Contributor, genreIDs []int64, tags []string) (*Book, error)
+	DeleteBook(ctx context.Context, id int64) (*Book, error)
+
+	// deals
+	CreateDeal(ctx context.Context, args CreateDealParams) (Deal, error)
+	GetDeal(ctx context.Context, id int64) (Deal, error)
+	ListDealsByAgentID(ctx context.Context, agentID int64) ([]Deal, error)
+	ListDealsByBookID(ctx context.Context, bookID int64) ([]Deal, error)
+	ListAvailableRights(ctx context.Context, territory string, language LanguageCode) ([]AvailableRights, error)
+	UpdateDeal(ctx context.Context, args UpdateDealParams) (Deal, error)
+	DeleteDeal(ctx context.Context, id int64) (Deal, error)
+
+	// editions
+	CreateEdition(ctx context.Context, args CreateEditionParams) (Edition, error)
+	GetEdition(ctx context.Context, id int64) (Edition, error)
+	GetEditionByISBN(ctx context.Context, isbn ISBN) (Edition, error)
+	ListEditions(ctx context.Context, args ListEditionsParams) ([]Edition, error)
+	ListEditionsByBookID(ctx context.Context, bookID int64) ([]Edition, error)
+	UpdateEdition(ctx context.Context, args UpdateEditionParams) (Edition, error)
+	DeleteEdition(ctx context.Context, id int64) (Edition, error)
+
+	// genres
+	CreateGenre(ctx context.Context, args CreateGenreParams) (Genre, error)
+	GetGenre(ctx context.Context, id int64) (Genre, error)
+	ListGenres(ctx context.Context) ([]Genre, error)
+	ListGenresByParentID(ctx context.Context, parentID int64) ([]Genre, error)
+	ListGenresByBookID(ctx context.Context, bookID int64) ([]Genre, error)
+	UpdateGenre(ctx context.Context, args UpdateGenreParams) (*Genre, error)
+	DeleteGenre(ctx context.Context, id int64) (Genre, error)
+
+	// publishers
+	CreatePublisher(ctx context.Context, args CreatePublisherParams) (Publisher, error)
+	GetPublisher(ctx context.Context, id int64) (Publisher, error)
+	ListPublishers(ctx context.Context) ([]Publisher, error)
+	UpdatePublisher(ctx context.Context, args UpdatePublisherParams) (Publisher, error)
+	DeletePublisher(ctx context.Context, id int64) (Publisher, error)
+
+	// representations
+	CreateRepresentation(ctx context.Context, args CreateRepresentationParams) (Representation, error)
+	ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]Representation, error)
+	EndRepresentation(ctx context.Context, id int64, endedAt Date) (Representation, error)
+
+	// royalties
+	SetRoyaltyRate(ctx context.Context, dealID, editionID int64, rate int) (RoyaltyRate, error)
+	ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]RoyaltyRate, error)
+	DeleteRoyaltyRate(ctx context.Context, dealID, editionID int64) (RoyaltyRate, error)
+	RecordSales(ctx context.Context, args RecordSalesParams) (Sales, error)
+	ListSalesByDealID(ctx context.Context, dealID int64) ([]Sales, error)
+	DeleteSales(ctx context.Context, id int64) (Sales, error)
+	GetRoyaltyStatement(ctx context.Context, authorID int64, period string) (RoyaltyStatement, error)
+
+	// series
+	CreateSeries(ctx context.Context, args CreateSeriesParams) (Series, error)
+	GetSeries(ctx context.Context, id int64) (Series, error)
+	ListSeries(ctx context.Context) ([]Series, error)
+	UpdateSeries(ctx context.Context, args UpdateSeriesParams) (Series, error)
+	ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*Series, error)
+	DeleteSeries(ctx context.Context, id int64) (*Series, error)
+
+	// submissions
+	CreateSubmission(ctx context.Context, args CreateSubmissionParams) (Submission, error)
+	GetSubmission(ctx context.Context, id int64) (Submission, error)
+	ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]Submission, error)
+	ListSubmissionsByAgentIDAndStatus(ctx context.Context, args ListSubmissionsByAgentIDAndStatusParams) ([]Submission, error)
+	ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]SubmissionTransition, error)
+	TransitionSubmission(ctx context.Context, id int64, status SubmissionStatus, note *string) (*Submission, error)
+	ConvertSubmission(ctx context.Context, id int64, cover string) (*Book, error)
+
+	// bulk methods
+	CreateAgents(ctx context.Context, args []CreateAgentParams, mode BulkMode) (*BulkAgentsResult, error)
+	CreateAuthors(ctx context.Context, args []CreateAuthorParams, mode BulkMode) (*BulkAuthorsResult, error)
+	CreateBooks(ctx context.Context, args []BulkCreateBookArgs, mode BulkMode) (*BulkBooksResult, error)
+	UpdateBooks(ctx context.Context, args []BulkUpdateBookArgs, mode BulkMode) (*BulkBooksResult, error)
+
+	// webhooks
+	CreateWebhook(ctx context.Context, args CreateWebhookParams) (Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (Webhook, error)
+	ListWebhooks(ctx context.Context) ([]Webhook, error)
+	UpdateWebhook(ctx context.Context, args UpdateWebhookParams) (Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) (Webhook, error)
+	ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]WebhookDelivery, error)
+	ListWebhookDeliveriesByStatus(ctx context.Context, args ListWebhookDeliveriesByStatusParams) ([]WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+}
+
+// ErrNotFound is returned when a record does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrTimeout is returned when an operation runs out of time.
+var ErrTimeout = errors.New("operation timed out")
+
+// ConstraintKind is the kind of constraint a ConstraintError violates.
+type ConstraintKind string
+
+// Constraint kinds.
+const (
+	// ForeignKeyConstraint is violated by a reference to a record that does
+	// not exist and by deleting a record that is still referenced.
+	ForeignKeyConstraint ConstraintKind = "foreign key"
+	// UniqueConstraint is violated by a duplicate value.
+	UniqueConstraint ConstraintKind = "unique"
+	// CheckConstraint is violated by an invalid value.
+	CheckConstraint ConstraintKind = "check"
+)
+
+// ConstraintError is returned when a write violates a constraint that has no
+// more specific error. Table is the table of the offending row and
+// Constraint the name the schema gives the constraint, if it has one.
+type ConstraintError struct {
+	Kind       ConstraintKind
+	Table      string
+	Constraint string
+	Message    string
+}
+
+func (e *ConstraintError) Error() string {
+	return e.Message
+}
+
+// Webhook event types, emitted whenever an author or a book changes.
+const (
+	EventAuthorCreated = "author.created"
+	EventAuthorUpdated = "author.updated"
+	EventAuthorDeleted = "author.deleted"
+	EventBookCreated   = "book.created"
+	EventBookUpdated   = "book.updated"
+	EventBookDeleted   = "book.deleted"
+)
+
+// WebhookEventTypes lists all event types a webhook can subscribe to.
+var WebhookEventTypes = []string{
+	EventAuthorCreated,
+	EventAuthorUpdated,
+	EventAuthorDeleted,
+	EventBookCreated,
+	EventBookUpdated,
+	EventBookDeleted,
+}
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// WebhookEvent is the JSON document delivered to webhook subscribers.
+type WebhookEvent struct {
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+// AuthorPayload is the webhook representation of an author.
+type AuthorPayload struct {
+	ID      int64   `json:"id"`
+	Name    string  `json:"name"`
+	Website *string `json:"website"`
+	AgentID int64   `json:"agentID"`
+}
+
+// NewAuthorPayload returns the webhook representation of the author.
+func NewAuthorPayload(a Author) AuthorPayload {
+	return AuthorPayload{
+		ID:      a.ID,
+		Name:    a.Name,
+		Website: a.Website,
+		AgentID: a.AgentID,
+	}
+}
+
+// BookPayload is the webhook representation of a book.
+type BookPayload struct {
+	ID             int64   `json:"id"`
+	Title          string  `json:"title"`
+	Description    string  `json:"description"`
+	Cover          string  `json:"cover"`
+	PublisherID    *int64  `json:"publisherID"`
+	SeriesID       *int64  `json:"seriesID"`
+	SeriesPosition *int    `json:"seriesPosition"`
+	ISBN           *string `json:"isbn"`
+	PublishedOn    *string `json:"publishedOn"`
+	PageCount      *int    `json:"pageCount"`
+	Language       *string `json:"language"`
+	AuthorIDs      []int64 `json:"authorIDs"`
+}
+
+// NewBookPayload returns the webhook representation of the book written by
+// the authors.
+func NewBookPayload(b Book, authorIDs []int64) BookPayload {
+	if authorIDs == nil {
+		authorIDs = []int64{}
+	}
+	p := BookPayload{
+		ID:             b.ID,
+		Title:          b.Title,
+		Description:    b.Description,
+		Cover:          b.Cover,
+		PublisherID:    b.PublisherID,
+		SeriesID:       b.SeriesID,
+		SeriesPosition: b.SeriesPosition,
+		PageCount:      b.PageCount,
+		AuthorIDs:      authorIDs,
+	}
+	if b.ISBN != nil {
+		isbn := string(*b.ISBN)
+		p.ISBN = &isbn
+	}
+	if b.PublishedOn != nil {
+		publishedOn := b.PublishedOn.String()
+		p.PublishedOn = &publishedOn
+	}
+	if b.Language != nil {
+		language := string(*b.Language)
+		p.Language = &language
+	}
+	return p
+}
+
+// ClaimedWebhookDelivery is a delivery leased to a dispatcher, along with the
+// URL and secret of its webhook.
+type ClaimedWebhookDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
+
+// ClaimWebhookDeliveriesParams select up to BatchSize due deliveries to lease
+// until LeaseUntil.
+type ClaimWebhookDeliveriesParams struct {
+	LeaseUntil time.Time
+	BatchSize  int
+}
+
+// CompleteWebhookDeliveryParams record a successful delivery attempt.
+type CompleteWebhookDeliveryParams struct {
+	ID             int64
+	ResponseStatus int
+}
+
+// FailWebhookDeliveryParams record a failed delivery attempt. ResponseStatus
+// is nil if no response was received.
+type FailWebhookDeliveryParams struct {
+	ID             int64
+	Status         string
+	ResponseStatus *int
+	LastError      string
+	NextAttemptAt  time.Time
+}
+
+// AgentHasAuthorsError is returned when deleting an agent that still
+// represents authors without naming an agent to reassign them to.
+type AgentHasAuthorsError struct {
+	AgentID int64
+	Authors []Author
+}
+
+func (e *AgentHasAuthorsError) Error() string {
+	authors := make([]string, 0, len(e.Authors))
+	for _, author := range e.Authors {
+		authors = append(authors, fmt.Sprintf("%s (%d)", author.Name, author.ID))
+	}
+	return fmt.Sprintf(
+		"agent %d still represents authors: %s; reassign them to another agent first",
+		e.AgentID,
+		strings.Join(authors, ", "),
+	)
+}
+
+// OrphanedBooksPolicy determines what happens to the books left without any
+// authors when an author is deleted.
+type OrphanedBooksPolicy string
+
+// Orphaned books policies.
+const (
+	// OrphanedBooksFail refuses to delete the author.
+	OrphanedBooksFail OrphanedBooksPolicy = "FAIL"
+	// OrphanedBooksDelete deletes the orphaned books along with the author.
+	OrphanedBooksDelete OrphanedBooksPolicy = "DELETE"
+	// OrphanedBooksKeep keeps the orphaned books without any authors.
+	OrphanedBooksKeep OrphanedBooksPolicy = "KEEP"
+)
+
+// BooksWouldBeOrphanedError is returned when deleting an author would leave
+// books without any authors under the OrphanedBooksFail policy.
+type BooksWouldBeOrphanedError struct {
+	AuthorID int64
+	Books    []Book
+}
+
+func (e *BooksWouldBeOrphanedError) Error() string {
+	books := make([]string, 0, len(e.Books))
+	for _, book := range e.Books {
+		books = append(books, fmt.Sprintf("%s (%d)", book.Title, book.ID))
+	}
+	return fmt.Sprintf(
+		"deleting author %d would leave books without authors: %s",
+		e.AuthorID,
+		strings.Join(books, ", "),
+	)
+}
+
+// BulkMode determines how a bulk operation handles items that fail.
+type BulkMode string
+
+// Bulk modes.
+const (
+	// BulkAllOrNothing rolls back the whole operation if any item fails.
+	BulkAllOrNothing BulkMode = "ALL_OR_NOTHING"
+	// BulkBestEffort commits the items that succeeded and skips the rest.
+	BulkBestEffort BulkMode = "BEST_EFFORT"
+)
+
+// ContributorRole is the part an author played in a book.
+type ContributorRole string
+
+// Contributor roles.
+const (
+	ContributorAuthor      ContributorRole = "AUTHOR"
+	ContributorIllustrator ContributorRole = "ILLUSTRATOR"
+	ContributorTranslator  ContributorRole = "TRANSLATOR"
+	ContributorEditor      ContributorRole = "EDITOR"
+)
+
+// EditionFormat is the format in which an edition is sold.
+type EditionFormat string
+
+// Edition formats.
+const (
+	EditionHardcover EditionFormat = "HARDCOVER"
+	EditionPaperback EditionFormat = "PAPERBACK"
+	EditionEbook     EditionFormat = "EBOOK"
+	EditionAudiobook EditionFormat = "AUDIOBOOK"
+)
+
+// DealStatus is the stage of a deal. Deals under negotiation and signed deals
+// hold their rights; terminated deals release them.
+type DealStatus string
+
+// Deal statuses.
+const (
+	DealNegotiating DealStatus = "NEGOTIATING"
+	DealSigned      DealStatus = "SIGNED"
+	DealTerminated  DealStatus = "TERMINATED"
+)
+
+// SubmissionStatus is the stage of a submission. Received submissions are
+// read, the full manuscript may be requested and the agent then offers
+// representation; the agent can decline and the author withdraw a submission
+// at any stage, which is final.
+type SubmissionStatus string
+
+// Submission statuses.
+const (
+	SubmissionReceived      SubmissionStatus = "RECEIVED"
+	SubmissionReading       SubmissionStatus = "READING"
+	SubmissionRequestedFull SubmissionStatus = "REQUESTED_FULL"
+	SubmissionOffered       SubmissionStatus = "OFFERED"
+	SubmissionDeclined      SubmissionStatus = "DECLINED"
+	SubmissionWithdrawn     SubmissionStatus = "WITHDRAWN"
+)
+
+// submissionTransitions lists the statuses a submission can move to from each
+// status. Declined and withdrawn submissions are final.
+var submissionTransitions = map[SubmissionStatus][]SubmissionStatus{
+	SubmissionReceived:      {SubmissionReading, SubmissionDeclined, SubmissionWithdrawn},
+	SubmissionReading:       {SubmissionRequestedFull, SubmissionOffered, SubmissionDeclined, SubmissionWithdrawn},
+	SubmissionRequestedFull: {SubmissionOffered, SubmissionDeclined, SubmissionWithdrawn},
+	SubmissionOffered:       {SubmissionDeclined, SubmissionWithdrawn},
+}
+
+// CanTransitionSubmission reports whether a submission can move from one
+// status to another.
+func CanTransitionSubmission(from, to SubmissionStatus) bool {
+	for _, status := range submissionTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// SubmissionTransitionError is returned when moving a submission to a status
+// it cannot move to from its current one.
+type SubmissionTransitionError struct {
+	SubmissionID int64
+	From         SubmissionStatus
+	To           SubmissionStatus
+}
+
+func (e *SubmissionTransitionError) Error() string {
+	return fmt.Sprintf("submission %d cannot move from %s to %s", e.SubmissionID, e.From, e.To)
+}
+
+// ErrGenreCycle is returned when moving a genre under itself or one of its
+// subgenres.
+var ErrGenreCycle = errors.New("a genre cannot be moved under itself or its subgenres")
+
+// ErrSeriesBooksMismatch is returned when reordering a series with a list of
+// books that is not exactly the books of the series.
+var ErrSeriesBooksMismatch = errors.New("the books must be exactly the books of the series")
+
+// ErrSeriesPositionTaken is returned when a book is placed at a position of
+// its series that another book already holds.
+var ErrSeriesPositionTaken = errors.New("the position is already taken in the series")
+
+// ErrInvalidSeriesPosition is returned when a book has a series without a
+// positive position, or a position without a series.
+var ErrInvalidSeriesPosition = errors.New("a book in a series needs a positive position and a book outside of one none")
+
+// ErrISBNTaken is returned when a book or an edition is given the ISBN of
+// another one.
+var ErrISBNTaken = errors.New("the ISBN is already taken")
+
+// ErrInvalidPageCount is returned when a book has a page count that is not
+// positive.
+var ErrInvalidPageCount = errors.New("the page count must be positive")
+
+// ErrInvalidContributorPosition is returned when a contributor has a
+// position that is not positive.
+var ErrInvalidContributorPosition = errors.New("the position of a contributor must be positive")
+
+// ErrDuplicateContributor is returned when an author is credited on a book
+// more than once.
+var ErrDuplicateContributor = errors.New("an author can be credited on a book only once")
+
+// ErrInvalidTag is returned for a book tag that is empty or starts or ends
+// with a space.
+var ErrInvalidTag = errors.New("a tag must not be empty or padded with spaces")
+
+// ErrDuplicateTag is returned when a book is given the same tag more than
+// once.
+var ErrDuplicateTag = errors.New("a book can have a tag only once")
+
+// ErrPrimaryRepresentation is returned when ending the primary representation
+// of an author, which only ends when the author changes agents.
+var ErrPrimaryRepresentation = errors.New("the primary representation ends when the author changes agents")
+
+// ErrInvalidRepresentationPeriod is returned when a representation would end
+// before it started.
+var ErrInvalidRepresentationPeriod = errors.New("a representation cannot end before it started")
+
+// ErrInvalidTerritory is returned for a territory that is neither WORLD nor
+// an ISO 3166-1 alpha-2 country code.
+var ErrInvalidTerritory = errors.New("the territory must be WORLD or an ISO 3166-1 alpha-2 country code")
+
+// ErrInvalidDealSigning is returned when a signed deal has no signing date or
+// a deal under negotiation has one.
+var ErrInvalidDealSigning = errors.New("a signed deal needs a signing date and a deal under negotiation none")
+
+// ErrInvalidRate is returned for a royalty or commission rate outside of 0
+// to 10000 basis points.
+var ErrInvalidRate = errors.New("a rate must be between 0 and 10000 basis points")
+
+// ErrInvalidPeriod is returned for a sales period that is not a quarter such
+// as 2024-Q1.
+var ErrInvalidPeriod = errors.New("the period must be a quarter such as 2024-Q1")
+
+// ErrEditionNotInDeal is returned for royalty rates and sales of an edition
+// that is not of the book and format of the deal, and for deals and editions
+// changing book or format in a way that would lead to them.
+var ErrEditionNotInDeal = errors.New("the edition must be of the book and format of the deal")
+
+// ErrAgentDeleted is returned when giving a deleted agent an author, a
+// representation, a deal or a submission.
+var ErrAgentDeleted = errors.New("the agent has been deleted")
+
+// ErrSubmissionNotOffered is returned when converting a submission that has
+// not been offered representation or is already a book.
+var ErrSubmissionNotOffered = errors.New("only an offered submission that is not a book yet can be converted into one")
+
+// ErrBookWithoutAuthors is reported for bulk book items with no contributors.
+var ErrBookWithoutAuthors = errors.New("a book must have at least one author")
+
+// BulkCreateBookArgs represents a single book of a bulk create operation.
+type BulkCreateBookArgs struct {
+	Book         CreateBookParams
+	Contributors []Contributor
+	GenreIDs     []int64
+	Tags         []string
+}
+
+// BulkUpdateBookArgs represents a single book of a bulk update operation.
+type BulkUpdateBookArgs struct {
+	Book         UpdateBookParams
+	Contributors []Contributor
+	GenreIDs     []int64
+	Tags         []string
+}
+
+// BulkAgentsResult is the outcome of a bulk agent operation. Agents and
+// Errors are indexed like the input; for every item exactly one of them is
+// set, unless the operation was rolled back, in which case no agents are set.
+type BulkAgentsResult struct {
+	Committed bool
+	Agents    []*Agent
+	Errors    []error
+}
+
+// BulkAuthorsResult is the outcome of a bulk author operation, see
+// BulkAgentsResult.
+type BulkAuthorsResult struct {
+	Committed bool
+	Authors   []*Author
+	Errors    []error
+}
+
+// BulkBooksResult is the outcome of a bulk book operation, see
+// BulkAgentsResult.
+type BulkBooksResult struct {
+	Committed bool
+	Books     []*Book
+	Errors    []error
+}
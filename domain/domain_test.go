@@ -0,0 +1,324 @@
+package domain_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain"
+)
+
+func TestParseISBN(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		in   string
+		exp  domain.ISBN
+		ok   bool
+	}{
+		{"isbn-13", "9780306406157", "9780306406157", true},
+		{"isbn-13 with hyphens", "978-0-306-40615-7", "9780306406157", true},
+		{"isbn-13 979 prefix", "979-10-90636-07-1", "9791090636071", true},
+		{"isbn-10", "0306406152", "9780306406157", true},
+		{"isbn-10 with spaces", "0 306 40615 2", "9780306406157", true},
+		{"isbn-10 check digit X", "080442957X", "9780804429573", true},
+		{"isbn-10 lower case x", "080442957x", "9780804429573", true},
+		{"isbn-13 wrong check digit", "9780306406158", "", false},
+		{"isbn-13 wrong prefix", "9770306406155", "", false},
+		{"isbn-10 wrong check digit", "0306406153", "", false},
+		{"isbn-10 misplaced X", "03064061X2", "", false},
+		{"letters", "978030640615a", "", false},
+		{"too short", "030640615", "", false},
+		{"empty", "", "", false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			isbn, err := domain.ParseISBN(tc.in)
+			if (err == nil) != tc.ok {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if isbn != tc.exp {
+				t.Errorf("expected %q, received %q", tc.exp, isbn)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		in   string
+		exp  domain.Date
+		ok   bool
+	}{
+		{"valid", "2020-03-05", domain.Date{Year: 2020, Month: time.March, Day: 5}, true},
+		{"leap day", "2020-02-29", domain.Date{Year: 2020, Month: time.February, Day: 29}, true},
+		{"not a leap year", "2019-02-29", domain.Date{}, false},
+		{"time of day", "2020-03-05T10:00:00Z", domain.Date{}, false},
+		{"empty", "", domain.Date{}, false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			date, err := domain.ParseDate(tc.in)
+			if (err == nil) != tc.ok {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if date != tc.exp {
+				t.Errorf("expected %v, received %v", tc.exp, date)
+			}
+			if tc.ok && date.String() != tc.in {
+				t.Errorf("expected %q, received %q", tc.in, date.String())
+			}
+		})
+	}
+}
+
+func TestParseLanguageCode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		in   string
+		exp  domain.LanguageCode
+		ok   bool
+	}{
+		{"valid", "en", "en", true},
+		{"upper case", "PL", "pl", true},
+		{"unassigned", "xx", "", false},
+		{"iso 639-2", "eng", "", false},
+		{"empty", "", "", false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			code, err := domain.ParseLanguageCode(tc.in)
+			if (err == nil) != tc.ok {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if code != tc.exp {
+				t.Errorf("expected %q, received %q", tc.exp, code)
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		in   string
+		exp  domain.Money
+		out  string
+		ok   bool
+	}{
+		{"valid", "25000.00 USD", domain.Money{Amount: 2500000, Currency: "USD"}, "25000.00 USD", true},
+		{"no decimals", "7 EUR", domain.Money{Amount: 700, Currency: "EUR"}, "7.00 EUR", true},
+		{"one decimal", "0.5 GBP", domain.Money{Amount: 50, Currency: "GBP"}, "0.50 GBP", true},
+		{"zero", "0 PLN", domain.Money{Amount: 0, Currency: "PLN"}, "0.00 PLN", true},
+		{"three decimals", "1.005 USD", domain.Money{}, "", false},
+		{"negative", "-1.00 USD", domain.Money{}, "", false},
+		{"lower case currency", "1.00 usd", domain.Money{}, "", false},
+		{"no currency", "1.00", domain.Money{}, "", false},
+		{"too large", "99999999999999999999 USD", domain.Money{}, "", false},
+		{"empty", "", domain.Money{}, "", false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			money, err := domain.ParseMoney(tc.in)
+			if (err == nil) != tc.ok {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if money != tc.exp {
+				t.Errorf("expected %v, received %v", tc.exp, money)
+			}
+			if tc.ok && money.String() != tc.out {
+				t.Errorf("expected %q, received %q", tc.out, money.String())
+			}
+		})
+	}
+}
+
+func TestNewRoyaltyStatement(t *testing.T) {
+	t.Parallel()
+	usd := func(amount int64) domain.Money { return domain.Money{Amount: amount, Currency: "USD"} }
+	eur := func(amount int64) domain.Money { return domain.Money{Amount: amount, Currency: "EUR"} }
+	sales := []domain.RoyaltySales{
+		{
+			// a single author at 10% with a 15% commission
+			Sales:          domain.Sales{ID: 1, DealID: 2, EditionID: 3, Period: "2024-Q1", Units: 100, Revenue: usd(200000)},
+			BookID:         4,
+			AgentID:        5,
+			RoyaltyRate:    1000,
+			CommissionRate: 1500,
+			Authors:        1,
+		},
+		{
+			// three co-authors, with the odd cents rounded down
+			Sales:          domain.Sales{ID: 6, DealID: 7, EditionID: 8, Period: "2024-Q1", Units: 10, Revenue: usd(10001)},
+			BookID:         9,
+			AgentID:        5,
+			RoyaltyRate:    2500,
+			CommissionRate: 2000,
+			Authors:        3,
+		},
+		{
+			Sales:          domain.Sales{ID: 10, DealID: 11, EditionID: 12, Period: "2024-Q1", Units: 5, Revenue: eur(5000)},
+			BookID:         4,
+			AgentID:        13,
+			RoyaltyRate:    800,
+			CommissionRate: 0,
+			Authors:        2,
+		},
+	}
+	exp := domain.RoyaltyStatement{
+		AuthorID: 42,
+		Period:   "2024-Q1",
+		Lines: []domain.RoyaltyLine{
+			{
+				Sales:          sales[0].Sales,
+				BookID:         4,
+				AgentID:        5,
+				RoyaltyRate:    1000,
+				Royalty:        usd(20000),
+				Authors:        1,
+				Share:          usd(20000),
+				CommissionRate: 1500,
+				Commission:     usd(3000),
+				Net:            usd(17000),
+			},
+			{
+				Sales:          sales[1].Sales,
+				BookID:         9,
+				AgentID:        5,
+				RoyaltyRate:    2500,
+				Royalty:        usd(2500),
+				Authors:        3,
+				Share:          usd(833),
+				CommissionRate: 2000,
+				Commission:     usd(166),
+				Net:            usd(667),
+			},
+			{
+				Sales:          sales[2].Sales,
+				BookID:         4,
+				AgentID:        13,
+				RoyaltyRate:    800,
+				Royalty:        eur(400),
+				Authors:        2,
+				Share:          eur(200),
+				CommissionRate: 0,
+				Commission:     eur(0),
+				Net:            eur(200),
+			},
+		},
+		Totals: []domain.RoyaltyTotal{
+			{Currency: "USD", Units: 110, Revenue: usd(210001), Share: usd(20833), Commission: usd(3166), Net: usd(17667)},
+			{Currency: "EUR", Units: 5, Revenue: eur(5000), Share: eur(200), Commission: eur(0), Net: eur(200)},
+		},
+	}
+	statement := domain.NewRoyaltyStatement(42, "2024-Q1", sales)
+	if !reflect.DeepEqual(statement, exp) {
+		t.Errorf("expected %+v, received %+v", exp, statement)
+	}
+
+	empty := domain.NewRoyaltyStatement(42, "2024-Q2", nil)
+	if len(empty.Lines) != 0 || len(empty.Totals) != 0 || empty.Period != "2024-Q2" {
+		t.Errorf("expected an empty statement, received %+v", empty)
+	}
+}
+
+func TestCanTransitionSubmission(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		from, to domain.SubmissionStatus
+		exp      bool
+	}{
+		{domain.SubmissionReceived, domain.SubmissionReading, true},
+		{domain.SubmissionReading, domain.SubmissionOffered, true},
+		{domain.SubmissionRequestedFull, domain.SubmissionOffered, true},
+		{domain.SubmissionOffered, domain.SubmissionWithdrawn, true},
+		{domain.SubmissionReceived, domain.SubmissionOffered, false},
+		{domain.SubmissionOffered, domain.SubmissionReading, false},
+		{domain.SubmissionDeclined, domain.SubmissionReading, false},
+		{domain.SubmissionWithdrawn, domain.SubmissionReceived, false},
+		{domain.SubmissionReading, domain.SubmissionReading, false},
+	}
+	for _, tc := range tests {
+		if res := domain.CanTransitionSubmission(tc.from, tc.to); res != tc.exp {
+			t.Errorf("%s to %s: expected %t, received %t", tc.from, tc.to, tc.exp, res)
+		}
+	}
+}
+
+func TestScalars(t *testing.T) {
+	t.Parallel()
+	var isbn domain.ISBN
+	if err := isbn.UnmarshalGQL("0-306-40615-2"); err != nil {
+		t.Fatal(err)
+	}
+	var date domain.Date
+	if err := date.UnmarshalGQL("2020-03-05"); err != nil {
+		t.Fatal(err)
+	}
+	var language domain.LanguageCode
+	if err := language.UnmarshalGQL("EN"); err != nil {
+		t.Fatal(err)
+	}
+	if err := language.UnmarshalGQL(1); err == nil {
+		t.Error("expected an error for a non-string language code")
+	}
+	var money domain.Money
+	if err := money.UnmarshalGQL("1250.5 EUR"); err != nil {
+		t.Fatal(err)
+	}
+	if err := money.UnmarshalGQL(1250); err == nil {
+		t.Error("expected an error for a non-string amount of money")
+	}
+	var buf bytes.Buffer
+	isbn.MarshalGQL(&buf)
+	date.MarshalGQL(&buf)
+	language.MarshalGQL(&buf)
+	money.MarshalGQL(&buf)
+	if exp := `"9780306406157""2020-03-05""en""1250.50 EUR"`; buf.String() != exp {
+		t.Errorf("expected %s, received %s", exp, buf.String())
+	}
+}
+
+func TestRoyaltyStatementWriteCSV(t *testing.T) {
+	t.Parallel()
+	statement := domain.NewRoyaltyStatement(1, "2024-Q1", []domain.RoyaltySales{
+		{
+			Sales: domain.Sales{
+				ID:        1,
+				DealID:    2,
+				EditionID: 3,
+				Period:    "2024-Q1",
+				Units:     10,
+				Revenue:   domain.Money{Amount: 10001, Currency: "USD"},
+			},
+			BookID:         4,
+			AgentID:        5,
+			RoyaltyRate:    2500,
+			CommissionRate: 2000,
+			Authors:        3,
+		},
+	})
+	var buf bytes.Buffer
+	if err := statement.WriteCSV(&buf); err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	exp := "sales_id,deal_id,edition_id,book_id,agent_id,period,units,currency," +
+		"revenue,royalty_rate,royalty,authors,share,commission_rate,commission,net\n" +
+		"1,2,3,4,5,2024-Q1,10,USD,100.01,2500,25.00,3,8.33,2000,1.66,6.67\n"
+	if buf.String() != exp {
+		t.Errorf("expected %q, received %q", exp, buf.String())
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ISBN is an International Standard Book Number in its 13 digit form.
+type ISBN string
+
+// ParseISBN validates an ISBN-10 or ISBN-13, ignoring hyphens and spaces,
+// and returns it as an ISBN-13.
+func ParseISBN(s string) (ISBN, error) {
+	digits := strings.NewReplacer("-", "", " ", "").Replace(s)
+	switch {
+	case len(digits) == 10 && isISBN10(digits):
+		return ISBN(isbn13("978" + digits[:9])), nil
+	case len(digits) == 13 && isISBN13(digits):
+		return ISBN(digits), nil
+	}
+	return "", fmt.Errorf("invalid ISBN: %q", s)
+}
+
+// isISBN10 checks the digits and the mod 11 check digit, which may be X.
+func isISBN10(s string) bool {
+	sum := 0
+	for i, c := range s {
+		var d int
+		switch {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case (c == 'X' || c == 'x') && i == 9:
+			d = 10
+		default:
+			return false
+		}
+		sum += (10 - i) * d
+	}
+	return sum%11 == 0
+}
+
+// isISBN13 checks the digits, the EAN prefix of books and the check digit.
+func isISBN13(s string) bool {
+	if !strings.HasPrefix(s, "978") && !strings.HasPrefix(s, "979") {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return isbn13(s[:12]) == s
+}
+
+// isbn13 appends the check digit to the first 12 digits of an ISBN-13.
+func isbn13(s string) string {
+	sum := 0
+	for i, c := range s {
+		d := int(c - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return s + strconv.Itoa((10-sum%10)%10)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface.
+func (i *ISBN) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("ISBN must be a string")
+	}
+	isbn, err := ParseISBN(s)
+	if err != nil {
+		return err
+	}
+	*i = isbn
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface.
+func (i ISBN) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(string(i)))
+}
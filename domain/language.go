@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LanguageCode is an ISO 639-1 two letter language code, in lower case.
+type LanguageCode string
+
+// ParseLanguageCode validates an ISO 639-1 language code, regardless of its
+// case.
+func ParseLanguageCode(s string) (LanguageCode, error) {
+	code := strings.ToLower(s)
+	if !iso6391[code] {
+		return "", fmt.Errorf("invalid ISO 639-1 language code: %q", s)
+	}
+	return LanguageCode(code), nil
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface.
+func (l *LanguageCode) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("LanguageCode must be a string")
+	}
+	code, err := ParseLanguageCode(s)
+	if err != nil {
+		return err
+	}
+	*l = code
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface.
+func (l LanguageCode) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(string(l)))
+}
+
+var iso6391 = func() map[string]bool {
+	codes := make(map[string]bool)
+	for _, code := range strings.Fields(`
+		aa ab ae af ak am an ar as av ay az ba be bg bi bm bn bo br bs ca ce ch
+		co cr cs cu cv cy da de dv dz ee el en eo es et eu fa ff fi fj fo fr fy
+		ga gd gl gn gu gv ha he hi ho hr ht hu hy hz ia id ie ig ii ik io is it
+		iu ja jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb lg li ln lo
+		lt lu lv mg mh mi mk ml mn mr ms mt my na nb nd ne ng nl nn no nr nv ny
+		oc oj om or os pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl
+		sm sn so sq sr ss st su sv sw ta te tg th ti tk tl tn to tr ts tt tw ty
+		ug uk ur uz ve vi vo wa wo xh yi yo za zh zu
+	`) {
+		codes[code] = true
+	}
+	return codes
+}()
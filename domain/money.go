@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// Money is an amount in hundredths of the unit of an ISO 4217 currency, such
+// as cents. It is written as the amount in units followed by the currency
+// code, such as "25000.00 USD".
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+var moneyPattern = regexp.MustCompile(`^([0-9]+)(?:\.([0-9]{1,2}))? ([A-Z]{3})$`)
+
+// ParseMoney parses a non-negative amount with at most two decimal places
+// followed by a space and an ISO 4217 currency code.
+func ParseMoney(s string) (Money, error) {
+	m := moneyPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Money{}, fmt.Errorf("invalid amount of money: %q", s)
+	}
+	cents := (m[2] + "00")[:2]
+	amount, err := strconv.ParseInt(m[1]+cents, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount of money: %q", s)
+	}
+	return Money{Amount: amount, Currency: m[3]}, nil
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%d.%02d %s", m.Amount/100, m.Amount%100, m.Currency)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface.
+func (m *Money) UnmarshalGQL(v interface{}) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Money must be a string")
+	}
+	money, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface.
+func (m Money) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(m.String()))
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// RoyaltySales are sales of a book along with the terms they earn royalties
+// on: the royalty rate of their deal or edition, the commission rate of the
+// agent who negotiated the deal and the number of authors of the book.
+type RoyaltySales struct {
+	Sales
+	BookID         int64
+	AgentID        int64
+	RoyaltyRate    int
+	CommissionRate int
+	Authors        int
+}
+
+// RoyaltyStatement is what an author earned on the sales of their books in a
+// quarter, with a line per sales record and totals per currency.
+type RoyaltyStatement struct {
+	AuthorID int64
+	Period   string
+	Lines    []RoyaltyLine
+	Totals   []RoyaltyTotal
+}
+
+// RoyaltyLine is the share of an author in the royalties on a sales record.
+// Royalty is earned by all authors of the book, who split it evenly; the
+// agent takes its commission from the share and the author is owed the net.
+type RoyaltyLine struct {
+	Sales          Sales
+	BookID         int64
+	AgentID        int64
+	RoyaltyRate    int
+	Royalty        Money
+	Authors        int
+	Share          Money
+	CommissionRate int
+	Commission     Money
+	Net            Money
+}
+
+// RoyaltyTotal sums the lines of a statement in one currency.
+type RoyaltyTotal struct {
+	Currency   string
+	Units      int
+	Revenue    Money
+	Share      Money
+	Commission Money
+	Net        Money
+}
+
+// NewRoyaltyStatement calculates the statement of an author from the sales of
+// their books in a period. Amounts are rounded down to the minor unit at each
+// step, so an author is never owed more than the royalty allows. Totals are in
+// the order their currencies first appear in the sales.
+func NewRoyaltyStatement(authorID int64, period string, sales []RoyaltySales) RoyaltyStatement {
+	statement := RoyaltyStatement{
+		AuthorID: authorID,
+		Period:   period,
+		Lines:    make([]RoyaltyLine, 0, len(sales)),
+		Totals:   []RoyaltyTotal{},
+	}
+	totals := make(map[string]int)
+	for _, s := range sales {
+		authors := s.Authors
+		if authors < 1 {
+			authors = 1
+		}
+		currency := s.Revenue.Currency
+		royalty := s.Revenue.Amount * int64(s.RoyaltyRate) / 10000
+		share := royalty / int64(authors)
+		commission := share * int64(s.CommissionRate) / 10000
+		line := RoyaltyLine{
+			Sales:          s.Sales,
+			BookID:         s.BookID,
+			AgentID:        s.AgentID,
+			RoyaltyRate:    s.RoyaltyRate,
+			Royalty:        Money{Amount: royalty, Currency: currency},
+			Authors:        authors,
+			Share:          Money{Amount: share, Currency: currency},
+			CommissionRate: s.CommissionRate,
+			Commission:     Money{Amount: commission, Currency: currency},
+			Net:            Money{Amount: share - commission, Currency: currency},
+		}
+		statement.Lines = append(statement.Lines, line)
+
+		i, ok := totals[currency]
+		if !ok {
+			i = len(statement.Totals)
+			totals[currency] = i
+			statement.Totals = append(statement.Totals, RoyaltyTotal{
+				Currency:   currency,
+				Revenue:    Money{Currency: currency},
+				Share:      Money{Currency: currency},
+				Commission: Money{Currency: currency},
+				Net:        Money{Currency: currency},
+			})
+		}
+		total := &statement.Totals[i]
+		total.Units += s.Units
+		total.Revenue.Amount += s.Revenue.Amount
+		total.Share.Amount += line.Share.Amount
+		total.Commission.Amount += line.Commission.Amount
+		total.Net.Amount += line.Net.Amount
+	}
+	return statement
+}
+
+var royaltyHeader = []string{
+	"sales_id", "deal_id", "edition_id", "book_id", "agent_id", "period", "units", "currency",
+	"revenue", "royalty_rate", "royalty", "authors", "share", "commission_rate", "commission", "net",
+}
+
+// WriteCSV writes the lines of the statement to w as CSV with a header row.
+// Amounts are written in units of their currency with two decimal places and
+// rates in basis points.
+func (s RoyaltyStatement) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(royaltyHeader); err != nil {
+		return err
+	}
+	for _, line := range s.Lines {
+		if err := cw.Write([]string{
+			strconv.FormatInt(line.Sales.ID, 10),
+			strconv.FormatInt(line.Sales.DealID, 10),
+			strconv.FormatInt(line.Sales.EditionID, 10),
+			strconv.FormatInt(line.BookID, 10),
+			strconv.FormatInt(line.AgentID, 10),
+			line.Sales.Period,
+			strconv.Itoa(line.Sales.Units),
+			line.Sales.Revenue.Currency,
+			formatAmount(line.Sales.Revenue),
+			strconv.Itoa(line.RoyaltyRate),
+			formatAmount(line.Royalty),
+			strconv.Itoa(line.Authors),
+			formatAmount(line.Share),
+			strconv.Itoa(line.CommissionRate),
+			formatAmount(line.Commission),
+			formatAmount(line.Net),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatAmount(m Money) string {
+	return fmt.Sprintf("%d.%02d", m.Amount/100, m.Amount%100)
+}
@@ -0,0 +1,199 @@
+// Package exporter exports agents, authors and books as JSON, newline
+// delimited JSON or CSV.
+//
+// Records are written as they are read from the database, so exports of any
+// size use a constant amount of memory. Authors are exported along with their
+// agent, books along with the ids of their authors; in CSV the author ids are
+// separated by semicolons. Deleted agents, authors and books are exported as
+// deletions, so that incremental exports can remove them.
+package exporter
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fwojciec/litag-example/postgres" // update the username
+)
+
+// Format is the format of the exported file.
+type Format string
+
+// Supported formats.
+const (
+	FormatCSV    Format = "csv"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Entity is the type of the exported records.
+type Entity string
+
+// Supported entities.
+const (
+	EntityAgents    Entity = "agents"
+	EntityAuthors   Entity = "authors"
+	EntityBooks     Entity = "books"
+	EntityDeletions Entity = "deletions"
+)
+
+// Exporter represents the datalayer methods used to export records.
+type Exporter interface {
+	ExportAgents(ctx context.Context, since time.Time, fn func(postgres.AgentExport) error) error
+	ExportAuthors(ctx context.Context, since time.Time, fn func(postgres.AuthorExport) error) error
+	ExportBooks(ctx context.Context, since time.Time, fn func(postgres.BookExport) error) error
+	ExportDeletions(ctx context.Context, since time.Time, fn func(postgres.DeletionExport) error) error
+}
+
+var headers = map[Entity][]string{
+	EntityAgents:    {"id", "name", "email", "updated_at"},
+	EntityAuthors:   {"id", "name", "website", "agent_id", "agent_name", "agent_email", "updated_at"},
+	EntityBooks:     {"id", "title", "description", "cover", "author_ids", "updated_at"},
+	EntityDeletions: {"entity", "id", "deleted_at"},
+}
+
+// ContentType returns the MIME type of the format.
+func ContentType(format Format) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/json"
+	}
+}
+
+// Check returns an error if the format or the entity is not supported.
+func Check(format Format, entity Entity) error {
+	switch format {
+	case FormatCSV, FormatJSON, FormatNDJSON:
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	if _, ok := headers[entity]; !ok {
+		return fmt.Errorf("unknown entity %q", entity)
+	}
+	return nil
+}
+
+// Run writes the records of the entity to w. If since is not zero, only
+// records updated, or for deletions deleted, at or after it are written.
+func Run(ctx context.Context, exp Exporter, w io.Writer, format Format, entity Entity, since time.Time) error {
+	if err := Check(format, entity); err != nil {
+		return err
+	}
+	enc := newEncoder(w, format, headers[entity])
+	if err := enc.begin(); err != nil {
+		return err
+	}
+	var err error
+	switch entity {
+	case EntityAgents:
+		err = exp.ExportAgents(ctx, since, func(a postgres.AgentExport) error {
+			return enc.write(a, []string{
+				formatID(a.ID), a.Name, a.Email, formatTime(a.UpdatedAt),
+			})
+		})
+	case EntityAuthors:
+		err = exp.ExportAuthors(ctx, since, func(a postgres.AuthorExport) error {
+			website := ""
+			if a.Website != nil {
+				website = *a.Website
+			}
+			return enc.write(a, []string{
+				formatID(a.ID), a.Name, website, formatID(a.AgentID), a.AgentName, a.AgentEmail, formatTime(a.UpdatedAt),
+			})
+		})
+	case EntityBooks:
+		err = exp.ExportBooks(ctx, since, func(b postgres.BookExport) error {
+			ids := make([]string, 0, len(b.AuthorIDs))
+			for _, id := range b.AuthorIDs {
+				ids = append(ids, formatID(id))
+			}
+			return enc.write(b, []string{
+				formatID(b.ID), b.Title, b.Description, b.Cover, strings.Join(ids, ";"), formatTime(b.UpdatedAt),
+			})
+		})
+	case EntityDeletions:
+		err = exp.ExportDeletions(ctx, since, func(d postgres.DeletionExport) error {
+			return enc.write(d, []string{d.Entity, formatID(d.ID), formatTime(d.DeletedAt)})
+		})
+	}
+	if err != nil {
+		return err
+	}
+	return enc.end()
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// encoder writes records in one of the formats. JSON formats encode the
+// record itself, CSV writes its fields.
+type encoder struct {
+	format Format
+	header []string
+	w      io.Writer
+	csv    *csv.Writer
+	json   *json.Encoder
+	n      int
+}
+
+func newEncoder(w io.Writer, format Format, header []string) *encoder {
+	enc := &encoder{format: format, header: header, w: w}
+	if format == FormatCSV {
+		enc.csv = csv.NewWriter(w)
+	} else {
+		enc.json = json.NewEncoder(w)
+	}
+	return enc
+}
+
+func (enc *encoder) begin() error {
+	switch enc.format {
+	case FormatCSV:
+		return enc.csv.Write(enc.header)
+	case FormatJSON:
+		_, err := io.WriteString(enc.w, "[\n")
+		return err
+	}
+	return nil
+}
+
+func (enc *encoder) write(v interface{}, fields []string) error {
+	defer func() { enc.n++ }()
+	switch enc.format {
+	case FormatCSV:
+		return enc.csv.Write(fields)
+	case FormatJSON:
+		if enc.n > 0 {
+			if _, err := io.WriteString(enc.w, ","); err != nil {
+				return err
+			}
+		}
+	}
+	return enc.json.Encode(v)
+}
+
+func (enc *encoder) end() error {
+	switch enc.format {
+	case FormatCSV:
+		enc.csv.Flush()
+		return enc.csv.Error()
+	case FormatJSON:
+		_, err := io.WriteString(enc.w, "]\n")
+		return err
+	}
+	return nil
+}
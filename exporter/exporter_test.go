@@ -0,0 +1,170 @@
+package exporter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/litag-example/exporter"
+	"github.com/fwojciec/litag-example/generated/mocks"
+	"github.com/fwojciec/litag-example/postgres"
+)
+
+var testTime = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newExporterMock() *mocks.ExporterMock {
+	website := "https://one.com"
+	return &mocks.ExporterMock{
+		ExportAgentsFunc: func(ctx context.Context, since time.Time, fn func(postgres.AgentExport) error) error {
+			for _, a := range []postgres.AgentExport{
+				{ID: 1, Name: "Agent One", Email: "one@example.com", UpdatedAt: testTime},
+				{ID: 2, Name: "Agent Two", Email: "two@example.com", UpdatedAt: testTime},
+			} {
+				if err := fn(a); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		ExportAuthorsFunc: func(ctx context.Context, since time.Time, fn func(postgres.AuthorExport) error) error {
+			return fn(postgres.AuthorExport{ID: 1, Name: "Author, One", Website: &website, AgentID: 1, AgentName: "Agent One", AgentEmail: "one@example.com", UpdatedAt: testTime})
+		},
+		ExportBooksFunc: func(ctx context.Context, since time.Time, fn func(postgres.BookExport) error) error {
+			return fn(postgres.BookExport{ID: 1, Title: "Title", Description: "Description", Cover: "cover.jpg", AuthorIDs: []int64{1, 2}, UpdatedAt: testTime})
+		},
+		ExportDeletionsFunc: func(ctx context.Context, since time.Time, fn func(postgres.DeletionExport) error) error {
+			return fn(postgres.DeletionExport{Entity: "authors", ID: 3, DeletedAt: testTime})
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format exporter.Format
+		entity exporter.Entity
+		exp    string
+	}{
+		{"agents json", exporter.FormatJSON, exporter.EntityAgents, "[\n" +
+			`{"id":1,"name":"Agent One","email":"one@example.com","updated_at":"2020-01-02T03:04:05Z"}` + "\n" +
+			`,{"id":2,"name":"Agent Two","email":"two@example.com","updated_at":"2020-01-02T03:04:05Z"}` + "\n" +
+			"]\n"},
+		{"agents ndjson", exporter.FormatNDJSON, exporter.EntityAgents,
+			`{"id":1,"name":"Agent One","email":"one@example.com","updated_at":"2020-01-02T03:04:05Z"}` + "\n" +
+				`{"id":2,"name":"Agent Two","email":"two@example.com","updated_at":"2020-01-02T03:04:05Z"}` + "\n"},
+		{"agents csv", exporter.FormatCSV, exporter.EntityAgents, "id,name,email,updated_at\n" +
+			"1,Agent One,one@example.com,2020-01-02T03:04:05Z\n" +
+			"2,Agent Two,two@example.com,2020-01-02T03:04:05Z\n"},
+		{"authors csv", exporter.FormatCSV, exporter.EntityAuthors, "id,name,website,agent_id,agent_name,agent_email,updated_at\n" +
+			`1,"Author, One",https://one.com,1,Agent One,one@example.com,2020-01-02T03:04:05Z` + "\n"},
+		{"books json", exporter.FormatJSON, exporter.EntityBooks, "[\n" +
+			`{"id":1,"title":"Title","description":"Description","cover":"cover.jpg","author_ids":[1,2],"updated_at":"2020-01-02T03:04:05Z"}` + "\n" +
+			"]\n"},
+		{"books csv", exporter.FormatCSV, exporter.EntityBooks, "id,title,description,cover,author_ids,updated_at\n" +
+			"1,Title,Description,cover.jpg,1;2,2020-01-02T03:04:05Z\n"},
+		{"deletions ndjson", exporter.FormatNDJSON, exporter.EntityDeletions,
+			`{"entity":"authors","id":3,"deleted_at":"2020-01-02T03:04:05Z"}` + "\n"},
+		{"deletions csv", exporter.FormatCSV, exporter.EntityDeletions, "entity,id,deleted_at\n" +
+			"authors,3,2020-01-02T03:04:05Z\n"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			err := exporter.Run(context.Background(), newExporterMock(), &buf, tc.format, tc.entity, time.Time{})
+			if err != nil {
+				t.Fatalf("expected no error, received %v", err)
+			}
+			if buf.String() != tc.exp {
+				t.Errorf("expected %q, received %q", tc.exp, buf.String())
+			}
+		})
+	}
+
+	t.Run("Invalid input", func(t *testing.T) {
+		t.Parallel()
+		for _, err := range []error{
+			exporter.Run(context.Background(), newExporterMock(), &bytes.Buffer{}, exporter.Format("xml"), exporter.EntityAgents, time.Time{}),
+			exporter.Run(context.Background(), newExporterMock(), &bytes.Buffer{}, exporter.FormatCSV, exporter.Entity("publishers"), time.Time{}),
+		} {
+			if err == nil {
+				t.Errorf("expected an error, received nil")
+			}
+		}
+	})
+
+	t.Run("Exporter error", func(t *testing.T) {
+		t.Parallel()
+		testError := errors.New("test error")
+		exp := &mocks.ExporterMock{
+			ExportAgentsFunc: func(ctx context.Context, since time.Time, fn func(postgres.AgentExport) error) error {
+				return testError
+			},
+		}
+		err := exporter.Run(context.Background(), exp, &bytes.Buffer{}, exporter.FormatJSON, exporter.EntityAgents, time.Time{})
+		if !errors.Is(err, testError) {
+			t.Errorf("wrong error: expected %v, received %v", testError, err)
+		}
+	})
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		target      string
+		auth        string
+		status      int
+		contentType string
+	}{
+		{"no token", "/export/agents", "", http.StatusUnauthorized, ""},
+		{"wrong token", "/export/agents", "Bearer wrong", http.StatusUnauthorized, ""},
+		{"default format", "/export/agents", "Bearer secret", http.StatusOK, "application/json"},
+		{"csv", "/export/books?format=csv", "Bearer secret", http.StatusOK, "text/csv; charset=utf-8"},
+		{"ndjson", "/export/authors?format=ndjson", "Bearer secret", http.StatusOK, "application/x-ndjson"},
+		{"updated since", "/export/agents?updated_since=2020-01-02T03:04:05Z", "Bearer secret", http.StatusOK, "application/json"},
+		{"unknown entity", "/export/publishers", "Bearer secret", http.StatusBadRequest, ""},
+		{"unknown format", "/export/agents?format=xml", "Bearer secret", http.StatusBadRequest, ""},
+		{"invalid updated since", "/export/agents?updated_since=yesterday", "Bearer secret", http.StatusBadRequest, ""},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var receivedSince time.Time
+			exp := newExporterMock()
+			exportAgents := exp.ExportAgentsFunc
+			exp.ExportAgentsFunc = func(ctx context.Context, since time.Time, fn func(postgres.AgentExport) error) error {
+				receivedSince = since
+				return exportAgents(ctx, since, fn)
+			}
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			rec := httptest.NewRecorder()
+			exporter.Handler(exp, "secret").ServeHTTP(rec, req)
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d, received %d", tc.status, rec.Code)
+			}
+			if tc.contentType != "" && rec.Header().Get("Content-Type") != tc.contentType {
+				t.Errorf("expected content type %q, received %q", tc.contentType, rec.Header().Get("Content-Type"))
+			}
+			if rec.Code != http.StatusOK && len(exp.ExportAgentsCalls())+len(exp.ExportAuthorsCalls())+len(exp.ExportBooksCalls()) != 0 {
+				t.Errorf("expected no exports")
+			}
+			if strings.Contains(tc.target, "updated_since") && rec.Code == http.StatusOK && !receivedSince.Equal(testTime) {
+				t.Errorf("expected since %v, received %v", testTime, receivedSince)
+			}
+		})
+	}
+}
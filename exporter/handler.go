@@ -0,0 +1,61 @@
+package exporter
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Handler serves exports at /export/{agents|authors|books|deletions}.
+// Requests must carry the token as a bearer token. The format query parameter
+// selects the format (JSON by default) and the optional updated_since
+// parameter, an RFC 3339 timestamp, limits the export to records updated (or
+// deleted) at or after it.
+func Handler(exp Exporter, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !authorized(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		entity := Entity(strings.TrimPrefix(r.URL.Path, "/export/"))
+		format := FormatJSON
+		if f := r.URL.Query().Get("format"); f != "" {
+			format = Format(f)
+		}
+		if err := Check(format, entity); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var since time.Time
+		if s := r.URL.Query().Get("updated_since"); s != "" {
+			var err error
+			if since, err = time.Parse(time.RFC3339, s); err != nil {
+				http.Error(w, "updated_since must be an RFC 3339 timestamp", http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", ContentType(format))
+		if err := Run(r.Context(), exp, w, format, entity, since); err != nil {
+			// the response has likely been partially written already, so the
+			// error can only be logged
+			log.Printf("export of %s failed: %v", entity, err)
+		}
+	})
+}
+
+func authorized(r *http.Request, token string) bool {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if token == "" || !strings.HasPrefix(h, prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(h[len(prefix):]), []byte(token)) == 1
+}
@@ -2,9 +2,97 @@
 
 package gqlgen
 
+import (
+	"github.com/fwojciec/litag-example/domain"
+)
+
+type AgentFilter struct {
+	AgencyID *int64 `json:"agencyId"`
+}
+
+type AuthorFilter struct {
+	// Authors whose primary agent works for the agency.
+	AgencyID *int64 `json:"agencyId"`
+}
+
+// Narrows down the books query to the books matching every field that is set.
+// Without a genreId, tag or agencyId all books are returned.
+type BookFilter struct {
+	GenreID *int64 `json:"genreId"`
+	// Also match books in any subgenre of the genre.
+	IncludeSubgenres *bool `json:"includeSubgenres"`
+	// Books with exactly this tag.
+	Tag *string `json:"tag"`
+	// Books by any author whose primary agent works for the agency.
+	AgencyID *int64 `json:"agencyId"`
+}
+
+type BulkAgentResult struct {
+	Agent *domain.Agent `json:"agent"`
+	Error *string       `json:"error"`
+}
+
+type BulkAgentsPayload struct {
+	Committed bool              `json:"committed"`
+	Results   []BulkAgentResult `json:"results"`
+}
+
+type BulkAuthorResult struct {
+	Author *domain.Author `json:"author"`
+	Error  *string        `json:"error"`
+}
+
+type BulkAuthorsPayload struct {
+	Committed bool               `json:"committed"`
+	Results   []BulkAuthorResult `json:"results"`
+}
+
+type BulkBookResult struct {
+	Book  *domain.Book `json:"book"`
+	Error *string      `json:"error"`
+}
+
+type BulkBooksPayload struct {
+	Committed bool             `json:"committed"`
+	Results   []BulkBookResult `json:"results"`
+}
+
+type BulkUpdateBookInput struct {
+	ID   int64                  `json:"id"`
+	Data *CreateUpdateBookInput `json:"data"`
+}
+
+type ContributorInput struct {
+	AuthorID int64                  `json:"authorID"`
+	Role     domain.ContributorRole `json:"role"`
+	// Must be positive.
+	Position int `json:"position"`
+}
+
+type CreateRepresentationInput struct {
+	AuthorID int64 `json:"authorID"`
+	AgentID  int64 `json:"agentID"`
+	// WORLD or an ISO 3166-1 alpha-2 country code.
+	Territory string      `json:"territory"`
+	StartedAt domain.Date `json:"startedAt"`
+}
+
+type CreateSubmissionInput struct {
+	AgentID       int64   `json:"agentID"`
+	AuthorName    string  `json:"authorName"`
+	AuthorWebsite *string `json:"authorWebsite"`
+	Title         string  `json:"title"`
+	Synopsis      string  `json:"synopsis"`
+}
+
+type CreateUpdateAgencyInput struct {
+	Name string `json:"name"`
+}
+
 type CreateUpdateAgentInput struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	AgencyID *int64 `json:"agencyID"`
 }
 
 type CreateUpdateAuthorInput struct {
@@ -14,8 +102,101 @@ type CreateUpdateAuthorInput struct {
 }
 
 type CreateUpdateBookInput struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Cover       string  `json:"cover"`
-	AuthorIDs   []int64 `json:"authorIDs"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Cover       string `json:"cover"`
+	PublisherID *int64 `json:"publisherID"`
+	// Both seriesID and seriesPosition are set for a book in a series.
+	SeriesID       *int64 `json:"seriesID"`
+	SeriesPosition *int   `json:"seriesPosition"`
+	// Unique across the catalog.
+	Isbn        *domain.ISBN `json:"isbn"`
+	PublishedOn *domain.Date `json:"publishedOn"`
+	// Must be positive.
+	PageCount *int                 `json:"pageCount"`
+	Language  *domain.LanguageCode `json:"language"`
+	// Credits the authors in order in the AUTHOR role; give either authorIDs or contributors.
+	AuthorIDs []int64 `json:"authorIDs"`
+	// Each author can be credited once.
+	Contributors []ContributorInput `json:"contributors"`
+	// Replaces the genres of the book; omitting it leaves the book without genres.
+	GenreIDs []int64 `json:"genreIDs"`
+	// Replaces the tags of the book; omitting it leaves the book without tags. Tags
+	// must be unique, not empty and not padded with spaces.
+	Tags []string `json:"tags"`
+}
+
+type CreateUpdateDealInput struct {
+	BookID      int64 `json:"bookID"`
+	PublisherID int64 `json:"publisherID"`
+	AgentID     int64 `json:"agentID"`
+	// WORLD or an ISO 3166-1 alpha-2 country code.
+	Territory string               `json:"territory"`
+	Language  domain.LanguageCode  `json:"language"`
+	Format    domain.EditionFormat `json:"format"`
+	// Must not be negative.
+	Advance domain.Money `json:"advance"`
+	// Required for signed deals, not allowed for deals under negotiation.
+	SignedOn *domain.Date       `json:"signedOn"`
+	Status   *domain.DealStatus `json:"status"`
+	// In basis points, from 0 to 10000.
+	RoyaltyRate int `json:"royaltyRate"`
+	// In basis points, from 0 to 10000.
+	CommissionRate int `json:"commissionRate"`
+}
+
+type CreateUpdateEditionInput struct {
+	BookID int64                `json:"bookID"`
+	Format domain.EditionFormat `json:"format"`
+	// Unique across editions.
+	Isbn        *domain.ISBN `json:"isbn"`
+	PublishedOn *domain.Date `json:"publishedOn"`
+	Price       *PriceInput  `json:"price"`
+}
+
+type CreateUpdateGenreInput struct {
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parentID"`
+}
+
+type CreateUpdatePublisherInput struct {
+	Name string `json:"name"`
+}
+
+type CreateUpdateSeriesInput struct {
+	Title string `json:"title"`
+}
+
+type CreateUpdateWebhookInput struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+// Narrows down the editions query. Every field that is set must match, and
+// without any all editions are returned.
+type EditionFilter struct {
+	BookID *int64                `json:"bookId"`
+	Format *domain.EditionFormat `json:"format"`
+	// Editions published on or after the date.
+	PublishedFrom *domain.Date `json:"publishedFrom"`
+	// Editions published on or before the date.
+	PublishedTo *domain.Date `json:"publishedTo"`
+}
+
+type PriceInput struct {
+	// Must not be negative.
+	Amount int `json:"amount"`
+	// An ISO 4217 code, such as USD.
+	Currency string `json:"currency"`
+}
+
+type RecordSalesInput struct {
+	DealID    int64 `json:"dealID"`
+	EditionID int64 `json:"editionID"`
+	// A quarter such as 2024-Q1.
+	Period string `json:"period"`
+	Units  int    `json:"units"`
+	// Must not be negative.
+	Revenue domain.Money `json:"revenue"`
 }
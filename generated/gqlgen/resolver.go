@@ -3,100 +3,490 @@ package gqlgen
 import (
 	"context"
 
-	"github.com/fwojciec/litag-example/generated/sqlc"
+	"github.com/fwojciec/litag-example/domain"
 )
 
 // THIS CODE IS A STARTING POINT ONLY. IT WILL NOT BE UPDATED WITH SCHEMA CHANGES.
 
 type Resolver struct{}
 
+func (r *Resolver) Agency() AgencyResolver {
+	return &agencyResolver{r}
+}
 func (r *Resolver) Agent() AgentResolver {
 	return &agentResolver{r}
 }
 func (r *Resolver) Author() AuthorResolver {
 	return &authorResolver{r}
 }
+func (r *Resolver) AvailableRights() AvailableRightsResolver {
+	return &availableRightsResolver{r}
+}
 func (r *Resolver) Book() BookResolver {
 	return &bookResolver{r}
 }
+func (r *Resolver) Contributor() ContributorResolver {
+	return &contributorResolver{r}
+}
+func (r *Resolver) Deal() DealResolver {
+	return &dealResolver{r}
+}
+func (r *Resolver) Edition() EditionResolver {
+	return &editionResolver{r}
+}
+func (r *Resolver) Genre() GenreResolver {
+	return &genreResolver{r}
+}
 func (r *Resolver) Mutation() MutationResolver {
 	return &mutationResolver{r}
 }
+func (r *Resolver) Publisher() PublisherResolver {
+	return &publisherResolver{r}
+}
 func (r *Resolver) Query() QueryResolver {
 	return &queryResolver{r}
 }
+func (r *Resolver) Representation() RepresentationResolver {
+	return &representationResolver{r}
+}
+func (r *Resolver) RoyaltyLine() RoyaltyLineResolver {
+	return &royaltyLineResolver{r}
+}
+func (r *Resolver) RoyaltyRate() RoyaltyRateResolver {
+	return &royaltyRateResolver{r}
+}
+func (r *Resolver) RoyaltyStatement() RoyaltyStatementResolver {
+	return &royaltyStatementResolver{r}
+}
+func (r *Resolver) Sales() SalesResolver {
+	return &salesResolver{r}
+}
+func (r *Resolver) Series() SeriesResolver {
+	return &seriesResolver{r}
+}
+func (r *Resolver) Submission() SubmissionResolver {
+	return &submissionResolver{r}
+}
+func (r *Resolver) Webhook() WebhookResolver {
+	return &webhookResolver{r}
+}
+func (r *Resolver) WebhookDelivery() WebhookDeliveryResolver {
+	return &webhookDeliveryResolver{r}
+}
+
+type agencyResolver struct{ *Resolver }
+
+func (r *agencyResolver) Agents(ctx context.Context, obj *domain.Agency) ([]domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *agencyResolver) Authors(ctx context.Context, obj *domain.Agency) ([]domain.Author, error) {
+	panic("not implemented")
+}
+func (r *agencyResolver) Books(ctx context.Context, obj *domain.Agency) ([]domain.Book, error) {
+	panic("not implemented")
+}
 
 type agentResolver struct{ *Resolver }
 
-func (r *agentResolver) Authors(ctx context.Context, obj *sqlc.Agent) ([]sqlc.Author, error) {
+func (r *agentResolver) Agency(ctx context.Context, obj *domain.Agent) (*domain.Agency, error) {
+	panic("not implemented")
+}
+func (r *agentResolver) Authors(ctx context.Context, obj *domain.Agent) ([]domain.Author, error) {
+	panic("not implemented")
+}
+func (r *agentResolver) FormerAuthors(ctx context.Context, obj *domain.Agent) ([]domain.Author, error) {
+	panic("not implemented")
+}
+func (r *agentResolver) Deals(ctx context.Context, obj *domain.Agent) ([]domain.Deal, error) {
+	panic("not implemented")
+}
+func (r *agentResolver) Submissions(ctx context.Context, obj *domain.Agent, status *domain.SubmissionStatus) ([]domain.Submission, error) {
 	panic("not implemented")
 }
 
 type authorResolver struct{ *Resolver }
 
-func (r *authorResolver) Website(ctx context.Context, obj *sqlc.Author) (*string, error) {
+func (r *authorResolver) Agent(ctx context.Context, obj *domain.Author) (*domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *authorResolver) Representations(ctx context.Context, obj *domain.Author) ([]domain.Representation, error) {
 	panic("not implemented")
 }
-func (r *authorResolver) Agent(ctx context.Context, obj *sqlc.Author) (*sqlc.Agent, error) {
+func (r *authorResolver) Books(ctx context.Context, obj *domain.Author) ([]domain.Book, error) {
 	panic("not implemented")
 }
-func (r *authorResolver) Books(ctx context.Context, obj *sqlc.Author) ([]sqlc.Book, error) {
+
+type availableRightsResolver struct{ *Resolver }
+
+func (r *availableRightsResolver) Book(ctx context.Context, obj *domain.AvailableRights) (*domain.Book, error) {
 	panic("not implemented")
 }
 
 type bookResolver struct{ *Resolver }
 
-func (r *bookResolver) Authors(ctx context.Context, obj *sqlc.Book) ([]sqlc.Author, error) {
+func (r *bookResolver) Publisher(ctx context.Context, obj *domain.Book) (*domain.Publisher, error) {
+	panic("not implemented")
+}
+func (r *bookResolver) Series(ctx context.Context, obj *domain.Book) (*domain.Series, error) {
+	panic("not implemented")
+}
+func (r *bookResolver) Authors(ctx context.Context, obj *domain.Book) ([]domain.Author, error) {
+	panic("not implemented")
+}
+func (r *bookResolver) Contributors(ctx context.Context, obj *domain.Book) ([]domain.Contributor, error) {
+	panic("not implemented")
+}
+func (r *bookResolver) Genres(ctx context.Context, obj *domain.Book) ([]domain.Genre, error) {
+	panic("not implemented")
+}
+func (r *bookResolver) Tags(ctx context.Context, obj *domain.Book) ([]string, error) {
+	panic("not implemented")
+}
+func (r *bookResolver) Editions(ctx context.Context, obj *domain.Book) ([]domain.Edition, error) {
+	panic("not implemented")
+}
+func (r *bookResolver) Deals(ctx context.Context, obj *domain.Book) ([]domain.Deal, error) {
+	panic("not implemented")
+}
+
+type contributorResolver struct{ *Resolver }
+
+func (r *contributorResolver) Author(ctx context.Context, obj *domain.Contributor) (*domain.Author, error) {
+	panic("not implemented")
+}
+
+type dealResolver struct{ *Resolver }
+
+func (r *dealResolver) Book(ctx context.Context, obj *domain.Deal) (*domain.Book, error) {
+	panic("not implemented")
+}
+func (r *dealResolver) Publisher(ctx context.Context, obj *domain.Deal) (*domain.Publisher, error) {
+	panic("not implemented")
+}
+func (r *dealResolver) Agent(ctx context.Context, obj *domain.Deal) (*domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *dealResolver) RoyaltyRates(ctx context.Context, obj *domain.Deal) ([]domain.RoyaltyRate, error) {
+	panic("not implemented")
+}
+func (r *dealResolver) Sales(ctx context.Context, obj *domain.Deal) ([]domain.Sales, error) {
+	panic("not implemented")
+}
+
+type editionResolver struct{ *Resolver }
+
+func (r *editionResolver) Book(ctx context.Context, obj *domain.Edition) (*domain.Book, error) {
+	panic("not implemented")
+}
+
+type genreResolver struct{ *Resolver }
+
+func (r *genreResolver) Parent(ctx context.Context, obj *domain.Genre) (*domain.Genre, error) {
+	panic("not implemented")
+}
+func (r *genreResolver) Children(ctx context.Context, obj *domain.Genre) ([]domain.Genre, error) {
+	panic("not implemented")
+}
+func (r *genreResolver) Books(ctx context.Context, obj *domain.Genre, includeSubgenres *bool) ([]domain.Book, error) {
 	panic("not implemented")
 }
 
 type mutationResolver struct{ *Resolver }
 
-func (r *mutationResolver) CreateAgent(ctx context.Context, data CreateUpdateAgentInput) (*sqlc.Agent, error) {
+func (r *mutationResolver) CreateAgency(ctx context.Context, data CreateUpdateAgencyInput) (*domain.Agency, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) UpdateAgency(ctx context.Context, id int64, data CreateUpdateAgencyInput) (*domain.Agency, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteAgency(ctx context.Context, id int64) (*domain.Agency, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateAgent(ctx context.Context, data CreateUpdateAgentInput) (*domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) UpdateAgent(ctx context.Context, id int64, data CreateUpdateAgentInput) (*domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateAgents(ctx context.Context, data []CreateUpdateAgentInput, mode *domain.BulkMode) (*BulkAgentsPayload, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateAuthor(ctx context.Context, data CreateUpdateAuthorInput) (*domain.Author, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) UpdateAuthor(ctx context.Context, id int64, data CreateUpdateAuthorInput) (*domain.Author, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteAuthor(ctx context.Context, id int64, orphanedBooks *domain.OrphanedBooksPolicy) (*domain.Author, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateAuthors(ctx context.Context, data []CreateUpdateAuthorInput, mode *domain.BulkMode) (*BulkAuthorsPayload, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateBook(ctx context.Context, data CreateUpdateBookInput) (*domain.Book, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) UpdateBook(ctx context.Context, id int64, data CreateUpdateBookInput) (*domain.Book, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteBook(ctx context.Context, id int64) (*domain.Book, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateBooks(ctx context.Context, data []CreateUpdateBookInput, mode *domain.BulkMode) (*BulkBooksPayload, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) UpdateBooks(ctx context.Context, data []BulkUpdateBookInput, mode *domain.BulkMode) (*BulkBooksPayload, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateDeal(ctx context.Context, data CreateUpdateDealInput) (*domain.Deal, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) UpdateDeal(ctx context.Context, id int64, data CreateUpdateDealInput) (*domain.Deal, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteDeal(ctx context.Context, id int64) (*domain.Deal, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) SetRoyaltyRate(ctx context.Context, dealID int64, editionID int64, rate int) (*domain.RoyaltyRate, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteRoyaltyRate(ctx context.Context, dealID int64, editionID int64) (*domain.RoyaltyRate, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) RecordSales(ctx context.Context, data RecordSalesInput) (*domain.Sales, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteSales(ctx context.Context, id int64) (*domain.Sales, error) {
 	panic("not implemented")
 }
-func (r *mutationResolver) UpdateAgent(ctx context.Context, id int64, data CreateUpdateAgentInput) (*sqlc.Agent, error) {
+func (r *mutationResolver) CreateEdition(ctx context.Context, data CreateUpdateEditionInput) (*domain.Edition, error) {
 	panic("not implemented")
 }
-func (r *mutationResolver) DeleteAgent(ctx context.Context, id int64) (*sqlc.Agent, error) {
+func (r *mutationResolver) UpdateEdition(ctx context.Context, id int64, data CreateUpdateEditionInput) (*domain.Edition, error) {
 	panic("not implemented")
 }
-func (r *mutationResolver) CreateAuthor(ctx context.Context, data CreateUpdateAuthorInput) (*sqlc.Author, error) {
+func (r *mutationResolver) DeleteEdition(ctx context.Context, id int64) (*domain.Edition, error) {
 	panic("not implemented")
 }
-func (r *mutationResolver) UpdateAuthor(ctx context.Context, id int64, data CreateUpdateAuthorInput) (*sqlc.Author, error) {
+func (r *mutationResolver) CreateGenre(ctx context.Context, data CreateUpdateGenreInput) (*domain.Genre, error) {
 	panic("not implemented")
 }
-func (r *mutationResolver) DeleteAuthor(ctx context.Context, id int64) (*sqlc.Author, error) {
+func (r *mutationResolver) UpdateGenre(ctx context.Context, id int64, data CreateUpdateGenreInput) (*domain.Genre, error) {
 	panic("not implemented")
 }
-func (r *mutationResolver) CreateBook(ctx context.Context, data CreateUpdateBookInput) (*sqlc.Book, error) {
+func (r *mutationResolver) DeleteGenre(ctx context.Context, id int64) (*domain.Genre, error) {
 	panic("not implemented")
 }
-func (r *mutationResolver) UpdateBook(ctx context.Context, id int64, data CreateUpdateBookInput) (*sqlc.Book, error) {
+func (r *mutationResolver) CreatePublisher(ctx context.Context, data CreateUpdatePublisherInput) (*domain.Publisher, error) {
 	panic("not implemented")
 }
-func (r *mutationResolver) DeleteBook(ctx context.Context, id int64) (*sqlc.Book, error) {
+func (r *mutationResolver) UpdatePublisher(ctx context.Context, id int64, data CreateUpdatePublisherInput) (*domain.Publisher, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeletePublisher(ctx context.Context, id int64) (*domain.Publisher, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateRepresentation(ctx context.Context, data CreateRepresentationInput) (*domain.Representation, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) EndRepresentation(ctx context.Context, id int64, endedAt domain.Date) (*domain.Representation, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateSeries(ctx context.Context, data CreateUpdateSeriesInput) (*domain.Series, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) UpdateSeries(ctx context.Context, id int64, data CreateUpdateSeriesInput) (*domain.Series, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteSeries(ctx context.Context, id int64) (*domain.Series, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateSubmission(ctx context.Context, data CreateSubmissionInput) (*domain.Submission, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) TransitionSubmission(ctx context.Context, id int64, status domain.SubmissionStatus, note *string) (*domain.Submission, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) ConvertSubmission(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) CreateWebhook(ctx context.Context, data CreateUpdateWebhookInput) (*domain.Webhook, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) UpdateWebhook(ctx context.Context, id int64, data CreateUpdateWebhookInput) (*domain.Webhook, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id int64) (*domain.Webhook, error) {
+	panic("not implemented")
+}
+func (r *mutationResolver) RetryWebhookDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error) {
+	panic("not implemented")
+}
+
+type publisherResolver struct{ *Resolver }
+
+func (r *publisherResolver) Books(ctx context.Context, obj *domain.Publisher) ([]domain.Book, error) {
 	panic("not implemented")
 }
 
 type queryResolver struct{ *Resolver }
 
-func (r *queryResolver) Agent(ctx context.Context, id int64) (*sqlc.Agent, error) {
+func (r *queryResolver) Agency(ctx context.Context, id int64) (*domain.Agency, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Agencies(ctx context.Context) ([]domain.Agency, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Agent(ctx context.Context, id int64) (*domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Agents(ctx context.Context, filter *AgentFilter) ([]domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Author(ctx context.Context, id int64) (*domain.Author, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Authors(ctx context.Context, filter *AuthorFilter) ([]domain.Author, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Book(ctx context.Context, id int64) (*domain.Book, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) BookByIsbn(ctx context.Context, isbn domain.ISBN) (*domain.Book, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Books(ctx context.Context, filter *BookFilter) ([]domain.Book, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) OrphanBooks(ctx context.Context) ([]domain.Book, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Deal(ctx context.Context, id int64) (*domain.Deal, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) AvailableRights(ctx context.Context, territory string, language domain.LanguageCode) ([]domain.AvailableRights, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) RoyaltyStatement(ctx context.Context, authorID int64, period string) (*domain.RoyaltyStatement, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Edition(ctx context.Context, id int64) (*domain.Edition, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) EditionByIsbn(ctx context.Context, isbn domain.ISBN) (*domain.Edition, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Editions(ctx context.Context, filter *EditionFilter) ([]domain.Edition, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Genre(ctx context.Context, id int64) (*domain.Genre, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Genres(ctx context.Context) ([]domain.Genre, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Publisher(ctx context.Context, id int64) (*domain.Publisher, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Publishers(ctx context.Context) ([]domain.Publisher, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Series(ctx context.Context, id int64) (*domain.Series, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) AllSeries(ctx context.Context) ([]domain.Series, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Submission(ctx context.Context, id int64) (*domain.Submission, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Webhook(ctx context.Context, id int64) (*domain.Webhook, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) Webhooks(ctx context.Context) ([]domain.Webhook, error) {
+	panic("not implemented")
+}
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID int64, status *string) ([]domain.WebhookDelivery, error) {
+	panic("not implemented")
+}
+
+type representationResolver struct{ *Resolver }
+
+func (r *representationResolver) Author(ctx context.Context, obj *domain.Representation) (*domain.Author, error) {
 	panic("not implemented")
 }
-func (r *queryResolver) Agents(ctx context.Context) ([]sqlc.Agent, error) {
+func (r *representationResolver) Agent(ctx context.Context, obj *domain.Representation) (*domain.Agent, error) {
 	panic("not implemented")
 }
-func (r *queryResolver) Author(ctx context.Context, id int64) (*sqlc.Author, error) {
+
+type royaltyLineResolver struct{ *Resolver }
+
+func (r *royaltyLineResolver) Book(ctx context.Context, obj *domain.RoyaltyLine) (*domain.Book, error) {
+	panic("not implemented")
+}
+
+type royaltyRateResolver struct{ *Resolver }
+
+func (r *royaltyRateResolver) Deal(ctx context.Context, obj *domain.RoyaltyRate) (*domain.Deal, error) {
 	panic("not implemented")
 }
-func (r *queryResolver) Authors(ctx context.Context) ([]sqlc.Author, error) {
+func (r *royaltyRateResolver) Edition(ctx context.Context, obj *domain.RoyaltyRate) (*domain.Edition, error) {
 	panic("not implemented")
 }
-func (r *queryResolver) Book(ctx context.Context, id int64) (*sqlc.Book, error) {
+
+type royaltyStatementResolver struct{ *Resolver }
+
+func (r *royaltyStatementResolver) Author(ctx context.Context, obj *domain.RoyaltyStatement) (*domain.Author, error) {
+	panic("not implemented")
+}
+func (r *royaltyStatementResolver) Csv(ctx context.Context, obj *domain.RoyaltyStatement) (string, error) {
+	panic("not implemented")
+}
+
+type salesResolver struct{ *Resolver }
+
+func (r *salesResolver) Deal(ctx context.Context, obj *domain.Sales) (*domain.Deal, error) {
+	panic("not implemented")
+}
+func (r *salesResolver) Edition(ctx context.Context, obj *domain.Sales) (*domain.Edition, error) {
+	panic("not implemented")
+}
+
+type seriesResolver struct{ *Resolver }
+
+func (r *seriesResolver) Books(ctx context.Context, obj *domain.Series) ([]domain.Book, error) {
+	panic("not implemented")
+}
+
+type submissionResolver struct{ *Resolver }
+
+func (r *submissionResolver) Agent(ctx context.Context, obj *domain.Submission) (*domain.Agent, error) {
+	panic("not implemented")
+}
+func (r *submissionResolver) Transitions(ctx context.Context, obj *domain.Submission) ([]domain.SubmissionTransition, error) {
+	panic("not implemented")
+}
+func (r *submissionResolver) Book(ctx context.Context, obj *domain.Submission) (*domain.Book, error) {
+	panic("not implemented")
+}
+
+type webhookResolver struct{ *Resolver }
+
+func (r *webhookResolver) Deliveries(ctx context.Context, obj *domain.Webhook, status *string) ([]domain.WebhookDelivery, error) {
+	panic("not implemented")
+}
+
+type webhookDeliveryResolver struct{ *Resolver }
+
+func (r *webhookDeliveryResolver) Webhook(ctx context.Context, obj *domain.WebhookDelivery) (*domain.Webhook, error) {
 	panic("not implemented")
 }
-func (r *queryResolver) Books(ctx context.Context) ([]sqlc.Book, error) {
+func (r *webhookDeliveryResolver) Payload(ctx context.Context, obj *domain.WebhookDelivery) (string, error) {
 	panic("not implemented")
 }
@@ -0,0 +1,257 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/fwojciec/litag-example/exporter"
+	"github.com/fwojciec/litag-example/postgres"
+	"sync"
+	"time"
+)
+
+var (
+	lockExporterMockExportAgents    sync.RWMutex
+	lockExporterMockExportAuthors   sync.RWMutex
+	lockExporterMockExportBooks     sync.RWMutex
+	lockExporterMockExportDeletions sync.RWMutex
+)
+
+// Ensure, that ExporterMock does implement exporter.Exporter.
+// If this is not the case, regenerate this file with moq.
+var _ exporter.Exporter = &ExporterMock{}
+
+// ExporterMock is a mock implementation of exporter.Exporter.
+//
+//     func TestSomethingThatUsesExporter(t *testing.T) {
+//
+//         // make and configure a mocked exporter.Exporter
+//         mockedExporter := &ExporterMock{
+//             ExportAgentsFunc: func(ctx context.Context, since time.Time, fn func(postgres.AgentExport) error) error {
+// 	               panic("mock out the ExportAgents method")
+//             },
+//             ExportAuthorsFunc: func(ctx context.Context, since time.Time, fn func(postgres.AuthorExport) error) error {
+// 	               panic("mock out the ExportAuthors method")
+//             },
+//             ExportBooksFunc: func(ctx context.Context, since time.Time, fn func(postgres.BookExport) error) error {
+// 	               panic("mock out the ExportBooks method")
+//             },
+//             ExportDeletionsFunc: func(ctx context.Context, since time.Time, fn func(postgres.DeletionExport) error) error {
+// 	               panic("mock out the ExportDeletions method")
+//             },
+//         }
+//
+//         // use mockedExporter in code that requires exporter.Exporter
+//         // and then make assertions.
+//
+//     }
+type ExporterMock struct {
+	// ExportAgentsFunc mocks the ExportAgents method.
+	ExportAgentsFunc func(ctx context.Context, since time.Time, fn func(postgres.AgentExport) error) error
+
+	// ExportAuthorsFunc mocks the ExportAuthors method.
+	ExportAuthorsFunc func(ctx context.Context, since time.Time, fn func(postgres.AuthorExport) error) error
+
+	// ExportBooksFunc mocks the ExportBooks method.
+	ExportBooksFunc func(ctx context.Context, since time.Time, fn func(postgres.BookExport) error) error
+
+	// ExportDeletionsFunc mocks the ExportDeletions method.
+	ExportDeletionsFunc func(ctx context.Context, since time.Time, fn func(postgres.DeletionExport) error) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ExportAgents holds details about calls to the ExportAgents method.
+		ExportAgents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Since is the since argument value.
+			Since time.Time
+			// Fn is the fn argument value.
+			Fn func(postgres.AgentExport) error
+		}
+		// ExportAuthors holds details about calls to the ExportAuthors method.
+		ExportAuthors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Since is the since argument value.
+			Since time.Time
+			// Fn is the fn argument value.
+			Fn func(postgres.AuthorExport) error
+		}
+		// ExportBooks holds details about calls to the ExportBooks method.
+		ExportBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Since is the since argument value.
+			Since time.Time
+			// Fn is the fn argument value.
+			Fn func(postgres.BookExport) error
+		}
+		// ExportDeletions holds details about calls to the ExportDeletions method.
+		ExportDeletions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Since is the since argument value.
+			Since time.Time
+			// Fn is the fn argument value.
+			Fn func(postgres.DeletionExport) error
+		}
+	}
+}
+
+// ExportAgents calls ExportAgentsFunc.
+func (mock *ExporterMock) ExportAgents(ctx context.Context, since time.Time, fn func(postgres.AgentExport) error) error {
+	if mock.ExportAgentsFunc == nil {
+		panic("ExporterMock.ExportAgentsFunc: method is nil but Exporter.ExportAgents was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Since time.Time
+		Fn    func(postgres.AgentExport) error
+	}{
+		Ctx:   ctx,
+		Since: since,
+		Fn:    fn,
+	}
+	lockExporterMockExportAgents.Lock()
+	mock.calls.ExportAgents = append(mock.calls.ExportAgents, callInfo)
+	lockExporterMockExportAgents.Unlock()
+	return mock.ExportAgentsFunc(ctx, since, fn)
+}
+
+// ExportAgentsCalls gets all the calls that were made to ExportAgents.
+// Check the length with:
+//     len(mockedExporter.ExportAgentsCalls())
+func (mock *ExporterMock) ExportAgentsCalls() []struct {
+	Ctx   context.Context
+	Since time.Time
+	Fn    func(postgres.AgentExport) error
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Since time.Time
+		Fn    func(postgres.AgentExport) error
+	}
+	lockExporterMockExportAgents.RLock()
+	calls = mock.calls.ExportAgents
+	lockExporterMockExportAgents.RUnlock()
+	return calls
+}
+
+// ExportAuthors calls ExportAuthorsFunc.
+func (mock *ExporterMock) ExportAuthors(ctx context.Context, since time.Time, fn func(postgres.AuthorExport) error) error {
+	if mock.ExportAuthorsFunc == nil {
+		panic("ExporterMock.ExportAuthorsFunc: method is nil but Exporter.ExportAuthors was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Since time.Time
+		Fn    func(postgres.AuthorExport) error
+	}{
+		Ctx:   ctx,
+		Since: since,
+		Fn:    fn,
+	}
+	lockExporterMockExportAuthors.Lock()
+	mock.calls.ExportAuthors = append(mock.calls.ExportAuthors, callInfo)
+	lockExporterMockExportAuthors.Unlock()
+	return mock.ExportAuthorsFunc(ctx, since, fn)
+}
+
+// ExportAuthorsCalls gets all the calls that were made to ExportAuthors.
+// Check the length with:
+//     len(mockedExporter.ExportAuthorsCalls())
+func (mock *ExporterMock) ExportAuthorsCalls() []struct {
+	Ctx   context.Context
+	Since time.Time
+	Fn    func(postgres.AuthorExport) error
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Since time.Time
+		Fn    func(postgres.AuthorExport) error
+	}
+	lockExporterMockExportAuthors.RLock()
+	calls = mock.calls.ExportAuthors
+	lockExporterMockExportAuthors.RUnlock()
+	return calls
+}
+
+// ExportBooks calls ExportBooksFunc.
+func (mock *ExporterMock) ExportBooks(ctx context.Context, since time.Time, fn func(postgres.BookExport) error) error {
+	if mock.ExportBooksFunc == nil {
+		panic("ExporterMock.ExportBooksFunc: method is nil but Exporter.ExportBooks was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Since time.Time
+		Fn    func(postgres.BookExport) error
+	}{
+		Ctx:   ctx,
+		Since: since,
+		Fn:    fn,
+	}
+	lockExporterMockExportBooks.Lock()
+	mock.calls.ExportBooks = append(mock.calls.ExportBooks, callInfo)
+	lockExporterMockExportBooks.Unlock()
+	return mock.ExportBooksFunc(ctx, since, fn)
+}
+
+// ExportBooksCalls gets all the calls that were made to ExportBooks.
+// Check the length with:
+//     len(mockedExporter.ExportBooksCalls())
+func (mock *ExporterMock) ExportBooksCalls() []struct {
+	Ctx   context.Context
+	Since time.Time
+	Fn    func(postgres.BookExport) error
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Since time.Time
+		Fn    func(postgres.BookExport) error
+	}
+	lockExporterMockExportBooks.RLock()
+	calls = mock.calls.ExportBooks
+	lockExporterMockExportBooks.RUnlock()
+	return calls
+}
+
+// ExportDeletions calls ExportDeletionsFunc.
+func (mock *ExporterMock) ExportDeletions(ctx context.Context, since time.Time, fn func(postgres.DeletionExport) error) error {
+	if mock.ExportDeletionsFunc == nil {
+		panic("ExporterMock.ExportDeletionsFunc: method is nil but Exporter.ExportDeletions was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Since time.Time
+		Fn    func(postgres.DeletionExport) error
+	}{
+		Ctx:   ctx,
+		Since: since,
+		Fn:    fn,
+	}
+	lockExporterMockExportDeletions.Lock()
+	mock.calls.ExportDeletions = append(mock.calls.ExportDeletions, callInfo)
+	lockExporterMockExportDeletions.Unlock()
+	return mock.ExportDeletionsFunc(ctx, since, fn)
+}
+
+// ExportDeletionsCalls gets all the calls that were made to ExportDeletions.
+// Check the length with:
+//     len(mockedExporter.ExportDeletionsCalls())
+func (mock *ExporterMock) ExportDeletionsCalls() []struct {
+	Ctx   context.Context
+	Since time.Time
+	Fn    func(postgres.DeletionExport) error
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Since time.Time
+		Fn    func(postgres.DeletionExport) error
+	}
+	lockExporterMockExportDeletions.RLock()
+	calls = mock.calls.ExportDeletions
+	lockExporterMockExportDeletions.RUnlock()
+	return calls
+}
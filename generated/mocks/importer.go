@@ -0,0 +1,201 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/fwojciec/litag-example/importer"
+	"github.com/fwojciec/litag-example/postgres"
+	"sync"
+)
+
+var (
+	lockImporterMockImportAgents  sync.RWMutex
+	lockImporterMockImportAuthors sync.RWMutex
+	lockImporterMockImportBooks   sync.RWMutex
+)
+
+// Ensure, that ImporterMock does implement importer.Importer.
+// If this is not the case, regenerate this file with moq.
+var _ importer.Importer = &ImporterMock{}
+
+// ImporterMock is a mock implementation of importer.Importer.
+//
+//     func TestSomethingThatUsesImporter(t *testing.T) {
+//
+//         // make and configure a mocked importer.Importer
+//         mockedImporter := &ImporterMock{
+//             ImportAgentsFunc: func(ctx context.Context, rows []postgres.AgentImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+// 	               panic("mock out the ImportAgents method")
+//             },
+//             ImportAuthorsFunc: func(ctx context.Context, rows []postgres.AuthorImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+// 	               panic("mock out the ImportAuthors method")
+//             },
+//             ImportBooksFunc: func(ctx context.Context, rows []postgres.BookImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+// 	               panic("mock out the ImportBooks method")
+//             },
+//         }
+//
+//         // use mockedImporter in code that requires importer.Importer
+//         // and then make assertions.
+//
+//     }
+type ImporterMock struct {
+	// ImportAgentsFunc mocks the ImportAgents method.
+	ImportAgentsFunc func(ctx context.Context, rows []postgres.AgentImportRow, dryRun bool) (*postgres.ImportSummary, error)
+
+	// ImportAuthorsFunc mocks the ImportAuthors method.
+	ImportAuthorsFunc func(ctx context.Context, rows []postgres.AuthorImportRow, dryRun bool) (*postgres.ImportSummary, error)
+
+	// ImportBooksFunc mocks the ImportBooks method.
+	ImportBooksFunc func(ctx context.Context, rows []postgres.BookImportRow, dryRun bool) (*postgres.ImportSummary, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ImportAgents holds details about calls to the ImportAgents method.
+		ImportAgents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Rows is the rows argument value.
+			Rows []postgres.AgentImportRow
+			// DryRun is the dryRun argument value.
+			DryRun bool
+		}
+		// ImportAuthors holds details about calls to the ImportAuthors method.
+		ImportAuthors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Rows is the rows argument value.
+			Rows []postgres.AuthorImportRow
+			// DryRun is the dryRun argument value.
+			DryRun bool
+		}
+		// ImportBooks holds details about calls to the ImportBooks method.
+		ImportBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Rows is the rows argument value.
+			Rows []postgres.BookImportRow
+			// DryRun is the dryRun argument value.
+			DryRun bool
+		}
+	}
+}
+
+// ImportAgents calls ImportAgentsFunc.
+func (mock *ImporterMock) ImportAgents(ctx context.Context, rows []postgres.AgentImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+	if mock.ImportAgentsFunc == nil {
+		panic("ImporterMock.ImportAgentsFunc: method is nil but Importer.ImportAgents was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Rows   []postgres.AgentImportRow
+		DryRun bool
+	}{
+		Ctx:    ctx,
+		Rows:   rows,
+		DryRun: dryRun,
+	}
+	lockImporterMockImportAgents.Lock()
+	mock.calls.ImportAgents = append(mock.calls.ImportAgents, callInfo)
+	lockImporterMockImportAgents.Unlock()
+	return mock.ImportAgentsFunc(ctx, rows, dryRun)
+}
+
+// ImportAgentsCalls gets all the calls that were made to ImportAgents.
+// Check the length with:
+//     len(mockedImporter.ImportAgentsCalls())
+func (mock *ImporterMock) ImportAgentsCalls() []struct {
+	Ctx    context.Context
+	Rows   []postgres.AgentImportRow
+	DryRun bool
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Rows   []postgres.AgentImportRow
+		DryRun bool
+	}
+	lockImporterMockImportAgents.RLock()
+	calls = mock.calls.ImportAgents
+	lockImporterMockImportAgents.RUnlock()
+	return calls
+}
+
+// ImportAuthors calls ImportAuthorsFunc.
+func (mock *ImporterMock) ImportAuthors(ctx context.Context, rows []postgres.AuthorImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+	if mock.ImportAuthorsFunc == nil {
+		panic("ImporterMock.ImportAuthorsFunc: method is nil but Importer.ImportAuthors was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Rows   []postgres.AuthorImportRow
+		DryRun bool
+	}{
+		Ctx:    ctx,
+		Rows:   rows,
+		DryRun: dryRun,
+	}
+	lockImporterMockImportAuthors.Lock()
+	mock.calls.ImportAuthors = append(mock.calls.ImportAuthors, callInfo)
+	lockImporterMockImportAuthors.Unlock()
+	return mock.ImportAuthorsFunc(ctx, rows, dryRun)
+}
+
+// ImportAuthorsCalls gets all the calls that were made to ImportAuthors.
+// Check the length with:
+//     len(mockedImporter.ImportAuthorsCalls())
+func (mock *ImporterMock) ImportAuthorsCalls() []struct {
+	Ctx    context.Context
+	Rows   []postgres.AuthorImportRow
+	DryRun bool
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Rows   []postgres.AuthorImportRow
+		DryRun bool
+	}
+	lockImporterMockImportAuthors.RLock()
+	calls = mock.calls.ImportAuthors
+	lockImporterMockImportAuthors.RUnlock()
+	return calls
+}
+
+// ImportBooks calls ImportBooksFunc.
+func (mock *ImporterMock) ImportBooks(ctx context.Context, rows []postgres.BookImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+	if mock.ImportBooksFunc == nil {
+		panic("ImporterMock.ImportBooksFunc: method is nil but Importer.ImportBooks was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Rows   []postgres.BookImportRow
+		DryRun bool
+	}{
+		Ctx:    ctx,
+		Rows:   rows,
+		DryRun: dryRun,
+	}
+	lockImporterMockImportBooks.Lock()
+	mock.calls.ImportBooks = append(mock.calls.ImportBooks, callInfo)
+	lockImporterMockImportBooks.Unlock()
+	return mock.ImportBooksFunc(ctx, rows, dryRun)
+}
+
+// ImportBooksCalls gets all the calls that were made to ImportBooks.
+// Check the length with:
+//     len(mockedImporter.ImportBooksCalls())
+func (mock *ImporterMock) ImportBooksCalls() []struct {
+	Ctx    context.Context
+	Rows   []postgres.BookImportRow
+	DryRun bool
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Rows   []postgres.BookImportRow
+		DryRun bool
+	}
+	lockImporterMockImportBooks.RLock()
+	calls = mock.calls.ImportBooks
+	lockImporterMockImportBooks.RUnlock()
+	return calls
+}
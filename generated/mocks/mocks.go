@@ -2,3 +2,7 @@ package mocks
 
 //go:generate moq -out querent.go -pkg mocks ../../postgres Querent
 //go:generate moq -out txquerent.go -pkg mocks ../../postgres TxQuerent
+//go:generate moq -out store.go -pkg mocks ../../webhooks Store
+//go:generate moq -out importer.go -pkg mocks ../../importer Importer
+//go:generate moq -out exporter.go -pkg mocks ../../exporter Exporter
+//go:generate moq -out repository.go -pkg mocks ../../domain Repository
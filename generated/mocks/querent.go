@@ -11,22 +11,90 @@ import (
 )
 
 var (
-	lockQuerentMockCreateAgent          sync.RWMutex
-	lockQuerentMockCreateAuthor         sync.RWMutex
-	lockQuerentMockDeleteAgent          sync.RWMutex
-	lockQuerentMockDeleteAuthor         sync.RWMutex
-	lockQuerentMockDeleteBook           sync.RWMutex
-	lockQuerentMockGetAgent             sync.RWMutex
-	lockQuerentMockGetAuthor            sync.RWMutex
-	lockQuerentMockGetBook              sync.RWMutex
-	lockQuerentMockListAgents           sync.RWMutex
-	lockQuerentMockListAuthors          sync.RWMutex
-	lockQuerentMockListAuthorsByAgentID sync.RWMutex
-	lockQuerentMockListAuthorsByBookID  sync.RWMutex
-	lockQuerentMockListBooks            sync.RWMutex
-	lockQuerentMockListBooksByAuthorID  sync.RWMutex
-	lockQuerentMockUpdateAgent          sync.RWMutex
-	lockQuerentMockUpdateAuthor         sync.RWMutex
+	lockQuerentMockClaimWebhookDeliveries            sync.RWMutex
+	lockQuerentMockCompleteWebhookDelivery           sync.RWMutex
+	lockQuerentMockCreateAgency                      sync.RWMutex
+	lockQuerentMockCreateAgent                       sync.RWMutex
+	lockQuerentMockCreateDeal                        sync.RWMutex
+	lockQuerentMockCreateEdition                     sync.RWMutex
+	lockQuerentMockCreateGenre                       sync.RWMutex
+	lockQuerentMockCreatePublisher                   sync.RWMutex
+	lockQuerentMockCreateRepresentation              sync.RWMutex
+	lockQuerentMockCreateSeries                      sync.RWMutex
+	lockQuerentMockCreateSubmission                  sync.RWMutex
+	lockQuerentMockCreateWebhook                     sync.RWMutex
+	lockQuerentMockDeleteAgency                      sync.RWMutex
+	lockQuerentMockDeleteDeal                        sync.RWMutex
+	lockQuerentMockDeleteEdition                     sync.RWMutex
+	lockQuerentMockDeleteGenre                       sync.RWMutex
+	lockQuerentMockDeletePublisher                   sync.RWMutex
+	lockQuerentMockDeleteRoyaltyRate                 sync.RWMutex
+	lockQuerentMockDeleteSales                       sync.RWMutex
+	lockQuerentMockDeleteWebhook                     sync.RWMutex
+	lockQuerentMockFailWebhookDelivery               sync.RWMutex
+	lockQuerentMockGetAgency                         sync.RWMutex
+	lockQuerentMockGetAgent                          sync.RWMutex
+	lockQuerentMockGetAuthor                         sync.RWMutex
+	lockQuerentMockGetBook                           sync.RWMutex
+	lockQuerentMockGetBookByISBN                     sync.RWMutex
+	lockQuerentMockGetDeal                           sync.RWMutex
+	lockQuerentMockGetEdition                        sync.RWMutex
+	lockQuerentMockGetEditionByISBN                  sync.RWMutex
+	lockQuerentMockGetGenre                          sync.RWMutex
+	lockQuerentMockGetPublisher                      sync.RWMutex
+	lockQuerentMockGetRepresentation                 sync.RWMutex
+	lockQuerentMockGetSeries                         sync.RWMutex
+	lockQuerentMockGetSubmission                     sync.RWMutex
+	lockQuerentMockGetWebhook                        sync.RWMutex
+	lockQuerentMockListAgencies                      sync.RWMutex
+	lockQuerentMockListAgents                        sync.RWMutex
+	lockQuerentMockListAgentsByAgencyID              sync.RWMutex
+	lockQuerentMockListAuthors                       sync.RWMutex
+	lockQuerentMockListAuthorsByAgencyID             sync.RWMutex
+	lockQuerentMockListAuthorsByAgentID              sync.RWMutex
+	lockQuerentMockListAuthorsByBookID               sync.RWMutex
+	lockQuerentMockListAvailableRights               sync.RWMutex
+	lockQuerentMockListBooks                         sync.RWMutex
+	lockQuerentMockListBooksByAgencyID               sync.RWMutex
+	lockQuerentMockListBooksByAuthorID               sync.RWMutex
+	lockQuerentMockListBooksByGenreID                sync.RWMutex
+	lockQuerentMockListBooksByPublisherID            sync.RWMutex
+	lockQuerentMockListBooksBySeriesID               sync.RWMutex
+	lockQuerentMockListBooksByTag                    sync.RWMutex
+	lockQuerentMockListBooksInGenreTree              sync.RWMutex
+	lockQuerentMockListContributorsByBookID          sync.RWMutex
+	lockQuerentMockListDealsByAgentID                sync.RWMutex
+	lockQuerentMockListDealsByBookID                 sync.RWMutex
+	lockQuerentMockListEditions                      sync.RWMutex
+	lockQuerentMockListEditionsByBookID              sync.RWMutex
+	lockQuerentMockListFormerAuthorsByAgentID        sync.RWMutex
+	lockQuerentMockListGenres                        sync.RWMutex
+	lockQuerentMockListGenresByBookID                sync.RWMutex
+	lockQuerentMockListGenresByParentID              sync.RWMutex
+	lockQuerentMockListOrphanBooks                   sync.RWMutex
+	lockQuerentMockListPublishers                    sync.RWMutex
+	lockQuerentMockListRepresentationsByAuthorID     sync.RWMutex
+	lockQuerentMockListRoyaltyRatesByDealID          sync.RWMutex
+	lockQuerentMockListRoyaltySales                  sync.RWMutex
+	lockQuerentMockListSalesByDealID                 sync.RWMutex
+	lockQuerentMockListSeries                        sync.RWMutex
+	lockQuerentMockListSubmissionTransitions         sync.RWMutex
+	lockQuerentMockListSubmissionsByAgentID          sync.RWMutex
+	lockQuerentMockListSubmissionsByAgentIDAndStatus sync.RWMutex
+	lockQuerentMockListTagsByBookID                  sync.RWMutex
+	lockQuerentMockListWebhookDeliveries             sync.RWMutex
+	lockQuerentMockListWebhookDeliveriesByStatus     sync.RWMutex
+	lockQuerentMockListWebhooks                      sync.RWMutex
+	lockQuerentMockRecordSales                       sync.RWMutex
+	lockQuerentMockRetryWebhookDelivery              sync.RWMutex
+	lockQuerentMockSetRoyaltyRate                    sync.RWMutex
+	lockQuerentMockUpdateAgency                      sync.RWMutex
+	lockQuerentMockUpdateAgent                       sync.RWMutex
+	lockQuerentMockUpdateDeal                        sync.RWMutex
+	lockQuerentMockUpdateEdition                     sync.RWMutex
+	lockQuerentMockUpdatePublisher                   sync.RWMutex
+	lockQuerentMockUpdateSeries                      sync.RWMutex
+	lockQuerentMockUpdateWebhook                     sync.RWMutex
 )
 
 // Ensure, that QuerentMock does implement postgres.Querent.
@@ -39,53 +107,257 @@ var _ postgres.Querent = &QuerentMock{}
 //
 //         // make and configure a mocked postgres.Querent
 //         mockedQuerent := &QuerentMock{
+//             ClaimWebhookDeliveriesFunc: func(ctx context.Context, args sqlc.ClaimWebhookDeliveriesParams) ([]sqlc.ClaimWebhookDeliveriesRow, error) {
+// 	               panic("mock out the ClaimWebhookDeliveries method")
+//             },
+//             CompleteWebhookDeliveryFunc: func(ctx context.Context, args sqlc.CompleteWebhookDeliveryParams) error {
+// 	               panic("mock out the CompleteWebhookDelivery method")
+//             },
+//             CreateAgencyFunc: func(ctx context.Context, name string) (sqlc.Agency, error) {
+// 	               panic("mock out the CreateAgency method")
+//             },
 //             CreateAgentFunc: func(ctx context.Context, args sqlc.CreateAgentParams) (sqlc.Agent, error) {
 // 	               panic("mock out the CreateAgent method")
 //             },
-//             CreateAuthorFunc: func(ctx context.Context, args sqlc.CreateAuthorParams) (sqlc.Author, error) {
-// 	               panic("mock out the CreateAuthor method")
+//             CreateDealFunc: func(ctx context.Context, args sqlc.CreateDealParams) (sqlc.Deal, error) {
+// 	               panic("mock out the CreateDeal method")
+//             },
+//             CreateEditionFunc: func(ctx context.Context, args sqlc.CreateEditionParams) (sqlc.Edition, error) {
+// 	               panic("mock out the CreateEdition method")
+//             },
+//             CreateGenreFunc: func(ctx context.Context, args sqlc.CreateGenreParams) (sqlc.Genre, error) {
+// 	               panic("mock out the CreateGenre method")
+//             },
+//             CreatePublisherFunc: func(ctx context.Context, name string) (sqlc.Publisher, error) {
+// 	               panic("mock out the CreatePublisher method")
+//             },
+//             CreateRepresentationFunc: func(ctx context.Context, args sqlc.CreateRepresentationParams) (sqlc.Representation, error) {
+// 	               panic("mock out the CreateRepresentation method")
+//             },
+//             CreateSeriesFunc: func(ctx context.Context, title string) (sqlc.Series, error) {
+// 	               panic("mock out the CreateSeries method")
+//             },
+//             CreateSubmissionFunc: func(ctx context.Context, args sqlc.CreateSubmissionParams) (sqlc.Submission, error) {
+// 	               panic("mock out the CreateSubmission method")
+//             },
+//             CreateWebhookFunc: func(ctx context.Context, args sqlc.CreateWebhookParams) (sqlc.Webhook, error) {
+// 	               panic("mock out the CreateWebhook method")
+//             },
+//             DeleteAgencyFunc: func(ctx context.Context, id int64) (sqlc.Agency, error) {
+// 	               panic("mock out the DeleteAgency method")
+//             },
+//             DeleteDealFunc: func(ctx context.Context, id int64) (sqlc.Deal, error) {
+// 	               panic("mock out the DeleteDeal method")
+//             },
+//             DeleteEditionFunc: func(ctx context.Context, id int64) (sqlc.Edition, error) {
+// 	               panic("mock out the DeleteEdition method")
+//             },
+//             DeleteGenreFunc: func(ctx context.Context, id int64) (sqlc.Genre, error) {
+// 	               panic("mock out the DeleteGenre method")
+//             },
+//             DeletePublisherFunc: func(ctx context.Context, id int64) (sqlc.Publisher, error) {
+// 	               panic("mock out the DeletePublisher method")
+//             },
+//             DeleteRoyaltyRateFunc: func(ctx context.Context, args sqlc.DeleteRoyaltyRateParams) (sqlc.RoyaltyRate, error) {
+// 	               panic("mock out the DeleteRoyaltyRate method")
 //             },
-//             DeleteAgentFunc: func(ctx context.Context, id int64) (sqlc.Agent, error) {
-// 	               panic("mock out the DeleteAgent method")
+//             DeleteSalesFunc: func(ctx context.Context, id int64) (sqlc.Sale, error) {
+// 	               panic("mock out the DeleteSales method")
 //             },
-//             DeleteAuthorFunc: func(ctx context.Context, id int64) (sqlc.Author, error) {
-// 	               panic("mock out the DeleteAuthor method")
+//             DeleteWebhookFunc: func(ctx context.Context, id int64) (sqlc.Webhook, error) {
+// 	               panic("mock out the DeleteWebhook method")
 //             },
-//             DeleteBookFunc: func(ctx context.Context, id int64) (sqlc.Book, error) {
-// 	               panic("mock out the DeleteBook method")
+//             FailWebhookDeliveryFunc: func(ctx context.Context, args sqlc.FailWebhookDeliveryParams) error {
+// 	               panic("mock out the FailWebhookDelivery method")
+//             },
+//             GetAgencyFunc: func(ctx context.Context, id int64) (sqlc.Agency, error) {
+// 	               panic("mock out the GetAgency method")
 //             },
 //             GetAgentFunc: func(ctx context.Context, id int64) (sqlc.Agent, error) {
 // 	               panic("mock out the GetAgent method")
 //             },
-//             GetAuthorFunc: func(ctx context.Context, id int64) (sqlc.Author, error) {
+//             GetAuthorFunc: func(ctx context.Context, id int64) (sqlc.GetAuthorRow, error) {
 // 	               panic("mock out the GetAuthor method")
 //             },
 //             GetBookFunc: func(ctx context.Context, id int64) (sqlc.Book, error) {
 // 	               panic("mock out the GetBook method")
 //             },
+//             GetBookByISBNFunc: func(ctx context.Context, isbn string) (sqlc.Book, error) {
+// 	               panic("mock out the GetBookByISBN method")
+//             },
+//             GetDealFunc: func(ctx context.Context, id int64) (sqlc.Deal, error) {
+// 	               panic("mock out the GetDeal method")
+//             },
+//             GetEditionFunc: func(ctx context.Context, id int64) (sqlc.Edition, error) {
+// 	               panic("mock out the GetEdition method")
+//             },
+//             GetEditionByISBNFunc: func(ctx context.Context, isbn string) (sqlc.Edition, error) {
+// 	               panic("mock out the GetEditionByISBN method")
+//             },
+//             GetGenreFunc: func(ctx context.Context, id int64) (sqlc.Genre, error) {
+// 	               panic("mock out the GetGenre method")
+//             },
+//             GetPublisherFunc: func(ctx context.Context, id int64) (sqlc.Publisher, error) {
+// 	               panic("mock out the GetPublisher method")
+//             },
+//             GetRepresentationFunc: func(ctx context.Context, id int64) (sqlc.Representation, error) {
+// 	               panic("mock out the GetRepresentation method")
+//             },
+//             GetSeriesFunc: func(ctx context.Context, id int64) (sqlc.Series, error) {
+// 	               panic("mock out the GetSeries method")
+//             },
+//             GetSubmissionFunc: func(ctx context.Context, id int64) (sqlc.Submission, error) {
+// 	               panic("mock out the GetSubmission method")
+//             },
+//             GetWebhookFunc: func(ctx context.Context, id int64) (sqlc.Webhook, error) {
+// 	               panic("mock out the GetWebhook method")
+//             },
+//             ListAgenciesFunc: func(ctx context.Context) ([]sqlc.Agency, error) {
+// 	               panic("mock out the ListAgencies method")
+//             },
 //             ListAgentsFunc: func(ctx context.Context) ([]sqlc.Agent, error) {
 // 	               panic("mock out the ListAgents method")
 //             },
-//             ListAuthorsFunc: func(ctx context.Context) ([]sqlc.Author, error) {
+//             ListAgentsByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]sqlc.Agent, error) {
+// 	               panic("mock out the ListAgentsByAgencyID method")
+//             },
+//             ListAuthorsFunc: func(ctx context.Context) ([]sqlc.ListAuthorsRow, error) {
 // 	               panic("mock out the ListAuthors method")
 //             },
-//             ListAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]sqlc.Author, error) {
+//             ListAuthorsByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]sqlc.ListAuthorsByAgencyIDRow, error) {
+// 	               panic("mock out the ListAuthorsByAgencyID method")
+//             },
+//             ListAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]sqlc.ListAuthorsByAgentIDRow, error) {
 // 	               panic("mock out the ListAuthorsByAgentID method")
 //             },
-//             ListAuthorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]sqlc.Author, error) {
+//             ListAuthorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]sqlc.ListAuthorsByBookIDRow, error) {
 // 	               panic("mock out the ListAuthorsByBookID method")
 //             },
+//             ListAvailableRightsFunc: func(ctx context.Context, args sqlc.ListAvailableRightsParams) ([]sqlc.ListAvailableRightsRow, error) {
+// 	               panic("mock out the ListAvailableRights method")
+//             },
 //             ListBooksFunc: func(ctx context.Context) ([]sqlc.Book, error) {
 // 	               panic("mock out the ListBooks method")
 //             },
+//             ListBooksByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]sqlc.Book, error) {
+// 	               panic("mock out the ListBooksByAgencyID method")
+//             },
 //             ListBooksByAuthorIDFunc: func(ctx context.Context, authorID int64) ([]sqlc.Book, error) {
 // 	               panic("mock out the ListBooksByAuthorID method")
 //             },
+//             ListBooksByGenreIDFunc: func(ctx context.Context, genreID int64) ([]sqlc.Book, error) {
+// 	               panic("mock out the ListBooksByGenreID method")
+//             },
+//             ListBooksByPublisherIDFunc: func(ctx context.Context, publisherID int64) ([]sqlc.Book, error) {
+// 	               panic("mock out the ListBooksByPublisherID method")
+//             },
+//             ListBooksBySeriesIDFunc: func(ctx context.Context, seriesID int64) ([]sqlc.Book, error) {
+// 	               panic("mock out the ListBooksBySeriesID method")
+//             },
+//             ListBooksByTagFunc: func(ctx context.Context, tag string) ([]sqlc.Book, error) {
+// 	               panic("mock out the ListBooksByTag method")
+//             },
+//             ListBooksInGenreTreeFunc: func(ctx context.Context, genreID int64) ([]sqlc.Book, error) {
+// 	               panic("mock out the ListBooksInGenreTree method")
+//             },
+//             ListContributorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]sqlc.BookAuthor, error) {
+// 	               panic("mock out the ListContributorsByBookID method")
+//             },
+//             ListDealsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]sqlc.Deal, error) {
+// 	               panic("mock out the ListDealsByAgentID method")
+//             },
+//             ListDealsByBookIDFunc: func(ctx context.Context, bookID int64) ([]sqlc.Deal, error) {
+// 	               panic("mock out the ListDealsByBookID method")
+//             },
+//             ListEditionsFunc: func(ctx context.Context, args sqlc.ListEditionsParams) ([]sqlc.Edition, error) {
+// 	               panic("mock out the ListEditions method")
+//             },
+//             ListEditionsByBookIDFunc: func(ctx context.Context, bookID int64) ([]sqlc.Edition, error) {
+// 	               panic("mock out the ListEditionsByBookID method")
+//             },
+//             ListFormerAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]sqlc.ListFormerAuthorsByAgentIDRow, error) {
+// 	               panic("mock out the ListFormerAuthorsByAgentID method")
+//             },
+//             ListGenresFunc: func(ctx context.Context) ([]sqlc.Genre, error) {
+// 	               panic("mock out the ListGenres method")
+//             },
+//             ListGenresByBookIDFunc: func(ctx context.Context, bookID int64) ([]sqlc.Genre, error) {
+// 	               panic("mock out the ListGenresByBookID method")
+//             },
+//             ListGenresByParentIDFunc: func(ctx context.Context, parentID int64) ([]sqlc.Genre, error) {
+// 	               panic("mock out the ListGenresByParentID method")
+//             },
+//             ListOrphanBooksFunc: func(ctx context.Context) ([]sqlc.Book, error) {
+// 	               panic("mock out the ListOrphanBooks method")
+//             },
+//             ListPublishersFunc: func(ctx context.Context) ([]sqlc.Publisher, error) {
+// 	               panic("mock out the ListPublishers method")
+//             },
+//             ListRepresentationsByAuthorIDFunc: func(ctx context.Context, authorID int64) ([]sqlc.Representation, error) {
+// 	               panic("mock out the ListRepresentationsByAuthorID method")
+//             },
+//             ListRoyaltyRatesByDealIDFunc: func(ctx context.Context, dealID int64) ([]sqlc.RoyaltyRate, error) {
+// 	               panic("mock out the ListRoyaltyRatesByDealID method")
+//             },
+//             ListRoyaltySalesFunc: func(ctx context.Context, args sqlc.ListRoyaltySalesParams) ([]sqlc.ListRoyaltySalesRow, error) {
+// 	               panic("mock out the ListRoyaltySales method")
+//             },
+//             ListSalesByDealIDFunc: func(ctx context.Context, dealID int64) ([]sqlc.Sale, error) {
+// 	               panic("mock out the ListSalesByDealID method")
+//             },
+//             ListSeriesFunc: func(ctx context.Context) ([]sqlc.Series, error) {
+// 	               panic("mock out the ListSeries method")
+//             },
+//             ListSubmissionTransitionsFunc: func(ctx context.Context, submissionID int64) ([]sqlc.SubmissionTransition, error) {
+// 	               panic("mock out the ListSubmissionTransitions method")
+//             },
+//             ListSubmissionsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]sqlc.Submission, error) {
+// 	               panic("mock out the ListSubmissionsByAgentID method")
+//             },
+//             ListSubmissionsByAgentIDAndStatusFunc: func(ctx context.Context, args sqlc.ListSubmissionsByAgentIDAndStatusParams) ([]sqlc.Submission, error) {
+// 	               panic("mock out the ListSubmissionsByAgentIDAndStatus method")
+//             },
+//             ListTagsByBookIDFunc: func(ctx context.Context, bookID int64) ([]string, error) {
+// 	               panic("mock out the ListTagsByBookID method")
+//             },
+//             ListWebhookDeliveriesFunc: func(ctx context.Context, webhookID int64) ([]sqlc.WebhookDelivery, error) {
+// 	               panic("mock out the ListWebhookDeliveries method")
+//             },
+//             ListWebhookDeliveriesByStatusFunc: func(ctx context.Context, args sqlc.ListWebhookDeliveriesByStatusParams) ([]sqlc.WebhookDelivery, error) {
+// 	               panic("mock out the ListWebhookDeliveriesByStatus method")
+//             },
+//             ListWebhooksFunc: func(ctx context.Context) ([]sqlc.Webhook, error) {
+// 	               panic("mock out the ListWebhooks method")
+//             },
+//             RecordSalesFunc: func(ctx context.Context, args sqlc.RecordSalesParams) (sqlc.Sale, error) {
+// 	               panic("mock out the RecordSales method")
+//             },
+//             RetryWebhookDeliveryFunc: func(ctx context.Context, id int64) (sqlc.WebhookDelivery, error) {
+// 	               panic("mock out the RetryWebhookDelivery method")
+//             },
+//             SetRoyaltyRateFunc: func(ctx context.Context, args sqlc.SetRoyaltyRateParams) (sqlc.RoyaltyRate, error) {
+// 	               panic("mock out the SetRoyaltyRate method")
+//             },
+//             UpdateAgencyFunc: func(ctx context.Context, args sqlc.UpdateAgencyParams) (sqlc.Agency, error) {
+// 	               panic("mock out the UpdateAgency method")
+//             },
 //             UpdateAgentFunc: func(ctx context.Context, args sqlc.UpdateAgentParams) (sqlc.Agent, error) {
 // 	               panic("mock out the UpdateAgent method")
 //             },
-//             UpdateAuthorFunc: func(ctx context.Context, args sqlc.UpdateAuthorParams) (sqlc.Author, error) {
-// 	               panic("mock out the UpdateAuthor method")
+//             UpdateDealFunc: func(ctx context.Context, args sqlc.UpdateDealParams) (sqlc.Deal, error) {
+// 	               panic("mock out the UpdateDeal method")
+//             },
+//             UpdateEditionFunc: func(ctx context.Context, args sqlc.UpdateEditionParams) (sqlc.Edition, error) {
+// 	               panic("mock out the UpdateEdition method")
+//             },
+//             UpdatePublisherFunc: func(ctx context.Context, args sqlc.UpdatePublisherParams) (sqlc.Publisher, error) {
+// 	               panic("mock out the UpdatePublisher method")
+//             },
+//             UpdateSeriesFunc: func(ctx context.Context, args sqlc.UpdateSeriesParams) (sqlc.Series, error) {
+// 	               panic("mock out the UpdateSeries method")
+//             },
+//             UpdateWebhookFunc: func(ctx context.Context, args sqlc.UpdateWebhookParams) (sqlc.Webhook, error) {
+// 	               panic("mock out the UpdateWebhook method")
 //             },
 //         }
 //
@@ -94,56 +366,281 @@ var _ postgres.Querent = &QuerentMock{}
 //
 //     }
 type QuerentMock struct {
+	// ClaimWebhookDeliveriesFunc mocks the ClaimWebhookDeliveries method.
+	ClaimWebhookDeliveriesFunc func(ctx context.Context, args sqlc.ClaimWebhookDeliveriesParams) ([]sqlc.ClaimWebhookDeliveriesRow, error)
+
+	// CompleteWebhookDeliveryFunc mocks the CompleteWebhookDelivery method.
+	CompleteWebhookDeliveryFunc func(ctx context.Context, args sqlc.CompleteWebhookDeliveryParams) error
+
+	// CreateAgencyFunc mocks the CreateAgency method.
+	CreateAgencyFunc func(ctx context.Context, name string) (sqlc.Agency, error)
+
 	// CreateAgentFunc mocks the CreateAgent method.
 	CreateAgentFunc func(ctx context.Context, args sqlc.CreateAgentParams) (sqlc.Agent, error)
 
-	// CreateAuthorFunc mocks the CreateAuthor method.
-	CreateAuthorFunc func(ctx context.Context, args sqlc.CreateAuthorParams) (sqlc.Author, error)
+	// CreateDealFunc mocks the CreateDeal method.
+	CreateDealFunc func(ctx context.Context, args sqlc.CreateDealParams) (sqlc.Deal, error)
+
+	// CreateEditionFunc mocks the CreateEdition method.
+	CreateEditionFunc func(ctx context.Context, args sqlc.CreateEditionParams) (sqlc.Edition, error)
+
+	// CreateGenreFunc mocks the CreateGenre method.
+	CreateGenreFunc func(ctx context.Context, args sqlc.CreateGenreParams) (sqlc.Genre, error)
+
+	// CreatePublisherFunc mocks the CreatePublisher method.
+	CreatePublisherFunc func(ctx context.Context, name string) (sqlc.Publisher, error)
+
+	// CreateRepresentationFunc mocks the CreateRepresentation method.
+	CreateRepresentationFunc func(ctx context.Context, args sqlc.CreateRepresentationParams) (sqlc.Representation, error)
+
+	// CreateSeriesFunc mocks the CreateSeries method.
+	CreateSeriesFunc func(ctx context.Context, title string) (sqlc.Series, error)
+
+	// CreateSubmissionFunc mocks the CreateSubmission method.
+	CreateSubmissionFunc func(ctx context.Context, args sqlc.CreateSubmissionParams) (sqlc.Submission, error)
+
+	// CreateWebhookFunc mocks the CreateWebhook method.
+	CreateWebhookFunc func(ctx context.Context, args sqlc.CreateWebhookParams) (sqlc.Webhook, error)
+
+	// DeleteAgencyFunc mocks the DeleteAgency method.
+	DeleteAgencyFunc func(ctx context.Context, id int64) (sqlc.Agency, error)
+
+	// DeleteDealFunc mocks the DeleteDeal method.
+	DeleteDealFunc func(ctx context.Context, id int64) (sqlc.Deal, error)
 
-	// DeleteAgentFunc mocks the DeleteAgent method.
-	DeleteAgentFunc func(ctx context.Context, id int64) (sqlc.Agent, error)
+	// DeleteEditionFunc mocks the DeleteEdition method.
+	DeleteEditionFunc func(ctx context.Context, id int64) (sqlc.Edition, error)
 
-	// DeleteAuthorFunc mocks the DeleteAuthor method.
-	DeleteAuthorFunc func(ctx context.Context, id int64) (sqlc.Author, error)
+	// DeleteGenreFunc mocks the DeleteGenre method.
+	DeleteGenreFunc func(ctx context.Context, id int64) (sqlc.Genre, error)
 
-	// DeleteBookFunc mocks the DeleteBook method.
-	DeleteBookFunc func(ctx context.Context, id int64) (sqlc.Book, error)
+	// DeletePublisherFunc mocks the DeletePublisher method.
+	DeletePublisherFunc func(ctx context.Context, id int64) (sqlc.Publisher, error)
+
+	// DeleteRoyaltyRateFunc mocks the DeleteRoyaltyRate method.
+	DeleteRoyaltyRateFunc func(ctx context.Context, args sqlc.DeleteRoyaltyRateParams) (sqlc.RoyaltyRate, error)
+
+	// DeleteSalesFunc mocks the DeleteSales method.
+	DeleteSalesFunc func(ctx context.Context, id int64) (sqlc.Sale, error)
+
+	// DeleteWebhookFunc mocks the DeleteWebhook method.
+	DeleteWebhookFunc func(ctx context.Context, id int64) (sqlc.Webhook, error)
+
+	// FailWebhookDeliveryFunc mocks the FailWebhookDelivery method.
+	FailWebhookDeliveryFunc func(ctx context.Context, args sqlc.FailWebhookDeliveryParams) error
+
+	// GetAgencyFunc mocks the GetAgency method.
+	GetAgencyFunc func(ctx context.Context, id int64) (sqlc.Agency, error)
 
 	// GetAgentFunc mocks the GetAgent method.
 	GetAgentFunc func(ctx context.Context, id int64) (sqlc.Agent, error)
 
 	// GetAuthorFunc mocks the GetAuthor method.
-	GetAuthorFunc func(ctx context.Context, id int64) (sqlc.Author, error)
+	GetAuthorFunc func(ctx context.Context, id int64) (sqlc.GetAuthorRow, error)
 
 	// GetBookFunc mocks the GetBook method.
 	GetBookFunc func(ctx context.Context, id int64) (sqlc.Book, error)
 
+	// GetBookByISBNFunc mocks the GetBookByISBN method.
+	GetBookByISBNFunc func(ctx context.Context, isbn string) (sqlc.Book, error)
+
+	// GetDealFunc mocks the GetDeal method.
+	GetDealFunc func(ctx context.Context, id int64) (sqlc.Deal, error)
+
+	// GetEditionFunc mocks the GetEdition method.
+	GetEditionFunc func(ctx context.Context, id int64) (sqlc.Edition, error)
+
+	// GetEditionByISBNFunc mocks the GetEditionByISBN method.
+	GetEditionByISBNFunc func(ctx context.Context, isbn string) (sqlc.Edition, error)
+
+	// GetGenreFunc mocks the GetGenre method.
+	GetGenreFunc func(ctx context.Context, id int64) (sqlc.Genre, error)
+
+	// GetPublisherFunc mocks the GetPublisher method.
+	GetPublisherFunc func(ctx context.Context, id int64) (sqlc.Publisher, error)
+
+	// GetRepresentationFunc mocks the GetRepresentation method.
+	GetRepresentationFunc func(ctx context.Context, id int64) (sqlc.Representation, error)
+
+	// GetSeriesFunc mocks the GetSeries method.
+	GetSeriesFunc func(ctx context.Context, id int64) (sqlc.Series, error)
+
+	// GetSubmissionFunc mocks the GetSubmission method.
+	GetSubmissionFunc func(ctx context.Context, id int64) (sqlc.Submission, error)
+
+	// GetWebhookFunc mocks the GetWebhook method.
+	GetWebhookFunc func(ctx context.Context, id int64) (sqlc.Webhook, error)
+
+	// ListAgenciesFunc mocks the ListAgencies method.
+	ListAgenciesFunc func(ctx context.Context) ([]sqlc.Agency, error)
+
 	// ListAgentsFunc mocks the ListAgents method.
 	ListAgentsFunc func(ctx context.Context) ([]sqlc.Agent, error)
 
+	// ListAgentsByAgencyIDFunc mocks the ListAgentsByAgencyID method.
+	ListAgentsByAgencyIDFunc func(ctx context.Context, agencyID int64) ([]sqlc.Agent, error)
+
 	// ListAuthorsFunc mocks the ListAuthors method.
-	ListAuthorsFunc func(ctx context.Context) ([]sqlc.Author, error)
+	ListAuthorsFunc func(ctx context.Context) ([]sqlc.ListAuthorsRow, error)
+
+	// ListAuthorsByAgencyIDFunc mocks the ListAuthorsByAgencyID method.
+	ListAuthorsByAgencyIDFunc func(ctx context.Context, agencyID int64) ([]sqlc.ListAuthorsByAgencyIDRow, error)
 
 	// ListAuthorsByAgentIDFunc mocks the ListAuthorsByAgentID method.
-	ListAuthorsByAgentIDFunc func(ctx context.Context, agentID int64) ([]sqlc.Author, error)
+	ListAuthorsByAgentIDFunc func(ctx context.Context, agentID int64) ([]sqlc.ListAuthorsByAgentIDRow, error)
 
 	// ListAuthorsByBookIDFunc mocks the ListAuthorsByBookID method.
-	ListAuthorsByBookIDFunc func(ctx context.Context, bookID int64) ([]sqlc.Author, error)
+	ListAuthorsByBookIDFunc func(ctx context.Context, bookID int64) ([]sqlc.ListAuthorsByBookIDRow, error)
+
+	// ListAvailableRightsFunc mocks the ListAvailableRights method.
+	ListAvailableRightsFunc func(ctx context.Context, args sqlc.ListAvailableRightsParams) ([]sqlc.ListAvailableRightsRow, error)
 
 	// ListBooksFunc mocks the ListBooks method.
 	ListBooksFunc func(ctx context.Context) ([]sqlc.Book, error)
 
+	// ListBooksByAgencyIDFunc mocks the ListBooksByAgencyID method.
+	ListBooksByAgencyIDFunc func(ctx context.Context, agencyID int64) ([]sqlc.Book, error)
+
 	// ListBooksByAuthorIDFunc mocks the ListBooksByAuthorID method.
 	ListBooksByAuthorIDFunc func(ctx context.Context, authorID int64) ([]sqlc.Book, error)
 
+	// ListBooksByGenreIDFunc mocks the ListBooksByGenreID method.
+	ListBooksByGenreIDFunc func(ctx context.Context, genreID int64) ([]sqlc.Book, error)
+
+	// ListBooksByPublisherIDFunc mocks the ListBooksByPublisherID method.
+	ListBooksByPublisherIDFunc func(ctx context.Context, publisherID int64) ([]sqlc.Book, error)
+
+	// ListBooksBySeriesIDFunc mocks the ListBooksBySeriesID method.
+	ListBooksBySeriesIDFunc func(ctx context.Context, seriesID int64) ([]sqlc.Book, error)
+
+	// ListBooksByTagFunc mocks the ListBooksByTag method.
+	ListBooksByTagFunc func(ctx context.Context, tag string) ([]sqlc.Book, error)
+
+	// ListBooksInGenreTreeFunc mocks the ListBooksInGenreTree method.
+	ListBooksInGenreTreeFunc func(ctx context.Context, genreID int64) ([]sqlc.Book, error)
+
+	// ListContributorsByBookIDFunc mocks the ListContributorsByBookID method.
+	ListContributorsByBookIDFunc func(ctx context.Context, bookID int64) ([]sqlc.BookAuthor, error)
+
+	// ListDealsByAgentIDFunc mocks the ListDealsByAgentID method.
+	ListDealsByAgentIDFunc func(ctx context.Context, agentID int64) ([]sqlc.Deal, error)
+
+	// ListDealsByBookIDFunc mocks the ListDealsByBookID method.
+	ListDealsByBookIDFunc func(ctx context.Context, bookID int64) ([]sqlc.Deal, error)
+
+	// ListEditionsFunc mocks the ListEditions method.
+	ListEditionsFunc func(ctx context.Context, args sqlc.ListEditionsParams) ([]sqlc.Edition, error)
+
+	// ListEditionsByBookIDFunc mocks the ListEditionsByBookID method.
+	ListEditionsByBookIDFunc func(ctx context.Context, bookID int64) ([]sqlc.Edition, error)
+
+	// ListFormerAuthorsByAgentIDFunc mocks the ListFormerAuthorsByAgentID method.
+	ListFormerAuthorsByAgentIDFunc func(ctx context.Context, agentID int64) ([]sqlc.ListFormerAuthorsByAgentIDRow, error)
+
+	// ListGenresFunc mocks the ListGenres method.
+	ListGenresFunc func(ctx context.Context) ([]sqlc.Genre, error)
+
+	// ListGenresByBookIDFunc mocks the ListGenresByBookID method.
+	ListGenresByBookIDFunc func(ctx context.Context, bookID int64) ([]sqlc.Genre, error)
+
+	// ListGenresByParentIDFunc mocks the ListGenresByParentID method.
+	ListGenresByParentIDFunc func(ctx context.Context, parentID int64) ([]sqlc.Genre, error)
+
+	// ListOrphanBooksFunc mocks the ListOrphanBooks method.
+	ListOrphanBooksFunc func(ctx context.Context) ([]sqlc.Book, error)
+
+	// ListPublishersFunc mocks the ListPublishers method.
+	ListPublishersFunc func(ctx context.Context) ([]sqlc.Publisher, error)
+
+	// ListRepresentationsByAuthorIDFunc mocks the ListRepresentationsByAuthorID method.
+	ListRepresentationsByAuthorIDFunc func(ctx context.Context, authorID int64) ([]sqlc.Representation, error)
+
+	// ListRoyaltyRatesByDealIDFunc mocks the ListRoyaltyRatesByDealID method.
+	ListRoyaltyRatesByDealIDFunc func(ctx context.Context, dealID int64) ([]sqlc.RoyaltyRate, error)
+
+	// ListRoyaltySalesFunc mocks the ListRoyaltySales method.
+	ListRoyaltySalesFunc func(ctx context.Context, args sqlc.ListRoyaltySalesParams) ([]sqlc.ListRoyaltySalesRow, error)
+
+	// ListSalesByDealIDFunc mocks the ListSalesByDealID method.
+	ListSalesByDealIDFunc func(ctx context.Context, dealID int64) ([]sqlc.Sale, error)
+
+	// ListSeriesFunc mocks the ListSeries method.
+	ListSeriesFunc func(ctx context.Context) ([]sqlc.Series, error)
+
+	// ListSubmissionTransitionsFunc mocks the ListSubmissionTransitions method.
+	ListSubmissionTransitionsFunc func(ctx context.Context, submissionID int64) ([]sqlc.SubmissionTransition, error)
+
+	// ListSubmissionsByAgentIDFunc mocks the ListSubmissionsByAgentID method.
+	ListSubmissionsByAgentIDFunc func(ctx context.Context, agentID int64) ([]sqlc.Submission, error)
+
+	// ListSubmissionsByAgentIDAndStatusFunc mocks the ListSubmissionsByAgentIDAndStatus method.
+	ListSubmissionsByAgentIDAndStatusFunc func(ctx context.Context, args sqlc.ListSubmissionsByAgentIDAndStatusParams) ([]sqlc.Submission, error)
+
+	// ListTagsByBookIDFunc mocks the ListTagsByBookID method.
+	ListTagsByBookIDFunc func(ctx context.Context, bookID int64) ([]string, error)
+
+	// ListWebhookDeliveriesFunc mocks the ListWebhookDeliveries method.
+	ListWebhookDeliveriesFunc func(ctx context.Context, webhookID int64) ([]sqlc.WebhookDelivery, error)
+
+	// ListWebhookDeliveriesByStatusFunc mocks the ListWebhookDeliveriesByStatus method.
+	ListWebhookDeliveriesByStatusFunc func(ctx context.Context, args sqlc.ListWebhookDeliveriesByStatusParams) ([]sqlc.WebhookDelivery, error)
+
+	// ListWebhooksFunc mocks the ListWebhooks method.
+	ListWebhooksFunc func(ctx context.Context) ([]sqlc.Webhook, error)
+
+	// RecordSalesFunc mocks the RecordSales method.
+	RecordSalesFunc func(ctx context.Context, args sqlc.RecordSalesParams) (sqlc.Sale, error)
+
+	// RetryWebhookDeliveryFunc mocks the RetryWebhookDelivery method.
+	RetryWebhookDeliveryFunc func(ctx context.Context, id int64) (sqlc.WebhookDelivery, error)
+
+	// SetRoyaltyRateFunc mocks the SetRoyaltyRate method.
+	SetRoyaltyRateFunc func(ctx context.Context, args sqlc.SetRoyaltyRateParams) (sqlc.RoyaltyRate, error)
+
+	// UpdateAgencyFunc mocks the UpdateAgency method.
+	UpdateAgencyFunc func(ctx context.Context, args sqlc.UpdateAgencyParams) (sqlc.Agency, error)
+
 	// UpdateAgentFunc mocks the UpdateAgent method.
 	UpdateAgentFunc func(ctx context.Context, args sqlc.UpdateAgentParams) (sqlc.Agent, error)
 
-	// UpdateAuthorFunc mocks the UpdateAuthor method.
-	UpdateAuthorFunc func(ctx context.Context, args sqlc.UpdateAuthorParams) (sqlc.Author, error)
+	// UpdateDealFunc mocks the UpdateDeal method.
+	UpdateDealFunc func(ctx context.Context, args sqlc.UpdateDealParams) (sqlc.Deal, error)
+
+	// UpdateEditionFunc mocks the UpdateEdition method.
+	UpdateEditionFunc func(ctx context.Context, args sqlc.UpdateEditionParams) (sqlc.Edition, error)
+
+	// UpdatePublisherFunc mocks the UpdatePublisher method.
+	UpdatePublisherFunc func(ctx context.Context, args sqlc.UpdatePublisherParams) (sqlc.Publisher, error)
+
+	// UpdateSeriesFunc mocks the UpdateSeries method.
+	UpdateSeriesFunc func(ctx context.Context, args sqlc.UpdateSeriesParams) (sqlc.Series, error)
+
+	// UpdateWebhookFunc mocks the UpdateWebhook method.
+	UpdateWebhookFunc func(ctx context.Context, args sqlc.UpdateWebhookParams) (sqlc.Webhook, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ClaimWebhookDeliveries holds details about calls to the ClaimWebhookDeliveries method.
+		ClaimWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.ClaimWebhookDeliveriesParams
+		}
+		// CompleteWebhookDelivery holds details about calls to the CompleteWebhookDelivery method.
+		CompleteWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.CompleteWebhookDeliveryParams
+		}
+		// CreateAgency holds details about calls to the CreateAgency method.
+		CreateAgency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
 		// CreateAgent holds details about calls to the CreateAgent method.
 		CreateAgent []struct {
 			// Ctx is the ctx argument value.
@@ -151,29 +648,127 @@ type QuerentMock struct {
 			// Args is the args argument value.
 			Args sqlc.CreateAgentParams
 		}
-		// CreateAuthor holds details about calls to the CreateAuthor method.
-		CreateAuthor []struct {
+		// CreateDeal holds details about calls to the CreateDeal method.
+		CreateDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.CreateDealParams
+		}
+		// CreateEdition holds details about calls to the CreateEdition method.
+		CreateEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.CreateEditionParams
+		}
+		// CreateGenre holds details about calls to the CreateGenre method.
+		CreateGenre []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.CreateGenreParams
+		}
+		// CreatePublisher holds details about calls to the CreatePublisher method.
+		CreatePublisher []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+		}
+		// CreateRepresentation holds details about calls to the CreateRepresentation method.
+		CreateRepresentation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.CreateRepresentationParams
+		}
+		// CreateSeries holds details about calls to the CreateSeries method.
+		CreateSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Title is the title argument value.
+			Title string
+		}
+		// CreateSubmission holds details about calls to the CreateSubmission method.
+		CreateSubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.CreateSubmissionParams
+		}
+		// CreateWebhook holds details about calls to the CreateWebhook method.
+		CreateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.CreateWebhookParams
+		}
+		// DeleteAgency holds details about calls to the DeleteAgency method.
+		DeleteAgency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteDeal holds details about calls to the DeleteDeal method.
+		DeleteDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteEdition holds details about calls to the DeleteEdition method.
+		DeleteEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteGenre holds details about calls to the DeleteGenre method.
+		DeleteGenre []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeletePublisher holds details about calls to the DeletePublisher method.
+		DeletePublisher []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteRoyaltyRate holds details about calls to the DeleteRoyaltyRate method.
+		DeleteRoyaltyRate []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Args is the args argument value.
-			Args sqlc.CreateAuthorParams
+			Args sqlc.DeleteRoyaltyRateParams
 		}
-		// DeleteAgent holds details about calls to the DeleteAgent method.
-		DeleteAgent []struct {
+		// DeleteSales holds details about calls to the DeleteSales method.
+		DeleteSales []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int64
 		}
-		// DeleteAuthor holds details about calls to the DeleteAuthor method.
-		DeleteAuthor []struct {
+		// DeleteWebhook holds details about calls to the DeleteWebhook method.
+		DeleteWebhook []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
 			ID int64
 		}
-		// DeleteBook holds details about calls to the DeleteBook method.
-		DeleteBook []struct {
+		// FailWebhookDelivery holds details about calls to the FailWebhookDelivery method.
+		FailWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.FailWebhookDeliveryParams
+		}
+		// GetAgency holds details about calls to the GetAgency method.
+		GetAgency []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
@@ -200,308 +795,2997 @@ type QuerentMock struct {
 			// ID is the id argument value.
 			ID int64
 		}
-		// ListAgents holds details about calls to the ListAgents method.
-		ListAgents []struct {
+		// GetBookByISBN holds details about calls to the GetBookByISBN method.
+		GetBookByISBN []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// Isbn is the isbn argument value.
+			Isbn string
 		}
-		// ListAuthors holds details about calls to the ListAuthors method.
-		ListAuthors []struct {
+		// GetDeal holds details about calls to the GetDeal method.
+		GetDeal []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
 		}
-		// ListAuthorsByAgentID holds details about calls to the ListAuthorsByAgentID method.
-		ListAuthorsByAgentID []struct {
+		// GetEdition holds details about calls to the GetEdition method.
+		GetEdition []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// AgentID is the agentID argument value.
-			AgentID int64
+			// ID is the id argument value.
+			ID int64
 		}
-		// ListAuthorsByBookID holds details about calls to the ListAuthorsByBookID method.
-		ListAuthorsByBookID []struct {
+		// GetEditionByISBN holds details about calls to the GetEditionByISBN method.
+		GetEditionByISBN []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// BookID is the bookID argument value.
-			BookID int64
+			// Isbn is the isbn argument value.
+			Isbn string
 		}
-		// ListBooks holds details about calls to the ListBooks method.
-		ListBooks []struct {
+		// GetGenre holds details about calls to the GetGenre method.
+		GetGenre []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
 		}
-		// ListBooksByAuthorID holds details about calls to the ListBooksByAuthorID method.
-		ListBooksByAuthorID []struct {
+		// GetPublisher holds details about calls to the GetPublisher method.
+		GetPublisher []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// AuthorID is the authorID argument value.
-			AuthorID int64
+			// ID is the id argument value.
+			ID int64
 		}
-		// UpdateAgent holds details about calls to the UpdateAgent method.
-		UpdateAgent []struct {
+		// GetRepresentation holds details about calls to the GetRepresentation method.
+		GetRepresentation []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Args is the args argument value.
-			Args sqlc.UpdateAgentParams
+			// ID is the id argument value.
+			ID int64
 		}
-		// UpdateAuthor holds details about calls to the UpdateAuthor method.
-		UpdateAuthor []struct {
+		// GetSeries holds details about calls to the GetSeries method.
+		GetSeries []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// Args is the args argument value.
-			Args sqlc.UpdateAuthorParams
+			// ID is the id argument value.
+			ID int64
 		}
-	}
-}
-
-// CreateAgent calls CreateAgentFunc.
-func (mock *QuerentMock) CreateAgent(ctx context.Context, args sqlc.CreateAgentParams) (sqlc.Agent, error) {
-	if mock.CreateAgentFunc == nil {
-		panic("QuerentMock.CreateAgentFunc: method is nil but Querent.CreateAgent was just called")
-	}
-	callInfo := struct {
-		Ctx  context.Context
-		Args sqlc.CreateAgentParams
-	}{
-		Ctx:  ctx,
-		Args: args,
-	}
+		// GetSubmission holds details about calls to the GetSubmission method.
+		GetSubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetWebhook holds details about calls to the GetWebhook method.
+		GetWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// ListAgencies holds details about calls to the ListAgencies method.
+		ListAgencies []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListAgents holds details about calls to the ListAgents method.
+		ListAgents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListAgentsByAgencyID holds details about calls to the ListAgentsByAgencyID method.
+		ListAgentsByAgencyID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgencyID is the agencyID argument value.
+			AgencyID int64
+		}
+		// ListAuthors holds details about calls to the ListAuthors method.
+		ListAuthors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListAuthorsByAgencyID holds details about calls to the ListAuthorsByAgencyID method.
+		ListAuthorsByAgencyID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgencyID is the agencyID argument value.
+			AgencyID int64
+		}
+		// ListAuthorsByAgentID holds details about calls to the ListAuthorsByAgentID method.
+		ListAuthorsByAgentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// ListAuthorsByBookID holds details about calls to the ListAuthorsByBookID method.
+		ListAuthorsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListAvailableRights holds details about calls to the ListAvailableRights method.
+		ListAvailableRights []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.ListAvailableRightsParams
+		}
+		// ListBooks holds details about calls to the ListBooks method.
+		ListBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListBooksByAgencyID holds details about calls to the ListBooksByAgencyID method.
+		ListBooksByAgencyID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgencyID is the agencyID argument value.
+			AgencyID int64
+		}
+		// ListBooksByAuthorID holds details about calls to the ListBooksByAuthorID method.
+		ListBooksByAuthorID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AuthorID is the authorID argument value.
+			AuthorID int64
+		}
+		// ListBooksByGenreID holds details about calls to the ListBooksByGenreID method.
+		ListBooksByGenreID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GenreID is the genreID argument value.
+			GenreID int64
+		}
+		// ListBooksByPublisherID holds details about calls to the ListBooksByPublisherID method.
+		ListBooksByPublisherID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PublisherID is the publisherID argument value.
+			PublisherID int64
+		}
+		// ListBooksBySeriesID holds details about calls to the ListBooksBySeriesID method.
+		ListBooksBySeriesID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SeriesID is the seriesID argument value.
+			SeriesID int64
+		}
+		// ListBooksByTag holds details about calls to the ListBooksByTag method.
+		ListBooksByTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Tag is the tag argument value.
+			Tag string
+		}
+		// ListBooksInGenreTree holds details about calls to the ListBooksInGenreTree method.
+		ListBooksInGenreTree []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GenreID is the genreID argument value.
+			GenreID int64
+		}
+		// ListContributorsByBookID holds details about calls to the ListContributorsByBookID method.
+		ListContributorsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListDealsByAgentID holds details about calls to the ListDealsByAgentID method.
+		ListDealsByAgentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// ListDealsByBookID holds details about calls to the ListDealsByBookID method.
+		ListDealsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListEditions holds details about calls to the ListEditions method.
+		ListEditions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.ListEditionsParams
+		}
+		// ListEditionsByBookID holds details about calls to the ListEditionsByBookID method.
+		ListEditionsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListFormerAuthorsByAgentID holds details about calls to the ListFormerAuthorsByAgentID method.
+		ListFormerAuthorsByAgentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// ListGenres holds details about calls to the ListGenres method.
+		ListGenres []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListGenresByBookID holds details about calls to the ListGenresByBookID method.
+		ListGenresByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListGenresByParentID holds details about calls to the ListGenresByParentID method.
+		ListGenresByParentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ParentID is the parentID argument value.
+			ParentID int64
+		}
+		// ListOrphanBooks holds details about calls to the ListOrphanBooks method.
+		ListOrphanBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListPublishers holds details about calls to the ListPublishers method.
+		ListPublishers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListRepresentationsByAuthorID holds details about calls to the ListRepresentationsByAuthorID method.
+		ListRepresentationsByAuthorID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AuthorID is the authorID argument value.
+			AuthorID int64
+		}
+		// ListRoyaltyRatesByDealID holds details about calls to the ListRoyaltyRatesByDealID method.
+		ListRoyaltyRatesByDealID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DealID is the dealID argument value.
+			DealID int64
+		}
+		// ListRoyaltySales holds details about calls to the ListRoyaltySales method.
+		ListRoyaltySales []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.ListRoyaltySalesParams
+		}
+		// ListSalesByDealID holds details about calls to the ListSalesByDealID method.
+		ListSalesByDealID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DealID is the dealID argument value.
+			DealID int64
+		}
+		// ListSeries holds details about calls to the ListSeries method.
+		ListSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListSubmissionTransitions holds details about calls to the ListSubmissionTransitions method.
+		ListSubmissionTransitions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubmissionID is the submissionID argument value.
+			SubmissionID int64
+		}
+		// ListSubmissionsByAgentID holds details about calls to the ListSubmissionsByAgentID method.
+		ListSubmissionsByAgentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// ListSubmissionsByAgentIDAndStatus holds details about calls to the ListSubmissionsByAgentIDAndStatus method.
+		ListSubmissionsByAgentIDAndStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.ListSubmissionsByAgentIDAndStatusParams
+		}
+		// ListTagsByBookID holds details about calls to the ListTagsByBookID method.
+		ListTagsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListWebhookDeliveries holds details about calls to the ListWebhookDeliveries method.
+		ListWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID int64
+		}
+		// ListWebhookDeliveriesByStatus holds details about calls to the ListWebhookDeliveriesByStatus method.
+		ListWebhookDeliveriesByStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.ListWebhookDeliveriesByStatusParams
+		}
+		// ListWebhooks holds details about calls to the ListWebhooks method.
+		ListWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RecordSales holds details about calls to the RecordSales method.
+		RecordSales []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.RecordSalesParams
+		}
+		// RetryWebhookDelivery holds details about calls to the RetryWebhookDelivery method.
+		RetryWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// SetRoyaltyRate holds details about calls to the SetRoyaltyRate method.
+		SetRoyaltyRate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.SetRoyaltyRateParams
+		}
+		// UpdateAgency holds details about calls to the UpdateAgency method.
+		UpdateAgency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.UpdateAgencyParams
+		}
+		// UpdateAgent holds details about calls to the UpdateAgent method.
+		UpdateAgent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.UpdateAgentParams
+		}
+		// UpdateDeal holds details about calls to the UpdateDeal method.
+		UpdateDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.UpdateDealParams
+		}
+		// UpdateEdition holds details about calls to the UpdateEdition method.
+		UpdateEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.UpdateEditionParams
+		}
+		// UpdatePublisher holds details about calls to the UpdatePublisher method.
+		UpdatePublisher []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.UpdatePublisherParams
+		}
+		// UpdateSeries holds details about calls to the UpdateSeries method.
+		UpdateSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.UpdateSeriesParams
+		}
+		// UpdateWebhook holds details about calls to the UpdateWebhook method.
+		UpdateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.UpdateWebhookParams
+		}
+	}
+}
+
+// ClaimWebhookDeliveries calls ClaimWebhookDeliveriesFunc.
+func (mock *QuerentMock) ClaimWebhookDeliveries(ctx context.Context, args sqlc.ClaimWebhookDeliveriesParams) ([]sqlc.ClaimWebhookDeliveriesRow, error) {
+	if mock.ClaimWebhookDeliveriesFunc == nil {
+		panic("QuerentMock.ClaimWebhookDeliveriesFunc: method is nil but Querent.ClaimWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.ClaimWebhookDeliveriesParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockClaimWebhookDeliveries.Lock()
+	mock.calls.ClaimWebhookDeliveries = append(mock.calls.ClaimWebhookDeliveries, callInfo)
+	lockQuerentMockClaimWebhookDeliveries.Unlock()
+	return mock.ClaimWebhookDeliveriesFunc(ctx, args)
+}
+
+// ClaimWebhookDeliveriesCalls gets all the calls that were made to ClaimWebhookDeliveries.
+// Check the length with:
+//     len(mockedQuerent.ClaimWebhookDeliveriesCalls())
+func (mock *QuerentMock) ClaimWebhookDeliveriesCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.ClaimWebhookDeliveriesParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.ClaimWebhookDeliveriesParams
+	}
+	lockQuerentMockClaimWebhookDeliveries.RLock()
+	calls = mock.calls.ClaimWebhookDeliveries
+	lockQuerentMockClaimWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// CompleteWebhookDelivery calls CompleteWebhookDeliveryFunc.
+func (mock *QuerentMock) CompleteWebhookDelivery(ctx context.Context, args sqlc.CompleteWebhookDeliveryParams) error {
+	if mock.CompleteWebhookDeliveryFunc == nil {
+		panic("QuerentMock.CompleteWebhookDeliveryFunc: method is nil but Querent.CompleteWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.CompleteWebhookDeliveryParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockCompleteWebhookDelivery.Lock()
+	mock.calls.CompleteWebhookDelivery = append(mock.calls.CompleteWebhookDelivery, callInfo)
+	lockQuerentMockCompleteWebhookDelivery.Unlock()
+	return mock.CompleteWebhookDeliveryFunc(ctx, args)
+}
+
+// CompleteWebhookDeliveryCalls gets all the calls that were made to CompleteWebhookDelivery.
+// Check the length with:
+//     len(mockedQuerent.CompleteWebhookDeliveryCalls())
+func (mock *QuerentMock) CompleteWebhookDeliveryCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.CompleteWebhookDeliveryParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.CompleteWebhookDeliveryParams
+	}
+	lockQuerentMockCompleteWebhookDelivery.RLock()
+	calls = mock.calls.CompleteWebhookDelivery
+	lockQuerentMockCompleteWebhookDelivery.RUnlock()
+	return calls
+}
+
+// CreateAgency calls CreateAgencyFunc.
+func (mock *QuerentMock) CreateAgency(ctx context.Context, name string) (sqlc.Agency, error) {
+	if mock.CreateAgencyFunc == nil {
+		panic("QuerentMock.CreateAgencyFunc: method is nil but Querent.CreateAgency was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	lockQuerentMockCreateAgency.Lock()
+	mock.calls.CreateAgency = append(mock.calls.CreateAgency, callInfo)
+	lockQuerentMockCreateAgency.Unlock()
+	return mock.CreateAgencyFunc(ctx, name)
+}
+
+// CreateAgencyCalls gets all the calls that were made to CreateAgency.
+// Check the length with:
+//     len(mockedQuerent.CreateAgencyCalls())
+func (mock *QuerentMock) CreateAgencyCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	lockQuerentMockCreateAgency.RLock()
+	calls = mock.calls.CreateAgency
+	lockQuerentMockCreateAgency.RUnlock()
+	return calls
+}
+
+// CreateAgent calls CreateAgentFunc.
+func (mock *QuerentMock) CreateAgent(ctx context.Context, args sqlc.CreateAgentParams) (sqlc.Agent, error) {
+	if mock.CreateAgentFunc == nil {
+		panic("QuerentMock.CreateAgentFunc: method is nil but Querent.CreateAgent was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.CreateAgentParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
 	lockQuerentMockCreateAgent.Lock()
 	mock.calls.CreateAgent = append(mock.calls.CreateAgent, callInfo)
 	lockQuerentMockCreateAgent.Unlock()
 	return mock.CreateAgentFunc(ctx, args)
 }
 
-// CreateAgentCalls gets all the calls that were made to CreateAgent.
+// CreateAgentCalls gets all the calls that were made to CreateAgent.
+// Check the length with:
+//     len(mockedQuerent.CreateAgentCalls())
+func (mock *QuerentMock) CreateAgentCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.CreateAgentParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.CreateAgentParams
+	}
+	lockQuerentMockCreateAgent.RLock()
+	calls = mock.calls.CreateAgent
+	lockQuerentMockCreateAgent.RUnlock()
+	return calls
+}
+
+// CreateDeal calls CreateDealFunc.
+func (mock *QuerentMock) CreateDeal(ctx context.Context, args sqlc.CreateDealParams) (sqlc.Deal, error) {
+	if mock.CreateDealFunc == nil {
+		panic("QuerentMock.CreateDealFunc: method is nil but Querent.CreateDeal was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.CreateDealParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockCreateDeal.Lock()
+	mock.calls.CreateDeal = append(mock.calls.CreateDeal, callInfo)
+	lockQuerentMockCreateDeal.Unlock()
+	return mock.CreateDealFunc(ctx, args)
+}
+
+// CreateDealCalls gets all the calls that were made to CreateDeal.
+// Check the length with:
+//     len(mockedQuerent.CreateDealCalls())
+func (mock *QuerentMock) CreateDealCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.CreateDealParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.CreateDealParams
+	}
+	lockQuerentMockCreateDeal.RLock()
+	calls = mock.calls.CreateDeal
+	lockQuerentMockCreateDeal.RUnlock()
+	return calls
+}
+
+// CreateEdition calls CreateEditionFunc.
+func (mock *QuerentMock) CreateEdition(ctx context.Context, args sqlc.CreateEditionParams) (sqlc.Edition, error) {
+	if mock.CreateEditionFunc == nil {
+		panic("QuerentMock.CreateEditionFunc: method is nil but Querent.CreateEdition was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.CreateEditionParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockCreateEdition.Lock()
+	mock.calls.CreateEdition = append(mock.calls.CreateEdition, callInfo)
+	lockQuerentMockCreateEdition.Unlock()
+	return mock.CreateEditionFunc(ctx, args)
+}
+
+// CreateEditionCalls gets all the calls that were made to CreateEdition.
+// Check the length with:
+//     len(mockedQuerent.CreateEditionCalls())
+func (mock *QuerentMock) CreateEditionCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.CreateEditionParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.CreateEditionParams
+	}
+	lockQuerentMockCreateEdition.RLock()
+	calls = mock.calls.CreateEdition
+	lockQuerentMockCreateEdition.RUnlock()
+	return calls
+}
+
+// CreateGenre calls CreateGenreFunc.
+func (mock *QuerentMock) CreateGenre(ctx context.Context, args sqlc.CreateGenreParams) (sqlc.Genre, error) {
+	if mock.CreateGenreFunc == nil {
+		panic("QuerentMock.CreateGenreFunc: method is nil but Querent.CreateGenre was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.CreateGenreParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockCreateGenre.Lock()
+	mock.calls.CreateGenre = append(mock.calls.CreateGenre, callInfo)
+	lockQuerentMockCreateGenre.Unlock()
+	return mock.CreateGenreFunc(ctx, args)
+}
+
+// CreateGenreCalls gets all the calls that were made to CreateGenre.
+// Check the length with:
+//     len(mockedQuerent.CreateGenreCalls())
+func (mock *QuerentMock) CreateGenreCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.CreateGenreParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.CreateGenreParams
+	}
+	lockQuerentMockCreateGenre.RLock()
+	calls = mock.calls.CreateGenre
+	lockQuerentMockCreateGenre.RUnlock()
+	return calls
+}
+
+// CreatePublisher calls CreatePublisherFunc.
+func (mock *QuerentMock) CreatePublisher(ctx context.Context, name string) (sqlc.Publisher, error) {
+	if mock.CreatePublisherFunc == nil {
+		panic("QuerentMock.CreatePublisherFunc: method is nil but Querent.CreatePublisher was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Name string
+	}{
+		Ctx:  ctx,
+		Name: name,
+	}
+	lockQuerentMockCreatePublisher.Lock()
+	mock.calls.CreatePublisher = append(mock.calls.CreatePublisher, callInfo)
+	lockQuerentMockCreatePublisher.Unlock()
+	return mock.CreatePublisherFunc(ctx, name)
+}
+
+// CreatePublisherCalls gets all the calls that were made to CreatePublisher.
+// Check the length with:
+//     len(mockedQuerent.CreatePublisherCalls())
+func (mock *QuerentMock) CreatePublisherCalls() []struct {
+	Ctx  context.Context
+	Name string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Name string
+	}
+	lockQuerentMockCreatePublisher.RLock()
+	calls = mock.calls.CreatePublisher
+	lockQuerentMockCreatePublisher.RUnlock()
+	return calls
+}
+
+// CreateRepresentation calls CreateRepresentationFunc.
+func (mock *QuerentMock) CreateRepresentation(ctx context.Context, args sqlc.CreateRepresentationParams) (sqlc.Representation, error) {
+	if mock.CreateRepresentationFunc == nil {
+		panic("QuerentMock.CreateRepresentationFunc: method is nil but Querent.CreateRepresentation was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.CreateRepresentationParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockCreateRepresentation.Lock()
+	mock.calls.CreateRepresentation = append(mock.calls.CreateRepresentation, callInfo)
+	lockQuerentMockCreateRepresentation.Unlock()
+	return mock.CreateRepresentationFunc(ctx, args)
+}
+
+// CreateRepresentationCalls gets all the calls that were made to CreateRepresentation.
+// Check the length with:
+//     len(mockedQuerent.CreateRepresentationCalls())
+func (mock *QuerentMock) CreateRepresentationCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.CreateRepresentationParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.CreateRepresentationParams
+	}
+	lockQuerentMockCreateRepresentation.RLock()
+	calls = mock.calls.CreateRepresentation
+	lockQuerentMockCreateRepresentation.RUnlock()
+	return calls
+}
+
+// CreateSeries calls CreateSeriesFunc.
+func (mock *QuerentMock) CreateSeries(ctx context.Context, title string) (sqlc.Series, error) {
+	if mock.CreateSeriesFunc == nil {
+		panic("QuerentMock.CreateSeriesFunc: method is nil but Querent.CreateSeries was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Title string
+	}{
+		Ctx:   ctx,
+		Title: title,
+	}
+	lockQuerentMockCreateSeries.Lock()
+	mock.calls.CreateSeries = append(mock.calls.CreateSeries, callInfo)
+	lockQuerentMockCreateSeries.Unlock()
+	return mock.CreateSeriesFunc(ctx, title)
+}
+
+// CreateSeriesCalls gets all the calls that were made to CreateSeries.
+// Check the length with:
+//     len(mockedQuerent.CreateSeriesCalls())
+func (mock *QuerentMock) CreateSeriesCalls() []struct {
+	Ctx   context.Context
+	Title string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Title string
+	}
+	lockQuerentMockCreateSeries.RLock()
+	calls = mock.calls.CreateSeries
+	lockQuerentMockCreateSeries.RUnlock()
+	return calls
+}
+
+// CreateSubmission calls CreateSubmissionFunc.
+func (mock *QuerentMock) CreateSubmission(ctx context.Context, args sqlc.CreateSubmissionParams) (sqlc.Submission, error) {
+	if mock.CreateSubmissionFunc == nil {
+		panic("QuerentMock.CreateSubmissionFunc: method is nil but Querent.CreateSubmission was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.CreateSubmissionParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockCreateSubmission.Lock()
+	mock.calls.CreateSubmission = append(mock.calls.CreateSubmission, callInfo)
+	lockQuerentMockCreateSubmission.Unlock()
+	return mock.CreateSubmissionFunc(ctx, args)
+}
+
+// CreateSubmissionCalls gets all the calls that were made to CreateSubmission.
+// Check the length with:
+//     len(mockedQuerent.CreateSubmissionCalls())
+func (mock *QuerentMock) CreateSubmissionCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.CreateSubmissionParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.CreateSubmissionParams
+	}
+	lockQuerentMockCreateSubmission.RLock()
+	calls = mock.calls.CreateSubmission
+	lockQuerentMockCreateSubmission.RUnlock()
+	return calls
+}
+
+// CreateWebhook calls CreateWebhookFunc.
+func (mock *QuerentMock) CreateWebhook(ctx context.Context, args sqlc.CreateWebhookParams) (sqlc.Webhook, error) {
+	if mock.CreateWebhookFunc == nil {
+		panic("QuerentMock.CreateWebhookFunc: method is nil but Querent.CreateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.CreateWebhookParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockCreateWebhook.Lock()
+	mock.calls.CreateWebhook = append(mock.calls.CreateWebhook, callInfo)
+	lockQuerentMockCreateWebhook.Unlock()
+	return mock.CreateWebhookFunc(ctx, args)
+}
+
+// CreateWebhookCalls gets all the calls that were made to CreateWebhook.
+// Check the length with:
+//     len(mockedQuerent.CreateWebhookCalls())
+func (mock *QuerentMock) CreateWebhookCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.CreateWebhookParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.CreateWebhookParams
+	}
+	lockQuerentMockCreateWebhook.RLock()
+	calls = mock.calls.CreateWebhook
+	lockQuerentMockCreateWebhook.RUnlock()
+	return calls
+}
+
+// DeleteAgency calls DeleteAgencyFunc.
+func (mock *QuerentMock) DeleteAgency(ctx context.Context, id int64) (sqlc.Agency, error) {
+	if mock.DeleteAgencyFunc == nil {
+		panic("QuerentMock.DeleteAgencyFunc: method is nil but Querent.DeleteAgency was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockDeleteAgency.Lock()
+	mock.calls.DeleteAgency = append(mock.calls.DeleteAgency, callInfo)
+	lockQuerentMockDeleteAgency.Unlock()
+	return mock.DeleteAgencyFunc(ctx, id)
+}
+
+// DeleteAgencyCalls gets all the calls that were made to DeleteAgency.
+// Check the length with:
+//     len(mockedQuerent.DeleteAgencyCalls())
+func (mock *QuerentMock) DeleteAgencyCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockDeleteAgency.RLock()
+	calls = mock.calls.DeleteAgency
+	lockQuerentMockDeleteAgency.RUnlock()
+	return calls
+}
+
+// DeleteDeal calls DeleteDealFunc.
+func (mock *QuerentMock) DeleteDeal(ctx context.Context, id int64) (sqlc.Deal, error) {
+	if mock.DeleteDealFunc == nil {
+		panic("QuerentMock.DeleteDealFunc: method is nil but Querent.DeleteDeal was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockDeleteDeal.Lock()
+	mock.calls.DeleteDeal = append(mock.calls.DeleteDeal, callInfo)
+	lockQuerentMockDeleteDeal.Unlock()
+	return mock.DeleteDealFunc(ctx, id)
+}
+
+// DeleteDealCalls gets all the calls that were made to DeleteDeal.
+// Check the length with:
+//     len(mockedQuerent.DeleteDealCalls())
+func (mock *QuerentMock) DeleteDealCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockDeleteDeal.RLock()
+	calls = mock.calls.DeleteDeal
+	lockQuerentMockDeleteDeal.RUnlock()
+	return calls
+}
+
+// DeleteEdition calls DeleteEditionFunc.
+func (mock *QuerentMock) DeleteEdition(ctx context.Context, id int64) (sqlc.Edition, error) {
+	if mock.DeleteEditionFunc == nil {
+		panic("QuerentMock.DeleteEditionFunc: method is nil but Querent.DeleteEdition was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockDeleteEdition.Lock()
+	mock.calls.DeleteEdition = append(mock.calls.DeleteEdition, callInfo)
+	lockQuerentMockDeleteEdition.Unlock()
+	return mock.DeleteEditionFunc(ctx, id)
+}
+
+// DeleteEditionCalls gets all the calls that were made to DeleteEdition.
+// Check the length with:
+//     len(mockedQuerent.DeleteEditionCalls())
+func (mock *QuerentMock) DeleteEditionCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockDeleteEdition.RLock()
+	calls = mock.calls.DeleteEdition
+	lockQuerentMockDeleteEdition.RUnlock()
+	return calls
+}
+
+// DeleteGenre calls DeleteGenreFunc.
+func (mock *QuerentMock) DeleteGenre(ctx context.Context, id int64) (sqlc.Genre, error) {
+	if mock.DeleteGenreFunc == nil {
+		panic("QuerentMock.DeleteGenreFunc: method is nil but Querent.DeleteGenre was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockDeleteGenre.Lock()
+	mock.calls.DeleteGenre = append(mock.calls.DeleteGenre, callInfo)
+	lockQuerentMockDeleteGenre.Unlock()
+	return mock.DeleteGenreFunc(ctx, id)
+}
+
+// DeleteGenreCalls gets all the calls that were made to DeleteGenre.
+// Check the length with:
+//     len(mockedQuerent.DeleteGenreCalls())
+func (mock *QuerentMock) DeleteGenreCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockDeleteGenre.RLock()
+	calls = mock.calls.DeleteGenre
+	lockQuerentMockDeleteGenre.RUnlock()
+	return calls
+}
+
+// DeletePublisher calls DeletePublisherFunc.
+func (mock *QuerentMock) DeletePublisher(ctx context.Context, id int64) (sqlc.Publisher, error) {
+	if mock.DeletePublisherFunc == nil {
+		panic("QuerentMock.DeletePublisherFunc: method is nil but Querent.DeletePublisher was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockDeletePublisher.Lock()
+	mock.calls.DeletePublisher = append(mock.calls.DeletePublisher, callInfo)
+	lockQuerentMockDeletePublisher.Unlock()
+	return mock.DeletePublisherFunc(ctx, id)
+}
+
+// DeletePublisherCalls gets all the calls that were made to DeletePublisher.
+// Check the length with:
+//     len(mockedQuerent.DeletePublisherCalls())
+func (mock *QuerentMock) DeletePublisherCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockDeletePublisher.RLock()
+	calls = mock.calls.DeletePublisher
+	lockQuerentMockDeletePublisher.RUnlock()
+	return calls
+}
+
+// DeleteRoyaltyRate calls DeleteRoyaltyRateFunc.
+func (mock *QuerentMock) DeleteRoyaltyRate(ctx context.Context, args sqlc.DeleteRoyaltyRateParams) (sqlc.RoyaltyRate, error) {
+	if mock.DeleteRoyaltyRateFunc == nil {
+		panic("QuerentMock.DeleteRoyaltyRateFunc: method is nil but Querent.DeleteRoyaltyRate was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.DeleteRoyaltyRateParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockDeleteRoyaltyRate.Lock()
+	mock.calls.DeleteRoyaltyRate = append(mock.calls.DeleteRoyaltyRate, callInfo)
+	lockQuerentMockDeleteRoyaltyRate.Unlock()
+	return mock.DeleteRoyaltyRateFunc(ctx, args)
+}
+
+// DeleteRoyaltyRateCalls gets all the calls that were made to DeleteRoyaltyRate.
+// Check the length with:
+//     len(mockedQuerent.DeleteRoyaltyRateCalls())
+func (mock *QuerentMock) DeleteRoyaltyRateCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.DeleteRoyaltyRateParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.DeleteRoyaltyRateParams
+	}
+	lockQuerentMockDeleteRoyaltyRate.RLock()
+	calls = mock.calls.DeleteRoyaltyRate
+	lockQuerentMockDeleteRoyaltyRate.RUnlock()
+	return calls
+}
+
+// DeleteSales calls DeleteSalesFunc.
+func (mock *QuerentMock) DeleteSales(ctx context.Context, id int64) (sqlc.Sale, error) {
+	if mock.DeleteSalesFunc == nil {
+		panic("QuerentMock.DeleteSalesFunc: method is nil but Querent.DeleteSales was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockDeleteSales.Lock()
+	mock.calls.DeleteSales = append(mock.calls.DeleteSales, callInfo)
+	lockQuerentMockDeleteSales.Unlock()
+	return mock.DeleteSalesFunc(ctx, id)
+}
+
+// DeleteSalesCalls gets all the calls that were made to DeleteSales.
+// Check the length with:
+//     len(mockedQuerent.DeleteSalesCalls())
+func (mock *QuerentMock) DeleteSalesCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockDeleteSales.RLock()
+	calls = mock.calls.DeleteSales
+	lockQuerentMockDeleteSales.RUnlock()
+	return calls
+}
+
+// DeleteWebhook calls DeleteWebhookFunc.
+func (mock *QuerentMock) DeleteWebhook(ctx context.Context, id int64) (sqlc.Webhook, error) {
+	if mock.DeleteWebhookFunc == nil {
+		panic("QuerentMock.DeleteWebhookFunc: method is nil but Querent.DeleteWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockDeleteWebhook.Lock()
+	mock.calls.DeleteWebhook = append(mock.calls.DeleteWebhook, callInfo)
+	lockQuerentMockDeleteWebhook.Unlock()
+	return mock.DeleteWebhookFunc(ctx, id)
+}
+
+// DeleteWebhookCalls gets all the calls that were made to DeleteWebhook.
+// Check the length with:
+//     len(mockedQuerent.DeleteWebhookCalls())
+func (mock *QuerentMock) DeleteWebhookCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockDeleteWebhook.RLock()
+	calls = mock.calls.DeleteWebhook
+	lockQuerentMockDeleteWebhook.RUnlock()
+	return calls
+}
+
+// FailWebhookDelivery calls FailWebhookDeliveryFunc.
+func (mock *QuerentMock) FailWebhookDelivery(ctx context.Context, args sqlc.FailWebhookDeliveryParams) error {
+	if mock.FailWebhookDeliveryFunc == nil {
+		panic("QuerentMock.FailWebhookDeliveryFunc: method is nil but Querent.FailWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.FailWebhookDeliveryParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockFailWebhookDelivery.Lock()
+	mock.calls.FailWebhookDelivery = append(mock.calls.FailWebhookDelivery, callInfo)
+	lockQuerentMockFailWebhookDelivery.Unlock()
+	return mock.FailWebhookDeliveryFunc(ctx, args)
+}
+
+// FailWebhookDeliveryCalls gets all the calls that were made to FailWebhookDelivery.
+// Check the length with:
+//     len(mockedQuerent.FailWebhookDeliveryCalls())
+func (mock *QuerentMock) FailWebhookDeliveryCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.FailWebhookDeliveryParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.FailWebhookDeliveryParams
+	}
+	lockQuerentMockFailWebhookDelivery.RLock()
+	calls = mock.calls.FailWebhookDelivery
+	lockQuerentMockFailWebhookDelivery.RUnlock()
+	return calls
+}
+
+// GetAgency calls GetAgencyFunc.
+func (mock *QuerentMock) GetAgency(ctx context.Context, id int64) (sqlc.Agency, error) {
+	if mock.GetAgencyFunc == nil {
+		panic("QuerentMock.GetAgencyFunc: method is nil but Querent.GetAgency was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetAgency.Lock()
+	mock.calls.GetAgency = append(mock.calls.GetAgency, callInfo)
+	lockQuerentMockGetAgency.Unlock()
+	return mock.GetAgencyFunc(ctx, id)
+}
+
+// GetAgencyCalls gets all the calls that were made to GetAgency.
+// Check the length with:
+//     len(mockedQuerent.GetAgencyCalls())
+func (mock *QuerentMock) GetAgencyCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetAgency.RLock()
+	calls = mock.calls.GetAgency
+	lockQuerentMockGetAgency.RUnlock()
+	return calls
+}
+
+// GetAgent calls GetAgentFunc.
+func (mock *QuerentMock) GetAgent(ctx context.Context, id int64) (sqlc.Agent, error) {
+	if mock.GetAgentFunc == nil {
+		panic("QuerentMock.GetAgentFunc: method is nil but Querent.GetAgent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetAgent.Lock()
+	mock.calls.GetAgent = append(mock.calls.GetAgent, callInfo)
+	lockQuerentMockGetAgent.Unlock()
+	return mock.GetAgentFunc(ctx, id)
+}
+
+// GetAgentCalls gets all the calls that were made to GetAgent.
+// Check the length with:
+//     len(mockedQuerent.GetAgentCalls())
+func (mock *QuerentMock) GetAgentCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetAgent.RLock()
+	calls = mock.calls.GetAgent
+	lockQuerentMockGetAgent.RUnlock()
+	return calls
+}
+
+// GetAuthor calls GetAuthorFunc.
+func (mock *QuerentMock) GetAuthor(ctx context.Context, id int64) (sqlc.GetAuthorRow, error) {
+	if mock.GetAuthorFunc == nil {
+		panic("QuerentMock.GetAuthorFunc: method is nil but Querent.GetAuthor was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetAuthor.Lock()
+	mock.calls.GetAuthor = append(mock.calls.GetAuthor, callInfo)
+	lockQuerentMockGetAuthor.Unlock()
+	return mock.GetAuthorFunc(ctx, id)
+}
+
+// GetAuthorCalls gets all the calls that were made to GetAuthor.
+// Check the length with:
+//     len(mockedQuerent.GetAuthorCalls())
+func (mock *QuerentMock) GetAuthorCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetAuthor.RLock()
+	calls = mock.calls.GetAuthor
+	lockQuerentMockGetAuthor.RUnlock()
+	return calls
+}
+
+// GetBook calls GetBookFunc.
+func (mock *QuerentMock) GetBook(ctx context.Context, id int64) (sqlc.Book, error) {
+	if mock.GetBookFunc == nil {
+		panic("QuerentMock.GetBookFunc: method is nil but Querent.GetBook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetBook.Lock()
+	mock.calls.GetBook = append(mock.calls.GetBook, callInfo)
+	lockQuerentMockGetBook.Unlock()
+	return mock.GetBookFunc(ctx, id)
+}
+
+// GetBookCalls gets all the calls that were made to GetBook.
+// Check the length with:
+//     len(mockedQuerent.GetBookCalls())
+func (mock *QuerentMock) GetBookCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetBook.RLock()
+	calls = mock.calls.GetBook
+	lockQuerentMockGetBook.RUnlock()
+	return calls
+}
+
+// GetBookByISBN calls GetBookByISBNFunc.
+func (mock *QuerentMock) GetBookByISBN(ctx context.Context, isbn string) (sqlc.Book, error) {
+	if mock.GetBookByISBNFunc == nil {
+		panic("QuerentMock.GetBookByISBNFunc: method is nil but Querent.GetBookByISBN was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Isbn string
+	}{
+		Ctx:  ctx,
+		Isbn: isbn,
+	}
+	lockQuerentMockGetBookByISBN.Lock()
+	mock.calls.GetBookByISBN = append(mock.calls.GetBookByISBN, callInfo)
+	lockQuerentMockGetBookByISBN.Unlock()
+	return mock.GetBookByISBNFunc(ctx, isbn)
+}
+
+// GetBookByISBNCalls gets all the calls that were made to GetBookByISBN.
+// Check the length with:
+//     len(mockedQuerent.GetBookByISBNCalls())
+func (mock *QuerentMock) GetBookByISBNCalls() []struct {
+	Ctx  context.Context
+	Isbn string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Isbn string
+	}
+	lockQuerentMockGetBookByISBN.RLock()
+	calls = mock.calls.GetBookByISBN
+	lockQuerentMockGetBookByISBN.RUnlock()
+	return calls
+}
+
+// GetDeal calls GetDealFunc.
+func (mock *QuerentMock) GetDeal(ctx context.Context, id int64) (sqlc.Deal, error) {
+	if mock.GetDealFunc == nil {
+		panic("QuerentMock.GetDealFunc: method is nil but Querent.GetDeal was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetDeal.Lock()
+	mock.calls.GetDeal = append(mock.calls.GetDeal, callInfo)
+	lockQuerentMockGetDeal.Unlock()
+	return mock.GetDealFunc(ctx, id)
+}
+
+// GetDealCalls gets all the calls that were made to GetDeal.
+// Check the length with:
+//     len(mockedQuerent.GetDealCalls())
+func (mock *QuerentMock) GetDealCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetDeal.RLock()
+	calls = mock.calls.GetDeal
+	lockQuerentMockGetDeal.RUnlock()
+	return calls
+}
+
+// GetEdition calls GetEditionFunc.
+func (mock *QuerentMock) GetEdition(ctx context.Context, id int64) (sqlc.Edition, error) {
+	if mock.GetEditionFunc == nil {
+		panic("QuerentMock.GetEditionFunc: method is nil but Querent.GetEdition was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetEdition.Lock()
+	mock.calls.GetEdition = append(mock.calls.GetEdition, callInfo)
+	lockQuerentMockGetEdition.Unlock()
+	return mock.GetEditionFunc(ctx, id)
+}
+
+// GetEditionCalls gets all the calls that were made to GetEdition.
+// Check the length with:
+//     len(mockedQuerent.GetEditionCalls())
+func (mock *QuerentMock) GetEditionCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetEdition.RLock()
+	calls = mock.calls.GetEdition
+	lockQuerentMockGetEdition.RUnlock()
+	return calls
+}
+
+// GetEditionByISBN calls GetEditionByISBNFunc.
+func (mock *QuerentMock) GetEditionByISBN(ctx context.Context, isbn string) (sqlc.Edition, error) {
+	if mock.GetEditionByISBNFunc == nil {
+		panic("QuerentMock.GetEditionByISBNFunc: method is nil but Querent.GetEditionByISBN was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Isbn string
+	}{
+		Ctx:  ctx,
+		Isbn: isbn,
+	}
+	lockQuerentMockGetEditionByISBN.Lock()
+	mock.calls.GetEditionByISBN = append(mock.calls.GetEditionByISBN, callInfo)
+	lockQuerentMockGetEditionByISBN.Unlock()
+	return mock.GetEditionByISBNFunc(ctx, isbn)
+}
+
+// GetEditionByISBNCalls gets all the calls that were made to GetEditionByISBN.
+// Check the length with:
+//     len(mockedQuerent.GetEditionByISBNCalls())
+func (mock *QuerentMock) GetEditionByISBNCalls() []struct {
+	Ctx  context.Context
+	Isbn string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Isbn string
+	}
+	lockQuerentMockGetEditionByISBN.RLock()
+	calls = mock.calls.GetEditionByISBN
+	lockQuerentMockGetEditionByISBN.RUnlock()
+	return calls
+}
+
+// GetGenre calls GetGenreFunc.
+func (mock *QuerentMock) GetGenre(ctx context.Context, id int64) (sqlc.Genre, error) {
+	if mock.GetGenreFunc == nil {
+		panic("QuerentMock.GetGenreFunc: method is nil but Querent.GetGenre was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetGenre.Lock()
+	mock.calls.GetGenre = append(mock.calls.GetGenre, callInfo)
+	lockQuerentMockGetGenre.Unlock()
+	return mock.GetGenreFunc(ctx, id)
+}
+
+// GetGenreCalls gets all the calls that were made to GetGenre.
+// Check the length with:
+//     len(mockedQuerent.GetGenreCalls())
+func (mock *QuerentMock) GetGenreCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetGenre.RLock()
+	calls = mock.calls.GetGenre
+	lockQuerentMockGetGenre.RUnlock()
+	return calls
+}
+
+// GetPublisher calls GetPublisherFunc.
+func (mock *QuerentMock) GetPublisher(ctx context.Context, id int64) (sqlc.Publisher, error) {
+	if mock.GetPublisherFunc == nil {
+		panic("QuerentMock.GetPublisherFunc: method is nil but Querent.GetPublisher was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetPublisher.Lock()
+	mock.calls.GetPublisher = append(mock.calls.GetPublisher, callInfo)
+	lockQuerentMockGetPublisher.Unlock()
+	return mock.GetPublisherFunc(ctx, id)
+}
+
+// GetPublisherCalls gets all the calls that were made to GetPublisher.
+// Check the length with:
+//     len(mockedQuerent.GetPublisherCalls())
+func (mock *QuerentMock) GetPublisherCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetPublisher.RLock()
+	calls = mock.calls.GetPublisher
+	lockQuerentMockGetPublisher.RUnlock()
+	return calls
+}
+
+// GetRepresentation calls GetRepresentationFunc.
+func (mock *QuerentMock) GetRepresentation(ctx context.Context, id int64) (sqlc.Representation, error) {
+	if mock.GetRepresentationFunc == nil {
+		panic("QuerentMock.GetRepresentationFunc: method is nil but Querent.GetRepresentation was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetRepresentation.Lock()
+	mock.calls.GetRepresentation = append(mock.calls.GetRepresentation, callInfo)
+	lockQuerentMockGetRepresentation.Unlock()
+	return mock.GetRepresentationFunc(ctx, id)
+}
+
+// GetRepresentationCalls gets all the calls that were made to GetRepresentation.
+// Check the length with:
+//     len(mockedQuerent.GetRepresentationCalls())
+func (mock *QuerentMock) GetRepresentationCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetRepresentation.RLock()
+	calls = mock.calls.GetRepresentation
+	lockQuerentMockGetRepresentation.RUnlock()
+	return calls
+}
+
+// GetSeries calls GetSeriesFunc.
+func (mock *QuerentMock) GetSeries(ctx context.Context, id int64) (sqlc.Series, error) {
+	if mock.GetSeriesFunc == nil {
+		panic("QuerentMock.GetSeriesFunc: method is nil but Querent.GetSeries was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetSeries.Lock()
+	mock.calls.GetSeries = append(mock.calls.GetSeries, callInfo)
+	lockQuerentMockGetSeries.Unlock()
+	return mock.GetSeriesFunc(ctx, id)
+}
+
+// GetSeriesCalls gets all the calls that were made to GetSeries.
+// Check the length with:
+//     len(mockedQuerent.GetSeriesCalls())
+func (mock *QuerentMock) GetSeriesCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetSeries.RLock()
+	calls = mock.calls.GetSeries
+	lockQuerentMockGetSeries.RUnlock()
+	return calls
+}
+
+// GetSubmission calls GetSubmissionFunc.
+func (mock *QuerentMock) GetSubmission(ctx context.Context, id int64) (sqlc.Submission, error) {
+	if mock.GetSubmissionFunc == nil {
+		panic("QuerentMock.GetSubmissionFunc: method is nil but Querent.GetSubmission was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetSubmission.Lock()
+	mock.calls.GetSubmission = append(mock.calls.GetSubmission, callInfo)
+	lockQuerentMockGetSubmission.Unlock()
+	return mock.GetSubmissionFunc(ctx, id)
+}
+
+// GetSubmissionCalls gets all the calls that were made to GetSubmission.
+// Check the length with:
+//     len(mockedQuerent.GetSubmissionCalls())
+func (mock *QuerentMock) GetSubmissionCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetSubmission.RLock()
+	calls = mock.calls.GetSubmission
+	lockQuerentMockGetSubmission.RUnlock()
+	return calls
+}
+
+// GetWebhook calls GetWebhookFunc.
+func (mock *QuerentMock) GetWebhook(ctx context.Context, id int64) (sqlc.Webhook, error) {
+	if mock.GetWebhookFunc == nil {
+		panic("QuerentMock.GetWebhookFunc: method is nil but Querent.GetWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockQuerentMockGetWebhook.Lock()
+	mock.calls.GetWebhook = append(mock.calls.GetWebhook, callInfo)
+	lockQuerentMockGetWebhook.Unlock()
+	return mock.GetWebhookFunc(ctx, id)
+}
+
+// GetWebhookCalls gets all the calls that were made to GetWebhook.
+// Check the length with:
+//     len(mockedQuerent.GetWebhookCalls())
+func (mock *QuerentMock) GetWebhookCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockQuerentMockGetWebhook.RLock()
+	calls = mock.calls.GetWebhook
+	lockQuerentMockGetWebhook.RUnlock()
+	return calls
+}
+
+// ListAgencies calls ListAgenciesFunc.
+func (mock *QuerentMock) ListAgencies(ctx context.Context) ([]sqlc.Agency, error) {
+	if mock.ListAgenciesFunc == nil {
+		panic("QuerentMock.ListAgenciesFunc: method is nil but Querent.ListAgencies was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockQuerentMockListAgencies.Lock()
+	mock.calls.ListAgencies = append(mock.calls.ListAgencies, callInfo)
+	lockQuerentMockListAgencies.Unlock()
+	return mock.ListAgenciesFunc(ctx)
+}
+
+// ListAgenciesCalls gets all the calls that were made to ListAgencies.
+// Check the length with:
+//     len(mockedQuerent.ListAgenciesCalls())
+func (mock *QuerentMock) ListAgenciesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockQuerentMockListAgencies.RLock()
+	calls = mock.calls.ListAgencies
+	lockQuerentMockListAgencies.RUnlock()
+	return calls
+}
+
+// ListAgents calls ListAgentsFunc.
+func (mock *QuerentMock) ListAgents(ctx context.Context) ([]sqlc.Agent, error) {
+	if mock.ListAgentsFunc == nil {
+		panic("QuerentMock.ListAgentsFunc: method is nil but Querent.ListAgents was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockQuerentMockListAgents.Lock()
+	mock.calls.ListAgents = append(mock.calls.ListAgents, callInfo)
+	lockQuerentMockListAgents.Unlock()
+	return mock.ListAgentsFunc(ctx)
+}
+
+// ListAgentsCalls gets all the calls that were made to ListAgents.
+// Check the length with:
+//     len(mockedQuerent.ListAgentsCalls())
+func (mock *QuerentMock) ListAgentsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockQuerentMockListAgents.RLock()
+	calls = mock.calls.ListAgents
+	lockQuerentMockListAgents.RUnlock()
+	return calls
+}
+
+// ListAgentsByAgencyID calls ListAgentsByAgencyIDFunc.
+func (mock *QuerentMock) ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.Agent, error) {
+	if mock.ListAgentsByAgencyIDFunc == nil {
+		panic("QuerentMock.ListAgentsByAgencyIDFunc: method is nil but Querent.ListAgentsByAgencyID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AgencyID int64
+	}{
+		Ctx:      ctx,
+		AgencyID: agencyID,
+	}
+	lockQuerentMockListAgentsByAgencyID.Lock()
+	mock.calls.ListAgentsByAgencyID = append(mock.calls.ListAgentsByAgencyID, callInfo)
+	lockQuerentMockListAgentsByAgencyID.Unlock()
+	return mock.ListAgentsByAgencyIDFunc(ctx, agencyID)
+}
+
+// ListAgentsByAgencyIDCalls gets all the calls that were made to ListAgentsByAgencyID.
+// Check the length with:
+//     len(mockedQuerent.ListAgentsByAgencyIDCalls())
+func (mock *QuerentMock) ListAgentsByAgencyIDCalls() []struct {
+	Ctx      context.Context
+	AgencyID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AgencyID int64
+	}
+	lockQuerentMockListAgentsByAgencyID.RLock()
+	calls = mock.calls.ListAgentsByAgencyID
+	lockQuerentMockListAgentsByAgencyID.RUnlock()
+	return calls
+}
+
+// ListAuthors calls ListAuthorsFunc.
+func (mock *QuerentMock) ListAuthors(ctx context.Context) ([]sqlc.ListAuthorsRow, error) {
+	if mock.ListAuthorsFunc == nil {
+		panic("QuerentMock.ListAuthorsFunc: method is nil but Querent.ListAuthors was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockQuerentMockListAuthors.Lock()
+	mock.calls.ListAuthors = append(mock.calls.ListAuthors, callInfo)
+	lockQuerentMockListAuthors.Unlock()
+	return mock.ListAuthorsFunc(ctx)
+}
+
+// ListAuthorsCalls gets all the calls that were made to ListAuthors.
+// Check the length with:
+//     len(mockedQuerent.ListAuthorsCalls())
+func (mock *QuerentMock) ListAuthorsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockQuerentMockListAuthors.RLock()
+	calls = mock.calls.ListAuthors
+	lockQuerentMockListAuthors.RUnlock()
+	return calls
+}
+
+// ListAuthorsByAgencyID calls ListAuthorsByAgencyIDFunc.
+func (mock *QuerentMock) ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.ListAuthorsByAgencyIDRow, error) {
+	if mock.ListAuthorsByAgencyIDFunc == nil {
+		panic("QuerentMock.ListAuthorsByAgencyIDFunc: method is nil but Querent.ListAuthorsByAgencyID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AgencyID int64
+	}{
+		Ctx:      ctx,
+		AgencyID: agencyID,
+	}
+	lockQuerentMockListAuthorsByAgencyID.Lock()
+	mock.calls.ListAuthorsByAgencyID = append(mock.calls.ListAuthorsByAgencyID, callInfo)
+	lockQuerentMockListAuthorsByAgencyID.Unlock()
+	return mock.ListAuthorsByAgencyIDFunc(ctx, agencyID)
+}
+
+// ListAuthorsByAgencyIDCalls gets all the calls that were made to ListAuthorsByAgencyID.
+// Check the length with:
+//     len(mockedQuerent.ListAuthorsByAgencyIDCalls())
+func (mock *QuerentMock) ListAuthorsByAgencyIDCalls() []struct {
+	Ctx      context.Context
+	AgencyID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AgencyID int64
+	}
+	lockQuerentMockListAuthorsByAgencyID.RLock()
+	calls = mock.calls.ListAuthorsByAgencyID
+	lockQuerentMockListAuthorsByAgencyID.RUnlock()
+	return calls
+}
+
+// ListAuthorsByAgentID calls ListAuthorsByAgentIDFunc.
+func (mock *QuerentMock) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.ListAuthorsByAgentIDRow, error) {
+	if mock.ListAuthorsByAgentIDFunc == nil {
+		panic("QuerentMock.ListAuthorsByAgentIDFunc: method is nil but Querent.ListAuthorsByAgentID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AgentID int64
+	}{
+		Ctx:     ctx,
+		AgentID: agentID,
+	}
+	lockQuerentMockListAuthorsByAgentID.Lock()
+	mock.calls.ListAuthorsByAgentID = append(mock.calls.ListAuthorsByAgentID, callInfo)
+	lockQuerentMockListAuthorsByAgentID.Unlock()
+	return mock.ListAuthorsByAgentIDFunc(ctx, agentID)
+}
+
+// ListAuthorsByAgentIDCalls gets all the calls that were made to ListAuthorsByAgentID.
+// Check the length with:
+//     len(mockedQuerent.ListAuthorsByAgentIDCalls())
+func (mock *QuerentMock) ListAuthorsByAgentIDCalls() []struct {
+	Ctx     context.Context
+	AgentID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AgentID int64
+	}
+	lockQuerentMockListAuthorsByAgentID.RLock()
+	calls = mock.calls.ListAuthorsByAgentID
+	lockQuerentMockListAuthorsByAgentID.RUnlock()
+	return calls
+}
+
+// ListAuthorsByBookID calls ListAuthorsByBookIDFunc.
+func (mock *QuerentMock) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]sqlc.ListAuthorsByBookIDRow, error) {
+	if mock.ListAuthorsByBookIDFunc == nil {
+		panic("QuerentMock.ListAuthorsByBookIDFunc: method is nil but Querent.ListAuthorsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockQuerentMockListAuthorsByBookID.Lock()
+	mock.calls.ListAuthorsByBookID = append(mock.calls.ListAuthorsByBookID, callInfo)
+	lockQuerentMockListAuthorsByBookID.Unlock()
+	return mock.ListAuthorsByBookIDFunc(ctx, bookID)
+}
+
+// ListAuthorsByBookIDCalls gets all the calls that were made to ListAuthorsByBookID.
+// Check the length with:
+//     len(mockedQuerent.ListAuthorsByBookIDCalls())
+func (mock *QuerentMock) ListAuthorsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockQuerentMockListAuthorsByBookID.RLock()
+	calls = mock.calls.ListAuthorsByBookID
+	lockQuerentMockListAuthorsByBookID.RUnlock()
+	return calls
+}
+
+// ListAvailableRights calls ListAvailableRightsFunc.
+func (mock *QuerentMock) ListAvailableRights(ctx context.Context, args sqlc.ListAvailableRightsParams) ([]sqlc.ListAvailableRightsRow, error) {
+	if mock.ListAvailableRightsFunc == nil {
+		panic("QuerentMock.ListAvailableRightsFunc: method is nil but Querent.ListAvailableRights was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.ListAvailableRightsParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockListAvailableRights.Lock()
+	mock.calls.ListAvailableRights = append(mock.calls.ListAvailableRights, callInfo)
+	lockQuerentMockListAvailableRights.Unlock()
+	return mock.ListAvailableRightsFunc(ctx, args)
+}
+
+// ListAvailableRightsCalls gets all the calls that were made to ListAvailableRights.
+// Check the length with:
+//     len(mockedQuerent.ListAvailableRightsCalls())
+func (mock *QuerentMock) ListAvailableRightsCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.ListAvailableRightsParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.ListAvailableRightsParams
+	}
+	lockQuerentMockListAvailableRights.RLock()
+	calls = mock.calls.ListAvailableRights
+	lockQuerentMockListAvailableRights.RUnlock()
+	return calls
+}
+
+// ListBooks calls ListBooksFunc.
+func (mock *QuerentMock) ListBooks(ctx context.Context) ([]sqlc.Book, error) {
+	if mock.ListBooksFunc == nil {
+		panic("QuerentMock.ListBooksFunc: method is nil but Querent.ListBooks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockQuerentMockListBooks.Lock()
+	mock.calls.ListBooks = append(mock.calls.ListBooks, callInfo)
+	lockQuerentMockListBooks.Unlock()
+	return mock.ListBooksFunc(ctx)
+}
+
+// ListBooksCalls gets all the calls that were made to ListBooks.
+// Check the length with:
+//     len(mockedQuerent.ListBooksCalls())
+func (mock *QuerentMock) ListBooksCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockQuerentMockListBooks.RLock()
+	calls = mock.calls.ListBooks
+	lockQuerentMockListBooks.RUnlock()
+	return calls
+}
+
+// ListBooksByAgencyID calls ListBooksByAgencyIDFunc.
+func (mock *QuerentMock) ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.Book, error) {
+	if mock.ListBooksByAgencyIDFunc == nil {
+		panic("QuerentMock.ListBooksByAgencyIDFunc: method is nil but Querent.ListBooksByAgencyID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AgencyID int64
+	}{
+		Ctx:      ctx,
+		AgencyID: agencyID,
+	}
+	lockQuerentMockListBooksByAgencyID.Lock()
+	mock.calls.ListBooksByAgencyID = append(mock.calls.ListBooksByAgencyID, callInfo)
+	lockQuerentMockListBooksByAgencyID.Unlock()
+	return mock.ListBooksByAgencyIDFunc(ctx, agencyID)
+}
+
+// ListBooksByAgencyIDCalls gets all the calls that were made to ListBooksByAgencyID.
+// Check the length with:
+//     len(mockedQuerent.ListBooksByAgencyIDCalls())
+func (mock *QuerentMock) ListBooksByAgencyIDCalls() []struct {
+	Ctx      context.Context
+	AgencyID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AgencyID int64
+	}
+	lockQuerentMockListBooksByAgencyID.RLock()
+	calls = mock.calls.ListBooksByAgencyID
+	lockQuerentMockListBooksByAgencyID.RUnlock()
+	return calls
+}
+
+// ListBooksByAuthorID calls ListBooksByAuthorIDFunc.
+func (mock *QuerentMock) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Book, error) {
+	if mock.ListBooksByAuthorIDFunc == nil {
+		panic("QuerentMock.ListBooksByAuthorIDFunc: method is nil but Querent.ListBooksByAuthorID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AuthorID int64
+	}{
+		Ctx:      ctx,
+		AuthorID: authorID,
+	}
+	lockQuerentMockListBooksByAuthorID.Lock()
+	mock.calls.ListBooksByAuthorID = append(mock.calls.ListBooksByAuthorID, callInfo)
+	lockQuerentMockListBooksByAuthorID.Unlock()
+	return mock.ListBooksByAuthorIDFunc(ctx, authorID)
+}
+
+// ListBooksByAuthorIDCalls gets all the calls that were made to ListBooksByAuthorID.
+// Check the length with:
+//     len(mockedQuerent.ListBooksByAuthorIDCalls())
+func (mock *QuerentMock) ListBooksByAuthorIDCalls() []struct {
+	Ctx      context.Context
+	AuthorID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AuthorID int64
+	}
+	lockQuerentMockListBooksByAuthorID.RLock()
+	calls = mock.calls.ListBooksByAuthorID
+	lockQuerentMockListBooksByAuthorID.RUnlock()
+	return calls
+}
+
+// ListBooksByGenreID calls ListBooksByGenreIDFunc.
+func (mock *QuerentMock) ListBooksByGenreID(ctx context.Context, genreID int64) ([]sqlc.Book, error) {
+	if mock.ListBooksByGenreIDFunc == nil {
+		panic("QuerentMock.ListBooksByGenreIDFunc: method is nil but Querent.ListBooksByGenreID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		GenreID int64
+	}{
+		Ctx:     ctx,
+		GenreID: genreID,
+	}
+	lockQuerentMockListBooksByGenreID.Lock()
+	mock.calls.ListBooksByGenreID = append(mock.calls.ListBooksByGenreID, callInfo)
+	lockQuerentMockListBooksByGenreID.Unlock()
+	return mock.ListBooksByGenreIDFunc(ctx, genreID)
+}
+
+// ListBooksByGenreIDCalls gets all the calls that were made to ListBooksByGenreID.
+// Check the length with:
+//     len(mockedQuerent.ListBooksByGenreIDCalls())
+func (mock *QuerentMock) ListBooksByGenreIDCalls() []struct {
+	Ctx     context.Context
+	GenreID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		GenreID int64
+	}
+	lockQuerentMockListBooksByGenreID.RLock()
+	calls = mock.calls.ListBooksByGenreID
+	lockQuerentMockListBooksByGenreID.RUnlock()
+	return calls
+}
+
+// ListBooksByPublisherID calls ListBooksByPublisherIDFunc.
+func (mock *QuerentMock) ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]sqlc.Book, error) {
+	if mock.ListBooksByPublisherIDFunc == nil {
+		panic("QuerentMock.ListBooksByPublisherIDFunc: method is nil but Querent.ListBooksByPublisherID was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		PublisherID int64
+	}{
+		Ctx:         ctx,
+		PublisherID: publisherID,
+	}
+	lockQuerentMockListBooksByPublisherID.Lock()
+	mock.calls.ListBooksByPublisherID = append(mock.calls.ListBooksByPublisherID, callInfo)
+	lockQuerentMockListBooksByPublisherID.Unlock()
+	return mock.ListBooksByPublisherIDFunc(ctx, publisherID)
+}
+
+// ListBooksByPublisherIDCalls gets all the calls that were made to ListBooksByPublisherID.
+// Check the length with:
+//     len(mockedQuerent.ListBooksByPublisherIDCalls())
+func (mock *QuerentMock) ListBooksByPublisherIDCalls() []struct {
+	Ctx         context.Context
+	PublisherID int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		PublisherID int64
+	}
+	lockQuerentMockListBooksByPublisherID.RLock()
+	calls = mock.calls.ListBooksByPublisherID
+	lockQuerentMockListBooksByPublisherID.RUnlock()
+	return calls
+}
+
+// ListBooksBySeriesID calls ListBooksBySeriesIDFunc.
+func (mock *QuerentMock) ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]sqlc.Book, error) {
+	if mock.ListBooksBySeriesIDFunc == nil {
+		panic("QuerentMock.ListBooksBySeriesIDFunc: method is nil but Querent.ListBooksBySeriesID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		SeriesID int64
+	}{
+		Ctx:      ctx,
+		SeriesID: seriesID,
+	}
+	lockQuerentMockListBooksBySeriesID.Lock()
+	mock.calls.ListBooksBySeriesID = append(mock.calls.ListBooksBySeriesID, callInfo)
+	lockQuerentMockListBooksBySeriesID.Unlock()
+	return mock.ListBooksBySeriesIDFunc(ctx, seriesID)
+}
+
+// ListBooksBySeriesIDCalls gets all the calls that were made to ListBooksBySeriesID.
+// Check the length with:
+//     len(mockedQuerent.ListBooksBySeriesIDCalls())
+func (mock *QuerentMock) ListBooksBySeriesIDCalls() []struct {
+	Ctx      context.Context
+	SeriesID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		SeriesID int64
+	}
+	lockQuerentMockListBooksBySeriesID.RLock()
+	calls = mock.calls.ListBooksBySeriesID
+	lockQuerentMockListBooksBySeriesID.RUnlock()
+	return calls
+}
+
+// ListBooksByTag calls ListBooksByTagFunc.
+func (mock *QuerentMock) ListBooksByTag(ctx context.Context, tag string) ([]sqlc.Book, error) {
+	if mock.ListBooksByTagFunc == nil {
+		panic("QuerentMock.ListBooksByTagFunc: method is nil but Querent.ListBooksByTag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Tag string
+	}{
+		Ctx: ctx,
+		Tag: tag,
+	}
+	lockQuerentMockListBooksByTag.Lock()
+	mock.calls.ListBooksByTag = append(mock.calls.ListBooksByTag, callInfo)
+	lockQuerentMockListBooksByTag.Unlock()
+	return mock.ListBooksByTagFunc(ctx, tag)
+}
+
+// ListBooksByTagCalls gets all the calls that were made to ListBooksByTag.
+// Check the length with:
+//     len(mockedQuerent.ListBooksByTagCalls())
+func (mock *QuerentMock) ListBooksByTagCalls() []struct {
+	Ctx context.Context
+	Tag string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Tag string
+	}
+	lockQuerentMockListBooksByTag.RLock()
+	calls = mock.calls.ListBooksByTag
+	lockQuerentMockListBooksByTag.RUnlock()
+	return calls
+}
+
+// ListBooksInGenreTree calls ListBooksInGenreTreeFunc.
+func (mock *QuerentMock) ListBooksInGenreTree(ctx context.Context, genreID int64) ([]sqlc.Book, error) {
+	if mock.ListBooksInGenreTreeFunc == nil {
+		panic("QuerentMock.ListBooksInGenreTreeFunc: method is nil but Querent.ListBooksInGenreTree was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		GenreID int64
+	}{
+		Ctx:     ctx,
+		GenreID: genreID,
+	}
+	lockQuerentMockListBooksInGenreTree.Lock()
+	mock.calls.ListBooksInGenreTree = append(mock.calls.ListBooksInGenreTree, callInfo)
+	lockQuerentMockListBooksInGenreTree.Unlock()
+	return mock.ListBooksInGenreTreeFunc(ctx, genreID)
+}
+
+// ListBooksInGenreTreeCalls gets all the calls that were made to ListBooksInGenreTree.
+// Check the length with:
+//     len(mockedQuerent.ListBooksInGenreTreeCalls())
+func (mock *QuerentMock) ListBooksInGenreTreeCalls() []struct {
+	Ctx     context.Context
+	GenreID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		GenreID int64
+	}
+	lockQuerentMockListBooksInGenreTree.RLock()
+	calls = mock.calls.ListBooksInGenreTree
+	lockQuerentMockListBooksInGenreTree.RUnlock()
+	return calls
+}
+
+// ListContributorsByBookID calls ListContributorsByBookIDFunc.
+func (mock *QuerentMock) ListContributorsByBookID(ctx context.Context, bookID int64) ([]sqlc.BookAuthor, error) {
+	if mock.ListContributorsByBookIDFunc == nil {
+		panic("QuerentMock.ListContributorsByBookIDFunc: method is nil but Querent.ListContributorsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockQuerentMockListContributorsByBookID.Lock()
+	mock.calls.ListContributorsByBookID = append(mock.calls.ListContributorsByBookID, callInfo)
+	lockQuerentMockListContributorsByBookID.Unlock()
+	return mock.ListContributorsByBookIDFunc(ctx, bookID)
+}
+
+// ListContributorsByBookIDCalls gets all the calls that were made to ListContributorsByBookID.
+// Check the length with:
+//     len(mockedQuerent.ListContributorsByBookIDCalls())
+func (mock *QuerentMock) ListContributorsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockQuerentMockListContributorsByBookID.RLock()
+	calls = mock.calls.ListContributorsByBookID
+	lockQuerentMockListContributorsByBookID.RUnlock()
+	return calls
+}
+
+// ListDealsByAgentID calls ListDealsByAgentIDFunc.
+func (mock *QuerentMock) ListDealsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Deal, error) {
+	if mock.ListDealsByAgentIDFunc == nil {
+		panic("QuerentMock.ListDealsByAgentIDFunc: method is nil but Querent.ListDealsByAgentID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AgentID int64
+	}{
+		Ctx:     ctx,
+		AgentID: agentID,
+	}
+	lockQuerentMockListDealsByAgentID.Lock()
+	mock.calls.ListDealsByAgentID = append(mock.calls.ListDealsByAgentID, callInfo)
+	lockQuerentMockListDealsByAgentID.Unlock()
+	return mock.ListDealsByAgentIDFunc(ctx, agentID)
+}
+
+// ListDealsByAgentIDCalls gets all the calls that were made to ListDealsByAgentID.
+// Check the length with:
+//     len(mockedQuerent.ListDealsByAgentIDCalls())
+func (mock *QuerentMock) ListDealsByAgentIDCalls() []struct {
+	Ctx     context.Context
+	AgentID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AgentID int64
+	}
+	lockQuerentMockListDealsByAgentID.RLock()
+	calls = mock.calls.ListDealsByAgentID
+	lockQuerentMockListDealsByAgentID.RUnlock()
+	return calls
+}
+
+// ListDealsByBookID calls ListDealsByBookIDFunc.
+func (mock *QuerentMock) ListDealsByBookID(ctx context.Context, bookID int64) ([]sqlc.Deal, error) {
+	if mock.ListDealsByBookIDFunc == nil {
+		panic("QuerentMock.ListDealsByBookIDFunc: method is nil but Querent.ListDealsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockQuerentMockListDealsByBookID.Lock()
+	mock.calls.ListDealsByBookID = append(mock.calls.ListDealsByBookID, callInfo)
+	lockQuerentMockListDealsByBookID.Unlock()
+	return mock.ListDealsByBookIDFunc(ctx, bookID)
+}
+
+// ListDealsByBookIDCalls gets all the calls that were made to ListDealsByBookID.
+// Check the length with:
+//     len(mockedQuerent.ListDealsByBookIDCalls())
+func (mock *QuerentMock) ListDealsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockQuerentMockListDealsByBookID.RLock()
+	calls = mock.calls.ListDealsByBookID
+	lockQuerentMockListDealsByBookID.RUnlock()
+	return calls
+}
+
+// ListEditions calls ListEditionsFunc.
+func (mock *QuerentMock) ListEditions(ctx context.Context, args sqlc.ListEditionsParams) ([]sqlc.Edition, error) {
+	if mock.ListEditionsFunc == nil {
+		panic("QuerentMock.ListEditionsFunc: method is nil but Querent.ListEditions was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.ListEditionsParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockListEditions.Lock()
+	mock.calls.ListEditions = append(mock.calls.ListEditions, callInfo)
+	lockQuerentMockListEditions.Unlock()
+	return mock.ListEditionsFunc(ctx, args)
+}
+
+// ListEditionsCalls gets all the calls that were made to ListEditions.
+// Check the length with:
+//     len(mockedQuerent.ListEditionsCalls())
+func (mock *QuerentMock) ListEditionsCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.ListEditionsParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.ListEditionsParams
+	}
+	lockQuerentMockListEditions.RLock()
+	calls = mock.calls.ListEditions
+	lockQuerentMockListEditions.RUnlock()
+	return calls
+}
+
+// ListEditionsByBookID calls ListEditionsByBookIDFunc.
+func (mock *QuerentMock) ListEditionsByBookID(ctx context.Context, bookID int64) ([]sqlc.Edition, error) {
+	if mock.ListEditionsByBookIDFunc == nil {
+		panic("QuerentMock.ListEditionsByBookIDFunc: method is nil but Querent.ListEditionsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockQuerentMockListEditionsByBookID.Lock()
+	mock.calls.ListEditionsByBookID = append(mock.calls.ListEditionsByBookID, callInfo)
+	lockQuerentMockListEditionsByBookID.Unlock()
+	return mock.ListEditionsByBookIDFunc(ctx, bookID)
+}
+
+// ListEditionsByBookIDCalls gets all the calls that were made to ListEditionsByBookID.
+// Check the length with:
+//     len(mockedQuerent.ListEditionsByBookIDCalls())
+func (mock *QuerentMock) ListEditionsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockQuerentMockListEditionsByBookID.RLock()
+	calls = mock.calls.ListEditionsByBookID
+	lockQuerentMockListEditionsByBookID.RUnlock()
+	return calls
+}
+
+// ListFormerAuthorsByAgentID calls ListFormerAuthorsByAgentIDFunc.
+func (mock *QuerentMock) ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.ListFormerAuthorsByAgentIDRow, error) {
+	if mock.ListFormerAuthorsByAgentIDFunc == nil {
+		panic("QuerentMock.ListFormerAuthorsByAgentIDFunc: method is nil but Querent.ListFormerAuthorsByAgentID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AgentID int64
+	}{
+		Ctx:     ctx,
+		AgentID: agentID,
+	}
+	lockQuerentMockListFormerAuthorsByAgentID.Lock()
+	mock.calls.ListFormerAuthorsByAgentID = append(mock.calls.ListFormerAuthorsByAgentID, callInfo)
+	lockQuerentMockListFormerAuthorsByAgentID.Unlock()
+	return mock.ListFormerAuthorsByAgentIDFunc(ctx, agentID)
+}
+
+// ListFormerAuthorsByAgentIDCalls gets all the calls that were made to ListFormerAuthorsByAgentID.
 // Check the length with:
-//     len(mockedQuerent.CreateAgentCalls())
-func (mock *QuerentMock) CreateAgentCalls() []struct {
-	Ctx  context.Context
-	Args sqlc.CreateAgentParams
+//     len(mockedQuerent.ListFormerAuthorsByAgentIDCalls())
+func (mock *QuerentMock) ListFormerAuthorsByAgentIDCalls() []struct {
+	Ctx     context.Context
+	AgentID int64
 } {
 	var calls []struct {
-		Ctx  context.Context
-		Args sqlc.CreateAgentParams
+		Ctx     context.Context
+		AgentID int64
 	}
-	lockQuerentMockCreateAgent.RLock()
-	calls = mock.calls.CreateAgent
-	lockQuerentMockCreateAgent.RUnlock()
+	lockQuerentMockListFormerAuthorsByAgentID.RLock()
+	calls = mock.calls.ListFormerAuthorsByAgentID
+	lockQuerentMockListFormerAuthorsByAgentID.RUnlock()
+	return calls
+}
+
+// ListGenres calls ListGenresFunc.
+func (mock *QuerentMock) ListGenres(ctx context.Context) ([]sqlc.Genre, error) {
+	if mock.ListGenresFunc == nil {
+		panic("QuerentMock.ListGenresFunc: method is nil but Querent.ListGenres was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockQuerentMockListGenres.Lock()
+	mock.calls.ListGenres = append(mock.calls.ListGenres, callInfo)
+	lockQuerentMockListGenres.Unlock()
+	return mock.ListGenresFunc(ctx)
+}
+
+// ListGenresCalls gets all the calls that were made to ListGenres.
+// Check the length with:
+//     len(mockedQuerent.ListGenresCalls())
+func (mock *QuerentMock) ListGenresCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockQuerentMockListGenres.RLock()
+	calls = mock.calls.ListGenres
+	lockQuerentMockListGenres.RUnlock()
+	return calls
+}
+
+// ListGenresByBookID calls ListGenresByBookIDFunc.
+func (mock *QuerentMock) ListGenresByBookID(ctx context.Context, bookID int64) ([]sqlc.Genre, error) {
+	if mock.ListGenresByBookIDFunc == nil {
+		panic("QuerentMock.ListGenresByBookIDFunc: method is nil but Querent.ListGenresByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockQuerentMockListGenresByBookID.Lock()
+	mock.calls.ListGenresByBookID = append(mock.calls.ListGenresByBookID, callInfo)
+	lockQuerentMockListGenresByBookID.Unlock()
+	return mock.ListGenresByBookIDFunc(ctx, bookID)
+}
+
+// ListGenresByBookIDCalls gets all the calls that were made to ListGenresByBookID.
+// Check the length with:
+//     len(mockedQuerent.ListGenresByBookIDCalls())
+func (mock *QuerentMock) ListGenresByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockQuerentMockListGenresByBookID.RLock()
+	calls = mock.calls.ListGenresByBookID
+	lockQuerentMockListGenresByBookID.RUnlock()
+	return calls
+}
+
+// ListGenresByParentID calls ListGenresByParentIDFunc.
+func (mock *QuerentMock) ListGenresByParentID(ctx context.Context, parentID int64) ([]sqlc.Genre, error) {
+	if mock.ListGenresByParentIDFunc == nil {
+		panic("QuerentMock.ListGenresByParentIDFunc: method is nil but Querent.ListGenresByParentID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ParentID int64
+	}{
+		Ctx:      ctx,
+		ParentID: parentID,
+	}
+	lockQuerentMockListGenresByParentID.Lock()
+	mock.calls.ListGenresByParentID = append(mock.calls.ListGenresByParentID, callInfo)
+	lockQuerentMockListGenresByParentID.Unlock()
+	return mock.ListGenresByParentIDFunc(ctx, parentID)
+}
+
+// ListGenresByParentIDCalls gets all the calls that were made to ListGenresByParentID.
+// Check the length with:
+//     len(mockedQuerent.ListGenresByParentIDCalls())
+func (mock *QuerentMock) ListGenresByParentIDCalls() []struct {
+	Ctx      context.Context
+	ParentID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ParentID int64
+	}
+	lockQuerentMockListGenresByParentID.RLock()
+	calls = mock.calls.ListGenresByParentID
+	lockQuerentMockListGenresByParentID.RUnlock()
+	return calls
+}
+
+// ListOrphanBooks calls ListOrphanBooksFunc.
+func (mock *QuerentMock) ListOrphanBooks(ctx context.Context) ([]sqlc.Book, error) {
+	if mock.ListOrphanBooksFunc == nil {
+		panic("QuerentMock.ListOrphanBooksFunc: method is nil but Querent.ListOrphanBooks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockQuerentMockListOrphanBooks.Lock()
+	mock.calls.ListOrphanBooks = append(mock.calls.ListOrphanBooks, callInfo)
+	lockQuerentMockListOrphanBooks.Unlock()
+	return mock.ListOrphanBooksFunc(ctx)
+}
+
+// ListOrphanBooksCalls gets all the calls that were made to ListOrphanBooks.
+// Check the length with:
+//     len(mockedQuerent.ListOrphanBooksCalls())
+func (mock *QuerentMock) ListOrphanBooksCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockQuerentMockListOrphanBooks.RLock()
+	calls = mock.calls.ListOrphanBooks
+	lockQuerentMockListOrphanBooks.RUnlock()
+	return calls
+}
+
+// ListPublishers calls ListPublishersFunc.
+func (mock *QuerentMock) ListPublishers(ctx context.Context) ([]sqlc.Publisher, error) {
+	if mock.ListPublishersFunc == nil {
+		panic("QuerentMock.ListPublishersFunc: method is nil but Querent.ListPublishers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockQuerentMockListPublishers.Lock()
+	mock.calls.ListPublishers = append(mock.calls.ListPublishers, callInfo)
+	lockQuerentMockListPublishers.Unlock()
+	return mock.ListPublishersFunc(ctx)
+}
+
+// ListPublishersCalls gets all the calls that were made to ListPublishers.
+// Check the length with:
+//     len(mockedQuerent.ListPublishersCalls())
+func (mock *QuerentMock) ListPublishersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockQuerentMockListPublishers.RLock()
+	calls = mock.calls.ListPublishers
+	lockQuerentMockListPublishers.RUnlock()
+	return calls
+}
+
+// ListRepresentationsByAuthorID calls ListRepresentationsByAuthorIDFunc.
+func (mock *QuerentMock) ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Representation, error) {
+	if mock.ListRepresentationsByAuthorIDFunc == nil {
+		panic("QuerentMock.ListRepresentationsByAuthorIDFunc: method is nil but Querent.ListRepresentationsByAuthorID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AuthorID int64
+	}{
+		Ctx:      ctx,
+		AuthorID: authorID,
+	}
+	lockQuerentMockListRepresentationsByAuthorID.Lock()
+	mock.calls.ListRepresentationsByAuthorID = append(mock.calls.ListRepresentationsByAuthorID, callInfo)
+	lockQuerentMockListRepresentationsByAuthorID.Unlock()
+	return mock.ListRepresentationsByAuthorIDFunc(ctx, authorID)
+}
+
+// ListRepresentationsByAuthorIDCalls gets all the calls that were made to ListRepresentationsByAuthorID.
+// Check the length with:
+//     len(mockedQuerent.ListRepresentationsByAuthorIDCalls())
+func (mock *QuerentMock) ListRepresentationsByAuthorIDCalls() []struct {
+	Ctx      context.Context
+	AuthorID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AuthorID int64
+	}
+	lockQuerentMockListRepresentationsByAuthorID.RLock()
+	calls = mock.calls.ListRepresentationsByAuthorID
+	lockQuerentMockListRepresentationsByAuthorID.RUnlock()
+	return calls
+}
+
+// ListRoyaltyRatesByDealID calls ListRoyaltyRatesByDealIDFunc.
+func (mock *QuerentMock) ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]sqlc.RoyaltyRate, error) {
+	if mock.ListRoyaltyRatesByDealIDFunc == nil {
+		panic("QuerentMock.ListRoyaltyRatesByDealIDFunc: method is nil but Querent.ListRoyaltyRatesByDealID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		DealID int64
+	}{
+		Ctx:    ctx,
+		DealID: dealID,
+	}
+	lockQuerentMockListRoyaltyRatesByDealID.Lock()
+	mock.calls.ListRoyaltyRatesByDealID = append(mock.calls.ListRoyaltyRatesByDealID, callInfo)
+	lockQuerentMockListRoyaltyRatesByDealID.Unlock()
+	return mock.ListRoyaltyRatesByDealIDFunc(ctx, dealID)
+}
+
+// ListRoyaltyRatesByDealIDCalls gets all the calls that were made to ListRoyaltyRatesByDealID.
+// Check the length with:
+//     len(mockedQuerent.ListRoyaltyRatesByDealIDCalls())
+func (mock *QuerentMock) ListRoyaltyRatesByDealIDCalls() []struct {
+	Ctx    context.Context
+	DealID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		DealID int64
+	}
+	lockQuerentMockListRoyaltyRatesByDealID.RLock()
+	calls = mock.calls.ListRoyaltyRatesByDealID
+	lockQuerentMockListRoyaltyRatesByDealID.RUnlock()
 	return calls
 }
 
-// CreateAuthor calls CreateAuthorFunc.
-func (mock *QuerentMock) CreateAuthor(ctx context.Context, args sqlc.CreateAuthorParams) (sqlc.Author, error) {
-	if mock.CreateAuthorFunc == nil {
-		panic("QuerentMock.CreateAuthorFunc: method is nil but Querent.CreateAuthor was just called")
+// ListRoyaltySales calls ListRoyaltySalesFunc.
+func (mock *QuerentMock) ListRoyaltySales(ctx context.Context, args sqlc.ListRoyaltySalesParams) ([]sqlc.ListRoyaltySalesRow, error) {
+	if mock.ListRoyaltySalesFunc == nil {
+		panic("QuerentMock.ListRoyaltySalesFunc: method is nil but Querent.ListRoyaltySales was just called")
 	}
 	callInfo := struct {
 		Ctx  context.Context
-		Args sqlc.CreateAuthorParams
+		Args sqlc.ListRoyaltySalesParams
 	}{
 		Ctx:  ctx,
 		Args: args,
 	}
-	lockQuerentMockCreateAuthor.Lock()
-	mock.calls.CreateAuthor = append(mock.calls.CreateAuthor, callInfo)
-	lockQuerentMockCreateAuthor.Unlock()
-	return mock.CreateAuthorFunc(ctx, args)
+	lockQuerentMockListRoyaltySales.Lock()
+	mock.calls.ListRoyaltySales = append(mock.calls.ListRoyaltySales, callInfo)
+	lockQuerentMockListRoyaltySales.Unlock()
+	return mock.ListRoyaltySalesFunc(ctx, args)
 }
 
-// CreateAuthorCalls gets all the calls that were made to CreateAuthor.
+// ListRoyaltySalesCalls gets all the calls that were made to ListRoyaltySales.
 // Check the length with:
-//     len(mockedQuerent.CreateAuthorCalls())
-func (mock *QuerentMock) CreateAuthorCalls() []struct {
+//     len(mockedQuerent.ListRoyaltySalesCalls())
+func (mock *QuerentMock) ListRoyaltySalesCalls() []struct {
 	Ctx  context.Context
-	Args sqlc.CreateAuthorParams
+	Args sqlc.ListRoyaltySalesParams
 } {
 	var calls []struct {
 		Ctx  context.Context
-		Args sqlc.CreateAuthorParams
+		Args sqlc.ListRoyaltySalesParams
+	}
+	lockQuerentMockListRoyaltySales.RLock()
+	calls = mock.calls.ListRoyaltySales
+	lockQuerentMockListRoyaltySales.RUnlock()
+	return calls
+}
+
+// ListSalesByDealID calls ListSalesByDealIDFunc.
+func (mock *QuerentMock) ListSalesByDealID(ctx context.Context, dealID int64) ([]sqlc.Sale, error) {
+	if mock.ListSalesByDealIDFunc == nil {
+		panic("QuerentMock.ListSalesByDealIDFunc: method is nil but Querent.ListSalesByDealID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		DealID int64
+	}{
+		Ctx:    ctx,
+		DealID: dealID,
+	}
+	lockQuerentMockListSalesByDealID.Lock()
+	mock.calls.ListSalesByDealID = append(mock.calls.ListSalesByDealID, callInfo)
+	lockQuerentMockListSalesByDealID.Unlock()
+	return mock.ListSalesByDealIDFunc(ctx, dealID)
+}
+
+// ListSalesByDealIDCalls gets all the calls that were made to ListSalesByDealID.
+// Check the length with:
+//     len(mockedQuerent.ListSalesByDealIDCalls())
+func (mock *QuerentMock) ListSalesByDealIDCalls() []struct {
+	Ctx    context.Context
+	DealID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		DealID int64
 	}
-	lockQuerentMockCreateAuthor.RLock()
-	calls = mock.calls.CreateAuthor
-	lockQuerentMockCreateAuthor.RUnlock()
+	lockQuerentMockListSalesByDealID.RLock()
+	calls = mock.calls.ListSalesByDealID
+	lockQuerentMockListSalesByDealID.RUnlock()
 	return calls
 }
 
-// DeleteAgent calls DeleteAgentFunc.
-func (mock *QuerentMock) DeleteAgent(ctx context.Context, id int64) (sqlc.Agent, error) {
-	if mock.DeleteAgentFunc == nil {
-		panic("QuerentMock.DeleteAgentFunc: method is nil but Querent.DeleteAgent was just called")
+// ListSeries calls ListSeriesFunc.
+func (mock *QuerentMock) ListSeries(ctx context.Context) ([]sqlc.Series, error) {
+	if mock.ListSeriesFunc == nil {
+		panic("QuerentMock.ListSeriesFunc: method is nil but Querent.ListSeries was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  int64
 	}{
 		Ctx: ctx,
-		ID:  id,
 	}
-	lockQuerentMockDeleteAgent.Lock()
-	mock.calls.DeleteAgent = append(mock.calls.DeleteAgent, callInfo)
-	lockQuerentMockDeleteAgent.Unlock()
-	return mock.DeleteAgentFunc(ctx, id)
+	lockQuerentMockListSeries.Lock()
+	mock.calls.ListSeries = append(mock.calls.ListSeries, callInfo)
+	lockQuerentMockListSeries.Unlock()
+	return mock.ListSeriesFunc(ctx)
 }
 
-// DeleteAgentCalls gets all the calls that were made to DeleteAgent.
+// ListSeriesCalls gets all the calls that were made to ListSeries.
 // Check the length with:
-//     len(mockedQuerent.DeleteAgentCalls())
-func (mock *QuerentMock) DeleteAgentCalls() []struct {
+//     len(mockedQuerent.ListSeriesCalls())
+func (mock *QuerentMock) ListSeriesCalls() []struct {
 	Ctx context.Context
-	ID  int64
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  int64
 	}
-	lockQuerentMockDeleteAgent.RLock()
-	calls = mock.calls.DeleteAgent
-	lockQuerentMockDeleteAgent.RUnlock()
+	lockQuerentMockListSeries.RLock()
+	calls = mock.calls.ListSeries
+	lockQuerentMockListSeries.RUnlock()
 	return calls
 }
 
-// DeleteAuthor calls DeleteAuthorFunc.
-func (mock *QuerentMock) DeleteAuthor(ctx context.Context, id int64) (sqlc.Author, error) {
-	if mock.DeleteAuthorFunc == nil {
-		panic("QuerentMock.DeleteAuthorFunc: method is nil but Querent.DeleteAuthor was just called")
+// ListSubmissionTransitions calls ListSubmissionTransitionsFunc.
+func (mock *QuerentMock) ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]sqlc.SubmissionTransition, error) {
+	if mock.ListSubmissionTransitionsFunc == nil {
+		panic("QuerentMock.ListSubmissionTransitionsFunc: method is nil but Querent.ListSubmissionTransitions was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx          context.Context
+		SubmissionID int64
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:          ctx,
+		SubmissionID: submissionID,
+	}
+	lockQuerentMockListSubmissionTransitions.Lock()
+	mock.calls.ListSubmissionTransitions = append(mock.calls.ListSubmissionTransitions, callInfo)
+	lockQuerentMockListSubmissionTransitions.Unlock()
+	return mock.ListSubmissionTransitionsFunc(ctx, submissionID)
+}
+
+// ListSubmissionTransitionsCalls gets all the calls that were made to ListSubmissionTransitions.
+// Check the length with:
+//     len(mockedQuerent.ListSubmissionTransitionsCalls())
+func (mock *QuerentMock) ListSubmissionTransitionsCalls() []struct {
+	Ctx          context.Context
+	SubmissionID int64
+} {
+	var calls []struct {
+		Ctx          context.Context
+		SubmissionID int64
+	}
+	lockQuerentMockListSubmissionTransitions.RLock()
+	calls = mock.calls.ListSubmissionTransitions
+	lockQuerentMockListSubmissionTransitions.RUnlock()
+	return calls
+}
+
+// ListSubmissionsByAgentID calls ListSubmissionsByAgentIDFunc.
+func (mock *QuerentMock) ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Submission, error) {
+	if mock.ListSubmissionsByAgentIDFunc == nil {
+		panic("QuerentMock.ListSubmissionsByAgentIDFunc: method is nil but Querent.ListSubmissionsByAgentID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AgentID int64
+	}{
+		Ctx:     ctx,
+		AgentID: agentID,
+	}
+	lockQuerentMockListSubmissionsByAgentID.Lock()
+	mock.calls.ListSubmissionsByAgentID = append(mock.calls.ListSubmissionsByAgentID, callInfo)
+	lockQuerentMockListSubmissionsByAgentID.Unlock()
+	return mock.ListSubmissionsByAgentIDFunc(ctx, agentID)
+}
+
+// ListSubmissionsByAgentIDCalls gets all the calls that were made to ListSubmissionsByAgentID.
+// Check the length with:
+//     len(mockedQuerent.ListSubmissionsByAgentIDCalls())
+func (mock *QuerentMock) ListSubmissionsByAgentIDCalls() []struct {
+	Ctx     context.Context
+	AgentID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AgentID int64
+	}
+	lockQuerentMockListSubmissionsByAgentID.RLock()
+	calls = mock.calls.ListSubmissionsByAgentID
+	lockQuerentMockListSubmissionsByAgentID.RUnlock()
+	return calls
+}
+
+// ListSubmissionsByAgentIDAndStatus calls ListSubmissionsByAgentIDAndStatusFunc.
+func (mock *QuerentMock) ListSubmissionsByAgentIDAndStatus(ctx context.Context, args sqlc.ListSubmissionsByAgentIDAndStatusParams) ([]sqlc.Submission, error) {
+	if mock.ListSubmissionsByAgentIDAndStatusFunc == nil {
+		panic("QuerentMock.ListSubmissionsByAgentIDAndStatusFunc: method is nil but Querent.ListSubmissionsByAgentIDAndStatus was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.ListSubmissionsByAgentIDAndStatusParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockQuerentMockListSubmissionsByAgentIDAndStatus.Lock()
+	mock.calls.ListSubmissionsByAgentIDAndStatus = append(mock.calls.ListSubmissionsByAgentIDAndStatus, callInfo)
+	lockQuerentMockListSubmissionsByAgentIDAndStatus.Unlock()
+	return mock.ListSubmissionsByAgentIDAndStatusFunc(ctx, args)
+}
+
+// ListSubmissionsByAgentIDAndStatusCalls gets all the calls that were made to ListSubmissionsByAgentIDAndStatus.
+// Check the length with:
+//     len(mockedQuerent.ListSubmissionsByAgentIDAndStatusCalls())
+func (mock *QuerentMock) ListSubmissionsByAgentIDAndStatusCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.ListSubmissionsByAgentIDAndStatusParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.ListSubmissionsByAgentIDAndStatusParams
+	}
+	lockQuerentMockListSubmissionsByAgentIDAndStatus.RLock()
+	calls = mock.calls.ListSubmissionsByAgentIDAndStatus
+	lockQuerentMockListSubmissionsByAgentIDAndStatus.RUnlock()
+	return calls
+}
+
+// ListTagsByBookID calls ListTagsByBookIDFunc.
+func (mock *QuerentMock) ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error) {
+	if mock.ListTagsByBookIDFunc == nil {
+		panic("QuerentMock.ListTagsByBookIDFunc: method is nil but Querent.ListTagsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockQuerentMockListTagsByBookID.Lock()
+	mock.calls.ListTagsByBookID = append(mock.calls.ListTagsByBookID, callInfo)
+	lockQuerentMockListTagsByBookID.Unlock()
+	return mock.ListTagsByBookIDFunc(ctx, bookID)
+}
+
+// ListTagsByBookIDCalls gets all the calls that were made to ListTagsByBookID.
+// Check the length with:
+//     len(mockedQuerent.ListTagsByBookIDCalls())
+func (mock *QuerentMock) ListTagsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockQuerentMockListTagsByBookID.RLock()
+	calls = mock.calls.ListTagsByBookID
+	lockQuerentMockListTagsByBookID.RUnlock()
+	return calls
+}
+
+// ListWebhookDeliveries calls ListWebhookDeliveriesFunc.
+func (mock *QuerentMock) ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]sqlc.WebhookDelivery, error) {
+	if mock.ListWebhookDeliveriesFunc == nil {
+		panic("QuerentMock.ListWebhookDeliveriesFunc: method is nil but Querent.ListWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		WebhookID int64
+	}{
+		Ctx:       ctx,
+		WebhookID: webhookID,
 	}
-	lockQuerentMockDeleteAuthor.Lock()
-	mock.calls.DeleteAuthor = append(mock.calls.DeleteAuthor, callInfo)
-	lockQuerentMockDeleteAuthor.Unlock()
-	return mock.DeleteAuthorFunc(ctx, id)
+	lockQuerentMockListWebhookDeliveries.Lock()
+	mock.calls.ListWebhookDeliveries = append(mock.calls.ListWebhookDeliveries, callInfo)
+	lockQuerentMockListWebhookDeliveries.Unlock()
+	return mock.ListWebhookDeliveriesFunc(ctx, webhookID)
 }
 
-// DeleteAuthorCalls gets all the calls that were made to DeleteAuthor.
+// ListWebhookDeliveriesCalls gets all the calls that were made to ListWebhookDeliveries.
 // Check the length with:
-//     len(mockedQuerent.DeleteAuthorCalls())
-func (mock *QuerentMock) DeleteAuthorCalls() []struct {
-	Ctx context.Context
-	ID  int64
+//     len(mockedQuerent.ListWebhookDeliveriesCalls())
+func (mock *QuerentMock) ListWebhookDeliveriesCalls() []struct {
+	Ctx       context.Context
+	WebhookID int64
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx       context.Context
+		WebhookID int64
 	}
-	lockQuerentMockDeleteAuthor.RLock()
-	calls = mock.calls.DeleteAuthor
-	lockQuerentMockDeleteAuthor.RUnlock()
+	lockQuerentMockListWebhookDeliveries.RLock()
+	calls = mock.calls.ListWebhookDeliveries
+	lockQuerentMockListWebhookDeliveries.RUnlock()
 	return calls
 }
 
-// DeleteBook calls DeleteBookFunc.
-func (mock *QuerentMock) DeleteBook(ctx context.Context, id int64) (sqlc.Book, error) {
-	if mock.DeleteBookFunc == nil {
-		panic("QuerentMock.DeleteBookFunc: method is nil but Querent.DeleteBook was just called")
+// ListWebhookDeliveriesByStatus calls ListWebhookDeliveriesByStatusFunc.
+func (mock *QuerentMock) ListWebhookDeliveriesByStatus(ctx context.Context, args sqlc.ListWebhookDeliveriesByStatusParams) ([]sqlc.WebhookDelivery, error) {
+	if mock.ListWebhookDeliveriesByStatusFunc == nil {
+		panic("QuerentMock.ListWebhookDeliveriesByStatusFunc: method is nil but Querent.ListWebhookDeliveriesByStatus was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx  context.Context
+		Args sqlc.ListWebhookDeliveriesByStatusParams
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:  ctx,
+		Args: args,
 	}
-	lockQuerentMockDeleteBook.Lock()
-	mock.calls.DeleteBook = append(mock.calls.DeleteBook, callInfo)
-	lockQuerentMockDeleteBook.Unlock()
-	return mock.DeleteBookFunc(ctx, id)
+	lockQuerentMockListWebhookDeliveriesByStatus.Lock()
+	mock.calls.ListWebhookDeliveriesByStatus = append(mock.calls.ListWebhookDeliveriesByStatus, callInfo)
+	lockQuerentMockListWebhookDeliveriesByStatus.Unlock()
+	return mock.ListWebhookDeliveriesByStatusFunc(ctx, args)
 }
 
-// DeleteBookCalls gets all the calls that were made to DeleteBook.
+// ListWebhookDeliveriesByStatusCalls gets all the calls that were made to ListWebhookDeliveriesByStatus.
 // Check the length with:
-//     len(mockedQuerent.DeleteBookCalls())
-func (mock *QuerentMock) DeleteBookCalls() []struct {
-	Ctx context.Context
-	ID  int64
+//     len(mockedQuerent.ListWebhookDeliveriesByStatusCalls())
+func (mock *QuerentMock) ListWebhookDeliveriesByStatusCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.ListWebhookDeliveriesByStatusParams
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx  context.Context
+		Args sqlc.ListWebhookDeliveriesByStatusParams
 	}
-	lockQuerentMockDeleteBook.RLock()
-	calls = mock.calls.DeleteBook
-	lockQuerentMockDeleteBook.RUnlock()
+	lockQuerentMockListWebhookDeliveriesByStatus.RLock()
+	calls = mock.calls.ListWebhookDeliveriesByStatus
+	lockQuerentMockListWebhookDeliveriesByStatus.RUnlock()
 	return calls
 }
 
-// GetAgent calls GetAgentFunc.
-func (mock *QuerentMock) GetAgent(ctx context.Context, id int64) (sqlc.Agent, error) {
-	if mock.GetAgentFunc == nil {
-		panic("QuerentMock.GetAgentFunc: method is nil but Querent.GetAgent was just called")
+// ListWebhooks calls ListWebhooksFunc.
+func (mock *QuerentMock) ListWebhooks(ctx context.Context) ([]sqlc.Webhook, error) {
+	if mock.ListWebhooksFunc == nil {
+		panic("QuerentMock.ListWebhooksFunc: method is nil but Querent.ListWebhooks was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  int64
 	}{
 		Ctx: ctx,
-		ID:  id,
 	}
-	lockQuerentMockGetAgent.Lock()
-	mock.calls.GetAgent = append(mock.calls.GetAgent, callInfo)
-	lockQuerentMockGetAgent.Unlock()
-	return mock.GetAgentFunc(ctx, id)
+	lockQuerentMockListWebhooks.Lock()
+	mock.calls.ListWebhooks = append(mock.calls.ListWebhooks, callInfo)
+	lockQuerentMockListWebhooks.Unlock()
+	return mock.ListWebhooksFunc(ctx)
 }
 
-// GetAgentCalls gets all the calls that were made to GetAgent.
+// ListWebhooksCalls gets all the calls that were made to ListWebhooks.
 // Check the length with:
-//     len(mockedQuerent.GetAgentCalls())
-func (mock *QuerentMock) GetAgentCalls() []struct {
+//     len(mockedQuerent.ListWebhooksCalls())
+func (mock *QuerentMock) ListWebhooksCalls() []struct {
 	Ctx context.Context
-	ID  int64
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  int64
 	}
-	lockQuerentMockGetAgent.RLock()
-	calls = mock.calls.GetAgent
-	lockQuerentMockGetAgent.RUnlock()
+	lockQuerentMockListWebhooks.RLock()
+	calls = mock.calls.ListWebhooks
+	lockQuerentMockListWebhooks.RUnlock()
 	return calls
 }
 
-// GetAuthor calls GetAuthorFunc.
-func (mock *QuerentMock) GetAuthor(ctx context.Context, id int64) (sqlc.Author, error) {
-	if mock.GetAuthorFunc == nil {
-		panic("QuerentMock.GetAuthorFunc: method is nil but Querent.GetAuthor was just called")
+// RecordSales calls RecordSalesFunc.
+func (mock *QuerentMock) RecordSales(ctx context.Context, args sqlc.RecordSalesParams) (sqlc.Sale, error) {
+	if mock.RecordSalesFunc == nil {
+		panic("QuerentMock.RecordSalesFunc: method is nil but Querent.RecordSales was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx  context.Context
+		Args sqlc.RecordSalesParams
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:  ctx,
+		Args: args,
 	}
-	lockQuerentMockGetAuthor.Lock()
-	mock.calls.GetAuthor = append(mock.calls.GetAuthor, callInfo)
-	lockQuerentMockGetAuthor.Unlock()
-	return mock.GetAuthorFunc(ctx, id)
+	lockQuerentMockRecordSales.Lock()
+	mock.calls.RecordSales = append(mock.calls.RecordSales, callInfo)
+	lockQuerentMockRecordSales.Unlock()
+	return mock.RecordSalesFunc(ctx, args)
 }
 
-// GetAuthorCalls gets all the calls that were made to GetAuthor.
+// RecordSalesCalls gets all the calls that were made to RecordSales.
 // Check the length with:
-//     len(mockedQuerent.GetAuthorCalls())
-func (mock *QuerentMock) GetAuthorCalls() []struct {
-	Ctx context.Context
-	ID  int64
+//     len(mockedQuerent.RecordSalesCalls())
+func (mock *QuerentMock) RecordSalesCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.RecordSalesParams
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx  context.Context
+		Args sqlc.RecordSalesParams
 	}
-	lockQuerentMockGetAuthor.RLock()
-	calls = mock.calls.GetAuthor
-	lockQuerentMockGetAuthor.RUnlock()
+	lockQuerentMockRecordSales.RLock()
+	calls = mock.calls.RecordSales
+	lockQuerentMockRecordSales.RUnlock()
 	return calls
 }
 
-// GetBook calls GetBookFunc.
-func (mock *QuerentMock) GetBook(ctx context.Context, id int64) (sqlc.Book, error) {
-	if mock.GetBookFunc == nil {
-		panic("QuerentMock.GetBookFunc: method is nil but Querent.GetBook was just called")
+// RetryWebhookDelivery calls RetryWebhookDeliveryFunc.
+func (mock *QuerentMock) RetryWebhookDelivery(ctx context.Context, id int64) (sqlc.WebhookDelivery, error) {
+	if mock.RetryWebhookDeliveryFunc == nil {
+		panic("QuerentMock.RetryWebhookDeliveryFunc: method is nil but Querent.RetryWebhookDelivery was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
@@ -510,16 +3794,16 @@ func (mock *QuerentMock) GetBook(ctx context.Context, id int64) (sqlc.Book, erro
 		Ctx: ctx,
 		ID:  id,
 	}
-	lockQuerentMockGetBook.Lock()
-	mock.calls.GetBook = append(mock.calls.GetBook, callInfo)
-	lockQuerentMockGetBook.Unlock()
-	return mock.GetBookFunc(ctx, id)
+	lockQuerentMockRetryWebhookDelivery.Lock()
+	mock.calls.RetryWebhookDelivery = append(mock.calls.RetryWebhookDelivery, callInfo)
+	lockQuerentMockRetryWebhookDelivery.Unlock()
+	return mock.RetryWebhookDeliveryFunc(ctx, id)
 }
 
-// GetBookCalls gets all the calls that were made to GetBook.
+// RetryWebhookDeliveryCalls gets all the calls that were made to RetryWebhookDelivery.
 // Check the length with:
-//     len(mockedQuerent.GetBookCalls())
-func (mock *QuerentMock) GetBookCalls() []struct {
+//     len(mockedQuerent.RetryWebhookDeliveryCalls())
+func (mock *QuerentMock) RetryWebhookDeliveryCalls() []struct {
 	Ctx context.Context
 	ID  int64
 } {
@@ -527,276 +3811,288 @@ func (mock *QuerentMock) GetBookCalls() []struct {
 		Ctx context.Context
 		ID  int64
 	}
-	lockQuerentMockGetBook.RLock()
-	calls = mock.calls.GetBook
-	lockQuerentMockGetBook.RUnlock()
+	lockQuerentMockRetryWebhookDelivery.RLock()
+	calls = mock.calls.RetryWebhookDelivery
+	lockQuerentMockRetryWebhookDelivery.RUnlock()
 	return calls
 }
 
-// ListAgents calls ListAgentsFunc.
-func (mock *QuerentMock) ListAgents(ctx context.Context) ([]sqlc.Agent, error) {
-	if mock.ListAgentsFunc == nil {
-		panic("QuerentMock.ListAgentsFunc: method is nil but Querent.ListAgents was just called")
+// SetRoyaltyRate calls SetRoyaltyRateFunc.
+func (mock *QuerentMock) SetRoyaltyRate(ctx context.Context, args sqlc.SetRoyaltyRateParams) (sqlc.RoyaltyRate, error) {
+	if mock.SetRoyaltyRateFunc == nil {
+		panic("QuerentMock.SetRoyaltyRateFunc: method is nil but Querent.SetRoyaltyRate was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Args sqlc.SetRoyaltyRateParams
 	}{
-		Ctx: ctx,
+		Ctx:  ctx,
+		Args: args,
 	}
-	lockQuerentMockListAgents.Lock()
-	mock.calls.ListAgents = append(mock.calls.ListAgents, callInfo)
-	lockQuerentMockListAgents.Unlock()
-	return mock.ListAgentsFunc(ctx)
+	lockQuerentMockSetRoyaltyRate.Lock()
+	mock.calls.SetRoyaltyRate = append(mock.calls.SetRoyaltyRate, callInfo)
+	lockQuerentMockSetRoyaltyRate.Unlock()
+	return mock.SetRoyaltyRateFunc(ctx, args)
 }
 
-// ListAgentsCalls gets all the calls that were made to ListAgents.
+// SetRoyaltyRateCalls gets all the calls that were made to SetRoyaltyRate.
 // Check the length with:
-//     len(mockedQuerent.ListAgentsCalls())
-func (mock *QuerentMock) ListAgentsCalls() []struct {
-	Ctx context.Context
+//     len(mockedQuerent.SetRoyaltyRateCalls())
+func (mock *QuerentMock) SetRoyaltyRateCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.SetRoyaltyRateParams
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Args sqlc.SetRoyaltyRateParams
 	}
-	lockQuerentMockListAgents.RLock()
-	calls = mock.calls.ListAgents
-	lockQuerentMockListAgents.RUnlock()
+	lockQuerentMockSetRoyaltyRate.RLock()
+	calls = mock.calls.SetRoyaltyRate
+	lockQuerentMockSetRoyaltyRate.RUnlock()
 	return calls
 }
 
-// ListAuthors calls ListAuthorsFunc.
-func (mock *QuerentMock) ListAuthors(ctx context.Context) ([]sqlc.Author, error) {
-	if mock.ListAuthorsFunc == nil {
-		panic("QuerentMock.ListAuthorsFunc: method is nil but Querent.ListAuthors was just called")
+// UpdateAgency calls UpdateAgencyFunc.
+func (mock *QuerentMock) UpdateAgency(ctx context.Context, args sqlc.UpdateAgencyParams) (sqlc.Agency, error) {
+	if mock.UpdateAgencyFunc == nil {
+		panic("QuerentMock.UpdateAgencyFunc: method is nil but Querent.UpdateAgency was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Args sqlc.UpdateAgencyParams
 	}{
-		Ctx: ctx,
+		Ctx:  ctx,
+		Args: args,
 	}
-	lockQuerentMockListAuthors.Lock()
-	mock.calls.ListAuthors = append(mock.calls.ListAuthors, callInfo)
-	lockQuerentMockListAuthors.Unlock()
-	return mock.ListAuthorsFunc(ctx)
+	lockQuerentMockUpdateAgency.Lock()
+	mock.calls.UpdateAgency = append(mock.calls.UpdateAgency, callInfo)
+	lockQuerentMockUpdateAgency.Unlock()
+	return mock.UpdateAgencyFunc(ctx, args)
 }
 
-// ListAuthorsCalls gets all the calls that were made to ListAuthors.
+// UpdateAgencyCalls gets all the calls that were made to UpdateAgency.
 // Check the length with:
-//     len(mockedQuerent.ListAuthorsCalls())
-func (mock *QuerentMock) ListAuthorsCalls() []struct {
-	Ctx context.Context
+//     len(mockedQuerent.UpdateAgencyCalls())
+func (mock *QuerentMock) UpdateAgencyCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.UpdateAgencyParams
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Args sqlc.UpdateAgencyParams
 	}
-	lockQuerentMockListAuthors.RLock()
-	calls = mock.calls.ListAuthors
-	lockQuerentMockListAuthors.RUnlock()
+	lockQuerentMockUpdateAgency.RLock()
+	calls = mock.calls.UpdateAgency
+	lockQuerentMockUpdateAgency.RUnlock()
 	return calls
 }
 
-// ListAuthorsByAgentID calls ListAuthorsByAgentIDFunc.
-func (mock *QuerentMock) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Author, error) {
-	if mock.ListAuthorsByAgentIDFunc == nil {
-		panic("QuerentMock.ListAuthorsByAgentIDFunc: method is nil but Querent.ListAuthorsByAgentID was just called")
+// UpdateAgent calls UpdateAgentFunc.
+func (mock *QuerentMock) UpdateAgent(ctx context.Context, args sqlc.UpdateAgentParams) (sqlc.Agent, error) {
+	if mock.UpdateAgentFunc == nil {
+		panic("QuerentMock.UpdateAgentFunc: method is nil but Querent.UpdateAgent was just called")
 	}
 	callInfo := struct {
-		Ctx     context.Context
-		AgentID int64
+		Ctx  context.Context
+		Args sqlc.UpdateAgentParams
 	}{
-		Ctx:     ctx,
-		AgentID: agentID,
+		Ctx:  ctx,
+		Args: args,
 	}
-	lockQuerentMockListAuthorsByAgentID.Lock()
-	mock.calls.ListAuthorsByAgentID = append(mock.calls.ListAuthorsByAgentID, callInfo)
-	lockQuerentMockListAuthorsByAgentID.Unlock()
-	return mock.ListAuthorsByAgentIDFunc(ctx, agentID)
+	lockQuerentMockUpdateAgent.Lock()
+	mock.calls.UpdateAgent = append(mock.calls.UpdateAgent, callInfo)
+	lockQuerentMockUpdateAgent.Unlock()
+	return mock.UpdateAgentFunc(ctx, args)
 }
 
-// ListAuthorsByAgentIDCalls gets all the calls that were made to ListAuthorsByAgentID.
+// UpdateAgentCalls gets all the calls that were made to UpdateAgent.
 // Check the length with:
-//     len(mockedQuerent.ListAuthorsByAgentIDCalls())
-func (mock *QuerentMock) ListAuthorsByAgentIDCalls() []struct {
-	Ctx     context.Context
-	AgentID int64
+//     len(mockedQuerent.UpdateAgentCalls())
+func (mock *QuerentMock) UpdateAgentCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.UpdateAgentParams
 } {
 	var calls []struct {
-		Ctx     context.Context
-		AgentID int64
+		Ctx  context.Context
+		Args sqlc.UpdateAgentParams
 	}
-	lockQuerentMockListAuthorsByAgentID.RLock()
-	calls = mock.calls.ListAuthorsByAgentID
-	lockQuerentMockListAuthorsByAgentID.RUnlock()
+	lockQuerentMockUpdateAgent.RLock()
+	calls = mock.calls.UpdateAgent
+	lockQuerentMockUpdateAgent.RUnlock()
 	return calls
 }
 
-// ListAuthorsByBookID calls ListAuthorsByBookIDFunc.
-func (mock *QuerentMock) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]sqlc.Author, error) {
-	if mock.ListAuthorsByBookIDFunc == nil {
-		panic("QuerentMock.ListAuthorsByBookIDFunc: method is nil but Querent.ListAuthorsByBookID was just called")
+// UpdateDeal calls UpdateDealFunc.
+func (mock *QuerentMock) UpdateDeal(ctx context.Context, args sqlc.UpdateDealParams) (sqlc.Deal, error) {
+	if mock.UpdateDealFunc == nil {
+		panic("QuerentMock.UpdateDealFunc: method is nil but Querent.UpdateDeal was just called")
 	}
 	callInfo := struct {
-		Ctx    context.Context
-		BookID int64
+		Ctx  context.Context
+		Args sqlc.UpdateDealParams
 	}{
-		Ctx:    ctx,
-		BookID: bookID,
+		Ctx:  ctx,
+		Args: args,
 	}
-	lockQuerentMockListAuthorsByBookID.Lock()
-	mock.calls.ListAuthorsByBookID = append(mock.calls.ListAuthorsByBookID, callInfo)
-	lockQuerentMockListAuthorsByBookID.Unlock()
-	return mock.ListAuthorsByBookIDFunc(ctx, bookID)
+	lockQuerentMockUpdateDeal.Lock()
+	mock.calls.UpdateDeal = append(mock.calls.UpdateDeal, callInfo)
+	lockQuerentMockUpdateDeal.Unlock()
+	return mock.UpdateDealFunc(ctx, args)
 }
 
-// ListAuthorsByBookIDCalls gets all the calls that were made to ListAuthorsByBookID.
+// UpdateDealCalls gets all the calls that were made to UpdateDeal.
 // Check the length with:
-//     len(mockedQuerent.ListAuthorsByBookIDCalls())
-func (mock *QuerentMock) ListAuthorsByBookIDCalls() []struct {
-	Ctx    context.Context
-	BookID int64
+//     len(mockedQuerent.UpdateDealCalls())
+func (mock *QuerentMock) UpdateDealCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.UpdateDealParams
 } {
 	var calls []struct {
-		Ctx    context.Context
-		BookID int64
+		Ctx  context.Context
+		Args sqlc.UpdateDealParams
 	}
-	lockQuerentMockListAuthorsByBookID.RLock()
-	calls = mock.calls.ListAuthorsByBookID
-	lockQuerentMockListAuthorsByBookID.RUnlock()
+	lockQuerentMockUpdateDeal.RLock()
+	calls = mock.calls.UpdateDeal
+	lockQuerentMockUpdateDeal.RUnlock()
 	return calls
 }
 
-// ListBooks calls ListBooksFunc.
-func (mock *QuerentMock) ListBooks(ctx context.Context) ([]sqlc.Book, error) {
-	if mock.ListBooksFunc == nil {
-		panic("QuerentMock.ListBooksFunc: method is nil but Querent.ListBooks was just called")
+// UpdateEdition calls UpdateEditionFunc.
+func (mock *QuerentMock) UpdateEdition(ctx context.Context, args sqlc.UpdateEditionParams) (sqlc.Edition, error) {
+	if mock.UpdateEditionFunc == nil {
+		panic("QuerentMock.UpdateEditionFunc: method is nil but Querent.UpdateEdition was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Args sqlc.UpdateEditionParams
 	}{
-		Ctx: ctx,
+		Ctx:  ctx,
+		Args: args,
 	}
-	lockQuerentMockListBooks.Lock()
-	mock.calls.ListBooks = append(mock.calls.ListBooks, callInfo)
-	lockQuerentMockListBooks.Unlock()
-	return mock.ListBooksFunc(ctx)
+	lockQuerentMockUpdateEdition.Lock()
+	mock.calls.UpdateEdition = append(mock.calls.UpdateEdition, callInfo)
+	lockQuerentMockUpdateEdition.Unlock()
+	return mock.UpdateEditionFunc(ctx, args)
 }
 
-// ListBooksCalls gets all the calls that were made to ListBooks.
+// UpdateEditionCalls gets all the calls that were made to UpdateEdition.
 // Check the length with:
-//     len(mockedQuerent.ListBooksCalls())
-func (mock *QuerentMock) ListBooksCalls() []struct {
-	Ctx context.Context
+//     len(mockedQuerent.UpdateEditionCalls())
+func (mock *QuerentMock) UpdateEditionCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.UpdateEditionParams
 } {
 	var calls []struct {
-		Ctx context.Context
+		Ctx  context.Context
+		Args sqlc.UpdateEditionParams
 	}
-	lockQuerentMockListBooks.RLock()
-	calls = mock.calls.ListBooks
-	lockQuerentMockListBooks.RUnlock()
+	lockQuerentMockUpdateEdition.RLock()
+	calls = mock.calls.UpdateEdition
+	lockQuerentMockUpdateEdition.RUnlock()
 	return calls
 }
 
-// ListBooksByAuthorID calls ListBooksByAuthorIDFunc.
-func (mock *QuerentMock) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Book, error) {
-	if mock.ListBooksByAuthorIDFunc == nil {
-		panic("QuerentMock.ListBooksByAuthorIDFunc: method is nil but Querent.ListBooksByAuthorID was just called")
+// UpdatePublisher calls UpdatePublisherFunc.
+func (mock *QuerentMock) UpdatePublisher(ctx context.Context, args sqlc.UpdatePublisherParams) (sqlc.Publisher, error) {
+	if mock.UpdatePublisherFunc == nil {
+		panic("QuerentMock.UpdatePublisherFunc: method is nil but Querent.UpdatePublisher was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		AuthorID int64
+		Ctx  context.Context
+		Args sqlc.UpdatePublisherParams
 	}{
-		Ctx:      ctx,
-		AuthorID: authorID,
+		Ctx:  ctx,
+		Args: args,
 	}
-	lockQuerentMockListBooksByAuthorID.Lock()
-	mock.calls.ListBooksByAuthorID = append(mock.calls.ListBooksByAuthorID, callInfo)
-	lockQuerentMockListBooksByAuthorID.Unlock()
-	return mock.ListBooksByAuthorIDFunc(ctx, authorID)
+	lockQuerentMockUpdatePublisher.Lock()
+	mock.calls.UpdatePublisher = append(mock.calls.UpdatePublisher, callInfo)
+	lockQuerentMockUpdatePublisher.Unlock()
+	return mock.UpdatePublisherFunc(ctx, args)
 }
 
-// ListBooksByAuthorIDCalls gets all the calls that were made to ListBooksByAuthorID.
+// UpdatePublisherCalls gets all the calls that were made to UpdatePublisher.
 // Check the length with:
-//     len(mockedQuerent.ListBooksByAuthorIDCalls())
-func (mock *QuerentMock) ListBooksByAuthorIDCalls() []struct {
-	Ctx      context.Context
-	AuthorID int64
+//     len(mockedQuerent.UpdatePublisherCalls())
+func (mock *QuerentMock) UpdatePublisherCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.UpdatePublisherParams
 } {
 	var calls []struct {
-		Ctx      context.Context
-		AuthorID int64
+		Ctx  context.Context
+		Args sqlc.UpdatePublisherParams
 	}
-	lockQuerentMockListBooksByAuthorID.RLock()
-	calls = mock.calls.ListBooksByAuthorID
-	lockQuerentMockListBooksByAuthorID.RUnlock()
+	lockQuerentMockUpdatePublisher.RLock()
+	calls = mock.calls.UpdatePublisher
+	lockQuerentMockUpdatePublisher.RUnlock()
 	return calls
 }
 
-// UpdateAgent calls UpdateAgentFunc.
-func (mock *QuerentMock) UpdateAgent(ctx context.Context, args sqlc.UpdateAgentParams) (sqlc.Agent, error) {
-	if mock.UpdateAgentFunc == nil {
-		panic("QuerentMock.UpdateAgentFunc: method is nil but Querent.UpdateAgent was just called")
+// UpdateSeries calls UpdateSeriesFunc.
+func (mock *QuerentMock) UpdateSeries(ctx context.Context, args sqlc.UpdateSeriesParams) (sqlc.Series, error) {
+	if mock.UpdateSeriesFunc == nil {
+		panic("QuerentMock.UpdateSeriesFunc: method is nil but Querent.UpdateSeries was just called")
 	}
 	callInfo := struct {
 		Ctx  context.Context
-		Args sqlc.UpdateAgentParams
+		Args sqlc.UpdateSeriesParams
 	}{
 		Ctx:  ctx,
 		Args: args,
 	}
-	lockQuerentMockUpdateAgent.Lock()
-	mock.calls.UpdateAgent = append(mock.calls.UpdateAgent, callInfo)
-	lockQuerentMockUpdateAgent.Unlock()
-	return mock.UpdateAgentFunc(ctx, args)
+	lockQuerentMockUpdateSeries.Lock()
+	mock.calls.UpdateSeries = append(mock.calls.UpdateSeries, callInfo)
+	lockQuerentMockUpdateSeries.Unlock()
+	return mock.UpdateSeriesFunc(ctx, args)
 }
 
-// UpdateAgentCalls gets all the calls that were made to UpdateAgent.
+// UpdateSeriesCalls gets all the calls that were made to UpdateSeries.
 // Check the length with:
-//     len(mockedQuerent.UpdateAgentCalls())
-func (mock *QuerentMock) UpdateAgentCalls() []struct {
+//     len(mockedQuerent.UpdateSeriesCalls())
+func (mock *QuerentMock) UpdateSeriesCalls() []struct {
 	Ctx  context.Context
-	Args sqlc.UpdateAgentParams
+	Args sqlc.UpdateSeriesParams
 } {
 	var calls []struct {
 		Ctx  context.Context
-		Args sqlc.UpdateAgentParams
+		Args sqlc.UpdateSeriesParams
 	}
-	lockQuerentMockUpdateAgent.RLock()
-	calls = mock.calls.UpdateAgent
-	lockQuerentMockUpdateAgent.RUnlock()
+	lockQuerentMockUpdateSeries.RLock()
+	calls = mock.calls.UpdateSeries
+	lockQuerentMockUpdateSeries.RUnlock()
 	return calls
 }
 
-// UpdateAuthor calls UpdateAuthorFunc.
-func (mock *QuerentMock) UpdateAuthor(ctx context.Context, args sqlc.UpdateAuthorParams) (sqlc.Author, error) {
-	if mock.UpdateAuthorFunc == nil {
-		panic("QuerentMock.UpdateAuthorFunc: method is nil but Querent.UpdateAuthor was just called")
+// UpdateWebhook calls UpdateWebhookFunc.
+func (mock *QuerentMock) UpdateWebhook(ctx context.Context, args sqlc.UpdateWebhookParams) (sqlc.Webhook, error) {
+	if mock.UpdateWebhookFunc == nil {
+		panic("QuerentMock.UpdateWebhookFunc: method is nil but Querent.UpdateWebhook was just called")
 	}
 	callInfo := struct {
 		Ctx  context.Context
-		Args sqlc.UpdateAuthorParams
+		Args sqlc.UpdateWebhookParams
 	}{
 		Ctx:  ctx,
 		Args: args,
 	}
-	lockQuerentMockUpdateAuthor.Lock()
-	mock.calls.UpdateAuthor = append(mock.calls.UpdateAuthor, callInfo)
-	lockQuerentMockUpdateAuthor.Unlock()
-	return mock.UpdateAuthorFunc(ctx, args)
+	lockQuerentMockUpdateWebhook.Lock()
+	mock.calls.UpdateWebhook = append(mock.calls.UpdateWebhook, callInfo)
+	lockQuerentMockUpdateWebhook.Unlock()
+	return mock.UpdateWebhookFunc(ctx, args)
 }
 
-// UpdateAuthorCalls gets all the calls that were made to UpdateAuthor.
+// UpdateWebhookCalls gets all the calls that were made to UpdateWebhook.
 // Check the length with:
-//     len(mockedQuerent.UpdateAuthorCalls())
-func (mock *QuerentMock) UpdateAuthorCalls() []struct {
+//     len(mockedQuerent.UpdateWebhookCalls())
+func (mock *QuerentMock) UpdateWebhookCalls() []struct {
 	Ctx  context.Context
-	Args sqlc.UpdateAuthorParams
+	Args sqlc.UpdateWebhookParams
 } {
 	var calls []struct {
 		Ctx  context.Context
-		Args sqlc.UpdateAuthorParams
+		Args sqlc.UpdateWebhookParams
 	}
-	lockQuerentMockUpdateAuthor.RLock()
-	calls = mock.calls.UpdateAuthor
-	lockQuerentMockUpdateAuthor.RUnlock()
+	lockQuerentMockUpdateWebhook.RLock()
+	calls = mock.calls.UpdateWebhook
+	lockQuerentMockUpdateWebhook.RUnlock()
 	return calls
 }
@@ -0,0 +1,4823 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/fwojciec/litag-example/domain"
+	"sync"
+)
+
+var (
+	lockRepositoryMockConvertSubmission                 sync.RWMutex
+	lockRepositoryMockCreateAgency                      sync.RWMutex
+	lockRepositoryMockCreateAgent                       sync.RWMutex
+	lockRepositoryMockCreateAgents                      sync.RWMutex
+	lockRepositoryMockCreateAuthor                      sync.RWMutex
+	lockRepositoryMockCreateAuthors                     sync.RWMutex
+	lockRepositoryMockCreateBook                        sync.RWMutex
+	lockRepositoryMockCreateBooks                       sync.RWMutex
+	lockRepositoryMockCreateDeal                        sync.RWMutex
+	lockRepositoryMockCreateEdition                     sync.RWMutex
+	lockRepositoryMockCreateGenre                       sync.RWMutex
+	lockRepositoryMockCreatePublisher                   sync.RWMutex
+	lockRepositoryMockCreateRepresentation              sync.RWMutex
+	lockRepositoryMockCreateSeries                      sync.RWMutex
+	lockRepositoryMockCreateSubmission                  sync.RWMutex
+	lockRepositoryMockCreateWebhook                     sync.RWMutex
+	lockRepositoryMockDeleteAgency                      sync.RWMutex
+	lockRepositoryMockDeleteAgent                       sync.RWMutex
+	lockRepositoryMockDeleteAuthor                      sync.RWMutex
+	lockRepositoryMockDeleteBook                        sync.RWMutex
+	lockRepositoryMockDeleteDeal                        sync.RWMutex
+	lockRepositoryMockDeleteEdition                     sync.RWMutex
+	lockRepositoryMockDeleteGenre                       sync.RWMutex
+	lockRepositoryMockDeletePublisher                   sync.RWMutex
+	lockRepositoryMockDeleteRoyaltyRate                 sync.RWMutex
+	lockRepositoryMockDeleteSales                       sync.RWMutex
+	lockRepositoryMockDeleteSeries                      sync.RWMutex
+	lockRepositoryMockDeleteWebhook                     sync.RWMutex
+	lockRepositoryMockEndRepresentation                 sync.RWMutex
+	lockRepositoryMockGetAgency                         sync.RWMutex
+	lockRepositoryMockGetAgent                          sync.RWMutex
+	lockRepositoryMockGetAuthor                         sync.RWMutex
+	lockRepositoryMockGetBook                           sync.RWMutex
+	lockRepositoryMockGetBookByISBN                     sync.RWMutex
+	lockRepositoryMockGetDeal                           sync.RWMutex
+	lockRepositoryMockGetEdition                        sync.RWMutex
+	lockRepositoryMockGetEditionByISBN                  sync.RWMutex
+	lockRepositoryMockGetGenre                          sync.RWMutex
+	lockRepositoryMockGetPublisher                      sync.RWMutex
+	lockRepositoryMockGetRoyaltyStatement               sync.RWMutex
+	lockRepositoryMockGetSeries                         sync.RWMutex
+	lockRepositoryMockGetSubmission                     sync.RWMutex
+	lockRepositoryMockGetWebhook                        sync.RWMutex
+	lockRepositoryMockListAgencies                      sync.RWMutex
+	lockRepositoryMockListAgents                        sync.RWMutex
+	lockRepositoryMockListAgentsByAgencyID              sync.RWMutex
+	lockRepositoryMockListAuthors                       sync.RWMutex
+	lockRepositoryMockListAuthorsByAgencyID             sync.RWMutex
+	lockRepositoryMockListAuthorsByAgentID              sync.RWMutex
+	lockRepositoryMockListAuthorsByBookID               sync.RWMutex
+	lockRepositoryMockListAvailableRights               sync.RWMutex
+	lockRepositoryMockListBooks                         sync.RWMutex
+	lockRepositoryMockListBooksByAgencyID               sync.RWMutex
+	lockRepositoryMockListBooksByAuthorID               sync.RWMutex
+	lockRepositoryMockListBooksByGenreID                sync.RWMutex
+	lockRepositoryMockListBooksByPublisherID            sync.RWMutex
+	lockRepositoryMockListBooksBySeriesID               sync.RWMutex
+	lockRepositoryMockListBooksByTag                    sync.RWMutex
+	lockRepositoryMockListContributorsByBookID          sync.RWMutex
+	lockRepositoryMockListDealsByAgentID                sync.RWMutex
+	lockRepositoryMockListDealsByBookID                 sync.RWMutex
+	lockRepositoryMockListEditions                      sync.RWMutex
+	lockRepositoryMockListEditionsByBookID              sync.RWMutex
+	lockRepositoryMockListFormerAuthorsByAgentID        sync.RWMutex
+	lockRepositoryMockListGenres                        sync.RWMutex
+	lockRepositoryMockListGenresByBookID                sync.RWMutex
+	lockRepositoryMockListGenresByParentID              sync.RWMutex
+	lockRepositoryMockListOrphanBooks                   sync.RWMutex
+	lockRepositoryMockListPublishers                    sync.RWMutex
+	lockRepositoryMockListRepresentationsByAuthorID     sync.RWMutex
+	lockRepositoryMockListRoyaltyRatesByDealID          sync.RWMutex
+	lockRepositoryMockListSalesByDealID                 sync.RWMutex
+	lockRepositoryMockListSeries                        sync.RWMutex
+	lockRepositoryMockListSubmissionTransitions         sync.RWMutex
+	lockRepositoryMockListSubmissionsByAgentID          sync.RWMutex
+	lockRepositoryMockListSubmissionsByAgentIDAndStatus sync.RWMutex
+	lockRepositoryMockListTagsByBookID                  sync.RWMutex
+	lockRepositoryMockListWebhookDeliveries             sync.RWMutex
+	lockRepositoryMockListWebhookDeliveriesByStatus     sync.RWMutex
+	lockRepositoryMockListWebhooks                      sync.RWMutex
+	lockRepositoryMockRecordSales                       sync.RWMutex
+	lockRepositoryMockReorderSeries                     sync.RWMutex
+	lockRepositoryMockRetryWebhookDelivery              sync.RWMutex
+	lockRepositoryMockSetRoyaltyRate                    sync.RWMutex
+	lockRepositoryMockTransitionSubmission              sync.RWMutex
+	lockRepositoryMockUpdateAgency                      sync.RWMutex
+	lockRepositoryMockUpdateAgent                       sync.RWMutex
+	lockRepositoryMockUpdateAuthor                      sync.RWMutex
+	lockRepositoryMockUpdateBook                        sync.RWMutex
+	lockRepositoryMockUpdateBooks                       sync.RWMutex
+	lockRepositoryMockUpdateDeal                        sync.RWMutex
+	lockRepositoryMockUpdateEdition                     sync.RWMutex
+	lockRepositoryMockUpdateGenre                       sync.RWMutex
+	lockRepositoryMockUpdatePublisher                   sync.RWMutex
+	lockRepositoryMockUpdateSeries                      sync.RWMutex
+	lockRepositoryMockUpdateWebhook                     sync.RWMutex
+)
+
+// Ensure, that RepositoryMock does implement domain.Repository.
+// If this is not the case, regenerate this file with moq.
+var _ domain.Repository = &RepositoryMock{}
+
+// RepositoryMock is a mock implementation of domain.Repository.
+//
+//     func TestSomethingThatUsesRepository(t *testing.T) {
+//
+//         // make and configure a mocked domain.Repository
+//         mockedRepository := &RepositoryMock{
+//             ConvertSubmissionFunc: func(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+// 	               panic("mock out the ConvertSubmission method")
+//             },
+//             CreateAgencyFunc: func(ctx context.Context, args domain.CreateAgencyParams) (domain.Agency, error) {
+// 	               panic("mock out the CreateAgency method")
+//             },
+//             CreateAgentFunc: func(ctx context.Context, args domain.CreateAgentParams) (domain.Agent, error) {
+// 	               panic("mock out the CreateAgent method")
+//             },
+//             CreateAgentsFunc: func(ctx context.Context, args []domain.CreateAgentParams, mode domain.BulkMode) (*domain.BulkAgentsResult, error) {
+// 	               panic("mock out the CreateAgents method")
+//             },
+//             CreateAuthorFunc: func(ctx context.Context, args domain.CreateAuthorParams) (*domain.Author, error) {
+// 	               panic("mock out the CreateAuthor method")
+//             },
+//             CreateAuthorsFunc: func(ctx context.Context, args []domain.CreateAuthorParams, mode domain.BulkMode) (*domain.BulkAuthorsResult, error) {
+// 	               panic("mock out the CreateAuthors method")
+//             },
+//             CreateBookFunc: func(ctx context.Context, args domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+// 	               panic("mock out the CreateBook method")
+//             },
+//             CreateBooksFunc: func(ctx context.Context, args []domain.BulkCreateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+// 	               panic("mock out the CreateBooks method")
+//             },
+//             CreateDealFunc: func(ctx context.Context, args domain.CreateDealParams) (domain.Deal, error) {
+// 	               panic("mock out the CreateDeal method")
+//             },
+//             CreateEditionFunc: func(ctx context.Context, args domain.CreateEditionParams) (domain.Edition, error) {
+// 	               panic("mock out the CreateEdition method")
+//             },
+//             CreateGenreFunc: func(ctx context.Context, args domain.CreateGenreParams) (domain.Genre, error) {
+// 	               panic("mock out the CreateGenre method")
+//             },
+//             CreatePublisherFunc: func(ctx context.Context, args domain.CreatePublisherParams) (domain.Publisher, error) {
+// 	               panic("mock out the CreatePublisher method")
+//             },
+//             CreateRepresentationFunc: func(ctx context.Context, args domain.CreateRepresentationParams) (domain.Representation, error) {
+// 	               panic("mock out the CreateRepresentation method")
+//             },
+//             CreateSeriesFunc: func(ctx context.Context, args domain.CreateSeriesParams) (domain.Series, error) {
+// 	               panic("mock out the CreateSeries method")
+//             },
+//             CreateSubmissionFunc: func(ctx context.Context, args domain.CreateSubmissionParams) (domain.Submission, error) {
+// 	               panic("mock out the CreateSubmission method")
+//             },
+//             CreateWebhookFunc: func(ctx context.Context, args domain.CreateWebhookParams) (domain.Webhook, error) {
+// 	               panic("mock out the CreateWebhook method")
+//             },
+//             DeleteAgencyFunc: func(ctx context.Context, id int64) (domain.Agency, error) {
+// 	               panic("mock out the DeleteAgency method")
+//             },
+//             DeleteAgentFunc: func(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+// 	               panic("mock out the DeleteAgent method")
+//             },
+//             DeleteAuthorFunc: func(ctx context.Context, id int64, orphanedBooks domain.OrphanedBooksPolicy) (*domain.Author, error) {
+// 	               panic("mock out the DeleteAuthor method")
+//             },
+//             DeleteBookFunc: func(ctx context.Context, id int64) (*domain.Book, error) {
+// 	               panic("mock out the DeleteBook method")
+//             },
+//             DeleteDealFunc: func(ctx context.Context, id int64) (domain.Deal, error) {
+// 	               panic("mock out the DeleteDeal method")
+//             },
+//             DeleteEditionFunc: func(ctx context.Context, id int64) (domain.Edition, error) {
+// 	               panic("mock out the DeleteEdition method")
+//             },
+//             DeleteGenreFunc: func(ctx context.Context, id int64) (domain.Genre, error) {
+// 	               panic("mock out the DeleteGenre method")
+//             },
+//             DeletePublisherFunc: func(ctx context.Context, id int64) (domain.Publisher, error) {
+// 	               panic("mock out the DeletePublisher method")
+//             },
+//             DeleteRoyaltyRateFunc: func(ctx context.Context, dealID int64, editionID int64) (domain.RoyaltyRate, error) {
+// 	               panic("mock out the DeleteRoyaltyRate method")
+//             },
+//             DeleteSalesFunc: func(ctx context.Context, id int64) (domain.Sales, error) {
+// 	               panic("mock out the DeleteSales method")
+//             },
+//             DeleteSeriesFunc: func(ctx context.Context, id int64) (*domain.Series, error) {
+// 	               panic("mock out the DeleteSeries method")
+//             },
+//             DeleteWebhookFunc: func(ctx context.Context, id int64) (domain.Webhook, error) {
+// 	               panic("mock out the DeleteWebhook method")
+//             },
+//             EndRepresentationFunc: func(ctx context.Context, id int64, endedAt domain.Date) (domain.Representation, error) {
+// 	               panic("mock out the EndRepresentation method")
+//             },
+//             GetAgencyFunc: func(ctx context.Context, id int64) (domain.Agency, error) {
+// 	               panic("mock out the GetAgency method")
+//             },
+//             GetAgentFunc: func(ctx context.Context, id int64) (domain.Agent, error) {
+// 	               panic("mock out the GetAgent method")
+//             },
+//             GetAuthorFunc: func(ctx context.Context, id int64) (domain.Author, error) {
+// 	               panic("mock out the GetAuthor method")
+//             },
+//             GetBookFunc: func(ctx context.Context, id int64) (domain.Book, error) {
+// 	               panic("mock out the GetBook method")
+//             },
+//             GetBookByISBNFunc: func(ctx context.Context, isbn domain.ISBN) (domain.Book, error) {
+// 	               panic("mock out the GetBookByISBN method")
+//             },
+//             GetDealFunc: func(ctx context.Context, id int64) (domain.Deal, error) {
+// 	               panic("mock out the GetDeal method")
+//             },
+//             GetEditionFunc: func(ctx context.Context, id int64) (domain.Edition, error) {
+// 	               panic("mock out the GetEdition method")
+//             },
+//             GetEditionByISBNFunc: func(ctx context.Context, isbn domain.ISBN) (domain.Edition, error) {
+// 	               panic("mock out the GetEditionByISBN method")
+//             },
+//             GetGenreFunc: func(ctx context.Context, id int64) (domain.Genre, error) {
+// 	               panic("mock out the GetGenre method")
+//             },
+//             GetPublisherFunc: func(ctx context.Context, id int64) (domain.Publisher, error) {
+// 	               panic("mock out the GetPublisher method")
+//             },
+//             GetRoyaltyStatementFunc: func(ctx context.Context, authorID int64, period string) (domain.RoyaltyStatement, error) {
+// 	               panic("mock out the GetRoyaltyStatement method")
+//             },
+//             GetSeriesFunc: func(ctx context.Context, id int64) (domain.Series, error) {
+// 	               panic("mock out the GetSeries method")
+//             },
+//             GetSubmissionFunc: func(ctx context.Context, id int64) (domain.Submission, error) {
+// 	               panic("mock out the GetSubmission method")
+//             },
+//             GetWebhookFunc: func(ctx context.Context, id int64) (domain.Webhook, error) {
+// 	               panic("mock out the GetWebhook method")
+//             },
+//             ListAgenciesFunc: func(ctx context.Context) ([]domain.Agency, error) {
+// 	               panic("mock out the ListAgencies method")
+//             },
+//             ListAgentsFunc: func(ctx context.Context) ([]domain.Agent, error) {
+// 	               panic("mock out the ListAgents method")
+//             },
+//             ListAgentsByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Agent, error) {
+// 	               panic("mock out the ListAgentsByAgencyID method")
+//             },
+//             ListAuthorsFunc: func(ctx context.Context) ([]domain.Author, error) {
+// 	               panic("mock out the ListAuthors method")
+//             },
+//             ListAuthorsByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Author, error) {
+// 	               panic("mock out the ListAuthorsByAgencyID method")
+//             },
+//             ListAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Author, error) {
+// 	               panic("mock out the ListAuthorsByAgentID method")
+//             },
+//             ListAuthorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Author, error) {
+// 	               panic("mock out the ListAuthorsByBookID method")
+//             },
+//             ListAvailableRightsFunc: func(ctx context.Context, territory string, language domain.LanguageCode) ([]domain.AvailableRights, error) {
+// 	               panic("mock out the ListAvailableRights method")
+//             },
+//             ListBooksFunc: func(ctx context.Context) ([]domain.Book, error) {
+// 	               panic("mock out the ListBooks method")
+//             },
+//             ListBooksByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Book, error) {
+// 	               panic("mock out the ListBooksByAgencyID method")
+//             },
+//             ListBooksByAuthorIDFunc: func(ctx context.Context, authorID int64) ([]domain.Book, error) {
+// 	               panic("mock out the ListBooksByAuthorID method")
+//             },
+//             ListBooksByGenreIDFunc: func(ctx context.Context, genreID int64, includeSubgenres bool) ([]domain.Book, error) {
+// 	               panic("mock out the ListBooksByGenreID method")
+//             },
+//             ListBooksByPublisherIDFunc: func(ctx context.Context, publisherID int64) ([]domain.Book, error) {
+// 	               panic("mock out the ListBooksByPublisherID method")
+//             },
+//             ListBooksBySeriesIDFunc: func(ctx context.Context, seriesID int64) ([]domain.Book, error) {
+// 	               panic("mock out the ListBooksBySeriesID method")
+//             },
+//             ListBooksByTagFunc: func(ctx context.Context, tag string) ([]domain.Book, error) {
+// 	               panic("mock out the ListBooksByTag method")
+//             },
+//             ListContributorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Contributor, error) {
+// 	               panic("mock out the ListContributorsByBookID method")
+//             },
+//             ListDealsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Deal, error) {
+// 	               panic("mock out the ListDealsByAgentID method")
+//             },
+//             ListDealsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Deal, error) {
+// 	               panic("mock out the ListDealsByBookID method")
+//             },
+//             ListEditionsFunc: func(ctx context.Context, args domain.ListEditionsParams) ([]domain.Edition, error) {
+// 	               panic("mock out the ListEditions method")
+//             },
+//             ListEditionsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Edition, error) {
+// 	               panic("mock out the ListEditionsByBookID method")
+//             },
+//             ListFormerAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Author, error) {
+// 	               panic("mock out the ListFormerAuthorsByAgentID method")
+//             },
+//             ListGenresFunc: func(ctx context.Context) ([]domain.Genre, error) {
+// 	               panic("mock out the ListGenres method")
+//             },
+//             ListGenresByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Genre, error) {
+// 	               panic("mock out the ListGenresByBookID method")
+//             },
+//             ListGenresByParentIDFunc: func(ctx context.Context, parentID int64) ([]domain.Genre, error) {
+// 	               panic("mock out the ListGenresByParentID method")
+//             },
+//             ListOrphanBooksFunc: func(ctx context.Context) ([]domain.Book, error) {
+// 	               panic("mock out the ListOrphanBooks method")
+//             },
+//             ListPublishersFunc: func(ctx context.Context) ([]domain.Publisher, error) {
+// 	               panic("mock out the ListPublishers method")
+//             },
+//             ListRepresentationsByAuthorIDFunc: func(ctx context.Context, authorID int64) ([]domain.Representation, error) {
+// 	               panic("mock out the ListRepresentationsByAuthorID method")
+//             },
+//             ListRoyaltyRatesByDealIDFunc: func(ctx context.Context, dealID int64) ([]domain.RoyaltyRate, error) {
+// 	               panic("mock out the ListRoyaltyRatesByDealID method")
+//             },
+//             ListSalesByDealIDFunc: func(ctx context.Context, dealID int64) ([]domain.Sales, error) {
+// 	               panic("mock out the ListSalesByDealID method")
+//             },
+//             ListSeriesFunc: func(ctx context.Context) ([]domain.Series, error) {
+// 	               panic("mock out the ListSeries method")
+//             },
+//             ListSubmissionTransitionsFunc: func(ctx context.Context, submissionID int64) ([]domain.SubmissionTransition, error) {
+// 	               panic("mock out the ListSubmissionTransitions method")
+//             },
+//             ListSubmissionsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Submission, error) {
+// 	               panic("mock out the ListSubmissionsByAgentID method")
+//             },
+//             ListSubmissionsByAgentIDAndStatusFunc: func(ctx context.Context, args domain.ListSubmissionsByAgentIDAndStatusParams) ([]domain.Submission, error) {
+// 	               panic("mock out the ListSubmissionsByAgentIDAndStatus method")
+//             },
+//             ListTagsByBookIDFunc: func(ctx context.Context, bookID int64) ([]string, error) {
+// 	               panic("mock out the ListTagsByBookID method")
+//             },
+//             ListWebhookDeliveriesFunc: func(ctx context.Context, webhookID int64) ([]domain.WebhookDelivery, error) {
+// 	               panic("mock out the ListWebhookDeliveries method")
+//             },
+//             ListWebhookDeliveriesByStatusFunc: func(ctx context.Context, args domain.ListWebhookDeliveriesByStatusParams) ([]domain.WebhookDelivery, error) {
+// 	               panic("mock out the ListWebhookDeliveriesByStatus method")
+//             },
+//             ListWebhooksFunc: func(ctx context.Context) ([]domain.Webhook, error) {
+// 	               panic("mock out the ListWebhooks method")
+//             },
+//             RecordSalesFunc: func(ctx context.Context, args domain.RecordSalesParams) (domain.Sales, error) {
+// 	               panic("mock out the RecordSales method")
+//             },
+//             ReorderSeriesFunc: func(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+// 	               panic("mock out the ReorderSeries method")
+//             },
+//             RetryWebhookDeliveryFunc: func(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+// 	               panic("mock out the RetryWebhookDelivery method")
+//             },
+//             SetRoyaltyRateFunc: func(ctx context.Context, dealID int64, editionID int64, rate int) (domain.RoyaltyRate, error) {
+// 	               panic("mock out the SetRoyaltyRate method")
+//             },
+//             TransitionSubmissionFunc: func(ctx context.Context, id int64, status domain.SubmissionStatus, note *string) (*domain.Submission, error) {
+// 	               panic("mock out the TransitionSubmission method")
+//             },
+//             UpdateAgencyFunc: func(ctx context.Context, args domain.UpdateAgencyParams) (domain.Agency, error) {
+// 	               panic("mock out the UpdateAgency method")
+//             },
+//             UpdateAgentFunc: func(ctx context.Context, args domain.UpdateAgentParams) (domain.Agent, error) {
+// 	               panic("mock out the UpdateAgent method")
+//             },
+//             UpdateAuthorFunc: func(ctx context.Context, args domain.UpdateAuthorParams) (*domain.Author, error) {
+// 	               panic("mock out the UpdateAuthor method")
+//             },
+//             UpdateBookFunc: func(ctx context.Context, args domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+// 	               panic("mock out the UpdateBook method")
+//             },
+//             UpdateBooksFunc: func(ctx context.Context, args []domain.BulkUpdateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+// 	               panic("mock out the UpdateBooks method")
+//             },
+//             UpdateDealFunc: func(ctx context.Context, args domain.UpdateDealParams) (domain.Deal, error) {
+// 	               panic("mock out the UpdateDeal method")
+//             },
+//             UpdateEditionFunc: func(ctx context.Context, args domain.UpdateEditionParams) (domain.Edition, error) {
+// 	               panic("mock out the UpdateEdition method")
+//             },
+//             UpdateGenreFunc: func(ctx context.Context, args domain.UpdateGenreParams) (*domain.Genre, error) {
+// 	               panic("mock out the UpdateGenre method")
+//             },
+//             UpdatePublisherFunc: func(ctx context.Context, args domain.UpdatePublisherParams) (domain.Publisher, error) {
+// 	               panic("mock out the UpdatePublisher method")
+//             },
+//             UpdateSeriesFunc: func(ctx context.Context, args domain.UpdateSeriesParams) (domain.Series, error) {
+// 	               panic("mock out the UpdateSeries method")
+//             },
+//             UpdateWebhookFunc: func(ctx context.Context, args domain.UpdateWebhookParams) (domain.Webhook, error) {
+// 	               panic("mock out the UpdateWebhook method")
+//             },
+//         }
+//
+//         // use mockedRepository in code that requires domain.Repository
+//         // and then make assertions.
+//
+//     }
+type RepositoryMock struct {
+	// ConvertSubmissionFunc mocks the ConvertSubmission method.
+	ConvertSubmissionFunc func(ctx context.Context, id int64, cover string) (*domain.Book, error)
+
+	// CreateAgencyFunc mocks the CreateAgency method.
+	CreateAgencyFunc func(ctx context.Context, args domain.CreateAgencyParams) (domain.Agency, error)
+
+	// CreateAgentFunc mocks the CreateAgent method.
+	CreateAgentFunc func(ctx context.Context, args domain.CreateAgentParams) (domain.Agent, error)
+
+	// CreateAgentsFunc mocks the CreateAgents method.
+	CreateAgentsFunc func(ctx context.Context, args []domain.CreateAgentParams, mode domain.BulkMode) (*domain.BulkAgentsResult, error)
+
+	// CreateAuthorFunc mocks the CreateAuthor method.
+	CreateAuthorFunc func(ctx context.Context, args domain.CreateAuthorParams) (*domain.Author, error)
+
+	// CreateAuthorsFunc mocks the CreateAuthors method.
+	CreateAuthorsFunc func(ctx context.Context, args []domain.CreateAuthorParams, mode domain.BulkMode) (*domain.BulkAuthorsResult, error)
+
+	// CreateBookFunc mocks the CreateBook method.
+	CreateBookFunc func(ctx context.Context, args domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error)
+
+	// CreateBooksFunc mocks the CreateBooks method.
+	CreateBooksFunc func(ctx context.Context, args []domain.BulkCreateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error)
+
+	// CreateDealFunc mocks the CreateDeal method.
+	CreateDealFunc func(ctx context.Context, args domain.CreateDealParams) (domain.Deal, error)
+
+	// CreateEditionFunc mocks the CreateEdition method.
+	CreateEditionFunc func(ctx context.Context, args domain.CreateEditionParams) (domain.Edition, error)
+
+	// CreateGenreFunc mocks the CreateGenre method.
+	CreateGenreFunc func(ctx context.Context, args domain.CreateGenreParams) (domain.Genre, error)
+
+	// CreatePublisherFunc mocks the CreatePublisher method.
+	CreatePublisherFunc func(ctx context.Context, args domain.CreatePublisherParams) (domain.Publisher, error)
+
+	// CreateRepresentationFunc mocks the CreateRepresentation method.
+	CreateRepresentationFunc func(ctx context.Context, args domain.CreateRepresentationParams) (domain.Representation, error)
+
+	// CreateSeriesFunc mocks the CreateSeries method.
+	CreateSeriesFunc func(ctx context.Context, args domain.CreateSeriesParams) (domain.Series, error)
+
+	// CreateSubmissionFunc mocks the CreateSubmission method.
+	CreateSubmissionFunc func(ctx context.Context, args domain.CreateSubmissionParams) (domain.Submission, error)
+
+	// CreateWebhookFunc mocks the CreateWebhook method.
+	CreateWebhookFunc func(ctx context.Context, args domain.CreateWebhookParams) (domain.Webhook, error)
+
+	// DeleteAgencyFunc mocks the DeleteAgency method.
+	DeleteAgencyFunc func(ctx context.Context, id int64) (domain.Agency, error)
+
+	// DeleteAgentFunc mocks the DeleteAgent method.
+	DeleteAgentFunc func(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error)
+
+	// DeleteAuthorFunc mocks the DeleteAuthor method.
+	DeleteAuthorFunc func(ctx context.Context, id int64, orphanedBooks domain.OrphanedBooksPolicy) (*domain.Author, error)
+
+	// DeleteBookFunc mocks the DeleteBook method.
+	DeleteBookFunc func(ctx context.Context, id int64) (*domain.Book, error)
+
+	// DeleteDealFunc mocks the DeleteDeal method.
+	DeleteDealFunc func(ctx context.Context, id int64) (domain.Deal, error)
+
+	// DeleteEditionFunc mocks the DeleteEdition method.
+	DeleteEditionFunc func(ctx context.Context, id int64) (domain.Edition, error)
+
+	// DeleteGenreFunc mocks the DeleteGenre method.
+	DeleteGenreFunc func(ctx context.Context, id int64) (domain.Genre, error)
+
+	// DeletePublisherFunc mocks the DeletePublisher method.
+	DeletePublisherFunc func(ctx context.Context, id int64) (domain.Publisher, error)
+
+	// DeleteRoyaltyRateFunc mocks the DeleteRoyaltyRate method.
+	DeleteRoyaltyRateFunc func(ctx context.Context, dealID int64, editionID int64) (domain.RoyaltyRate, error)
+
+	// DeleteSalesFunc mocks the DeleteSales method.
+	DeleteSalesFunc func(ctx context.Context, id int64) (domain.Sales, error)
+
+	// DeleteSeriesFunc mocks the DeleteSeries method.
+	DeleteSeriesFunc func(ctx context.Context, id int64) (*domain.Series, error)
+
+	// DeleteWebhookFunc mocks the DeleteWebhook method.
+	DeleteWebhookFunc func(ctx context.Context, id int64) (domain.Webhook, error)
+
+	// EndRepresentationFunc mocks the EndRepresentation method.
+	EndRepresentationFunc func(ctx context.Context, id int64, endedAt domain.Date) (domain.Representation, error)
+
+	// GetAgencyFunc mocks the GetAgency method.
+	GetAgencyFunc func(ctx context.Context, id int64) (domain.Agency, error)
+
+	// GetAgentFunc mocks the GetAgent method.
+	GetAgentFunc func(ctx context.Context, id int64) (domain.Agent, error)
+
+	// GetAuthorFunc mocks the GetAuthor method.
+	GetAuthorFunc func(ctx context.Context, id int64) (domain.Author, error)
+
+	// GetBookFunc mocks the GetBook method.
+	GetBookFunc func(ctx context.Context, id int64) (domain.Book, error)
+
+	// GetBookByISBNFunc mocks the GetBookByISBN method.
+	GetBookByISBNFunc func(ctx context.Context, isbn domain.ISBN) (domain.Book, error)
+
+	// GetDealFunc mocks the GetDeal method.
+	GetDealFunc func(ctx context.Context, id int64) (domain.Deal, error)
+
+	// GetEditionFunc mocks the GetEdition method.
+	GetEditionFunc func(ctx context.Context, id int64) (domain.Edition, error)
+
+	// GetEditionByISBNFunc mocks the GetEditionByISBN method.
+	GetEditionByISBNFunc func(ctx context.Context, isbn domain.ISBN) (domain.Edition, error)
+
+	// GetGenreFunc mocks the GetGenre method.
+	GetGenreFunc func(ctx context.Context, id int64) (domain.Genre, error)
+
+	// GetPublisherFunc mocks the GetPublisher method.
+	GetPublisherFunc func(ctx context.Context, id int64) (domain.Publisher, error)
+
+	// GetRoyaltyStatementFunc mocks the GetRoyaltyStatement method.
+	GetRoyaltyStatementFunc func(ctx context.Context, authorID int64, period string) (domain.RoyaltyStatement, error)
+
+	// GetSeriesFunc mocks the GetSeries method.
+	GetSeriesFunc func(ctx context.Context, id int64) (domain.Series, error)
+
+	// GetSubmissionFunc mocks the GetSubmission method.
+	GetSubmissionFunc func(ctx context.Context, id int64) (domain.Submission, error)
+
+	// GetWebhookFunc mocks the GetWebhook method.
+	GetWebhookFunc func(ctx context.Context, id int64) (domain.Webhook, error)
+
+	// ListAgenciesFunc mocks the ListAgencies method.
+	ListAgenciesFunc func(ctx context.Context) ([]domain.Agency, error)
+
+	// ListAgentsFunc mocks the ListAgents method.
+	ListAgentsFunc func(ctx context.Context) ([]domain.Agent, error)
+
+	// ListAgentsByAgencyIDFunc mocks the ListAgentsByAgencyID method.
+	ListAgentsByAgencyIDFunc func(ctx context.Context, agencyID int64) ([]domain.Agent, error)
+
+	// ListAuthorsFunc mocks the ListAuthors method.
+	ListAuthorsFunc func(ctx context.Context) ([]domain.Author, error)
+
+	// ListAuthorsByAgencyIDFunc mocks the ListAuthorsByAgencyID method.
+	ListAuthorsByAgencyIDFunc func(ctx context.Context, agencyID int64) ([]domain.Author, error)
+
+	// ListAuthorsByAgentIDFunc mocks the ListAuthorsByAgentID method.
+	ListAuthorsByAgentIDFunc func(ctx context.Context, agentID int64) ([]domain.Author, error)
+
+	// ListAuthorsByBookIDFunc mocks the ListAuthorsByBookID method.
+	ListAuthorsByBookIDFunc func(ctx context.Context, bookID int64) ([]domain.Author, error)
+
+	// ListAvailableRightsFunc mocks the ListAvailableRights method.
+	ListAvailableRightsFunc func(ctx context.Context, territory string, language domain.LanguageCode) ([]domain.AvailableRights, error)
+
+	// ListBooksFunc mocks the ListBooks method.
+	ListBooksFunc func(ctx context.Context) ([]domain.Book, error)
+
+	// ListBooksByAgencyIDFunc mocks the ListBooksByAgencyID method.
+	ListBooksByAgencyIDFunc func(ctx context.Context, agencyID int64) ([]domain.Book, error)
+
+	// ListBooksByAuthorIDFunc mocks the ListBooksByAuthorID method.
+	ListBooksByAuthorIDFunc func(ctx context.Context, authorID int64) ([]domain.Book, error)
+
+	// ListBooksByGenreIDFunc mocks the ListBooksByGenreID method.
+	ListBooksByGenreIDFunc func(ctx context.Context, genreID int64, includeSubgenres bool) ([]domain.Book, error)
+
+	// ListBooksByPublisherIDFunc mocks the ListBooksByPublisherID method.
+	ListBooksByPublisherIDFunc func(ctx context.Context, publisherID int64) ([]domain.Book, error)
+
+	// ListBooksBySeriesIDFunc mocks the ListBooksBySeriesID method.
+	ListBooksBySeriesIDFunc func(ctx context.Context, seriesID int64) ([]domain.Book, error)
+
+	// ListBooksByTagFunc mocks the ListBooksByTag method.
+	ListBooksByTagFunc func(ctx context.Context, tag string) ([]domain.Book, error)
+
+	// ListContributorsByBookIDFunc mocks the ListContributorsByBookID method.
+	ListContributorsByBookIDFunc func(ctx context.Context, bookID int64) ([]domain.Contributor, error)
+
+	// ListDealsByAgentIDFunc mocks the ListDealsByAgentID method.
+	ListDealsByAgentIDFunc func(ctx context.Context, agentID int64) ([]domain.Deal, error)
+
+	// ListDealsByBookIDFunc mocks the ListDealsByBookID method.
+	ListDealsByBookIDFunc func(ctx context.Context, bookID int64) ([]domain.Deal, error)
+
+	// ListEditionsFunc mocks the ListEditions method.
+	ListEditionsFunc func(ctx context.Context, args domain.ListEditionsParams) ([]domain.Edition, error)
+
+	// ListEditionsByBookIDFunc mocks the ListEditionsByBookID method.
+	ListEditionsByBookIDFunc func(ctx context.Context, bookID int64) ([]domain.Edition, error)
+
+	// ListFormerAuthorsByAgentIDFunc mocks the ListFormerAuthorsByAgentID method.
+	ListFormerAuthorsByAgentIDFunc func(ctx context.Context, agentID int64) ([]domain.Author, error)
+
+	// ListGenresFunc mocks the ListGenres method.
+	ListGenresFunc func(ctx context.Context) ([]domain.Genre, error)
+
+	// ListGenresByBookIDFunc mocks the ListGenresByBookID method.
+	ListGenresByBookIDFunc func(ctx context.Context, bookID int64) ([]domain.Genre, error)
+
+	// ListGenresByParentIDFunc mocks the ListGenresByParentID method.
+	ListGenresByParentIDFunc func(ctx context.Context, parentID int64) ([]domain.Genre, error)
+
+	// ListOrphanBooksFunc mocks the ListOrphanBooks method.
+	ListOrphanBooksFunc func(ctx context.Context) ([]domain.Book, error)
+
+	// ListPublishersFunc mocks the ListPublishers method.
+	ListPublishersFunc func(ctx context.Context) ([]domain.Publisher, error)
+
+	// ListRepresentationsByAuthorIDFunc mocks the ListRepresentationsByAuthorID method.
+	ListRepresentationsByAuthorIDFunc func(ctx context.Context, authorID int64) ([]domain.Representation, error)
+
+	// ListRoyaltyRatesByDealIDFunc mocks the ListRoyaltyRatesByDealID method.
+	ListRoyaltyRatesByDealIDFunc func(ctx context.Context, dealID int64) ([]domain.RoyaltyRate, error)
+
+	// ListSalesByDealIDFunc mocks the ListSalesByDealID method.
+	ListSalesByDealIDFunc func(ctx context.Context, dealID int64) ([]domain.Sales, error)
+
+	// ListSeriesFunc mocks the ListSeries method.
+	ListSeriesFunc func(ctx context.Context) ([]domain.Series, error)
+
+	// ListSubmissionTransitionsFunc mocks the ListSubmissionTransitions method.
+	ListSubmissionTransitionsFunc func(ctx context.Context, submissionID int64) ([]domain.SubmissionTransition, error)
+
+	// ListSubmissionsByAgentIDFunc mocks the ListSubmissionsByAgentID method.
+	ListSubmissionsByAgentIDFunc func(ctx context.Context, agentID int64) ([]domain.Submission, error)
+
+	// ListSubmissionsByAgentIDAndStatusFunc mocks the ListSubmissionsByAgentIDAndStatus method.
+	ListSubmissionsByAgentIDAndStatusFunc func(ctx context.Context, args domain.ListSubmissionsByAgentIDAndStatusParams) ([]domain.Submission, error)
+
+	// ListTagsByBookIDFunc mocks the ListTagsByBookID method.
+	ListTagsByBookIDFunc func(ctx context.Context, bookID int64) ([]string, error)
+
+	// ListWebhookDeliveriesFunc mocks the ListWebhookDeliveries method.
+	ListWebhookDeliveriesFunc func(ctx context.Context, webhookID int64) ([]domain.WebhookDelivery, error)
+
+	// ListWebhookDeliveriesByStatusFunc mocks the ListWebhookDeliveriesByStatus method.
+	ListWebhookDeliveriesByStatusFunc func(ctx context.Context, args domain.ListWebhookDeliveriesByStatusParams) ([]domain.WebhookDelivery, error)
+
+	// ListWebhooksFunc mocks the ListWebhooks method.
+	ListWebhooksFunc func(ctx context.Context) ([]domain.Webhook, error)
+
+	// RecordSalesFunc mocks the RecordSales method.
+	RecordSalesFunc func(ctx context.Context, args domain.RecordSalesParams) (domain.Sales, error)
+
+	// ReorderSeriesFunc mocks the ReorderSeries method.
+	ReorderSeriesFunc func(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error)
+
+	// RetryWebhookDeliveryFunc mocks the RetryWebhookDelivery method.
+	RetryWebhookDeliveryFunc func(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+
+	// SetRoyaltyRateFunc mocks the SetRoyaltyRate method.
+	SetRoyaltyRateFunc func(ctx context.Context, dealID int64, editionID int64, rate int) (domain.RoyaltyRate, error)
+
+	// TransitionSubmissionFunc mocks the TransitionSubmission method.
+	TransitionSubmissionFunc func(ctx context.Context, id int64, status domain.SubmissionStatus, note *string) (*domain.Submission, error)
+
+	// UpdateAgencyFunc mocks the UpdateAgency method.
+	UpdateAgencyFunc func(ctx context.Context, args domain.UpdateAgencyParams) (domain.Agency, error)
+
+	// UpdateAgentFunc mocks the UpdateAgent method.
+	UpdateAgentFunc func(ctx context.Context, args domain.UpdateAgentParams) (domain.Agent, error)
+
+	// UpdateAuthorFunc mocks the UpdateAuthor method.
+	UpdateAuthorFunc func(ctx context.Context, args domain.UpdateAuthorParams) (*domain.Author, error)
+
+	// UpdateBookFunc mocks the UpdateBook method.
+	UpdateBookFunc func(ctx context.Context, args domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error)
+
+	// UpdateBooksFunc mocks the UpdateBooks method.
+	UpdateBooksFunc func(ctx context.Context, args []domain.BulkUpdateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error)
+
+	// UpdateDealFunc mocks the UpdateDeal method.
+	UpdateDealFunc func(ctx context.Context, args domain.UpdateDealParams) (domain.Deal, error)
+
+	// UpdateEditionFunc mocks the UpdateEdition method.
+	UpdateEditionFunc func(ctx context.Context, args domain.UpdateEditionParams) (domain.Edition, error)
+
+	// UpdateGenreFunc mocks the UpdateGenre method.
+	UpdateGenreFunc func(ctx context.Context, args domain.UpdateGenreParams) (*domain.Genre, error)
+
+	// UpdatePublisherFunc mocks the UpdatePublisher method.
+	UpdatePublisherFunc func(ctx context.Context, args domain.UpdatePublisherParams) (domain.Publisher, error)
+
+	// UpdateSeriesFunc mocks the UpdateSeries method.
+	UpdateSeriesFunc func(ctx context.Context, args domain.UpdateSeriesParams) (domain.Series, error)
+
+	// UpdateWebhookFunc mocks the UpdateWebhook method.
+	UpdateWebhookFunc func(ctx context.Context, args domain.UpdateWebhookParams) (domain.Webhook, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ConvertSubmission holds details about calls to the ConvertSubmission method.
+		ConvertSubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Cover is the cover argument value.
+			Cover string
+		}
+		// CreateAgency holds details about calls to the CreateAgency method.
+		CreateAgency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateAgencyParams
+		}
+		// CreateAgent holds details about calls to the CreateAgent method.
+		CreateAgent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateAgentParams
+		}
+		// CreateAgents holds details about calls to the CreateAgents method.
+		CreateAgents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []domain.CreateAgentParams
+			// Mode is the mode argument value.
+			Mode domain.BulkMode
+		}
+		// CreateAuthor holds details about calls to the CreateAuthor method.
+		CreateAuthor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateAuthorParams
+		}
+		// CreateAuthors holds details about calls to the CreateAuthors method.
+		CreateAuthors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []domain.CreateAuthorParams
+			// Mode is the mode argument value.
+			Mode domain.BulkMode
+		}
+		// CreateBook holds details about calls to the CreateBook method.
+		CreateBook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateBookParams
+			// Contributors is the contributors argument value.
+			Contributors []domain.Contributor
+			// GenreIDs is the genreIDs argument value.
+			GenreIDs []int64
+			// Tags is the tags argument value.
+			Tags []string
+		}
+		// CreateBooks holds details about calls to the CreateBooks method.
+		CreateBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []domain.BulkCreateBookArgs
+			// Mode is the mode argument value.
+			Mode domain.BulkMode
+		}
+		// CreateDeal holds details about calls to the CreateDeal method.
+		CreateDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateDealParams
+		}
+		// CreateEdition holds details about calls to the CreateEdition method.
+		CreateEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateEditionParams
+		}
+		// CreateGenre holds details about calls to the CreateGenre method.
+		CreateGenre []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateGenreParams
+		}
+		// CreatePublisher holds details about calls to the CreatePublisher method.
+		CreatePublisher []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreatePublisherParams
+		}
+		// CreateRepresentation holds details about calls to the CreateRepresentation method.
+		CreateRepresentation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateRepresentationParams
+		}
+		// CreateSeries holds details about calls to the CreateSeries method.
+		CreateSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateSeriesParams
+		}
+		// CreateSubmission holds details about calls to the CreateSubmission method.
+		CreateSubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateSubmissionParams
+		}
+		// CreateWebhook holds details about calls to the CreateWebhook method.
+		CreateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CreateWebhookParams
+		}
+		// DeleteAgency holds details about calls to the DeleteAgency method.
+		DeleteAgency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteAgent holds details about calls to the DeleteAgent method.
+		DeleteAgent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// ReassignAuthorsTo is the reassignAuthorsTo argument value.
+			ReassignAuthorsTo *int64
+		}
+		// DeleteAuthor holds details about calls to the DeleteAuthor method.
+		DeleteAuthor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// OrphanedBooks is the orphanedBooks argument value.
+			OrphanedBooks domain.OrphanedBooksPolicy
+		}
+		// DeleteBook holds details about calls to the DeleteBook method.
+		DeleteBook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteDeal holds details about calls to the DeleteDeal method.
+		DeleteDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteEdition holds details about calls to the DeleteEdition method.
+		DeleteEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteGenre holds details about calls to the DeleteGenre method.
+		DeleteGenre []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeletePublisher holds details about calls to the DeletePublisher method.
+		DeletePublisher []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteRoyaltyRate holds details about calls to the DeleteRoyaltyRate method.
+		DeleteRoyaltyRate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DealID is the dealID argument value.
+			DealID int64
+			// EditionID is the editionID argument value.
+			EditionID int64
+		}
+		// DeleteSales holds details about calls to the DeleteSales method.
+		DeleteSales []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteSeries holds details about calls to the DeleteSeries method.
+		DeleteSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteWebhook holds details about calls to the DeleteWebhook method.
+		DeleteWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// EndRepresentation holds details about calls to the EndRepresentation method.
+		EndRepresentation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// EndedAt is the endedAt argument value.
+			EndedAt domain.Date
+		}
+		// GetAgency holds details about calls to the GetAgency method.
+		GetAgency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetAgent holds details about calls to the GetAgent method.
+		GetAgent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetAuthor holds details about calls to the GetAuthor method.
+		GetAuthor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetBook holds details about calls to the GetBook method.
+		GetBook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetBookByISBN holds details about calls to the GetBookByISBN method.
+		GetBookByISBN []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Isbn is the isbn argument value.
+			Isbn domain.ISBN
+		}
+		// GetDeal holds details about calls to the GetDeal method.
+		GetDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetEdition holds details about calls to the GetEdition method.
+		GetEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetEditionByISBN holds details about calls to the GetEditionByISBN method.
+		GetEditionByISBN []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Isbn is the isbn argument value.
+			Isbn domain.ISBN
+		}
+		// GetGenre holds details about calls to the GetGenre method.
+		GetGenre []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetPublisher holds details about calls to the GetPublisher method.
+		GetPublisher []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetRoyaltyStatement holds details about calls to the GetRoyaltyStatement method.
+		GetRoyaltyStatement []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AuthorID is the authorID argument value.
+			AuthorID int64
+			// Period is the period argument value.
+			Period string
+		}
+		// GetSeries holds details about calls to the GetSeries method.
+		GetSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetSubmission holds details about calls to the GetSubmission method.
+		GetSubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetWebhook holds details about calls to the GetWebhook method.
+		GetWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// ListAgencies holds details about calls to the ListAgencies method.
+		ListAgencies []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListAgents holds details about calls to the ListAgents method.
+		ListAgents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListAgentsByAgencyID holds details about calls to the ListAgentsByAgencyID method.
+		ListAgentsByAgencyID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgencyID is the agencyID argument value.
+			AgencyID int64
+		}
+		// ListAuthors holds details about calls to the ListAuthors method.
+		ListAuthors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListAuthorsByAgencyID holds details about calls to the ListAuthorsByAgencyID method.
+		ListAuthorsByAgencyID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgencyID is the agencyID argument value.
+			AgencyID int64
+		}
+		// ListAuthorsByAgentID holds details about calls to the ListAuthorsByAgentID method.
+		ListAuthorsByAgentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// ListAuthorsByBookID holds details about calls to the ListAuthorsByBookID method.
+		ListAuthorsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListAvailableRights holds details about calls to the ListAvailableRights method.
+		ListAvailableRights []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Territory is the territory argument value.
+			Territory string
+			// Language is the language argument value.
+			Language domain.LanguageCode
+		}
+		// ListBooks holds details about calls to the ListBooks method.
+		ListBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListBooksByAgencyID holds details about calls to the ListBooksByAgencyID method.
+		ListBooksByAgencyID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgencyID is the agencyID argument value.
+			AgencyID int64
+		}
+		// ListBooksByAuthorID holds details about calls to the ListBooksByAuthorID method.
+		ListBooksByAuthorID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AuthorID is the authorID argument value.
+			AuthorID int64
+		}
+		// ListBooksByGenreID holds details about calls to the ListBooksByGenreID method.
+		ListBooksByGenreID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// GenreID is the genreID argument value.
+			GenreID int64
+			// IncludeSubgenres is the includeSubgenres argument value.
+			IncludeSubgenres bool
+		}
+		// ListBooksByPublisherID holds details about calls to the ListBooksByPublisherID method.
+		ListBooksByPublisherID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PublisherID is the publisherID argument value.
+			PublisherID int64
+		}
+		// ListBooksBySeriesID holds details about calls to the ListBooksBySeriesID method.
+		ListBooksBySeriesID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SeriesID is the seriesID argument value.
+			SeriesID int64
+		}
+		// ListBooksByTag holds details about calls to the ListBooksByTag method.
+		ListBooksByTag []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Tag is the tag argument value.
+			Tag string
+		}
+		// ListContributorsByBookID holds details about calls to the ListContributorsByBookID method.
+		ListContributorsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListDealsByAgentID holds details about calls to the ListDealsByAgentID method.
+		ListDealsByAgentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// ListDealsByBookID holds details about calls to the ListDealsByBookID method.
+		ListDealsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListEditions holds details about calls to the ListEditions method.
+		ListEditions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.ListEditionsParams
+		}
+		// ListEditionsByBookID holds details about calls to the ListEditionsByBookID method.
+		ListEditionsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListFormerAuthorsByAgentID holds details about calls to the ListFormerAuthorsByAgentID method.
+		ListFormerAuthorsByAgentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// ListGenres holds details about calls to the ListGenres method.
+		ListGenres []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListGenresByBookID holds details about calls to the ListGenresByBookID method.
+		ListGenresByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListGenresByParentID holds details about calls to the ListGenresByParentID method.
+		ListGenresByParentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ParentID is the parentID argument value.
+			ParentID int64
+		}
+		// ListOrphanBooks holds details about calls to the ListOrphanBooks method.
+		ListOrphanBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListPublishers holds details about calls to the ListPublishers method.
+		ListPublishers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListRepresentationsByAuthorID holds details about calls to the ListRepresentationsByAuthorID method.
+		ListRepresentationsByAuthorID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AuthorID is the authorID argument value.
+			AuthorID int64
+		}
+		// ListRoyaltyRatesByDealID holds details about calls to the ListRoyaltyRatesByDealID method.
+		ListRoyaltyRatesByDealID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DealID is the dealID argument value.
+			DealID int64
+		}
+		// ListSalesByDealID holds details about calls to the ListSalesByDealID method.
+		ListSalesByDealID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DealID is the dealID argument value.
+			DealID int64
+		}
+		// ListSeries holds details about calls to the ListSeries method.
+		ListSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ListSubmissionTransitions holds details about calls to the ListSubmissionTransitions method.
+		ListSubmissionTransitions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubmissionID is the submissionID argument value.
+			SubmissionID int64
+		}
+		// ListSubmissionsByAgentID holds details about calls to the ListSubmissionsByAgentID method.
+		ListSubmissionsByAgentID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// ListSubmissionsByAgentIDAndStatus holds details about calls to the ListSubmissionsByAgentIDAndStatus method.
+		ListSubmissionsByAgentIDAndStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.ListSubmissionsByAgentIDAndStatusParams
+		}
+		// ListTagsByBookID holds details about calls to the ListTagsByBookID method.
+		ListTagsByBookID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BookID is the bookID argument value.
+			BookID int64
+		}
+		// ListWebhookDeliveries holds details about calls to the ListWebhookDeliveries method.
+		ListWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID int64
+		}
+		// ListWebhookDeliveriesByStatus holds details about calls to the ListWebhookDeliveriesByStatus method.
+		ListWebhookDeliveriesByStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.ListWebhookDeliveriesByStatusParams
+		}
+		// ListWebhooks holds details about calls to the ListWebhooks method.
+		ListWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RecordSales holds details about calls to the RecordSales method.
+		RecordSales []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.RecordSalesParams
+		}
+		// ReorderSeries holds details about calls to the ReorderSeries method.
+		ReorderSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// BookIDs is the bookIDs argument value.
+			BookIDs []int64
+		}
+		// RetryWebhookDelivery holds details about calls to the RetryWebhookDelivery method.
+		RetryWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// SetRoyaltyRate holds details about calls to the SetRoyaltyRate method.
+		SetRoyaltyRate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DealID is the dealID argument value.
+			DealID int64
+			// EditionID is the editionID argument value.
+			EditionID int64
+			// Rate is the rate argument value.
+			Rate int
+		}
+		// TransitionSubmission holds details about calls to the TransitionSubmission method.
+		TransitionSubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Status is the status argument value.
+			Status domain.SubmissionStatus
+			// Note is the note argument value.
+			Note *string
+		}
+		// UpdateAgency holds details about calls to the UpdateAgency method.
+		UpdateAgency []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateAgencyParams
+		}
+		// UpdateAgent holds details about calls to the UpdateAgent method.
+		UpdateAgent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateAgentParams
+		}
+		// UpdateAuthor holds details about calls to the UpdateAuthor method.
+		UpdateAuthor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateAuthorParams
+		}
+		// UpdateBook holds details about calls to the UpdateBook method.
+		UpdateBook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateBookParams
+			// Contributors is the contributors argument value.
+			Contributors []domain.Contributor
+			// GenreIDs is the genreIDs argument value.
+			GenreIDs []int64
+			// Tags is the tags argument value.
+			Tags []string
+		}
+		// UpdateBooks holds details about calls to the UpdateBooks method.
+		UpdateBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []domain.BulkUpdateBookArgs
+			// Mode is the mode argument value.
+			Mode domain.BulkMode
+		}
+		// UpdateDeal holds details about calls to the UpdateDeal method.
+		UpdateDeal []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateDealParams
+		}
+		// UpdateEdition holds details about calls to the UpdateEdition method.
+		UpdateEdition []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateEditionParams
+		}
+		// UpdateGenre holds details about calls to the UpdateGenre method.
+		UpdateGenre []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateGenreParams
+		}
+		// UpdatePublisher holds details about calls to the UpdatePublisher method.
+		UpdatePublisher []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdatePublisherParams
+		}
+		// UpdateSeries holds details about calls to the UpdateSeries method.
+		UpdateSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateSeriesParams
+		}
+		// UpdateWebhook holds details about calls to the UpdateWebhook method.
+		UpdateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.UpdateWebhookParams
+		}
+	}
+}
+
+// ConvertSubmission calls ConvertSubmissionFunc.
+func (mock *RepositoryMock) ConvertSubmission(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+	if mock.ConvertSubmissionFunc == nil {
+		panic("RepositoryMock.ConvertSubmissionFunc: method is nil but Repository.ConvertSubmission was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    int64
+		Cover string
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Cover: cover,
+	}
+	lockRepositoryMockConvertSubmission.Lock()
+	mock.calls.ConvertSubmission = append(mock.calls.ConvertSubmission, callInfo)
+	lockRepositoryMockConvertSubmission.Unlock()
+	return mock.ConvertSubmissionFunc(ctx, id, cover)
+}
+
+// ConvertSubmissionCalls gets all the calls that were made to ConvertSubmission.
+// Check the length with:
+//     len(mockedRepository.ConvertSubmissionCalls())
+func (mock *RepositoryMock) ConvertSubmissionCalls() []struct {
+	Ctx   context.Context
+	ID    int64
+	Cover string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    int64
+		Cover string
+	}
+	lockRepositoryMockConvertSubmission.RLock()
+	calls = mock.calls.ConvertSubmission
+	lockRepositoryMockConvertSubmission.RUnlock()
+	return calls
+}
+
+// CreateAgency calls CreateAgencyFunc.
+func (mock *RepositoryMock) CreateAgency(ctx context.Context, args domain.CreateAgencyParams) (domain.Agency, error) {
+	if mock.CreateAgencyFunc == nil {
+		panic("RepositoryMock.CreateAgencyFunc: method is nil but Repository.CreateAgency was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateAgencyParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateAgency.Lock()
+	mock.calls.CreateAgency = append(mock.calls.CreateAgency, callInfo)
+	lockRepositoryMockCreateAgency.Unlock()
+	return mock.CreateAgencyFunc(ctx, args)
+}
+
+// CreateAgencyCalls gets all the calls that were made to CreateAgency.
+// Check the length with:
+//     len(mockedRepository.CreateAgencyCalls())
+func (mock *RepositoryMock) CreateAgencyCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateAgencyParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateAgencyParams
+	}
+	lockRepositoryMockCreateAgency.RLock()
+	calls = mock.calls.CreateAgency
+	lockRepositoryMockCreateAgency.RUnlock()
+	return calls
+}
+
+// CreateAgent calls CreateAgentFunc.
+func (mock *RepositoryMock) CreateAgent(ctx context.Context, args domain.CreateAgentParams) (domain.Agent, error) {
+	if mock.CreateAgentFunc == nil {
+		panic("RepositoryMock.CreateAgentFunc: method is nil but Repository.CreateAgent was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateAgentParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateAgent.Lock()
+	mock.calls.CreateAgent = append(mock.calls.CreateAgent, callInfo)
+	lockRepositoryMockCreateAgent.Unlock()
+	return mock.CreateAgentFunc(ctx, args)
+}
+
+// CreateAgentCalls gets all the calls that were made to CreateAgent.
+// Check the length with:
+//     len(mockedRepository.CreateAgentCalls())
+func (mock *RepositoryMock) CreateAgentCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateAgentParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateAgentParams
+	}
+	lockRepositoryMockCreateAgent.RLock()
+	calls = mock.calls.CreateAgent
+	lockRepositoryMockCreateAgent.RUnlock()
+	return calls
+}
+
+// CreateAgents calls CreateAgentsFunc.
+func (mock *RepositoryMock) CreateAgents(ctx context.Context, args []domain.CreateAgentParams, mode domain.BulkMode) (*domain.BulkAgentsResult, error) {
+	if mock.CreateAgentsFunc == nil {
+		panic("RepositoryMock.CreateAgentsFunc: method is nil but Repository.CreateAgents was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []domain.CreateAgentParams
+		Mode domain.BulkMode
+	}{
+		Ctx:  ctx,
+		Args: args,
+		Mode: mode,
+	}
+	lockRepositoryMockCreateAgents.Lock()
+	mock.calls.CreateAgents = append(mock.calls.CreateAgents, callInfo)
+	lockRepositoryMockCreateAgents.Unlock()
+	return mock.CreateAgentsFunc(ctx, args, mode)
+}
+
+// CreateAgentsCalls gets all the calls that were made to CreateAgents.
+// Check the length with:
+//     len(mockedRepository.CreateAgentsCalls())
+func (mock *RepositoryMock) CreateAgentsCalls() []struct {
+	Ctx  context.Context
+	Args []domain.CreateAgentParams
+	Mode domain.BulkMode
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []domain.CreateAgentParams
+		Mode domain.BulkMode
+	}
+	lockRepositoryMockCreateAgents.RLock()
+	calls = mock.calls.CreateAgents
+	lockRepositoryMockCreateAgents.RUnlock()
+	return calls
+}
+
+// CreateAuthor calls CreateAuthorFunc.
+func (mock *RepositoryMock) CreateAuthor(ctx context.Context, args domain.CreateAuthorParams) (*domain.Author, error) {
+	if mock.CreateAuthorFunc == nil {
+		panic("RepositoryMock.CreateAuthorFunc: method is nil but Repository.CreateAuthor was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateAuthorParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateAuthor.Lock()
+	mock.calls.CreateAuthor = append(mock.calls.CreateAuthor, callInfo)
+	lockRepositoryMockCreateAuthor.Unlock()
+	return mock.CreateAuthorFunc(ctx, args)
+}
+
+// CreateAuthorCalls gets all the calls that were made to CreateAuthor.
+// Check the length with:
+//     len(mockedRepository.CreateAuthorCalls())
+func (mock *RepositoryMock) CreateAuthorCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateAuthorParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateAuthorParams
+	}
+	lockRepositoryMockCreateAuthor.RLock()
+	calls = mock.calls.CreateAuthor
+	lockRepositoryMockCreateAuthor.RUnlock()
+	return calls
+}
+
+// CreateAuthors calls CreateAuthorsFunc.
+func (mock *RepositoryMock) CreateAuthors(ctx context.Context, args []domain.CreateAuthorParams, mode domain.BulkMode) (*domain.BulkAuthorsResult, error) {
+	if mock.CreateAuthorsFunc == nil {
+		panic("RepositoryMock.CreateAuthorsFunc: method is nil but Repository.CreateAuthors was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []domain.CreateAuthorParams
+		Mode domain.BulkMode
+	}{
+		Ctx:  ctx,
+		Args: args,
+		Mode: mode,
+	}
+	lockRepositoryMockCreateAuthors.Lock()
+	mock.calls.CreateAuthors = append(mock.calls.CreateAuthors, callInfo)
+	lockRepositoryMockCreateAuthors.Unlock()
+	return mock.CreateAuthorsFunc(ctx, args, mode)
+}
+
+// CreateAuthorsCalls gets all the calls that were made to CreateAuthors.
+// Check the length with:
+//     len(mockedRepository.CreateAuthorsCalls())
+func (mock *RepositoryMock) CreateAuthorsCalls() []struct {
+	Ctx  context.Context
+	Args []domain.CreateAuthorParams
+	Mode domain.BulkMode
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []domain.CreateAuthorParams
+		Mode domain.BulkMode
+	}
+	lockRepositoryMockCreateAuthors.RLock()
+	calls = mock.calls.CreateAuthors
+	lockRepositoryMockCreateAuthors.RUnlock()
+	return calls
+}
+
+// CreateBook calls CreateBookFunc.
+func (mock *RepositoryMock) CreateBook(ctx context.Context, args domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+	if mock.CreateBookFunc == nil {
+		panic("RepositoryMock.CreateBookFunc: method is nil but Repository.CreateBook was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Args         domain.CreateBookParams
+		Contributors []domain.Contributor
+		GenreIDs     []int64
+		Tags         []string
+	}{
+		Ctx:          ctx,
+		Args:         args,
+		Contributors: contributors,
+		GenreIDs:     genreIDs,
+		Tags:         tags,
+	}
+	lockRepositoryMockCreateBook.Lock()
+	mock.calls.CreateBook = append(mock.calls.CreateBook, callInfo)
+	lockRepositoryMockCreateBook.Unlock()
+	return mock.CreateBookFunc(ctx, args, contributors, genreIDs, tags)
+}
+
+// CreateBookCalls gets all the calls that were made to CreateBook.
+// Check the length with:
+//     len(mockedRepository.CreateBookCalls())
+func (mock *RepositoryMock) CreateBookCalls() []struct {
+	Ctx          context.Context
+	Args         domain.CreateBookParams
+	Contributors []domain.Contributor
+	GenreIDs     []int64
+	Tags         []string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Args         domain.CreateBookParams
+		Contributors []domain.Contributor
+		GenreIDs     []int64
+		Tags         []string
+	}
+	lockRepositoryMockCreateBook.RLock()
+	calls = mock.calls.CreateBook
+	lockRepositoryMockCreateBook.RUnlock()
+	return calls
+}
+
+// CreateBooks calls CreateBooksFunc.
+func (mock *RepositoryMock) CreateBooks(ctx context.Context, args []domain.BulkCreateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+	if mock.CreateBooksFunc == nil {
+		panic("RepositoryMock.CreateBooksFunc: method is nil but Repository.CreateBooks was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []domain.BulkCreateBookArgs
+		Mode domain.BulkMode
+	}{
+		Ctx:  ctx,
+		Args: args,
+		Mode: mode,
+	}
+	lockRepositoryMockCreateBooks.Lock()
+	mock.calls.CreateBooks = append(mock.calls.CreateBooks, callInfo)
+	lockRepositoryMockCreateBooks.Unlock()
+	return mock.CreateBooksFunc(ctx, args, mode)
+}
+
+// CreateBooksCalls gets all the calls that were made to CreateBooks.
+// Check the length with:
+//     len(mockedRepository.CreateBooksCalls())
+func (mock *RepositoryMock) CreateBooksCalls() []struct {
+	Ctx  context.Context
+	Args []domain.BulkCreateBookArgs
+	Mode domain.BulkMode
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []domain.BulkCreateBookArgs
+		Mode domain.BulkMode
+	}
+	lockRepositoryMockCreateBooks.RLock()
+	calls = mock.calls.CreateBooks
+	lockRepositoryMockCreateBooks.RUnlock()
+	return calls
+}
+
+// CreateDeal calls CreateDealFunc.
+func (mock *RepositoryMock) CreateDeal(ctx context.Context, args domain.CreateDealParams) (domain.Deal, error) {
+	if mock.CreateDealFunc == nil {
+		panic("RepositoryMock.CreateDealFunc: method is nil but Repository.CreateDeal was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateDealParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateDeal.Lock()
+	mock.calls.CreateDeal = append(mock.calls.CreateDeal, callInfo)
+	lockRepositoryMockCreateDeal.Unlock()
+	return mock.CreateDealFunc(ctx, args)
+}
+
+// CreateDealCalls gets all the calls that were made to CreateDeal.
+// Check the length with:
+//     len(mockedRepository.CreateDealCalls())
+func (mock *RepositoryMock) CreateDealCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateDealParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateDealParams
+	}
+	lockRepositoryMockCreateDeal.RLock()
+	calls = mock.calls.CreateDeal
+	lockRepositoryMockCreateDeal.RUnlock()
+	return calls
+}
+
+// CreateEdition calls CreateEditionFunc.
+func (mock *RepositoryMock) CreateEdition(ctx context.Context, args domain.CreateEditionParams) (domain.Edition, error) {
+	if mock.CreateEditionFunc == nil {
+		panic("RepositoryMock.CreateEditionFunc: method is nil but Repository.CreateEdition was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateEditionParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateEdition.Lock()
+	mock.calls.CreateEdition = append(mock.calls.CreateEdition, callInfo)
+	lockRepositoryMockCreateEdition.Unlock()
+	return mock.CreateEditionFunc(ctx, args)
+}
+
+// CreateEditionCalls gets all the calls that were made to CreateEdition.
+// Check the length with:
+//     len(mockedRepository.CreateEditionCalls())
+func (mock *RepositoryMock) CreateEditionCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateEditionParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateEditionParams
+	}
+	lockRepositoryMockCreateEdition.RLock()
+	calls = mock.calls.CreateEdition
+	lockRepositoryMockCreateEdition.RUnlock()
+	return calls
+}
+
+// CreateGenre calls CreateGenreFunc.
+func (mock *RepositoryMock) CreateGenre(ctx context.Context, args domain.CreateGenreParams) (domain.Genre, error) {
+	if mock.CreateGenreFunc == nil {
+		panic("RepositoryMock.CreateGenreFunc: method is nil but Repository.CreateGenre was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateGenreParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateGenre.Lock()
+	mock.calls.CreateGenre = append(mock.calls.CreateGenre, callInfo)
+	lockRepositoryMockCreateGenre.Unlock()
+	return mock.CreateGenreFunc(ctx, args)
+}
+
+// CreateGenreCalls gets all the calls that were made to CreateGenre.
+// Check the length with:
+//     len(mockedRepository.CreateGenreCalls())
+func (mock *RepositoryMock) CreateGenreCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateGenreParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateGenreParams
+	}
+	lockRepositoryMockCreateGenre.RLock()
+	calls = mock.calls.CreateGenre
+	lockRepositoryMockCreateGenre.RUnlock()
+	return calls
+}
+
+// CreatePublisher calls CreatePublisherFunc.
+func (mock *RepositoryMock) CreatePublisher(ctx context.Context, args domain.CreatePublisherParams) (domain.Publisher, error) {
+	if mock.CreatePublisherFunc == nil {
+		panic("RepositoryMock.CreatePublisherFunc: method is nil but Repository.CreatePublisher was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreatePublisherParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreatePublisher.Lock()
+	mock.calls.CreatePublisher = append(mock.calls.CreatePublisher, callInfo)
+	lockRepositoryMockCreatePublisher.Unlock()
+	return mock.CreatePublisherFunc(ctx, args)
+}
+
+// CreatePublisherCalls gets all the calls that were made to CreatePublisher.
+// Check the length with:
+//     len(mockedRepository.CreatePublisherCalls())
+func (mock *RepositoryMock) CreatePublisherCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreatePublisherParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreatePublisherParams
+	}
+	lockRepositoryMockCreatePublisher.RLock()
+	calls = mock.calls.CreatePublisher
+	lockRepositoryMockCreatePublisher.RUnlock()
+	return calls
+}
+
+// CreateRepresentation calls CreateRepresentationFunc.
+func (mock *RepositoryMock) CreateRepresentation(ctx context.Context, args domain.CreateRepresentationParams) (domain.Representation, error) {
+	if mock.CreateRepresentationFunc == nil {
+		panic("RepositoryMock.CreateRepresentationFunc: method is nil but Repository.CreateRepresentation was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateRepresentationParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateRepresentation.Lock()
+	mock.calls.CreateRepresentation = append(mock.calls.CreateRepresentation, callInfo)
+	lockRepositoryMockCreateRepresentation.Unlock()
+	return mock.CreateRepresentationFunc(ctx, args)
+}
+
+// CreateRepresentationCalls gets all the calls that were made to CreateRepresentation.
+// Check the length with:
+//     len(mockedRepository.CreateRepresentationCalls())
+func (mock *RepositoryMock) CreateRepresentationCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateRepresentationParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateRepresentationParams
+	}
+	lockRepositoryMockCreateRepresentation.RLock()
+	calls = mock.calls.CreateRepresentation
+	lockRepositoryMockCreateRepresentation.RUnlock()
+	return calls
+}
+
+// CreateSeries calls CreateSeriesFunc.
+func (mock *RepositoryMock) CreateSeries(ctx context.Context, args domain.CreateSeriesParams) (domain.Series, error) {
+	if mock.CreateSeriesFunc == nil {
+		panic("RepositoryMock.CreateSeriesFunc: method is nil but Repository.CreateSeries was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateSeriesParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateSeries.Lock()
+	mock.calls.CreateSeries = append(mock.calls.CreateSeries, callInfo)
+	lockRepositoryMockCreateSeries.Unlock()
+	return mock.CreateSeriesFunc(ctx, args)
+}
+
+// CreateSeriesCalls gets all the calls that were made to CreateSeries.
+// Check the length with:
+//     len(mockedRepository.CreateSeriesCalls())
+func (mock *RepositoryMock) CreateSeriesCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateSeriesParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateSeriesParams
+	}
+	lockRepositoryMockCreateSeries.RLock()
+	calls = mock.calls.CreateSeries
+	lockRepositoryMockCreateSeries.RUnlock()
+	return calls
+}
+
+// CreateSubmission calls CreateSubmissionFunc.
+func (mock *RepositoryMock) CreateSubmission(ctx context.Context, args domain.CreateSubmissionParams) (domain.Submission, error) {
+	if mock.CreateSubmissionFunc == nil {
+		panic("RepositoryMock.CreateSubmissionFunc: method is nil but Repository.CreateSubmission was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateSubmissionParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateSubmission.Lock()
+	mock.calls.CreateSubmission = append(mock.calls.CreateSubmission, callInfo)
+	lockRepositoryMockCreateSubmission.Unlock()
+	return mock.CreateSubmissionFunc(ctx, args)
+}
+
+// CreateSubmissionCalls gets all the calls that were made to CreateSubmission.
+// Check the length with:
+//     len(mockedRepository.CreateSubmissionCalls())
+func (mock *RepositoryMock) CreateSubmissionCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateSubmissionParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateSubmissionParams
+	}
+	lockRepositoryMockCreateSubmission.RLock()
+	calls = mock.calls.CreateSubmission
+	lockRepositoryMockCreateSubmission.RUnlock()
+	return calls
+}
+
+// CreateWebhook calls CreateWebhookFunc.
+func (mock *RepositoryMock) CreateWebhook(ctx context.Context, args domain.CreateWebhookParams) (domain.Webhook, error) {
+	if mock.CreateWebhookFunc == nil {
+		panic("RepositoryMock.CreateWebhookFunc: method is nil but Repository.CreateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CreateWebhookParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockCreateWebhook.Lock()
+	mock.calls.CreateWebhook = append(mock.calls.CreateWebhook, callInfo)
+	lockRepositoryMockCreateWebhook.Unlock()
+	return mock.CreateWebhookFunc(ctx, args)
+}
+
+// CreateWebhookCalls gets all the calls that were made to CreateWebhook.
+// Check the length with:
+//     len(mockedRepository.CreateWebhookCalls())
+func (mock *RepositoryMock) CreateWebhookCalls() []struct {
+	Ctx  context.Context
+	Args domain.CreateWebhookParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CreateWebhookParams
+	}
+	lockRepositoryMockCreateWebhook.RLock()
+	calls = mock.calls.CreateWebhook
+	lockRepositoryMockCreateWebhook.RUnlock()
+	return calls
+}
+
+// DeleteAgency calls DeleteAgencyFunc.
+func (mock *RepositoryMock) DeleteAgency(ctx context.Context, id int64) (domain.Agency, error) {
+	if mock.DeleteAgencyFunc == nil {
+		panic("RepositoryMock.DeleteAgencyFunc: method is nil but Repository.DeleteAgency was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeleteAgency.Lock()
+	mock.calls.DeleteAgency = append(mock.calls.DeleteAgency, callInfo)
+	lockRepositoryMockDeleteAgency.Unlock()
+	return mock.DeleteAgencyFunc(ctx, id)
+}
+
+// DeleteAgencyCalls gets all the calls that were made to DeleteAgency.
+// Check the length with:
+//     len(mockedRepository.DeleteAgencyCalls())
+func (mock *RepositoryMock) DeleteAgencyCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeleteAgency.RLock()
+	calls = mock.calls.DeleteAgency
+	lockRepositoryMockDeleteAgency.RUnlock()
+	return calls
+}
+
+// DeleteAgent calls DeleteAgentFunc.
+func (mock *RepositoryMock) DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+	if mock.DeleteAgentFunc == nil {
+		panic("RepositoryMock.DeleteAgentFunc: method is nil but Repository.DeleteAgent was just called")
+	}
+	callInfo := struct {
+		Ctx               context.Context
+		ID                int64
+		ReassignAuthorsTo *int64
+	}{
+		Ctx:               ctx,
+		ID:                id,
+		ReassignAuthorsTo: reassignAuthorsTo,
+	}
+	lockRepositoryMockDeleteAgent.Lock()
+	mock.calls.DeleteAgent = append(mock.calls.DeleteAgent, callInfo)
+	lockRepositoryMockDeleteAgent.Unlock()
+	return mock.DeleteAgentFunc(ctx, id, reassignAuthorsTo)
+}
+
+// DeleteAgentCalls gets all the calls that were made to DeleteAgent.
+// Check the length with:
+//     len(mockedRepository.DeleteAgentCalls())
+func (mock *RepositoryMock) DeleteAgentCalls() []struct {
+	Ctx               context.Context
+	ID                int64
+	ReassignAuthorsTo *int64
+} {
+	var calls []struct {
+		Ctx               context.Context
+		ID                int64
+		ReassignAuthorsTo *int64
+	}
+	lockRepositoryMockDeleteAgent.RLock()
+	calls = mock.calls.DeleteAgent
+	lockRepositoryMockDeleteAgent.RUnlock()
+	return calls
+}
+
+// DeleteAuthor calls DeleteAuthorFunc.
+func (mock *RepositoryMock) DeleteAuthor(ctx context.Context, id int64, orphanedBooks domain.OrphanedBooksPolicy) (*domain.Author, error) {
+	if mock.DeleteAuthorFunc == nil {
+		panic("RepositoryMock.DeleteAuthorFunc: method is nil but Repository.DeleteAuthor was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		ID            int64
+		OrphanedBooks domain.OrphanedBooksPolicy
+	}{
+		Ctx:           ctx,
+		ID:            id,
+		OrphanedBooks: orphanedBooks,
+	}
+	lockRepositoryMockDeleteAuthor.Lock()
+	mock.calls.DeleteAuthor = append(mock.calls.DeleteAuthor, callInfo)
+	lockRepositoryMockDeleteAuthor.Unlock()
+	return mock.DeleteAuthorFunc(ctx, id, orphanedBooks)
+}
+
+// DeleteAuthorCalls gets all the calls that were made to DeleteAuthor.
+// Check the length with:
+//     len(mockedRepository.DeleteAuthorCalls())
+func (mock *RepositoryMock) DeleteAuthorCalls() []struct {
+	Ctx           context.Context
+	ID            int64
+	OrphanedBooks domain.OrphanedBooksPolicy
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ID            int64
+		OrphanedBooks domain.OrphanedBooksPolicy
+	}
+	lockRepositoryMockDeleteAuthor.RLock()
+	calls = mock.calls.DeleteAuthor
+	lockRepositoryMockDeleteAuthor.RUnlock()
+	return calls
+}
+
+// DeleteBook calls DeleteBookFunc.
+func (mock *RepositoryMock) DeleteBook(ctx context.Context, id int64) (*domain.Book, error) {
+	if mock.DeleteBookFunc == nil {
+		panic("RepositoryMock.DeleteBookFunc: method is nil but Repository.DeleteBook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeleteBook.Lock()
+	mock.calls.DeleteBook = append(mock.calls.DeleteBook, callInfo)
+	lockRepositoryMockDeleteBook.Unlock()
+	return mock.DeleteBookFunc(ctx, id)
+}
+
+// DeleteBookCalls gets all the calls that were made to DeleteBook.
+// Check the length with:
+//     len(mockedRepository.DeleteBookCalls())
+func (mock *RepositoryMock) DeleteBookCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeleteBook.RLock()
+	calls = mock.calls.DeleteBook
+	lockRepositoryMockDeleteBook.RUnlock()
+	return calls
+}
+
+// DeleteDeal calls DeleteDealFunc.
+func (mock *RepositoryMock) DeleteDeal(ctx context.Context, id int64) (domain.Deal, error) {
+	if mock.DeleteDealFunc == nil {
+		panic("RepositoryMock.DeleteDealFunc: method is nil but Repository.DeleteDeal was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeleteDeal.Lock()
+	mock.calls.DeleteDeal = append(mock.calls.DeleteDeal, callInfo)
+	lockRepositoryMockDeleteDeal.Unlock()
+	return mock.DeleteDealFunc(ctx, id)
+}
+
+// DeleteDealCalls gets all the calls that were made to DeleteDeal.
+// Check the length with:
+//     len(mockedRepository.DeleteDealCalls())
+func (mock *RepositoryMock) DeleteDealCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeleteDeal.RLock()
+	calls = mock.calls.DeleteDeal
+	lockRepositoryMockDeleteDeal.RUnlock()
+	return calls
+}
+
+// DeleteEdition calls DeleteEditionFunc.
+func (mock *RepositoryMock) DeleteEdition(ctx context.Context, id int64) (domain.Edition, error) {
+	if mock.DeleteEditionFunc == nil {
+		panic("RepositoryMock.DeleteEditionFunc: method is nil but Repository.DeleteEdition was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeleteEdition.Lock()
+	mock.calls.DeleteEdition = append(mock.calls.DeleteEdition, callInfo)
+	lockRepositoryMockDeleteEdition.Unlock()
+	return mock.DeleteEditionFunc(ctx, id)
+}
+
+// DeleteEditionCalls gets all the calls that were made to DeleteEdition.
+// Check the length with:
+//     len(mockedRepository.DeleteEditionCalls())
+func (mock *RepositoryMock) DeleteEditionCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeleteEdition.RLock()
+	calls = mock.calls.DeleteEdition
+	lockRepositoryMockDeleteEdition.RUnlock()
+	return calls
+}
+
+// DeleteGenre calls DeleteGenreFunc.
+func (mock *RepositoryMock) DeleteGenre(ctx context.Context, id int64) (domain.Genre, error) {
+	if mock.DeleteGenreFunc == nil {
+		panic("RepositoryMock.DeleteGenreFunc: method is nil but Repository.DeleteGenre was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeleteGenre.Lock()
+	mock.calls.DeleteGenre = append(mock.calls.DeleteGenre, callInfo)
+	lockRepositoryMockDeleteGenre.Unlock()
+	return mock.DeleteGenreFunc(ctx, id)
+}
+
+// DeleteGenreCalls gets all the calls that were made to DeleteGenre.
+// Check the length with:
+//     len(mockedRepository.DeleteGenreCalls())
+func (mock *RepositoryMock) DeleteGenreCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeleteGenre.RLock()
+	calls = mock.calls.DeleteGenre
+	lockRepositoryMockDeleteGenre.RUnlock()
+	return calls
+}
+
+// DeletePublisher calls DeletePublisherFunc.
+func (mock *RepositoryMock) DeletePublisher(ctx context.Context, id int64) (domain.Publisher, error) {
+	if mock.DeletePublisherFunc == nil {
+		panic("RepositoryMock.DeletePublisherFunc: method is nil but Repository.DeletePublisher was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeletePublisher.Lock()
+	mock.calls.DeletePublisher = append(mock.calls.DeletePublisher, callInfo)
+	lockRepositoryMockDeletePublisher.Unlock()
+	return mock.DeletePublisherFunc(ctx, id)
+}
+
+// DeletePublisherCalls gets all the calls that were made to DeletePublisher.
+// Check the length with:
+//     len(mockedRepository.DeletePublisherCalls())
+func (mock *RepositoryMock) DeletePublisherCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeletePublisher.RLock()
+	calls = mock.calls.DeletePublisher
+	lockRepositoryMockDeletePublisher.RUnlock()
+	return calls
+}
+
+// DeleteRoyaltyRate calls DeleteRoyaltyRateFunc.
+func (mock *RepositoryMock) DeleteRoyaltyRate(ctx context.Context, dealID int64, editionID int64) (domain.RoyaltyRate, error) {
+	if mock.DeleteRoyaltyRateFunc == nil {
+		panic("RepositoryMock.DeleteRoyaltyRateFunc: method is nil but Repository.DeleteRoyaltyRate was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DealID    int64
+		EditionID int64
+	}{
+		Ctx:       ctx,
+		DealID:    dealID,
+		EditionID: editionID,
+	}
+	lockRepositoryMockDeleteRoyaltyRate.Lock()
+	mock.calls.DeleteRoyaltyRate = append(mock.calls.DeleteRoyaltyRate, callInfo)
+	lockRepositoryMockDeleteRoyaltyRate.Unlock()
+	return mock.DeleteRoyaltyRateFunc(ctx, dealID, editionID)
+}
+
+// DeleteRoyaltyRateCalls gets all the calls that were made to DeleteRoyaltyRate.
+// Check the length with:
+//     len(mockedRepository.DeleteRoyaltyRateCalls())
+func (mock *RepositoryMock) DeleteRoyaltyRateCalls() []struct {
+	Ctx       context.Context
+	DealID    int64
+	EditionID int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DealID    int64
+		EditionID int64
+	}
+	lockRepositoryMockDeleteRoyaltyRate.RLock()
+	calls = mock.calls.DeleteRoyaltyRate
+	lockRepositoryMockDeleteRoyaltyRate.RUnlock()
+	return calls
+}
+
+// DeleteSales calls DeleteSalesFunc.
+func (mock *RepositoryMock) DeleteSales(ctx context.Context, id int64) (domain.Sales, error) {
+	if mock.DeleteSalesFunc == nil {
+		panic("RepositoryMock.DeleteSalesFunc: method is nil but Repository.DeleteSales was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeleteSales.Lock()
+	mock.calls.DeleteSales = append(mock.calls.DeleteSales, callInfo)
+	lockRepositoryMockDeleteSales.Unlock()
+	return mock.DeleteSalesFunc(ctx, id)
+}
+
+// DeleteSalesCalls gets all the calls that were made to DeleteSales.
+// Check the length with:
+//     len(mockedRepository.DeleteSalesCalls())
+func (mock *RepositoryMock) DeleteSalesCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeleteSales.RLock()
+	calls = mock.calls.DeleteSales
+	lockRepositoryMockDeleteSales.RUnlock()
+	return calls
+}
+
+// DeleteSeries calls DeleteSeriesFunc.
+func (mock *RepositoryMock) DeleteSeries(ctx context.Context, id int64) (*domain.Series, error) {
+	if mock.DeleteSeriesFunc == nil {
+		panic("RepositoryMock.DeleteSeriesFunc: method is nil but Repository.DeleteSeries was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeleteSeries.Lock()
+	mock.calls.DeleteSeries = append(mock.calls.DeleteSeries, callInfo)
+	lockRepositoryMockDeleteSeries.Unlock()
+	return mock.DeleteSeriesFunc(ctx, id)
+}
+
+// DeleteSeriesCalls gets all the calls that were made to DeleteSeries.
+// Check the length with:
+//     len(mockedRepository.DeleteSeriesCalls())
+func (mock *RepositoryMock) DeleteSeriesCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeleteSeries.RLock()
+	calls = mock.calls.DeleteSeries
+	lockRepositoryMockDeleteSeries.RUnlock()
+	return calls
+}
+
+// DeleteWebhook calls DeleteWebhookFunc.
+func (mock *RepositoryMock) DeleteWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	if mock.DeleteWebhookFunc == nil {
+		panic("RepositoryMock.DeleteWebhookFunc: method is nil but Repository.DeleteWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockDeleteWebhook.Lock()
+	mock.calls.DeleteWebhook = append(mock.calls.DeleteWebhook, callInfo)
+	lockRepositoryMockDeleteWebhook.Unlock()
+	return mock.DeleteWebhookFunc(ctx, id)
+}
+
+// DeleteWebhookCalls gets all the calls that were made to DeleteWebhook.
+// Check the length with:
+//     len(mockedRepository.DeleteWebhookCalls())
+func (mock *RepositoryMock) DeleteWebhookCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockDeleteWebhook.RLock()
+	calls = mock.calls.DeleteWebhook
+	lockRepositoryMockDeleteWebhook.RUnlock()
+	return calls
+}
+
+// EndRepresentation calls EndRepresentationFunc.
+func (mock *RepositoryMock) EndRepresentation(ctx context.Context, id int64, endedAt domain.Date) (domain.Representation, error) {
+	if mock.EndRepresentationFunc == nil {
+		panic("RepositoryMock.EndRepresentationFunc: method is nil but Repository.EndRepresentation was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		EndedAt domain.Date
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		EndedAt: endedAt,
+	}
+	lockRepositoryMockEndRepresentation.Lock()
+	mock.calls.EndRepresentation = append(mock.calls.EndRepresentation, callInfo)
+	lockRepositoryMockEndRepresentation.Unlock()
+	return mock.EndRepresentationFunc(ctx, id, endedAt)
+}
+
+// EndRepresentationCalls gets all the calls that were made to EndRepresentation.
+// Check the length with:
+//     len(mockedRepository.EndRepresentationCalls())
+func (mock *RepositoryMock) EndRepresentationCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	EndedAt domain.Date
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		EndedAt domain.Date
+	}
+	lockRepositoryMockEndRepresentation.RLock()
+	calls = mock.calls.EndRepresentation
+	lockRepositoryMockEndRepresentation.RUnlock()
+	return calls
+}
+
+// GetAgency calls GetAgencyFunc.
+func (mock *RepositoryMock) GetAgency(ctx context.Context, id int64) (domain.Agency, error) {
+	if mock.GetAgencyFunc == nil {
+		panic("RepositoryMock.GetAgencyFunc: method is nil but Repository.GetAgency was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetAgency.Lock()
+	mock.calls.GetAgency = append(mock.calls.GetAgency, callInfo)
+	lockRepositoryMockGetAgency.Unlock()
+	return mock.GetAgencyFunc(ctx, id)
+}
+
+// GetAgencyCalls gets all the calls that were made to GetAgency.
+// Check the length with:
+//     len(mockedRepository.GetAgencyCalls())
+func (mock *RepositoryMock) GetAgencyCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetAgency.RLock()
+	calls = mock.calls.GetAgency
+	lockRepositoryMockGetAgency.RUnlock()
+	return calls
+}
+
+// GetAgent calls GetAgentFunc.
+func (mock *RepositoryMock) GetAgent(ctx context.Context, id int64) (domain.Agent, error) {
+	if mock.GetAgentFunc == nil {
+		panic("RepositoryMock.GetAgentFunc: method is nil but Repository.GetAgent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetAgent.Lock()
+	mock.calls.GetAgent = append(mock.calls.GetAgent, callInfo)
+	lockRepositoryMockGetAgent.Unlock()
+	return mock.GetAgentFunc(ctx, id)
+}
+
+// GetAgentCalls gets all the calls that were made to GetAgent.
+// Check the length with:
+//     len(mockedRepository.GetAgentCalls())
+func (mock *RepositoryMock) GetAgentCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetAgent.RLock()
+	calls = mock.calls.GetAgent
+	lockRepositoryMockGetAgent.RUnlock()
+	return calls
+}
+
+// GetAuthor calls GetAuthorFunc.
+func (mock *RepositoryMock) GetAuthor(ctx context.Context, id int64) (domain.Author, error) {
+	if mock.GetAuthorFunc == nil {
+		panic("RepositoryMock.GetAuthorFunc: method is nil but Repository.GetAuthor was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetAuthor.Lock()
+	mock.calls.GetAuthor = append(mock.calls.GetAuthor, callInfo)
+	lockRepositoryMockGetAuthor.Unlock()
+	return mock.GetAuthorFunc(ctx, id)
+}
+
+// GetAuthorCalls gets all the calls that were made to GetAuthor.
+// Check the length with:
+//     len(mockedRepository.GetAuthorCalls())
+func (mock *RepositoryMock) GetAuthorCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetAuthor.RLock()
+	calls = mock.calls.GetAuthor
+	lockRepositoryMockGetAuthor.RUnlock()
+	return calls
+}
+
+// GetBook calls GetBookFunc.
+func (mock *RepositoryMock) GetBook(ctx context.Context, id int64) (domain.Book, error) {
+	if mock.GetBookFunc == nil {
+		panic("RepositoryMock.GetBookFunc: method is nil but Repository.GetBook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetBook.Lock()
+	mock.calls.GetBook = append(mock.calls.GetBook, callInfo)
+	lockRepositoryMockGetBook.Unlock()
+	return mock.GetBookFunc(ctx, id)
+}
+
+// GetBookCalls gets all the calls that were made to GetBook.
+// Check the length with:
+//     len(mockedRepository.GetBookCalls())
+func (mock *RepositoryMock) GetBookCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetBook.RLock()
+	calls = mock.calls.GetBook
+	lockRepositoryMockGetBook.RUnlock()
+	return calls
+}
+
+// GetBookByISBN calls GetBookByISBNFunc.
+func (mock *RepositoryMock) GetBookByISBN(ctx context.Context, isbn domain.ISBN) (domain.Book, error) {
+	if mock.GetBookByISBNFunc == nil {
+		panic("RepositoryMock.GetBookByISBNFunc: method is nil but Repository.GetBookByISBN was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Isbn domain.ISBN
+	}{
+		Ctx:  ctx,
+		Isbn: isbn,
+	}
+	lockRepositoryMockGetBookByISBN.Lock()
+	mock.calls.GetBookByISBN = append(mock.calls.GetBookByISBN, callInfo)
+	lockRepositoryMockGetBookByISBN.Unlock()
+	return mock.GetBookByISBNFunc(ctx, isbn)
+}
+
+// GetBookByISBNCalls gets all the calls that were made to GetBookByISBN.
+// Check the length with:
+//     len(mockedRepository.GetBookByISBNCalls())
+func (mock *RepositoryMock) GetBookByISBNCalls() []struct {
+	Ctx  context.Context
+	Isbn domain.ISBN
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Isbn domain.ISBN
+	}
+	lockRepositoryMockGetBookByISBN.RLock()
+	calls = mock.calls.GetBookByISBN
+	lockRepositoryMockGetBookByISBN.RUnlock()
+	return calls
+}
+
+// GetDeal calls GetDealFunc.
+func (mock *RepositoryMock) GetDeal(ctx context.Context, id int64) (domain.Deal, error) {
+	if mock.GetDealFunc == nil {
+		panic("RepositoryMock.GetDealFunc: method is nil but Repository.GetDeal was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetDeal.Lock()
+	mock.calls.GetDeal = append(mock.calls.GetDeal, callInfo)
+	lockRepositoryMockGetDeal.Unlock()
+	return mock.GetDealFunc(ctx, id)
+}
+
+// GetDealCalls gets all the calls that were made to GetDeal.
+// Check the length with:
+//     len(mockedRepository.GetDealCalls())
+func (mock *RepositoryMock) GetDealCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetDeal.RLock()
+	calls = mock.calls.GetDeal
+	lockRepositoryMockGetDeal.RUnlock()
+	return calls
+}
+
+// GetEdition calls GetEditionFunc.
+func (mock *RepositoryMock) GetEdition(ctx context.Context, id int64) (domain.Edition, error) {
+	if mock.GetEditionFunc == nil {
+		panic("RepositoryMock.GetEditionFunc: method is nil but Repository.GetEdition was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetEdition.Lock()
+	mock.calls.GetEdition = append(mock.calls.GetEdition, callInfo)
+	lockRepositoryMockGetEdition.Unlock()
+	return mock.GetEditionFunc(ctx, id)
+}
+
+// GetEditionCalls gets all the calls that were made to GetEdition.
+// Check the length with:
+//     len(mockedRepository.GetEditionCalls())
+func (mock *RepositoryMock) GetEditionCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetEdition.RLock()
+	calls = mock.calls.GetEdition
+	lockRepositoryMockGetEdition.RUnlock()
+	return calls
+}
+
+// GetEditionByISBN calls GetEditionByISBNFunc.
+func (mock *RepositoryMock) GetEditionByISBN(ctx context.Context, isbn domain.ISBN) (domain.Edition, error) {
+	if mock.GetEditionByISBNFunc == nil {
+		panic("RepositoryMock.GetEditionByISBNFunc: method is nil but Repository.GetEditionByISBN was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Isbn domain.ISBN
+	}{
+		Ctx:  ctx,
+		Isbn: isbn,
+	}
+	lockRepositoryMockGetEditionByISBN.Lock()
+	mock.calls.GetEditionByISBN = append(mock.calls.GetEditionByISBN, callInfo)
+	lockRepositoryMockGetEditionByISBN.Unlock()
+	return mock.GetEditionByISBNFunc(ctx, isbn)
+}
+
+// GetEditionByISBNCalls gets all the calls that were made to GetEditionByISBN.
+// Check the length with:
+//     len(mockedRepository.GetEditionByISBNCalls())
+func (mock *RepositoryMock) GetEditionByISBNCalls() []struct {
+	Ctx  context.Context
+	Isbn domain.ISBN
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Isbn domain.ISBN
+	}
+	lockRepositoryMockGetEditionByISBN.RLock()
+	calls = mock.calls.GetEditionByISBN
+	lockRepositoryMockGetEditionByISBN.RUnlock()
+	return calls
+}
+
+// GetGenre calls GetGenreFunc.
+func (mock *RepositoryMock) GetGenre(ctx context.Context, id int64) (domain.Genre, error) {
+	if mock.GetGenreFunc == nil {
+		panic("RepositoryMock.GetGenreFunc: method is nil but Repository.GetGenre was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetGenre.Lock()
+	mock.calls.GetGenre = append(mock.calls.GetGenre, callInfo)
+	lockRepositoryMockGetGenre.Unlock()
+	return mock.GetGenreFunc(ctx, id)
+}
+
+// GetGenreCalls gets all the calls that were made to GetGenre.
+// Check the length with:
+//     len(mockedRepository.GetGenreCalls())
+func (mock *RepositoryMock) GetGenreCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetGenre.RLock()
+	calls = mock.calls.GetGenre
+	lockRepositoryMockGetGenre.RUnlock()
+	return calls
+}
+
+// GetPublisher calls GetPublisherFunc.
+func (mock *RepositoryMock) GetPublisher(ctx context.Context, id int64) (domain.Publisher, error) {
+	if mock.GetPublisherFunc == nil {
+		panic("RepositoryMock.GetPublisherFunc: method is nil but Repository.GetPublisher was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetPublisher.Lock()
+	mock.calls.GetPublisher = append(mock.calls.GetPublisher, callInfo)
+	lockRepositoryMockGetPublisher.Unlock()
+	return mock.GetPublisherFunc(ctx, id)
+}
+
+// GetPublisherCalls gets all the calls that were made to GetPublisher.
+// Check the length with:
+//     len(mockedRepository.GetPublisherCalls())
+func (mock *RepositoryMock) GetPublisherCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetPublisher.RLock()
+	calls = mock.calls.GetPublisher
+	lockRepositoryMockGetPublisher.RUnlock()
+	return calls
+}
+
+// GetRoyaltyStatement calls GetRoyaltyStatementFunc.
+func (mock *RepositoryMock) GetRoyaltyStatement(ctx context.Context, authorID int64, period string) (domain.RoyaltyStatement, error) {
+	if mock.GetRoyaltyStatementFunc == nil {
+		panic("RepositoryMock.GetRoyaltyStatementFunc: method is nil but Repository.GetRoyaltyStatement was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AuthorID int64
+		Period   string
+	}{
+		Ctx:      ctx,
+		AuthorID: authorID,
+		Period:   period,
+	}
+	lockRepositoryMockGetRoyaltyStatement.Lock()
+	mock.calls.GetRoyaltyStatement = append(mock.calls.GetRoyaltyStatement, callInfo)
+	lockRepositoryMockGetRoyaltyStatement.Unlock()
+	return mock.GetRoyaltyStatementFunc(ctx, authorID, period)
+}
+
+// GetRoyaltyStatementCalls gets all the calls that were made to GetRoyaltyStatement.
+// Check the length with:
+//     len(mockedRepository.GetRoyaltyStatementCalls())
+func (mock *RepositoryMock) GetRoyaltyStatementCalls() []struct {
+	Ctx      context.Context
+	AuthorID int64
+	Period   string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AuthorID int64
+		Period   string
+	}
+	lockRepositoryMockGetRoyaltyStatement.RLock()
+	calls = mock.calls.GetRoyaltyStatement
+	lockRepositoryMockGetRoyaltyStatement.RUnlock()
+	return calls
+}
+
+// GetSeries calls GetSeriesFunc.
+func (mock *RepositoryMock) GetSeries(ctx context.Context, id int64) (domain.Series, error) {
+	if mock.GetSeriesFunc == nil {
+		panic("RepositoryMock.GetSeriesFunc: method is nil but Repository.GetSeries was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetSeries.Lock()
+	mock.calls.GetSeries = append(mock.calls.GetSeries, callInfo)
+	lockRepositoryMockGetSeries.Unlock()
+	return mock.GetSeriesFunc(ctx, id)
+}
+
+// GetSeriesCalls gets all the calls that were made to GetSeries.
+// Check the length with:
+//     len(mockedRepository.GetSeriesCalls())
+func (mock *RepositoryMock) GetSeriesCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetSeries.RLock()
+	calls = mock.calls.GetSeries
+	lockRepositoryMockGetSeries.RUnlock()
+	return calls
+}
+
+// GetSubmission calls GetSubmissionFunc.
+func (mock *RepositoryMock) GetSubmission(ctx context.Context, id int64) (domain.Submission, error) {
+	if mock.GetSubmissionFunc == nil {
+		panic("RepositoryMock.GetSubmissionFunc: method is nil but Repository.GetSubmission was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetSubmission.Lock()
+	mock.calls.GetSubmission = append(mock.calls.GetSubmission, callInfo)
+	lockRepositoryMockGetSubmission.Unlock()
+	return mock.GetSubmissionFunc(ctx, id)
+}
+
+// GetSubmissionCalls gets all the calls that were made to GetSubmission.
+// Check the length with:
+//     len(mockedRepository.GetSubmissionCalls())
+func (mock *RepositoryMock) GetSubmissionCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetSubmission.RLock()
+	calls = mock.calls.GetSubmission
+	lockRepositoryMockGetSubmission.RUnlock()
+	return calls
+}
+
+// GetWebhook calls GetWebhookFunc.
+func (mock *RepositoryMock) GetWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	if mock.GetWebhookFunc == nil {
+		panic("RepositoryMock.GetWebhookFunc: method is nil but Repository.GetWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockGetWebhook.Lock()
+	mock.calls.GetWebhook = append(mock.calls.GetWebhook, callInfo)
+	lockRepositoryMockGetWebhook.Unlock()
+	return mock.GetWebhookFunc(ctx, id)
+}
+
+// GetWebhookCalls gets all the calls that were made to GetWebhook.
+// Check the length with:
+//     len(mockedRepository.GetWebhookCalls())
+func (mock *RepositoryMock) GetWebhookCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockGetWebhook.RLock()
+	calls = mock.calls.GetWebhook
+	lockRepositoryMockGetWebhook.RUnlock()
+	return calls
+}
+
+// ListAgencies calls ListAgenciesFunc.
+func (mock *RepositoryMock) ListAgencies(ctx context.Context) ([]domain.Agency, error) {
+	if mock.ListAgenciesFunc == nil {
+		panic("RepositoryMock.ListAgenciesFunc: method is nil but Repository.ListAgencies was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListAgencies.Lock()
+	mock.calls.ListAgencies = append(mock.calls.ListAgencies, callInfo)
+	lockRepositoryMockListAgencies.Unlock()
+	return mock.ListAgenciesFunc(ctx)
+}
+
+// ListAgenciesCalls gets all the calls that were made to ListAgencies.
+// Check the length with:
+//     len(mockedRepository.ListAgenciesCalls())
+func (mock *RepositoryMock) ListAgenciesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListAgencies.RLock()
+	calls = mock.calls.ListAgencies
+	lockRepositoryMockListAgencies.RUnlock()
+	return calls
+}
+
+// ListAgents calls ListAgentsFunc.
+func (mock *RepositoryMock) ListAgents(ctx context.Context) ([]domain.Agent, error) {
+	if mock.ListAgentsFunc == nil {
+		panic("RepositoryMock.ListAgentsFunc: method is nil but Repository.ListAgents was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListAgents.Lock()
+	mock.calls.ListAgents = append(mock.calls.ListAgents, callInfo)
+	lockRepositoryMockListAgents.Unlock()
+	return mock.ListAgentsFunc(ctx)
+}
+
+// ListAgentsCalls gets all the calls that were made to ListAgents.
+// Check the length with:
+//     len(mockedRepository.ListAgentsCalls())
+func (mock *RepositoryMock) ListAgentsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListAgents.RLock()
+	calls = mock.calls.ListAgents
+	lockRepositoryMockListAgents.RUnlock()
+	return calls
+}
+
+// ListAgentsByAgencyID calls ListAgentsByAgencyIDFunc.
+func (mock *RepositoryMock) ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]domain.Agent, error) {
+	if mock.ListAgentsByAgencyIDFunc == nil {
+		panic("RepositoryMock.ListAgentsByAgencyIDFunc: method is nil but Repository.ListAgentsByAgencyID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AgencyID int64
+	}{
+		Ctx:      ctx,
+		AgencyID: agencyID,
+	}
+	lockRepositoryMockListAgentsByAgencyID.Lock()
+	mock.calls.ListAgentsByAgencyID = append(mock.calls.ListAgentsByAgencyID, callInfo)
+	lockRepositoryMockListAgentsByAgencyID.Unlock()
+	return mock.ListAgentsByAgencyIDFunc(ctx, agencyID)
+}
+
+// ListAgentsByAgencyIDCalls gets all the calls that were made to ListAgentsByAgencyID.
+// Check the length with:
+//     len(mockedRepository.ListAgentsByAgencyIDCalls())
+func (mock *RepositoryMock) ListAgentsByAgencyIDCalls() []struct {
+	Ctx      context.Context
+	AgencyID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AgencyID int64
+	}
+	lockRepositoryMockListAgentsByAgencyID.RLock()
+	calls = mock.calls.ListAgentsByAgencyID
+	lockRepositoryMockListAgentsByAgencyID.RUnlock()
+	return calls
+}
+
+// ListAuthors calls ListAuthorsFunc.
+func (mock *RepositoryMock) ListAuthors(ctx context.Context) ([]domain.Author, error) {
+	if mock.ListAuthorsFunc == nil {
+		panic("RepositoryMock.ListAuthorsFunc: method is nil but Repository.ListAuthors was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListAuthors.Lock()
+	mock.calls.ListAuthors = append(mock.calls.ListAuthors, callInfo)
+	lockRepositoryMockListAuthors.Unlock()
+	return mock.ListAuthorsFunc(ctx)
+}
+
+// ListAuthorsCalls gets all the calls that were made to ListAuthors.
+// Check the length with:
+//     len(mockedRepository.ListAuthorsCalls())
+func (mock *RepositoryMock) ListAuthorsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListAuthors.RLock()
+	calls = mock.calls.ListAuthors
+	lockRepositoryMockListAuthors.RUnlock()
+	return calls
+}
+
+// ListAuthorsByAgencyID calls ListAuthorsByAgencyIDFunc.
+func (mock *RepositoryMock) ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]domain.Author, error) {
+	if mock.ListAuthorsByAgencyIDFunc == nil {
+		panic("RepositoryMock.ListAuthorsByAgencyIDFunc: method is nil but Repository.ListAuthorsByAgencyID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AgencyID int64
+	}{
+		Ctx:      ctx,
+		AgencyID: agencyID,
+	}
+	lockRepositoryMockListAuthorsByAgencyID.Lock()
+	mock.calls.ListAuthorsByAgencyID = append(mock.calls.ListAuthorsByAgencyID, callInfo)
+	lockRepositoryMockListAuthorsByAgencyID.Unlock()
+	return mock.ListAuthorsByAgencyIDFunc(ctx, agencyID)
+}
+
+// ListAuthorsByAgencyIDCalls gets all the calls that were made to ListAuthorsByAgencyID.
+// Check the length with:
+//     len(mockedRepository.ListAuthorsByAgencyIDCalls())
+func (mock *RepositoryMock) ListAuthorsByAgencyIDCalls() []struct {
+	Ctx      context.Context
+	AgencyID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AgencyID int64
+	}
+	lockRepositoryMockListAuthorsByAgencyID.RLock()
+	calls = mock.calls.ListAuthorsByAgencyID
+	lockRepositoryMockListAuthorsByAgencyID.RUnlock()
+	return calls
+}
+
+// ListAuthorsByAgentID calls ListAuthorsByAgentIDFunc.
+func (mock *RepositoryMock) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]domain.Author, error) {
+	if mock.ListAuthorsByAgentIDFunc == nil {
+		panic("RepositoryMock.ListAuthorsByAgentIDFunc: method is nil but Repository.ListAuthorsByAgentID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AgentID int64
+	}{
+		Ctx:     ctx,
+		AgentID: agentID,
+	}
+	lockRepositoryMockListAuthorsByAgentID.Lock()
+	mock.calls.ListAuthorsByAgentID = append(mock.calls.ListAuthorsByAgentID, callInfo)
+	lockRepositoryMockListAuthorsByAgentID.Unlock()
+	return mock.ListAuthorsByAgentIDFunc(ctx, agentID)
+}
+
+// ListAuthorsByAgentIDCalls gets all the calls that were made to ListAuthorsByAgentID.
+// Check the length with:
+//     len(mockedRepository.ListAuthorsByAgentIDCalls())
+func (mock *RepositoryMock) ListAuthorsByAgentIDCalls() []struct {
+	Ctx     context.Context
+	AgentID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AgentID int64
+	}
+	lockRepositoryMockListAuthorsByAgentID.RLock()
+	calls = mock.calls.ListAuthorsByAgentID
+	lockRepositoryMockListAuthorsByAgentID.RUnlock()
+	return calls
+}
+
+// ListAuthorsByBookID calls ListAuthorsByBookIDFunc.
+func (mock *RepositoryMock) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]domain.Author, error) {
+	if mock.ListAuthorsByBookIDFunc == nil {
+		panic("RepositoryMock.ListAuthorsByBookIDFunc: method is nil but Repository.ListAuthorsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockRepositoryMockListAuthorsByBookID.Lock()
+	mock.calls.ListAuthorsByBookID = append(mock.calls.ListAuthorsByBookID, callInfo)
+	lockRepositoryMockListAuthorsByBookID.Unlock()
+	return mock.ListAuthorsByBookIDFunc(ctx, bookID)
+}
+
+// ListAuthorsByBookIDCalls gets all the calls that were made to ListAuthorsByBookID.
+// Check the length with:
+//     len(mockedRepository.ListAuthorsByBookIDCalls())
+func (mock *RepositoryMock) ListAuthorsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockRepositoryMockListAuthorsByBookID.RLock()
+	calls = mock.calls.ListAuthorsByBookID
+	lockRepositoryMockListAuthorsByBookID.RUnlock()
+	return calls
+}
+
+// ListAvailableRights calls ListAvailableRightsFunc.
+func (mock *RepositoryMock) ListAvailableRights(ctx context.Context, territory string, language domain.LanguageCode) ([]domain.AvailableRights, error) {
+	if mock.ListAvailableRightsFunc == nil {
+		panic("RepositoryMock.ListAvailableRightsFunc: method is nil but Repository.ListAvailableRights was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Territory string
+		Language  domain.LanguageCode
+	}{
+		Ctx:       ctx,
+		Territory: territory,
+		Language:  language,
+	}
+	lockRepositoryMockListAvailableRights.Lock()
+	mock.calls.ListAvailableRights = append(mock.calls.ListAvailableRights, callInfo)
+	lockRepositoryMockListAvailableRights.Unlock()
+	return mock.ListAvailableRightsFunc(ctx, territory, language)
+}
+
+// ListAvailableRightsCalls gets all the calls that were made to ListAvailableRights.
+// Check the length with:
+//     len(mockedRepository.ListAvailableRightsCalls())
+func (mock *RepositoryMock) ListAvailableRightsCalls() []struct {
+	Ctx       context.Context
+	Territory string
+	Language  domain.LanguageCode
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Territory string
+		Language  domain.LanguageCode
+	}
+	lockRepositoryMockListAvailableRights.RLock()
+	calls = mock.calls.ListAvailableRights
+	lockRepositoryMockListAvailableRights.RUnlock()
+	return calls
+}
+
+// ListBooks calls ListBooksFunc.
+func (mock *RepositoryMock) ListBooks(ctx context.Context) ([]domain.Book, error) {
+	if mock.ListBooksFunc == nil {
+		panic("RepositoryMock.ListBooksFunc: method is nil but Repository.ListBooks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListBooks.Lock()
+	mock.calls.ListBooks = append(mock.calls.ListBooks, callInfo)
+	lockRepositoryMockListBooks.Unlock()
+	return mock.ListBooksFunc(ctx)
+}
+
+// ListBooksCalls gets all the calls that were made to ListBooks.
+// Check the length with:
+//     len(mockedRepository.ListBooksCalls())
+func (mock *RepositoryMock) ListBooksCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListBooks.RLock()
+	calls = mock.calls.ListBooks
+	lockRepositoryMockListBooks.RUnlock()
+	return calls
+}
+
+// ListBooksByAgencyID calls ListBooksByAgencyIDFunc.
+func (mock *RepositoryMock) ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]domain.Book, error) {
+	if mock.ListBooksByAgencyIDFunc == nil {
+		panic("RepositoryMock.ListBooksByAgencyIDFunc: method is nil but Repository.ListBooksByAgencyID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AgencyID int64
+	}{
+		Ctx:      ctx,
+		AgencyID: agencyID,
+	}
+	lockRepositoryMockListBooksByAgencyID.Lock()
+	mock.calls.ListBooksByAgencyID = append(mock.calls.ListBooksByAgencyID, callInfo)
+	lockRepositoryMockListBooksByAgencyID.Unlock()
+	return mock.ListBooksByAgencyIDFunc(ctx, agencyID)
+}
+
+// ListBooksByAgencyIDCalls gets all the calls that were made to ListBooksByAgencyID.
+// Check the length with:
+//     len(mockedRepository.ListBooksByAgencyIDCalls())
+func (mock *RepositoryMock) ListBooksByAgencyIDCalls() []struct {
+	Ctx      context.Context
+	AgencyID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AgencyID int64
+	}
+	lockRepositoryMockListBooksByAgencyID.RLock()
+	calls = mock.calls.ListBooksByAgencyID
+	lockRepositoryMockListBooksByAgencyID.RUnlock()
+	return calls
+}
+
+// ListBooksByAuthorID calls ListBooksByAuthorIDFunc.
+func (mock *RepositoryMock) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]domain.Book, error) {
+	if mock.ListBooksByAuthorIDFunc == nil {
+		panic("RepositoryMock.ListBooksByAuthorIDFunc: method is nil but Repository.ListBooksByAuthorID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AuthorID int64
+	}{
+		Ctx:      ctx,
+		AuthorID: authorID,
+	}
+	lockRepositoryMockListBooksByAuthorID.Lock()
+	mock.calls.ListBooksByAuthorID = append(mock.calls.ListBooksByAuthorID, callInfo)
+	lockRepositoryMockListBooksByAuthorID.Unlock()
+	return mock.ListBooksByAuthorIDFunc(ctx, authorID)
+}
+
+// ListBooksByAuthorIDCalls gets all the calls that were made to ListBooksByAuthorID.
+// Check the length with:
+//     len(mockedRepository.ListBooksByAuthorIDCalls())
+func (mock *RepositoryMock) ListBooksByAuthorIDCalls() []struct {
+	Ctx      context.Context
+	AuthorID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AuthorID int64
+	}
+	lockRepositoryMockListBooksByAuthorID.RLock()
+	calls = mock.calls.ListBooksByAuthorID
+	lockRepositoryMockListBooksByAuthorID.RUnlock()
+	return calls
+}
+
+// ListBooksByGenreID calls ListBooksByGenreIDFunc.
+func (mock *RepositoryMock) ListBooksByGenreID(ctx context.Context, genreID int64, includeSubgenres bool) ([]domain.Book, error) {
+	if mock.ListBooksByGenreIDFunc == nil {
+		panic("RepositoryMock.ListBooksByGenreIDFunc: method is nil but Repository.ListBooksByGenreID was just called")
+	}
+	callInfo := struct {
+		Ctx              context.Context
+		GenreID          int64
+		IncludeSubgenres bool
+	}{
+		Ctx:              ctx,
+		GenreID:          genreID,
+		IncludeSubgenres: includeSubgenres,
+	}
+	lockRepositoryMockListBooksByGenreID.Lock()
+	mock.calls.ListBooksByGenreID = append(mock.calls.ListBooksByGenreID, callInfo)
+	lockRepositoryMockListBooksByGenreID.Unlock()
+	return mock.ListBooksByGenreIDFunc(ctx, genreID, includeSubgenres)
+}
+
+// ListBooksByGenreIDCalls gets all the calls that were made to ListBooksByGenreID.
+// Check the length with:
+//     len(mockedRepository.ListBooksByGenreIDCalls())
+func (mock *RepositoryMock) ListBooksByGenreIDCalls() []struct {
+	Ctx              context.Context
+	GenreID          int64
+	IncludeSubgenres bool
+} {
+	var calls []struct {
+		Ctx              context.Context
+		GenreID          int64
+		IncludeSubgenres bool
+	}
+	lockRepositoryMockListBooksByGenreID.RLock()
+	calls = mock.calls.ListBooksByGenreID
+	lockRepositoryMockListBooksByGenreID.RUnlock()
+	return calls
+}
+
+// ListBooksByPublisherID calls ListBooksByPublisherIDFunc.
+func (mock *RepositoryMock) ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]domain.Book, error) {
+	if mock.ListBooksByPublisherIDFunc == nil {
+		panic("RepositoryMock.ListBooksByPublisherIDFunc: method is nil but Repository.ListBooksByPublisherID was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		PublisherID int64
+	}{
+		Ctx:         ctx,
+		PublisherID: publisherID,
+	}
+	lockRepositoryMockListBooksByPublisherID.Lock()
+	mock.calls.ListBooksByPublisherID = append(mock.calls.ListBooksByPublisherID, callInfo)
+	lockRepositoryMockListBooksByPublisherID.Unlock()
+	return mock.ListBooksByPublisherIDFunc(ctx, publisherID)
+}
+
+// ListBooksByPublisherIDCalls gets all the calls that were made to ListBooksByPublisherID.
+// Check the length with:
+//     len(mockedRepository.ListBooksByPublisherIDCalls())
+func (mock *RepositoryMock) ListBooksByPublisherIDCalls() []struct {
+	Ctx         context.Context
+	PublisherID int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		PublisherID int64
+	}
+	lockRepositoryMockListBooksByPublisherID.RLock()
+	calls = mock.calls.ListBooksByPublisherID
+	lockRepositoryMockListBooksByPublisherID.RUnlock()
+	return calls
+}
+
+// ListBooksBySeriesID calls ListBooksBySeriesIDFunc.
+func (mock *RepositoryMock) ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]domain.Book, error) {
+	if mock.ListBooksBySeriesIDFunc == nil {
+		panic("RepositoryMock.ListBooksBySeriesIDFunc: method is nil but Repository.ListBooksBySeriesID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		SeriesID int64
+	}{
+		Ctx:      ctx,
+		SeriesID: seriesID,
+	}
+	lockRepositoryMockListBooksBySeriesID.Lock()
+	mock.calls.ListBooksBySeriesID = append(mock.calls.ListBooksBySeriesID, callInfo)
+	lockRepositoryMockListBooksBySeriesID.Unlock()
+	return mock.ListBooksBySeriesIDFunc(ctx, seriesID)
+}
+
+// ListBooksBySeriesIDCalls gets all the calls that were made to ListBooksBySeriesID.
+// Check the length with:
+//     len(mockedRepository.ListBooksBySeriesIDCalls())
+func (mock *RepositoryMock) ListBooksBySeriesIDCalls() []struct {
+	Ctx      context.Context
+	SeriesID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		SeriesID int64
+	}
+	lockRepositoryMockListBooksBySeriesID.RLock()
+	calls = mock.calls.ListBooksBySeriesID
+	lockRepositoryMockListBooksBySeriesID.RUnlock()
+	return calls
+}
+
+// ListBooksByTag calls ListBooksByTagFunc.
+func (mock *RepositoryMock) ListBooksByTag(ctx context.Context, tag string) ([]domain.Book, error) {
+	if mock.ListBooksByTagFunc == nil {
+		panic("RepositoryMock.ListBooksByTagFunc: method is nil but Repository.ListBooksByTag was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Tag string
+	}{
+		Ctx: ctx,
+		Tag: tag,
+	}
+	lockRepositoryMockListBooksByTag.Lock()
+	mock.calls.ListBooksByTag = append(mock.calls.ListBooksByTag, callInfo)
+	lockRepositoryMockListBooksByTag.Unlock()
+	return mock.ListBooksByTagFunc(ctx, tag)
+}
+
+// ListBooksByTagCalls gets all the calls that were made to ListBooksByTag.
+// Check the length with:
+//     len(mockedRepository.ListBooksByTagCalls())
+func (mock *RepositoryMock) ListBooksByTagCalls() []struct {
+	Ctx context.Context
+	Tag string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Tag string
+	}
+	lockRepositoryMockListBooksByTag.RLock()
+	calls = mock.calls.ListBooksByTag
+	lockRepositoryMockListBooksByTag.RUnlock()
+	return calls
+}
+
+// ListContributorsByBookID calls ListContributorsByBookIDFunc.
+func (mock *RepositoryMock) ListContributorsByBookID(ctx context.Context, bookID int64) ([]domain.Contributor, error) {
+	if mock.ListContributorsByBookIDFunc == nil {
+		panic("RepositoryMock.ListContributorsByBookIDFunc: method is nil but Repository.ListContributorsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockRepositoryMockListContributorsByBookID.Lock()
+	mock.calls.ListContributorsByBookID = append(mock.calls.ListContributorsByBookID, callInfo)
+	lockRepositoryMockListContributorsByBookID.Unlock()
+	return mock.ListContributorsByBookIDFunc(ctx, bookID)
+}
+
+// ListContributorsByBookIDCalls gets all the calls that were made to ListContributorsByBookID.
+// Check the length with:
+//     len(mockedRepository.ListContributorsByBookIDCalls())
+func (mock *RepositoryMock) ListContributorsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockRepositoryMockListContributorsByBookID.RLock()
+	calls = mock.calls.ListContributorsByBookID
+	lockRepositoryMockListContributorsByBookID.RUnlock()
+	return calls
+}
+
+// ListDealsByAgentID calls ListDealsByAgentIDFunc.
+func (mock *RepositoryMock) ListDealsByAgentID(ctx context.Context, agentID int64) ([]domain.Deal, error) {
+	if mock.ListDealsByAgentIDFunc == nil {
+		panic("RepositoryMock.ListDealsByAgentIDFunc: method is nil but Repository.ListDealsByAgentID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AgentID int64
+	}{
+		Ctx:     ctx,
+		AgentID: agentID,
+	}
+	lockRepositoryMockListDealsByAgentID.Lock()
+	mock.calls.ListDealsByAgentID = append(mock.calls.ListDealsByAgentID, callInfo)
+	lockRepositoryMockListDealsByAgentID.Unlock()
+	return mock.ListDealsByAgentIDFunc(ctx, agentID)
+}
+
+// ListDealsByAgentIDCalls gets all the calls that were made to ListDealsByAgentID.
+// Check the length with:
+//     len(mockedRepository.ListDealsByAgentIDCalls())
+func (mock *RepositoryMock) ListDealsByAgentIDCalls() []struct {
+	Ctx     context.Context
+	AgentID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AgentID int64
+	}
+	lockRepositoryMockListDealsByAgentID.RLock()
+	calls = mock.calls.ListDealsByAgentID
+	lockRepositoryMockListDealsByAgentID.RUnlock()
+	return calls
+}
+
+// ListDealsByBookID calls ListDealsByBookIDFunc.
+func (mock *RepositoryMock) ListDealsByBookID(ctx context.Context, bookID int64) ([]domain.Deal, error) {
+	if mock.ListDealsByBookIDFunc == nil {
+		panic("RepositoryMock.ListDealsByBookIDFunc: method is nil but Repository.ListDealsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockRepositoryMockListDealsByBookID.Lock()
+	mock.calls.ListDealsByBookID = append(mock.calls.ListDealsByBookID, callInfo)
+	lockRepositoryMockListDealsByBookID.Unlock()
+	return mock.ListDealsByBookIDFunc(ctx, bookID)
+}
+
+// ListDealsByBookIDCalls gets all the calls that were made to ListDealsByBookID.
+// Check the length with:
+//     len(mockedRepository.ListDealsByBookIDCalls())
+func (mock *RepositoryMock) ListDealsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockRepositoryMockListDealsByBookID.RLock()
+	calls = mock.calls.ListDealsByBookID
+	lockRepositoryMockListDealsByBookID.RUnlock()
+	return calls
+}
+
+// ListEditions calls ListEditionsFunc.
+func (mock *RepositoryMock) ListEditions(ctx context.Context, args domain.ListEditionsParams) ([]domain.Edition, error) {
+	if mock.ListEditionsFunc == nil {
+		panic("RepositoryMock.ListEditionsFunc: method is nil but Repository.ListEditions was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.ListEditionsParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockListEditions.Lock()
+	mock.calls.ListEditions = append(mock.calls.ListEditions, callInfo)
+	lockRepositoryMockListEditions.Unlock()
+	return mock.ListEditionsFunc(ctx, args)
+}
+
+// ListEditionsCalls gets all the calls that were made to ListEditions.
+// Check the length with:
+//     len(mockedRepository.ListEditionsCalls())
+func (mock *RepositoryMock) ListEditionsCalls() []struct {
+	Ctx  context.Context
+	Args domain.ListEditionsParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.ListEditionsParams
+	}
+	lockRepositoryMockListEditions.RLock()
+	calls = mock.calls.ListEditions
+	lockRepositoryMockListEditions.RUnlock()
+	return calls
+}
+
+// ListEditionsByBookID calls ListEditionsByBookIDFunc.
+func (mock *RepositoryMock) ListEditionsByBookID(ctx context.Context, bookID int64) ([]domain.Edition, error) {
+	if mock.ListEditionsByBookIDFunc == nil {
+		panic("RepositoryMock.ListEditionsByBookIDFunc: method is nil but Repository.ListEditionsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockRepositoryMockListEditionsByBookID.Lock()
+	mock.calls.ListEditionsByBookID = append(mock.calls.ListEditionsByBookID, callInfo)
+	lockRepositoryMockListEditionsByBookID.Unlock()
+	return mock.ListEditionsByBookIDFunc(ctx, bookID)
+}
+
+// ListEditionsByBookIDCalls gets all the calls that were made to ListEditionsByBookID.
+// Check the length with:
+//     len(mockedRepository.ListEditionsByBookIDCalls())
+func (mock *RepositoryMock) ListEditionsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockRepositoryMockListEditionsByBookID.RLock()
+	calls = mock.calls.ListEditionsByBookID
+	lockRepositoryMockListEditionsByBookID.RUnlock()
+	return calls
+}
+
+// ListFormerAuthorsByAgentID calls ListFormerAuthorsByAgentIDFunc.
+func (mock *RepositoryMock) ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]domain.Author, error) {
+	if mock.ListFormerAuthorsByAgentIDFunc == nil {
+		panic("RepositoryMock.ListFormerAuthorsByAgentIDFunc: method is nil but Repository.ListFormerAuthorsByAgentID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AgentID int64
+	}{
+		Ctx:     ctx,
+		AgentID: agentID,
+	}
+	lockRepositoryMockListFormerAuthorsByAgentID.Lock()
+	mock.calls.ListFormerAuthorsByAgentID = append(mock.calls.ListFormerAuthorsByAgentID, callInfo)
+	lockRepositoryMockListFormerAuthorsByAgentID.Unlock()
+	return mock.ListFormerAuthorsByAgentIDFunc(ctx, agentID)
+}
+
+// ListFormerAuthorsByAgentIDCalls gets all the calls that were made to ListFormerAuthorsByAgentID.
+// Check the length with:
+//     len(mockedRepository.ListFormerAuthorsByAgentIDCalls())
+func (mock *RepositoryMock) ListFormerAuthorsByAgentIDCalls() []struct {
+	Ctx     context.Context
+	AgentID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AgentID int64
+	}
+	lockRepositoryMockListFormerAuthorsByAgentID.RLock()
+	calls = mock.calls.ListFormerAuthorsByAgentID
+	lockRepositoryMockListFormerAuthorsByAgentID.RUnlock()
+	return calls
+}
+
+// ListGenres calls ListGenresFunc.
+func (mock *RepositoryMock) ListGenres(ctx context.Context) ([]domain.Genre, error) {
+	if mock.ListGenresFunc == nil {
+		panic("RepositoryMock.ListGenresFunc: method is nil but Repository.ListGenres was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListGenres.Lock()
+	mock.calls.ListGenres = append(mock.calls.ListGenres, callInfo)
+	lockRepositoryMockListGenres.Unlock()
+	return mock.ListGenresFunc(ctx)
+}
+
+// ListGenresCalls gets all the calls that were made to ListGenres.
+// Check the length with:
+//     len(mockedRepository.ListGenresCalls())
+func (mock *RepositoryMock) ListGenresCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListGenres.RLock()
+	calls = mock.calls.ListGenres
+	lockRepositoryMockListGenres.RUnlock()
+	return calls
+}
+
+// ListGenresByBookID calls ListGenresByBookIDFunc.
+func (mock *RepositoryMock) ListGenresByBookID(ctx context.Context, bookID int64) ([]domain.Genre, error) {
+	if mock.ListGenresByBookIDFunc == nil {
+		panic("RepositoryMock.ListGenresByBookIDFunc: method is nil but Repository.ListGenresByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockRepositoryMockListGenresByBookID.Lock()
+	mock.calls.ListGenresByBookID = append(mock.calls.ListGenresByBookID, callInfo)
+	lockRepositoryMockListGenresByBookID.Unlock()
+	return mock.ListGenresByBookIDFunc(ctx, bookID)
+}
+
+// ListGenresByBookIDCalls gets all the calls that were made to ListGenresByBookID.
+// Check the length with:
+//     len(mockedRepository.ListGenresByBookIDCalls())
+func (mock *RepositoryMock) ListGenresByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockRepositoryMockListGenresByBookID.RLock()
+	calls = mock.calls.ListGenresByBookID
+	lockRepositoryMockListGenresByBookID.RUnlock()
+	return calls
+}
+
+// ListGenresByParentID calls ListGenresByParentIDFunc.
+func (mock *RepositoryMock) ListGenresByParentID(ctx context.Context, parentID int64) ([]domain.Genre, error) {
+	if mock.ListGenresByParentIDFunc == nil {
+		panic("RepositoryMock.ListGenresByParentIDFunc: method is nil but Repository.ListGenresByParentID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ParentID int64
+	}{
+		Ctx:      ctx,
+		ParentID: parentID,
+	}
+	lockRepositoryMockListGenresByParentID.Lock()
+	mock.calls.ListGenresByParentID = append(mock.calls.ListGenresByParentID, callInfo)
+	lockRepositoryMockListGenresByParentID.Unlock()
+	return mock.ListGenresByParentIDFunc(ctx, parentID)
+}
+
+// ListGenresByParentIDCalls gets all the calls that were made to ListGenresByParentID.
+// Check the length with:
+//     len(mockedRepository.ListGenresByParentIDCalls())
+func (mock *RepositoryMock) ListGenresByParentIDCalls() []struct {
+	Ctx      context.Context
+	ParentID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ParentID int64
+	}
+	lockRepositoryMockListGenresByParentID.RLock()
+	calls = mock.calls.ListGenresByParentID
+	lockRepositoryMockListGenresByParentID.RUnlock()
+	return calls
+}
+
+// ListOrphanBooks calls ListOrphanBooksFunc.
+func (mock *RepositoryMock) ListOrphanBooks(ctx context.Context) ([]domain.Book, error) {
+	if mock.ListOrphanBooksFunc == nil {
+		panic("RepositoryMock.ListOrphanBooksFunc: method is nil but Repository.ListOrphanBooks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListOrphanBooks.Lock()
+	mock.calls.ListOrphanBooks = append(mock.calls.ListOrphanBooks, callInfo)
+	lockRepositoryMockListOrphanBooks.Unlock()
+	return mock.ListOrphanBooksFunc(ctx)
+}
+
+// ListOrphanBooksCalls gets all the calls that were made to ListOrphanBooks.
+// Check the length with:
+//     len(mockedRepository.ListOrphanBooksCalls())
+func (mock *RepositoryMock) ListOrphanBooksCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListOrphanBooks.RLock()
+	calls = mock.calls.ListOrphanBooks
+	lockRepositoryMockListOrphanBooks.RUnlock()
+	return calls
+}
+
+// ListPublishers calls ListPublishersFunc.
+func (mock *RepositoryMock) ListPublishers(ctx context.Context) ([]domain.Publisher, error) {
+	if mock.ListPublishersFunc == nil {
+		panic("RepositoryMock.ListPublishersFunc: method is nil but Repository.ListPublishers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListPublishers.Lock()
+	mock.calls.ListPublishers = append(mock.calls.ListPublishers, callInfo)
+	lockRepositoryMockListPublishers.Unlock()
+	return mock.ListPublishersFunc(ctx)
+}
+
+// ListPublishersCalls gets all the calls that were made to ListPublishers.
+// Check the length with:
+//     len(mockedRepository.ListPublishersCalls())
+func (mock *RepositoryMock) ListPublishersCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListPublishers.RLock()
+	calls = mock.calls.ListPublishers
+	lockRepositoryMockListPublishers.RUnlock()
+	return calls
+}
+
+// ListRepresentationsByAuthorID calls ListRepresentationsByAuthorIDFunc.
+func (mock *RepositoryMock) ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]domain.Representation, error) {
+	if mock.ListRepresentationsByAuthorIDFunc == nil {
+		panic("RepositoryMock.ListRepresentationsByAuthorIDFunc: method is nil but Repository.ListRepresentationsByAuthorID was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		AuthorID int64
+	}{
+		Ctx:      ctx,
+		AuthorID: authorID,
+	}
+	lockRepositoryMockListRepresentationsByAuthorID.Lock()
+	mock.calls.ListRepresentationsByAuthorID = append(mock.calls.ListRepresentationsByAuthorID, callInfo)
+	lockRepositoryMockListRepresentationsByAuthorID.Unlock()
+	return mock.ListRepresentationsByAuthorIDFunc(ctx, authorID)
+}
+
+// ListRepresentationsByAuthorIDCalls gets all the calls that were made to ListRepresentationsByAuthorID.
+// Check the length with:
+//     len(mockedRepository.ListRepresentationsByAuthorIDCalls())
+func (mock *RepositoryMock) ListRepresentationsByAuthorIDCalls() []struct {
+	Ctx      context.Context
+	AuthorID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		AuthorID int64
+	}
+	lockRepositoryMockListRepresentationsByAuthorID.RLock()
+	calls = mock.calls.ListRepresentationsByAuthorID
+	lockRepositoryMockListRepresentationsByAuthorID.RUnlock()
+	return calls
+}
+
+// ListRoyaltyRatesByDealID calls ListRoyaltyRatesByDealIDFunc.
+func (mock *RepositoryMock) ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]domain.RoyaltyRate, error) {
+	if mock.ListRoyaltyRatesByDealIDFunc == nil {
+		panic("RepositoryMock.ListRoyaltyRatesByDealIDFunc: method is nil but Repository.ListRoyaltyRatesByDealID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		DealID int64
+	}{
+		Ctx:    ctx,
+		DealID: dealID,
+	}
+	lockRepositoryMockListRoyaltyRatesByDealID.Lock()
+	mock.calls.ListRoyaltyRatesByDealID = append(mock.calls.ListRoyaltyRatesByDealID, callInfo)
+	lockRepositoryMockListRoyaltyRatesByDealID.Unlock()
+	return mock.ListRoyaltyRatesByDealIDFunc(ctx, dealID)
+}
+
+// ListRoyaltyRatesByDealIDCalls gets all the calls that were made to ListRoyaltyRatesByDealID.
+// Check the length with:
+//     len(mockedRepository.ListRoyaltyRatesByDealIDCalls())
+func (mock *RepositoryMock) ListRoyaltyRatesByDealIDCalls() []struct {
+	Ctx    context.Context
+	DealID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		DealID int64
+	}
+	lockRepositoryMockListRoyaltyRatesByDealID.RLock()
+	calls = mock.calls.ListRoyaltyRatesByDealID
+	lockRepositoryMockListRoyaltyRatesByDealID.RUnlock()
+	return calls
+}
+
+// ListSalesByDealID calls ListSalesByDealIDFunc.
+func (mock *RepositoryMock) ListSalesByDealID(ctx context.Context, dealID int64) ([]domain.Sales, error) {
+	if mock.ListSalesByDealIDFunc == nil {
+		panic("RepositoryMock.ListSalesByDealIDFunc: method is nil but Repository.ListSalesByDealID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		DealID int64
+	}{
+		Ctx:    ctx,
+		DealID: dealID,
+	}
+	lockRepositoryMockListSalesByDealID.Lock()
+	mock.calls.ListSalesByDealID = append(mock.calls.ListSalesByDealID, callInfo)
+	lockRepositoryMockListSalesByDealID.Unlock()
+	return mock.ListSalesByDealIDFunc(ctx, dealID)
+}
+
+// ListSalesByDealIDCalls gets all the calls that were made to ListSalesByDealID.
+// Check the length with:
+//     len(mockedRepository.ListSalesByDealIDCalls())
+func (mock *RepositoryMock) ListSalesByDealIDCalls() []struct {
+	Ctx    context.Context
+	DealID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		DealID int64
+	}
+	lockRepositoryMockListSalesByDealID.RLock()
+	calls = mock.calls.ListSalesByDealID
+	lockRepositoryMockListSalesByDealID.RUnlock()
+	return calls
+}
+
+// ListSeries calls ListSeriesFunc.
+func (mock *RepositoryMock) ListSeries(ctx context.Context) ([]domain.Series, error) {
+	if mock.ListSeriesFunc == nil {
+		panic("RepositoryMock.ListSeriesFunc: method is nil but Repository.ListSeries was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListSeries.Lock()
+	mock.calls.ListSeries = append(mock.calls.ListSeries, callInfo)
+	lockRepositoryMockListSeries.Unlock()
+	return mock.ListSeriesFunc(ctx)
+}
+
+// ListSeriesCalls gets all the calls that were made to ListSeries.
+// Check the length with:
+//     len(mockedRepository.ListSeriesCalls())
+func (mock *RepositoryMock) ListSeriesCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListSeries.RLock()
+	calls = mock.calls.ListSeries
+	lockRepositoryMockListSeries.RUnlock()
+	return calls
+}
+
+// ListSubmissionTransitions calls ListSubmissionTransitionsFunc.
+func (mock *RepositoryMock) ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]domain.SubmissionTransition, error) {
+	if mock.ListSubmissionTransitionsFunc == nil {
+		panic("RepositoryMock.ListSubmissionTransitionsFunc: method is nil but Repository.ListSubmissionTransitions was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		SubmissionID int64
+	}{
+		Ctx:          ctx,
+		SubmissionID: submissionID,
+	}
+	lockRepositoryMockListSubmissionTransitions.Lock()
+	mock.calls.ListSubmissionTransitions = append(mock.calls.ListSubmissionTransitions, callInfo)
+	lockRepositoryMockListSubmissionTransitions.Unlock()
+	return mock.ListSubmissionTransitionsFunc(ctx, submissionID)
+}
+
+// ListSubmissionTransitionsCalls gets all the calls that were made to ListSubmissionTransitions.
+// Check the length with:
+//     len(mockedRepository.ListSubmissionTransitionsCalls())
+func (mock *RepositoryMock) ListSubmissionTransitionsCalls() []struct {
+	Ctx          context.Context
+	SubmissionID int64
+} {
+	var calls []struct {
+		Ctx          context.Context
+		SubmissionID int64
+	}
+	lockRepositoryMockListSubmissionTransitions.RLock()
+	calls = mock.calls.ListSubmissionTransitions
+	lockRepositoryMockListSubmissionTransitions.RUnlock()
+	return calls
+}
+
+// ListSubmissionsByAgentID calls ListSubmissionsByAgentIDFunc.
+func (mock *RepositoryMock) ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]domain.Submission, error) {
+	if mock.ListSubmissionsByAgentIDFunc == nil {
+		panic("RepositoryMock.ListSubmissionsByAgentIDFunc: method is nil but Repository.ListSubmissionsByAgentID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		AgentID int64
+	}{
+		Ctx:     ctx,
+		AgentID: agentID,
+	}
+	lockRepositoryMockListSubmissionsByAgentID.Lock()
+	mock.calls.ListSubmissionsByAgentID = append(mock.calls.ListSubmissionsByAgentID, callInfo)
+	lockRepositoryMockListSubmissionsByAgentID.Unlock()
+	return mock.ListSubmissionsByAgentIDFunc(ctx, agentID)
+}
+
+// ListSubmissionsByAgentIDCalls gets all the calls that were made to ListSubmissionsByAgentID.
+// Check the length with:
+//     len(mockedRepository.ListSubmissionsByAgentIDCalls())
+func (mock *RepositoryMock) ListSubmissionsByAgentIDCalls() []struct {
+	Ctx     context.Context
+	AgentID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		AgentID int64
+	}
+	lockRepositoryMockListSubmissionsByAgentID.RLock()
+	calls = mock.calls.ListSubmissionsByAgentID
+	lockRepositoryMockListSubmissionsByAgentID.RUnlock()
+	return calls
+}
+
+// ListSubmissionsByAgentIDAndStatus calls ListSubmissionsByAgentIDAndStatusFunc.
+func (mock *RepositoryMock) ListSubmissionsByAgentIDAndStatus(ctx context.Context, args domain.ListSubmissionsByAgentIDAndStatusParams) ([]domain.Submission, error) {
+	if mock.ListSubmissionsByAgentIDAndStatusFunc == nil {
+		panic("RepositoryMock.ListSubmissionsByAgentIDAndStatusFunc: method is nil but Repository.ListSubmissionsByAgentIDAndStatus was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.ListSubmissionsByAgentIDAndStatusParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockListSubmissionsByAgentIDAndStatus.Lock()
+	mock.calls.ListSubmissionsByAgentIDAndStatus = append(mock.calls.ListSubmissionsByAgentIDAndStatus, callInfo)
+	lockRepositoryMockListSubmissionsByAgentIDAndStatus.Unlock()
+	return mock.ListSubmissionsByAgentIDAndStatusFunc(ctx, args)
+}
+
+// ListSubmissionsByAgentIDAndStatusCalls gets all the calls that were made to ListSubmissionsByAgentIDAndStatus.
+// Check the length with:
+//     len(mockedRepository.ListSubmissionsByAgentIDAndStatusCalls())
+func (mock *RepositoryMock) ListSubmissionsByAgentIDAndStatusCalls() []struct {
+	Ctx  context.Context
+	Args domain.ListSubmissionsByAgentIDAndStatusParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.ListSubmissionsByAgentIDAndStatusParams
+	}
+	lockRepositoryMockListSubmissionsByAgentIDAndStatus.RLock()
+	calls = mock.calls.ListSubmissionsByAgentIDAndStatus
+	lockRepositoryMockListSubmissionsByAgentIDAndStatus.RUnlock()
+	return calls
+}
+
+// ListTagsByBookID calls ListTagsByBookIDFunc.
+func (mock *RepositoryMock) ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error) {
+	if mock.ListTagsByBookIDFunc == nil {
+		panic("RepositoryMock.ListTagsByBookIDFunc: method is nil but Repository.ListTagsByBookID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		BookID int64
+	}{
+		Ctx:    ctx,
+		BookID: bookID,
+	}
+	lockRepositoryMockListTagsByBookID.Lock()
+	mock.calls.ListTagsByBookID = append(mock.calls.ListTagsByBookID, callInfo)
+	lockRepositoryMockListTagsByBookID.Unlock()
+	return mock.ListTagsByBookIDFunc(ctx, bookID)
+}
+
+// ListTagsByBookIDCalls gets all the calls that were made to ListTagsByBookID.
+// Check the length with:
+//     len(mockedRepository.ListTagsByBookIDCalls())
+func (mock *RepositoryMock) ListTagsByBookIDCalls() []struct {
+	Ctx    context.Context
+	BookID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		BookID int64
+	}
+	lockRepositoryMockListTagsByBookID.RLock()
+	calls = mock.calls.ListTagsByBookID
+	lockRepositoryMockListTagsByBookID.RUnlock()
+	return calls
+}
+
+// ListWebhookDeliveries calls ListWebhookDeliveriesFunc.
+func (mock *RepositoryMock) ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]domain.WebhookDelivery, error) {
+	if mock.ListWebhookDeliveriesFunc == nil {
+		panic("RepositoryMock.ListWebhookDeliveriesFunc: method is nil but Repository.ListWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		WebhookID int64
+	}{
+		Ctx:       ctx,
+		WebhookID: webhookID,
+	}
+	lockRepositoryMockListWebhookDeliveries.Lock()
+	mock.calls.ListWebhookDeliveries = append(mock.calls.ListWebhookDeliveries, callInfo)
+	lockRepositoryMockListWebhookDeliveries.Unlock()
+	return mock.ListWebhookDeliveriesFunc(ctx, webhookID)
+}
+
+// ListWebhookDeliveriesCalls gets all the calls that were made to ListWebhookDeliveries.
+// Check the length with:
+//     len(mockedRepository.ListWebhookDeliveriesCalls())
+func (mock *RepositoryMock) ListWebhookDeliveriesCalls() []struct {
+	Ctx       context.Context
+	WebhookID int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		WebhookID int64
+	}
+	lockRepositoryMockListWebhookDeliveries.RLock()
+	calls = mock.calls.ListWebhookDeliveries
+	lockRepositoryMockListWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// ListWebhookDeliveriesByStatus calls ListWebhookDeliveriesByStatusFunc.
+func (mock *RepositoryMock) ListWebhookDeliveriesByStatus(ctx context.Context, args domain.ListWebhookDeliveriesByStatusParams) ([]domain.WebhookDelivery, error) {
+	if mock.ListWebhookDeliveriesByStatusFunc == nil {
+		panic("RepositoryMock.ListWebhookDeliveriesByStatusFunc: method is nil but Repository.ListWebhookDeliveriesByStatus was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.ListWebhookDeliveriesByStatusParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockListWebhookDeliveriesByStatus.Lock()
+	mock.calls.ListWebhookDeliveriesByStatus = append(mock.calls.ListWebhookDeliveriesByStatus, callInfo)
+	lockRepositoryMockListWebhookDeliveriesByStatus.Unlock()
+	return mock.ListWebhookDeliveriesByStatusFunc(ctx, args)
+}
+
+// ListWebhookDeliveriesByStatusCalls gets all the calls that were made to ListWebhookDeliveriesByStatus.
+// Check the length with:
+//     len(mockedRepository.ListWebhookDeliveriesByStatusCalls())
+func (mock *RepositoryMock) ListWebhookDeliveriesByStatusCalls() []struct {
+	Ctx  context.Context
+	Args domain.ListWebhookDeliveriesByStatusParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.ListWebhookDeliveriesByStatusParams
+	}
+	lockRepositoryMockListWebhookDeliveriesByStatus.RLock()
+	calls = mock.calls.ListWebhookDeliveriesByStatus
+	lockRepositoryMockListWebhookDeliveriesByStatus.RUnlock()
+	return calls
+}
+
+// ListWebhooks calls ListWebhooksFunc.
+func (mock *RepositoryMock) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	if mock.ListWebhooksFunc == nil {
+		panic("RepositoryMock.ListWebhooksFunc: method is nil but Repository.ListWebhooks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	lockRepositoryMockListWebhooks.Lock()
+	mock.calls.ListWebhooks = append(mock.calls.ListWebhooks, callInfo)
+	lockRepositoryMockListWebhooks.Unlock()
+	return mock.ListWebhooksFunc(ctx)
+}
+
+// ListWebhooksCalls gets all the calls that were made to ListWebhooks.
+// Check the length with:
+//     len(mockedRepository.ListWebhooksCalls())
+func (mock *RepositoryMock) ListWebhooksCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	lockRepositoryMockListWebhooks.RLock()
+	calls = mock.calls.ListWebhooks
+	lockRepositoryMockListWebhooks.RUnlock()
+	return calls
+}
+
+// RecordSales calls RecordSalesFunc.
+func (mock *RepositoryMock) RecordSales(ctx context.Context, args domain.RecordSalesParams) (domain.Sales, error) {
+	if mock.RecordSalesFunc == nil {
+		panic("RepositoryMock.RecordSalesFunc: method is nil but Repository.RecordSales was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.RecordSalesParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockRecordSales.Lock()
+	mock.calls.RecordSales = append(mock.calls.RecordSales, callInfo)
+	lockRepositoryMockRecordSales.Unlock()
+	return mock.RecordSalesFunc(ctx, args)
+}
+
+// RecordSalesCalls gets all the calls that were made to RecordSales.
+// Check the length with:
+//     len(mockedRepository.RecordSalesCalls())
+func (mock *RepositoryMock) RecordSalesCalls() []struct {
+	Ctx  context.Context
+	Args domain.RecordSalesParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.RecordSalesParams
+	}
+	lockRepositoryMockRecordSales.RLock()
+	calls = mock.calls.RecordSales
+	lockRepositoryMockRecordSales.RUnlock()
+	return calls
+}
+
+// ReorderSeries calls ReorderSeriesFunc.
+func (mock *RepositoryMock) ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+	if mock.ReorderSeriesFunc == nil {
+		panic("RepositoryMock.ReorderSeriesFunc: method is nil but Repository.ReorderSeries was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		BookIDs []int64
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		BookIDs: bookIDs,
+	}
+	lockRepositoryMockReorderSeries.Lock()
+	mock.calls.ReorderSeries = append(mock.calls.ReorderSeries, callInfo)
+	lockRepositoryMockReorderSeries.Unlock()
+	return mock.ReorderSeriesFunc(ctx, id, bookIDs)
+}
+
+// ReorderSeriesCalls gets all the calls that were made to ReorderSeries.
+// Check the length with:
+//     len(mockedRepository.ReorderSeriesCalls())
+func (mock *RepositoryMock) ReorderSeriesCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	BookIDs []int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		BookIDs []int64
+	}
+	lockRepositoryMockReorderSeries.RLock()
+	calls = mock.calls.ReorderSeries
+	lockRepositoryMockReorderSeries.RUnlock()
+	return calls
+}
+
+// RetryWebhookDelivery calls RetryWebhookDeliveryFunc.
+func (mock *RepositoryMock) RetryWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	if mock.RetryWebhookDeliveryFunc == nil {
+		panic("RepositoryMock.RetryWebhookDeliveryFunc: method is nil but Repository.RetryWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockRepositoryMockRetryWebhookDelivery.Lock()
+	mock.calls.RetryWebhookDelivery = append(mock.calls.RetryWebhookDelivery, callInfo)
+	lockRepositoryMockRetryWebhookDelivery.Unlock()
+	return mock.RetryWebhookDeliveryFunc(ctx, id)
+}
+
+// RetryWebhookDeliveryCalls gets all the calls that were made to RetryWebhookDelivery.
+// Check the length with:
+//     len(mockedRepository.RetryWebhookDeliveryCalls())
+func (mock *RepositoryMock) RetryWebhookDeliveryCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockRepositoryMockRetryWebhookDelivery.RLock()
+	calls = mock.calls.RetryWebhookDelivery
+	lockRepositoryMockRetryWebhookDelivery.RUnlock()
+	return calls
+}
+
+// SetRoyaltyRate calls SetRoyaltyRateFunc.
+func (mock *RepositoryMock) SetRoyaltyRate(ctx context.Context, dealID int64, editionID int64, rate int) (domain.RoyaltyRate, error) {
+	if mock.SetRoyaltyRateFunc == nil {
+		panic("RepositoryMock.SetRoyaltyRateFunc: method is nil but Repository.SetRoyaltyRate was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		DealID    int64
+		EditionID int64
+		Rate      int
+	}{
+		Ctx:       ctx,
+		DealID:    dealID,
+		EditionID: editionID,
+		Rate:      rate,
+	}
+	lockRepositoryMockSetRoyaltyRate.Lock()
+	mock.calls.SetRoyaltyRate = append(mock.calls.SetRoyaltyRate, callInfo)
+	lockRepositoryMockSetRoyaltyRate.Unlock()
+	return mock.SetRoyaltyRateFunc(ctx, dealID, editionID, rate)
+}
+
+// SetRoyaltyRateCalls gets all the calls that were made to SetRoyaltyRate.
+// Check the length with:
+//     len(mockedRepository.SetRoyaltyRateCalls())
+func (mock *RepositoryMock) SetRoyaltyRateCalls() []struct {
+	Ctx       context.Context
+	DealID    int64
+	EditionID int64
+	Rate      int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		DealID    int64
+		EditionID int64
+		Rate      int
+	}
+	lockRepositoryMockSetRoyaltyRate.RLock()
+	calls = mock.calls.SetRoyaltyRate
+	lockRepositoryMockSetRoyaltyRate.RUnlock()
+	return calls
+}
+
+// TransitionSubmission calls TransitionSubmissionFunc.
+func (mock *RepositoryMock) TransitionSubmission(ctx context.Context, id int64, status domain.SubmissionStatus, note *string) (*domain.Submission, error) {
+	if mock.TransitionSubmissionFunc == nil {
+		panic("RepositoryMock.TransitionSubmissionFunc: method is nil but Repository.TransitionSubmission was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     int64
+		Status domain.SubmissionStatus
+		Note   *string
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Status: status,
+		Note:   note,
+	}
+	lockRepositoryMockTransitionSubmission.Lock()
+	mock.calls.TransitionSubmission = append(mock.calls.TransitionSubmission, callInfo)
+	lockRepositoryMockTransitionSubmission.Unlock()
+	return mock.TransitionSubmissionFunc(ctx, id, status, note)
+}
+
+// TransitionSubmissionCalls gets all the calls that were made to TransitionSubmission.
+// Check the length with:
+//     len(mockedRepository.TransitionSubmissionCalls())
+func (mock *RepositoryMock) TransitionSubmissionCalls() []struct {
+	Ctx    context.Context
+	ID     int64
+	Status domain.SubmissionStatus
+	Note   *string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     int64
+		Status domain.SubmissionStatus
+		Note   *string
+	}
+	lockRepositoryMockTransitionSubmission.RLock()
+	calls = mock.calls.TransitionSubmission
+	lockRepositoryMockTransitionSubmission.RUnlock()
+	return calls
+}
+
+// UpdateAgency calls UpdateAgencyFunc.
+func (mock *RepositoryMock) UpdateAgency(ctx context.Context, args domain.UpdateAgencyParams) (domain.Agency, error) {
+	if mock.UpdateAgencyFunc == nil {
+		panic("RepositoryMock.UpdateAgencyFunc: method is nil but Repository.UpdateAgency was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdateAgencyParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdateAgency.Lock()
+	mock.calls.UpdateAgency = append(mock.calls.UpdateAgency, callInfo)
+	lockRepositoryMockUpdateAgency.Unlock()
+	return mock.UpdateAgencyFunc(ctx, args)
+}
+
+// UpdateAgencyCalls gets all the calls that were made to UpdateAgency.
+// Check the length with:
+//     len(mockedRepository.UpdateAgencyCalls())
+func (mock *RepositoryMock) UpdateAgencyCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdateAgencyParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdateAgencyParams
+	}
+	lockRepositoryMockUpdateAgency.RLock()
+	calls = mock.calls.UpdateAgency
+	lockRepositoryMockUpdateAgency.RUnlock()
+	return calls
+}
+
+// UpdateAgent calls UpdateAgentFunc.
+func (mock *RepositoryMock) UpdateAgent(ctx context.Context, args domain.UpdateAgentParams) (domain.Agent, error) {
+	if mock.UpdateAgentFunc == nil {
+		panic("RepositoryMock.UpdateAgentFunc: method is nil but Repository.UpdateAgent was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdateAgentParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdateAgent.Lock()
+	mock.calls.UpdateAgent = append(mock.calls.UpdateAgent, callInfo)
+	lockRepositoryMockUpdateAgent.Unlock()
+	return mock.UpdateAgentFunc(ctx, args)
+}
+
+// UpdateAgentCalls gets all the calls that were made to UpdateAgent.
+// Check the length with:
+//     len(mockedRepository.UpdateAgentCalls())
+func (mock *RepositoryMock) UpdateAgentCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdateAgentParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdateAgentParams
+	}
+	lockRepositoryMockUpdateAgent.RLock()
+	calls = mock.calls.UpdateAgent
+	lockRepositoryMockUpdateAgent.RUnlock()
+	return calls
+}
+
+// UpdateAuthor calls UpdateAuthorFunc.
+func (mock *RepositoryMock) UpdateAuthor(ctx context.Context, args domain.UpdateAuthorParams) (*domain.Author, error) {
+	if mock.UpdateAuthorFunc == nil {
+		panic("RepositoryMock.UpdateAuthorFunc: method is nil but Repository.UpdateAuthor was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdateAuthorParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdateAuthor.Lock()
+	mock.calls.UpdateAuthor = append(mock.calls.UpdateAuthor, callInfo)
+	lockRepositoryMockUpdateAuthor.Unlock()
+	return mock.UpdateAuthorFunc(ctx, args)
+}
+
+// UpdateAuthorCalls gets all the calls that were made to UpdateAuthor.
+// Check the length with:
+//     len(mockedRepository.UpdateAuthorCalls())
+func (mock *RepositoryMock) UpdateAuthorCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdateAuthorParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdateAuthorParams
+	}
+	lockRepositoryMockUpdateAuthor.RLock()
+	calls = mock.calls.UpdateAuthor
+	lockRepositoryMockUpdateAuthor.RUnlock()
+	return calls
+}
+
+// UpdateBook calls UpdateBookFunc.
+func (mock *RepositoryMock) UpdateBook(ctx context.Context, args domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+	if mock.UpdateBookFunc == nil {
+		panic("RepositoryMock.UpdateBookFunc: method is nil but Repository.UpdateBook was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Args         domain.UpdateBookParams
+		Contributors []domain.Contributor
+		GenreIDs     []int64
+		Tags         []string
+	}{
+		Ctx:          ctx,
+		Args:         args,
+		Contributors: contributors,
+		GenreIDs:     genreIDs,
+		Tags:         tags,
+	}
+	lockRepositoryMockUpdateBook.Lock()
+	mock.calls.UpdateBook = append(mock.calls.UpdateBook, callInfo)
+	lockRepositoryMockUpdateBook.Unlock()
+	return mock.UpdateBookFunc(ctx, args, contributors, genreIDs, tags)
+}
+
+// UpdateBookCalls gets all the calls that were made to UpdateBook.
+// Check the length with:
+//     len(mockedRepository.UpdateBookCalls())
+func (mock *RepositoryMock) UpdateBookCalls() []struct {
+	Ctx          context.Context
+	Args         domain.UpdateBookParams
+	Contributors []domain.Contributor
+	GenreIDs     []int64
+	Tags         []string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Args         domain.UpdateBookParams
+		Contributors []domain.Contributor
+		GenreIDs     []int64
+		Tags         []string
+	}
+	lockRepositoryMockUpdateBook.RLock()
+	calls = mock.calls.UpdateBook
+	lockRepositoryMockUpdateBook.RUnlock()
+	return calls
+}
+
+// UpdateBooks calls UpdateBooksFunc.
+func (mock *RepositoryMock) UpdateBooks(ctx context.Context, args []domain.BulkUpdateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+	if mock.UpdateBooksFunc == nil {
+		panic("RepositoryMock.UpdateBooksFunc: method is nil but Repository.UpdateBooks was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []domain.BulkUpdateBookArgs
+		Mode domain.BulkMode
+	}{
+		Ctx:  ctx,
+		Args: args,
+		Mode: mode,
+	}
+	lockRepositoryMockUpdateBooks.Lock()
+	mock.calls.UpdateBooks = append(mock.calls.UpdateBooks, callInfo)
+	lockRepositoryMockUpdateBooks.Unlock()
+	return mock.UpdateBooksFunc(ctx, args, mode)
+}
+
+// UpdateBooksCalls gets all the calls that were made to UpdateBooks.
+// Check the length with:
+//     len(mockedRepository.UpdateBooksCalls())
+func (mock *RepositoryMock) UpdateBooksCalls() []struct {
+	Ctx  context.Context
+	Args []domain.BulkUpdateBookArgs
+	Mode domain.BulkMode
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []domain.BulkUpdateBookArgs
+		Mode domain.BulkMode
+	}
+	lockRepositoryMockUpdateBooks.RLock()
+	calls = mock.calls.UpdateBooks
+	lockRepositoryMockUpdateBooks.RUnlock()
+	return calls
+}
+
+// UpdateDeal calls UpdateDealFunc.
+func (mock *RepositoryMock) UpdateDeal(ctx context.Context, args domain.UpdateDealParams) (domain.Deal, error) {
+	if mock.UpdateDealFunc == nil {
+		panic("RepositoryMock.UpdateDealFunc: method is nil but Repository.UpdateDeal was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdateDealParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdateDeal.Lock()
+	mock.calls.UpdateDeal = append(mock.calls.UpdateDeal, callInfo)
+	lockRepositoryMockUpdateDeal.Unlock()
+	return mock.UpdateDealFunc(ctx, args)
+}
+
+// UpdateDealCalls gets all the calls that were made to UpdateDeal.
+// Check the length with:
+//     len(mockedRepository.UpdateDealCalls())
+func (mock *RepositoryMock) UpdateDealCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdateDealParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdateDealParams
+	}
+	lockRepositoryMockUpdateDeal.RLock()
+	calls = mock.calls.UpdateDeal
+	lockRepositoryMockUpdateDeal.RUnlock()
+	return calls
+}
+
+// UpdateEdition calls UpdateEditionFunc.
+func (mock *RepositoryMock) UpdateEdition(ctx context.Context, args domain.UpdateEditionParams) (domain.Edition, error) {
+	if mock.UpdateEditionFunc == nil {
+		panic("RepositoryMock.UpdateEditionFunc: method is nil but Repository.UpdateEdition was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdateEditionParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdateEdition.Lock()
+	mock.calls.UpdateEdition = append(mock.calls.UpdateEdition, callInfo)
+	lockRepositoryMockUpdateEdition.Unlock()
+	return mock.UpdateEditionFunc(ctx, args)
+}
+
+// UpdateEditionCalls gets all the calls that were made to UpdateEdition.
+// Check the length with:
+//     len(mockedRepository.UpdateEditionCalls())
+func (mock *RepositoryMock) UpdateEditionCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdateEditionParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdateEditionParams
+	}
+	lockRepositoryMockUpdateEdition.RLock()
+	calls = mock.calls.UpdateEdition
+	lockRepositoryMockUpdateEdition.RUnlock()
+	return calls
+}
+
+// UpdateGenre calls UpdateGenreFunc.
+func (mock *RepositoryMock) UpdateGenre(ctx context.Context, args domain.UpdateGenreParams) (*domain.Genre, error) {
+	if mock.UpdateGenreFunc == nil {
+		panic("RepositoryMock.UpdateGenreFunc: method is nil but Repository.UpdateGenre was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdateGenreParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdateGenre.Lock()
+	mock.calls.UpdateGenre = append(mock.calls.UpdateGenre, callInfo)
+	lockRepositoryMockUpdateGenre.Unlock()
+	return mock.UpdateGenreFunc(ctx, args)
+}
+
+// UpdateGenreCalls gets all the calls that were made to UpdateGenre.
+// Check the length with:
+//     len(mockedRepository.UpdateGenreCalls())
+func (mock *RepositoryMock) UpdateGenreCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdateGenreParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdateGenreParams
+	}
+	lockRepositoryMockUpdateGenre.RLock()
+	calls = mock.calls.UpdateGenre
+	lockRepositoryMockUpdateGenre.RUnlock()
+	return calls
+}
+
+// UpdatePublisher calls UpdatePublisherFunc.
+func (mock *RepositoryMock) UpdatePublisher(ctx context.Context, args domain.UpdatePublisherParams) (domain.Publisher, error) {
+	if mock.UpdatePublisherFunc == nil {
+		panic("RepositoryMock.UpdatePublisherFunc: method is nil but Repository.UpdatePublisher was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdatePublisherParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdatePublisher.Lock()
+	mock.calls.UpdatePublisher = append(mock.calls.UpdatePublisher, callInfo)
+	lockRepositoryMockUpdatePublisher.Unlock()
+	return mock.UpdatePublisherFunc(ctx, args)
+}
+
+// UpdatePublisherCalls gets all the calls that were made to UpdatePublisher.
+// Check the length with:
+//     len(mockedRepository.UpdatePublisherCalls())
+func (mock *RepositoryMock) UpdatePublisherCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdatePublisherParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdatePublisherParams
+	}
+	lockRepositoryMockUpdatePublisher.RLock()
+	calls = mock.calls.UpdatePublisher
+	lockRepositoryMockUpdatePublisher.RUnlock()
+	return calls
+}
+
+// UpdateSeries calls UpdateSeriesFunc.
+func (mock *RepositoryMock) UpdateSeries(ctx context.Context, args domain.UpdateSeriesParams) (domain.Series, error) {
+	if mock.UpdateSeriesFunc == nil {
+		panic("RepositoryMock.UpdateSeriesFunc: method is nil but Repository.UpdateSeries was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdateSeriesParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdateSeries.Lock()
+	mock.calls.UpdateSeries = append(mock.calls.UpdateSeries, callInfo)
+	lockRepositoryMockUpdateSeries.Unlock()
+	return mock.UpdateSeriesFunc(ctx, args)
+}
+
+// UpdateSeriesCalls gets all the calls that were made to UpdateSeries.
+// Check the length with:
+//     len(mockedRepository.UpdateSeriesCalls())
+func (mock *RepositoryMock) UpdateSeriesCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdateSeriesParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdateSeriesParams
+	}
+	lockRepositoryMockUpdateSeries.RLock()
+	calls = mock.calls.UpdateSeries
+	lockRepositoryMockUpdateSeries.RUnlock()
+	return calls
+}
+
+// UpdateWebhook calls UpdateWebhookFunc.
+func (mock *RepositoryMock) UpdateWebhook(ctx context.Context, args domain.UpdateWebhookParams) (domain.Webhook, error) {
+	if mock.UpdateWebhookFunc == nil {
+		panic("RepositoryMock.UpdateWebhookFunc: method is nil but Repository.UpdateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.UpdateWebhookParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockRepositoryMockUpdateWebhook.Lock()
+	mock.calls.UpdateWebhook = append(mock.calls.UpdateWebhook, callInfo)
+	lockRepositoryMockUpdateWebhook.Unlock()
+	return mock.UpdateWebhookFunc(ctx, args)
+}
+
+// UpdateWebhookCalls gets all the calls that were made to UpdateWebhook.
+// Check the length with:
+//     len(mockedRepository.UpdateWebhookCalls())
+func (mock *RepositoryMock) UpdateWebhookCalls() []struct {
+	Ctx  context.Context
+	Args domain.UpdateWebhookParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.UpdateWebhookParams
+	}
+	lockRepositoryMockUpdateWebhook.RLock()
+	calls = mock.calls.UpdateWebhook
+	lockRepositoryMockUpdateWebhook.RUnlock()
+	return calls
+}
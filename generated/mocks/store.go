@@ -0,0 +1,183 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mocks
+
+import (
+	"context"
+	"github.com/fwojciec/litag-example/domain"
+	"github.com/fwojciec/litag-example/webhooks"
+	"sync"
+)
+
+var (
+	lockStoreMockClaimWebhookDeliveries  sync.RWMutex
+	lockStoreMockCompleteWebhookDelivery sync.RWMutex
+	lockStoreMockFailWebhookDelivery     sync.RWMutex
+)
+
+// Ensure, that StoreMock does implement webhooks.Store.
+// If this is not the case, regenerate this file with moq.
+var _ webhooks.Store = &StoreMock{}
+
+// StoreMock is a mock implementation of webhooks.Store.
+//
+//     func TestSomethingThatUsesStore(t *testing.T) {
+//
+//         // make and configure a mocked webhooks.Store
+//         mockedStore := &StoreMock{
+//             ClaimWebhookDeliveriesFunc: func(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+// 	               panic("mock out the ClaimWebhookDeliveries method")
+//             },
+//             CompleteWebhookDeliveryFunc: func(ctx context.Context, args domain.CompleteWebhookDeliveryParams) error {
+// 	               panic("mock out the CompleteWebhookDelivery method")
+//             },
+//             FailWebhookDeliveryFunc: func(ctx context.Context, args domain.FailWebhookDeliveryParams) error {
+// 	               panic("mock out the FailWebhookDelivery method")
+//             },
+//         }
+//
+//         // use mockedStore in code that requires webhooks.Store
+//         // and then make assertions.
+//
+//     }
+type StoreMock struct {
+	// ClaimWebhookDeliveriesFunc mocks the ClaimWebhookDeliveries method.
+	ClaimWebhookDeliveriesFunc func(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error)
+
+	// CompleteWebhookDeliveryFunc mocks the CompleteWebhookDelivery method.
+	CompleteWebhookDeliveryFunc func(ctx context.Context, args domain.CompleteWebhookDeliveryParams) error
+
+	// FailWebhookDeliveryFunc mocks the FailWebhookDelivery method.
+	FailWebhookDeliveryFunc func(ctx context.Context, args domain.FailWebhookDeliveryParams) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ClaimWebhookDeliveries holds details about calls to the ClaimWebhookDeliveries method.
+		ClaimWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.ClaimWebhookDeliveriesParams
+		}
+		// CompleteWebhookDelivery holds details about calls to the CompleteWebhookDelivery method.
+		CompleteWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.CompleteWebhookDeliveryParams
+		}
+		// FailWebhookDelivery holds details about calls to the FailWebhookDelivery method.
+		FailWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args domain.FailWebhookDeliveryParams
+		}
+	}
+}
+
+// ClaimWebhookDeliveries calls ClaimWebhookDeliveriesFunc.
+func (mock *StoreMock) ClaimWebhookDeliveries(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+	if mock.ClaimWebhookDeliveriesFunc == nil {
+		panic("StoreMock.ClaimWebhookDeliveriesFunc: method is nil but Store.ClaimWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.ClaimWebhookDeliveriesParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockStoreMockClaimWebhookDeliveries.Lock()
+	mock.calls.ClaimWebhookDeliveries = append(mock.calls.ClaimWebhookDeliveries, callInfo)
+	lockStoreMockClaimWebhookDeliveries.Unlock()
+	return mock.ClaimWebhookDeliveriesFunc(ctx, args)
+}
+
+// ClaimWebhookDeliveriesCalls gets all the calls that were made to ClaimWebhookDeliveries.
+// Check the length with:
+//     len(mockedStore.ClaimWebhookDeliveriesCalls())
+func (mock *StoreMock) ClaimWebhookDeliveriesCalls() []struct {
+	Ctx  context.Context
+	Args domain.ClaimWebhookDeliveriesParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.ClaimWebhookDeliveriesParams
+	}
+	lockStoreMockClaimWebhookDeliveries.RLock()
+	calls = mock.calls.ClaimWebhookDeliveries
+	lockStoreMockClaimWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// CompleteWebhookDelivery calls CompleteWebhookDeliveryFunc.
+func (mock *StoreMock) CompleteWebhookDelivery(ctx context.Context, args domain.CompleteWebhookDeliveryParams) error {
+	if mock.CompleteWebhookDeliveryFunc == nil {
+		panic("StoreMock.CompleteWebhookDeliveryFunc: method is nil but Store.CompleteWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.CompleteWebhookDeliveryParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockStoreMockCompleteWebhookDelivery.Lock()
+	mock.calls.CompleteWebhookDelivery = append(mock.calls.CompleteWebhookDelivery, callInfo)
+	lockStoreMockCompleteWebhookDelivery.Unlock()
+	return mock.CompleteWebhookDeliveryFunc(ctx, args)
+}
+
+// CompleteWebhookDeliveryCalls gets all the calls that were made to CompleteWebhookDelivery.
+// Check the length with:
+//     len(mockedStore.CompleteWebhookDeliveryCalls())
+func (mock *StoreMock) CompleteWebhookDeliveryCalls() []struct {
+	Ctx  context.Context
+	Args domain.CompleteWebhookDeliveryParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.CompleteWebhookDeliveryParams
+	}
+	lockStoreMockCompleteWebhookDelivery.RLock()
+	calls = mock.calls.CompleteWebhookDelivery
+	lockStoreMockCompleteWebhookDelivery.RUnlock()
+	return calls
+}
+
+// FailWebhookDelivery calls FailWebhookDeliveryFunc.
+func (mock *StoreMock) FailWebhookDelivery(ctx context.Context, args domain.FailWebhookDeliveryParams) error {
+	if mock.FailWebhookDeliveryFunc == nil {
+		panic("StoreMock.FailWebhookDeliveryFunc: method is nil but Store.FailWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args domain.FailWebhookDeliveryParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockStoreMockFailWebhookDelivery.Lock()
+	mock.calls.FailWebhookDelivery = append(mock.calls.FailWebhookDelivery, callInfo)
+	lockStoreMockFailWebhookDelivery.Unlock()
+	return mock.FailWebhookDeliveryFunc(ctx, args)
+}
+
+// FailWebhookDeliveryCalls gets all the calls that were made to FailWebhookDelivery.
+// Check the length with:
+//     len(mockedStore.FailWebhookDeliveryCalls())
+func (mock *StoreMock) FailWebhookDeliveryCalls() []struct {
+	Ctx  context.Context
+	Args domain.FailWebhookDeliveryParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args domain.FailWebhookDeliveryParams
+	}
+	lockStoreMockFailWebhookDelivery.RLock()
+	calls = mock.calls.FailWebhookDelivery
+	lockStoreMockFailWebhookDelivery.RUnlock()
+	return calls
+}
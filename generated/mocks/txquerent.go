@@ -5,14 +5,31 @@ package mocks
 
 import (
 	"context"
+	"database/sql"
 	"github.com/fwojciec/litag-example/generated/sqlc"
 	"github.com/fwojciec/litag-example/postgres"
 	"sync"
+	"time"
 )
 
 var (
-	lockTxQuerentMockCreateBook sync.RWMutex
-	lockTxQuerentMockUpdateBook sync.RWMutex
+	lockTxQuerentMockConvertSubmission    sync.RWMutex
+	lockTxQuerentMockCreateAgents         sync.RWMutex
+	lockTxQuerentMockCreateAuthor         sync.RWMutex
+	lockTxQuerentMockCreateAuthors        sync.RWMutex
+	lockTxQuerentMockCreateBook           sync.RWMutex
+	lockTxQuerentMockCreateBooks          sync.RWMutex
+	lockTxQuerentMockDeleteAgent          sync.RWMutex
+	lockTxQuerentMockDeleteAuthor         sync.RWMutex
+	lockTxQuerentMockDeleteBook           sync.RWMutex
+	lockTxQuerentMockDeleteSeries         sync.RWMutex
+	lockTxQuerentMockEndRepresentation    sync.RWMutex
+	lockTxQuerentMockReorderSeries        sync.RWMutex
+	lockTxQuerentMockTransitionSubmission sync.RWMutex
+	lockTxQuerentMockUpdateAuthor         sync.RWMutex
+	lockTxQuerentMockUpdateBook           sync.RWMutex
+	lockTxQuerentMockUpdateBooks          sync.RWMutex
+	lockTxQuerentMockUpdateGenre          sync.RWMutex
 )
 
 // Ensure, that TxQuerentMock does implement postgres.TxQuerent.
@@ -25,12 +42,57 @@ var _ postgres.TxQuerent = &TxQuerentMock{}
 //
 //         // make and configure a mocked postgres.TxQuerent
 //         mockedTxQuerent := &TxQuerentMock{
-//             CreateBookFunc: func(ctx context.Context, bookArgs sqlc.CreateBookParams, authorIDs []int64) (*sqlc.Book, error) {
+//             ConvertSubmissionFunc: func(ctx context.Context, id int64, cover string) (*sqlc.Book, error) {
+// 	               panic("mock out the ConvertSubmission method")
+//             },
+//             CreateAgentsFunc: func(ctx context.Context, args []sqlc.CreateAgentParams, mode postgres.BulkMode) (*postgres.BulkAgentsResult, error) {
+// 	               panic("mock out the CreateAgents method")
+//             },
+//             CreateAuthorFunc: func(ctx context.Context, args postgres.CreateAuthorParams) (*postgres.Author, error) {
+// 	               panic("mock out the CreateAuthor method")
+//             },
+//             CreateAuthorsFunc: func(ctx context.Context, args []postgres.CreateAuthorParams, mode postgres.BulkMode) (*postgres.BulkAuthorsResult, error) {
+// 	               panic("mock out the CreateAuthors method")
+//             },
+//             CreateBookFunc: func(ctx context.Context, bookArgs sqlc.CreateBookParams, contributors []postgres.Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
 // 	               panic("mock out the CreateBook method")
 //             },
-//             UpdateBookFunc: func(ctx context.Context, bookArgs sqlc.UpdateBookParams, authorIDs []int64) (*sqlc.Book, error) {
+//             CreateBooksFunc: func(ctx context.Context, args []postgres.BulkCreateBookArgs, mode postgres.BulkMode) (*postgres.BulkBooksResult, error) {
+// 	               panic("mock out the CreateBooks method")
+//             },
+//             DeleteAgentFunc: func(ctx context.Context, id int64, reassignAuthorsTo *int64) (*sqlc.Agent, error) {
+// 	               panic("mock out the DeleteAgent method")
+//             },
+//             DeleteAuthorFunc: func(ctx context.Context, id int64, orphanedBooks postgres.OrphanedBooksPolicy) (*postgres.Author, error) {
+// 	               panic("mock out the DeleteAuthor method")
+//             },
+//             DeleteBookFunc: func(ctx context.Context, id int64) (*sqlc.Book, error) {
+// 	               panic("mock out the DeleteBook method")
+//             },
+//             DeleteSeriesFunc: func(ctx context.Context, id int64) (*sqlc.Series, error) {
+// 	               panic("mock out the DeleteSeries method")
+//             },
+//             EndRepresentationFunc: func(ctx context.Context, id int64, endedAt time.Time) (*sqlc.Representation, error) {
+// 	               panic("mock out the EndRepresentation method")
+//             },
+//             ReorderSeriesFunc: func(ctx context.Context, id int64, bookIDs []int64) (*sqlc.Series, error) {
+// 	               panic("mock out the ReorderSeries method")
+//             },
+//             TransitionSubmissionFunc: func(ctx context.Context, id int64, status string, note sql.NullString) (*sqlc.Submission, error) {
+// 	               panic("mock out the TransitionSubmission method")
+//             },
+//             UpdateAuthorFunc: func(ctx context.Context, args postgres.UpdateAuthorParams) (*postgres.Author, error) {
+// 	               panic("mock out the UpdateAuthor method")
+//             },
+//             UpdateBookFunc: func(ctx context.Context, bookArgs sqlc.UpdateBookParams, contributors []postgres.Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
 // 	               panic("mock out the UpdateBook method")
 //             },
+//             UpdateBooksFunc: func(ctx context.Context, args []postgres.BulkUpdateBookArgs, mode postgres.BulkMode) (*postgres.BulkBooksResult, error) {
+// 	               panic("mock out the UpdateBooks method")
+//             },
+//             UpdateGenreFunc: func(ctx context.Context, args sqlc.UpdateGenreParams) (*sqlc.Genre, error) {
+// 	               panic("mock out the UpdateGenre method")
+//             },
 //         }
 //
 //         // use mockedTxQuerent in code that requires postgres.TxQuerent
@@ -38,22 +100,182 @@ var _ postgres.TxQuerent = &TxQuerentMock{}
 //
 //     }
 type TxQuerentMock struct {
+	// ConvertSubmissionFunc mocks the ConvertSubmission method.
+	ConvertSubmissionFunc func(ctx context.Context, id int64, cover string) (*sqlc.Book, error)
+
+	// CreateAgentsFunc mocks the CreateAgents method.
+	CreateAgentsFunc func(ctx context.Context, args []sqlc.CreateAgentParams, mode postgres.BulkMode) (*postgres.BulkAgentsResult, error)
+
+	// CreateAuthorFunc mocks the CreateAuthor method.
+	CreateAuthorFunc func(ctx context.Context, args postgres.CreateAuthorParams) (*postgres.Author, error)
+
+	// CreateAuthorsFunc mocks the CreateAuthors method.
+	CreateAuthorsFunc func(ctx context.Context, args []postgres.CreateAuthorParams, mode postgres.BulkMode) (*postgres.BulkAuthorsResult, error)
+
 	// CreateBookFunc mocks the CreateBook method.
-	CreateBookFunc func(ctx context.Context, bookArgs sqlc.CreateBookParams, authorIDs []int64) (*sqlc.Book, error)
+	CreateBookFunc func(ctx context.Context, bookArgs sqlc.CreateBookParams, contributors []postgres.Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error)
+
+	// CreateBooksFunc mocks the CreateBooks method.
+	CreateBooksFunc func(ctx context.Context, args []postgres.BulkCreateBookArgs, mode postgres.BulkMode) (*postgres.BulkBooksResult, error)
+
+	// DeleteAgentFunc mocks the DeleteAgent method.
+	DeleteAgentFunc func(ctx context.Context, id int64, reassignAuthorsTo *int64) (*sqlc.Agent, error)
+
+	// DeleteAuthorFunc mocks the DeleteAuthor method.
+	DeleteAuthorFunc func(ctx context.Context, id int64, orphanedBooks postgres.OrphanedBooksPolicy) (*postgres.Author, error)
+
+	// DeleteBookFunc mocks the DeleteBook method.
+	DeleteBookFunc func(ctx context.Context, id int64) (*sqlc.Book, error)
+
+	// DeleteSeriesFunc mocks the DeleteSeries method.
+	DeleteSeriesFunc func(ctx context.Context, id int64) (*sqlc.Series, error)
+
+	// EndRepresentationFunc mocks the EndRepresentation method.
+	EndRepresentationFunc func(ctx context.Context, id int64, endedAt time.Time) (*sqlc.Representation, error)
+
+	// ReorderSeriesFunc mocks the ReorderSeries method.
+	ReorderSeriesFunc func(ctx context.Context, id int64, bookIDs []int64) (*sqlc.Series, error)
+
+	// TransitionSubmissionFunc mocks the TransitionSubmission method.
+	TransitionSubmissionFunc func(ctx context.Context, id int64, status string, note sql.NullString) (*sqlc.Submission, error)
+
+	// UpdateAuthorFunc mocks the UpdateAuthor method.
+	UpdateAuthorFunc func(ctx context.Context, args postgres.UpdateAuthorParams) (*postgres.Author, error)
 
 	// UpdateBookFunc mocks the UpdateBook method.
-	UpdateBookFunc func(ctx context.Context, bookArgs sqlc.UpdateBookParams, authorIDs []int64) (*sqlc.Book, error)
+	UpdateBookFunc func(ctx context.Context, bookArgs sqlc.UpdateBookParams, contributors []postgres.Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error)
+
+	// UpdateBooksFunc mocks the UpdateBooks method.
+	UpdateBooksFunc func(ctx context.Context, args []postgres.BulkUpdateBookArgs, mode postgres.BulkMode) (*postgres.BulkBooksResult, error)
+
+	// UpdateGenreFunc mocks the UpdateGenre method.
+	UpdateGenreFunc func(ctx context.Context, args sqlc.UpdateGenreParams) (*sqlc.Genre, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ConvertSubmission holds details about calls to the ConvertSubmission method.
+		ConvertSubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Cover is the cover argument value.
+			Cover string
+		}
+		// CreateAgents holds details about calls to the CreateAgents method.
+		CreateAgents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []sqlc.CreateAgentParams
+			// Mode is the mode argument value.
+			Mode postgres.BulkMode
+		}
+		// CreateAuthor holds details about calls to the CreateAuthor method.
+		CreateAuthor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args postgres.CreateAuthorParams
+		}
+		// CreateAuthors holds details about calls to the CreateAuthors method.
+		CreateAuthors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []postgres.CreateAuthorParams
+			// Mode is the mode argument value.
+			Mode postgres.BulkMode
+		}
 		// CreateBook holds details about calls to the CreateBook method.
 		CreateBook []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// BookArgs is the bookArgs argument value.
 			BookArgs sqlc.CreateBookParams
-			// AuthorIDs is the authorIDs argument value.
-			AuthorIDs []int64
+			// Contributors is the contributors argument value.
+			Contributors []postgres.Contributor
+			// GenreIDs is the genreIDs argument value.
+			GenreIDs []int64
+			// Tags is the tags argument value.
+			Tags []string
+		}
+		// CreateBooks holds details about calls to the CreateBooks method.
+		CreateBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []postgres.BulkCreateBookArgs
+			// Mode is the mode argument value.
+			Mode postgres.BulkMode
+		}
+		// DeleteAgent holds details about calls to the DeleteAgent method.
+		DeleteAgent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// ReassignAuthorsTo is the reassignAuthorsTo argument value.
+			ReassignAuthorsTo *int64
+		}
+		// DeleteAuthor holds details about calls to the DeleteAuthor method.
+		DeleteAuthor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// OrphanedBooks is the orphanedBooks argument value.
+			OrphanedBooks postgres.OrphanedBooksPolicy
+		}
+		// DeleteBook holds details about calls to the DeleteBook method.
+		DeleteBook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// DeleteSeries holds details about calls to the DeleteSeries method.
+		DeleteSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// EndRepresentation holds details about calls to the EndRepresentation method.
+		EndRepresentation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// EndedAt is the endedAt argument value.
+			EndedAt time.Time
+		}
+		// ReorderSeries holds details about calls to the ReorderSeries method.
+		ReorderSeries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// BookIDs is the bookIDs argument value.
+			BookIDs []int64
+		}
+		// TransitionSubmission holds details about calls to the TransitionSubmission method.
+		TransitionSubmission []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+			// Status is the status argument value.
+			Status string
+			// Note is the note argument value.
+			Note sql.NullString
+		}
+		// UpdateAuthor holds details about calls to the UpdateAuthor method.
+		UpdateAuthor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args postgres.UpdateAuthorParams
 		}
 		// UpdateBook holds details about calls to the UpdateBook method.
 		UpdateBook []struct {
@@ -61,44 +283,224 @@ type TxQuerentMock struct {
 			Ctx context.Context
 			// BookArgs is the bookArgs argument value.
 			BookArgs sqlc.UpdateBookParams
-			// AuthorIDs is the authorIDs argument value.
-			AuthorIDs []int64
+			// Contributors is the contributors argument value.
+			Contributors []postgres.Contributor
+			// GenreIDs is the genreIDs argument value.
+			GenreIDs []int64
+			// Tags is the tags argument value.
+			Tags []string
 		}
+		// UpdateBooks holds details about calls to the UpdateBooks method.
+		UpdateBooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args []postgres.BulkUpdateBookArgs
+			// Mode is the mode argument value.
+			Mode postgres.BulkMode
+		}
+		// UpdateGenre holds details about calls to the UpdateGenre method.
+		UpdateGenre []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Args is the args argument value.
+			Args sqlc.UpdateGenreParams
+		}
+	}
+}
+
+// ConvertSubmission calls ConvertSubmissionFunc.
+func (mock *TxQuerentMock) ConvertSubmission(ctx context.Context, id int64, cover string) (*sqlc.Book, error) {
+	if mock.ConvertSubmissionFunc == nil {
+		panic("TxQuerentMock.ConvertSubmissionFunc: method is nil but TxQuerent.ConvertSubmission was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    int64
+		Cover string
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		Cover: cover,
+	}
+	lockTxQuerentMockConvertSubmission.Lock()
+	mock.calls.ConvertSubmission = append(mock.calls.ConvertSubmission, callInfo)
+	lockTxQuerentMockConvertSubmission.Unlock()
+	return mock.ConvertSubmissionFunc(ctx, id, cover)
+}
+
+// ConvertSubmissionCalls gets all the calls that were made to ConvertSubmission.
+// Check the length with:
+//     len(mockedTxQuerent.ConvertSubmissionCalls())
+func (mock *TxQuerentMock) ConvertSubmissionCalls() []struct {
+	Ctx   context.Context
+	ID    int64
+	Cover string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    int64
+		Cover string
+	}
+	lockTxQuerentMockConvertSubmission.RLock()
+	calls = mock.calls.ConvertSubmission
+	lockTxQuerentMockConvertSubmission.RUnlock()
+	return calls
+}
+
+// CreateAgents calls CreateAgentsFunc.
+func (mock *TxQuerentMock) CreateAgents(ctx context.Context, args []sqlc.CreateAgentParams, mode postgres.BulkMode) (*postgres.BulkAgentsResult, error) {
+	if mock.CreateAgentsFunc == nil {
+		panic("TxQuerentMock.CreateAgentsFunc: method is nil but TxQuerent.CreateAgents was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []sqlc.CreateAgentParams
+		Mode postgres.BulkMode
+	}{
+		Ctx:  ctx,
+		Args: args,
+		Mode: mode,
 	}
+	lockTxQuerentMockCreateAgents.Lock()
+	mock.calls.CreateAgents = append(mock.calls.CreateAgents, callInfo)
+	lockTxQuerentMockCreateAgents.Unlock()
+	return mock.CreateAgentsFunc(ctx, args, mode)
+}
+
+// CreateAgentsCalls gets all the calls that were made to CreateAgents.
+// Check the length with:
+//     len(mockedTxQuerent.CreateAgentsCalls())
+func (mock *TxQuerentMock) CreateAgentsCalls() []struct {
+	Ctx  context.Context
+	Args []sqlc.CreateAgentParams
+	Mode postgres.BulkMode
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []sqlc.CreateAgentParams
+		Mode postgres.BulkMode
+	}
+	lockTxQuerentMockCreateAgents.RLock()
+	calls = mock.calls.CreateAgents
+	lockTxQuerentMockCreateAgents.RUnlock()
+	return calls
+}
+
+// CreateAuthor calls CreateAuthorFunc.
+func (mock *TxQuerentMock) CreateAuthor(ctx context.Context, args postgres.CreateAuthorParams) (*postgres.Author, error) {
+	if mock.CreateAuthorFunc == nil {
+		panic("TxQuerentMock.CreateAuthorFunc: method is nil but TxQuerent.CreateAuthor was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args postgres.CreateAuthorParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockTxQuerentMockCreateAuthor.Lock()
+	mock.calls.CreateAuthor = append(mock.calls.CreateAuthor, callInfo)
+	lockTxQuerentMockCreateAuthor.Unlock()
+	return mock.CreateAuthorFunc(ctx, args)
+}
+
+// CreateAuthorCalls gets all the calls that were made to CreateAuthor.
+// Check the length with:
+//     len(mockedTxQuerent.CreateAuthorCalls())
+func (mock *TxQuerentMock) CreateAuthorCalls() []struct {
+	Ctx  context.Context
+	Args postgres.CreateAuthorParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args postgres.CreateAuthorParams
+	}
+	lockTxQuerentMockCreateAuthor.RLock()
+	calls = mock.calls.CreateAuthor
+	lockTxQuerentMockCreateAuthor.RUnlock()
+	return calls
+}
+
+// CreateAuthors calls CreateAuthorsFunc.
+func (mock *TxQuerentMock) CreateAuthors(ctx context.Context, args []postgres.CreateAuthorParams, mode postgres.BulkMode) (*postgres.BulkAuthorsResult, error) {
+	if mock.CreateAuthorsFunc == nil {
+		panic("TxQuerentMock.CreateAuthorsFunc: method is nil but TxQuerent.CreateAuthors was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []postgres.CreateAuthorParams
+		Mode postgres.BulkMode
+	}{
+		Ctx:  ctx,
+		Args: args,
+		Mode: mode,
+	}
+	lockTxQuerentMockCreateAuthors.Lock()
+	mock.calls.CreateAuthors = append(mock.calls.CreateAuthors, callInfo)
+	lockTxQuerentMockCreateAuthors.Unlock()
+	return mock.CreateAuthorsFunc(ctx, args, mode)
+}
+
+// CreateAuthorsCalls gets all the calls that were made to CreateAuthors.
+// Check the length with:
+//     len(mockedTxQuerent.CreateAuthorsCalls())
+func (mock *TxQuerentMock) CreateAuthorsCalls() []struct {
+	Ctx  context.Context
+	Args []postgres.CreateAuthorParams
+	Mode postgres.BulkMode
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []postgres.CreateAuthorParams
+		Mode postgres.BulkMode
+	}
+	lockTxQuerentMockCreateAuthors.RLock()
+	calls = mock.calls.CreateAuthors
+	lockTxQuerentMockCreateAuthors.RUnlock()
+	return calls
 }
 
 // CreateBook calls CreateBookFunc.
-func (mock *TxQuerentMock) CreateBook(ctx context.Context, bookArgs sqlc.CreateBookParams, authorIDs []int64) (*sqlc.Book, error) {
+func (mock *TxQuerentMock) CreateBook(ctx context.Context, bookArgs sqlc.CreateBookParams, contributors []postgres.Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
 	if mock.CreateBookFunc == nil {
 		panic("TxQuerentMock.CreateBookFunc: method is nil but TxQuerent.CreateBook was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		BookArgs  sqlc.CreateBookParams
-		AuthorIDs []int64
+		Ctx          context.Context
+		BookArgs     sqlc.CreateBookParams
+		Contributors []postgres.Contributor
+		GenreIDs     []int64
+		Tags         []string
 	}{
-		Ctx:       ctx,
-		BookArgs:  bookArgs,
-		AuthorIDs: authorIDs,
+		Ctx:          ctx,
+		BookArgs:     bookArgs,
+		Contributors: contributors,
+		GenreIDs:     genreIDs,
+		Tags:         tags,
 	}
 	lockTxQuerentMockCreateBook.Lock()
 	mock.calls.CreateBook = append(mock.calls.CreateBook, callInfo)
 	lockTxQuerentMockCreateBook.Unlock()
-	return mock.CreateBookFunc(ctx, bookArgs, authorIDs)
+	return mock.CreateBookFunc(ctx, bookArgs, contributors, genreIDs, tags)
 }
 
 // CreateBookCalls gets all the calls that were made to CreateBook.
 // Check the length with:
 //     len(mockedTxQuerent.CreateBookCalls())
 func (mock *TxQuerentMock) CreateBookCalls() []struct {
-	Ctx       context.Context
-	BookArgs  sqlc.CreateBookParams
-	AuthorIDs []int64
+	Ctx          context.Context
+	BookArgs     sqlc.CreateBookParams
+	Contributors []postgres.Contributor
+	GenreIDs     []int64
+	Tags         []string
 } {
 	var calls []struct {
-		Ctx       context.Context
-		BookArgs  sqlc.CreateBookParams
-		AuthorIDs []int64
+		Ctx          context.Context
+		BookArgs     sqlc.CreateBookParams
+		Contributors []postgres.Contributor
+		GenreIDs     []int64
+		Tags         []string
 	}
 	lockTxQuerentMockCreateBook.RLock()
 	calls = mock.calls.CreateBook
@@ -106,41 +508,466 @@ func (mock *TxQuerentMock) CreateBookCalls() []struct {
 	return calls
 }
 
+// CreateBooks calls CreateBooksFunc.
+func (mock *TxQuerentMock) CreateBooks(ctx context.Context, args []postgres.BulkCreateBookArgs, mode postgres.BulkMode) (*postgres.BulkBooksResult, error) {
+	if mock.CreateBooksFunc == nil {
+		panic("TxQuerentMock.CreateBooksFunc: method is nil but TxQuerent.CreateBooks was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []postgres.BulkCreateBookArgs
+		Mode postgres.BulkMode
+	}{
+		Ctx:  ctx,
+		Args: args,
+		Mode: mode,
+	}
+	lockTxQuerentMockCreateBooks.Lock()
+	mock.calls.CreateBooks = append(mock.calls.CreateBooks, callInfo)
+	lockTxQuerentMockCreateBooks.Unlock()
+	return mock.CreateBooksFunc(ctx, args, mode)
+}
+
+// CreateBooksCalls gets all the calls that were made to CreateBooks.
+// Check the length with:
+//     len(mockedTxQuerent.CreateBooksCalls())
+func (mock *TxQuerentMock) CreateBooksCalls() []struct {
+	Ctx  context.Context
+	Args []postgres.BulkCreateBookArgs
+	Mode postgres.BulkMode
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []postgres.BulkCreateBookArgs
+		Mode postgres.BulkMode
+	}
+	lockTxQuerentMockCreateBooks.RLock()
+	calls = mock.calls.CreateBooks
+	lockTxQuerentMockCreateBooks.RUnlock()
+	return calls
+}
+
+// DeleteAgent calls DeleteAgentFunc.
+func (mock *TxQuerentMock) DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*sqlc.Agent, error) {
+	if mock.DeleteAgentFunc == nil {
+		panic("TxQuerentMock.DeleteAgentFunc: method is nil but TxQuerent.DeleteAgent was just called")
+	}
+	callInfo := struct {
+		Ctx               context.Context
+		ID                int64
+		ReassignAuthorsTo *int64
+	}{
+		Ctx:               ctx,
+		ID:                id,
+		ReassignAuthorsTo: reassignAuthorsTo,
+	}
+	lockTxQuerentMockDeleteAgent.Lock()
+	mock.calls.DeleteAgent = append(mock.calls.DeleteAgent, callInfo)
+	lockTxQuerentMockDeleteAgent.Unlock()
+	return mock.DeleteAgentFunc(ctx, id, reassignAuthorsTo)
+}
+
+// DeleteAgentCalls gets all the calls that were made to DeleteAgent.
+// Check the length with:
+//     len(mockedTxQuerent.DeleteAgentCalls())
+func (mock *TxQuerentMock) DeleteAgentCalls() []struct {
+	Ctx               context.Context
+	ID                int64
+	ReassignAuthorsTo *int64
+} {
+	var calls []struct {
+		Ctx               context.Context
+		ID                int64
+		ReassignAuthorsTo *int64
+	}
+	lockTxQuerentMockDeleteAgent.RLock()
+	calls = mock.calls.DeleteAgent
+	lockTxQuerentMockDeleteAgent.RUnlock()
+	return calls
+}
+
+// DeleteAuthor calls DeleteAuthorFunc.
+func (mock *TxQuerentMock) DeleteAuthor(ctx context.Context, id int64, orphanedBooks postgres.OrphanedBooksPolicy) (*postgres.Author, error) {
+	if mock.DeleteAuthorFunc == nil {
+		panic("TxQuerentMock.DeleteAuthorFunc: method is nil but TxQuerent.DeleteAuthor was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		ID            int64
+		OrphanedBooks postgres.OrphanedBooksPolicy
+	}{
+		Ctx:           ctx,
+		ID:            id,
+		OrphanedBooks: orphanedBooks,
+	}
+	lockTxQuerentMockDeleteAuthor.Lock()
+	mock.calls.DeleteAuthor = append(mock.calls.DeleteAuthor, callInfo)
+	lockTxQuerentMockDeleteAuthor.Unlock()
+	return mock.DeleteAuthorFunc(ctx, id, orphanedBooks)
+}
+
+// DeleteAuthorCalls gets all the calls that were made to DeleteAuthor.
+// Check the length with:
+//     len(mockedTxQuerent.DeleteAuthorCalls())
+func (mock *TxQuerentMock) DeleteAuthorCalls() []struct {
+	Ctx           context.Context
+	ID            int64
+	OrphanedBooks postgres.OrphanedBooksPolicy
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ID            int64
+		OrphanedBooks postgres.OrphanedBooksPolicy
+	}
+	lockTxQuerentMockDeleteAuthor.RLock()
+	calls = mock.calls.DeleteAuthor
+	lockTxQuerentMockDeleteAuthor.RUnlock()
+	return calls
+}
+
+// DeleteBook calls DeleteBookFunc.
+func (mock *TxQuerentMock) DeleteBook(ctx context.Context, id int64) (*sqlc.Book, error) {
+	if mock.DeleteBookFunc == nil {
+		panic("TxQuerentMock.DeleteBookFunc: method is nil but TxQuerent.DeleteBook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockTxQuerentMockDeleteBook.Lock()
+	mock.calls.DeleteBook = append(mock.calls.DeleteBook, callInfo)
+	lockTxQuerentMockDeleteBook.Unlock()
+	return mock.DeleteBookFunc(ctx, id)
+}
+
+// DeleteBookCalls gets all the calls that were made to DeleteBook.
+// Check the length with:
+//     len(mockedTxQuerent.DeleteBookCalls())
+func (mock *TxQuerentMock) DeleteBookCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockTxQuerentMockDeleteBook.RLock()
+	calls = mock.calls.DeleteBook
+	lockTxQuerentMockDeleteBook.RUnlock()
+	return calls
+}
+
+// DeleteSeries calls DeleteSeriesFunc.
+func (mock *TxQuerentMock) DeleteSeries(ctx context.Context, id int64) (*sqlc.Series, error) {
+	if mock.DeleteSeriesFunc == nil {
+		panic("TxQuerentMock.DeleteSeriesFunc: method is nil but TxQuerent.DeleteSeries was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	lockTxQuerentMockDeleteSeries.Lock()
+	mock.calls.DeleteSeries = append(mock.calls.DeleteSeries, callInfo)
+	lockTxQuerentMockDeleteSeries.Unlock()
+	return mock.DeleteSeriesFunc(ctx, id)
+}
+
+// DeleteSeriesCalls gets all the calls that were made to DeleteSeries.
+// Check the length with:
+//     len(mockedTxQuerent.DeleteSeriesCalls())
+func (mock *TxQuerentMock) DeleteSeriesCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	lockTxQuerentMockDeleteSeries.RLock()
+	calls = mock.calls.DeleteSeries
+	lockTxQuerentMockDeleteSeries.RUnlock()
+	return calls
+}
+
+// EndRepresentation calls EndRepresentationFunc.
+func (mock *TxQuerentMock) EndRepresentation(ctx context.Context, id int64, endedAt time.Time) (*sqlc.Representation, error) {
+	if mock.EndRepresentationFunc == nil {
+		panic("TxQuerentMock.EndRepresentationFunc: method is nil but TxQuerent.EndRepresentation was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		EndedAt time.Time
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		EndedAt: endedAt,
+	}
+	lockTxQuerentMockEndRepresentation.Lock()
+	mock.calls.EndRepresentation = append(mock.calls.EndRepresentation, callInfo)
+	lockTxQuerentMockEndRepresentation.Unlock()
+	return mock.EndRepresentationFunc(ctx, id, endedAt)
+}
+
+// EndRepresentationCalls gets all the calls that were made to EndRepresentation.
+// Check the length with:
+//     len(mockedTxQuerent.EndRepresentationCalls())
+func (mock *TxQuerentMock) EndRepresentationCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	EndedAt time.Time
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		EndedAt time.Time
+	}
+	lockTxQuerentMockEndRepresentation.RLock()
+	calls = mock.calls.EndRepresentation
+	lockTxQuerentMockEndRepresentation.RUnlock()
+	return calls
+}
+
+// ReorderSeries calls ReorderSeriesFunc.
+func (mock *TxQuerentMock) ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*sqlc.Series, error) {
+	if mock.ReorderSeriesFunc == nil {
+		panic("TxQuerentMock.ReorderSeriesFunc: method is nil but TxQuerent.ReorderSeries was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      int64
+		BookIDs []int64
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		BookIDs: bookIDs,
+	}
+	lockTxQuerentMockReorderSeries.Lock()
+	mock.calls.ReorderSeries = append(mock.calls.ReorderSeries, callInfo)
+	lockTxQuerentMockReorderSeries.Unlock()
+	return mock.ReorderSeriesFunc(ctx, id, bookIDs)
+}
+
+// ReorderSeriesCalls gets all the calls that were made to ReorderSeries.
+// Check the length with:
+//     len(mockedTxQuerent.ReorderSeriesCalls())
+func (mock *TxQuerentMock) ReorderSeriesCalls() []struct {
+	Ctx     context.Context
+	ID      int64
+	BookIDs []int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      int64
+		BookIDs []int64
+	}
+	lockTxQuerentMockReorderSeries.RLock()
+	calls = mock.calls.ReorderSeries
+	lockTxQuerentMockReorderSeries.RUnlock()
+	return calls
+}
+
+// TransitionSubmission calls TransitionSubmissionFunc.
+func (mock *TxQuerentMock) TransitionSubmission(ctx context.Context, id int64, status string, note sql.NullString) (*sqlc.Submission, error) {
+	if mock.TransitionSubmissionFunc == nil {
+		panic("TxQuerentMock.TransitionSubmissionFunc: method is nil but TxQuerent.TransitionSubmission was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     int64
+		Status string
+		Note   sql.NullString
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Status: status,
+		Note:   note,
+	}
+	lockTxQuerentMockTransitionSubmission.Lock()
+	mock.calls.TransitionSubmission = append(mock.calls.TransitionSubmission, callInfo)
+	lockTxQuerentMockTransitionSubmission.Unlock()
+	return mock.TransitionSubmissionFunc(ctx, id, status, note)
+}
+
+// TransitionSubmissionCalls gets all the calls that were made to TransitionSubmission.
+// Check the length with:
+//     len(mockedTxQuerent.TransitionSubmissionCalls())
+func (mock *TxQuerentMock) TransitionSubmissionCalls() []struct {
+	Ctx    context.Context
+	ID     int64
+	Status string
+	Note   sql.NullString
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     int64
+		Status string
+		Note   sql.NullString
+	}
+	lockTxQuerentMockTransitionSubmission.RLock()
+	calls = mock.calls.TransitionSubmission
+	lockTxQuerentMockTransitionSubmission.RUnlock()
+	return calls
+}
+
+// UpdateAuthor calls UpdateAuthorFunc.
+func (mock *TxQuerentMock) UpdateAuthor(ctx context.Context, args postgres.UpdateAuthorParams) (*postgres.Author, error) {
+	if mock.UpdateAuthorFunc == nil {
+		panic("TxQuerentMock.UpdateAuthorFunc: method is nil but TxQuerent.UpdateAuthor was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args postgres.UpdateAuthorParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockTxQuerentMockUpdateAuthor.Lock()
+	mock.calls.UpdateAuthor = append(mock.calls.UpdateAuthor, callInfo)
+	lockTxQuerentMockUpdateAuthor.Unlock()
+	return mock.UpdateAuthorFunc(ctx, args)
+}
+
+// UpdateAuthorCalls gets all the calls that were made to UpdateAuthor.
+// Check the length with:
+//     len(mockedTxQuerent.UpdateAuthorCalls())
+func (mock *TxQuerentMock) UpdateAuthorCalls() []struct {
+	Ctx  context.Context
+	Args postgres.UpdateAuthorParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args postgres.UpdateAuthorParams
+	}
+	lockTxQuerentMockUpdateAuthor.RLock()
+	calls = mock.calls.UpdateAuthor
+	lockTxQuerentMockUpdateAuthor.RUnlock()
+	return calls
+}
+
 // UpdateBook calls UpdateBookFunc.
-func (mock *TxQuerentMock) UpdateBook(ctx context.Context, bookArgs sqlc.UpdateBookParams, authorIDs []int64) (*sqlc.Book, error) {
+func (mock *TxQuerentMock) UpdateBook(ctx context.Context, bookArgs sqlc.UpdateBookParams, contributors []postgres.Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
 	if mock.UpdateBookFunc == nil {
 		panic("TxQuerentMock.UpdateBookFunc: method is nil but TxQuerent.UpdateBook was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		BookArgs  sqlc.UpdateBookParams
-		AuthorIDs []int64
+		Ctx          context.Context
+		BookArgs     sqlc.UpdateBookParams
+		Contributors []postgres.Contributor
+		GenreIDs     []int64
+		Tags         []string
 	}{
-		Ctx:       ctx,
-		BookArgs:  bookArgs,
-		AuthorIDs: authorIDs,
+		Ctx:          ctx,
+		BookArgs:     bookArgs,
+		Contributors: contributors,
+		GenreIDs:     genreIDs,
+		Tags:         tags,
 	}
 	lockTxQuerentMockUpdateBook.Lock()
 	mock.calls.UpdateBook = append(mock.calls.UpdateBook, callInfo)
 	lockTxQuerentMockUpdateBook.Unlock()
-	return mock.UpdateBookFunc(ctx, bookArgs, authorIDs)
+	return mock.UpdateBookFunc(ctx, bookArgs, contributors, genreIDs, tags)
 }
 
 // UpdateBookCalls gets all the calls that were made to UpdateBook.
 // Check the length with:
 //     len(mockedTxQuerent.UpdateBookCalls())
 func (mock *TxQuerentMock) UpdateBookCalls() []struct {
-	Ctx       context.Context
-	BookArgs  sqlc.UpdateBookParams
-	AuthorIDs []int64
+	Ctx          context.Context
+	BookArgs     sqlc.UpdateBookParams
+	Contributors []postgres.Contributor
+	GenreIDs     []int64
+	Tags         []string
 } {
 	var calls []struct {
-		Ctx       context.Context
-		BookArgs  sqlc.UpdateBookParams
-		AuthorIDs []int64
+		Ctx          context.Context
+		BookArgs     sqlc.UpdateBookParams
+		Contributors []postgres.Contributor
+		GenreIDs     []int64
+		Tags         []string
 	}
 	lockTxQuerentMockUpdateBook.RLock()
 	calls = mock.calls.UpdateBook
 	lockTxQuerentMockUpdateBook.RUnlock()
 	return calls
 }
+
+// UpdateBooks calls UpdateBooksFunc.
+func (mock *TxQuerentMock) UpdateBooks(ctx context.Context, args []postgres.BulkUpdateBookArgs, mode postgres.BulkMode) (*postgres.BulkBooksResult, error) {
+	if mock.UpdateBooksFunc == nil {
+		panic("TxQuerentMock.UpdateBooksFunc: method is nil but TxQuerent.UpdateBooks was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args []postgres.BulkUpdateBookArgs
+		Mode postgres.BulkMode
+	}{
+		Ctx:  ctx,
+		Args: args,
+		Mode: mode,
+	}
+	lockTxQuerentMockUpdateBooks.Lock()
+	mock.calls.UpdateBooks = append(mock.calls.UpdateBooks, callInfo)
+	lockTxQuerentMockUpdateBooks.Unlock()
+	return mock.UpdateBooksFunc(ctx, args, mode)
+}
+
+// UpdateBooksCalls gets all the calls that were made to UpdateBooks.
+// Check the length with:
+//     len(mockedTxQuerent.UpdateBooksCalls())
+func (mock *TxQuerentMock) UpdateBooksCalls() []struct {
+	Ctx  context.Context
+	Args []postgres.BulkUpdateBookArgs
+	Mode postgres.BulkMode
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args []postgres.BulkUpdateBookArgs
+		Mode postgres.BulkMode
+	}
+	lockTxQuerentMockUpdateBooks.RLock()
+	calls = mock.calls.UpdateBooks
+	lockTxQuerentMockUpdateBooks.RUnlock()
+	return calls
+}
+
+// UpdateGenre calls UpdateGenreFunc.
+func (mock *TxQuerentMock) UpdateGenre(ctx context.Context, args sqlc.UpdateGenreParams) (*sqlc.Genre, error) {
+	if mock.UpdateGenreFunc == nil {
+		panic("TxQuerentMock.UpdateGenreFunc: method is nil but TxQuerent.UpdateGenre was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Args sqlc.UpdateGenreParams
+	}{
+		Ctx:  ctx,
+		Args: args,
+	}
+	lockTxQuerentMockUpdateGenre.Lock()
+	mock.calls.UpdateGenre = append(mock.calls.UpdateGenre, callInfo)
+	lockTxQuerentMockUpdateGenre.Unlock()
+	return mock.UpdateGenreFunc(ctx, args)
+}
+
+// UpdateGenreCalls gets all the calls that were made to UpdateGenre.
+// Check the length with:
+//     len(mockedTxQuerent.UpdateGenreCalls())
+func (mock *TxQuerentMock) UpdateGenreCalls() []struct {
+	Ctx  context.Context
+	Args sqlc.UpdateGenreParams
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Args sqlc.UpdateGenreParams
+	}
+	lockTxQuerentMockUpdateGenre.RLock()
+	calls = mock.calls.UpdateGenre
+	lockTxQuerentMockUpdateGenre.RUnlock()
+	return calls
+}
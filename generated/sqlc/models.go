@@ -4,30 +4,179 @@ package sqlc
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
+type Agency struct {
+	ID   int64
+	Name string
+}
+
 type Agent struct {
-	ID    int64
-	Name  string
-	Email string
+	ID        int64
+	Name      string
+	Email     string
+	AgencyID  sql.NullInt64
+	UpdatedAt time.Time
+	DeletedAt sql.NullTime
 }
 
 type Author struct {
-	ID      int64
-	Name    string
-	Website sql.NullString
-	AgentID int64
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	UpdatedAt time.Time
 }
 
 type Book struct {
-	ID          int64
-	Title       string
-	Description string
-	Cover       string
+	ID             int64
+	Title          string
+	Description    string
+	Cover          string
+	PublisherID    sql.NullInt64
+	SeriesID       sql.NullInt64
+	SeriesPosition sql.NullInt32
+	Isbn           sql.NullString
+	PublishedOn    sql.NullTime
+	PageCount      sql.NullInt32
+	Language       sql.NullString
+	UpdatedAt      time.Time
 }
 
 type BookAuthor struct {
 	ID       int64
 	BookID   int64
 	AuthorID int64
+	Role     string
+	Position int32
+}
+
+type BookGenre struct {
+	ID      int64
+	BookID  int64
+	GenreID int64
+}
+
+type BookTag struct {
+	ID     int64
+	BookID int64
+	Tag    string
+}
+
+type Deal struct {
+	ID              int64
+	BookID          int64
+	PublisherID     int64
+	AgentID         int64
+	Territory       string
+	Language        string
+	Format          string
+	AdvanceAmount   int64
+	AdvanceCurrency string
+	SignedOn        sql.NullTime
+	Status          string
+	RoyaltyRate     int32
+	CommissionRate  int32
+}
+
+type Deletion struct {
+	Entity    string
+	ID        int64
+	DeletedAt time.Time
+}
+
+type Edition struct {
+	ID            int64
+	BookID        int64
+	Format        string
+	Isbn          sql.NullString
+	PublishedOn   sql.NullTime
+	PriceAmount   sql.NullInt64
+	PriceCurrency sql.NullString
+}
+
+type Genre struct {
+	ID       int64
+	Name     string
+	ParentID sql.NullInt64
+}
+
+type Publisher struct {
+	ID   int64
+	Name string
+}
+
+type Representation struct {
+	ID        int64
+	AuthorID  int64
+	AgentID   int64
+	Territory string
+	StartedAt time.Time
+	EndedAt   sql.NullTime
+	IsPrimary bool
+}
+
+type RoyaltyRate struct {
+	ID        int64
+	DealID    int64
+	EditionID int64
+	Rate      int32
+}
+
+type Sale struct {
+	ID              int64
+	DealID          int64
+	EditionID       int64
+	Period          string
+	Units           int32
+	RevenueAmount   int64
+	RevenueCurrency string
+}
+
+type Series struct {
+	ID    int64
+	Title string
+}
+
+type Submission struct {
+	ID            int64
+	AgentID       int64
+	AuthorName    string
+	AuthorWebsite sql.NullString
+	Title         string
+	Synopsis      string
+	Status        string
+	ReceivedAt    time.Time
+	BookID        sql.NullInt64
+}
+
+type SubmissionTransition struct {
+	ID             int64
+	SubmissionID   int64
+	FromStatus     string
+	ToStatus       string
+	Note           sql.NullString
+	TransitionedAt time.Time
+}
+
+type Webhook struct {
+	ID         int64
+	Url        string
+	Secret     string
+	EventTypes []string
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int32
+	ResponseStatus sql.NullInt32
+	LastError      sql.NullString
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	DeliveredAt    sql.NullTime
 }
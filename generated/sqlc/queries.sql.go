@@ -6,176 +6,198 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
 )
 
-const createAgent = `-- name: CreateAgent :one
-INSERT INTO agents (name, email)
-VALUES ($1, $2)
-RETURNING id, name, email
+const agentHasHistory = `-- name: AgentHasHistory :one
+SELECT EXISTS (SELECT 1 FROM representations WHERE agent_id = $1)
+    OR EXISTS (SELECT 1 FROM deals WHERE agent_id = $1)
 `
 
-type CreateAgentParams struct {
-	Name  string
-	Email string
-}
-
-func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent, error) {
-	row := q.db.QueryRowContext(ctx, createAgent, arg.Name, arg.Email)
-	var i Agent
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
-	return i, err
+func (q *Queries) AgentHasHistory(ctx context.Context, agentID int64) (bool, error) {
+	row := q.db.QueryRowContext(ctx, agentHasHistory, agentID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
 }
 
-const createAuthor = `-- name: CreateAuthor :one
-INSERT INTO authors (name, website, agent_id)
-VALUES ($1, $2, $3)
-RETURNING id, name, website, agent_id
+const allowOrphanedBooks = `-- name: AllowOrphanedBooks :exec
+SELECT set_config('litag.allow_orphaned_books', 'on', true)
 `
 
-type CreateAuthorParams struct {
-	Name    string
-	Website sql.NullString
-	AgentID int64
+func (q *Queries) AllowOrphanedBooks(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, allowOrphanedBooks)
+	return err
 }
 
-func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error) {
-	row := q.db.QueryRowContext(ctx, createAuthor, arg.Name, arg.Website, arg.AgentID)
-	var i Author
+const archiveAgent = `-- name: ArchiveAgent :one
+UPDATE agents
+SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, email, agency_id, updated_at, deleted_at
+`
+
+func (q *Queries) ArchiveAgent(ctx context.Context, id int64) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, archiveAgent, id)
+	var i Agent
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Website,
-		&i.AgentID,
+		&i.Email,
+		&i.AgencyID,
+		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const createBook = `-- name: CreateBook :one
-INSERT INTO books (title, description, cover)
-VALUES ($1, $2, $3)
-RETURNING id, title, description, cover
+const claimWebhookDeliveries = `-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET attempts = webhook_deliveries.attempts + 1, next_attempt_at = $1::timestamptz
+FROM webhooks
+WHERE webhooks.id = webhook_deliveries.webhook_id AND webhook_deliveries.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= now()
+    ORDER BY next_attempt_at
+    LIMIT $2::int
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING webhook_deliveries.id, webhook_deliveries.webhook_id, webhook_deliveries.event_type, webhook_deliveries.payload, webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.response_status, webhook_deliveries.last_error, webhook_deliveries.created_at, webhook_deliveries.next_attempt_at, webhook_deliveries.delivered_at, webhooks.url, webhooks.secret
 `
 
-type CreateBookParams struct {
-	Title       string
-	Description string
-	Cover       string
+type ClaimWebhookDeliveriesParams struct {
+	LeaseUntil time.Time
+	BatchSize  int32
 }
 
-func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, error) {
-	row := q.db.QueryRowContext(ctx, createBook, arg.Title, arg.Description, arg.Cover)
-	var i Book
-	err := row.Scan(
-		&i.ID,
-		&i.Title,
-		&i.Description,
-		&i.Cover,
-	)
-	return i, err
+type ClaimWebhookDeliveriesRow struct {
+	ID             int64
+	WebhookID      int64
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int32
+	ResponseStatus sql.NullInt32
+	LastError      sql.NullString
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	DeliveredAt    sql.NullTime
+	Url            string
+	Secret         string
 }
 
-const deleteAgent = `-- name: DeleteAgent :one
-DELETE FROM agents
-WHERE id = $1
-RETURNING id, name, email
-`
-
-func (q *Queries) DeleteAgent(ctx context.Context, id int64) (Agent, error) {
-	row := q.db.QueryRowContext(ctx, deleteAgent, id)
-	var i Agent
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
-	return i, err
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, claimWebhookDeliveries, arg.LeaseUntil, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimWebhookDeliveriesRow
+	for rows.Next() {
+		var i ClaimWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const deleteAuthor = `-- name: DeleteAuthor :one
-DELETE FROM authors
+const completeWebhookDelivery = `-- name: CompleteWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = 'delivered', response_status = $2, last_error = NULL, delivered_at = now()
 WHERE id = $1
-RETURNING id, name, website, agent_id
 `
 
-func (q *Queries) DeleteAuthor(ctx context.Context, id int64) (Author, error) {
-	row := q.db.QueryRowContext(ctx, deleteAuthor, id)
-	var i Author
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Website,
-		&i.AgentID,
-	)
-	return i, err
+type CompleteWebhookDeliveryParams struct {
+	ID             int64
+	ResponseStatus sql.NullInt32
 }
 
-const deleteBook = `-- name: DeleteBook :one
-DELETE FROM books
-WHERE id = $1
-RETURNING id, title, description, cover
-`
-
-func (q *Queries) DeleteBook(ctx context.Context, id int64) (Book, error) {
-	row := q.db.QueryRowContext(ctx, deleteBook, id)
-	var i Book
-	err := row.Scan(
-		&i.ID,
-		&i.Title,
-		&i.Description,
-		&i.Cover,
-	)
-	return i, err
+func (q *Queries) CompleteWebhookDelivery(ctx context.Context, arg CompleteWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, completeWebhookDelivery, arg.ID, arg.ResponseStatus)
+	return err
 }
 
-const getAgent = `-- name: GetAgent :one
-SELECT id, name, email FROM agents
-WHERE id = $1
+const createAgency = `-- name: CreateAgency :one
+INSERT INTO agencies (name)
+VALUES ($1)
+RETURNING id, name
 `
 
-func (q *Queries) GetAgent(ctx context.Context, id int64) (Agent, error) {
-	row := q.db.QueryRowContext(ctx, getAgent, id)
-	var i Agent
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
+func (q *Queries) CreateAgency(ctx context.Context, name string) (Agency, error) {
+	row := q.db.QueryRowContext(ctx, createAgency, name)
+	var i Agency
+	err := row.Scan(&i.ID, &i.Name)
 	return i, err
 }
 
-const getAuthor = `-- name: GetAuthor :one
-SELECT id, name, website, agent_id FROM authors
-WHERE id = $1
+const createAgent = `-- name: CreateAgent :one
+INSERT INTO agents (name, email, agency_id)
+VALUES ($1, $2, $3)
+RETURNING id, name, email, agency_id, updated_at, deleted_at
 `
 
-func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
-	row := q.db.QueryRowContext(ctx, getAuthor, id)
-	var i Author
+type CreateAgentParams struct {
+	Name     string
+	Email    string
+	AgencyID sql.NullInt64
+}
+
+func (q *Queries) CreateAgent(ctx context.Context, arg CreateAgentParams) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, createAgent, arg.Name, arg.Email, arg.AgencyID)
+	var i Agent
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
-		&i.Website,
-		&i.AgentID,
+		&i.Email,
+		&i.AgencyID,
+		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const getBook = `-- name: GetBook :one
-SELECT id, title, description, cover FROM books
-WHERE id = $1
+const createAgents = `-- name: CreateAgents :many
+INSERT INTO agents (name, email, agency_id)
+SELECT u.name, u.email, NULLIF(u.agency_id, 0)
+FROM unnest($1::text[], $2::text[], $3::bigint[])
+WITH ORDINALITY AS u(name, email, agency_id, ord)
+ORDER BY u.ord
+RETURNING id, name, email, agency_id, updated_at, deleted_at
 `
 
-func (q *Queries) GetBook(ctx context.Context, id int64) (Book, error) {
-	row := q.db.QueryRowContext(ctx, getBook, id)
-	var i Book
-	err := row.Scan(
-		&i.ID,
-		&i.Title,
-		&i.Description,
-		&i.Cover,
-	)
-	return i, err
+type CreateAgentsParams struct {
+	Names     []string
+	Emails    []string
+	AgencyIds []int64
 }
 
-const listAgents = `-- name: ListAgents :many
-SELECT id, name, email FROM agents
-ORDER BY name
-`
-
-func (q *Queries) ListAgents(ctx context.Context) ([]Agent, error) {
-	rows, err := q.db.QueryContext(ctx, listAgents)
+func (q *Queries) CreateAgents(ctx context.Context, arg CreateAgentsParams) ([]Agent, error) {
+	rows, err := q.db.QueryContext(ctx, createAgents, pq.Array(arg.Names), pq.Array(arg.Emails), pq.Array(arg.AgencyIds))
 	if err != nil {
 		return nil, err
 	}
@@ -183,39 +205,13 @@ func (q *Queries) ListAgents(ctx context.Context) ([]Agent, error) {
 	var items []Agent
 	for rows.Next() {
 		var i Agent
-		if err := rows.Scan(&i.ID, &i.Name, &i.Email); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const listAuthors = `-- name: ListAuthors :many
-SELECT id, name, website, agent_id FROM authors
-ORDER BY name
-`
-
-func (q *Queries) ListAuthors(ctx context.Context) ([]Author, error) {
-	rows, err := q.db.QueryContext(ctx, listAuthors)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Author
-	for rows.Next() {
-		var i Author
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
-			&i.Website,
-			&i.AgentID,
+			&i.Email,
+			&i.AgencyID,
+			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -230,46 +226,45 @@ func (q *Queries) ListAuthors(ctx context.Context) ([]Author, error) {
 	return items, nil
 }
 
-const listAuthorsByAgentID = `-- name: ListAuthorsByAgentID :many
-SELECT authors.id, authors.name, authors.website, authors.agent_id FROM authors, agents
-WHERE agents.id = authors.agent_id AND authors.agent_id = $1
+const createAuthor = `-- name: CreateAuthor :one
+INSERT INTO authors (name, website)
+VALUES ($1, $2)
+RETURNING id, name, website, updated_at
 `
 
-func (q *Queries) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]Author, error) {
-	rows, err := q.db.QueryContext(ctx, listAuthorsByAgentID, agentID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Author
-	for rows.Next() {
-		var i Author
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Website,
-			&i.AgentID,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type CreateAuthorParams struct {
+	Name    string
+	Website sql.NullString
 }
 
-const listAuthorsByBookID = `-- name: ListAuthorsByBookID :many
-SELECT authors.id, authors.name, authors.website, authors.agent_id FROM authors, book_authors
-WHERE authors.id = book_authors.author_id AND book_authors.book_id = $1
+func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error) {
+	row := q.db.QueryRowContext(ctx, createAuthor, arg.Name, arg.Website)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createAuthors = `-- name: CreateAuthors :many
+INSERT INTO authors (name, website)
+SELECT u.name, NULLIF(u.website, '')
+FROM unnest($1::text[], $2::text[])
+WITH ORDINALITY AS u(name, website, ord)
+ORDER BY u.ord
+RETURNING id, name, website, updated_at
 `
 
-func (q *Queries) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]Author, error) {
-	rows, err := q.db.QueryContext(ctx, listAuthorsByBookID, bookID)
+type CreateAuthorsParams struct {
+	Names    []string
+	Websites []string
+}
+
+func (q *Queries) CreateAuthors(ctx context.Context, arg CreateAuthorsParams) ([]Author, error) {
+	rows, err := q.db.QueryContext(ctx, createAuthors, pq.Array(arg.Names), pq.Array(arg.Websites))
 	if err != nil {
 		return nil, err
 	}
@@ -281,7 +276,7 @@ func (q *Queries) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]Auth
 			&i.ID,
 			&i.Name,
 			&i.Website,
-			&i.AgentID,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -296,46 +291,93 @@ func (q *Queries) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]Auth
 	return items, nil
 }
 
-const listBooks = `-- name: ListBooks :many
-SELECT id, title, description, cover FROM books
-ORDER BY title
+const createBook = `-- name: CreateBook :one
+INSERT INTO books (title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at
 `
 
-func (q *Queries) ListBooks(ctx context.Context) ([]Book, error) {
-	rows, err := q.db.QueryContext(ctx, listBooks)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []Book
-	for rows.Next() {
-		var i Book
-		if err := rows.Scan(
-			&i.ID,
-			&i.Title,
-			&i.Description,
-			&i.Cover,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type CreateBookParams struct {
+	Title          string
+	Description    string
+	Cover          string
+	PublisherID    sql.NullInt64
+	SeriesID       sql.NullInt64
+	SeriesPosition sql.NullInt32
+	Isbn           sql.NullString
+	PublishedOn    sql.NullTime
+	PageCount      sql.NullInt32
+	Language       sql.NullString
 }
 
-const listBooksByAuthorID = `-- name: ListBooksByAuthorID :many
-SELECT books.id, books.title, books.description, books.cover FROM books, book_authors
-WHERE books.id = book_authors.book_id AND book_authors.author_id = $1
+func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, error) {
+	row := q.db.QueryRowContext(ctx, createBook,
+		arg.Title,
+		arg.Description,
+		arg.Cover,
+		arg.PublisherID,
+		arg.SeriesID,
+		arg.SeriesPosition,
+		arg.Isbn,
+		arg.PublishedOn,
+		arg.PageCount,
+		arg.Language,
+	)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Cover,
+		&i.PublisherID,
+		&i.SeriesID,
+		&i.SeriesPosition,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PageCount,
+		&i.Language,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createBooks = `-- name: CreateBooks :many
+INSERT INTO books (title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language)
+SELECT u.title, u.description, u.cover, NULLIF(u.publisher_id, 0), NULLIF(u.series_id, 0), NULLIF(u.series_position, 0),
+    NULLIF(u.isbn, ''), NULLIF(u.published_on, '')::date, NULLIF(u.page_count, '')::int, NULLIF(u.language, '')
+FROM unnest($1::text[], $2::text[], $3::text[], $4::bigint[], $5::bigint[], $6::int[],
+    $7::text[], $8::text[], $9::text[], $10::text[])
+WITH ORDINALITY AS u(title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, ord)
+ORDER BY u.ord
+RETURNING id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at
 `
 
-func (q *Queries) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]Book, error) {
-	rows, err := q.db.QueryContext(ctx, listBooksByAuthorID, authorID)
+type CreateBooksParams struct {
+	Titles          []string
+	Descriptions    []string
+	Covers          []string
+	PublisherIds    []int64
+	SeriesIds       []int64
+	SeriesPositions []int32
+	Isbns           []string
+	PublishedOns    []string
+	PageCounts      []string
+	Languages       []string
+}
+
+func (q *Queries) CreateBooks(ctx context.Context, arg CreateBooksParams) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, createBooks,
+		pq.Array(arg.Titles),
+		pq.Array(arg.Descriptions),
+		pq.Array(arg.Covers),
+		pq.Array(arg.PublisherIds),
+		pq.Array(arg.SeriesIds),
+		pq.Array(arg.SeriesPositions),
+		pq.Array(arg.Isbns),
+		pq.Array(arg.PublishedOns),
+		pq.Array(arg.PageCounts),
+		pq.Array(arg.Languages),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -348,6 +390,14 @@ func (q *Queries) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]Bo
 			&i.Title,
 			&i.Description,
 			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -362,18 +412,2938 @@ func (q *Queries) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]Bo
 	return items, nil
 }
 
-const setBookAuthor = `-- name: SetBookAuthor :exec
-INSERT INTO book_authors (book_id, author_id)
-VALUES ($1, $2)
+const createDeal = `-- name: CreateDeal :one
+INSERT INTO deals (book_id, publisher_id, agent_id, territory, language, format, advance_amount, advance_currency, signed_on, status, royalty_rate, commission_rate)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, book_id, publisher_id, agent_id, territory, language, format, advance_amount, advance_currency, signed_on, status, royalty_rate, commission_rate
+`
+
+type CreateDealParams struct {
+	BookID          int64
+	PublisherID     int64
+	AgentID         int64
+	Territory       string
+	Language        string
+	Format          string
+	AdvanceAmount   int64
+	AdvanceCurrency string
+	SignedOn        sql.NullTime
+	Status          string
+	RoyaltyRate     int32
+	CommissionRate  int32
+}
+
+func (q *Queries) CreateDeal(ctx context.Context, arg CreateDealParams) (Deal, error) {
+	row := q.db.QueryRowContext(ctx, createDeal,
+		arg.BookID,
+		arg.PublisherID,
+		arg.AgentID,
+		arg.Territory,
+		arg.Language,
+		arg.Format,
+		arg.AdvanceAmount,
+		arg.AdvanceCurrency,
+		arg.SignedOn,
+		arg.Status,
+		arg.RoyaltyRate,
+		arg.CommissionRate,
+	)
+	var i Deal
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.PublisherID,
+		&i.AgentID,
+		&i.Territory,
+		&i.Language,
+		&i.Format,
+		&i.AdvanceAmount,
+		&i.AdvanceCurrency,
+		&i.SignedOn,
+		&i.Status,
+		&i.RoyaltyRate,
+		&i.CommissionRate,
+	)
+	return i, err
+}
+
+const createEdition = `-- name: CreateEdition :one
+INSERT INTO editions (book_id, format, isbn, published_on, price_amount, price_currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, book_id, format, isbn, published_on, price_amount, price_currency
+`
+
+type CreateEditionParams struct {
+	BookID        int64
+	Format        string
+	Isbn          sql.NullString
+	PublishedOn   sql.NullTime
+	PriceAmount   sql.NullInt64
+	PriceCurrency sql.NullString
+}
+
+func (q *Queries) CreateEdition(ctx context.Context, arg CreateEditionParams) (Edition, error) {
+	row := q.db.QueryRowContext(ctx, createEdition,
+		arg.BookID,
+		arg.Format,
+		arg.Isbn,
+		arg.PublishedOn,
+		arg.PriceAmount,
+		arg.PriceCurrency,
+	)
+	var i Edition
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Format,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PriceAmount,
+		&i.PriceCurrency,
+	)
+	return i, err
+}
+
+const createGenre = `-- name: CreateGenre :one
+INSERT INTO genres (name, parent_id)
+VALUES ($1, $2)
+RETURNING id, name, parent_id
+`
+
+type CreateGenreParams struct {
+	Name     string
+	ParentID sql.NullInt64
+}
+
+func (q *Queries) CreateGenre(ctx context.Context, arg CreateGenreParams) (Genre, error) {
+	row := q.db.QueryRowContext(ctx, createGenre, arg.Name, arg.ParentID)
+	var i Genre
+	err := row.Scan(&i.ID, &i.Name, &i.ParentID)
+	return i, err
+}
+
+const createPublisher = `-- name: CreatePublisher :one
+INSERT INTO publishers (name)
+VALUES ($1)
+RETURNING id, name
+`
+
+func (q *Queries) CreatePublisher(ctx context.Context, name string) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, createPublisher, name)
+	var i Publisher
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const createRepresentation = `-- name: CreateRepresentation :one
+INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+VALUES ($1, $2, $3, $4, false)
+RETURNING id, author_id, agent_id, territory, started_at, ended_at, is_primary
+`
+
+type CreateRepresentationParams struct {
+	AuthorID  int64
+	AgentID   int64
+	Territory string
+	StartedAt time.Time
+}
+
+func (q *Queries) CreateRepresentation(ctx context.Context, arg CreateRepresentationParams) (Representation, error) {
+	row := q.db.QueryRowContext(ctx, createRepresentation,
+		arg.AuthorID,
+		arg.AgentID,
+		arg.Territory,
+		arg.StartedAt,
+	)
+	var i Representation
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.AgentID,
+		&i.Territory,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.IsPrimary,
+	)
+	return i, err
+}
+
+const createSeries = `-- name: CreateSeries :one
+INSERT INTO series (title)
+VALUES ($1)
+RETURNING id, title
+`
+
+func (q *Queries) CreateSeries(ctx context.Context, title string) (Series, error) {
+	row := q.db.QueryRowContext(ctx, createSeries, title)
+	var i Series
+	err := row.Scan(&i.ID, &i.Title)
+	return i, err
+}
+
+const createSubmission = `-- name: CreateSubmission :one
+INSERT INTO submissions (agent_id, author_name, author_website, title, synopsis)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, agent_id, author_name, author_website, title, synopsis, status, received_at, book_id
+`
+
+type CreateSubmissionParams struct {
+	AgentID       int64
+	AuthorName    string
+	AuthorWebsite sql.NullString
+	Title         string
+	Synopsis      string
+}
+
+func (q *Queries) CreateSubmission(ctx context.Context, arg CreateSubmissionParams) (Submission, error) {
+	row := q.db.QueryRowContext(ctx, createSubmission,
+		arg.AgentID,
+		arg.AuthorName,
+		arg.AuthorWebsite,
+		arg.Title,
+		arg.Synopsis,
+	)
+	var i Submission
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.AuthorName,
+		&i.AuthorWebsite,
+		&i.Title,
+		&i.Synopsis,
+		&i.Status,
+		&i.ReceivedAt,
+		&i.BookID,
+	)
+	return i, err
+}
+
+const createSubmissionTransition = `-- name: CreateSubmissionTransition :one
+INSERT INTO submission_transitions (submission_id, from_status, to_status, note)
+VALUES ($1, $2, $3, $4)
+RETURNING id, submission_id, from_status, to_status, note, transitioned_at
+`
+
+type CreateSubmissionTransitionParams struct {
+	SubmissionID int64
+	FromStatus   string
+	ToStatus     string
+	Note         sql.NullString
+}
+
+func (q *Queries) CreateSubmissionTransition(ctx context.Context, arg CreateSubmissionTransitionParams) (SubmissionTransition, error) {
+	row := q.db.QueryRowContext(ctx, createSubmissionTransition,
+		arg.SubmissionID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Note,
+	)
+	var i SubmissionTransition
+	err := row.Scan(
+		&i.ID,
+		&i.SubmissionID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Note,
+		&i.TransitionedAt,
+	)
+	return i, err
+}
+
+const createWebhook = `-- name: CreateWebhook :one
+INSERT INTO webhooks (url, secret, event_types)
+VALUES ($1, $2, $3)
+RETURNING id, url, secret, event_types
+`
+
+type CreateWebhookParams struct {
+	Url        string
+	Secret     string
+	EventTypes []string
+}
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, createWebhook, arg.Url, arg.Secret, pq.Array(arg.EventTypes))
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
+	)
+	return i, err
+}
+
+const deferSeriesPositions = `-- name: DeferSeriesPositions :exec
+SET CONSTRAINTS books_series_position_key DEFERRED
+`
+
+func (q *Queries) DeferSeriesPositions(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deferSeriesPositions)
+	return err
+}
+
+const deleteAgency = `-- name: DeleteAgency :one
+DELETE FROM agencies
+WHERE id = $1
+RETURNING id, name
+`
+
+func (q *Queries) DeleteAgency(ctx context.Context, id int64) (Agency, error) {
+	row := q.db.QueryRowContext(ctx, deleteAgency, id)
+	var i Agency
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const deleteAgent = `-- name: DeleteAgent :one
+DELETE FROM agents
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, email, agency_id, updated_at, deleted_at
+`
+
+func (q *Queries) DeleteAgent(ctx context.Context, id int64) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, deleteAgent, id)
+	var i Agent
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.AgencyID,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deleteAuthor = `-- name: DeleteAuthor :one
+DELETE FROM authors
+WHERE id = $1
+RETURNING id, name, website, updated_at
+`
+
+func (q *Queries) DeleteAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, deleteAuthor, id)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteBook = `-- name: DeleteBook :one
+DELETE FROM books
+WHERE id = $1
+RETURNING id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at
+`
+
+func (q *Queries) DeleteBook(ctx context.Context, id int64) (Book, error) {
+	row := q.db.QueryRowContext(ctx, deleteBook, id)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Cover,
+		&i.PublisherID,
+		&i.SeriesID,
+		&i.SeriesPosition,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PageCount,
+		&i.Language,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteDeal = `-- name: DeleteDeal :one
+DELETE FROM deals
+WHERE id = $1
+RETURNING id, book_id, publisher_id, agent_id, territory, language, format, advance_amount, advance_currency, signed_on, status, royalty_rate, commission_rate
+`
+
+func (q *Queries) DeleteDeal(ctx context.Context, id int64) (Deal, error) {
+	row := q.db.QueryRowContext(ctx, deleteDeal, id)
+	var i Deal
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.PublisherID,
+		&i.AgentID,
+		&i.Territory,
+		&i.Language,
+		&i.Format,
+		&i.AdvanceAmount,
+		&i.AdvanceCurrency,
+		&i.SignedOn,
+		&i.Status,
+		&i.RoyaltyRate,
+		&i.CommissionRate,
+	)
+	return i, err
+}
+
+const deleteEdition = `-- name: DeleteEdition :one
+DELETE FROM editions
+WHERE id = $1
+RETURNING id, book_id, format, isbn, published_on, price_amount, price_currency
+`
+
+func (q *Queries) DeleteEdition(ctx context.Context, id int64) (Edition, error) {
+	row := q.db.QueryRowContext(ctx, deleteEdition, id)
+	var i Edition
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Format,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PriceAmount,
+		&i.PriceCurrency,
+	)
+	return i, err
+}
+
+const deleteGenre = `-- name: DeleteGenre :one
+DELETE FROM genres
+WHERE id = $1
+RETURNING id, name, parent_id
+`
+
+func (q *Queries) DeleteGenre(ctx context.Context, id int64) (Genre, error) {
+	row := q.db.QueryRowContext(ctx, deleteGenre, id)
+	var i Genre
+	err := row.Scan(&i.ID, &i.Name, &i.ParentID)
+	return i, err
+}
+
+const deletePublisher = `-- name: DeletePublisher :one
+DELETE FROM publishers
+WHERE id = $1
+RETURNING id, name
+`
+
+func (q *Queries) DeletePublisher(ctx context.Context, id int64) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, deletePublisher, id)
+	var i Publisher
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const deleteRoyaltyRate = `-- name: DeleteRoyaltyRate :one
+DELETE FROM royalty_rates
+WHERE deal_id = $1 AND edition_id = $2
+RETURNING id, deal_id, edition_id, rate
+`
+
+type DeleteRoyaltyRateParams struct {
+	DealID    int64
+	EditionID int64
+}
+
+func (q *Queries) DeleteRoyaltyRate(ctx context.Context, arg DeleteRoyaltyRateParams) (RoyaltyRate, error) {
+	row := q.db.QueryRowContext(ctx, deleteRoyaltyRate, arg.DealID, arg.EditionID)
+	var i RoyaltyRate
+	err := row.Scan(
+		&i.ID,
+		&i.DealID,
+		&i.EditionID,
+		&i.Rate,
+	)
+	return i, err
+}
+
+const deleteSales = `-- name: DeleteSales :one
+DELETE FROM sales
+WHERE id = $1
+RETURNING id, deal_id, edition_id, period, units, revenue_amount, revenue_currency
+`
+
+func (q *Queries) DeleteSales(ctx context.Context, id int64) (Sale, error) {
+	row := q.db.QueryRowContext(ctx, deleteSales, id)
+	var i Sale
+	err := row.Scan(
+		&i.ID,
+		&i.DealID,
+		&i.EditionID,
+		&i.Period,
+		&i.Units,
+		&i.RevenueAmount,
+		&i.RevenueCurrency,
+	)
+	return i, err
+}
+
+const deleteSeries = `-- name: DeleteSeries :one
+DELETE FROM series
+WHERE id = $1
+RETURNING id, title
+`
+
+func (q *Queries) DeleteSeries(ctx context.Context, id int64) (Series, error) {
+	row := q.db.QueryRowContext(ctx, deleteSeries, id)
+	var i Series
+	err := row.Scan(&i.ID, &i.Title)
+	return i, err
+}
+
+const deleteSubmissionsByAgentID = `-- name: DeleteSubmissionsByAgentID :exec
+DELETE FROM submissions
+WHERE agent_id = $1
+`
+
+func (q *Queries) DeleteSubmissionsByAgentID(ctx context.Context, agentID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteSubmissionsByAgentID, agentID)
+	return err
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :one
+DELETE FROM webhooks
+WHERE id = $1
+RETURNING id, url, secret, event_types
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, id int64) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, deleteWebhook, id)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
+	)
+	return i, err
+}
+
+const endRepresentation = `-- name: EndRepresentation :one
+UPDATE representations
+SET ended_at = $1::date
+WHERE id = $2
+RETURNING id, author_id, agent_id, territory, started_at, ended_at, is_primary
+`
+
+type EndRepresentationParams struct {
+	EndedAt time.Time
+	ID      int64
+}
+
+func (q *Queries) EndRepresentation(ctx context.Context, arg EndRepresentationParams) (Representation, error) {
+	row := q.db.QueryRowContext(ctx, endRepresentation, arg.EndedAt, arg.ID)
+	var i Representation
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.AgentID,
+		&i.Territory,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.IsPrimary,
+	)
+	return i, err
+}
+
+const endRepresentationsByAgentID = `-- name: EndRepresentationsByAgentID :exec
+UPDATE representations
+SET ended_at = GREATEST(started_at, CURRENT_DATE)
+WHERE agent_id = $1 AND ended_at IS NULL
+`
+
+func (q *Queries) EndRepresentationsByAgentID(ctx context.Context, agentID int64) error {
+	_, err := q.db.ExecContext(ctx, endRepresentationsByAgentID, agentID)
+	return err
+}
+
+const enqueueWebhookDeliveries = `-- name: EnqueueWebhookDeliveries :exec
+INSERT INTO webhook_deliveries (webhook_id, event_type, payload)
+SELECT webhooks.id, $1::text, $2::jsonb FROM webhooks
+WHERE $1::text = ANY(webhooks.event_types)
+`
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventType string
+	Payload   json.RawMessage
+}
+
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) error {
+	_, err := q.db.ExecContext(ctx, enqueueWebhookDeliveries, arg.EventType, arg.Payload)
+	return err
+}
+
+const failWebhookDelivery = `-- name: FailWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = $2, response_status = $3, last_error = $4, next_attempt_at = $5
+WHERE id = $1
+`
+
+type FailWebhookDeliveryParams struct {
+	ID             int64
+	Status         string
+	ResponseStatus sql.NullInt32
+	LastError      sql.NullString
+	NextAttemptAt  time.Time
+}
+
+func (q *Queries) FailWebhookDelivery(ctx context.Context, arg FailWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, failWebhookDelivery,
+		arg.ID,
+		arg.Status,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.NextAttemptAt,
+	)
+	return err
+}
+
+const getAgency = `-- name: GetAgency :one
+SELECT id, name FROM agencies
+WHERE id = $1
+`
+
+func (q *Queries) GetAgency(ctx context.Context, id int64) (Agency, error) {
+	row := q.db.QueryRowContext(ctx, getAgency, id)
+	var i Agency
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const getAgent = `-- name: GetAgent :one
+SELECT id, name, email, agency_id, updated_at, deleted_at FROM agents
+WHERE id = $1
+`
+
+func (q *Queries) GetAgent(ctx context.Context, id int64) (Agent, error) {
+	row := q.db.QueryRowContext(ctx, getAgent, id)
+	var i Agent
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.AgencyID,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT authors.id, authors.name, authors.website, authors.updated_at, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+WHERE authors.id = $1
+`
+
+type GetAuthorRow struct {
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	UpdatedAt time.Time
+	AgentID   int64
+}
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (GetAuthorRow, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i GetAuthorRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Website,
+		&i.UpdatedAt,
+		&i.AgentID,
+	)
+	return i, err
+}
+
+const getBook = `-- name: GetBook :one
+SELECT id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at FROM books
+WHERE id = $1
+`
+
+func (q *Queries) GetBook(ctx context.Context, id int64) (Book, error) {
+	row := q.db.QueryRowContext(ctx, getBook, id)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Cover,
+		&i.PublisherID,
+		&i.SeriesID,
+		&i.SeriesPosition,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PageCount,
+		&i.Language,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getBookByISBN = `-- name: GetBookByISBN :one
+SELECT id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at FROM books
+WHERE isbn = $1::text
+`
+
+func (q *Queries) GetBookByISBN(ctx context.Context, isbn string) (Book, error) {
+	row := q.db.QueryRowContext(ctx, getBookByISBN, isbn)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Cover,
+		&i.PublisherID,
+		&i.SeriesID,
+		&i.SeriesPosition,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PageCount,
+		&i.Language,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getDeal = `-- name: GetDeal :one
+SELECT id, book_id, publisher_id, agent_id, territory, language, format, advance_amount, advance_currency, signed_on, status, royalty_rate, commission_rate FROM deals
+WHERE id = $1
+`
+
+func (q *Queries) GetDeal(ctx context.Context, id int64) (Deal, error) {
+	row := q.db.QueryRowContext(ctx, getDeal, id)
+	var i Deal
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.PublisherID,
+		&i.AgentID,
+		&i.Territory,
+		&i.Language,
+		&i.Format,
+		&i.AdvanceAmount,
+		&i.AdvanceCurrency,
+		&i.SignedOn,
+		&i.Status,
+		&i.RoyaltyRate,
+		&i.CommissionRate,
+	)
+	return i, err
+}
+
+const getEdition = `-- name: GetEdition :one
+SELECT id, book_id, format, isbn, published_on, price_amount, price_currency FROM editions
+WHERE id = $1
+`
+
+func (q *Queries) GetEdition(ctx context.Context, id int64) (Edition, error) {
+	row := q.db.QueryRowContext(ctx, getEdition, id)
+	var i Edition
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Format,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PriceAmount,
+		&i.PriceCurrency,
+	)
+	return i, err
+}
+
+const getEditionByISBN = `-- name: GetEditionByISBN :one
+SELECT id, book_id, format, isbn, published_on, price_amount, price_currency FROM editions
+WHERE isbn = $1::text
+`
+
+func (q *Queries) GetEditionByISBN(ctx context.Context, isbn string) (Edition, error) {
+	row := q.db.QueryRowContext(ctx, getEditionByISBN, isbn)
+	var i Edition
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Format,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PriceAmount,
+		&i.PriceCurrency,
+	)
+	return i, err
+}
+
+const getGenre = `-- name: GetGenre :one
+SELECT id, name, parent_id FROM genres
+WHERE id = $1
+`
+
+func (q *Queries) GetGenre(ctx context.Context, id int64) (Genre, error) {
+	row := q.db.QueryRowContext(ctx, getGenre, id)
+	var i Genre
+	err := row.Scan(&i.ID, &i.Name, &i.ParentID)
+	return i, err
+}
+
+const getPublisher = `-- name: GetPublisher :one
+SELECT id, name FROM publishers
+WHERE id = $1
+`
+
+func (q *Queries) GetPublisher(ctx context.Context, id int64) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, getPublisher, id)
+	var i Publisher
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const getRepresentation = `-- name: GetRepresentation :one
+SELECT id, author_id, agent_id, territory, started_at, ended_at, is_primary FROM representations
+WHERE id = $1
+`
+
+func (q *Queries) GetRepresentation(ctx context.Context, id int64) (Representation, error) {
+	row := q.db.QueryRowContext(ctx, getRepresentation, id)
+	var i Representation
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.AgentID,
+		&i.Territory,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.IsPrimary,
+	)
+	return i, err
+}
+
+const getSeries = `-- name: GetSeries :one
+SELECT id, title FROM series
+WHERE id = $1
+`
+
+func (q *Queries) GetSeries(ctx context.Context, id int64) (Series, error) {
+	row := q.db.QueryRowContext(ctx, getSeries, id)
+	var i Series
+	err := row.Scan(&i.ID, &i.Title)
+	return i, err
+}
+
+const getSeriesForUpdate = `-- name: GetSeriesForUpdate :one
+SELECT id, title FROM series
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetSeriesForUpdate(ctx context.Context, id int64) (Series, error) {
+	row := q.db.QueryRowContext(ctx, getSeriesForUpdate, id)
+	var i Series
+	err := row.Scan(&i.ID, &i.Title)
+	return i, err
+}
+
+const getSubmission = `-- name: GetSubmission :one
+SELECT id, agent_id, author_name, author_website, title, synopsis, status, received_at, book_id FROM submissions
+WHERE id = $1
+`
+
+func (q *Queries) GetSubmission(ctx context.Context, id int64) (Submission, error) {
+	row := q.db.QueryRowContext(ctx, getSubmission, id)
+	var i Submission
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.AuthorName,
+		&i.AuthorWebsite,
+		&i.Title,
+		&i.Synopsis,
+		&i.Status,
+		&i.ReceivedAt,
+		&i.BookID,
+	)
+	return i, err
+}
+
+const getSubmissionForUpdate = `-- name: GetSubmissionForUpdate :one
+SELECT id, agent_id, author_name, author_website, title, synopsis, status, received_at, book_id FROM submissions
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetSubmissionForUpdate(ctx context.Context, id int64) (Submission, error) {
+	row := q.db.QueryRowContext(ctx, getSubmissionForUpdate, id)
+	var i Submission
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.AuthorName,
+		&i.AuthorWebsite,
+		&i.Title,
+		&i.Synopsis,
+		&i.Status,
+		&i.ReceivedAt,
+		&i.BookID,
+	)
+	return i, err
+}
+
+const getWebhook = `-- name: GetWebhook :one
+SELECT id, url, secret, event_types FROM webhooks
+WHERE id = $1
+`
+
+func (q *Queries) GetWebhook(ctx context.Context, id int64) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, getWebhook, id)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
+	)
+	return i, err
+}
+
+const listAgencies = `-- name: ListAgencies :many
+SELECT id, name FROM agencies
+ORDER BY name
+`
+
+func (q *Queries) ListAgencies(ctx context.Context) ([]Agency, error) {
+	rows, err := q.db.QueryContext(ctx, listAgencies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Agency
+	for rows.Next() {
+		var i Agency
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAgents = `-- name: ListAgents :many
+SELECT id, name, email, agency_id, updated_at, deleted_at FROM agents
+WHERE deleted_at IS NULL
+ORDER BY name
+`
+
+func (q *Queries) ListAgents(ctx context.Context) ([]Agent, error) {
+	rows, err := q.db.QueryContext(ctx, listAgents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Agent
+	for rows.Next() {
+		var i Agent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.AgencyID,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAgentsByAgencyID = `-- name: ListAgentsByAgencyID :many
+SELECT id, name, email, agency_id, updated_at, deleted_at FROM agents
+WHERE agency_id = $1::bigint AND deleted_at IS NULL
+ORDER BY name
+`
+
+func (q *Queries) ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]Agent, error) {
+	rows, err := q.db.QueryContext(ctx, listAgentsByAgencyID, agencyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Agent
+	for rows.Next() {
+		var i Agent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.AgencyID,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT authors.id, authors.name, authors.website, authors.updated_at, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+ORDER BY authors.name
+`
+
+type ListAuthorsRow struct {
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	UpdatedAt time.Time
+	AgentID   int64
+}
+
+func (q *Queries) ListAuthors(ctx context.Context) ([]ListAuthorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthors)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsRow
+	for rows.Next() {
+		var i ListAuthorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Website,
+			&i.UpdatedAt,
+			&i.AgentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByAgencyID = `-- name: ListAuthorsByAgencyID :many
+SELECT authors.id, authors.name, authors.website, authors.updated_at, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+JOIN agents ON agents.id = representations.agent_id
+WHERE agents.agency_id = $1::bigint
+ORDER BY authors.name
+`
+
+type ListAuthorsByAgencyIDRow struct {
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	UpdatedAt time.Time
+	AgentID   int64
+}
+
+func (q *Queries) ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]ListAuthorsByAgencyIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByAgencyID, agencyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsByAgencyIDRow
+	for rows.Next() {
+		var i ListAuthorsByAgencyIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Website,
+			&i.UpdatedAt,
+			&i.AgentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByAgentID = `-- name: ListAuthorsByAgentID :many
+SELECT authors.id, authors.name, authors.website, authors.updated_at, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+WHERE representations.agent_id = $1
+ORDER BY authors.id
+`
+
+type ListAuthorsByAgentIDRow struct {
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	UpdatedAt time.Time
+	AgentID   int64
+}
+
+func (q *Queries) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]ListAuthorsByAgentIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByAgentID, agentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsByAgentIDRow
+	for rows.Next() {
+		var i ListAuthorsByAgentIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Website,
+			&i.UpdatedAt,
+			&i.AgentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByBookID = `-- name: ListAuthorsByBookID :many
+SELECT authors.id, authors.name, authors.website, authors.updated_at, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+JOIN book_authors ON book_authors.author_id = authors.id
+WHERE book_authors.book_id = $1
+ORDER BY book_authors.position, book_authors.id
+`
+
+type ListAuthorsByBookIDRow struct {
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	UpdatedAt time.Time
+	AgentID   int64
+}
+
+func (q *Queries) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]ListAuthorsByBookIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsByBookIDRow
+	for rows.Next() {
+		var i ListAuthorsByBookIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Website,
+			&i.UpdatedAt,
+			&i.AgentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAvailableRights = `-- name: ListAvailableRights :many
+SELECT books.id AS book_id, formats.format::text AS format
+FROM books, unnest(ARRAY['HARDCOVER', 'PAPERBACK', 'EBOOK', 'AUDIOBOOK']) WITH ORDINALITY AS formats(format, ord)
+WHERE NOT EXISTS (
+    SELECT 1 FROM deals
+    WHERE deals.book_id = books.id AND deals.format = formats.format AND deals.status <> 'TERMINATED'
+        AND (deals.territory IN ('WORLD', $1::text) OR $1::text = 'WORLD')
+        AND deals.language = $2::text
+)
+ORDER BY books.title, books.id, formats.ord
+`
+
+type ListAvailableRightsParams struct {
+	Territory string
+	Language  string
+}
+
+type ListAvailableRightsRow struct {
+	BookID int64
+	Format string
+}
+
+func (q *Queries) ListAvailableRights(ctx context.Context, arg ListAvailableRightsParams) ([]ListAvailableRightsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAvailableRights, arg.Territory, arg.Language)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAvailableRightsRow
+	for rows.Next() {
+		var i ListAvailableRightsRow
+		if err := rows.Scan(&i.BookID, &i.Format); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooks = `-- name: ListBooks :many
+SELECT id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at FROM books
+ORDER BY title
+`
+
+func (q *Queries) ListBooks(ctx context.Context) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByAgencyID = `-- name: ListBooksByAgencyID :many
+SELECT id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at FROM books
+WHERE EXISTS (
+    SELECT 1 FROM book_authors, representations, agents
+    WHERE book_authors.book_id = books.id AND representations.author_id = book_authors.author_id
+        AND representations.is_primary AND representations.ended_at IS NULL
+        AND agents.id = representations.agent_id AND agents.agency_id = $1::bigint
+)
+ORDER BY title
+`
+
+func (q *Queries) ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksByAgencyID, agencyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByAuthorID = `-- name: ListBooksByAuthorID :many
+SELECT books.id, books.title, books.description, books.cover, books.publisher_id, books.series_id, books.series_position, books.isbn, books.published_on, books.page_count, books.language, books.updated_at FROM books, book_authors
+WHERE books.id = book_authors.book_id AND book_authors.author_id = $1
+`
+
+func (q *Queries) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksByAuthorID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByGenreID = `-- name: ListBooksByGenreID :many
+SELECT books.id, books.title, books.description, books.cover, books.publisher_id, books.series_id, books.series_position, books.isbn, books.published_on, books.page_count, books.language, books.updated_at FROM books, book_genres
+WHERE books.id = book_genres.book_id AND book_genres.genre_id = $1
+ORDER BY books.title
+`
+
+func (q *Queries) ListBooksByGenreID(ctx context.Context, genreID int64) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksByGenreID, genreID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByPublisherID = `-- name: ListBooksByPublisherID :many
+SELECT id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at FROM books
+WHERE publisher_id = $1::bigint
+ORDER BY title
+`
+
+func (q *Queries) ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksByPublisherID, publisherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksBySeriesID = `-- name: ListBooksBySeriesID :many
+SELECT id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at FROM books
+WHERE series_id = $1::bigint
+ORDER BY series_position
+`
+
+func (q *Queries) ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksBySeriesID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByTag = `-- name: ListBooksByTag :many
+SELECT books.id, books.title, books.description, books.cover, books.publisher_id, books.series_id, books.series_position, books.isbn, books.published_on, books.page_count, books.language, books.updated_at FROM books, book_tags
+WHERE books.id = book_tags.book_id AND book_tags.tag = $1
+ORDER BY books.title
+`
+
+func (q *Queries) ListBooksByTag(ctx context.Context, tag string) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksByTag, tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksInGenreTree = `-- name: ListBooksInGenreTree :many
+WITH RECURSIVE subgenres AS (
+    SELECT genres.id FROM genres
+    WHERE genres.id = $1::bigint
+    UNION
+    SELECT genres.id FROM genres, subgenres
+    WHERE genres.parent_id = subgenres.id
+)
+SELECT books.id, books.title, books.description, books.cover, books.publisher_id, books.series_id, books.series_position, books.isbn, books.published_on, books.page_count, books.language, books.updated_at FROM books
+WHERE EXISTS (
+    SELECT 1 FROM book_genres, subgenres
+    WHERE book_genres.book_id = books.id AND book_genres.genre_id = subgenres.id
+)
+ORDER BY books.title
+`
+
+func (q *Queries) ListBooksInGenreTree(ctx context.Context, genreID int64) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksInGenreTree, genreID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksOrphanedByAuthorID = `-- name: ListBooksOrphanedByAuthorID :many
+SELECT books.id, books.title, books.description, books.cover, books.publisher_id, books.series_id, books.series_position, books.isbn, books.published_on, books.page_count, books.language, books.updated_at FROM books, book_authors
+WHERE books.id = book_authors.book_id AND book_authors.author_id = $1
+AND NOT EXISTS (
+    SELECT 1 FROM book_authors others
+    WHERE others.book_id = books.id AND others.author_id <> $1
+)
+ORDER BY books.title
+`
+
+func (q *Queries) ListBooksOrphanedByAuthorID(ctx context.Context, authorID int64) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listBooksOrphanedByAuthorID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listContributorsByBookID = `-- name: ListContributorsByBookID :many
+SELECT id, book_id, author_id, role, position FROM book_authors
+WHERE book_id = $1
+ORDER BY position, id
+`
+
+func (q *Queries) ListContributorsByBookID(ctx context.Context, bookID int64) ([]BookAuthor, error) {
+	rows, err := q.db.QueryContext(ctx, listContributorsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BookAuthor
+	for rows.Next() {
+		var i BookAuthor
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.AuthorID,
+			&i.Role,
+			&i.Position,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDealsByAgentID = `-- name: ListDealsByAgentID :many
+SELECT id, book_id, publisher_id, agent_id, territory, language, format, advance_amount, advance_currency, signed_on, status, royalty_rate, commission_rate FROM deals
+WHERE agent_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListDealsByAgentID(ctx context.Context, agentID int64) ([]Deal, error) {
+	rows, err := q.db.QueryContext(ctx, listDealsByAgentID, agentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Deal
+	for rows.Next() {
+		var i Deal
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.PublisherID,
+			&i.AgentID,
+			&i.Territory,
+			&i.Language,
+			&i.Format,
+			&i.AdvanceAmount,
+			&i.AdvanceCurrency,
+			&i.SignedOn,
+			&i.Status,
+			&i.RoyaltyRate,
+			&i.CommissionRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDealsByBookID = `-- name: ListDealsByBookID :many
+SELECT id, book_id, publisher_id, agent_id, territory, language, format, advance_amount, advance_currency, signed_on, status, royalty_rate, commission_rate FROM deals
+WHERE book_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListDealsByBookID(ctx context.Context, bookID int64) ([]Deal, error) {
+	rows, err := q.db.QueryContext(ctx, listDealsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Deal
+	for rows.Next() {
+		var i Deal
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.PublisherID,
+			&i.AgentID,
+			&i.Territory,
+			&i.Language,
+			&i.Format,
+			&i.AdvanceAmount,
+			&i.AdvanceCurrency,
+			&i.SignedOn,
+			&i.Status,
+			&i.RoyaltyRate,
+			&i.CommissionRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEditions = `-- name: ListEditions :many
+SELECT id, book_id, format, isbn, published_on, price_amount, price_currency FROM editions
+WHERE ($1::bigint = 0 OR book_id = $1::bigint)
+AND ($2::text = '' OR format = $2::text)
+AND ($3::text = '' OR published_on >= $3::text::date)
+AND ($4::text = '' OR published_on <= $4::text::date)
+ORDER BY id
+`
+
+type ListEditionsParams struct {
+	BookID        int64
+	Format        string
+	PublishedFrom string
+	PublishedTo   string
+}
+
+func (q *Queries) ListEditions(ctx context.Context, arg ListEditionsParams) ([]Edition, error) {
+	rows, err := q.db.QueryContext(ctx, listEditions,
+		arg.BookID,
+		arg.Format,
+		arg.PublishedFrom,
+		arg.PublishedTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Edition
+	for rows.Next() {
+		var i Edition
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.Format,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PriceAmount,
+			&i.PriceCurrency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEditionsByBookID = `-- name: ListEditionsByBookID :many
+SELECT id, book_id, format, isbn, published_on, price_amount, price_currency FROM editions
+WHERE book_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListEditionsByBookID(ctx context.Context, bookID int64) ([]Edition, error) {
+	rows, err := q.db.QueryContext(ctx, listEditionsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Edition
+	for rows.Next() {
+		var i Edition
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.Format,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PriceAmount,
+			&i.PriceCurrency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFormerAuthorsByAgentID = `-- name: ListFormerAuthorsByAgentID :many
+SELECT authors.id, authors.name, authors.website, authors.updated_at, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+WHERE EXISTS (
+    SELECT 1 FROM representations
+    WHERE representations.author_id = authors.id AND representations.agent_id = $1
+) AND NOT EXISTS (
+    SELECT 1 FROM representations
+    WHERE representations.author_id = authors.id AND representations.agent_id = $1
+        AND representations.ended_at IS NULL
+)
+ORDER BY authors.name
+`
+
+type ListFormerAuthorsByAgentIDRow struct {
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	UpdatedAt time.Time
+	AgentID   int64
+}
+
+func (q *Queries) ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]ListFormerAuthorsByAgentIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFormerAuthorsByAgentID, agentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFormerAuthorsByAgentIDRow
+	for rows.Next() {
+		var i ListFormerAuthorsByAgentIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Website,
+			&i.UpdatedAt,
+			&i.AgentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGenreAncestorIDs = `-- name: ListGenreAncestorIDs :many
+WITH RECURSIVE ancestors AS (
+    SELECT genres.id, genres.parent_id FROM genres
+    WHERE genres.id = $1::bigint
+    UNION
+    SELECT genres.id, genres.parent_id FROM genres, ancestors
+    WHERE genres.id = ancestors.parent_id
+)
+SELECT genres.id FROM genres
+WHERE genres.id IN (SELECT ancestors.id FROM ancestors)
+`
+
+func (q *Queries) ListGenreAncestorIDs(ctx context.Context, genreID int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listGenreAncestorIDs, genreID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGenres = `-- name: ListGenres :many
+SELECT id, name, parent_id FROM genres
+ORDER BY name
+`
+
+func (q *Queries) ListGenres(ctx context.Context) ([]Genre, error) {
+	rows, err := q.db.QueryContext(ctx, listGenres)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Genre
+	for rows.Next() {
+		var i Genre
+		if err := rows.Scan(&i.ID, &i.Name, &i.ParentID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGenresByBookID = `-- name: ListGenresByBookID :many
+SELECT genres.id, genres.name, genres.parent_id FROM genres, book_genres
+WHERE genres.id = book_genres.genre_id AND book_genres.book_id = $1
+ORDER BY genres.name
+`
+
+func (q *Queries) ListGenresByBookID(ctx context.Context, bookID int64) ([]Genre, error) {
+	rows, err := q.db.QueryContext(ctx, listGenresByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Genre
+	for rows.Next() {
+		var i Genre
+		if err := rows.Scan(&i.ID, &i.Name, &i.ParentID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGenresByParentID = `-- name: ListGenresByParentID :many
+SELECT id, name, parent_id FROM genres
+WHERE parent_id = $1::bigint
+ORDER BY name
+`
+
+func (q *Queries) ListGenresByParentID(ctx context.Context, parentID int64) ([]Genre, error) {
+	rows, err := q.db.QueryContext(ctx, listGenresByParentID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Genre
+	for rows.Next() {
+		var i Genre
+		if err := rows.Scan(&i.ID, &i.Name, &i.ParentID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrphanBooks = `-- name: ListOrphanBooks :many
+SELECT id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at FROM books
+WHERE NOT EXISTS (
+    SELECT 1 FROM book_authors
+    WHERE book_authors.book_id = books.id
+)
+ORDER BY title
+`
+
+func (q *Queries) ListOrphanBooks(ctx context.Context) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, listOrphanBooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishers = `-- name: ListPublishers :many
+SELECT id, name FROM publishers
+ORDER BY name
+`
+
+func (q *Queries) ListPublishers(ctx context.Context) ([]Publisher, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Publisher
+	for rows.Next() {
+		var i Publisher
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRepresentationsByAuthorID = `-- name: ListRepresentationsByAuthorID :many
+SELECT id, author_id, agent_id, territory, started_at, ended_at, is_primary FROM representations
+WHERE author_id = $1
+ORDER BY started_at, id
+`
+
+func (q *Queries) ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]Representation, error) {
+	rows, err := q.db.QueryContext(ctx, listRepresentationsByAuthorID, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Representation
+	for rows.Next() {
+		var i Representation
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.AgentID,
+			&i.Territory,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.IsPrimary,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoyaltyRatesByDealID = `-- name: ListRoyaltyRatesByDealID :many
+SELECT id, deal_id, edition_id, rate FROM royalty_rates
+WHERE deal_id = $1
+ORDER BY edition_id
+`
+
+func (q *Queries) ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]RoyaltyRate, error) {
+	rows, err := q.db.QueryContext(ctx, listRoyaltyRatesByDealID, dealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RoyaltyRate
+	for rows.Next() {
+		var i RoyaltyRate
+		if err := rows.Scan(
+			&i.ID,
+			&i.DealID,
+			&i.EditionID,
+			&i.Rate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoyaltySales = `-- name: ListRoyaltySales :many
+SELECT sales.id, sales.deal_id, sales.edition_id, sales.period, sales.units, sales.revenue_amount, sales.revenue_currency, deals.book_id, deals.agent_id, COALESCE(royalty_rates.rate, deals.royalty_rate)::int AS royalty_rate, deals.commission_rate, (SELECT count(*) FROM book_authors
+     WHERE book_authors.book_id = deals.book_id AND book_authors.role = 'AUTHOR')::int AS authors
+FROM sales
+JOIN deals ON deals.id = sales.deal_id
+LEFT JOIN royalty_rates ON royalty_rates.deal_id = sales.deal_id AND royalty_rates.edition_id = sales.edition_id
+WHERE sales.period = $1::text AND EXISTS (
+    SELECT 1 FROM book_authors
+    WHERE book_authors.book_id = deals.book_id AND book_authors.author_id = $2::bigint
+        AND book_authors.role = 'AUTHOR'
+)
+ORDER BY sales.id
+`
+
+type ListRoyaltySalesParams struct {
+	Period   string
+	AuthorID int64
+}
+
+type ListRoyaltySalesRow struct {
+	ID              int64
+	DealID          int64
+	EditionID       int64
+	Period          string
+	Units           int32
+	RevenueAmount   int64
+	RevenueCurrency string
+	BookID          int64
+	AgentID         int64
+	RoyaltyRate     int32
+	CommissionRate  int32
+	Authors         int32
+}
+
+func (q *Queries) ListRoyaltySales(ctx context.Context, arg ListRoyaltySalesParams) ([]ListRoyaltySalesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRoyaltySales, arg.Period, arg.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRoyaltySalesRow
+	for rows.Next() {
+		var i ListRoyaltySalesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DealID,
+			&i.EditionID,
+			&i.Period,
+			&i.Units,
+			&i.RevenueAmount,
+			&i.RevenueCurrency,
+			&i.BookID,
+			&i.AgentID,
+			&i.RoyaltyRate,
+			&i.CommissionRate,
+			&i.Authors,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSalesByDealID = `-- name: ListSalesByDealID :many
+SELECT id, deal_id, edition_id, period, units, revenue_amount, revenue_currency FROM sales
+WHERE deal_id = $1
+ORDER BY period, edition_id
+`
+
+func (q *Queries) ListSalesByDealID(ctx context.Context, dealID int64) ([]Sale, error) {
+	rows, err := q.db.QueryContext(ctx, listSalesByDealID, dealID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Sale
+	for rows.Next() {
+		var i Sale
+		if err := rows.Scan(
+			&i.ID,
+			&i.DealID,
+			&i.EditionID,
+			&i.Period,
+			&i.Units,
+			&i.RevenueAmount,
+			&i.RevenueCurrency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeries = `-- name: ListSeries :many
+SELECT id, title FROM series
+ORDER BY title
+`
+
+func (q *Queries) ListSeries(ctx context.Context) ([]Series, error) {
+	rows, err := q.db.QueryContext(ctx, listSeries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Series
+	for rows.Next() {
+		var i Series
+		if err := rows.Scan(&i.ID, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubmissionTransitions = `-- name: ListSubmissionTransitions :many
+SELECT id, submission_id, from_status, to_status, note, transitioned_at FROM submission_transitions
+WHERE submission_id = $1
+ORDER BY transitioned_at, id
+`
+
+func (q *Queries) ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]SubmissionTransition, error) {
+	rows, err := q.db.QueryContext(ctx, listSubmissionTransitions, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SubmissionTransition
+	for rows.Next() {
+		var i SubmissionTransition
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubmissionID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Note,
+			&i.TransitionedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubmissionsByAgentID = `-- name: ListSubmissionsByAgentID :many
+SELECT id, agent_id, author_name, author_website, title, synopsis, status, received_at, book_id FROM submissions
+WHERE agent_id = $1
+ORDER BY received_at, id
+`
+
+func (q *Queries) ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]Submission, error) {
+	rows, err := q.db.QueryContext(ctx, listSubmissionsByAgentID, agentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Submission
+	for rows.Next() {
+		var i Submission
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgentID,
+			&i.AuthorName,
+			&i.AuthorWebsite,
+			&i.Title,
+			&i.Synopsis,
+			&i.Status,
+			&i.ReceivedAt,
+			&i.BookID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubmissionsByAgentIDAndStatus = `-- name: ListSubmissionsByAgentIDAndStatus :many
+SELECT id, agent_id, author_name, author_website, title, synopsis, status, received_at, book_id FROM submissions
+WHERE agent_id = $1 AND status = $2
+ORDER BY received_at, id
+`
+
+type ListSubmissionsByAgentIDAndStatusParams struct {
+	AgentID int64
+	Status  string
+}
+
+func (q *Queries) ListSubmissionsByAgentIDAndStatus(ctx context.Context, arg ListSubmissionsByAgentIDAndStatusParams) ([]Submission, error) {
+	rows, err := q.db.QueryContext(ctx, listSubmissionsByAgentIDAndStatus, arg.AgentID, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Submission
+	for rows.Next() {
+		var i Submission
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgentID,
+			&i.AuthorName,
+			&i.AuthorWebsite,
+			&i.Title,
+			&i.Synopsis,
+			&i.Status,
+			&i.ReceivedAt,
+			&i.BookID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTagsByBookID = `-- name: ListTagsByBookID :many
+SELECT tag FROM book_tags
+WHERE book_id = $1
+ORDER BY tag
+`
+
+func (q *Queries) ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listTagsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		items = append(items, tag)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, webhook_id, event_type, payload, status, attempts, response_status, last_error, created_at, next_attempt_at, delivered_at FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY id DESC
+`
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveriesByStatus = `-- name: ListWebhookDeliveriesByStatus :many
+SELECT id, webhook_id, event_type, payload, status, attempts, response_status, last_error, created_at, next_attempt_at, delivered_at FROM webhook_deliveries
+WHERE webhook_id = $1 AND status = $2
+ORDER BY id DESC
+`
+
+type ListWebhookDeliveriesByStatusParams struct {
+	WebhookID int64
+	Status    string
+}
+
+func (q *Queries) ListWebhookDeliveriesByStatus(ctx context.Context, arg ListWebhookDeliveriesByStatusParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveriesByStatus, arg.WebhookID, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhooks = `-- name: ListWebhooks :many
+SELECT id, url, secret, event_types FROM webhooks
+ORDER BY id
+`
+
+func (q *Queries) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Webhook
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Secret,
+			pq.Array(&i.EventTypes),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockGenres = `-- name: LockGenres :exec
+LOCK TABLE genres IN SHARE ROW EXCLUSIVE MODE
+`
+
+func (q *Queries) LockGenres(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockGenres)
+	return err
+}
+
+const reassignAuthors = `-- name: ReassignAuthors :many
+INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+SELECT author_id, $1, 'WORLD', CURRENT_DATE, true FROM representations
+WHERE agent_id = $2 AND is_primary AND ended_at IS NULL
+ORDER BY author_id
+RETURNING author_id
+`
+
+type ReassignAuthorsParams struct {
+	ToAgentID   int64
+	FromAgentID int64
+}
+
+func (q *Queries) ReassignAuthors(ctx context.Context, arg ReassignAuthorsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, reassignAuthors, arg.ToAgentID, arg.FromAgentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var authorID int64
+		if err := rows.Scan(&authorID); err != nil {
+			return nil, err
+		}
+		items = append(items, authorID)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordSales = `-- name: RecordSales :one
+INSERT INTO sales (deal_id, edition_id, period, units, revenue_amount, revenue_currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (deal_id, edition_id, period) DO UPDATE
+SET units = EXCLUDED.units, revenue_amount = EXCLUDED.revenue_amount, revenue_currency = EXCLUDED.revenue_currency
+RETURNING id, deal_id, edition_id, period, units, revenue_amount, revenue_currency
+`
+
+type RecordSalesParams struct {
+	DealID          int64
+	EditionID       int64
+	Period          string
+	Units           int32
+	RevenueAmount   int64
+	RevenueCurrency string
+}
+
+func (q *Queries) RecordSales(ctx context.Context, arg RecordSalesParams) (Sale, error) {
+	row := q.db.QueryRowContext(ctx, recordSales,
+		arg.DealID,
+		arg.EditionID,
+		arg.Period,
+		arg.Units,
+		arg.RevenueAmount,
+		arg.RevenueCurrency,
+	)
+	var i Sale
+	err := row.Scan(
+		&i.ID,
+		&i.DealID,
+		&i.EditionID,
+		&i.Period,
+		&i.Units,
+		&i.RevenueAmount,
+		&i.RevenueCurrency,
+	)
+	return i, err
+}
+
+const removeBooksFromSeries = `-- name: RemoveBooksFromSeries :exec
+UPDATE books
+SET series_id = NULL, series_position = NULL
+WHERE series_id = $1::bigint
+`
+
+func (q *Queries) RemoveBooksFromSeries(ctx context.Context, seriesID int64) error {
+	_, err := q.db.ExecContext(ctx, removeBooksFromSeries, seriesID)
+	return err
+}
+
+const retryWebhookDelivery = `-- name: RetryWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, last_error = NULL, next_attempt_at = now()
+WHERE id = $1
+RETURNING id, webhook_id, event_type, payload, status, attempts, response_status, last_error, created_at, next_attempt_at, delivered_at
+`
+
+func (q *Queries) RetryWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, retryWebhookDelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.WebhookID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.NextAttemptAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const setBookAuthor = `-- name: SetBookAuthor :exec
+INSERT INTO book_authors (book_id, author_id, role, position)
+VALUES ($1, $2, $3, $4)
 `
 
 type SetBookAuthorParams struct {
 	BookID   int64
 	AuthorID int64
+	Role     string
+	Position int32
+}
+
+func (q *Queries) SetBookAuthor(ctx context.Context, arg SetBookAuthorParams) error {
+	_, err := q.db.ExecContext(ctx, setBookAuthor,
+		arg.BookID,
+		arg.AuthorID,
+		arg.Role,
+		arg.Position,
+	)
+	return err
+}
+
+const setBookGenre = `-- name: SetBookGenre :exec
+INSERT INTO book_genres (book_id, genre_id)
+VALUES ($1, $2)
+`
+
+type SetBookGenreParams struct {
+	BookID  int64
+	GenreID int64
+}
+
+func (q *Queries) SetBookGenre(ctx context.Context, arg SetBookGenreParams) error {
+	_, err := q.db.ExecContext(ctx, setBookGenre, arg.BookID, arg.GenreID)
+	return err
+}
+
+const setBookTag = `-- name: SetBookTag :exec
+INSERT INTO book_tags (book_id, tag)
+VALUES ($1, $2)
+`
+
+type SetBookTagParams struct {
+	BookID int64
+	Tag    string
+}
+
+func (q *Queries) SetBookTag(ctx context.Context, arg SetBookTagParams) error {
+	_, err := q.db.ExecContext(ctx, setBookTag, arg.BookID, arg.Tag)
+	return err
+}
+
+const setBooksAuthors = `-- name: SetBooksAuthors :exec
+INSERT INTO book_authors (book_id, author_id, role, position)
+SELECT * FROM unnest($1::bigint[], $2::bigint[], $3::text[], $4::int[])
+`
+
+type SetBooksAuthorsParams struct {
+	BookIds   []int64
+	AuthorIds []int64
+	Roles     []string
+	Positions []int32
+}
+
+func (q *Queries) SetBooksAuthors(ctx context.Context, arg SetBooksAuthorsParams) error {
+	_, err := q.db.ExecContext(ctx, setBooksAuthors,
+		pq.Array(arg.BookIds),
+		pq.Array(arg.AuthorIds),
+		pq.Array(arg.Roles),
+		pq.Array(arg.Positions),
+	)
+	return err
+}
+
+const setBooksGenres = `-- name: SetBooksGenres :exec
+INSERT INTO book_genres (book_id, genre_id)
+SELECT unnest($1::bigint[]), unnest($2::bigint[])
+`
+
+type SetBooksGenresParams struct {
+	BookIds  []int64
+	GenreIds []int64
+}
+
+func (q *Queries) SetBooksGenres(ctx context.Context, arg SetBooksGenresParams) error {
+	_, err := q.db.ExecContext(ctx, setBooksGenres, pq.Array(arg.BookIds), pq.Array(arg.GenreIds))
+	return err
+}
+
+const setBooksTags = `-- name: SetBooksTags :exec
+INSERT INTO book_tags (book_id, tag)
+SELECT unnest($1::bigint[]), unnest($2::text[])
+`
+
+type SetBooksTagsParams struct {
+	BookIds []int64
+	Tags    []string
+}
+
+func (q *Queries) SetBooksTags(ctx context.Context, arg SetBooksTagsParams) error {
+	_, err := q.db.ExecContext(ctx, setBooksTags, pq.Array(arg.BookIds), pq.Array(arg.Tags))
+	return err
+}
+
+const setRoyaltyRate = `-- name: SetRoyaltyRate :one
+INSERT INTO royalty_rates (deal_id, edition_id, rate)
+VALUES ($1, $2, $3)
+ON CONFLICT (deal_id, edition_id) DO UPDATE SET rate = EXCLUDED.rate
+RETURNING id, deal_id, edition_id, rate
+`
+
+type SetRoyaltyRateParams struct {
+	DealID    int64
+	EditionID int64
+	Rate      int32
+}
+
+func (q *Queries) SetRoyaltyRate(ctx context.Context, arg SetRoyaltyRateParams) (RoyaltyRate, error) {
+	row := q.db.QueryRowContext(ctx, setRoyaltyRate, arg.DealID, arg.EditionID, arg.Rate)
+	var i RoyaltyRate
+	err := row.Scan(
+		&i.ID,
+		&i.DealID,
+		&i.EditionID,
+		&i.Rate,
+	)
+	return i, err
+}
+
+const setSeriesPositions = `-- name: SetSeriesPositions :many
+UPDATE books
+SET series_position = u.series_position
+FROM unnest($1::bigint[], $2::int[]) AS u(id, series_position)
+WHERE books.id = u.id
+RETURNING books.id, books.title, books.description, books.cover, books.publisher_id, books.series_id, books.series_position, books.isbn, books.published_on, books.page_count, books.language, books.updated_at
+`
+
+type SetSeriesPositionsParams struct {
+	Ids             []int64
+	SeriesPositions []int32
+}
+
+func (q *Queries) SetSeriesPositions(ctx context.Context, arg SetSeriesPositionsParams) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, setSeriesPositions, pq.Array(arg.Ids), pq.Array(arg.SeriesPositions))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setSubmissionBook = `-- name: SetSubmissionBook :one
+UPDATE submissions
+SET book_id = $2
+WHERE id = $1
+RETURNING id, agent_id, author_name, author_website, title, synopsis, status, received_at, book_id
+`
+
+type SetSubmissionBookParams struct {
+	ID     int64
+	BookID sql.NullInt64
+}
+
+func (q *Queries) SetSubmissionBook(ctx context.Context, arg SetSubmissionBookParams) (Submission, error) {
+	row := q.db.QueryRowContext(ctx, setSubmissionBook, arg.ID, arg.BookID)
+	var i Submission
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.AuthorName,
+		&i.AuthorWebsite,
+		&i.Title,
+		&i.Synopsis,
+		&i.Status,
+		&i.ReceivedAt,
+		&i.BookID,
+	)
+	return i, err
+}
+
+const setSubmissionStatus = `-- name: SetSubmissionStatus :one
+UPDATE submissions
+SET status = $2
+WHERE id = $1
+RETURNING id, agent_id, author_name, author_website, title, synopsis, status, received_at, book_id
+`
+
+type SetSubmissionStatusParams struct {
+	ID     int64
+	Status string
+}
+
+func (q *Queries) SetSubmissionStatus(ctx context.Context, arg SetSubmissionStatusParams) (Submission, error) {
+	row := q.db.QueryRowContext(ctx, setSubmissionStatus, arg.ID, arg.Status)
+	var i Submission
+	err := row.Scan(
+		&i.ID,
+		&i.AgentID,
+		&i.AuthorName,
+		&i.AuthorWebsite,
+		&i.Title,
+		&i.Synopsis,
+		&i.Status,
+		&i.ReceivedAt,
+		&i.BookID,
+	)
+	return i, err
+}
+
+const startPrimaryRepresentation = `-- name: StartPrimaryRepresentation :exec
+INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+SELECT $1, $2, 'WORLD', CURRENT_DATE, true
+WHERE NOT EXISTS (
+    SELECT 1 FROM representations
+    WHERE author_id = $1 AND agent_id = $2 AND is_primary AND ended_at IS NULL
+)
+`
+
+type StartPrimaryRepresentationParams struct {
+	AuthorID int64
+	AgentID  int64
 }
 
-func (q *Queries) SetBookAuthor(ctx context.Context, arg SetBookAuthorParams) error {
-	_, err := q.db.ExecContext(ctx, setBookAuthor, arg.BookID, arg.AuthorID)
+func (q *Queries) StartPrimaryRepresentation(ctx context.Context, arg StartPrimaryRepresentationParams) error {
+	_, err := q.db.ExecContext(ctx, startPrimaryRepresentation, arg.AuthorID, arg.AgentID)
+	return err
+}
+
+const startPrimaryRepresentations = `-- name: StartPrimaryRepresentations :exec
+INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+SELECT u.author_id, u.agent_id, 'WORLD', CURRENT_DATE, true
+FROM unnest($1::bigint[], $2::bigint[]) AS u(author_id, agent_id)
+`
+
+type StartPrimaryRepresentationsParams struct {
+	AuthorIds []int64
+	AgentIds  []int64
+}
+
+func (q *Queries) StartPrimaryRepresentations(ctx context.Context, arg StartPrimaryRepresentationsParams) error {
+	_, err := q.db.ExecContext(ctx, startPrimaryRepresentations, pq.Array(arg.AuthorIds), pq.Array(arg.AgentIds))
 	return err
 }
 
@@ -387,69 +3357,153 @@ func (q *Queries) UnsetBookAuthors(ctx context.Context, bookID int64) error {
 	return err
 }
 
+const unsetBookGenres = `-- name: UnsetBookGenres :exec
+DELETE FROM book_genres
+WHERE book_id = $1
+`
+
+func (q *Queries) UnsetBookGenres(ctx context.Context, bookID int64) error {
+	_, err := q.db.ExecContext(ctx, unsetBookGenres, bookID)
+	return err
+}
+
+const unsetBookTags = `-- name: UnsetBookTags :exec
+DELETE FROM book_tags
+WHERE book_id = $1
+`
+
+func (q *Queries) UnsetBookTags(ctx context.Context, bookID int64) error {
+	_, err := q.db.ExecContext(ctx, unsetBookTags, bookID)
+	return err
+}
+
+const unsetBooksAuthors = `-- name: UnsetBooksAuthors :exec
+DELETE FROM book_authors
+WHERE book_id = ANY($1::bigint[])
+`
+
+func (q *Queries) UnsetBooksAuthors(ctx context.Context, bookIds []int64) error {
+	_, err := q.db.ExecContext(ctx, unsetBooksAuthors, pq.Array(bookIds))
+	return err
+}
+
+const unsetBooksGenres = `-- name: UnsetBooksGenres :exec
+DELETE FROM book_genres
+WHERE book_id = ANY($1::bigint[])
+`
+
+func (q *Queries) UnsetBooksGenres(ctx context.Context, bookIds []int64) error {
+	_, err := q.db.ExecContext(ctx, unsetBooksGenres, pq.Array(bookIds))
+	return err
+}
+
+const unsetBooksTags = `-- name: UnsetBooksTags :exec
+DELETE FROM book_tags
+WHERE book_id = ANY($1::bigint[])
+`
+
+func (q *Queries) UnsetBooksTags(ctx context.Context, bookIds []int64) error {
+	_, err := q.db.ExecContext(ctx, unsetBooksTags, pq.Array(bookIds))
+	return err
+}
+
+const updateAgency = `-- name: UpdateAgency :one
+UPDATE agencies
+SET name = $2
+WHERE id = $1
+RETURNING id, name
+`
+
+type UpdateAgencyParams struct {
+	ID   int64
+	Name string
+}
+
+func (q *Queries) UpdateAgency(ctx context.Context, arg UpdateAgencyParams) (Agency, error) {
+	row := q.db.QueryRowContext(ctx, updateAgency, arg.ID, arg.Name)
+	var i Agency
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
 const updateAgent = `-- name: UpdateAgent :one
 UPDATE agents
-SET name = $2, email = $3
-WHERE id = $1
-RETURNING id, name, email
+SET name = $2, email = $3, agency_id = $4
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, email, agency_id, updated_at, deleted_at
 `
 
 type UpdateAgentParams struct {
-	ID    int64
-	Name  string
-	Email string
+	ID       int64
+	Name     string
+	Email    string
+	AgencyID sql.NullInt64
 }
 
 func (q *Queries) UpdateAgent(ctx context.Context, arg UpdateAgentParams) (Agent, error) {
-	row := q.db.QueryRowContext(ctx, updateAgent, arg.ID, arg.Name, arg.Email)
+	row := q.db.QueryRowContext(ctx, updateAgent,
+		arg.ID,
+		arg.Name,
+		arg.Email,
+		arg.AgencyID,
+	)
 	var i Agent
-	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.AgencyID,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
 	return i, err
 }
 
 const updateAuthor = `-- name: UpdateAuthor :one
 UPDATE authors
-SET name = $2, website = $3, agent_id = $4
+SET name = $2, website = $3
 WHERE id = $1
-RETURNING id, name, website, agent_id
+RETURNING id, name, website, updated_at
 `
 
 type UpdateAuthorParams struct {
 	ID      int64
 	Name    string
 	Website sql.NullString
-	AgentID int64
 }
 
 func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) (Author, error) {
-	row := q.db.QueryRowContext(ctx, updateAuthor,
-		arg.ID,
-		arg.Name,
-		arg.Website,
-		arg.AgentID,
-	)
+	row := q.db.QueryRowContext(ctx, updateAuthor, arg.ID, arg.Name, arg.Website)
 	var i Author
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.Website,
-		&i.AgentID,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const updateBook = `-- name: UpdateBook :one
 UPDATE books
-SET title = $2, description = $3, cover = $4
+SET title = $2, description = $3, cover = $4, publisher_id = $5, series_id = $6, series_position = $7,
+    isbn = $8, published_on = $9, page_count = $10, language = $11
 WHERE id = $1
-RETURNING id, title, description, cover
+RETURNING id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, updated_at
 `
 
 type UpdateBookParams struct {
-	ID          int64
-	Title       string
-	Description string
-	Cover       string
+	ID             int64
+	Title          string
+	Description    string
+	Cover          string
+	PublisherID    sql.NullInt64
+	SeriesID       sql.NullInt64
+	SeriesPosition sql.NullInt32
+	Isbn           sql.NullString
+	PublishedOn    sql.NullTime
+	PageCount      sql.NullInt32
+	Language       sql.NullString
 }
 
 func (q *Queries) UpdateBook(ctx context.Context, arg UpdateBookParams) (Book, error) {
@@ -458,6 +3512,13 @@ func (q *Queries) UpdateBook(ctx context.Context, arg UpdateBookParams) (Book, e
 		arg.Title,
 		arg.Description,
 		arg.Cover,
+		arg.PublisherID,
+		arg.SeriesID,
+		arg.SeriesPosition,
+		arg.Isbn,
+		arg.PublishedOn,
+		arg.PageCount,
+		arg.Language,
 	)
 	var i Book
 	err := row.Scan(
@@ -465,6 +3526,276 @@ func (q *Queries) UpdateBook(ctx context.Context, arg UpdateBookParams) (Book, e
 		&i.Title,
 		&i.Description,
 		&i.Cover,
+		&i.PublisherID,
+		&i.SeriesID,
+		&i.SeriesPosition,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PageCount,
+		&i.Language,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateBooks = `-- name: UpdateBooks :many
+UPDATE books
+SET title = u.title, description = u.description, cover = u.cover, publisher_id = NULLIF(u.publisher_id, 0),
+    series_id = NULLIF(u.series_id, 0), series_position = NULLIF(u.series_position, 0),
+    isbn = NULLIF(u.isbn, ''), published_on = NULLIF(u.published_on, '')::date, page_count = NULLIF(u.page_count, '')::int, language = NULLIF(u.language, '')
+FROM unnest($1::bigint[], $2::text[], $3::text[], $4::text[], $5::bigint[], $6::bigint[], $7::int[],
+    $8::text[], $9::text[], $10::text[], $11::text[])
+AS u(id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language)
+WHERE books.id = u.id
+RETURNING books.id, books.title, books.description, books.cover, books.publisher_id, books.series_id, books.series_position, books.isbn, books.published_on, books.page_count, books.language, books.updated_at
+`
+
+type UpdateBooksParams struct {
+	Ids             []int64
+	Titles          []string
+	Descriptions    []string
+	Covers          []string
+	PublisherIds    []int64
+	SeriesIds       []int64
+	SeriesPositions []int32
+	Isbns           []string
+	PublishedOns    []string
+	PageCounts      []string
+	Languages       []string
+}
+
+func (q *Queries) UpdateBooks(ctx context.Context, arg UpdateBooksParams) ([]Book, error) {
+	rows, err := q.db.QueryContext(ctx, updateBooks,
+		pq.Array(arg.Ids),
+		pq.Array(arg.Titles),
+		pq.Array(arg.Descriptions),
+		pq.Array(arg.Covers),
+		pq.Array(arg.PublisherIds),
+		pq.Array(arg.SeriesIds),
+		pq.Array(arg.SeriesPositions),
+		pq.Array(arg.Isbns),
+		pq.Array(arg.PublishedOns),
+		pq.Array(arg.PageCounts),
+		pq.Array(arg.Languages),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Cover,
+			&i.PublisherID,
+			&i.SeriesID,
+			&i.SeriesPosition,
+			&i.Isbn,
+			&i.PublishedOn,
+			&i.PageCount,
+			&i.Language,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateDeal = `-- name: UpdateDeal :one
+UPDATE deals
+SET book_id = $2, publisher_id = $3, agent_id = $4, territory = $5, language = $6, format = $7,
+    advance_amount = $8, advance_currency = $9, signed_on = $10, status = $11, royalty_rate = $12, commission_rate = $13
+WHERE id = $1
+RETURNING id, book_id, publisher_id, agent_id, territory, language, format, advance_amount, advance_currency, signed_on, status, royalty_rate, commission_rate
+`
+
+type UpdateDealParams struct {
+	ID              int64
+	BookID          int64
+	PublisherID     int64
+	AgentID         int64
+	Territory       string
+	Language        string
+	Format          string
+	AdvanceAmount   int64
+	AdvanceCurrency string
+	SignedOn        sql.NullTime
+	Status          string
+	RoyaltyRate     int32
+	CommissionRate  int32
+}
+
+func (q *Queries) UpdateDeal(ctx context.Context, arg UpdateDealParams) (Deal, error) {
+	row := q.db.QueryRowContext(ctx, updateDeal,
+		arg.ID,
+		arg.BookID,
+		arg.PublisherID,
+		arg.AgentID,
+		arg.Territory,
+		arg.Language,
+		arg.Format,
+		arg.AdvanceAmount,
+		arg.AdvanceCurrency,
+		arg.SignedOn,
+		arg.Status,
+		arg.RoyaltyRate,
+		arg.CommissionRate,
+	)
+	var i Deal
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.PublisherID,
+		&i.AgentID,
+		&i.Territory,
+		&i.Language,
+		&i.Format,
+		&i.AdvanceAmount,
+		&i.AdvanceCurrency,
+		&i.SignedOn,
+		&i.Status,
+		&i.RoyaltyRate,
+		&i.CommissionRate,
+	)
+	return i, err
+}
+
+const updateEdition = `-- name: UpdateEdition :one
+UPDATE editions
+SET book_id = $2, format = $3, isbn = $4, published_on = $5, price_amount = $6, price_currency = $7
+WHERE id = $1
+RETURNING id, book_id, format, isbn, published_on, price_amount, price_currency
+`
+
+type UpdateEditionParams struct {
+	ID            int64
+	BookID        int64
+	Format        string
+	Isbn          sql.NullString
+	PublishedOn   sql.NullTime
+	PriceAmount   sql.NullInt64
+	PriceCurrency sql.NullString
+}
+
+func (q *Queries) UpdateEdition(ctx context.Context, arg UpdateEditionParams) (Edition, error) {
+	row := q.db.QueryRowContext(ctx, updateEdition,
+		arg.ID,
+		arg.BookID,
+		arg.Format,
+		arg.Isbn,
+		arg.PublishedOn,
+		arg.PriceAmount,
+		arg.PriceCurrency,
+	)
+	var i Edition
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Format,
+		&i.Isbn,
+		&i.PublishedOn,
+		&i.PriceAmount,
+		&i.PriceCurrency,
+	)
+	return i, err
+}
+
+const updateGenre = `-- name: UpdateGenre :one
+UPDATE genres
+SET name = $2, parent_id = $3
+WHERE id = $1
+RETURNING id, name, parent_id
+`
+
+type UpdateGenreParams struct {
+	ID       int64
+	Name     string
+	ParentID sql.NullInt64
+}
+
+func (q *Queries) UpdateGenre(ctx context.Context, arg UpdateGenreParams) (Genre, error) {
+	row := q.db.QueryRowContext(ctx, updateGenre, arg.ID, arg.Name, arg.ParentID)
+	var i Genre
+	err := row.Scan(&i.ID, &i.Name, &i.ParentID)
+	return i, err
+}
+
+const updatePublisher = `-- name: UpdatePublisher :one
+UPDATE publishers
+SET name = $2
+WHERE id = $1
+RETURNING id, name
+`
+
+type UpdatePublisherParams struct {
+	ID   int64
+	Name string
+}
+
+func (q *Queries) UpdatePublisher(ctx context.Context, arg UpdatePublisherParams) (Publisher, error) {
+	row := q.db.QueryRowContext(ctx, updatePublisher, arg.ID, arg.Name)
+	var i Publisher
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const updateSeries = `-- name: UpdateSeries :one
+UPDATE series
+SET title = $2
+WHERE id = $1
+RETURNING id, title
+`
+
+type UpdateSeriesParams struct {
+	ID    int64
+	Title string
+}
+
+func (q *Queries) UpdateSeries(ctx context.Context, arg UpdateSeriesParams) (Series, error) {
+	row := q.db.QueryRowContext(ctx, updateSeries, arg.ID, arg.Title)
+	var i Series
+	err := row.Scan(&i.ID, &i.Title)
+	return i, err
+}
+
+const updateWebhook = `-- name: UpdateWebhook :one
+UPDATE webhooks
+SET url = $2, secret = $3, event_types = $4
+WHERE id = $1
+RETURNING id, url, secret, event_types
+`
+
+type UpdateWebhookParams struct {
+	ID         int64
+	Url        string
+	Secret     string
+	EventTypes []string
+}
+
+func (q *Queries) UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, updateWebhook,
+		arg.ID,
+		arg.Url,
+		arg.Secret,
+		pq.Array(arg.EventTypes),
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Secret,
+		pq.Array(&i.EventTypes),
 	)
 	return i, err
 }
@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// record is a single decoded row. Values are strings, nil or, in the JSON
+// formats, lists of strings.
+type record struct {
+	line   int
+	fields map[string]interface{}
+}
+
+// decodeFunc decodes rows from a reader, calling fn for every row. Errors
+// confined to a single row are passed to fn, any other error stops decoding.
+type decodeFunc func(r io.Reader, fn func(rec record, err error)) error
+
+func decoder(format Format) (decodeFunc, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV, nil
+	case FormatJSON:
+		return decodeJSON, nil
+	case FormatNDJSON:
+		return decodeNDJSON, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// decodeCSV decodes CSV with a header row naming the fields. Empty values are
+// treated as missing.
+func decodeCSV(r io.Reader, fn func(rec record, err error)) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for {
+		values, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := cr.FieldPos(0)
+		if len(values) != len(header) {
+			fn(record{line: line}, fmt.Errorf("expected %d fields, found %d", len(header), len(values)))
+			continue
+		}
+		rec := record{line: line, fields: make(map[string]interface{}, len(header))}
+		for i, name := range header {
+			if values[i] != "" {
+				rec.fields[strings.TrimSpace(name)] = values[i]
+			}
+		}
+		fn(rec, nil)
+	}
+}
+
+// decodeJSON decodes a JSON array of objects.
+func decodeJSON(r io.Reader, fn func(rec record, err error)) error {
+	lc := &lineCounter{r: r}
+	dec := json.NewDecoder(lc)
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return errors.New("expected a JSON array")
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		line := lc.line(dec.InputOffset() - int64(len(raw)))
+		fn(unmarshalRecord(line, raw))
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// decodeNDJSON decodes newline delimited JSON objects, skipping blank lines.
+func decodeNDJSON(r io.Reader, fn func(rec record, err error)) error {
+	br := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		b, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(b)) > 0 {
+			fn(unmarshalRecord(line, b))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func unmarshalRecord(line int, b []byte) (record, error) {
+	rec := record{line: line}
+	if err := json.Unmarshal(b, &rec.fields); err != nil {
+		return rec, errors.New("expected a JSON object")
+	}
+	return rec, nil
+}
+
+// lineCounter records the offsets of the newlines read through it, so that
+// offsets reported by a json.Decoder can be turned into line numbers.
+type lineCounter struct {
+	r        io.Reader
+	offset   int64
+	newlines []int64
+}
+
+func (lc *lineCounter) Read(p []byte) (int, error) {
+	n, err := lc.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			lc.newlines = append(lc.newlines, lc.offset+int64(i))
+		}
+	}
+	lc.offset += int64(n)
+	return n, err
+}
+
+// line returns the 1-based line number of the byte at the given offset.
+func (lc *lineCounter) line(offset int64) int {
+	return sort.Search(len(lc.newlines), func(i int) bool {
+		return lc.newlines[i] >= offset
+	}) + 1
+}
@@ -0,0 +1,233 @@
+// Package importer imports agents, authors and books from CSV, JSON and
+// newline delimited JSON files.
+//
+// The fields of each entity, given as CSV header names or JSON object keys,
+// are:
+//
+//	agents   name, email
+//	authors  name, website (optional), agent_email
+//	books    title, description, cover, authors
+//
+// Book authors are referenced by name; in CSV they are separated by
+// semicolons, in JSON they are given as a list.
+package importer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fwojciec/litag-example/postgres" // update the username
+)
+
+// Format is the format of the imported file.
+type Format string
+
+// Supported formats.
+const (
+	FormatCSV    Format = "csv"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Entity is the type of the imported records.
+type Entity string
+
+// Supported entities.
+const (
+	EntityAgents  Entity = "agents"
+	EntityAuthors Entity = "authors"
+	EntityBooks   Entity = "books"
+)
+
+// Importer represents the datalayer methods used to import records.
+type Importer interface {
+	ImportAgents(ctx context.Context, rows []postgres.AgentImportRow, dryRun bool) (*postgres.ImportSummary, error)
+	ImportAuthors(ctx context.Context, rows []postgres.AuthorImportRow, dryRun bool) (*postgres.ImportSummary, error)
+	ImportBooks(ctx context.Context, rows []postgres.BookImportRow, dryRun bool) (*postgres.ImportSummary, error)
+}
+
+var fields = map[Entity][]string{
+	EntityAgents:  {"name", "email"},
+	EntityAuthors: {"name", "website", "agent_email"},
+	EntityBooks:   {"title", "description", "cover", "authors"},
+}
+
+// Run decodes the records of the entity from r and imports them. Rows that
+// fail to decode are reported in the summary along with the rows rejected by
+// the importer; the remaining rows are imported.
+func Run(ctx context.Context, imp Importer, r io.Reader, format Format, entity Entity, dryRun bool) (*postgres.ImportSummary, error) {
+	decode, err := decoder(format)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fields[entity]; !ok {
+		return nil, fmt.Errorf("unknown entity %q", entity)
+	}
+	var (
+		rowErrors []*postgres.ImportRowError
+		agents    []postgres.AgentImportRow
+		authors   []postgres.AuthorImportRow
+		books     []postgres.BookImportRow
+	)
+	err = decode(r, func(rec record, err error) {
+		if err == nil {
+			err = rec.checkFields(fields[entity])
+		}
+		if err == nil {
+			switch entity {
+			case EntityAgents:
+				var row postgres.AgentImportRow
+				if row, err = agentRow(rec); err == nil {
+					agents = append(agents, row)
+				}
+			case EntityAuthors:
+				var row postgres.AuthorImportRow
+				if row, err = authorRow(rec); err == nil {
+					authors = append(authors, row)
+				}
+			case EntityBooks:
+				var row postgres.BookImportRow
+				if row, err = bookRow(rec); err == nil {
+					books = append(books, row)
+				}
+			}
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, &postgres.ImportRowError{Line: rec.line, Err: err})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	var s *postgres.ImportSummary
+	switch entity {
+	case EntityAgents:
+		s, err = imp.ImportAgents(ctx, agents, dryRun)
+	case EntityAuthors:
+		s, err = imp.ImportAuthors(ctx, authors, dryRun)
+	case EntityBooks:
+		s, err = imp.ImportBooks(ctx, books, dryRun)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Errors = append(rowErrors, s.Errors...)
+	sort.SliceStable(s.Errors, func(i, j int) bool { return s.Errors[i].Line < s.Errors[j].Line })
+	return s, nil
+}
+
+func agentRow(rec record) (postgres.AgentImportRow, error) {
+	row := postgres.AgentImportRow{Line: rec.line}
+	var err error
+	if row.Name, err = rec.required("name"); err != nil {
+		return row, err
+	}
+	if row.Email, err = rec.required("email"); err != nil {
+		return row, err
+	}
+	return row, nil
+}
+
+func authorRow(rec record) (postgres.AuthorImportRow, error) {
+	row := postgres.AuthorImportRow{Line: rec.line}
+	var err error
+	if row.Name, err = rec.required("name"); err != nil {
+		return row, err
+	}
+	if row.Website, err = rec.optional("website"); err != nil {
+		return row, err
+	}
+	if row.AgentEmail, err = rec.required("agent_email"); err != nil {
+		return row, err
+	}
+	return row, nil
+}
+
+func bookRow(rec record) (postgres.BookImportRow, error) {
+	row := postgres.BookImportRow{Line: rec.line}
+	var err error
+	if row.Title, err = rec.required("title"); err != nil {
+		return row, err
+	}
+	if row.Description, err = rec.required("description"); err != nil {
+		return row, err
+	}
+	if row.Cover, err = rec.required("cover"); err != nil {
+		return row, err
+	}
+	if row.AuthorNames, err = rec.list("authors"); err != nil {
+		return row, err
+	}
+	if len(row.AuthorNames) == 0 {
+		return row, errors.New("missing authors")
+	}
+	return row, nil
+}
+
+func (rec record) checkFields(allowed []string) error {
+	for name := range rec.fields {
+		found := false
+		for _, a := range allowed {
+			found = found || a == name
+		}
+		if !found {
+			return fmt.Errorf("unknown field %q", name)
+		}
+	}
+	return nil
+}
+
+func (rec record) required(name string) (string, error) {
+	s, err := rec.optional(name)
+	if err != nil {
+		return "", err
+	}
+	if !s.Valid || strings.TrimSpace(s.String) == "" {
+		return "", fmt.Errorf("missing %s", name)
+	}
+	return s.String, nil
+}
+
+func (rec record) optional(name string) (sql.NullString, error) {
+	switch v := rec.fields[name].(type) {
+	case nil:
+		return sql.NullString{}, nil
+	case string:
+		return sql.NullString{String: v, Valid: true}, nil
+	default:
+		return sql.NullString{}, fmt.Errorf("%s must be a string", name)
+	}
+}
+
+// list returns a list of non-empty strings given either as a JSON list or as
+// a string with the values separated by semicolons.
+func (rec record) list(name string) ([]string, error) {
+	var values []string
+	switch v := rec.fields[name].(type) {
+	case nil:
+	case string:
+		values = strings.Split(v, ";")
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a list of strings", name)
+			}
+			values = append(values, s)
+		}
+	default:
+		return nil, fmt.Errorf("%s must be a list of strings", name)
+	}
+	res := make([]string, 0, len(values))
+	for _, s := range values {
+		if s = strings.TrimSpace(s); s != "" {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,219 @@
+package importer_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fwojciec/litag-example/generated/mocks"
+	"github.com/fwojciec/litag-example/importer"
+	"github.com/fwojciec/litag-example/postgres"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Agents", func(t *testing.T) {
+		t.Parallel()
+		exp := []postgres.AgentImportRow{
+			{Line: 2, Name: "Agent One", Email: "one@example.com"},
+			{Line: 4, Name: "Agent Two", Email: "two@example.com"},
+		}
+		tests := []struct {
+			name   string
+			format importer.Format
+			input  string
+		}{
+			{"csv", importer.FormatCSV, "name,email\n" +
+				"Agent One,one@example.com\n" +
+				"\n" +
+				"Agent Two,two@example.com\n"},
+			{"json", importer.FormatJSON, "[\n" +
+				`  {"name": "Agent One", "email": "one@example.com"},` + "\n" +
+				"\n" +
+				`  {"name": "Agent Two", "email": "two@example.com"}` + "\n" +
+				"]\n"},
+			{"ndjson", importer.FormatNDJSON, "\n" +
+				`{"name": "Agent One", "email": "one@example.com"}` + "\n" +
+				"\n" +
+				`{"name": "Agent Two", "email": "two@example.com"}`},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedRows []postgres.AgentImportRow
+				var receivedDryRun bool
+				imp := &mocks.ImporterMock{
+					ImportAgentsFunc: func(ctx context.Context, rows []postgres.AgentImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+						receivedRows = rows
+						receivedDryRun = dryRun
+						return &postgres.ImportSummary{Inserted: len(rows)}, nil
+					},
+				}
+				s, err := importer.Run(context.Background(), imp, strings.NewReader(tc.input), tc.format, importer.EntityAgents, true)
+				if err != nil {
+					t.Fatalf("expected no error, received %v", err)
+				}
+				if !reflect.DeepEqual(exp, receivedRows) {
+					t.Errorf("expected %v, received %v", exp, receivedRows)
+				}
+				if !receivedDryRun {
+					t.Errorf("expected a dry run")
+				}
+				if s.Inserted != 2 || len(s.Errors) != 0 {
+					t.Errorf("wrong summary: %v", s)
+				}
+			})
+		}
+	})
+
+	t.Run("Authors", func(t *testing.T) {
+		t.Parallel()
+		input := "name,website,agent_email\n" +
+			"Author One,https://one.com,agent@example.com\n" +
+			"Author Two,,agent@example.com\n"
+		var receivedRows []postgres.AuthorImportRow
+		imp := &mocks.ImporterMock{
+			ImportAuthorsFunc: func(ctx context.Context, rows []postgres.AuthorImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+				receivedRows = rows
+				return &postgres.ImportSummary{}, nil
+			},
+		}
+		_, err := importer.Run(context.Background(), imp, strings.NewReader(input), importer.FormatCSV, importer.EntityAuthors, false)
+		if err != nil {
+			t.Fatalf("expected no error, received %v", err)
+		}
+		exp := []postgres.AuthorImportRow{
+			{Line: 2, Name: "Author One", Website: sql.NullString{String: "https://one.com", Valid: true}, AgentEmail: "agent@example.com"},
+			{Line: 3, Name: "Author Two", AgentEmail: "agent@example.com"},
+		}
+		if !reflect.DeepEqual(exp, receivedRows) {
+			t.Errorf("expected %v, received %v", exp, receivedRows)
+		}
+	})
+
+	t.Run("Books", func(t *testing.T) {
+		t.Parallel()
+		exp := []postgres.BookImportRow{
+			{Line: 2, Title: "Title", Description: "Description", Cover: "cover.jpg", AuthorNames: []string{"Author One", "Author Two"}},
+		}
+		tests := []struct {
+			name   string
+			format importer.Format
+			input  string
+		}{
+			{"csv", importer.FormatCSV, "title,description,cover,authors\n" +
+				`Title,Description,cover.jpg,"Author One; Author Two"` + "\n"},
+			{"ndjson", importer.FormatNDJSON, "\n" +
+				`{"title": "Title", "description": "Description", "cover": "cover.jpg", "authors": ["Author One", "Author Two"]}`},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedRows []postgres.BookImportRow
+				imp := &mocks.ImporterMock{
+					ImportBooksFunc: func(ctx context.Context, rows []postgres.BookImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+						receivedRows = rows
+						return &postgres.ImportSummary{}, nil
+					},
+				}
+				_, err := importer.Run(context.Background(), imp, strings.NewReader(tc.input), tc.format, importer.EntityBooks, false)
+				if err != nil {
+					t.Fatalf("expected no error, received %v", err)
+				}
+				if !reflect.DeepEqual(exp, receivedRows) {
+					t.Errorf("expected %v, received %v", exp, receivedRows)
+				}
+			})
+		}
+	})
+
+	t.Run("Row errors", func(t *testing.T) {
+		t.Parallel()
+		input := "[\n" +
+			`{"title": "Valid", "description": "d", "cover": "c", "authors": ["A"]},` + "\n" +
+			`{"title": "No authors", "description": "d", "cover": "c", "authors": []},` + "\n" +
+			`{"title": "Unknown field", "description": "d", "cover": "c", "authors": ["A"], "isbn": "1"},` + "\n" +
+			`"not an object",` + "\n" +
+			`{"title": "Wrong type", "description": "d", "cover": "c", "authors": [1]},` + "\n" +
+			`{"title": "Rejected", "description": "d", "cover": "c", "authors": ["A"]}` + "\n" +
+			"]"
+		var receivedRows []postgres.BookImportRow
+		imp := &mocks.ImporterMock{
+			ImportBooksFunc: func(ctx context.Context, rows []postgres.BookImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+				receivedRows = rows
+				return &postgres.ImportSummary{
+					Inserted: 1,
+					Errors:   []*postgres.ImportRowError{{Line: 7, Err: errors.New("unknown author A")}},
+				}, nil
+			},
+		}
+		s, err := importer.Run(context.Background(), imp, strings.NewReader(input), importer.FormatJSON, importer.EntityBooks, false)
+		if err != nil {
+			t.Fatalf("expected no error, received %v", err)
+		}
+		if len(receivedRows) != 2 || receivedRows[0].Line != 2 || receivedRows[1].Line != 7 {
+			t.Errorf("expected rows from lines 2 and 7, received %v", receivedRows)
+		}
+		exp := []string{
+			"line 3: missing authors",
+			`line 4: unknown field "isbn"`,
+			"line 5: expected a JSON object",
+			"line 6: authors must be a list of strings",
+			"line 7: unknown author A",
+		}
+		received := make([]string, 0, len(s.Errors))
+		for _, e := range s.Errors {
+			received = append(received, e.Error())
+		}
+		if !reflect.DeepEqual(exp, received) {
+			t.Errorf("expected %v, received %v", exp, received)
+		}
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			format importer.Format
+			entity importer.Entity
+			input  string
+		}{
+			{"unknown format", importer.Format("xml"), importer.EntityAgents, ""},
+			{"unknown entity", importer.FormatCSV, importer.Entity("publishers"), ""},
+			{"malformed csv", importer.FormatCSV, importer.EntityAgents, "name,email\n\"unterminated,x\n"},
+			{"malformed json", importer.FormatJSON, importer.EntityAgents, `[{"name": "x"`},
+			{"json object instead of array", importer.FormatJSON, importer.EntityAgents, `{"name": "x"}`},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				imp := &mocks.ImporterMock{}
+				_, err := importer.Run(context.Background(), imp, strings.NewReader(tc.input), tc.format, tc.entity, false)
+				if err == nil {
+					t.Errorf("expected an error, received nil")
+				}
+			})
+		}
+	})
+
+	t.Run("Importer error", func(t *testing.T) {
+		t.Parallel()
+		testError := errors.New("test error")
+		imp := &mocks.ImporterMock{
+			ImportAgentsFunc: func(ctx context.Context, rows []postgres.AgentImportRow, dryRun bool) (*postgres.ImportSummary, error) {
+				return nil, testError
+			},
+		}
+		_, err := importer.Run(context.Background(), imp, strings.NewReader("name,email\na,b\n"), importer.FormatCSV, importer.EntityAgents, false)
+		if !errors.Is(err, testError) {
+			t.Errorf("wrong error: expected %v, received %v", testError, err)
+		}
+	})
+}
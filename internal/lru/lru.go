@@ -0,0 +1,178 @@
+// Package lru provides the in-process caches of the application: a
+// size-bounded, least recently used map whose entries may expire and carry
+// tags that invalidate them.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is a size-bounded, least recently used map whose entries expire after a
+// TTL. Every entry carries tags, and invalidating a tag removes all entries
+// carrying it; an entry's own key is always one of its tags.
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	now     func() time.Time
+	ll      *list.List
+	entries map[string]*list.Element
+	tagged  map[string]map[string]struct{}
+	// version changes on every invalidation, so that values read before an
+	// invalidation are not stored after it.
+	version uint64
+	// invalidated is the time of the last invalidation.
+	invalidated time.Time
+
+	hits, misses, evictions, invalidations uint64
+}
+
+type entry struct {
+	key     string
+	value   interface{}
+	tags    []string
+	expires time.Time
+}
+
+// New returns a new instance of LRU holding at most size entries, each for at
+// most ttl; entries never expire if ttl is zero.
+func New(size int, ttl time.Duration) *LRU {
+	return &LRU{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		ll:      list.New(),
+		entries: make(map[string]*list.Element),
+		tagged:  make(map[string]map[string]struct{}),
+	}
+}
+
+// Get returns the value of a live entry and the current version, which must
+// be passed to Set when storing the value read on a miss.
+func (c *LRU) Get(key string) (interface{}, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry)
+		if e.expires.IsZero() || c.now().Before(e.expires) {
+			c.ll.MoveToFront(el)
+			c.hits++
+			return e.value, c.version, true
+		}
+		c.remove(el)
+	}
+	c.misses++
+	return nil, c.version, false
+}
+
+// Set stores a value unless something was invalidated since version.
+func (c *LRU) Set(version uint64, key string, value interface{}, tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if version != c.version {
+		return
+	}
+	c.add(key, value, tags)
+}
+
+// Add stores a value regardless of invalidations.
+func (c *LRU) Add(key string, value interface{}, tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.add(key, value, tags)
+}
+
+func (c *LRU) add(key string, value interface{}, tags []string) {
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	e := &entry{
+		key:   key,
+		value: value,
+		tags:  append([]string{key}, tags...),
+	}
+	if c.ttl > 0 {
+		e.expires = c.now().Add(c.ttl)
+	}
+	c.entries[key] = c.ll.PushFront(e)
+	for _, tag := range e.tags {
+		keys, ok := c.tagged[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tagged[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+	for c.ll.Len() > c.size {
+		c.remove(c.ll.Back())
+		c.evictions++
+	}
+}
+
+// Invalidate removes all entries carrying any of the tags.
+func (c *LRU) Invalidate(tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version++
+	c.invalidated = c.now()
+	for _, tag := range tags {
+		for key := range c.tagged[tag] {
+			c.remove(c.entries[key])
+			c.invalidations++
+		}
+	}
+}
+
+// InvalidatedWithin reports whether anything was invalidated in the last d.
+func (c *LRU) InvalidatedWithin(d time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.invalidated.IsZero() && c.now().Sub(c.invalidated) < d
+}
+
+func (c *LRU) remove(el *list.Element) {
+	e := c.ll.Remove(el).(*entry)
+	delete(c.entries, e.key)
+	for _, tag := range e.tags {
+		delete(c.tagged[tag], e.key)
+		if len(c.tagged[tag]) == 0 {
+			delete(c.tagged, tag)
+		}
+	}
+}
+
+// Stats are the counters of an LRU.
+type Stats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Evictions     uint64 `json:"evictions"`
+	Invalidations uint64 `json:"invalidations"`
+	Entries       int    `json:"entries"`
+}
+
+// Stats returns the hit, miss, eviction and invalidation counters.
+func (c *LRU) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		Hits:          c.hits,
+		Misses:        c.misses,
+		Evictions:     c.evictions,
+		Invalidations: c.invalidations,
+		Entries:       c.ll.Len(),
+	}
+}
+
+// Purge removes all entries.
+func (c *LRU) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.version++
+	c.invalidated = c.now()
+	c.invalidations += uint64(c.ll.Len())
+	c.ll.Init()
+	c.entries = make(map[string]*list.Element)
+	c.tagged = make(map[string]map[string]struct{})
+}
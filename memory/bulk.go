@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fwojciec/litag-example/domain" // use your own github username
+)
+
+// CreateAgents creates agents in bulk.
+func (s *Store) CreateAgents(ctx context.Context, args []domain.CreateAgentParams, mode domain.BulkMode) (*domain.BulkAgentsResult, error) {
+	res := &domain.BulkAgentsResult{
+		Agents: make([]*domain.Agent, len(args)),
+		Errors: make([]error, len(args)),
+	}
+	committed, err := s.runBulk(ctx, mode, res.Errors, func(t *tx, i int) error {
+		agent, err := t.createAgent(args[i])
+		if err != nil {
+			return err
+		}
+		res.Agents[i] = &agent
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Committed = committed
+	if !committed {
+		res.Agents = make([]*domain.Agent, len(args))
+	}
+	return res, nil
+}
+
+// CreateAuthors creates authors in bulk.
+func (s *Store) CreateAuthors(ctx context.Context, args []domain.CreateAuthorParams, mode domain.BulkMode) (*domain.BulkAuthorsResult, error) {
+	res := &domain.BulkAuthorsResult{
+		Authors: make([]*domain.Author, len(args)),
+		Errors:  make([]error, len(args)),
+	}
+	committed, err := s.runBulk(ctx, mode, res.Errors, func(t *tx, i int) error {
+		author, err := t.createAuthorWithEvent(args[i])
+		if err != nil {
+			return err
+		}
+		res.Authors[i] = &author
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Committed = committed
+	if !committed {
+		res.Authors = make([]*domain.Author, len(args))
+	}
+	return res, nil
+}
+
+// CreateBooks creates books in bulk.
+func (s *Store) CreateBooks(ctx context.Context, args []domain.BulkCreateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+	res := &domain.BulkBooksResult{
+		Books:  make([]*domain.Book, len(args)),
+		Errors: make([]error, len(args)),
+	}
+	for i, arg := range args {
+		if len(arg.Contributors) == 0 {
+			res.Errors[i] = domain.ErrBookWithoutAuthors
+		}
+	}
+	committed, err := s.runBulk(ctx, mode, res.Errors, func(t *tx, i int) error {
+		book, err := t.createBookWithEvent(args[i].Book, args[i].Contributors, args[i].GenreIDs, args[i].Tags)
+		if err != nil {
+			return err
+		}
+		res.Books[i] = &book
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Committed = committed
+	if !committed {
+		res.Books = make([]*domain.Book, len(args))
+	}
+	return res, nil
+}
+
+// UpdateBooks updates books in bulk.
+func (s *Store) UpdateBooks(ctx context.Context, args []domain.BulkUpdateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+	res := &domain.BulkBooksResult{
+		Books:  make([]*domain.Book, len(args)),
+		Errors: make([]error, len(args)),
+	}
+	for i, arg := range args {
+		if len(arg.Contributors) == 0 {
+			res.Errors[i] = domain.ErrBookWithoutAuthors
+		}
+	}
+	committed, err := s.runBulk(ctx, mode, res.Errors, func(t *tx, i int) error {
+		book, err := t.updateBookWithEvent(args[i].Book, args[i].Contributors, args[i].GenreIDs, args[i].Tags)
+		if err != nil {
+			return err
+		}
+		res.Books[i] = &book
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.Committed = committed
+	if !committed {
+		res.Books = make([]*domain.Book, len(args))
+	}
+	return res, nil
+}
+
+var errRollback = errors.New("rollback")
+
+// runBulk processes each item whose errs entry is nil in its own savepoint,
+// recording the error of every item that fails in errs. Unless the mode is
+// domain.BulkBestEffort, a failed item rolls back the whole operation. It
+// reports whether the changes were committed.
+func (s *Store) runBulk(ctx context.Context, mode domain.BulkMode, errs []error, one func(t *tx, i int) error) (bool, error) {
+	err := s.write(ctx, func(t *tx) error {
+		for i := range errs {
+			if errs[i] != nil {
+				continue
+			}
+			errs[i] = t.savepoint(func() error { return one(t, i) })
+		}
+		if mode != domain.BulkBestEffort {
+			for _, err := range errs {
+				if err != nil {
+					return errRollback
+				}
+			}
+		}
+		return nil
+	})
+	if err == errRollback {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,1570 @@
+// Package memory implements the datalayer in memory, for tests and for local
+// development without a database. It follows the semantics of the PostgreSQL
+// implementation, including ordering, errors and cascades, and reports the
+// constraint violations that have no more specific error as
+// *domain.ConstraintError values with the constraint names of the schema.
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain" // use your own github username
+)
+
+var _ domain.Repository = (*Store)(nil)
+
+// Store is a thread-safe in-memory implementation of domain.Repository. It
+// also implements the store of the webhook dispatcher.
+//
+// Every write works on a copy of the data which replaces the original once
+// the write succeeds, so failed writes leave no trace, like a rolled back
+// transaction. Writes are serialized. The store copies the pointer fields of
+// the records it is given, but the records it returns share them with the
+// store, so they must not be modified through them.
+type Store struct {
+	mu   sync.RWMutex
+	data *state
+	seqs map[string]int64
+}
+
+// New returns a new, empty instance of Store.
+func New() *Store {
+	return &Store{
+		data: newState(),
+		seqs: make(map[string]int64),
+	}
+}
+
+// state holds the records of every table.
+type state struct {
+	agencies    map[int64]domain.Agency
+	agents      map[int64]domain.Agent
+	authors     map[int64]domain.Author
+	books       map[int64]domain.Book
+	bookAuthors []bookAuthor
+	deals       map[int64]domain.Deal
+	editions    map[int64]domain.Edition
+	genres      map[int64]domain.Genre
+	bookGenres  []bookGenre
+	bookTags    []bookTag
+	publishers  map[int64]domain.Publisher
+	reps        map[int64]domain.Representation
+	rates       map[rateKey]domain.RoyaltyRate
+	sales       map[int64]domain.Sales
+	series      map[int64]domain.Series
+	submissions map[int64]domain.Submission
+	transitions map[int64]domain.SubmissionTransition
+	webhooks    map[int64]domain.Webhook
+	deliveries  map[int64]domain.WebhookDelivery
+}
+
+// bookAuthor credits an author on a book, like a row of book_authors.
+type bookAuthor struct {
+	id     int64
+	bookID int64
+	domain.Contributor
+}
+
+// bookGenre classifies a book in a genre, like a row of book_genres.
+type bookGenre struct {
+	id      int64
+	bookID  int64
+	genreID int64
+}
+
+// bookTag tags a book, like a row of book_tags.
+type bookTag struct {
+	id     int64
+	bookID int64
+	tag    string
+}
+
+// rateKey identifies the royalty rate of a deal for an edition.
+type rateKey struct {
+	dealID    int64
+	editionID int64
+}
+
+func newState() *state {
+	return &state{
+		agencies:    make(map[int64]domain.Agency),
+		agents:      make(map[int64]domain.Agent),
+		authors:     make(map[int64]domain.Author),
+		books:       make(map[int64]domain.Book),
+		deals:       make(map[int64]domain.Deal),
+		editions:    make(map[int64]domain.Edition),
+		genres:      make(map[int64]domain.Genre),
+		publishers:  make(map[int64]domain.Publisher),
+		reps:        make(map[int64]domain.Representation),
+		rates:       make(map[rateKey]domain.RoyaltyRate),
+		sales:       make(map[int64]domain.Sales),
+		series:      make(map[int64]domain.Series),
+		submissions: make(map[int64]domain.Submission),
+		transitions: make(map[int64]domain.SubmissionTransition),
+		webhooks:    make(map[int64]domain.Webhook),
+		deliveries:  make(map[int64]domain.WebhookDelivery),
+	}
+}
+
+// clone returns a copy of the state. Records are copied by value; the values
+// their pointer fields and slices point to are never modified in place, so
+// they can be shared.
+func (st *state) clone() *state {
+	c := &state{
+		agencies:    make(map[int64]domain.Agency, len(st.agencies)),
+		agents:      make(map[int64]domain.Agent, len(st.agents)),
+		authors:     make(map[int64]domain.Author, len(st.authors)),
+		books:       make(map[int64]domain.Book, len(st.books)),
+		bookAuthors: append([]bookAuthor(nil), st.bookAuthors...),
+		deals:       make(map[int64]domain.Deal, len(st.deals)),
+		editions:    make(map[int64]domain.Edition, len(st.editions)),
+		genres:      make(map[int64]domain.Genre, len(st.genres)),
+		bookGenres:  append([]bookGenre(nil), st.bookGenres...),
+		bookTags:    append([]bookTag(nil), st.bookTags...),
+		publishers:  make(map[int64]domain.Publisher, len(st.publishers)),
+		reps:        make(map[int64]domain.Representation, len(st.reps)),
+		rates:       make(map[rateKey]domain.RoyaltyRate, len(st.rates)),
+		sales:       make(map[int64]domain.Sales, len(st.sales)),
+		series:      make(map[int64]domain.Series, len(st.series)),
+		submissions: make(map[int64]domain.Submission, len(st.submissions)),
+		transitions: make(map[int64]domain.SubmissionTransition, len(st.transitions)),
+		webhooks:    make(map[int64]domain.Webhook, len(st.webhooks)),
+		deliveries:  make(map[int64]domain.WebhookDelivery, len(st.deliveries)),
+	}
+	for id, v := range st.agencies {
+		c.agencies[id] = v
+	}
+	for id, v := range st.agents {
+		c.agents[id] = v
+	}
+	for id, v := range st.authors {
+		c.authors[id] = v
+	}
+	for id, v := range st.books {
+		c.books[id] = v
+	}
+	for id, v := range st.deals {
+		c.deals[id] = v
+	}
+	for id, v := range st.editions {
+		c.editions[id] = v
+	}
+	for id, v := range st.genres {
+		c.genres[id] = v
+	}
+	for id, v := range st.publishers {
+		c.publishers[id] = v
+	}
+	for id, v := range st.reps {
+		c.reps[id] = v
+	}
+	for key, v := range st.rates {
+		c.rates[key] = v
+	}
+	for id, v := range st.sales {
+		c.sales[id] = v
+	}
+	for id, v := range st.series {
+		c.series[id] = v
+	}
+	for id, v := range st.submissions {
+		c.submissions[id] = v
+	}
+	for id, v := range st.transitions {
+		c.transitions[id] = v
+	}
+	for id, v := range st.webhooks {
+		c.webhooks[id] = v
+	}
+	for id, v := range st.deliveries {
+		c.deliveries[id] = v
+	}
+	return c
+}
+
+// read calls fn with the current state.
+func (s *Store) read(ctx context.Context, fn func(st *state) error) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.data)
+}
+
+// write calls fn with a transaction working on a copy of the current state.
+// The copy replaces the current state if fn succeeds and the deferred
+// constraints hold.
+func (s *Store) write(ctx context.Context, fn func(t *tx) error) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &tx{
+		state:      s.data.clone(),
+		store:      s,
+		now:        time.Now(),
+		checkBooks: make(map[int64]bool),
+	}
+	if err := fn(t); err != nil {
+		return err
+	}
+	if err := t.checkBooksHaveAuthors(); err != nil {
+		return err
+	}
+	s.data = t.state
+	return nil
+}
+
+// contextError returns the error of a done context, reporting an expired
+// deadline as domain.ErrTimeout.
+func contextError(ctx context.Context) error {
+	if err := ctx.Err(); err != context.DeadlineExceeded {
+		return err
+	}
+	return domain.ErrTimeout
+}
+
+// nextID returns the next value of the sequence of the table. Like
+// PostgreSQL sequences, it is not rolled back with failed writes.
+func (s *Store) nextID(table string) int64 {
+	s.seqs[table]++
+	return s.seqs[table]
+}
+
+// agencies
+
+// CreateAgency creates an agency.
+func (s *Store) CreateAgency(ctx context.Context, args domain.CreateAgencyParams) (domain.Agency, error) {
+	var agency domain.Agency
+	err := s.write(ctx, func(t *tx) error {
+		agency = t.createAgency(args.Name)
+		return nil
+	})
+	return agency, err
+}
+
+// GetAgency returns an agency.
+func (s *Store) GetAgency(ctx context.Context, id int64) (domain.Agency, error) {
+	var agency domain.Agency
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		agency, err = st.getAgency(id)
+		return err
+	})
+	return agency, err
+}
+
+// ListAgencies returns all agencies ordered by name.
+func (s *Store) ListAgencies(ctx context.Context) ([]domain.Agency, error) {
+	agencies := []domain.Agency{}
+	err := s.read(ctx, func(st *state) error {
+		for _, agency := range st.agencies {
+			agencies = append(agencies, agency)
+		}
+		return nil
+	})
+	sort.Slice(agencies, func(i, j int) bool {
+		return less(agencies[i].Name, agencies[j].Name, agencies[i].ID, agencies[j].ID)
+	})
+	return agencies, err
+}
+
+// UpdateAgency updates an agency.
+func (s *Store) UpdateAgency(ctx context.Context, args domain.UpdateAgencyParams) (domain.Agency, error) {
+	var agency domain.Agency
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		agency, err = t.updateAgency(args)
+		return err
+	})
+	return agency, err
+}
+
+// DeleteAgency deletes an agency, leaving its agents without one.
+func (s *Store) DeleteAgency(ctx context.Context, id int64) (domain.Agency, error) {
+	var agency domain.Agency
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		agency, err = t.deleteAgency(id)
+		return err
+	})
+	return agency, err
+}
+
+// agents
+
+// CreateAgent creates an agent.
+func (s *Store) CreateAgent(ctx context.Context, args domain.CreateAgentParams) (domain.Agent, error) {
+	var agent domain.Agent
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		agent, err = t.createAgent(args)
+		return err
+	})
+	return agent, err
+}
+
+// GetAgent returns an agent.
+func (s *Store) GetAgent(ctx context.Context, id int64) (domain.Agent, error) {
+	var agent domain.Agent
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		agent, err = st.getAgent(id)
+		return err
+	})
+	return agent, err
+}
+
+// ListAgents returns all agents ordered by name.
+func (s *Store) ListAgents(ctx context.Context) ([]domain.Agent, error) {
+	agents := []domain.Agent{}
+	err := s.read(ctx, func(st *state) error {
+		for _, agent := range st.agents {
+			if agent.DeletedAt == nil {
+				agents = append(agents, agent)
+			}
+		}
+		return nil
+	})
+	sortAgents(agents)
+	return agents, err
+}
+
+// ListAgentsByAgencyID returns the agents of the agency ordered by name.
+func (s *Store) ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]domain.Agent, error) {
+	agents := []domain.Agent{}
+	err := s.read(ctx, func(st *state) error {
+		agents = append(agents, st.listAgentsByAgencyID(agencyID)...)
+		return nil
+	})
+	sortAgents(agents)
+	return agents, err
+}
+
+// UpdateAgent updates an agent.
+func (s *Store) UpdateAgent(ctx context.Context, args domain.UpdateAgentParams) (domain.Agent, error) {
+	var agent domain.Agent
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		agent, err = t.updateAgent(args)
+		return err
+	})
+	return agent, err
+}
+
+// authors
+
+// GetAuthor returns an author.
+func (s *Store) GetAuthor(ctx context.Context, id int64) (domain.Author, error) {
+	var author domain.Author
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		author, err = st.getAuthor(id)
+		return err
+	})
+	return author, err
+}
+
+// ListAuthors returns all authors ordered by name.
+func (s *Store) ListAuthors(ctx context.Context) ([]domain.Author, error) {
+	authors := []domain.Author{}
+	err := s.read(ctx, func(st *state) error {
+		for _, author := range st.authors {
+			authors = append(authors, author)
+		}
+		return nil
+	})
+	sortAuthors(authors)
+	return authors, err
+}
+
+// ListAuthorsByAgencyID returns the authors represented by the agents of the
+// agency ordered by name.
+func (s *Store) ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]domain.Author, error) {
+	authors := []domain.Author{}
+	err := s.read(ctx, func(st *state) error {
+		authors = append(authors, st.listAuthorsByAgencyID(agencyID)...)
+		return nil
+	})
+	sortAuthors(authors)
+	return authors, err
+}
+
+// ListAuthorsByAgentID returns the authors represented by the agent ordered
+// by id.
+func (s *Store) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]domain.Author, error) {
+	authors := []domain.Author{}
+	err := s.read(ctx, func(st *state) error {
+		authors = append(authors, st.listAuthorsByAgentID(agentID)...)
+		return nil
+	})
+	return authors, err
+}
+
+// ListAuthorsByBookID returns the authors of the book in position order.
+func (s *Store) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]domain.Author, error) {
+	authors := []domain.Author{}
+	err := s.read(ctx, func(st *state) error {
+		for _, ba := range st.listBookAuthors(bookID) {
+			authors = append(authors, st.authors[ba.AuthorID])
+		}
+		return nil
+	})
+	return authors, err
+}
+
+// ListFormerAuthorsByAgentID returns the authors the agent represented in the
+// past but no longer does, ordered by name.
+func (s *Store) ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]domain.Author, error) {
+	authors := []domain.Author{}
+	err := s.read(ctx, func(st *state) error {
+		represented, current := make(map[int64]bool), make(map[int64]bool)
+		for _, r := range st.reps {
+			if r.AgentID == agentID {
+				represented[r.AuthorID] = true
+				current[r.AuthorID] = current[r.AuthorID] || r.EndedAt == nil
+			}
+		}
+		for id := range represented {
+			if !current[id] {
+				authors = append(authors, st.authors[id])
+			}
+		}
+		return nil
+	})
+	sortAuthors(authors)
+	return authors, err
+}
+
+// books
+
+// GetBook returns a book.
+func (s *Store) GetBook(ctx context.Context, id int64) (domain.Book, error) {
+	var book domain.Book
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		book, err = st.getBook(id)
+		return err
+	})
+	return book, err
+}
+
+// GetBookByISBN returns the book with the ISBN.
+func (s *Store) GetBookByISBN(ctx context.Context, isbn domain.ISBN) (domain.Book, error) {
+	var book domain.Book
+	err := s.read(ctx, func(st *state) error {
+		for _, b := range st.books {
+			if b.ISBN != nil && *b.ISBN == isbn {
+				book = b
+				return nil
+			}
+		}
+		return domain.ErrNotFound
+	})
+	return book, err
+}
+
+// ListBooks returns all books ordered by title.
+func (s *Store) ListBooks(ctx context.Context) ([]domain.Book, error) {
+	books := []domain.Book{}
+	err := s.read(ctx, func(st *state) error {
+		for _, book := range st.books {
+			books = append(books, book)
+		}
+		return nil
+	})
+	sortBooks(books)
+	return books, err
+}
+
+// ListBooksByAgencyID returns the books by authors represented by the agents
+// of the agency ordered by title.
+func (s *Store) ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]domain.Book, error) {
+	books := []domain.Book{}
+	err := s.read(ctx, func(st *state) error {
+		represented := make(map[int64]bool)
+		for _, author := range st.listAuthorsByAgencyID(agencyID) {
+			represented[author.ID] = true
+		}
+		seen := make(map[int64]bool)
+		for _, ba := range st.bookAuthors {
+			if represented[ba.AuthorID] && !seen[ba.bookID] {
+				seen[ba.bookID] = true
+				books = append(books, st.books[ba.bookID])
+			}
+		}
+		return nil
+	})
+	sortBooks(books)
+	return books, err
+}
+
+// ListBooksByAuthorID returns the books of the author.
+func (s *Store) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]domain.Book, error) {
+	books := []domain.Book{}
+	err := s.read(ctx, func(st *state) error {
+		for _, ba := range st.bookAuthors {
+			if ba.AuthorID == authorID {
+				books = append(books, st.books[ba.bookID])
+			}
+		}
+		return nil
+	})
+	return books, err
+}
+
+// ListBooksByGenreID returns the books classified in the genre ordered by
+// title, including those in its subgenres if includeSubgenres is set.
+func (s *Store) ListBooksByGenreID(ctx context.Context, genreID int64, includeSubgenres bool) ([]domain.Book, error) {
+	books := []domain.Book{}
+	err := s.read(ctx, func(st *state) error {
+		genres := map[int64]bool{genreID: true}
+		if includeSubgenres {
+			genres = st.genreTree(genreID)
+		}
+		seen := make(map[int64]bool)
+		for _, bg := range st.bookGenres {
+			if genres[bg.genreID] && !seen[bg.bookID] {
+				seen[bg.bookID] = true
+				books = append(books, st.books[bg.bookID])
+			}
+		}
+		return nil
+	})
+	sortBooks(books)
+	return books, err
+}
+
+// ListBooksByPublisherID returns the books of the publisher ordered by title.
+func (s *Store) ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]domain.Book, error) {
+	books := []domain.Book{}
+	err := s.read(ctx, func(st *state) error {
+		for _, book := range st.books {
+			if book.PublisherID != nil && *book.PublisherID == publisherID {
+				books = append(books, book)
+			}
+		}
+		return nil
+	})
+	sortBooks(books)
+	return books, err
+}
+
+// ListBooksBySeriesID returns the books of the series ordered by position.
+func (s *Store) ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]domain.Book, error) {
+	books := []domain.Book{}
+	err := s.read(ctx, func(st *state) error {
+		books = append(books, st.listBooksBySeriesID(seriesID)...)
+		return nil
+	})
+	return books, err
+}
+
+// ListBooksByTag returns the books with the tag ordered by title.
+func (s *Store) ListBooksByTag(ctx context.Context, tag string) ([]domain.Book, error) {
+	books := []domain.Book{}
+	err := s.read(ctx, func(st *state) error {
+		for _, bt := range st.bookTags {
+			if bt.tag == tag {
+				books = append(books, st.books[bt.bookID])
+			}
+		}
+		return nil
+	})
+	sortBooks(books)
+	return books, err
+}
+
+// ListContributorsByBookID returns the contributors of the book in position
+// order.
+func (s *Store) ListContributorsByBookID(ctx context.Context, bookID int64) ([]domain.Contributor, error) {
+	contributors := []domain.Contributor{}
+	err := s.read(ctx, func(st *state) error {
+		for _, ba := range st.listBookAuthors(bookID) {
+			contributors = append(contributors, ba.Contributor)
+		}
+		return nil
+	})
+	return contributors, err
+}
+
+// ListOrphanBooks returns the books without any authors ordered by title.
+func (s *Store) ListOrphanBooks(ctx context.Context) ([]domain.Book, error) {
+	books := []domain.Book{}
+	err := s.read(ctx, func(st *state) error {
+		for _, book := range st.books {
+			if !st.hasAuthors(book.ID) {
+				books = append(books, book)
+			}
+		}
+		return nil
+	})
+	sortBooks(books)
+	return books, err
+}
+
+// ListTagsByBookID returns the tags of the book in alphabetical order.
+func (s *Store) ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error) {
+	tags := []string{}
+	err := s.read(ctx, func(st *state) error {
+		for _, bt := range st.bookTags {
+			if bt.bookID == bookID {
+				tags = append(tags, bt.tag)
+			}
+		}
+		return nil
+	})
+	sort.Strings(tags)
+	return tags, err
+}
+
+// deals
+
+// CreateDeal creates a deal.
+func (s *Store) CreateDeal(ctx context.Context, args domain.CreateDealParams) (domain.Deal, error) {
+	var deal domain.Deal
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		deal, err = t.createDeal(args)
+		return err
+	})
+	return deal, err
+}
+
+// GetDeal returns a deal.
+func (s *Store) GetDeal(ctx context.Context, id int64) (domain.Deal, error) {
+	var deal domain.Deal
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		deal, err = st.getDeal(id)
+		return err
+	})
+	return deal, err
+}
+
+// ListDealsByAgentID returns the deals of the agent ordered by id.
+func (s *Store) ListDealsByAgentID(ctx context.Context, agentID int64) ([]domain.Deal, error) {
+	deals := []domain.Deal{}
+	err := s.read(ctx, func(st *state) error {
+		for _, d := range st.deals {
+			if d.AgentID == agentID {
+				deals = append(deals, d)
+			}
+		}
+		return nil
+	})
+	sortDeals(deals)
+	return deals, err
+}
+
+// ListDealsByBookID returns the deals of the book ordered by id.
+func (s *Store) ListDealsByBookID(ctx context.Context, bookID int64) ([]domain.Deal, error) {
+	deals := []domain.Deal{}
+	err := s.read(ctx, func(st *state) error {
+		for _, d := range st.deals {
+			if d.BookID == bookID {
+				deals = append(deals, d)
+			}
+		}
+		return nil
+	})
+	sortDeals(deals)
+	return deals, err
+}
+
+// editionFormats lists the edition formats in the order of the
+// edition_format type.
+var editionFormats = []domain.EditionFormat{
+	domain.EditionHardcover,
+	domain.EditionPaperback,
+	domain.EditionEbook,
+	domain.EditionAudiobook,
+}
+
+// ListAvailableRights returns, for every book with any, the formats whose
+// rights in the territory and language no deal holds, ordered by book title
+// and then format. A worldwide deal holds the rights in every territory, and
+// a deal in any territory holds a part of the worldwide rights.
+func (s *Store) ListAvailableRights(ctx context.Context, territory string, language domain.LanguageCode) ([]domain.AvailableRights, error) {
+	rights := []domain.AvailableRights{}
+	err := s.read(ctx, func(st *state) error {
+		type held struct {
+			bookID int64
+			format domain.EditionFormat
+		}
+		holds := make(map[held]bool)
+		for _, d := range st.deals {
+			if d.Status == domain.DealTerminated || d.Language != language {
+				continue
+			}
+			if d.Territory == "WORLD" || d.Territory == territory || territory == "WORLD" {
+				holds[held{d.BookID, d.Format}] = true
+			}
+		}
+		books := make([]domain.Book, 0, len(st.books))
+		for _, book := range st.books {
+			books = append(books, book)
+		}
+		sortBooks(books)
+		for _, book := range books {
+			var formats []domain.EditionFormat
+			for _, format := range editionFormats {
+				if !holds[held{book.ID, format}] {
+					formats = append(formats, format)
+				}
+			}
+			if len(formats) > 0 {
+				rights = append(rights, domain.AvailableRights{BookID: book.ID, Formats: formats})
+			}
+		}
+		return nil
+	})
+	return rights, err
+}
+
+// UpdateDeal updates a deal.
+func (s *Store) UpdateDeal(ctx context.Context, args domain.UpdateDealParams) (domain.Deal, error) {
+	var deal domain.Deal
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		deal, err = t.updateDeal(args)
+		return err
+	})
+	return deal, err
+}
+
+// DeleteDeal deletes a deal, cascading to its royalty rates and sales.
+func (s *Store) DeleteDeal(ctx context.Context, id int64) (domain.Deal, error) {
+	var deal domain.Deal
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		if deal, err = t.getDeal(id); err != nil {
+			return err
+		}
+		t.deleteDeal(id)
+		return nil
+	})
+	return deal, err
+}
+
+// editions
+
+// CreateEdition creates an edition.
+func (s *Store) CreateEdition(ctx context.Context, args domain.CreateEditionParams) (domain.Edition, error) {
+	var edition domain.Edition
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		edition, err = t.createEdition(args)
+		return err
+	})
+	return edition, err
+}
+
+// GetEdition returns an edition.
+func (s *Store) GetEdition(ctx context.Context, id int64) (domain.Edition, error) {
+	var edition domain.Edition
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		edition, err = st.getEdition(id)
+		return err
+	})
+	return edition, err
+}
+
+// GetEditionByISBN returns the edition with the ISBN.
+func (s *Store) GetEditionByISBN(ctx context.Context, isbn domain.ISBN) (domain.Edition, error) {
+	var edition domain.Edition
+	err := s.read(ctx, func(st *state) error {
+		for _, e := range st.editions {
+			if e.ISBN != nil && *e.ISBN == isbn {
+				edition = e
+				return nil
+			}
+		}
+		return domain.ErrNotFound
+	})
+	return edition, err
+}
+
+// ListEditions returns the editions matching the set filters ordered by id.
+// Like in SQL, editions without a publication date never match a date
+// filter.
+func (s *Store) ListEditions(ctx context.Context, args domain.ListEditionsParams) ([]domain.Edition, error) {
+	editions := []domain.Edition{}
+	err := s.read(ctx, func(st *state) error {
+		for _, e := range st.editions {
+			switch {
+			case args.BookID != nil && e.BookID != *args.BookID,
+				args.Format != nil && e.Format != *args.Format,
+				args.PublishedFrom != nil && (e.PublishedOn == nil || e.PublishedOn.Time().Before(args.PublishedFrom.Time())),
+				args.PublishedTo != nil && (e.PublishedOn == nil || e.PublishedOn.Time().After(args.PublishedTo.Time())):
+				continue
+			}
+			editions = append(editions, e)
+		}
+		return nil
+	})
+	sortEditions(editions)
+	return editions, err
+}
+
+// ListEditionsByBookID returns the editions of the book ordered by id.
+func (s *Store) ListEditionsByBookID(ctx context.Context, bookID int64) ([]domain.Edition, error) {
+	editions := []domain.Edition{}
+	err := s.read(ctx, func(st *state) error {
+		for _, e := range st.editions {
+			if e.BookID == bookID {
+				editions = append(editions, e)
+			}
+		}
+		return nil
+	})
+	sortEditions(editions)
+	return editions, err
+}
+
+// UpdateEdition updates an edition.
+func (s *Store) UpdateEdition(ctx context.Context, args domain.UpdateEditionParams) (domain.Edition, error) {
+	var edition domain.Edition
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		edition, err = t.updateEdition(args)
+		return err
+	})
+	return edition, err
+}
+
+// DeleteEdition deletes an edition, cascading to its royalty rates and
+// sales.
+func (s *Store) DeleteEdition(ctx context.Context, id int64) (domain.Edition, error) {
+	var edition domain.Edition
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		if edition, err = t.getEdition(id); err != nil {
+			return err
+		}
+		t.deleteEdition(id)
+		return nil
+	})
+	return edition, err
+}
+
+// genres
+
+// CreateGenre creates a genre.
+func (s *Store) CreateGenre(ctx context.Context, args domain.CreateGenreParams) (domain.Genre, error) {
+	var genre domain.Genre
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		genre, err = t.createGenre(args)
+		return err
+	})
+	return genre, err
+}
+
+// GetGenre returns a genre.
+func (s *Store) GetGenre(ctx context.Context, id int64) (domain.Genre, error) {
+	var genre domain.Genre
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		genre, err = st.getGenre(id)
+		return err
+	})
+	return genre, err
+}
+
+// ListGenres returns all genres ordered by name.
+func (s *Store) ListGenres(ctx context.Context) ([]domain.Genre, error) {
+	genres := []domain.Genre{}
+	err := s.read(ctx, func(st *state) error {
+		for _, genre := range st.genres {
+			genres = append(genres, genre)
+		}
+		return nil
+	})
+	sortGenres(genres)
+	return genres, err
+}
+
+// ListGenresByParentID returns the direct subgenres of the genre ordered by
+// name.
+func (s *Store) ListGenresByParentID(ctx context.Context, parentID int64) ([]domain.Genre, error) {
+	genres := []domain.Genre{}
+	err := s.read(ctx, func(st *state) error {
+		genres = append(genres, st.listGenresByParentID(parentID)...)
+		return nil
+	})
+	sortGenres(genres)
+	return genres, err
+}
+
+// ListGenresByBookID returns the genres of the book ordered by name.
+func (s *Store) ListGenresByBookID(ctx context.Context, bookID int64) ([]domain.Genre, error) {
+	genres := []domain.Genre{}
+	err := s.read(ctx, func(st *state) error {
+		for _, bg := range st.bookGenres {
+			if bg.bookID == bookID {
+				genres = append(genres, st.genres[bg.genreID])
+			}
+		}
+		return nil
+	})
+	sortGenres(genres)
+	return genres, err
+}
+
+// DeleteGenre deletes a genre without subgenres, cascading to its book
+// associations.
+func (s *Store) DeleteGenre(ctx context.Context, id int64) (domain.Genre, error) {
+	var genre domain.Genre
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		genre, err = t.deleteGenre(id)
+		return err
+	})
+	return genre, err
+}
+
+// publishers
+
+// CreatePublisher creates a publisher.
+func (s *Store) CreatePublisher(ctx context.Context, args domain.CreatePublisherParams) (domain.Publisher, error) {
+	var publisher domain.Publisher
+	err := s.write(ctx, func(t *tx) error {
+		publisher = t.createPublisher(args.Name)
+		return nil
+	})
+	return publisher, err
+}
+
+// GetPublisher returns a publisher.
+func (s *Store) GetPublisher(ctx context.Context, id int64) (domain.Publisher, error) {
+	var publisher domain.Publisher
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		publisher, err = st.getPublisher(id)
+		return err
+	})
+	return publisher, err
+}
+
+// ListPublishers returns all publishers ordered by name.
+func (s *Store) ListPublishers(ctx context.Context) ([]domain.Publisher, error) {
+	publishers := []domain.Publisher{}
+	err := s.read(ctx, func(st *state) error {
+		for _, publisher := range st.publishers {
+			publishers = append(publishers, publisher)
+		}
+		return nil
+	})
+	sort.Slice(publishers, func(i, j int) bool {
+		return less(publishers[i].Name, publishers[j].Name, publishers[i].ID, publishers[j].ID)
+	})
+	return publishers, err
+}
+
+// UpdatePublisher updates a publisher.
+func (s *Store) UpdatePublisher(ctx context.Context, args domain.UpdatePublisherParams) (domain.Publisher, error) {
+	var publisher domain.Publisher
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		publisher, err = t.updatePublisher(args)
+		return err
+	})
+	return publisher, err
+}
+
+// DeletePublisher deletes a publisher, leaving its books without one.
+func (s *Store) DeletePublisher(ctx context.Context, id int64) (domain.Publisher, error) {
+	var publisher domain.Publisher
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		publisher, err = t.deletePublisher(id)
+		return err
+	})
+	return publisher, err
+}
+
+// representations
+
+// CreateRepresentation creates a representation that is not primary.
+func (s *Store) CreateRepresentation(ctx context.Context, args domain.CreateRepresentationParams) (domain.Representation, error) {
+	var representation domain.Representation
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		representation, err = t.createRepresentation(args)
+		return err
+	})
+	return representation, err
+}
+
+// ListRepresentationsByAuthorID returns the representations of the author
+// ordered by start date and then id.
+func (s *Store) ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]domain.Representation, error) {
+	representations := []domain.Representation{}
+	err := s.read(ctx, func(st *state) error {
+		for _, r := range st.reps {
+			if r.AuthorID == authorID {
+				representations = append(representations, r)
+			}
+		}
+		return nil
+	})
+	sort.Slice(representations, func(i, j int) bool {
+		a, b := representations[i], representations[j]
+		if a.StartedAt != b.StartedAt {
+			return a.StartedAt.Time().Before(b.StartedAt.Time())
+		}
+		return a.ID < b.ID
+	})
+	return representations, err
+}
+
+// royalties
+
+// SetRoyaltyRate sets the royalty rate of the deal for the edition,
+// replacing the rate set before.
+func (s *Store) SetRoyaltyRate(ctx context.Context, dealID, editionID int64, rate int) (domain.RoyaltyRate, error) {
+	var res domain.RoyaltyRate
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		res, err = t.setRoyaltyRate(dealID, editionID, rate)
+		return err
+	})
+	return res, err
+}
+
+// ListRoyaltyRatesByDealID returns the royalty rates of the deal ordered by
+// edition id.
+func (s *Store) ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]domain.RoyaltyRate, error) {
+	rates := []domain.RoyaltyRate{}
+	err := s.read(ctx, func(st *state) error {
+		for _, r := range st.rates {
+			if r.DealID == dealID {
+				rates = append(rates, r)
+			}
+		}
+		return nil
+	})
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].EditionID < rates[j].EditionID
+	})
+	return rates, err
+}
+
+// DeleteRoyaltyRate deletes the royalty rate of the deal for the edition.
+func (s *Store) DeleteRoyaltyRate(ctx context.Context, dealID, editionID int64) (domain.RoyaltyRate, error) {
+	var rate domain.RoyaltyRate
+	err := s.write(ctx, func(t *tx) error {
+		key := rateKey{dealID, editionID}
+		var ok bool
+		if rate, ok = t.rates[key]; !ok {
+			return domain.ErrNotFound
+		}
+		delete(t.rates, key)
+		return nil
+	})
+	return rate, err
+}
+
+// RecordSales records the sales of an edition under a deal in a period,
+// replacing the sales recorded before for the same period.
+func (s *Store) RecordSales(ctx context.Context, args domain.RecordSalesParams) (domain.Sales, error) {
+	var sales domain.Sales
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		sales, err = t.recordSales(args)
+		return err
+	})
+	return sales, err
+}
+
+// ListSalesByDealID returns the sales of the deal ordered by period and then
+// edition id.
+func (s *Store) ListSalesByDealID(ctx context.Context, dealID int64) ([]domain.Sales, error) {
+	sales := []domain.Sales{}
+	err := s.read(ctx, func(st *state) error {
+		for _, v := range st.sales {
+			if v.DealID == dealID {
+				sales = append(sales, v)
+			}
+		}
+		return nil
+	})
+	sort.Slice(sales, func(i, j int) bool {
+		if sales[i].Period != sales[j].Period {
+			return sales[i].Period < sales[j].Period
+		}
+		return sales[i].EditionID < sales[j].EditionID
+	})
+	return sales, err
+}
+
+// DeleteSales deletes a sales record.
+func (s *Store) DeleteSales(ctx context.Context, id int64) (domain.Sales, error) {
+	var sales domain.Sales
+	err := s.write(ctx, func(t *tx) error {
+		var ok bool
+		if sales, ok = t.sales[id]; !ok {
+			return domain.ErrNotFound
+		}
+		delete(t.sales, id)
+		return nil
+	})
+	return sales, err
+}
+
+// GetRoyaltyStatement calculates the royalty statement of an author for a
+// quarter from the sales of the books the author is credited on in the
+// AUTHOR role, taken in the order of their ids.
+func (s *Store) GetRoyaltyStatement(ctx context.Context, authorID int64, period string) (domain.RoyaltyStatement, error) {
+	var sales []domain.RoyaltySales
+	err := s.read(ctx, func(st *state) error {
+		for _, v := range st.sales {
+			if v.Period != period {
+				continue
+			}
+			deal := st.deals[v.DealID]
+			authors := 0
+			credited := false
+			for _, ba := range st.bookAuthors {
+				if ba.bookID != deal.BookID || ba.Role != domain.ContributorAuthor {
+					continue
+				}
+				authors++
+				if ba.AuthorID == authorID {
+					credited = true
+				}
+			}
+			if !credited {
+				continue
+			}
+			rate := deal.RoyaltyRate
+			if r, ok := st.rates[rateKey{v.DealID, v.EditionID}]; ok {
+				rate = r.Rate
+			}
+			sales = append(sales, domain.RoyaltySales{
+				Sales:          v,
+				BookID:         deal.BookID,
+				AgentID:        deal.AgentID,
+				RoyaltyRate:    rate,
+				CommissionRate: deal.CommissionRate,
+				Authors:        authors,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.RoyaltyStatement{}, err
+	}
+	sort.Slice(sales, func(i, j int) bool {
+		return sales[i].ID < sales[j].ID
+	})
+	return domain.NewRoyaltyStatement(authorID, period, sales), nil
+}
+
+// series
+
+// CreateSeries creates a series.
+func (s *Store) CreateSeries(ctx context.Context, args domain.CreateSeriesParams) (domain.Series, error) {
+	var series domain.Series
+	err := s.write(ctx, func(t *tx) error {
+		series = t.createSeries(args.Title)
+		return nil
+	})
+	return series, err
+}
+
+// GetSeries returns a series.
+func (s *Store) GetSeries(ctx context.Context, id int64) (domain.Series, error) {
+	var series domain.Series
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		series, err = st.getSeries(id)
+		return err
+	})
+	return series, err
+}
+
+// ListSeries returns all series ordered by title.
+func (s *Store) ListSeries(ctx context.Context) ([]domain.Series, error) {
+	series := []domain.Series{}
+	err := s.read(ctx, func(st *state) error {
+		for _, v := range st.series {
+			series = append(series, v)
+		}
+		return nil
+	})
+	sort.Slice(series, func(i, j int) bool {
+		return less(series[i].Title, series[j].Title, series[i].ID, series[j].ID)
+	})
+	return series, err
+}
+
+// UpdateSeries updates a series.
+func (s *Store) UpdateSeries(ctx context.Context, args domain.UpdateSeriesParams) (domain.Series, error) {
+	var series domain.Series
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		series, err = t.updateSeries(args)
+		return err
+	})
+	return series, err
+}
+
+// submissions
+
+// CreateSubmission records a manuscript received by an agent.
+func (s *Store) CreateSubmission(ctx context.Context, args domain.CreateSubmissionParams) (domain.Submission, error) {
+	var submission domain.Submission
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		submission, err = t.createSubmission(args)
+		return err
+	})
+	return submission, err
+}
+
+// GetSubmission returns a submission.
+func (s *Store) GetSubmission(ctx context.Context, id int64) (domain.Submission, error) {
+	var submission domain.Submission
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		submission, err = st.getSubmission(id)
+		return err
+	})
+	return submission, err
+}
+
+// ListSubmissionsByAgentID returns the submissions to the agent in the order
+// they were received.
+func (s *Store) ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]domain.Submission, error) {
+	submissions := []domain.Submission{}
+	err := s.read(ctx, func(st *state) error {
+		for _, v := range st.submissions {
+			if v.AgentID == agentID {
+				submissions = append(submissions, v)
+			}
+		}
+		return nil
+	})
+	sortSubmissions(submissions)
+	return submissions, err
+}
+
+// ListSubmissionsByAgentIDAndStatus returns the submissions to the agent with
+// the status in the order they were received.
+func (s *Store) ListSubmissionsByAgentIDAndStatus(ctx context.Context, args domain.ListSubmissionsByAgentIDAndStatusParams) ([]domain.Submission, error) {
+	submissions := []domain.Submission{}
+	err := s.read(ctx, func(st *state) error {
+		for _, v := range st.submissions {
+			if v.AgentID == args.AgentID && v.Status == args.Status {
+				submissions = append(submissions, v)
+			}
+		}
+		return nil
+	})
+	sortSubmissions(submissions)
+	return submissions, err
+}
+
+// ListSubmissionTransitions returns the transitions of the submission in the
+// order they happened.
+func (s *Store) ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]domain.SubmissionTransition, error) {
+	transitions := []domain.SubmissionTransition{}
+	err := s.read(ctx, func(st *state) error {
+		for _, t := range st.transitions {
+			if t.SubmissionID == submissionID {
+				transitions = append(transitions, t)
+			}
+		}
+		return nil
+	})
+	sort.Slice(transitions, func(i, j int) bool {
+		if !transitions[i].TransitionedAt.Equal(transitions[j].TransitionedAt) {
+			return transitions[i].TransitionedAt.Before(transitions[j].TransitionedAt)
+		}
+		return transitions[i].ID < transitions[j].ID
+	})
+	return transitions, err
+}
+
+func (st *state) getAgency(id int64) (domain.Agency, error) {
+	agency, ok := st.agencies[id]
+	if !ok {
+		return domain.Agency{}, domain.ErrNotFound
+	}
+	return agency, nil
+}
+
+func (st *state) getAgent(id int64) (domain.Agent, error) {
+	agent, ok := st.agents[id]
+	if !ok {
+		return domain.Agent{}, domain.ErrNotFound
+	}
+	return agent, nil
+}
+
+func (st *state) listAgentsByAgencyID(agencyID int64) []domain.Agent {
+	var agents []domain.Agent
+	for _, agent := range st.agents {
+		if agent.AgencyID != nil && *agent.AgencyID == agencyID && agent.DeletedAt == nil {
+			agents = append(agents, agent)
+		}
+	}
+	return agents
+}
+
+func (st *state) getAuthor(id int64) (domain.Author, error) {
+	author, ok := st.authors[id]
+	if !ok {
+		return domain.Author{}, domain.ErrNotFound
+	}
+	return author, nil
+}
+
+func (st *state) listAuthorsByAgencyID(agencyID int64) []domain.Author {
+	var authors []domain.Author
+	for _, agent := range st.listAgentsByAgencyID(agencyID) {
+		authors = append(authors, st.listAuthorsByAgentID(agent.ID)...)
+	}
+	return authors
+}
+
+func (st *state) listAuthorsByAgentID(agentID int64) []domain.Author {
+	var authors []domain.Author
+	for _, author := range st.authors {
+		if author.AgentID == agentID {
+			authors = append(authors, author)
+		}
+	}
+	sort.Slice(authors, func(i, j int) bool { return authors[i].ID < authors[j].ID })
+	return authors
+}
+
+func (st *state) getBook(id int64) (domain.Book, error) {
+	book, ok := st.books[id]
+	if !ok {
+		return domain.Book{}, domain.ErrNotFound
+	}
+	return book, nil
+}
+
+func (st *state) listBooksBySeriesID(seriesID int64) []domain.Book {
+	var books []domain.Book
+	for _, book := range st.books {
+		if book.SeriesID != nil && *book.SeriesID == seriesID {
+			books = append(books, book)
+		}
+	}
+	sort.Slice(books, func(i, j int) bool {
+		return *books[i].SeriesPosition < *books[j].SeriesPosition
+	})
+	return books
+}
+
+// listBookAuthors returns the credits of the book ordered by position and
+// then id.
+func (st *state) listBookAuthors(bookID int64) []bookAuthor {
+	var credits []bookAuthor
+	for _, ba := range st.bookAuthors {
+		if ba.bookID == bookID {
+			credits = append(credits, ba)
+		}
+	}
+	sort.Slice(credits, func(i, j int) bool {
+		if credits[i].Position != credits[j].Position {
+			return credits[i].Position < credits[j].Position
+		}
+		return credits[i].id < credits[j].id
+	})
+	return credits
+}
+
+// listAuthorIDsByBookID returns the ids of the book's authors in position
+// order.
+func (st *state) listAuthorIDsByBookID(bookID int64) []int64 {
+	var ids []int64
+	for _, ba := range st.listBookAuthors(bookID) {
+		ids = append(ids, ba.AuthorID)
+	}
+	return ids
+}
+
+// listBooksOrphanedByAuthorID returns the books whose only author is the
+// given one, ordered by title.
+func (st *state) listBooksOrphanedByAuthorID(authorID int64) []domain.Book {
+	var books []domain.Book
+	for _, ba := range st.bookAuthors {
+		if ba.AuthorID != authorID {
+			continue
+		}
+		orphaned := true
+		for _, other := range st.bookAuthors {
+			if other.bookID == ba.bookID && other.AuthorID != authorID {
+				orphaned = false
+				break
+			}
+		}
+		if orphaned {
+			books = append(books, st.books[ba.bookID])
+		}
+	}
+	sortBooks(books)
+	return books
+}
+
+func (st *state) hasAuthors(bookID int64) bool {
+	for _, ba := range st.bookAuthors {
+		if ba.bookID == bookID {
+			return true
+		}
+	}
+	return false
+}
+
+func (st *state) getDeal(id int64) (domain.Deal, error) {
+	deal, ok := st.deals[id]
+	if !ok {
+		return domain.Deal{}, domain.ErrNotFound
+	}
+	return deal, nil
+}
+
+func (st *state) getEdition(id int64) (domain.Edition, error) {
+	edition, ok := st.editions[id]
+	if !ok {
+		return domain.Edition{}, domain.ErrNotFound
+	}
+	return edition, nil
+}
+
+func (st *state) getGenre(id int64) (domain.Genre, error) {
+	genre, ok := st.genres[id]
+	if !ok {
+		return domain.Genre{}, domain.ErrNotFound
+	}
+	return genre, nil
+}
+
+func (st *state) listGenresByParentID(parentID int64) []domain.Genre {
+	var genres []domain.Genre
+	for _, genre := range st.genres {
+		if genre.ParentID != nil && *genre.ParentID == parentID {
+			genres = append(genres, genre)
+		}
+	}
+	return genres
+}
+
+// genreTree returns the ids of the genre and all of its subgenres.
+func (st *state) genreTree(genreID int64) map[int64]bool {
+	tree := make(map[int64]bool)
+	if _, ok := st.genres[genreID]; !ok {
+		return tree
+	}
+	queue := []int64{genreID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if tree[id] {
+			continue
+		}
+		tree[id] = true
+		for _, child := range st.listGenresByParentID(id) {
+			queue = append(queue, child.ID)
+		}
+	}
+	return tree
+}
+
+func (st *state) getPublisher(id int64) (domain.Publisher, error) {
+	publisher, ok := st.publishers[id]
+	if !ok {
+		return domain.Publisher{}, domain.ErrNotFound
+	}
+	return publisher, nil
+}
+
+func (st *state) getRepresentation(id int64) (domain.Representation, error) {
+	representation, ok := st.reps[id]
+	if !ok {
+		return domain.Representation{}, domain.ErrNotFound
+	}
+	return representation, nil
+}
+
+func (st *state) getSeries(id int64) (domain.Series, error) {
+	series, ok := st.series[id]
+	if !ok {
+		return domain.Series{}, domain.ErrNotFound
+	}
+	return series, nil
+}
+
+func (st *state) getSubmission(id int64) (domain.Submission, error) {
+	submission, ok := st.submissions[id]
+	if !ok {
+		return domain.Submission{}, domain.ErrNotFound
+	}
+	return submission, nil
+}
+
+func sortAgents(agents []domain.Agent) {
+	sort.Slice(agents, func(i, j int) bool {
+		return less(agents[i].Name, agents[j].Name, agents[i].ID, agents[j].ID)
+	})
+}
+
+func sortAuthors(authors []domain.Author) {
+	sort.Slice(authors, func(i, j int) bool {
+		return less(authors[i].Name, authors[j].Name, authors[i].ID, authors[j].ID)
+	})
+}
+
+func sortBooks(books []domain.Book) {
+	sort.Slice(books, func(i, j int) bool {
+		return less(books[i].Title, books[j].Title, books[i].ID, books[j].ID)
+	})
+}
+
+func sortDeals(deals []domain.Deal) {
+	sort.Slice(deals, func(i, j int) bool {
+		return deals[i].ID < deals[j].ID
+	})
+}
+
+func sortEditions(editions []domain.Edition) {
+	sort.Slice(editions, func(i, j int) bool {
+		return editions[i].ID < editions[j].ID
+	})
+}
+
+func sortGenres(genres []domain.Genre) {
+	sort.Slice(genres, func(i, j int) bool {
+		return less(genres[i].Name, genres[j].Name, genres[i].ID, genres[j].ID)
+	})
+}
+
+func sortSubmissions(submissions []domain.Submission) {
+	sort.Slice(submissions, func(i, j int) bool {
+		if !submissions[i].ReceivedAt.Equal(submissions[j].ReceivedAt) {
+			return submissions[i].ReceivedAt.Before(submissions[j].ReceivedAt)
+		}
+		return submissions[i].ID < submissions[j].ID
+	})
+}
+
+// less orders by a name, falling back to the ids so that the order of equal
+// names is stable.
+func less(a, b string, aID, bID int64) bool {
+	if a != b {
+		return a < b
+	}
+	return aID < bID
+}
@@ -0,0 +1,346 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain"
+	"github.com/fwojciec/litag-example/memory"
+	"github.com/fwojciec/litag-example/repotest"
+)
+
+const testSeed = `{
+	"agents": [
+		{"name": "Agent B", "email": "b@example.com"},
+		{"name": "Agent A", "email": "a@example.com"}
+	],
+	"authors": [
+		{"name": "Author Two", "agent_email": "a@example.com"},
+		{"name": "Author One", "website": "https://one.com", "agent_email": "b@example.com"}
+	],
+	"books": [
+		{"title": "Shared", "description": "d", "cover": "c", "authors": ["Author One", "Author Two"]},
+		{"title": "Alone", "description": "d", "cover": "c", "authors": ["Author One"]}
+	]
+}`
+
+// newStore returns a store seeded with testSeed, in which the ids follow the
+// order of the document.
+func newStore(t *testing.T) *memory.Store {
+	t.Helper()
+	s := memory.New()
+	if err := s.Seed(context.Background(), strings.NewReader(testSeed)); err != nil {
+		t.Fatalf("failed to seed the store: %s", err)
+	}
+	return s
+}
+
+func constraintKind(err error) domain.ConstraintKind {
+	var constraintErr *domain.ConstraintError
+	if errors.As(err, &constraintErr) {
+		return constraintErr.Kind
+	}
+	return ""
+}
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) domain.Repository {
+		return memory.New()
+	})
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("Ordering", func(t *testing.T) {
+		t.Parallel()
+		s := newStore(t)
+		agents, _ := s.ListAgents(ctx)
+		if len(agents) != 2 || agents[0].Name != "Agent A" || agents[1].Name != "Agent B" {
+			t.Errorf("agents not ordered by name: %v", agents)
+		}
+		authors, _ := s.ListAuthors(ctx)
+		if len(authors) != 2 || authors[0].Name != "Author One" || authors[1].Name != "Author Two" {
+			t.Errorf("authors not ordered by name: %v", authors)
+		}
+		books, _ := s.ListBooks(ctx)
+		if len(books) != 2 || books[0].Title != "Alone" || books[1].Title != "Shared" {
+			t.Errorf("books not ordered by title: %v", books)
+		}
+	})
+
+	t.Run("Relationships", func(t *testing.T) {
+		t.Parallel()
+		s := newStore(t)
+		authors, _ := s.ListAuthorsByBookID(ctx, 1)
+		if len(authors) != 2 || authors[0].Name != "Author One" || authors[1].Name != "Author Two" {
+			t.Errorf("wrong authors of book 1: %v", authors)
+		}
+		books, _ := s.ListBooksByAuthorID(ctx, 2)
+		if len(books) != 2 {
+			t.Errorf("expected 2 books of author 2, received %v", books)
+		}
+		authors, _ = s.ListAuthorsByAgentID(ctx, 2)
+		if len(authors) != 1 || authors[0].Name != "Author Two" {
+			t.Errorf("wrong authors of agent 2: %v", authors)
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		s := newStore(t)
+		errs := []error{}
+		_, err := s.GetAgent(ctx, 100)
+		errs = append(errs, err)
+		_, err = s.GetAuthor(ctx, 100)
+		errs = append(errs, err)
+		_, err = s.GetBook(ctx, 100)
+		errs = append(errs, err)
+		_, err = s.UpdateAgent(ctx, domain.UpdateAgentParams{ID: 100})
+		errs = append(errs, err)
+		_, err = s.DeleteBook(ctx, 100)
+		errs = append(errs, err)
+		for i, err := range errs {
+			if err != domain.ErrNotFound {
+				t.Errorf("%d: expected domain.ErrNotFound, received %v", i, err)
+			}
+		}
+	})
+
+	t.Run("Constraint errors", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			fn   func(s *memory.Store) error
+			err  error
+			kind domain.ConstraintKind
+		}{
+			{"author with unknown agent", func(s *memory.Store) error {
+				_, err := s.CreateAuthor(ctx, domain.CreateAuthorParams{Name: "x", AgentID: 100})
+				return err
+			}, nil, domain.ForeignKeyConstraint},
+			{"book with unknown author", func(s *memory.Store) error {
+				_, err := s.CreateBook(ctx, domain.CreateBookParams{Title: "x"}, []domain.Contributor{{AuthorID: 100, Role: "AUTHOR", Position: 1}}, nil, nil)
+				return err
+			}, nil, domain.ForeignKeyConstraint},
+			{"book with repeated author", func(s *memory.Store) error {
+				_, err := s.CreateBook(ctx, domain.CreateBookParams{Title: "x"}, []domain.Contributor{
+					{AuthorID: 1, Role: "AUTHOR", Position: 1},
+					{AuthorID: 1, Role: "EDITOR", Position: 2},
+				}, nil, nil)
+				return err
+			}, domain.ErrDuplicateContributor, ""},
+			{"contributor with unknown role", func(s *memory.Store) error {
+				_, err := s.CreateBook(ctx, domain.CreateBookParams{Title: "x"}, []domain.Contributor{{AuthorID: 1, Role: "GHOSTWRITER", Position: 1}}, nil, nil)
+				return err
+			}, nil, domain.CheckConstraint},
+			{"book without authors", func(s *memory.Store) error {
+				_, err := s.UpdateBook(ctx, domain.UpdateBookParams{ID: 1, Title: "x"}, nil, nil, nil)
+				return err
+			}, nil, domain.CheckConstraint},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				s := newStore(t)
+				err := tc.fn(s)
+				if tc.err != nil && !errors.Is(err, tc.err) {
+					t.Errorf("expected %v, received %v", tc.err, err)
+				}
+				if kind := constraintKind(err); kind != tc.kind {
+					t.Errorf("expected a %q constraint error, received %q", tc.kind, kind)
+				}
+				// failed writes leave no trace
+				books, _ := s.ListBooks(ctx)
+				if len(books) != 2 || books[1].Title != "Shared" {
+					t.Errorf("failed write changed the books: %v", books)
+				}
+			})
+		}
+	})
+
+	t.Run("DeleteBook cascades", func(t *testing.T) {
+		t.Parallel()
+		s := newStore(t)
+		if _, err := s.DeleteBook(ctx, 1); err != nil {
+			t.Fatalf("failed to delete book: %s", err)
+		}
+		books, _ := s.ListBooksByAuthorID(ctx, 1)
+		if len(books) != 0 {
+			t.Errorf("expected no books of author 1, received %v", books)
+		}
+	})
+
+	t.Run("DeleteAgent", func(t *testing.T) {
+		t.Parallel()
+		s := newStore(t)
+		var hasAuthors *domain.AgentHasAuthorsError
+		if _, err := s.DeleteAgent(ctx, 1, nil); !errors.As(err, &hasAuthors) {
+			t.Errorf("expected AgentHasAuthorsError, received %v", err)
+		}
+		to := int64(2)
+		if _, err := s.DeleteAgent(ctx, 1, &to); err != nil {
+			t.Fatalf("failed to delete agent: %s", err)
+		}
+		authors, _ := s.ListAuthorsByAgentID(ctx, 2)
+		if len(authors) != 2 {
+			t.Errorf("expected 2 reassigned authors, received %v", authors)
+		}
+	})
+
+	t.Run("DeleteAuthor", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			policy  domain.OrphanedBooksPolicy
+			books   int
+			orphans int
+		}{
+			{domain.OrphanedBooksDelete, 1, 0},
+			{domain.OrphanedBooksKeep, 2, 1},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(string(tc.policy), func(t *testing.T) {
+				t.Parallel()
+				s := newStore(t)
+				var orphaned *domain.BooksWouldBeOrphanedError
+				if _, err := s.DeleteAuthor(ctx, 2, domain.OrphanedBooksFail); !errors.As(err, &orphaned) {
+					t.Fatalf("expected BooksWouldBeOrphanedError, received %v", err)
+				}
+				if _, err := s.DeleteAuthor(ctx, 2, tc.policy); err != nil {
+					t.Fatalf("failed to delete author: %s", err)
+				}
+				books, _ := s.ListBooks(ctx)
+				orphans, _ := s.ListOrphanBooks(ctx)
+				if len(books) != tc.books || len(orphans) != tc.orphans {
+					t.Errorf("expected %d books and %d orphans, received %v and %v", tc.books, tc.orphans, books, orphans)
+				}
+			})
+		}
+	})
+
+	t.Run("Bulk", func(t *testing.T) {
+		t.Parallel()
+		args := []domain.BulkCreateBookArgs{
+			{Book: domain.CreateBookParams{Title: "ok"}, Contributors: []domain.Contributor{{AuthorID: 1, Role: "AUTHOR", Position: 1}}},
+			{Book: domain.CreateBookParams{Title: "unknown author"}, Contributors: []domain.Contributor{{AuthorID: 100, Role: "AUTHOR", Position: 1}}},
+			{Book: domain.CreateBookParams{Title: "no authors"}},
+		}
+		tests := []struct {
+			mode      domain.BulkMode
+			committed bool
+			books     int
+		}{
+			{domain.BulkAllOrNothing, false, 2},
+			{domain.BulkBestEffort, true, 3},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(string(tc.mode), func(t *testing.T) {
+				t.Parallel()
+				s := newStore(t)
+				res, err := s.CreateBooks(ctx, args, tc.mode)
+				if err != nil {
+					t.Fatalf("failed to create books: %s", err)
+				}
+				if res.Committed != tc.committed {
+					t.Errorf("expected committed %v, received %v", tc.committed, res.Committed)
+				}
+				if res.Errors[0] != nil || res.Errors[1] == nil || res.Errors[2] != domain.ErrBookWithoutAuthors {
+					t.Errorf("wrong errors: %v", res.Errors)
+				}
+				books, _ := s.ListBooks(ctx)
+				if len(books) != tc.books {
+					t.Errorf("expected %d books, received %d", tc.books, len(books))
+				}
+			})
+		}
+	})
+
+	t.Run("Webhook deliveries", func(t *testing.T) {
+		t.Parallel()
+		s := newStore(t)
+		webhook, err := s.CreateWebhook(ctx, domain.CreateWebhookParams{
+			URL:        "https://example.com",
+			Secret:     "secret",
+			EventTypes: []string{domain.EventBookDeleted},
+		})
+		if err != nil {
+			t.Fatalf("failed to create webhook: %s", err)
+		}
+		if _, err := s.DeleteBook(ctx, 2); err != nil {
+			t.Fatalf("failed to delete book: %s", err)
+		}
+		if _, err := s.UpdateAuthor(ctx, domain.UpdateAuthorParams{ID: 1, Name: "x", AgentID: 1}); err != nil {
+			t.Fatalf("failed to update author: %s", err)
+		}
+		lease := time.Now().Add(time.Minute)
+		rows, err := s.ClaimWebhookDeliveries(ctx, domain.ClaimWebhookDeliveriesParams{LeaseUntil: lease, BatchSize: 10})
+		if err != nil {
+			t.Fatalf("failed to claim deliveries: %s", err)
+		}
+		if len(rows) != 1 || rows[0].EventType != domain.EventBookDeleted || rows[0].URL != webhook.URL || rows[0].Attempts != 1 {
+			t.Fatalf("wrong claimed deliveries: %v", rows)
+		}
+		deliveryID := rows[0].ID
+		rows, _ = s.ClaimWebhookDeliveries(ctx, domain.ClaimWebhookDeliveriesParams{LeaseUntil: lease, BatchSize: 10})
+		if len(rows) != 0 {
+			t.Errorf("expected leased deliveries not to be claimed again, received %v", rows)
+		}
+		err = s.FailWebhookDelivery(ctx, domain.FailWebhookDeliveryParams{
+			ID:            deliveryID,
+			Status:        domain.DeliveryDead,
+			LastError:     "test error",
+			NextAttemptAt: lease,
+		})
+		if err != nil {
+			t.Fatalf("failed to fail delivery: %s", err)
+		}
+		d, err := s.RetryWebhookDelivery(ctx, deliveryID)
+		if err != nil {
+			t.Fatalf("failed to retry delivery: %s", err)
+		}
+		if d.Status != domain.DeliveryPending || d.Attempts != 0 || d.LastError != nil {
+			t.Errorf("expected a pending delivery with fresh attempts, received %v", d)
+		}
+		if _, err := s.DeleteWebhook(ctx, webhook.ID); err != nil {
+			t.Fatalf("failed to delete webhook: %s", err)
+		}
+		deliveries, _ := s.ListWebhookDeliveries(ctx, webhook.ID)
+		if len(deliveries) != 0 {
+			t.Errorf("expected deliveries to be deleted with the webhook, received %v", deliveries)
+		}
+	})
+
+	t.Run("Seed errors", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			seed string
+		}{
+			{"malformed", `{"agents": [`},
+			{"unknown field", `{"publishers": []}`},
+			{"unknown agent", `{"authors": [{"name": "x", "agent_email": "x@example.com"}]}`},
+			{"book without authors", `{"books": [{"title": "x", "description": "d", "cover": "c"}]}`},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				s := memory.New()
+				if err := s.Seed(ctx, strings.NewReader(tc.seed)); err == nil {
+					t.Errorf("expected an error, received nil")
+				}
+				books, _ := s.ListBooks(ctx)
+				if len(books) != 0 {
+					t.Errorf("expected no books, received %v", books)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/fwojciec/litag-example/domain" // use your own github username
+)
+
+// seed is the document read by Seed. Its fields are named like the fields of
+// the import files.
+type seed struct {
+	Agents []struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"agents"`
+	Authors []struct {
+		Name       string  `json:"name"`
+		Website    *string `json:"website"`
+		AgentEmail string  `json:"agent_email"`
+	} `json:"authors"`
+	Books []struct {
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		Cover       string   `json:"cover"`
+		Authors     []string `json:"authors"`
+	} `json:"books"`
+}
+
+// Seed loads agents, authors and books from a JSON document of the form:
+//
+//	{
+//	  "agents": [{"name": "...", "email": "..."}],
+//	  "authors": [{"name": "...", "website": "...", "agent_email": "..."}],
+//	  "books": [{"title": "...", "description": "...", "cover": "...", "authors": ["..."]}]
+//	}
+//
+// Authors reference their agent by email and books their authors by name.
+// Either the whole document is loaded or, on error, nothing is.
+func (s *Store) Seed(ctx context.Context, r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var doc seed
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("invalid seed: %v", err)
+	}
+	return s.write(ctx, func(t *tx) error {
+		agents := make(map[string]int64, len(doc.Agents))
+		for _, a := range doc.Agents {
+			agent, err := t.createAgent(domain.CreateAgentParams{Name: a.Name, Email: a.Email})
+			if err != nil {
+				return err
+			}
+			agents[a.Email] = agent.ID
+		}
+		authors := make(map[string]int64, len(doc.Authors))
+		for _, a := range doc.Authors {
+			agentID, ok := agents[a.AgentEmail]
+			if !ok {
+				return fmt.Errorf("invalid seed: unknown agent email %s of author %s", a.AgentEmail, a.Name)
+			}
+			author, err := t.createAuthor(domain.CreateAuthorParams{Name: a.Name, Website: a.Website, AgentID: agentID})
+			if err != nil {
+				return err
+			}
+			authors[a.Name] = author.ID
+		}
+		for _, b := range doc.Books {
+			book, err := t.createBook(domain.CreateBookParams{Title: b.Title, Description: b.Description, Cover: b.Cover})
+			if err != nil {
+				return err
+			}
+			for i, name := range b.Authors {
+				authorID, ok := authors[name]
+				if !ok {
+					return fmt.Errorf("invalid seed: unknown author %s of book %s", name, b.Title)
+				}
+				c := domain.Contributor{AuthorID: authorID, Role: domain.ContributorAuthor, Position: i + 1}
+				if err := t.setBookAuthor(book.ID, c); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,351 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fwojciec/litag-example/domain" // use your own github username
+)
+
+// DeleteAgent deletes an agent, reassigning its authors to another agent
+// first if reassignAuthorsTo is set. Like the PostgreSQL implementation it
+// archives an agent that representations or deals refer to instead.
+func (s *Store) DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+	var agent domain.Agent
+	err := s.write(ctx, func(t *tx) error {
+		if reassignAuthorsTo != nil {
+			if *reassignAuthorsTo == id {
+				return fmt.Errorf("cannot reassign authors of agent %d to the same agent", id)
+			}
+			authors, err := t.reassignAuthors(id, *reassignAuthorsTo)
+			if err != nil {
+				return err
+			}
+			for _, author := range authors {
+				if err := t.authorEvent(domain.EventAuthorUpdated, author); err != nil {
+					return err
+				}
+			}
+		} else if authors := t.listAuthorsByAgentID(id); len(authors) > 0 {
+			return &domain.AgentHasAuthorsError{AgentID: id, Authors: authors}
+		}
+		var err error
+		agent, err = t.deleteAgent(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+// CreateAuthor creates an author.
+func (s *Store) CreateAuthor(ctx context.Context, args domain.CreateAuthorParams) (*domain.Author, error) {
+	var author domain.Author
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		author, err = t.createAuthorWithEvent(args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+// UpdateAuthor updates an author.
+func (s *Store) UpdateAuthor(ctx context.Context, args domain.UpdateAuthorParams) (*domain.Author, error) {
+	var author domain.Author
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		if author, err = t.updateAuthor(args); err != nil {
+			return err
+		}
+		return t.authorEvent(domain.EventAuthorUpdated, author)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+// DeleteAuthor deletes an author, handling the books it would leave without
+// authors according to the policy.
+func (s *Store) DeleteAuthor(ctx context.Context, id int64, orphanedBooks domain.OrphanedBooksPolicy) (*domain.Author, error) {
+	var author domain.Author
+	err := s.write(ctx, func(t *tx) error {
+		if books := t.listBooksOrphanedByAuthorID(id); len(books) > 0 {
+			switch orphanedBooks {
+			case domain.OrphanedBooksDelete:
+				for _, book := range books {
+					if _, err := t.deleteBook(book.ID); err != nil {
+						return err
+					}
+					err := t.enqueueEvent(domain.EventBookDeleted, domain.NewBookPayload(book, []int64{id}))
+					if err != nil {
+						return err
+					}
+				}
+			case domain.OrphanedBooksKeep:
+				t.allowOrphanedBooks = true
+			default:
+				return &domain.BooksWouldBeOrphanedError{AuthorID: id, Books: books}
+			}
+		}
+		var err error
+		if author, err = t.deleteAuthor(id); err != nil {
+			return err
+		}
+		return t.authorEvent(domain.EventAuthorDeleted, author)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+// CreateBook creates a book with the authors.
+func (s *Store) CreateBook(ctx context.Context, bookArgs domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+	var book domain.Book
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		book, err = t.createBookWithEvent(bookArgs, contributors, genreIDs, tags)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// UpdateBook updates a book, replacing its authors, genres and tags.
+func (s *Store) UpdateBook(ctx context.Context, bookArgs domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+	var book domain.Book
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		book, err = t.updateBookWithEvent(bookArgs, contributors, genreIDs, tags)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// DeleteBook deletes a book.
+func (s *Store) DeleteBook(ctx context.Context, id int64) (*domain.Book, error) {
+	var book domain.Book
+	err := s.write(ctx, func(t *tx) error {
+		authorIDs := t.listAuthorIDsByBookID(id)
+		var err error
+		if book, err = t.deleteBook(id); err != nil {
+			return err
+		}
+		return t.enqueueEvent(domain.EventBookDeleted, domain.NewBookPayload(book, authorIDs))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// UpdateGenre updates a genre, refusing to move it under itself or one of
+// its subgenres.
+func (s *Store) UpdateGenre(ctx context.Context, args domain.UpdateGenreParams) (*domain.Genre, error) {
+	var genre domain.Genre
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		genre, err = t.updateGenre(args)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &genre, nil
+}
+
+// EndRepresentation ends a representation that is not primary.
+func (s *Store) EndRepresentation(ctx context.Context, id int64, endedAt domain.Date) (domain.Representation, error) {
+	var representation domain.Representation
+	err := s.write(ctx, func(t *tx) error {
+		r, err := t.getRepresentation(id)
+		if err != nil {
+			return err
+		}
+		if r.Primary {
+			return domain.ErrPrimaryRepresentation
+		}
+		representation, err = t.endRepresentation(id, endedAt)
+		return err
+	})
+	return representation, err
+}
+
+// ReorderSeries numbers the books of the series from 1 in the order of
+// bookIDs.
+func (s *Store) ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+	var series domain.Series
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		if series, err = t.getSeries(id); err != nil {
+			return err
+		}
+		if !sameBooks(t.listBooksBySeriesID(id), bookIDs) {
+			return domain.ErrSeriesBooksMismatch
+		}
+		for i, bookID := range bookIDs {
+			book := t.books[bookID]
+			position := i + 1
+			book.SeriesPosition = &position
+			book.UpdatedAt = t.now
+			t.books[bookID] = book
+			if err := t.bookEvent(domain.EventBookUpdated, book); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// DeleteSeries deletes a series, keeping its books outside of any series.
+func (s *Store) DeleteSeries(ctx context.Context, id int64) (*domain.Series, error) {
+	var series domain.Series
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		series, err = t.deleteSeries(id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// TransitionSubmission moves the submission to status, recording the
+// transition along with the note. A submission converted into a book cannot
+// move anymore.
+func (s *Store) TransitionSubmission(ctx context.Context, id int64, status domain.SubmissionStatus, note *string) (*domain.Submission, error) {
+	var submission domain.Submission
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		if submission, err = t.getSubmission(id); err != nil {
+			return err
+		}
+		if submission.BookID != nil || !domain.CanTransitionSubmission(submission.Status, status) {
+			return &domain.SubmissionTransitionError{SubmissionID: id, From: submission.Status, To: status}
+		}
+		submission = t.transitionSubmission(submission, status, note)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// ConvertSubmission turns an offered submission into a book with the given
+// cover, titled and described after the submission, by a new author
+// represented by the agent of the submission.
+func (s *Store) ConvertSubmission(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+	var book domain.Book
+	err := s.write(ctx, func(t *tx) error {
+		submission, err := t.getSubmission(id)
+		if err != nil {
+			return err
+		}
+		if submission.Status != domain.SubmissionOffered || submission.BookID != nil {
+			return domain.ErrSubmissionNotOffered
+		}
+		author, err := t.createAuthorWithEvent(domain.CreateAuthorParams{
+			Name:    submission.AuthorName,
+			Website: submission.AuthorWebsite,
+			AgentID: submission.AgentID,
+		})
+		if err != nil {
+			return err
+		}
+		book, err = t.createBookWithEvent(domain.CreateBookParams{
+			Title:       submission.Title,
+			Description: submission.Synopsis,
+			Cover:       cover,
+		}, []domain.Contributor{{AuthorID: author.ID, Role: domain.ContributorAuthor, Position: 1}}, nil, nil)
+		if err != nil {
+			return err
+		}
+		bookID := book.ID
+		submission.BookID = &bookID
+		t.submissions[id] = submission
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// sameBooks reports whether ids lists every book exactly once.
+func sameBooks(books []domain.Book, ids []int64) bool {
+	if len(books) != len(ids) {
+		return false
+	}
+	remaining := make(map[int64]bool, len(books))
+	for _, book := range books {
+		remaining[book.ID] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+	return true
+}
+
+func (t *tx) createAuthorWithEvent(args domain.CreateAuthorParams) (domain.Author, error) {
+	author, err := t.createAuthor(args)
+	if err != nil {
+		return author, err
+	}
+	return author, t.authorEvent(domain.EventAuthorCreated, author)
+}
+
+func (t *tx) createBookWithEvent(bookArgs domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (domain.Book, error) {
+	book, err := t.createBook(bookArgs)
+	if err != nil {
+		return book, err
+	}
+	if err := t.setBookAuthors(book.ID, contributors); err != nil {
+		return book, err
+	}
+	if err := t.setBookGenres(book.ID, genreIDs); err != nil {
+		return book, err
+	}
+	if err := t.setBookTags(book.ID, tags); err != nil {
+		return book, err
+	}
+	return book, t.bookEvent(domain.EventBookCreated, book)
+}
+
+func (t *tx) updateBookWithEvent(bookArgs domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (domain.Book, error) {
+	book, err := t.updateBook(bookArgs)
+	if err != nil {
+		return book, err
+	}
+	t.unsetBookAuthors(book.ID)
+	if err := t.setBookAuthors(book.ID, contributors); err != nil {
+		return book, err
+	}
+	t.deleteBookGenres(func(bg bookGenre) bool { return bg.bookID == book.ID })
+	if err := t.setBookGenres(book.ID, genreIDs); err != nil {
+		return book, err
+	}
+	t.deleteBookTags(func(bt bookTag) bool { return bt.bookID == book.ID })
+	if err := t.setBookTags(book.ID, tags); err != nil {
+		return book, err
+	}
+	return book, t.bookEvent(domain.EventBookUpdated, book)
+}
@@ -0,0 +1,1267 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain" // use your own github username
+)
+
+// tx is a write in progress. Its methods mirror the SQL queries, including
+// the constraints the schema enforces.
+type tx struct {
+	*state
+	store *Store
+	now   time.Time
+
+	// allowOrphanedBooks waives the check that books have authors, like the
+	// litag.allow_orphaned_books setting.
+	allowOrphanedBooks bool
+	// checkBooks holds the ids of the books whose authors are checked when
+	// the write finishes, like the deferred constraint triggers.
+	checkBooks map[int64]bool
+}
+
+// savepoint runs fn and undoes its changes if it fails.
+func (t *tx) savepoint(fn func() error) error {
+	saved := t.state.clone()
+	if err := fn(); err != nil {
+		t.state = saved
+		return err
+	}
+	return nil
+}
+
+func (t *tx) checkBooksHaveAuthors() error {
+	if t.allowOrphanedBooks {
+		return nil
+	}
+	for id := range t.checkBooks {
+		if _, ok := t.books[id]; ok && !t.hasAuthors(id) {
+			return &domain.ConstraintError{
+				Kind:    domain.CheckConstraint,
+				Message: fmt.Sprintf("book %d must have at least one author", id),
+			}
+		}
+	}
+	return nil
+}
+
+func foreignKeyViolation(table, constraint string) error {
+	return &domain.ConstraintError{
+		Kind:       domain.ForeignKeyConstraint,
+		Table:      table,
+		Constraint: constraint,
+		Message:    fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint),
+	}
+}
+
+func foreignKeyReferenced(table, constraint, referencing string) error {
+	return &domain.ConstraintError{
+		Kind:       domain.ForeignKeyConstraint,
+		Table:      referencing,
+		Constraint: constraint,
+		Message:    fmt.Sprintf("update or delete on table %q violates foreign key constraint %q on table %q", table, constraint, referencing),
+	}
+}
+
+func uniqueViolation(table, constraint string) error {
+	return &domain.ConstraintError{
+		Kind:       domain.UniqueConstraint,
+		Table:      table,
+		Constraint: constraint,
+		Message:    fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+	}
+}
+
+func checkViolation(table, constraint string) error {
+	return &domain.ConstraintError{
+		Kind:       domain.CheckConstraint,
+		Table:      table,
+		Constraint: constraint,
+		Message:    fmt.Sprintf("new row for relation %q violates check constraint %q", table, constraint),
+	}
+}
+
+// agencies
+
+func (t *tx) createAgency(name string) domain.Agency {
+	agency := domain.Agency{
+		ID:   t.store.nextID("agencies"),
+		Name: name,
+	}
+	t.agencies[agency.ID] = agency
+	return agency
+}
+
+func (t *tx) updateAgency(args domain.UpdateAgencyParams) (domain.Agency, error) {
+	agency, err := t.getAgency(args.ID)
+	if err != nil {
+		return agency, err
+	}
+	agency.Name = args.Name
+	t.agencies[agency.ID] = agency
+	return agency, nil
+}
+
+// deleteAgency deletes the agency, setting the agency of its agents to NULL.
+func (t *tx) deleteAgency(id int64) (domain.Agency, error) {
+	agency, err := t.getAgency(id)
+	if err != nil {
+		return agency, err
+	}
+	delete(t.agencies, id)
+	for _, agent := range t.agents {
+		if agent.AgencyID != nil && *agent.AgencyID == id {
+			agent.AgencyID = nil
+			agent.UpdatedAt = t.now
+			t.agents[agent.ID] = agent
+		}
+	}
+	return agency, nil
+}
+
+func (t *tx) agencyExists(id *int64) bool {
+	if id == nil {
+		return true
+	}
+	_, ok := t.agencies[*id]
+	return ok
+}
+
+// agents
+
+func (t *tx) createAgent(args domain.CreateAgentParams) (domain.Agent, error) {
+	if !t.agencyExists(args.AgencyID) {
+		return domain.Agent{}, foreignKeyViolation("agents", "agents_agency_id_fkey")
+	}
+	agent := domain.Agent{
+		ID:        t.store.nextID("agents"),
+		Name:      args.Name,
+		Email:     args.Email,
+		AgencyID:  copyInt64(args.AgencyID),
+		UpdatedAt: t.now,
+	}
+	t.agents[agent.ID] = agent
+	return agent, nil
+}
+
+func (t *tx) updateAgent(args domain.UpdateAgentParams) (domain.Agent, error) {
+	agent, err := t.getActiveAgent(args.ID)
+	if err != nil {
+		return agent, err
+	}
+	if !t.agencyExists(args.AgencyID) {
+		return domain.Agent{}, foreignKeyViolation("agents", "agents_agency_id_fkey")
+	}
+	agent.Name = args.Name
+	agent.Email = args.Email
+	agent.AgencyID = copyInt64(args.AgencyID)
+	agent.UpdatedAt = t.now
+	t.agents[agent.ID] = agent
+	return agent, nil
+}
+
+// deleteAgent deletes an agent without authors, cascading to its
+// submissions. An agent that representations or deals refer to is archived
+// instead, after ending its co-representations.
+func (t *tx) deleteAgent(id int64) (domain.Agent, error) {
+	agent, err := t.getActiveAgent(id)
+	if err != nil {
+		return agent, err
+	}
+	for _, submission := range t.submissions {
+		if submission.AgentID == id {
+			t.deleteSubmission(submission.ID)
+		}
+	}
+	if !t.agentHasHistory(id) {
+		delete(t.agents, id)
+		return agent, nil
+	}
+	for _, r := range t.reps {
+		if r.AgentID == id && r.EndedAt == nil {
+			t.endRepresentationToday(r)
+		}
+	}
+	deletedAt := t.now
+	agent.DeletedAt = &deletedAt
+	agent.UpdatedAt = t.now
+	t.agents[id] = agent
+	return agent, nil
+}
+
+func (t *tx) agentHasHistory(id int64) bool {
+	for _, r := range t.reps {
+		if r.AgentID == id {
+			return true
+		}
+	}
+	for _, d := range t.deals {
+		if d.AgentID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// getActiveAgent is getAgent for the agents that have not been archived.
+func (t *tx) getActiveAgent(id int64) (domain.Agent, error) {
+	agent, err := t.getAgent(id)
+	if err == nil && agent.DeletedAt != nil {
+		return domain.Agent{}, domain.ErrNotFound
+	}
+	return agent, err
+}
+
+// checkAgentNotDeleted mirrors the check_agent_not_deleted trigger; unknown
+// agents are left to the foreign key checks.
+func (t *tx) checkAgentNotDeleted(id int64) error {
+	if agent, ok := t.agents[id]; ok && agent.DeletedAt != nil {
+		return domain.ErrAgentDeleted
+	}
+	return nil
+}
+
+// authors
+
+func (t *tx) createAuthor(args domain.CreateAuthorParams) (domain.Author, error) {
+	author := domain.Author{
+		ID:        t.store.nextID("authors"),
+		Name:      args.Name,
+		Website:   normalizeWebsite(args.Website),
+		UpdatedAt: t.now,
+	}
+	t.authors[author.ID] = author
+	return t.startPrimaryRepresentation(author.ID, args.AgentID)
+}
+
+func (t *tx) updateAuthor(args domain.UpdateAuthorParams) (domain.Author, error) {
+	author, err := t.getAuthor(args.ID)
+	if err != nil {
+		return author, err
+	}
+	author.Name = args.Name
+	author.Website = normalizeWebsite(args.Website)
+	author.UpdatedAt = t.now
+	t.authors[author.ID] = author
+	return t.startPrimaryRepresentation(author.ID, args.AgentID)
+}
+
+// normalizeWebsite stores an empty website as NULL, like the PostgreSQL
+// implementation does on every path.
+func normalizeWebsite(website *string) *string {
+	if website == nil || *website == "" {
+		return nil
+	}
+	return copyString(website)
+}
+
+func (t *tx) reassignAuthors(fromAgentID, toAgentID int64) ([]domain.Author, error) {
+	authors := t.listAuthorsByAgentID(fromAgentID)
+	if len(authors) == 0 {
+		return nil, nil
+	}
+	for i := range authors {
+		author, err := t.startPrimaryRepresentation(authors[i].ID, toAgentID)
+		if err != nil {
+			return nil, err
+		}
+		authors[i] = author
+	}
+	return authors, nil
+}
+
+// deleteAuthor deletes the author, cascading to its book associations.
+func (t *tx) deleteAuthor(id int64) (domain.Author, error) {
+	author, err := t.getAuthor(id)
+	if err != nil {
+		return author, err
+	}
+	delete(t.authors, id)
+	t.deleteBookAuthors(func(ba bookAuthor) bool { return ba.AuthorID == id })
+	t.deleteRepresentations(func(r domain.Representation) bool { return r.AuthorID == id })
+	return author, nil
+}
+
+// books
+
+func (t *tx) createBook(args domain.CreateBookParams) (domain.Book, error) {
+	if !t.publisherExists(args.PublisherID) {
+		return domain.Book{}, foreignKeyViolation("books", "books_publisher_id_fkey")
+	}
+	book := domain.Book{
+		ID:             t.store.nextID("books"),
+		Title:          args.Title,
+		Description:    args.Description,
+		Cover:          args.Cover,
+		PublisherID:    copyInt64(args.PublisherID),
+		SeriesID:       copyInt64(args.SeriesID),
+		SeriesPosition: copyInt(args.SeriesPosition),
+		ISBN:           copyISBN(args.ISBN),
+		PublishedOn:    copyDate(args.PublishedOn),
+		PageCount:      copyInt(args.PageCount),
+		Language:       copyLanguage(args.Language),
+		UpdatedAt:      t.now,
+	}
+	if err := t.checkSeriesPosition(book); err != nil {
+		return domain.Book{}, err
+	}
+	if err := t.checkBibliographic(book); err != nil {
+		return domain.Book{}, err
+	}
+	t.books[book.ID] = book
+	t.checkBooks[book.ID] = true
+	return book, nil
+}
+
+func (t *tx) updateBook(args domain.UpdateBookParams) (domain.Book, error) {
+	book, err := t.getBook(args.ID)
+	if err != nil {
+		return book, err
+	}
+	if !t.publisherExists(args.PublisherID) {
+		return domain.Book{}, foreignKeyViolation("books", "books_publisher_id_fkey")
+	}
+	book.Title = args.Title
+	book.Description = args.Description
+	book.Cover = args.Cover
+	book.PublisherID = copyInt64(args.PublisherID)
+	book.SeriesID = copyInt64(args.SeriesID)
+	book.SeriesPosition = copyInt(args.SeriesPosition)
+	book.ISBN = copyISBN(args.ISBN)
+	book.PublishedOn = copyDate(args.PublishedOn)
+	book.PageCount = copyInt(args.PageCount)
+	book.Language = copyLanguage(args.Language)
+	book.UpdatedAt = t.now
+	if err := t.checkSeriesPosition(book); err != nil {
+		return domain.Book{}, err
+	}
+	if err := t.checkBibliographic(book); err != nil {
+		return domain.Book{}, err
+	}
+	t.books[book.ID] = book
+	return book, nil
+}
+
+// deleteBook deletes the book, cascading to its author associations,
+// editions and deals, and on to their royalty rates and sales. Submissions
+// converted into the book are kept without it.
+func (t *tx) deleteBook(id int64) (domain.Book, error) {
+	book, err := t.getBook(id)
+	if err != nil {
+		return book, err
+	}
+	delete(t.books, id)
+	t.deleteBookAuthors(func(ba bookAuthor) bool { return ba.bookID == id })
+	for _, edition := range t.editions {
+		if edition.BookID == id {
+			t.deleteEdition(edition.ID)
+		}
+	}
+	for _, deal := range t.deals {
+		if deal.BookID == id {
+			t.deleteDeal(deal.ID)
+		}
+	}
+	t.deleteBookGenres(func(bg bookGenre) bool { return bg.bookID == id })
+	t.deleteBookTags(func(bt bookTag) bool { return bt.bookID == id })
+	for _, submission := range t.submissions {
+		if submission.BookID != nil && *submission.BookID == id {
+			submission.BookID = nil
+			t.submissions[submission.ID] = submission
+		}
+	}
+	return book, nil
+}
+
+// publisherExists reports whether a book may reference the publisher; books
+// without a publisher always may.
+func (t *tx) publisherExists(id *int64) bool {
+	if id == nil {
+		return true
+	}
+	_, ok := t.publishers[*id]
+	return ok
+}
+
+// checkSeriesPosition enforces the series constraints of the books table on
+// the book about to be stored.
+func (t *tx) checkSeriesPosition(book domain.Book) error {
+	if book.SeriesID == nil {
+		if book.SeriesPosition != nil {
+			return domain.ErrInvalidSeriesPosition
+		}
+		return nil
+	}
+	if _, ok := t.series[*book.SeriesID]; !ok {
+		return foreignKeyViolation("books", "books_series_id_fkey")
+	}
+	if book.SeriesPosition == nil || *book.SeriesPosition <= 0 {
+		return domain.ErrInvalidSeriesPosition
+	}
+	for _, other := range t.listBooksBySeriesID(*book.SeriesID) {
+		if other.ID != book.ID && *other.SeriesPosition == *book.SeriesPosition {
+			return domain.ErrSeriesPositionTaken
+		}
+	}
+	return nil
+}
+
+var (
+	isbnPattern     = regexp.MustCompile(`^97[89][0-9]{10}$`)
+	languagePattern = regexp.MustCompile(`^[a-z]{2}$`)
+)
+
+// checkBibliographic enforces the ISBN, page count and language constraints
+// of the books table on the book about to be stored.
+func (t *tx) checkBibliographic(book domain.Book) error {
+	if book.ISBN != nil {
+		if !isbnPattern.MatchString(string(*book.ISBN)) {
+			return checkViolation("books", "books_isbn_check")
+		}
+		for _, other := range t.books {
+			if other.ID != book.ID && other.ISBN != nil && *other.ISBN == *book.ISBN {
+				return domain.ErrISBNTaken
+			}
+		}
+	}
+	if book.PageCount != nil && *book.PageCount <= 0 {
+		return domain.ErrInvalidPageCount
+	}
+	if book.Language != nil && !languagePattern.MatchString(string(*book.Language)) {
+		return checkViolation("books", "books_language_check")
+	}
+	return nil
+}
+
+func (t *tx) setBookAuthor(bookID int64, c domain.Contributor) error {
+	if _, ok := t.books[bookID]; !ok {
+		return foreignKeyViolation("book_authors", "book_authors_book_id_fkey")
+	}
+	if _, ok := t.authors[c.AuthorID]; !ok {
+		return foreignKeyViolation("book_authors", "book_authors_author_id_fkey")
+	}
+	if !contributorRoles[c.Role] {
+		return checkViolation("book_authors", "book_authors_role_check")
+	}
+	if c.Position <= 0 {
+		return domain.ErrInvalidContributorPosition
+	}
+	for _, ba := range t.bookAuthors {
+		if ba.bookID == bookID && ba.AuthorID == c.AuthorID {
+			return domain.ErrDuplicateContributor
+		}
+	}
+	t.bookAuthors = append(t.bookAuthors, bookAuthor{
+		id:          t.store.nextID("book_authors"),
+		bookID:      bookID,
+		Contributor: c,
+	})
+	return nil
+}
+
+// contributorRoles mirrors book_authors_role_check.
+var contributorRoles = map[domain.ContributorRole]bool{
+	domain.ContributorAuthor:      true,
+	domain.ContributorIllustrator: true,
+	domain.ContributorTranslator:  true,
+	domain.ContributorEditor:      true,
+}
+
+// setBookAuthors associates the book with each of the contributors.
+func (t *tx) setBookAuthors(bookID int64, contributors []domain.Contributor) error {
+	for _, c := range contributors {
+		if err := t.setBookAuthor(bookID, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *tx) unsetBookAuthors(bookID int64) {
+	t.deleteBookAuthors(func(ba bookAuthor) bool { return ba.bookID == bookID })
+}
+
+// deleteBookAuthors deletes the matching associations and marks their books
+// for the authors check.
+func (t *tx) deleteBookAuthors(match func(ba bookAuthor) bool) {
+	kept := t.bookAuthors[:0:0]
+	for _, ba := range t.bookAuthors {
+		if match(ba) {
+			t.checkBooks[ba.bookID] = true
+			continue
+		}
+		kept = append(kept, ba)
+	}
+	t.bookAuthors = kept
+}
+
+// deals
+
+func (t *tx) createDeal(args domain.CreateDealParams) (domain.Deal, error) {
+	deal := domain.Deal{
+		ID:             t.store.nextID("deals"),
+		BookID:         args.BookID,
+		PublisherID:    args.PublisherID,
+		AgentID:        args.AgentID,
+		Territory:      args.Territory,
+		Language:       args.Language,
+		Format:         args.Format,
+		Advance:        args.Advance,
+		SignedOn:       copyDate(args.SignedOn),
+		Status:         args.Status,
+		RoyaltyRate:    args.RoyaltyRate,
+		CommissionRate: args.CommissionRate,
+	}
+	if err := t.checkAgentNotDeleted(deal.AgentID); err != nil {
+		return domain.Deal{}, err
+	}
+	if err := t.checkDeal(deal); err != nil {
+		return domain.Deal{}, err
+	}
+	t.deals[deal.ID] = deal
+	return deal, nil
+}
+
+func (t *tx) updateDeal(args domain.UpdateDealParams) (domain.Deal, error) {
+	deal, err := t.getDeal(args.ID)
+	if err != nil {
+		return deal, err
+	}
+	if args.AgentID != deal.AgentID {
+		if err := t.checkAgentNotDeleted(args.AgentID); err != nil {
+			return domain.Deal{}, err
+		}
+	}
+	deal.BookID = args.BookID
+	deal.PublisherID = args.PublisherID
+	deal.AgentID = args.AgentID
+	deal.Territory = args.Territory
+	deal.Language = args.Language
+	deal.Format = args.Format
+	deal.Advance = args.Advance
+	deal.SignedOn = copyDate(args.SignedOn)
+	deal.Status = args.Status
+	deal.RoyaltyRate = args.RoyaltyRate
+	deal.CommissionRate = args.CommissionRate
+	if err := t.checkDeal(deal); err != nil {
+		return domain.Deal{}, err
+	}
+	t.deals[deal.ID] = deal
+	if err := t.checkRoyaltyEditions(); err != nil {
+		return domain.Deal{}, err
+	}
+	return deal, nil
+}
+
+// checkDeal enforces the constraints of the deals table on the deal about to
+// be stored.
+func (t *tx) checkDeal(deal domain.Deal) error {
+	if _, ok := t.books[deal.BookID]; !ok {
+		return foreignKeyViolation("deals", "deals_book_id_fkey")
+	}
+	if _, ok := t.publishers[deal.PublisherID]; !ok {
+		return foreignKeyViolation("deals", "deals_publisher_id_fkey")
+	}
+	if _, ok := t.agents[deal.AgentID]; !ok {
+		return foreignKeyViolation("deals", "deals_agent_id_fkey")
+	}
+	if !territoryPattern.MatchString(deal.Territory) {
+		return domain.ErrInvalidTerritory
+	}
+	if !languagePattern.MatchString(string(deal.Language)) {
+		return checkViolation("deals", "deals_language_check")
+	}
+	if !editionFormatValid(deal.Format) {
+		return checkViolation("deals", "deals_format_check")
+	}
+	if deal.Advance.Amount < 0 || !currencyPattern.MatchString(deal.Advance.Currency) {
+		return checkViolation("deals", "deals_advance_check")
+	}
+	switch deal.Status {
+	case domain.DealNegotiating, domain.DealSigned, domain.DealTerminated:
+	default:
+		return checkViolation("deals", "deals_status_check")
+	}
+	if deal.Status == domain.DealNegotiating && deal.SignedOn != nil || deal.Status == domain.DealSigned && deal.SignedOn == nil {
+		return domain.ErrInvalidDealSigning
+	}
+	if deal.RoyaltyRate < 0 || deal.RoyaltyRate > 10000 {
+		return domain.ErrInvalidRate
+	}
+	if deal.CommissionRate < 0 || deal.CommissionRate > 10000 {
+		return domain.ErrInvalidRate
+	}
+	return nil
+}
+
+// editionFormatValid mirrors the edition_format type.
+func editionFormatValid(format domain.EditionFormat) bool {
+	for _, f := range editionFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteDeal deletes the deal, cascading to its royalty rates and sales.
+func (t *tx) deleteDeal(id int64) {
+	delete(t.deals, id)
+	for key := range t.rates {
+		if key.dealID == id {
+			delete(t.rates, key)
+		}
+	}
+	for _, sales := range t.sales {
+		if sales.DealID == id {
+			delete(t.sales, sales.ID)
+		}
+	}
+}
+
+// royalties
+
+func (t *tx) setRoyaltyRate(dealID, editionID int64, rate int) (domain.RoyaltyRate, error) {
+	if _, ok := t.deals[dealID]; !ok {
+		return domain.RoyaltyRate{}, foreignKeyViolation("royalty_rates", "royalty_rates_deal_id_fkey")
+	}
+	if _, ok := t.editions[editionID]; !ok {
+		return domain.RoyaltyRate{}, foreignKeyViolation("royalty_rates", "royalty_rates_edition_id_fkey")
+	}
+	if !editionInDeal(t.editions[editionID], t.deals[dealID]) {
+		return domain.RoyaltyRate{}, domain.ErrEditionNotInDeal
+	}
+	if rate < 0 || rate > 10000 {
+		return domain.RoyaltyRate{}, domain.ErrInvalidRate
+	}
+	r := domain.RoyaltyRate{
+		DealID:    dealID,
+		EditionID: editionID,
+		Rate:      rate,
+	}
+	t.rates[rateKey{dealID, editionID}] = r
+	return r, nil
+}
+
+// editionInDeal reports whether the edition is of the book and format of the
+// deal, which the royalty rates and sales of the deal require.
+func editionInDeal(edition domain.Edition, deal domain.Deal) bool {
+	return edition.BookID == deal.BookID && edition.Format == deal.Format
+}
+
+// checkRoyaltyEditions mirrors the deals_keep_editions and editions_keep_deals
+// triggers, run after a deal or edition changed: every royalty rate and sales
+// must still be for an edition in its deal.
+func (t *tx) checkRoyaltyEditions() error {
+	for key := range t.rates {
+		if !editionInDeal(t.editions[key.editionID], t.deals[key.dealID]) {
+			return domain.ErrEditionNotInDeal
+		}
+	}
+	for _, sales := range t.sales {
+		if !editionInDeal(t.editions[sales.EditionID], t.deals[sales.DealID]) {
+			return domain.ErrEditionNotInDeal
+		}
+	}
+	return nil
+}
+
+var periodPattern = regexp.MustCompile(`^[0-9]{4}-Q[1-4]$`)
+
+func (t *tx) recordSales(args domain.RecordSalesParams) (domain.Sales, error) {
+	if _, ok := t.deals[args.DealID]; !ok {
+		return domain.Sales{}, foreignKeyViolation("sales", "sales_deal_id_fkey")
+	}
+	if _, ok := t.editions[args.EditionID]; !ok {
+		return domain.Sales{}, foreignKeyViolation("sales", "sales_edition_id_fkey")
+	}
+	if !editionInDeal(t.editions[args.EditionID], t.deals[args.DealID]) {
+		return domain.Sales{}, domain.ErrEditionNotInDeal
+	}
+	if !periodPattern.MatchString(args.Period) {
+		return domain.Sales{}, domain.ErrInvalidPeriod
+	}
+	if args.Units < 0 {
+		return domain.Sales{}, checkViolation("sales", "sales_units_check")
+	}
+	if args.Revenue.Amount < 0 || !currencyPattern.MatchString(args.Revenue.Currency) {
+		return domain.Sales{}, checkViolation("sales", "sales_revenue_check")
+	}
+	var sales domain.Sales
+	found := false
+	for _, existing := range t.sales {
+		if existing.DealID == args.DealID && existing.EditionID == args.EditionID && existing.Period == args.Period {
+			sales, found = existing, true
+			break
+		}
+	}
+	if !found {
+		sales = domain.Sales{
+			ID:        t.store.nextID("sales"),
+			DealID:    args.DealID,
+			EditionID: args.EditionID,
+			Period:    args.Period,
+		}
+	}
+	sales.Units = args.Units
+	sales.Revenue = args.Revenue
+	t.sales[sales.ID] = sales
+	return sales, nil
+}
+
+// editions
+
+func (t *tx) createEdition(args domain.CreateEditionParams) (domain.Edition, error) {
+	edition := domain.Edition{
+		ID:          t.store.nextID("editions"),
+		BookID:      args.BookID,
+		Format:      args.Format,
+		ISBN:        copyISBN(args.ISBN),
+		PublishedOn: copyDate(args.PublishedOn),
+		Price:       copyPrice(args.Price),
+	}
+	if err := t.checkEdition(edition); err != nil {
+		return domain.Edition{}, err
+	}
+	t.editions[edition.ID] = edition
+	return edition, nil
+}
+
+func (t *tx) updateEdition(args domain.UpdateEditionParams) (domain.Edition, error) {
+	edition, err := t.getEdition(args.ID)
+	if err != nil {
+		return edition, err
+	}
+	edition.BookID = args.BookID
+	edition.Format = args.Format
+	edition.ISBN = copyISBN(args.ISBN)
+	edition.PublishedOn = copyDate(args.PublishedOn)
+	edition.Price = copyPrice(args.Price)
+	if err := t.checkEdition(edition); err != nil {
+		return domain.Edition{}, err
+	}
+	t.editions[edition.ID] = edition
+	if err := t.checkRoyaltyEditions(); err != nil {
+		return domain.Edition{}, err
+	}
+	return edition, nil
+}
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// checkEdition enforces the constraints of the editions table on the edition
+// about to be stored.
+func (t *tx) checkEdition(edition domain.Edition) error {
+	if _, ok := t.books[edition.BookID]; !ok {
+		return foreignKeyViolation("editions", "editions_book_id_fkey")
+	}
+	if !editionFormatValid(edition.Format) {
+		return checkViolation("editions", "editions_format_check")
+	}
+	if edition.ISBN != nil {
+		if !isbnPattern.MatchString(string(*edition.ISBN)) {
+			return checkViolation("editions", "editions_isbn_check")
+		}
+		for _, other := range t.editions {
+			if other.ID != edition.ID && other.ISBN != nil && *other.ISBN == *edition.ISBN {
+				return domain.ErrISBNTaken
+			}
+		}
+	}
+	if p := edition.Price; p != nil && (p.Amount < 0 || !currencyPattern.MatchString(p.Currency)) {
+		return checkViolation("editions", "editions_price_check")
+	}
+	return nil
+}
+
+// deleteEdition deletes the edition, cascading to its royalty rates and
+// sales.
+func (t *tx) deleteEdition(id int64) {
+	delete(t.editions, id)
+	for key := range t.rates {
+		if key.editionID == id {
+			delete(t.rates, key)
+		}
+	}
+	for _, sales := range t.sales {
+		if sales.EditionID == id {
+			delete(t.sales, sales.ID)
+		}
+	}
+}
+
+// genres
+
+func (t *tx) createGenre(args domain.CreateGenreParams) (domain.Genre, error) {
+	if args.ParentID != nil {
+		if _, ok := t.genres[*args.ParentID]; !ok {
+			return domain.Genre{}, foreignKeyViolation("genres", "genres_parent_id_fkey")
+		}
+	}
+	genre := domain.Genre{
+		ID:       t.store.nextID("genres"),
+		Name:     args.Name,
+		ParentID: copyInt64(args.ParentID),
+	}
+	t.genres[genre.ID] = genre
+	return genre, nil
+}
+
+// updateGenre updates the genre, refusing to move it into its own subtree.
+func (t *tx) updateGenre(args domain.UpdateGenreParams) (domain.Genre, error) {
+	if args.ParentID != nil && t.genreTree(args.ID)[*args.ParentID] {
+		return domain.Genre{}, domain.ErrGenreCycle
+	}
+	genre, err := t.getGenre(args.ID)
+	if err != nil {
+		return genre, err
+	}
+	if args.ParentID != nil {
+		if _, ok := t.genres[*args.ParentID]; !ok {
+			return domain.Genre{}, foreignKeyViolation("genres", "genres_parent_id_fkey")
+		}
+	}
+	genre.Name = args.Name
+	genre.ParentID = copyInt64(args.ParentID)
+	t.genres[genre.ID] = genre
+	return genre, nil
+}
+
+// deleteGenre deletes the genre, cascading to its book associations. Genres
+// with subgenres cannot be deleted.
+func (t *tx) deleteGenre(id int64) (domain.Genre, error) {
+	genre, err := t.getGenre(id)
+	if err != nil {
+		return genre, err
+	}
+	if len(t.listGenresByParentID(id)) > 0 {
+		return domain.Genre{}, foreignKeyReferenced("genres", "genres_parent_id_fkey", "genres")
+	}
+	delete(t.genres, id)
+	t.deleteBookGenres(func(bg bookGenre) bool { return bg.genreID == id })
+	return genre, nil
+}
+
+func (t *tx) setBookGenre(bookID, genreID int64) error {
+	if _, ok := t.books[bookID]; !ok {
+		return foreignKeyViolation("book_genres", "book_genres_book_id_fkey")
+	}
+	if _, ok := t.genres[genreID]; !ok {
+		return foreignKeyViolation("book_genres", "book_genres_genre_id_fkey")
+	}
+	for _, bg := range t.bookGenres {
+		if bg.bookID == bookID && bg.genreID == genreID {
+			return uniqueViolation("book_genres", "book_genres_book_id_genre_id_key")
+		}
+	}
+	t.bookGenres = append(t.bookGenres, bookGenre{
+		id:      t.store.nextID("book_genres"),
+		bookID:  bookID,
+		genreID: genreID,
+	})
+	return nil
+}
+
+// setBookGenres classifies the book in each of the genres.
+func (t *tx) setBookGenres(bookID int64, genreIDs []int64) error {
+	for _, genreID := range genreIDs {
+		if err := t.setBookGenre(bookID, genreID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteBookGenres deletes the matching genre associations.
+func (t *tx) deleteBookGenres(match func(bg bookGenre) bool) {
+	kept := t.bookGenres[:0:0]
+	for _, bg := range t.bookGenres {
+		if !match(bg) {
+			kept = append(kept, bg)
+		}
+	}
+	t.bookGenres = kept
+}
+
+func (t *tx) setBookTag(bookID int64, tag string) error {
+	if _, ok := t.books[bookID]; !ok {
+		return foreignKeyViolation("book_tags", "book_tags_book_id_fkey")
+	}
+	if tag == "" || strings.Trim(tag, " ") != tag {
+		return domain.ErrInvalidTag
+	}
+	for _, bt := range t.bookTags {
+		if bt.bookID == bookID && bt.tag == tag {
+			return domain.ErrDuplicateTag
+		}
+	}
+	t.bookTags = append(t.bookTags, bookTag{
+		id:     t.store.nextID("book_tags"),
+		bookID: bookID,
+		tag:    tag,
+	})
+	return nil
+}
+
+// setBookTags gives the book each of the tags.
+func (t *tx) setBookTags(bookID int64, tags []string) error {
+	for _, tag := range tags {
+		if err := t.setBookTag(bookID, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteBookTags deletes the matching tags.
+func (t *tx) deleteBookTags(match func(bt bookTag) bool) {
+	kept := t.bookTags[:0:0]
+	for _, bt := range t.bookTags {
+		if !match(bt) {
+			kept = append(kept, bt)
+		}
+	}
+	t.bookTags = kept
+}
+
+// publishers
+
+func (t *tx) createPublisher(name string) domain.Publisher {
+	publisher := domain.Publisher{
+		ID:   t.store.nextID("publishers"),
+		Name: name,
+	}
+	t.publishers[publisher.ID] = publisher
+	return publisher
+}
+
+func (t *tx) updatePublisher(args domain.UpdatePublisherParams) (domain.Publisher, error) {
+	publisher, err := t.getPublisher(args.ID)
+	if err != nil {
+		return publisher, err
+	}
+	publisher.Name = args.Name
+	t.publishers[publisher.ID] = publisher
+	return publisher, nil
+}
+
+// deletePublisher deletes the publisher, setting the publisher of its books
+// to NULL. Publishers with deals cannot be deleted.
+func (t *tx) deletePublisher(id int64) (domain.Publisher, error) {
+	publisher, err := t.getPublisher(id)
+	if err != nil {
+		return publisher, err
+	}
+	for _, d := range t.deals {
+		if d.PublisherID == id {
+			return domain.Publisher{}, foreignKeyReferenced("publishers", "deals_publisher_id_fkey", "deals")
+		}
+	}
+	delete(t.publishers, id)
+	for _, book := range t.books {
+		if book.PublisherID != nil && *book.PublisherID == id {
+			book.PublisherID = nil
+			book.UpdatedAt = t.now
+			t.books[book.ID] = book
+		}
+	}
+	return publisher, nil
+}
+
+// representations
+
+// today is the date of the write, like CURRENT_DATE.
+func (t *tx) today() domain.Date {
+	return domain.DateOf(t.now.UTC())
+}
+
+// startPrimaryRepresentation mirrors the StartPrimaryRepresentation query and
+// the representations_start_primary trigger: unless the agent already is the
+// primary one, it ends the current primary representation of the author and
+// any co-representation by the agent, and starts a worldwide primary one. The
+// agent of an author is the one of its current primary representation.
+func (t *tx) startPrimaryRepresentation(authorID, agentID int64) (domain.Author, error) {
+	if _, ok := t.agents[agentID]; !ok {
+		return domain.Author{}, foreignKeyViolation("representations", "representations_agent_id_fkey")
+	}
+	if err := t.checkAgentNotDeleted(agentID); err != nil {
+		return domain.Author{}, err
+	}
+	author := t.authors[authorID]
+	for _, r := range t.reps {
+		if r.AuthorID == authorID && r.Primary && r.EndedAt == nil && r.AgentID == agentID {
+			return author, nil
+		}
+	}
+	for _, r := range t.reps {
+		if r.AuthorID == authorID && r.EndedAt == nil && (r.Primary || r.AgentID == agentID) {
+			t.endRepresentationToday(r)
+		}
+	}
+	r := domain.Representation{
+		ID:        t.store.nextID("representations"),
+		AuthorID:  authorID,
+		AgentID:   agentID,
+		Territory: "WORLD",
+		StartedAt: t.today(),
+		Primary:   true,
+	}
+	t.reps[r.ID] = r
+	author.AgentID = agentID
+	author.UpdatedAt = t.now
+	t.authors[authorID] = author
+	return author, nil
+}
+
+// endRepresentationToday ends a representation today, or on the day it
+// starts if that is later.
+func (t *tx) endRepresentationToday(r domain.Representation) {
+	endedAt := t.today()
+	if r.StartedAt.Time().After(endedAt.Time()) {
+		endedAt = r.StartedAt
+	}
+	r.EndedAt = &endedAt
+	t.reps[r.ID] = r
+}
+
+var territoryPattern = regexp.MustCompile(`^(WORLD|[A-Z]{2})$`)
+
+func (t *tx) createRepresentation(args domain.CreateRepresentationParams) (domain.Representation, error) {
+	if _, ok := t.authors[args.AuthorID]; !ok {
+		return domain.Representation{}, foreignKeyViolation("representations", "representations_author_id_fkey")
+	}
+	if _, ok := t.agents[args.AgentID]; !ok {
+		return domain.Representation{}, foreignKeyViolation("representations", "representations_agent_id_fkey")
+	}
+	if err := t.checkAgentNotDeleted(args.AgentID); err != nil {
+		return domain.Representation{}, err
+	}
+	if !territoryPattern.MatchString(args.Territory) {
+		return domain.Representation{}, domain.ErrInvalidTerritory
+	}
+	r := domain.Representation{
+		ID:        t.store.nextID("representations"),
+		AuthorID:  args.AuthorID,
+		AgentID:   args.AgentID,
+		Territory: args.Territory,
+		StartedAt: args.StartedAt,
+	}
+	t.reps[r.ID] = r
+	return r, nil
+}
+
+func (t *tx) endRepresentation(id int64, endedAt domain.Date) (domain.Representation, error) {
+	r, err := t.getRepresentation(id)
+	if err != nil {
+		return r, err
+	}
+	if endedAt.Time().Before(r.StartedAt.Time()) {
+		return domain.Representation{}, domain.ErrInvalidRepresentationPeriod
+	}
+	r.EndedAt = &endedAt
+	t.reps[r.ID] = r
+	return r, nil
+}
+
+func (t *tx) deleteRepresentations(match func(r domain.Representation) bool) {
+	for id, r := range t.reps {
+		if match(r) {
+			delete(t.reps, id)
+		}
+	}
+}
+
+// series
+
+func (t *tx) createSeries(title string) domain.Series {
+	series := domain.Series{
+		ID:    t.store.nextID("series"),
+		Title: title,
+	}
+	t.series[series.ID] = series
+	return series
+}
+
+func (t *tx) updateSeries(args domain.UpdateSeriesParams) (domain.Series, error) {
+	series, err := t.getSeries(args.ID)
+	if err != nil {
+		return series, err
+	}
+	series.Title = args.Title
+	t.series[series.ID] = series
+	return series, nil
+}
+
+// deleteSeries deletes the series, removing its books from it.
+func (t *tx) deleteSeries(id int64) (domain.Series, error) {
+	series, err := t.getSeries(id)
+	if err != nil {
+		return series, err
+	}
+	for _, book := range t.listBooksBySeriesID(id) {
+		book.SeriesID = nil
+		book.SeriesPosition = nil
+		book.UpdatedAt = t.now
+		t.books[book.ID] = book
+	}
+	delete(t.series, id)
+	return series, nil
+}
+
+// submissions
+
+func (t *tx) createSubmission(args domain.CreateSubmissionParams) (domain.Submission, error) {
+	if _, ok := t.agents[args.AgentID]; !ok {
+		return domain.Submission{}, foreignKeyViolation("submissions", "submissions_agent_id_fkey")
+	}
+	if err := t.checkAgentNotDeleted(args.AgentID); err != nil {
+		return domain.Submission{}, err
+	}
+	submission := domain.Submission{
+		ID:            t.store.nextID("submissions"),
+		AgentID:       args.AgentID,
+		AuthorName:    args.AuthorName,
+		AuthorWebsite: copyString(args.AuthorWebsite),
+		Title:         args.Title,
+		Synopsis:      args.Synopsis,
+		Status:        domain.SubmissionReceived,
+		ReceivedAt:    t.now,
+	}
+	t.submissions[submission.ID] = submission
+	return submission, nil
+}
+
+// transitionSubmission moves the submission to the status and records the
+// transition, without checking that the submission can move there.
+func (t *tx) transitionSubmission(submission domain.Submission, status domain.SubmissionStatus, note *string) domain.Submission {
+	transition := domain.SubmissionTransition{
+		ID:             t.store.nextID("submission_transitions"),
+		SubmissionID:   submission.ID,
+		From:           submission.Status,
+		To:             status,
+		Note:           copyString(note),
+		TransitionedAt: t.now,
+	}
+	t.transitions[transition.ID] = transition
+	submission.Status = status
+	t.submissions[submission.ID] = submission
+	return submission
+}
+
+// deleteSubmission deletes the submission, cascading to its transitions.
+func (t *tx) deleteSubmission(id int64) {
+	delete(t.submissions, id)
+	for _, transition := range t.transitions {
+		if transition.SubmissionID == id {
+			delete(t.transitions, transition.ID)
+		}
+	}
+}
+
+// webhooks
+
+// enqueueEvent queues a delivery of the event for every webhook subscribed
+// to its type.
+func (t *tx) enqueueEvent(eventType string, data interface{}) error {
+	payload, err := json.Marshal(domain.WebhookEvent{
+		Type:       eventType,
+		OccurredAt: t.now.UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	for _, webhook := range t.listWebhooks() {
+		for _, et := range webhook.EventTypes {
+			if et != eventType {
+				continue
+			}
+			d := domain.WebhookDelivery{
+				ID:            t.store.nextID("webhook_deliveries"),
+				WebhookID:     webhook.ID,
+				EventType:     eventType,
+				Payload:       payload,
+				Status:        domain.DeliveryPending,
+				CreatedAt:     t.now,
+				NextAttemptAt: t.now,
+			}
+			t.deliveries[d.ID] = d
+			break
+		}
+	}
+	return nil
+}
+
+// authorEvent queues the event with the payload of the author.
+func (t *tx) authorEvent(eventType string, author domain.Author) error {
+	return t.enqueueEvent(eventType, domain.NewAuthorPayload(author))
+}
+
+// bookEvent queues the event with the payload of the book and its current
+// authors.
+func (t *tx) bookEvent(eventType string, book domain.Book) error {
+	return t.enqueueEvent(eventType, domain.NewBookPayload(book, t.listAuthorIDsByBookID(book.ID)))
+}
+
+// copies of the pointer fields of the records given to the store
+
+func copyInt64(p *int64) *int64 {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyInt(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyString(p *string) *string {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyISBN(p *domain.ISBN) *domain.ISBN {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyDate(p *domain.Date) *domain.Date {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyLanguage(p *domain.LanguageCode) *domain.LanguageCode {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyPrice(p *domain.Price) *domain.Price {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
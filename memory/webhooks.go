@@ -0,0 +1,244 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/fwojciec/litag-example/domain" // use your own github username
+)
+
+// webhooks
+
+// CreateWebhook creates a webhook.
+func (s *Store) CreateWebhook(ctx context.Context, args domain.CreateWebhookParams) (domain.Webhook, error) {
+	webhook := domain.Webhook{
+		URL:        args.URL,
+		Secret:     args.Secret,
+		EventTypes: copyStrings(args.EventTypes),
+	}
+	err := s.write(ctx, func(t *tx) error {
+		webhook.ID = s.nextID("webhooks")
+		t.webhooks[webhook.ID] = webhook
+		return nil
+	})
+	return webhook, err
+}
+
+// DeleteWebhook deletes a webhook, cascading to its deliveries.
+func (s *Store) DeleteWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		if webhook, err = t.getWebhook(id); err != nil {
+			return err
+		}
+		delete(t.webhooks, id)
+		for _, d := range t.deliveries {
+			if d.WebhookID == id {
+				delete(t.deliveries, d.ID)
+			}
+		}
+		return nil
+	})
+	return webhook, err
+}
+
+// GetWebhook returns a webhook.
+func (s *Store) GetWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := s.read(ctx, func(st *state) error {
+		var err error
+		webhook, err = st.getWebhook(id)
+		return err
+	})
+	return webhook, err
+}
+
+// ListWebhooks returns all webhooks ordered by id.
+func (s *Store) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	webhooks := []domain.Webhook{}
+	err := s.read(ctx, func(st *state) error {
+		webhooks = append(webhooks, st.listWebhooks()...)
+		return nil
+	})
+	return webhooks, err
+}
+
+// UpdateWebhook updates a webhook.
+func (s *Store) UpdateWebhook(ctx context.Context, args domain.UpdateWebhookParams) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := s.write(ctx, func(t *tx) error {
+		var err error
+		if webhook, err = t.getWebhook(args.ID); err != nil {
+			return err
+		}
+		webhook.URL = args.URL
+		webhook.Secret = args.Secret
+		webhook.EventTypes = copyStrings(args.EventTypes)
+		t.webhooks[webhook.ID] = webhook
+		return nil
+	})
+	return webhook, err
+}
+
+// webhook deliveries
+
+// ClaimWebhookDeliveries leases up to BatchSize pending deliveries that are
+// due, counting an attempt for each of them.
+func (s *Store) ClaimWebhookDeliveries(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+	var claimed []domain.ClaimedWebhookDelivery
+	err := s.write(ctx, func(t *tx) error {
+		var due []domain.WebhookDelivery
+		for _, d := range t.deliveries {
+			if d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(t.now) {
+				due = append(due, d)
+			}
+		}
+		sort.Slice(due, func(i, j int) bool {
+			if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+				return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+			}
+			return due[i].ID < due[j].ID
+		})
+		if len(due) > args.BatchSize {
+			due = due[:args.BatchSize]
+		}
+		for _, d := range due {
+			d.Attempts++
+			d.NextAttemptAt = args.LeaseUntil
+			t.deliveries[d.ID] = d
+			webhook := t.webhooks[d.WebhookID]
+			claimed = append(claimed, domain.ClaimedWebhookDelivery{
+				WebhookDelivery: d,
+				URL:             webhook.URL,
+				Secret:          webhook.Secret,
+			})
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+// CompleteWebhookDelivery marks a delivery as delivered.
+func (s *Store) CompleteWebhookDelivery(ctx context.Context, args domain.CompleteWebhookDeliveryParams) error {
+	return s.write(ctx, func(t *tx) error {
+		d, ok := t.deliveries[args.ID]
+		if !ok {
+			return nil
+		}
+		status, deliveredAt := args.ResponseStatus, t.now
+		d.Status = domain.DeliveryDelivered
+		d.ResponseStatus = &status
+		d.LastError = nil
+		d.DeliveredAt = &deliveredAt
+		t.deliveries[d.ID] = d
+		return nil
+	})
+}
+
+// FailWebhookDelivery records a failed delivery attempt.
+func (s *Store) FailWebhookDelivery(ctx context.Context, args domain.FailWebhookDeliveryParams) error {
+	return s.write(ctx, func(t *tx) error {
+		d, ok := t.deliveries[args.ID]
+		if !ok {
+			return nil
+		}
+		if !validDeliveryStatus(args.Status) {
+			return checkViolation("webhook_deliveries", "webhook_deliveries_status_check")
+		}
+		lastError := args.LastError
+		d.Status = args.Status
+		d.ResponseStatus = copyInt(args.ResponseStatus)
+		d.LastError = &lastError
+		d.NextAttemptAt = args.NextAttemptAt
+		t.deliveries[d.ID] = d
+		return nil
+	})
+}
+
+// ListWebhookDeliveries returns the deliveries of the webhook, newest first.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]domain.WebhookDelivery, error) {
+	deliveries := []domain.WebhookDelivery{}
+	err := s.read(ctx, func(st *state) error {
+		deliveries = append(deliveries, st.listWebhookDeliveries(func(d domain.WebhookDelivery) bool {
+			return d.WebhookID == webhookID
+		})...)
+		return nil
+	})
+	return deliveries, err
+}
+
+// ListWebhookDeliveriesByStatus returns the deliveries of the webhook with
+// the status, newest first.
+func (s *Store) ListWebhookDeliveriesByStatus(ctx context.Context, args domain.ListWebhookDeliveriesByStatusParams) ([]domain.WebhookDelivery, error) {
+	deliveries := []domain.WebhookDelivery{}
+	err := s.read(ctx, func(st *state) error {
+		deliveries = append(deliveries, st.listWebhookDeliveries(func(d domain.WebhookDelivery) bool {
+			return d.WebhookID == args.WebhookID && d.Status == args.Status
+		})...)
+		return nil
+	})
+	return deliveries, err
+}
+
+// RetryWebhookDelivery makes a delivery pending and due again, with a fresh
+// set of attempts.
+func (s *Store) RetryWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	err := s.write(ctx, func(t *tx) error {
+		var ok bool
+		if d, ok = t.deliveries[id]; !ok {
+			return domain.ErrNotFound
+		}
+		d.Status = domain.DeliveryPending
+		d.Attempts = 0
+		d.LastError = nil
+		d.NextAttemptAt = t.now
+		t.deliveries[d.ID] = d
+		return nil
+	})
+	return d, err
+}
+
+func (st *state) getWebhook(id int64) (domain.Webhook, error) {
+	webhook, ok := st.webhooks[id]
+	if !ok {
+		return domain.Webhook{}, domain.ErrNotFound
+	}
+	return webhook, nil
+}
+
+func (st *state) listWebhooks() []domain.Webhook {
+	var webhooks []domain.Webhook
+	for _, webhook := range st.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks
+}
+
+func (st *state) listWebhookDeliveries(match func(d domain.WebhookDelivery) bool) []domain.WebhookDelivery {
+	var deliveries []domain.WebhookDelivery
+	for _, d := range st.deliveries {
+		if match(d) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+	return deliveries
+}
+
+func validDeliveryStatus(status string) bool {
+	switch status {
+	case domain.DeliveryPending, domain.DeliveryDelivered, domain.DeliveryDead:
+		return true
+	}
+	return false
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
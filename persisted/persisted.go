@@ -0,0 +1,126 @@
+// Package persisted implements the storage behind automatic persisted queries
+// and an operation allowlist for the GraphQL handler.
+//
+// With automatic persisted queries clients send the sha256 hash of a query in
+// extensions.persistedQuery instead of the query itself, and the query only
+// when the server does not know the hash yet. Cache remembers the queries
+// clients registered this way.
+//
+// In production the server can instead execute only the operations of an
+// allowlist file generated from the client code, a JSON object mapping the
+// hex-encoded sha256 hash of every query document to the document:
+//
+//	{"5f2c…": "query Books { books { id title } }"}
+package persisted
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fwojciec/litag-example/internal/lru" // update your username
+	"github.com/vektah/gqlparser/gqlerror"
+)
+
+// CodeOperationNotAllowed is the extensions code of the error returned for
+// operations missing from the allowlist.
+const CodeOperationNotAllowed = "OPERATION_NOT_ALLOWED"
+
+// Hash returns the hash identifying a query document.
+func Hash(query string) string {
+	b := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(b[:])
+}
+
+// Cache is a bounded store of the queries registered by clients; the least
+// recently used queries are dropped first.
+type Cache struct {
+	lru *lru.LRU
+}
+
+// NewCache returns a new instance of Cache holding at most size queries.
+func NewCache(size int) (*Cache, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid persisted query cache size %d", size)
+	}
+	return &Cache{lru: lru.New(size, 0)}, nil
+}
+
+// Add registers a query under its hash; the handler has verified the hash.
+func (c *Cache) Add(ctx context.Context, hash string, query string) {
+	c.lru.Add(hash, query)
+}
+
+// Get returns the query registered under the hash.
+func (c *Cache) Get(ctx context.Context, hash string) (string, bool) {
+	v, _, ok := c.lru.Get(hash)
+	if !ok {
+		return "", false
+	}
+	return v.(string), true
+}
+
+// Allowlist is the set of operations a server in production mode executes.
+// It also serves the automatic persisted query protocol, but only for the
+// queries it lists; clients cannot register new ones.
+type Allowlist struct {
+	queries map[string]string
+}
+
+// LoadAllowlist reads an allowlist file.
+func LoadAllowlist(path string) (*Allowlist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadAllowlist(f)
+}
+
+// ReadAllowlist reads an allowlist, checking that every query matches its
+// hash.
+func ReadAllowlist(r io.Reader) (*Allowlist, error) {
+	var queries map[string]string
+	if err := json.NewDecoder(r).Decode(&queries); err != nil {
+		return nil, fmt.Errorf("invalid allowlist: %s", err)
+	}
+	for hash, query := range queries {
+		if Hash(query) != hash {
+			return nil, fmt.Errorf("invalid allowlist: hash %s does not match its query", hash)
+		}
+	}
+	return &Allowlist{queries: queries}, nil
+}
+
+// Add does nothing, the allowlist is fixed.
+func (a *Allowlist) Add(ctx context.Context, hash string, query string) {}
+
+// Get returns the allowed query with the hash.
+func (a *Allowlist) Get(ctx context.Context, hash string) (string, bool) {
+	query, ok := a.queries[hash]
+	return query, ok
+}
+
+// Allowed reports whether the query document is in the allowlist.
+func (a *Allowlist) Allowed(query string) bool {
+	_, ok := a.queries[Hash(query)]
+	return ok
+}
+
+// Middleware is a graphql.RequestMiddleware that refuses to execute
+// operations missing from the allowlist, however they were sent.
+func (a *Allowlist) Middleware(ctx context.Context, next func(ctx context.Context) []byte) []byte {
+	if !a.Allowed(graphql.GetRequestContext(ctx).RawQuery) {
+		graphql.AddError(ctx, &gqlerror.Error{
+			Message:    "operation is not in the allowlist",
+			Extensions: map[string]interface{}{"code": CodeOperationNotAllowed},
+		})
+		return nil
+	}
+	return next(ctx)
+}
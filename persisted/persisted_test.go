@@ -0,0 +1,181 @@
+package persisted_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/handler"
+	"github.com/fwojciec/litag-example/domain"
+	"github.com/fwojciec/litag-example/generated/gqlgen"
+	"github.com/fwojciec/litag-example/generated/mocks"
+	"github.com/fwojciec/litag-example/persisted"
+	"github.com/fwojciec/litag-example/resolvers"
+)
+
+const (
+	agentsQuery = "query Agents { agents { id name } }"
+	booksQuery  = "query Books { books { id title } }"
+)
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newHandler(options ...handler.Option) http.HandlerFunc {
+	return handler.GraphQL(gqlgen.NewExecutableSchema(gqlgen.Config{
+		Resolvers: &resolvers.Resolver{
+			Repo: &mocks.RepositoryMock{
+				ListAgentsFunc: func(ctx context.Context) ([]domain.Agent, error) {
+					return []domain.Agent{{ID: 1, Name: "agent"}}, nil
+				},
+				ListBooksFunc: func(ctx context.Context) ([]domain.Book, error) {
+					return []domain.Book{{ID: 2, Title: "book"}}, nil
+				},
+			},
+		},
+	}), options...)
+}
+
+// do posts a request with the query, the persisted query hash, or both.
+func do(t *testing.T, h http.Handler, query, hash string) response {
+	t.Helper()
+	body := map[string]interface{}{}
+	if query != "" {
+		body["query"] = query
+	}
+	if hash != "" {
+		body["extensions"] = map[string]interface{}{
+			"persistedQuery": map[string]interface{}{"version": 1, "sha256Hash": hash},
+		}
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/query", strings.NewReader(string(b)))
+	r.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(w, r)
+	var res response
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("invalid response %s: %s", w.Body.String(), err)
+	}
+	return res
+}
+
+func errorMessage(res response) string {
+	if len(res.Errors) == 0 {
+		return ""
+	}
+	return res.Errors[0].Message
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+	cache, err := persisted.NewCache(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newHandler(handler.EnablePersistedQueryCache(cache))
+
+	if msg := errorMessage(do(t, h, "", persisted.Hash(agentsQuery))); msg != "PersistedQueryNotFound" {
+		t.Errorf("expected PersistedQueryNotFound, received %q", msg)
+	}
+	if res := do(t, h, agentsQuery, persisted.Hash(agentsQuery)); len(res.Errors) > 0 {
+		t.Fatalf("unexpected errors registering the query: %v", res.Errors)
+	}
+	res := do(t, h, "", persisted.Hash(agentsQuery))
+	if exp := `{"agents":[{"id":1,"name":"agent"}]}`; string(res.Data) != exp {
+		t.Errorf("expected %s, received %s (%v)", exp, res.Data, res.Errors)
+	}
+	// registering another query evicts the first one
+	do(t, h, booksQuery, persisted.Hash(booksQuery))
+	if msg := errorMessage(do(t, h, "", persisted.Hash(agentsQuery))); msg != "PersistedQueryNotFound" {
+		t.Errorf("expected the evicted query to be unknown, received %q", msg)
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	t.Parallel()
+	allowlist, err := persisted.ReadAllowlist(strings.NewReader(
+		fmt.Sprintf(`{%q: %q}`, persisted.Hash(agentsQuery), agentsQuery),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newHandler(
+		handler.EnablePersistedQueryCache(allowlist),
+		handler.RequestMiddleware(allowlist.Middleware),
+	)
+	exp := `{"agents":[{"id":1,"name":"agent"}]}`
+
+	tests := []struct {
+		name  string
+		query string
+		hash  string
+		data  string
+		code  interface{}
+	}{
+		{"allowed query", agentsQuery, "", exp, nil},
+		{"allowed hash", "", persisted.Hash(agentsQuery), exp, nil},
+		{"allowed query and hash", agentsQuery, persisted.Hash(agentsQuery), exp, nil},
+		{"other query", booksQuery, "", "null", persisted.CodeOperationNotAllowed},
+		{"other query registered", booksQuery, persisted.Hash(booksQuery), "null", persisted.CodeOperationNotAllowed},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			res := do(t, h, tc.query, tc.hash)
+			if string(res.Data) != tc.data {
+				t.Errorf("expected data %s, received %s", tc.data, res.Data)
+			}
+			var code interface{}
+			if len(res.Errors) > 0 {
+				code = res.Errors[0].Extensions["code"]
+			}
+			if code != tc.code {
+				t.Errorf("expected error code %v, received %v (%v)", tc.code, code, res.Errors)
+			}
+		})
+	}
+
+	t.Run("Unregistered hash", func(t *testing.T) {
+		t.Parallel()
+		if msg := errorMessage(do(t, h, "", persisted.Hash(booksQuery))); msg != "PersistedQueryNotFound" {
+			t.Errorf("expected PersistedQueryNotFound, received %q", msg)
+		}
+	})
+}
+
+func TestReadAllowlist(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"valid", fmt.Sprintf(`{%q: %q}`, persisted.Hash(agentsQuery), agentsQuery), true},
+		{"wrong hash", fmt.Sprintf(`{%q: %q}`, persisted.Hash(booksQuery), agentsQuery), false},
+		{"not json", `agents`, false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := persisted.ReadAllowlist(strings.NewReader(tc.input))
+			if (err == nil) != tc.valid {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
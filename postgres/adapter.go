@@ -0,0 +1,1783 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/fwojciec/litag-example/domain"         // use your own github username
+	"github.com/fwojciec/litag-example/generated/sqlc" // use your own github username
+	"github.com/lib/pq"
+)
+
+// Adapter implements domain.Repository on top of a Repo, converting between
+// the sqlc and the domain types. It also implements the store of the webhook
+// dispatcher.
+type Adapter struct {
+	repo *Repo
+}
+
+// NewAdapter returns a new instance of Adapter.
+func NewAdapter(repo *Repo) *Adapter {
+	return &Adapter{repo: repo}
+}
+
+var _ domain.Repository = (*Adapter)(nil)
+
+// agencies
+
+// CreateAgency creates an agency.
+func (a *Adapter) CreateAgency(ctx context.Context, args domain.CreateAgencyParams) (domain.Agency, error) {
+	agency, err := a.repo.CreateAgency(ctx, args.Name)
+	if err != nil {
+		return domain.Agency{}, toDomainError(err)
+	}
+	return toDomainAgency(agency), nil
+}
+
+// GetAgency returns an agency.
+func (a *Adapter) GetAgency(ctx context.Context, id int64) (domain.Agency, error) {
+	agency, err := a.repo.GetAgency(ctx, id)
+	if err != nil {
+		return domain.Agency{}, toDomainError(err)
+	}
+	return toDomainAgency(agency), nil
+}
+
+// ListAgencies returns all agencies.
+func (a *Adapter) ListAgencies(ctx context.Context) ([]domain.Agency, error) {
+	agencies, err := a.repo.ListAgencies(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Agency, 0, len(agencies))
+	for _, agency := range agencies {
+		res = append(res, toDomainAgency(agency))
+	}
+	return res, nil
+}
+
+// UpdateAgency updates an agency.
+func (a *Adapter) UpdateAgency(ctx context.Context, args domain.UpdateAgencyParams) (domain.Agency, error) {
+	agency, err := a.repo.UpdateAgency(ctx, sqlc.UpdateAgencyParams{
+		ID:   args.ID,
+		Name: args.Name,
+	})
+	if err != nil {
+		return domain.Agency{}, toDomainError(err)
+	}
+	return toDomainAgency(agency), nil
+}
+
+// DeleteAgency deletes an agency; its agents are kept without one.
+func (a *Adapter) DeleteAgency(ctx context.Context, id int64) (domain.Agency, error) {
+	agency, err := a.repo.DeleteAgency(ctx, id)
+	if err != nil {
+		return domain.Agency{}, toDomainError(err)
+	}
+	return toDomainAgency(agency), nil
+}
+
+// agents
+
+// CreateAgent creates an agent.
+func (a *Adapter) CreateAgent(ctx context.Context, args domain.CreateAgentParams) (domain.Agent, error) {
+	agent, err := a.repo.CreateAgent(ctx, sqlc.CreateAgentParams{
+		Name:     args.Name,
+		Email:    args.Email,
+		AgencyID: int64PtrToNullInt64(args.AgencyID),
+	})
+	if err != nil {
+		return domain.Agent{}, toDomainError(err)
+	}
+	return toDomainAgent(agent), nil
+}
+
+// GetAgent returns an agent.
+func (a *Adapter) GetAgent(ctx context.Context, id int64) (domain.Agent, error) {
+	agent, err := a.repo.GetAgent(ctx, id)
+	if err != nil {
+		return domain.Agent{}, toDomainError(err)
+	}
+	return toDomainAgent(agent), nil
+}
+
+// ListAgents returns all agents.
+func (a *Adapter) ListAgents(ctx context.Context) ([]domain.Agent, error) {
+	agents, err := a.repo.ListAgents(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainAgents(agents), nil
+}
+
+// ListAgentsByAgencyID returns the agents of an agency.
+func (a *Adapter) ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]domain.Agent, error) {
+	agents, err := a.repo.ListAgentsByAgencyID(ctx, agencyID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainAgents(agents), nil
+}
+
+// UpdateAgent updates an agent.
+func (a *Adapter) UpdateAgent(ctx context.Context, args domain.UpdateAgentParams) (domain.Agent, error) {
+	agent, err := a.repo.UpdateAgent(ctx, sqlc.UpdateAgentParams{
+		ID:       args.ID,
+		Name:     args.Name,
+		Email:    args.Email,
+		AgencyID: int64PtrToNullInt64(args.AgencyID),
+	})
+	if err != nil {
+		return domain.Agent{}, toDomainError(err)
+	}
+	return toDomainAgent(agent), nil
+}
+
+// DeleteAgent deletes an agent.
+func (a *Adapter) DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+	agent, err := a.repo.DeleteAgent(ctx, id, reassignAuthorsTo)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainAgent(*agent)
+	return &res, nil
+}
+
+// authors
+
+// CreateAuthor creates an author.
+func (a *Adapter) CreateAuthor(ctx context.Context, args domain.CreateAuthorParams) (*domain.Author, error) {
+	author, err := a.repo.CreateAuthor(ctx, CreateAuthorParams{
+		CreateAuthorParams: sqlc.CreateAuthorParams{
+			Name:    args.Name,
+			Website: stringPtrToNullString(args.Website),
+		},
+		AgentID: args.AgentID,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainAuthor(*author)
+	return &res, nil
+}
+
+// GetAuthor returns an author.
+func (a *Adapter) GetAuthor(ctx context.Context, id int64) (domain.Author, error) {
+	author, err := a.repo.GetAuthor(ctx, id)
+	if err != nil {
+		return domain.Author{}, toDomainError(err)
+	}
+	return toDomainAuthor(Author(author)), nil
+}
+
+// ListAuthors returns all authors.
+func (a *Adapter) ListAuthors(ctx context.Context) ([]domain.Author, error) {
+	authors, err := a.repo.ListAuthors(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Author, 0, len(authors))
+	for _, author := range authors {
+		res = append(res, toDomainAuthor(Author(author)))
+	}
+	return res, nil
+}
+
+// ListAuthorsByAgencyID returns the authors represented by the agents of an
+// agency.
+func (a *Adapter) ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]domain.Author, error) {
+	authors, err := a.repo.ListAuthorsByAgencyID(ctx, agencyID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Author, 0, len(authors))
+	for _, author := range authors {
+		res = append(res, toDomainAuthor(Author(author)))
+	}
+	return res, nil
+}
+
+// ListAuthorsByAgentID returns the authors represented by an agent.
+func (a *Adapter) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]domain.Author, error) {
+	authors, err := a.repo.ListAuthorsByAgentID(ctx, agentID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Author, 0, len(authors))
+	for _, author := range authors {
+		res = append(res, toDomainAuthor(Author(author)))
+	}
+	return res, nil
+}
+
+// ListAuthorsByBookID returns the authors of a book.
+func (a *Adapter) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]domain.Author, error) {
+	authors, err := a.repo.ListAuthorsByBookID(ctx, bookID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Author, 0, len(authors))
+	for _, author := range authors {
+		res = append(res, toDomainAuthor(Author(author)))
+	}
+	return res, nil
+}
+
+// ListFormerAuthorsByAgentID returns the authors an agent once represented
+// but no longer does.
+func (a *Adapter) ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]domain.Author, error) {
+	authors, err := a.repo.ListFormerAuthorsByAgentID(ctx, agentID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Author, 0, len(authors))
+	for _, author := range authors {
+		res = append(res, toDomainAuthor(Author(author)))
+	}
+	return res, nil
+}
+
+// UpdateAuthor updates an author.
+func (a *Adapter) UpdateAuthor(ctx context.Context, args domain.UpdateAuthorParams) (*domain.Author, error) {
+	author, err := a.repo.UpdateAuthor(ctx, UpdateAuthorParams{
+		UpdateAuthorParams: sqlc.UpdateAuthorParams{
+			ID:      args.ID,
+			Name:    args.Name,
+			Website: stringPtrToNullString(args.Website),
+		},
+		AgentID: args.AgentID,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainAuthor(*author)
+	return &res, nil
+}
+
+// DeleteAuthor deletes an author.
+func (a *Adapter) DeleteAuthor(ctx context.Context, id int64, orphanedBooks domain.OrphanedBooksPolicy) (*domain.Author, error) {
+	author, err := a.repo.DeleteAuthor(ctx, id, OrphanedBooksPolicy(orphanedBooks))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainAuthor(*author)
+	return &res, nil
+}
+
+// books
+
+// CreateBook creates a book.
+func (a *Adapter) CreateBook(ctx context.Context, args domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+	book, err := a.repo.CreateBook(ctx, toCreateBookParams(args), toContributors(contributors), genreIDs, tags)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainBook(*book)
+	return &res, nil
+}
+
+// GetBook returns a book.
+func (a *Adapter) GetBook(ctx context.Context, id int64) (domain.Book, error) {
+	book, err := a.repo.GetBook(ctx, id)
+	if err != nil {
+		return domain.Book{}, toDomainError(err)
+	}
+	return toDomainBook(book), nil
+}
+
+// GetBookByISBN returns the book with the ISBN.
+func (a *Adapter) GetBookByISBN(ctx context.Context, isbn domain.ISBN) (domain.Book, error) {
+	book, err := a.repo.GetBookByISBN(ctx, string(isbn))
+	if err != nil {
+		return domain.Book{}, toDomainError(err)
+	}
+	return toDomainBook(book), nil
+}
+
+// ListBooks returns all books.
+func (a *Adapter) ListBooks(ctx context.Context) ([]domain.Book, error) {
+	books, err := a.repo.ListBooks(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBooks(books), nil
+}
+
+// ListBooksByAgencyID returns the books by authors represented by the agents
+// of an agency.
+func (a *Adapter) ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]domain.Book, error) {
+	books, err := a.repo.ListBooksByAgencyID(ctx, agencyID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBooks(books), nil
+}
+
+// ListBooksByAuthorID returns the books of an author.
+func (a *Adapter) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]domain.Book, error) {
+	books, err := a.repo.ListBooksByAuthorID(ctx, authorID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBooks(books), nil
+}
+
+// ListBooksByGenreID returns the books classified in a genre and, if
+// includeSubgenres is set, in any of its subgenres.
+func (a *Adapter) ListBooksByGenreID(ctx context.Context, genreID int64, includeSubgenres bool) ([]domain.Book, error) {
+	var books []sqlc.Book
+	var err error
+	if includeSubgenres {
+		books, err = a.repo.ListBooksInGenreTree(ctx, genreID)
+	} else {
+		books, err = a.repo.ListBooksByGenreID(ctx, genreID)
+	}
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBooks(books), nil
+}
+
+// ListBooksByPublisherID returns the books of a publisher.
+func (a *Adapter) ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]domain.Book, error) {
+	books, err := a.repo.ListBooksByPublisherID(ctx, publisherID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBooks(books), nil
+}
+
+// ListBooksBySeriesID returns the books of a series in series order.
+func (a *Adapter) ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]domain.Book, error) {
+	books, err := a.repo.ListBooksBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBooks(books), nil
+}
+
+// ListBooksByTag returns the books with a tag.
+func (a *Adapter) ListBooksByTag(ctx context.Context, tag string) ([]domain.Book, error) {
+	books, err := a.repo.ListBooksByTag(ctx, tag)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBooks(books), nil
+}
+
+// ListContributorsByBookID returns the contributors of a book in position
+// order.
+func (a *Adapter) ListContributorsByBookID(ctx context.Context, bookID int64) ([]domain.Contributor, error) {
+	contributors, err := a.repo.ListContributorsByBookID(ctx, bookID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainContributors(contributors), nil
+}
+
+// ListOrphanBooks returns the books without any authors.
+func (a *Adapter) ListOrphanBooks(ctx context.Context) ([]domain.Book, error) {
+	books, err := a.repo.ListOrphanBooks(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBooks(books), nil
+}
+
+// ListTagsByBookID returns the tags of a book in alphabetical order.
+func (a *Adapter) ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error) {
+	tags, err := a.repo.ListTagsByBookID(ctx, bookID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return tags, nil
+}
+
+// UpdateBook updates a book.
+func (a *Adapter) UpdateBook(ctx context.Context, args domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+	book, err := a.repo.UpdateBook(ctx, toUpdateBookParams(args), toContributors(contributors), genreIDs, tags)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainBook(*book)
+	return &res, nil
+}
+
+// DeleteBook deletes a book.
+func (a *Adapter) DeleteBook(ctx context.Context, id int64) (*domain.Book, error) {
+	book, err := a.repo.DeleteBook(ctx, id)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainBook(*book)
+	return &res, nil
+}
+
+// deals
+
+// CreateDeal creates a deal.
+func (a *Adapter) CreateDeal(ctx context.Context, args domain.CreateDealParams) (domain.Deal, error) {
+	deal, err := a.repo.CreateDeal(ctx, sqlc.CreateDealParams{
+		BookID:          args.BookID,
+		PublisherID:     args.PublisherID,
+		AgentID:         args.AgentID,
+		Territory:       args.Territory,
+		Language:        string(args.Language),
+		Format:          string(args.Format),
+		AdvanceAmount:   args.Advance.Amount,
+		AdvanceCurrency: args.Advance.Currency,
+		SignedOn:        datePtrToNullTime(args.SignedOn),
+		Status:          string(args.Status),
+		RoyaltyRate:     int32(args.RoyaltyRate),
+		CommissionRate:  int32(args.CommissionRate),
+	})
+	if err != nil {
+		return domain.Deal{}, toDomainError(err)
+	}
+	return toDomainDeal(deal), nil
+}
+
+// GetDeal returns a deal.
+func (a *Adapter) GetDeal(ctx context.Context, id int64) (domain.Deal, error) {
+	deal, err := a.repo.GetDeal(ctx, id)
+	if err != nil {
+		return domain.Deal{}, toDomainError(err)
+	}
+	return toDomainDeal(deal), nil
+}
+
+// ListDealsByAgentID returns the deals negotiated by an agent.
+func (a *Adapter) ListDealsByAgentID(ctx context.Context, agentID int64) ([]domain.Deal, error) {
+	deals, err := a.repo.ListDealsByAgentID(ctx, agentID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainDeals(deals), nil
+}
+
+// ListDealsByBookID returns the deals of a book.
+func (a *Adapter) ListDealsByBookID(ctx context.Context, bookID int64) ([]domain.Deal, error) {
+	deals, err := a.repo.ListDealsByBookID(ctx, bookID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainDeals(deals), nil
+}
+
+// ListAvailableRights returns, for every book with any, the formats whose
+// rights in the territory and language no deal holds.
+func (a *Adapter) ListAvailableRights(ctx context.Context, territory string, language domain.LanguageCode) ([]domain.AvailableRights, error) {
+	rows, err := a.repo.ListAvailableRights(ctx, sqlc.ListAvailableRightsParams{
+		Territory: territory,
+		Language:  string(language),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.AvailableRights, 0, len(rows))
+	for _, row := range rows {
+		if n := len(res); n == 0 || res[n-1].BookID != row.BookID {
+			res = append(res, domain.AvailableRights{BookID: row.BookID})
+		}
+		last := &res[len(res)-1]
+		last.Formats = append(last.Formats, domain.EditionFormat(row.Format))
+	}
+	return res, nil
+}
+
+// UpdateDeal updates a deal.
+func (a *Adapter) UpdateDeal(ctx context.Context, args domain.UpdateDealParams) (domain.Deal, error) {
+	deal, err := a.repo.UpdateDeal(ctx, sqlc.UpdateDealParams{
+		ID:              args.ID,
+		BookID:          args.BookID,
+		PublisherID:     args.PublisherID,
+		AgentID:         args.AgentID,
+		Territory:       args.Territory,
+		Language:        string(args.Language),
+		Format:          string(args.Format),
+		AdvanceAmount:   args.Advance.Amount,
+		AdvanceCurrency: args.Advance.Currency,
+		SignedOn:        datePtrToNullTime(args.SignedOn),
+		Status:          string(args.Status),
+		RoyaltyRate:     int32(args.RoyaltyRate),
+		CommissionRate:  int32(args.CommissionRate),
+	})
+	if err != nil {
+		return domain.Deal{}, toDomainError(err)
+	}
+	return toDomainDeal(deal), nil
+}
+
+// DeleteDeal deletes a deal.
+func (a *Adapter) DeleteDeal(ctx context.Context, id int64) (domain.Deal, error) {
+	deal, err := a.repo.DeleteDeal(ctx, id)
+	if err != nil {
+		return domain.Deal{}, toDomainError(err)
+	}
+	return toDomainDeal(deal), nil
+}
+
+// editions
+
+// CreateEdition creates an edition of a book.
+func (a *Adapter) CreateEdition(ctx context.Context, args domain.CreateEditionParams) (domain.Edition, error) {
+	edition, err := a.repo.CreateEdition(ctx, sqlc.CreateEditionParams{
+		BookID:        args.BookID,
+		Format:        string(args.Format),
+		Isbn:          isbnPtrToNullString(args.ISBN),
+		PublishedOn:   datePtrToNullTime(args.PublishedOn),
+		PriceAmount:   priceAmountToNullInt64(args.Price),
+		PriceCurrency: priceCurrencyToNullString(args.Price),
+	})
+	if err != nil {
+		return domain.Edition{}, toDomainError(err)
+	}
+	return toDomainEdition(edition), nil
+}
+
+// GetEdition returns an edition.
+func (a *Adapter) GetEdition(ctx context.Context, id int64) (domain.Edition, error) {
+	edition, err := a.repo.GetEdition(ctx, id)
+	if err != nil {
+		return domain.Edition{}, toDomainError(err)
+	}
+	return toDomainEdition(edition), nil
+}
+
+// GetEditionByISBN returns the edition with the ISBN.
+func (a *Adapter) GetEditionByISBN(ctx context.Context, isbn domain.ISBN) (domain.Edition, error) {
+	edition, err := a.repo.GetEditionByISBN(ctx, string(isbn))
+	if err != nil {
+		return domain.Edition{}, toDomainError(err)
+	}
+	return toDomainEdition(edition), nil
+}
+
+// ListEditions returns the editions matching the filters, regardless of
+// their book.
+func (a *Adapter) ListEditions(ctx context.Context, args domain.ListEditionsParams) ([]domain.Edition, error) {
+	params := sqlc.ListEditionsParams{}
+	if args.BookID != nil {
+		params.BookID = *args.BookID
+	}
+	if args.Format != nil {
+		params.Format = string(*args.Format)
+	}
+	if args.PublishedFrom != nil {
+		params.PublishedFrom = args.PublishedFrom.String()
+	}
+	if args.PublishedTo != nil {
+		params.PublishedTo = args.PublishedTo.String()
+	}
+	editions, err := a.repo.ListEditions(ctx, params)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainEditions(editions), nil
+}
+
+// ListEditionsByBookID returns the editions of a book.
+func (a *Adapter) ListEditionsByBookID(ctx context.Context, bookID int64) ([]domain.Edition, error) {
+	editions, err := a.repo.ListEditionsByBookID(ctx, bookID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainEditions(editions), nil
+}
+
+// UpdateEdition updates an edition.
+func (a *Adapter) UpdateEdition(ctx context.Context, args domain.UpdateEditionParams) (domain.Edition, error) {
+	edition, err := a.repo.UpdateEdition(ctx, sqlc.UpdateEditionParams{
+		ID:            args.ID,
+		BookID:        args.BookID,
+		Format:        string(args.Format),
+		Isbn:          isbnPtrToNullString(args.ISBN),
+		PublishedOn:   datePtrToNullTime(args.PublishedOn),
+		PriceAmount:   priceAmountToNullInt64(args.Price),
+		PriceCurrency: priceCurrencyToNullString(args.Price),
+	})
+	if err != nil {
+		return domain.Edition{}, toDomainError(err)
+	}
+	return toDomainEdition(edition), nil
+}
+
+// DeleteEdition deletes an edition.
+func (a *Adapter) DeleteEdition(ctx context.Context, id int64) (domain.Edition, error) {
+	edition, err := a.repo.DeleteEdition(ctx, id)
+	if err != nil {
+		return domain.Edition{}, toDomainError(err)
+	}
+	return toDomainEdition(edition), nil
+}
+
+// genres
+
+// CreateGenre creates a genre.
+func (a *Adapter) CreateGenre(ctx context.Context, args domain.CreateGenreParams) (domain.Genre, error) {
+	genre, err := a.repo.CreateGenre(ctx, sqlc.CreateGenreParams{
+		Name:     args.Name,
+		ParentID: int64PtrToNullInt64(args.ParentID),
+	})
+	if err != nil {
+		return domain.Genre{}, toDomainError(err)
+	}
+	return toDomainGenre(genre), nil
+}
+
+// GetGenre returns a genre.
+func (a *Adapter) GetGenre(ctx context.Context, id int64) (domain.Genre, error) {
+	genre, err := a.repo.GetGenre(ctx, id)
+	if err != nil {
+		return domain.Genre{}, toDomainError(err)
+	}
+	return toDomainGenre(genre), nil
+}
+
+// ListGenres returns all genres.
+func (a *Adapter) ListGenres(ctx context.Context) ([]domain.Genre, error) {
+	genres, err := a.repo.ListGenres(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainGenres(genres), nil
+}
+
+// ListGenresByParentID returns the direct subgenres of a genre.
+func (a *Adapter) ListGenresByParentID(ctx context.Context, parentID int64) ([]domain.Genre, error) {
+	genres, err := a.repo.ListGenresByParentID(ctx, parentID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainGenres(genres), nil
+}
+
+// ListGenresByBookID returns the genres of a book.
+func (a *Adapter) ListGenresByBookID(ctx context.Context, bookID int64) ([]domain.Genre, error) {
+	genres, err := a.repo.ListGenresByBookID(ctx, bookID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainGenres(genres), nil
+}
+
+// UpdateGenre updates a genre.
+func (a *Adapter) UpdateGenre(ctx context.Context, args domain.UpdateGenreParams) (*domain.Genre, error) {
+	genre, err := a.repo.UpdateGenre(ctx, sqlc.UpdateGenreParams{
+		ID:       args.ID,
+		Name:     args.Name,
+		ParentID: int64PtrToNullInt64(args.ParentID),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainGenre(*genre)
+	return &res, nil
+}
+
+// DeleteGenre deletes a genre; its book associations are removed with it.
+func (a *Adapter) DeleteGenre(ctx context.Context, id int64) (domain.Genre, error) {
+	genre, err := a.repo.DeleteGenre(ctx, id)
+	if err != nil {
+		return domain.Genre{}, toDomainError(err)
+	}
+	return toDomainGenre(genre), nil
+}
+
+// publishers
+
+// CreatePublisher creates a publisher.
+func (a *Adapter) CreatePublisher(ctx context.Context, args domain.CreatePublisherParams) (domain.Publisher, error) {
+	publisher, err := a.repo.CreatePublisher(ctx, args.Name)
+	if err != nil {
+		return domain.Publisher{}, toDomainError(err)
+	}
+	return toDomainPublisher(publisher), nil
+}
+
+// GetPublisher returns a publisher.
+func (a *Adapter) GetPublisher(ctx context.Context, id int64) (domain.Publisher, error) {
+	publisher, err := a.repo.GetPublisher(ctx, id)
+	if err != nil {
+		return domain.Publisher{}, toDomainError(err)
+	}
+	return toDomainPublisher(publisher), nil
+}
+
+// ListPublishers returns all publishers.
+func (a *Adapter) ListPublishers(ctx context.Context) ([]domain.Publisher, error) {
+	publishers, err := a.repo.ListPublishers(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Publisher, 0, len(publishers))
+	for _, p := range publishers {
+		res = append(res, toDomainPublisher(p))
+	}
+	return res, nil
+}
+
+// UpdatePublisher updates a publisher.
+func (a *Adapter) UpdatePublisher(ctx context.Context, args domain.UpdatePublisherParams) (domain.Publisher, error) {
+	publisher, err := a.repo.UpdatePublisher(ctx, sqlc.UpdatePublisherParams{
+		ID:   args.ID,
+		Name: args.Name,
+	})
+	if err != nil {
+		return domain.Publisher{}, toDomainError(err)
+	}
+	return toDomainPublisher(publisher), nil
+}
+
+// DeletePublisher deletes a publisher; its books are kept without one.
+func (a *Adapter) DeletePublisher(ctx context.Context, id int64) (domain.Publisher, error) {
+	publisher, err := a.repo.DeletePublisher(ctx, id)
+	if err != nil {
+		return domain.Publisher{}, toDomainError(err)
+	}
+	return toDomainPublisher(publisher), nil
+}
+
+// representations
+
+// CreateRepresentation adds a co-representation of an author by an agent.
+func (a *Adapter) CreateRepresentation(ctx context.Context, args domain.CreateRepresentationParams) (domain.Representation, error) {
+	representation, err := a.repo.CreateRepresentation(ctx, sqlc.CreateRepresentationParams{
+		AuthorID:  args.AuthorID,
+		AgentID:   args.AgentID,
+		Territory: args.Territory,
+		StartedAt: args.StartedAt.Time(),
+	})
+	if err != nil {
+		return domain.Representation{}, toDomainError(err)
+	}
+	return toDomainRepresentation(representation), nil
+}
+
+// ListRepresentationsByAuthorID returns the representation history of an
+// author, oldest first.
+func (a *Adapter) ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]domain.Representation, error) {
+	representations, err := a.repo.ListRepresentationsByAuthorID(ctx, authorID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Representation, 0, len(representations))
+	for _, r := range representations {
+		res = append(res, toDomainRepresentation(r))
+	}
+	return res, nil
+}
+
+// EndRepresentation ends a co-representation at endedAt.
+func (a *Adapter) EndRepresentation(ctx context.Context, id int64, endedAt domain.Date) (domain.Representation, error) {
+	representation, err := a.repo.EndRepresentation(ctx, id, endedAt.Time())
+	if err != nil {
+		return domain.Representation{}, toDomainError(err)
+	}
+	return toDomainRepresentation(*representation), nil
+}
+
+// royalties
+
+// SetRoyaltyRate sets the royalty rate of a deal for the sales of an edition,
+// replacing any rate set before.
+func (a *Adapter) SetRoyaltyRate(ctx context.Context, dealID, editionID int64, rate int) (domain.RoyaltyRate, error) {
+	royaltyRate, err := a.repo.SetRoyaltyRate(ctx, sqlc.SetRoyaltyRateParams{
+		DealID:    dealID,
+		EditionID: editionID,
+		Rate:      int32(rate),
+	})
+	if err != nil {
+		return domain.RoyaltyRate{}, toDomainError(err)
+	}
+	return toDomainRoyaltyRate(royaltyRate), nil
+}
+
+// ListRoyaltyRatesByDealID returns the royalty rates of the editions of a deal.
+func (a *Adapter) ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]domain.RoyaltyRate, error) {
+	royaltyRates, err := a.repo.ListRoyaltyRatesByDealID(ctx, dealID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.RoyaltyRate, 0, len(royaltyRates))
+	for _, r := range royaltyRates {
+		res = append(res, toDomainRoyaltyRate(r))
+	}
+	return res, nil
+}
+
+// DeleteRoyaltyRate deletes the royalty rate of a deal for an edition, whose
+// sales then earn the rate of the deal.
+func (a *Adapter) DeleteRoyaltyRate(ctx context.Context, dealID, editionID int64) (domain.RoyaltyRate, error) {
+	royaltyRate, err := a.repo.DeleteRoyaltyRate(ctx, sqlc.DeleteRoyaltyRateParams{
+		DealID:    dealID,
+		EditionID: editionID,
+	})
+	if err != nil {
+		return domain.RoyaltyRate{}, toDomainError(err)
+	}
+	return toDomainRoyaltyRate(royaltyRate), nil
+}
+
+// RecordSales records the sales of an edition under a deal in a quarter.
+func (a *Adapter) RecordSales(ctx context.Context, args domain.RecordSalesParams) (domain.Sales, error) {
+	sales, err := a.repo.RecordSales(ctx, sqlc.RecordSalesParams{
+		DealID:          args.DealID,
+		EditionID:       args.EditionID,
+		Period:          args.Period,
+		Units:           int32(args.Units),
+		RevenueAmount:   args.Revenue.Amount,
+		RevenueCurrency: args.Revenue.Currency,
+	})
+	if err != nil {
+		return domain.Sales{}, toDomainError(err)
+	}
+	return toDomainSales(sales), nil
+}
+
+// ListSalesByDealID returns the sales recorded under a deal.
+func (a *Adapter) ListSalesByDealID(ctx context.Context, dealID int64) ([]domain.Sales, error) {
+	sales, err := a.repo.ListSalesByDealID(ctx, dealID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Sales, 0, len(sales))
+	for _, s := range sales {
+		res = append(res, toDomainSales(s))
+	}
+	return res, nil
+}
+
+// DeleteSales deletes a sales record.
+func (a *Adapter) DeleteSales(ctx context.Context, id int64) (domain.Sales, error) {
+	sales, err := a.repo.DeleteSales(ctx, id)
+	if err != nil {
+		return domain.Sales{}, toDomainError(err)
+	}
+	return toDomainSales(sales), nil
+}
+
+// GetRoyaltyStatement calculates the royalty statement of an author for a
+// quarter from the sales of the books they wrote.
+func (a *Adapter) GetRoyaltyStatement(ctx context.Context, authorID int64, period string) (domain.RoyaltyStatement, error) {
+	rows, err := a.repo.ListRoyaltySales(ctx, sqlc.ListRoyaltySalesParams{
+		Period:   period,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		return domain.RoyaltyStatement{}, toDomainError(err)
+	}
+	sales := make([]domain.RoyaltySales, 0, len(rows))
+	for _, row := range rows {
+		sales = append(sales, domain.RoyaltySales{
+			Sales: domain.Sales{
+				ID:        row.ID,
+				DealID:    row.DealID,
+				EditionID: row.EditionID,
+				Period:    row.Period,
+				Units:     int(row.Units),
+				Revenue:   domain.Money{Amount: row.RevenueAmount, Currency: row.RevenueCurrency},
+			},
+			BookID:         row.BookID,
+			AgentID:        row.AgentID,
+			RoyaltyRate:    int(row.RoyaltyRate),
+			CommissionRate: int(row.CommissionRate),
+			Authors:        int(row.Authors),
+		})
+	}
+	return domain.NewRoyaltyStatement(authorID, period, sales), nil
+}
+
+// series
+
+// CreateSeries creates a series.
+func (a *Adapter) CreateSeries(ctx context.Context, args domain.CreateSeriesParams) (domain.Series, error) {
+	series, err := a.repo.CreateSeries(ctx, args.Title)
+	if err != nil {
+		return domain.Series{}, toDomainError(err)
+	}
+	return toDomainSeries(series), nil
+}
+
+// GetSeries returns a series.
+func (a *Adapter) GetSeries(ctx context.Context, id int64) (domain.Series, error) {
+	series, err := a.repo.GetSeries(ctx, id)
+	if err != nil {
+		return domain.Series{}, toDomainError(err)
+	}
+	return toDomainSeries(series), nil
+}
+
+// ListSeries returns all series.
+func (a *Adapter) ListSeries(ctx context.Context) ([]domain.Series, error) {
+	series, err := a.repo.ListSeries(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Series, 0, len(series))
+	for _, s := range series {
+		res = append(res, toDomainSeries(s))
+	}
+	return res, nil
+}
+
+// UpdateSeries updates a series.
+func (a *Adapter) UpdateSeries(ctx context.Context, args domain.UpdateSeriesParams) (domain.Series, error) {
+	series, err := a.repo.UpdateSeries(ctx, sqlc.UpdateSeriesParams{
+		ID:    args.ID,
+		Title: args.Title,
+	})
+	if err != nil {
+		return domain.Series{}, toDomainError(err)
+	}
+	return toDomainSeries(series), nil
+}
+
+// ReorderSeries numbers the books of a series in the order of bookIDs.
+func (a *Adapter) ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+	series, err := a.repo.ReorderSeries(ctx, id, bookIDs)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainSeries(*series)
+	return &res, nil
+}
+
+// DeleteSeries deletes a series; its books are kept outside of any series.
+func (a *Adapter) DeleteSeries(ctx context.Context, id int64) (*domain.Series, error) {
+	series, err := a.repo.DeleteSeries(ctx, id)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainSeries(*series)
+	return &res, nil
+}
+
+// submissions
+
+// CreateSubmission records a manuscript received by an agent.
+func (a *Adapter) CreateSubmission(ctx context.Context, args domain.CreateSubmissionParams) (domain.Submission, error) {
+	submission, err := a.repo.CreateSubmission(ctx, sqlc.CreateSubmissionParams{
+		AgentID:       args.AgentID,
+		AuthorName:    args.AuthorName,
+		AuthorWebsite: stringPtrToNullString(args.AuthorWebsite),
+		Title:         args.Title,
+		Synopsis:      args.Synopsis,
+	})
+	if err != nil {
+		return domain.Submission{}, toDomainError(err)
+	}
+	return toDomainSubmission(submission), nil
+}
+
+// GetSubmission returns a submission.
+func (a *Adapter) GetSubmission(ctx context.Context, id int64) (domain.Submission, error) {
+	submission, err := a.repo.GetSubmission(ctx, id)
+	if err != nil {
+		return domain.Submission{}, toDomainError(err)
+	}
+	return toDomainSubmission(submission), nil
+}
+
+// ListSubmissionsByAgentID returns the submissions to an agent in the order
+// they were received.
+func (a *Adapter) ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]domain.Submission, error) {
+	submissions, err := a.repo.ListSubmissionsByAgentID(ctx, agentID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainSubmissions(submissions), nil
+}
+
+// ListSubmissionsByAgentIDAndStatus returns the submissions to an agent with a
+// status in the order they were received.
+func (a *Adapter) ListSubmissionsByAgentIDAndStatus(ctx context.Context, args domain.ListSubmissionsByAgentIDAndStatusParams) ([]domain.Submission, error) {
+	submissions, err := a.repo.ListSubmissionsByAgentIDAndStatus(ctx, sqlc.ListSubmissionsByAgentIDAndStatusParams{
+		AgentID: args.AgentID,
+		Status:  string(args.Status),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainSubmissions(submissions), nil
+}
+
+// ListSubmissionTransitions returns the transitions of a submission, oldest
+// first.
+func (a *Adapter) ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]domain.SubmissionTransition, error) {
+	transitions, err := a.repo.ListSubmissionTransitions(ctx, submissionID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.SubmissionTransition, 0, len(transitions))
+	for _, t := range transitions {
+		res = append(res, toDomainSubmissionTransition(t))
+	}
+	return res, nil
+}
+
+// TransitionSubmission moves a submission to a status, recording the
+// transition with the note.
+func (a *Adapter) TransitionSubmission(ctx context.Context, id int64, status domain.SubmissionStatus, note *string) (*domain.Submission, error) {
+	submission, err := a.repo.TransitionSubmission(ctx, id, string(status), stringPtrToNullString(note))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainSubmission(*submission)
+	return &res, nil
+}
+
+// ConvertSubmission turns an offered submission into a book by a new author.
+func (a *Adapter) ConvertSubmission(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+	book, err := a.repo.ConvertSubmission(ctx, id, cover)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := toDomainBook(*book)
+	return &res, nil
+}
+
+// bulk methods
+
+// CreateAgents creates agents in bulk.
+func (a *Adapter) CreateAgents(ctx context.Context, args []domain.CreateAgentParams, mode domain.BulkMode) (*domain.BulkAgentsResult, error) {
+	params := make([]sqlc.CreateAgentParams, 0, len(args))
+	for _, arg := range args {
+		params = append(params, sqlc.CreateAgentParams{
+			Name:     arg.Name,
+			Email:    arg.Email,
+			AgencyID: int64PtrToNullInt64(arg.AgencyID),
+		})
+	}
+	res, err := a.repo.CreateAgents(ctx, params, BulkMode(mode))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	agents := make([]*domain.Agent, 0, len(res.Agents))
+	for _, agent := range res.Agents {
+		var d *domain.Agent
+		if agent != nil {
+			v := toDomainAgent(*agent)
+			d = &v
+		}
+		agents = append(agents, d)
+	}
+	return &domain.BulkAgentsResult{
+		Committed: res.Committed,
+		Agents:    agents,
+		Errors:    toDomainErrors(res.Errors),
+	}, nil
+}
+
+// CreateAuthors creates authors in bulk.
+func (a *Adapter) CreateAuthors(ctx context.Context, args []domain.CreateAuthorParams, mode domain.BulkMode) (*domain.BulkAuthorsResult, error) {
+	params := make([]CreateAuthorParams, 0, len(args))
+	for _, arg := range args {
+		params = append(params, CreateAuthorParams{
+			CreateAuthorParams: sqlc.CreateAuthorParams{
+				Name:    arg.Name,
+				Website: stringPtrToNullString(arg.Website),
+			},
+			AgentID: arg.AgentID,
+		})
+	}
+	res, err := a.repo.CreateAuthors(ctx, params, BulkMode(mode))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	authors := make([]*domain.Author, 0, len(res.Authors))
+	for _, author := range res.Authors {
+		var d *domain.Author
+		if author != nil {
+			v := toDomainAuthor(*author)
+			d = &v
+		}
+		authors = append(authors, d)
+	}
+	return &domain.BulkAuthorsResult{
+		Committed: res.Committed,
+		Authors:   authors,
+		Errors:    toDomainErrors(res.Errors),
+	}, nil
+}
+
+// CreateBooks creates books in bulk.
+func (a *Adapter) CreateBooks(ctx context.Context, args []domain.BulkCreateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+	params := make([]BulkCreateBookArgs, 0, len(args))
+	for _, arg := range args {
+		params = append(params, BulkCreateBookArgs{
+			Book:         toCreateBookParams(arg.Book),
+			Contributors: toContributors(arg.Contributors),
+			GenreIDs:     arg.GenreIDs,
+			Tags:         arg.Tags,
+		})
+	}
+	res, err := a.repo.CreateBooks(ctx, params, BulkMode(mode))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBulkBooksResult(res), nil
+}
+
+// UpdateBooks updates books in bulk.
+func (a *Adapter) UpdateBooks(ctx context.Context, args []domain.BulkUpdateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+	params := make([]BulkUpdateBookArgs, 0, len(args))
+	for _, arg := range args {
+		params = append(params, BulkUpdateBookArgs{
+			Book:         toUpdateBookParams(arg.Book),
+			Contributors: toContributors(arg.Contributors),
+			GenreIDs:     arg.GenreIDs,
+			Tags:         arg.Tags,
+		})
+	}
+	res, err := a.repo.UpdateBooks(ctx, params, BulkMode(mode))
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainBulkBooksResult(res), nil
+}
+
+// webhooks
+
+// CreateWebhook creates a webhook.
+func (a *Adapter) CreateWebhook(ctx context.Context, args domain.CreateWebhookParams) (domain.Webhook, error) {
+	webhook, err := a.repo.CreateWebhook(ctx, sqlc.CreateWebhookParams{
+		Url:        args.URL,
+		Secret:     args.Secret,
+		EventTypes: args.EventTypes,
+	})
+	if err != nil {
+		return domain.Webhook{}, toDomainError(err)
+	}
+	return toDomainWebhook(webhook), nil
+}
+
+// GetWebhook returns a webhook.
+func (a *Adapter) GetWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	webhook, err := a.repo.GetWebhook(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, toDomainError(err)
+	}
+	return toDomainWebhook(webhook), nil
+}
+
+// ListWebhooks returns all webhooks.
+func (a *Adapter) ListWebhooks(ctx context.Context) ([]domain.Webhook, error) {
+	webhooks, err := a.repo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		res = append(res, toDomainWebhook(webhook))
+	}
+	return res, nil
+}
+
+// UpdateWebhook updates a webhook.
+func (a *Adapter) UpdateWebhook(ctx context.Context, args domain.UpdateWebhookParams) (domain.Webhook, error) {
+	webhook, err := a.repo.UpdateWebhook(ctx, sqlc.UpdateWebhookParams{
+		ID:         args.ID,
+		Url:        args.URL,
+		Secret:     args.Secret,
+		EventTypes: args.EventTypes,
+	})
+	if err != nil {
+		return domain.Webhook{}, toDomainError(err)
+	}
+	return toDomainWebhook(webhook), nil
+}
+
+// DeleteWebhook deletes a webhook along with its deliveries.
+func (a *Adapter) DeleteWebhook(ctx context.Context, id int64) (domain.Webhook, error) {
+	webhook, err := a.repo.DeleteWebhook(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, toDomainError(err)
+	}
+	return toDomainWebhook(webhook), nil
+}
+
+// ListWebhookDeliveries returns the deliveries of a webhook.
+func (a *Adapter) ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]domain.WebhookDelivery, error) {
+	deliveries, err := a.repo.ListWebhookDeliveries(ctx, webhookID)
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainWebhookDeliveries(deliveries), nil
+}
+
+// ListWebhookDeliveriesByStatus returns the deliveries of a webhook with a
+// status.
+func (a *Adapter) ListWebhookDeliveriesByStatus(ctx context.Context, args domain.ListWebhookDeliveriesByStatusParams) ([]domain.WebhookDelivery, error) {
+	deliveries, err := a.repo.ListWebhookDeliveriesByStatus(ctx, sqlc.ListWebhookDeliveriesByStatusParams{
+		WebhookID: args.WebhookID,
+		Status:    args.Status,
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	return toDomainWebhookDeliveries(deliveries), nil
+}
+
+// RetryWebhookDelivery makes a delivery pending again.
+func (a *Adapter) RetryWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	delivery, err := a.repo.RetryWebhookDelivery(ctx, id)
+	if err != nil {
+		return domain.WebhookDelivery{}, toDomainError(err)
+	}
+	return toDomainWebhookDelivery(delivery), nil
+}
+
+// webhook deliveries
+
+// ClaimWebhookDeliveries leases due deliveries to the caller.
+func (a *Adapter) ClaimWebhookDeliveries(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+	rows, err := a.repo.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+		LeaseUntil: args.LeaseUntil,
+		BatchSize:  int32(args.BatchSize),
+	})
+	if err != nil {
+		return nil, toDomainError(err)
+	}
+	res := make([]domain.ClaimedWebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		res = append(res, domain.ClaimedWebhookDelivery{
+			WebhookDelivery: toDomainWebhookDelivery(sqlc.WebhookDelivery{
+				ID:             row.ID,
+				WebhookID:      row.WebhookID,
+				EventType:      row.EventType,
+				Payload:        row.Payload,
+				Status:         row.Status,
+				Attempts:       row.Attempts,
+				ResponseStatus: row.ResponseStatus,
+				LastError:      row.LastError,
+				CreatedAt:      row.CreatedAt,
+				NextAttemptAt:  row.NextAttemptAt,
+				DeliveredAt:    row.DeliveredAt,
+			}),
+			URL:    row.Url,
+			Secret: row.Secret,
+		})
+	}
+	return res, nil
+}
+
+// CompleteWebhookDelivery records a successful delivery attempt.
+func (a *Adapter) CompleteWebhookDelivery(ctx context.Context, args domain.CompleteWebhookDeliveryParams) error {
+	err := a.repo.CompleteWebhookDelivery(ctx, sqlc.CompleteWebhookDeliveryParams{
+		ID:             args.ID,
+		ResponseStatus: sql.NullInt32{Int32: int32(args.ResponseStatus), Valid: true},
+	})
+	return toDomainError(err)
+}
+
+// FailWebhookDelivery records a failed delivery attempt.
+func (a *Adapter) FailWebhookDelivery(ctx context.Context, args domain.FailWebhookDeliveryParams) error {
+	err := a.repo.FailWebhookDelivery(ctx, sqlc.FailWebhookDeliveryParams{
+		ID:             args.ID,
+		Status:         args.Status,
+		ResponseStatus: intPtrToNullInt32(args.ResponseStatus),
+		LastError:      sql.NullString{String: args.LastError, Valid: true},
+		NextAttemptAt:  args.NextAttemptAt,
+	})
+	return toDomainError(err)
+}
+
+// conversions
+
+func toDomainAgency(a sqlc.Agency) domain.Agency {
+	return domain.Agency{
+		ID:   a.ID,
+		Name: a.Name,
+	}
+}
+
+func toDomainAgent(a sqlc.Agent) domain.Agent {
+	res := domain.Agent{
+		ID:        a.ID,
+		Name:      a.Name,
+		Email:     a.Email,
+		AgencyID:  nullInt64ToPtr(a.AgencyID),
+		UpdatedAt: a.UpdatedAt,
+	}
+	if a.DeletedAt.Valid {
+		t := a.DeletedAt.Time
+		res.DeletedAt = &t
+	}
+	return res
+}
+
+func toDomainAgents(agents []sqlc.Agent) []domain.Agent {
+	res := make([]domain.Agent, 0, len(agents))
+	for _, a := range agents {
+		res = append(res, toDomainAgent(a))
+	}
+	return res
+}
+
+func toDomainAuthor(a Author) domain.Author {
+	return domain.Author{
+		ID:        a.ID,
+		Name:      a.Name,
+		Website:   nullStringToPtr(a.Website),
+		AgentID:   a.AgentID,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+func toDomainAuthors(authors []Author) []domain.Author {
+	res := make([]domain.Author, 0, len(authors))
+	for _, a := range authors {
+		res = append(res, toDomainAuthor(a))
+	}
+	return res
+}
+
+func toDomainBook(b sqlc.Book) domain.Book {
+	return domain.Book{
+		ID:             b.ID,
+		Title:          b.Title,
+		Description:    b.Description,
+		Cover:          b.Cover,
+		PublisherID:    nullInt64ToPtr(b.PublisherID),
+		SeriesID:       nullInt64ToPtr(b.SeriesID),
+		SeriesPosition: nullInt32ToIntPtr(b.SeriesPosition),
+		ISBN:           nullStringToISBNPtr(b.Isbn),
+		PublishedOn:    nullTimeToDatePtr(b.PublishedOn),
+		PageCount:      nullInt32ToIntPtr(b.PageCount),
+		Language:       nullStringToLanguageCodePtr(b.Language),
+		UpdatedAt:      b.UpdatedAt,
+	}
+}
+
+func toDomainBooks(books []sqlc.Book) []domain.Book {
+	res := make([]domain.Book, 0, len(books))
+	for _, b := range books {
+		res = append(res, toDomainBook(b))
+	}
+	return res
+}
+
+func toDomainBulkBooksResult(res *BulkBooksResult) *domain.BulkBooksResult {
+	books := make([]*domain.Book, 0, len(res.Books))
+	for _, book := range res.Books {
+		var d *domain.Book
+		if book != nil {
+			v := toDomainBook(*book)
+			d = &v
+		}
+		books = append(books, d)
+	}
+	return &domain.BulkBooksResult{
+		Committed: res.Committed,
+		Books:     books,
+		Errors:    toDomainErrors(res.Errors),
+	}
+}
+
+func toDomainContributors(contributors []sqlc.BookAuthor) []domain.Contributor {
+	res := make([]domain.Contributor, 0, len(contributors))
+	for _, c := range contributors {
+		res = append(res, domain.Contributor{
+			AuthorID: c.AuthorID,
+			Role:     domain.ContributorRole(c.Role),
+			Position: int(c.Position),
+		})
+	}
+	return res
+}
+
+func toDomainDeal(d sqlc.Deal) domain.Deal {
+	return domain.Deal{
+		ID:             d.ID,
+		BookID:         d.BookID,
+		PublisherID:    d.PublisherID,
+		AgentID:        d.AgentID,
+		Territory:      d.Territory,
+		Language:       domain.LanguageCode(d.Language),
+		Format:         domain.EditionFormat(d.Format),
+		Advance:        domain.Money{Amount: d.AdvanceAmount, Currency: d.AdvanceCurrency},
+		SignedOn:       nullTimeToDatePtr(d.SignedOn),
+		Status:         domain.DealStatus(d.Status),
+		RoyaltyRate:    int(d.RoyaltyRate),
+		CommissionRate: int(d.CommissionRate),
+	}
+}
+
+func toDomainDeals(deals []sqlc.Deal) []domain.Deal {
+	res := make([]domain.Deal, 0, len(deals))
+	for _, d := range deals {
+		res = append(res, toDomainDeal(d))
+	}
+	return res
+}
+
+func toDomainRoyaltyRate(r sqlc.RoyaltyRate) domain.RoyaltyRate {
+	return domain.RoyaltyRate{
+		DealID:    r.DealID,
+		EditionID: r.EditionID,
+		Rate:      int(r.Rate),
+	}
+}
+
+func toDomainSales(s sqlc.Sale) domain.Sales {
+	return domain.Sales{
+		ID:        s.ID,
+		DealID:    s.DealID,
+		EditionID: s.EditionID,
+		Period:    s.Period,
+		Units:     int(s.Units),
+		Revenue:   domain.Money{Amount: s.RevenueAmount, Currency: s.RevenueCurrency},
+	}
+}
+
+func toDomainEdition(e sqlc.Edition) domain.Edition {
+	res := domain.Edition{
+		ID:          e.ID,
+		BookID:      e.BookID,
+		Format:      domain.EditionFormat(e.Format),
+		ISBN:        nullStringToISBNPtr(e.Isbn),
+		PublishedOn: nullTimeToDatePtr(e.PublishedOn),
+	}
+	if e.PriceAmount.Valid && e.PriceCurrency.Valid {
+		res.Price = &domain.Price{
+			Amount:   int(e.PriceAmount.Int64),
+			Currency: e.PriceCurrency.String,
+		}
+	}
+	return res
+}
+
+func toDomainEditions(editions []sqlc.Edition) []domain.Edition {
+	res := make([]domain.Edition, 0, len(editions))
+	for _, e := range editions {
+		res = append(res, toDomainEdition(e))
+	}
+	return res
+}
+
+func toDomainGenre(g sqlc.Genre) domain.Genre {
+	return domain.Genre{
+		ID:       g.ID,
+		Name:     g.Name,
+		ParentID: nullInt64ToPtr(g.ParentID),
+	}
+}
+
+func toDomainGenres(genres []sqlc.Genre) []domain.Genre {
+	res := make([]domain.Genre, 0, len(genres))
+	for _, g := range genres {
+		res = append(res, toDomainGenre(g))
+	}
+	return res
+}
+
+func toDomainPublisher(p sqlc.Publisher) domain.Publisher {
+	return domain.Publisher{
+		ID:   p.ID,
+		Name: p.Name,
+	}
+}
+
+func toDomainRepresentation(r sqlc.Representation) domain.Representation {
+	return domain.Representation{
+		ID:        r.ID,
+		AuthorID:  r.AuthorID,
+		AgentID:   r.AgentID,
+		Territory: r.Territory,
+		StartedAt: domain.DateOf(r.StartedAt),
+		EndedAt:   nullTimeToDatePtr(r.EndedAt),
+		Primary:   r.IsPrimary,
+	}
+}
+
+func toDomainSeries(s sqlc.Series) domain.Series {
+	return domain.Series{
+		ID:    s.ID,
+		Title: s.Title,
+	}
+}
+
+func toDomainSubmission(s sqlc.Submission) domain.Submission {
+	return domain.Submission{
+		ID:            s.ID,
+		AgentID:       s.AgentID,
+		AuthorName:    s.AuthorName,
+		AuthorWebsite: nullStringToPtr(s.AuthorWebsite),
+		Title:         s.Title,
+		Synopsis:      s.Synopsis,
+		Status:        domain.SubmissionStatus(s.Status),
+		ReceivedAt:    s.ReceivedAt,
+		BookID:        nullInt64ToPtr(s.BookID),
+	}
+}
+
+func toDomainSubmissions(submissions []sqlc.Submission) []domain.Submission {
+	res := make([]domain.Submission, 0, len(submissions))
+	for _, s := range submissions {
+		res = append(res, toDomainSubmission(s))
+	}
+	return res
+}
+
+func toDomainSubmissionTransition(t sqlc.SubmissionTransition) domain.SubmissionTransition {
+	return domain.SubmissionTransition{
+		ID:             t.ID,
+		SubmissionID:   t.SubmissionID,
+		From:           domain.SubmissionStatus(t.FromStatus),
+		To:             domain.SubmissionStatus(t.ToStatus),
+		Note:           nullStringToPtr(t.Note),
+		TransitionedAt: t.TransitionedAt,
+	}
+}
+
+func toDomainWebhook(w sqlc.Webhook) domain.Webhook {
+	return domain.Webhook{
+		ID:         w.ID,
+		URL:        w.Url,
+		Secret:     w.Secret,
+		EventTypes: w.EventTypes,
+	}
+}
+
+func toDomainWebhookDelivery(d sqlc.WebhookDelivery) domain.WebhookDelivery {
+	res := domain.WebhookDelivery{
+		ID:            d.ID,
+		WebhookID:     d.WebhookID,
+		EventType:     d.EventType,
+		Payload:       d.Payload,
+		Status:        d.Status,
+		Attempts:      int(d.Attempts),
+		LastError:     nullStringToPtr(d.LastError),
+		CreatedAt:     d.CreatedAt,
+		NextAttemptAt: d.NextAttemptAt,
+	}
+	if d.ResponseStatus.Valid {
+		s := int(d.ResponseStatus.Int32)
+		res.ResponseStatus = &s
+	}
+	if d.DeliveredAt.Valid {
+		t := d.DeliveredAt.Time
+		res.DeliveredAt = &t
+	}
+	return res
+}
+
+func toDomainWebhookDeliveries(deliveries []sqlc.WebhookDelivery) []domain.WebhookDelivery {
+	res := make([]domain.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		res = append(res, toDomainWebhookDelivery(d))
+	}
+	return res
+}
+
+func toContributors(contributors []domain.Contributor) []Contributor {
+	res := make([]Contributor, 0, len(contributors))
+	for _, c := range contributors {
+		res = append(res, Contributor{
+			AuthorID: c.AuthorID,
+			Role:     string(c.Role),
+			Position: int32(c.Position),
+		})
+	}
+	return res
+}
+
+func toCreateBookParams(b domain.CreateBookParams) sqlc.CreateBookParams {
+	return sqlc.CreateBookParams{
+		Title:          b.Title,
+		Description:    b.Description,
+		Cover:          b.Cover,
+		PublisherID:    int64PtrToNullInt64(b.PublisherID),
+		SeriesID:       int64PtrToNullInt64(b.SeriesID),
+		SeriesPosition: intPtrToNullInt32(b.SeriesPosition),
+		Isbn:           isbnPtrToNullString(b.ISBN),
+		PublishedOn:    datePtrToNullTime(b.PublishedOn),
+		PageCount:      intPtrToNullInt32(b.PageCount),
+		Language:       languageCodePtrToNullString(b.Language),
+	}
+}
+
+func toUpdateBookParams(b domain.UpdateBookParams) sqlc.UpdateBookParams {
+	return sqlc.UpdateBookParams{
+		ID:             b.ID,
+		Title:          b.Title,
+		Description:    b.Description,
+		Cover:          b.Cover,
+		PublisherID:    int64PtrToNullInt64(b.PublisherID),
+		SeriesID:       int64PtrToNullInt64(b.SeriesID),
+		SeriesPosition: intPtrToNullInt32(b.SeriesPosition),
+		Isbn:           isbnPtrToNullString(b.ISBN),
+		PublishedOn:    datePtrToNullTime(b.PublishedOn),
+		PageCount:      intPtrToNullInt32(b.PageCount),
+		Language:       languageCodePtrToNullString(b.Language),
+	}
+}
+
+// toDomainError translates the errors with a domain equivalent.
+func toDomainError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sql.ErrNoRows):
+		return domain.ErrNotFound
+	case errors.Is(err, context.DeadlineExceeded), isQueryCanceled(err):
+		return domain.ErrTimeout
+	case isConstraintViolation(err, "books_series_position_key"):
+		return domain.ErrSeriesPositionTaken
+	case isConstraintViolation(err, "books_series_position_check"):
+		return domain.ErrInvalidSeriesPosition
+	case isConstraintViolation(err, "books_isbn_key"), isConstraintViolation(err, "editions_isbn_key"):
+		return domain.ErrISBNTaken
+	case isConstraintViolation(err, "books_page_count_check"):
+		return domain.ErrInvalidPageCount
+	case isConstraintViolation(err, "book_authors_position_check"):
+		return domain.ErrInvalidContributorPosition
+	case isConstraintViolation(err, "book_authors_book_id_author_id_key"):
+		return domain.ErrDuplicateContributor
+	case isConstraintViolation(err, "book_tags_tag_check"):
+		return domain.ErrInvalidTag
+	case isConstraintViolation(err, "book_tags_book_id_tag_key"):
+		return domain.ErrDuplicateTag
+	case isConstraintViolation(err, "representations_period_check"):
+		return domain.ErrInvalidRepresentationPeriod
+	case isConstraintViolation(err, "representations_territory_check"), isConstraintViolation(err, "deals_territory_check"):
+		return domain.ErrInvalidTerritory
+	case isConstraintViolation(err, "deals_signed_on_check"):
+		return domain.ErrInvalidDealSigning
+	case isConstraintViolation(err, "deals_royalty_rate_check"),
+		isConstraintViolation(err, "deals_commission_rate_check"),
+		isConstraintViolation(err, "royalty_rates_rate_check"):
+		return domain.ErrInvalidRate
+	case isConstraintViolation(err, "sales_period_check"):
+		return domain.ErrInvalidPeriod
+	case isConstraintViolation(err, "royalty_rates_edition_check"), isConstraintViolation(err, "sales_edition_check"):
+		return domain.ErrEditionNotInDeal
+	case isConstraintViolation(err, "representations_agent_check"),
+		isConstraintViolation(err, "deals_agent_check"),
+		isConstraintViolation(err, "submissions_agent_check"):
+		return domain.ErrAgentDeleted
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		if kind, ok := constraintKinds[pqErr.Code]; ok {
+			return &domain.ConstraintError{
+				Kind:       kind,
+				Table:      pqErr.Table,
+				Constraint: pqErr.Constraint,
+				Message:    pqErr.Message,
+			}
+		}
+	}
+	return err
+}
+
+// constraintKinds maps the codes of the constraint violations without a more
+// specific domain error to their kind.
+var constraintKinds = map[pq.ErrorCode]domain.ConstraintKind{
+	"23503": domain.ForeignKeyConstraint,
+	"23505": domain.UniqueConstraint,
+	"23514": domain.CheckConstraint,
+}
+
+// isQueryCanceled reports whether PostgreSQL canceled a statement, either
+// because of statement_timeout or because its context expired.
+func isQueryCanceled(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "57014"
+}
+
+// isConstraintViolation reports whether err violates the named constraint.
+func isConstraintViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Constraint == constraint
+}
+
+func toDomainErrors(errs []error) []error {
+	res := make([]error, 0, len(errs))
+	for _, err := range errs {
+		res = append(res, toDomainError(err))
+	}
+	return res
+}
+
+func stringPtrToNullString(s *string) sql.NullString {
+	if s != nil {
+		return sql.NullString{String: *s, Valid: true}
+	}
+	return sql.NullString{}
+}
+
+func int64PtrToNullInt64(i *int64) sql.NullInt64 {
+	if i != nil {
+		return sql.NullInt64{Int64: *i, Valid: true}
+	}
+	return sql.NullInt64{}
+}
+
+func intPtrToNullInt32(i *int) sql.NullInt32 {
+	if i != nil {
+		return sql.NullInt32{Int32: int32(*i), Valid: true}
+	}
+	return sql.NullInt32{}
+}
+
+func isbnPtrToNullString(i *domain.ISBN) sql.NullString {
+	if i != nil {
+		return sql.NullString{String: string(*i), Valid: true}
+	}
+	return sql.NullString{}
+}
+
+func datePtrToNullTime(d *domain.Date) sql.NullTime {
+	if d != nil {
+		return sql.NullTime{Time: d.Time(), Valid: true}
+	}
+	return sql.NullTime{}
+}
+
+func languageCodePtrToNullString(l *domain.LanguageCode) sql.NullString {
+	if l != nil {
+		return sql.NullString{String: string(*l), Valid: true}
+	}
+	return sql.NullString{}
+}
+
+func nullStringToISBNPtr(ns sql.NullString) *domain.ISBN {
+	if ns.Valid {
+		i := domain.ISBN(ns.String)
+		return &i
+	}
+	return nil
+}
+
+func nullTimeToDatePtr(nt sql.NullTime) *domain.Date {
+	if nt.Valid {
+		d := domain.DateOf(nt.Time)
+		return &d
+	}
+	return nil
+}
+
+func nullStringToLanguageCodePtr(ns sql.NullString) *domain.LanguageCode {
+	if ns.Valid {
+		l := domain.LanguageCode(ns.String)
+		return &l
+	}
+	return nil
+}
+
+func priceAmountToNullInt64(p *domain.Price) sql.NullInt64 {
+	if p != nil {
+		return sql.NullInt64{Int64: int64(p.Amount), Valid: true}
+	}
+	return sql.NullInt64{}
+}
+
+func priceCurrencyToNullString(p *domain.Price) sql.NullString {
+	if p != nil {
+		return sql.NullString{String: p.Currency, Valid: true}
+	}
+	return sql.NullString{}
+}
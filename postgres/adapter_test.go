@@ -0,0 +1,517 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain"
+	"github.com/fwojciec/litag-example/generated/mocks"
+	"github.com/fwojciec/litag-example/generated/sqlc"
+	"github.com/fwojciec/litag-example/postgres"
+	"github.com/lib/pq"
+)
+
+func TestAdapter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Conversions", func(t *testing.T) {
+		t.Parallel()
+		website := "https://example.com"
+		deliveredAt := time.Unix(1577836800, 0)
+		publishedOn := time.Date(2020, time.March, 5, 0, 0, 0, 0, time.UTC)
+		var receivedAuthorParams postgres.CreateAuthorParams
+		var receivedBookParams sqlc.CreateBookParams
+		var receivedContributors []postgres.Contributor
+		a := postgres.NewAdapter(&postgres.Repo{
+			Querent: &mocks.QuerentMock{
+				GetAuthorFunc: func(ctx context.Context, id int64) (sqlc.GetAuthorRow, error) {
+					return sqlc.GetAuthorRow{ID: id, Website: sql.NullString{String: website, Valid: true}}, nil
+				},
+				GetBookFunc: func(ctx context.Context, id int64) (sqlc.Book, error) {
+					return sqlc.Book{
+						ID:          id,
+						PublisherID: sql.NullInt64{Int64: 5, Valid: true},
+						Isbn:        sql.NullString{String: "9780306406157", Valid: true},
+						PublishedOn: sql.NullTime{Time: publishedOn, Valid: true},
+					}, nil
+				},
+				RetryWebhookDeliveryFunc: func(ctx context.Context, id int64) (sqlc.WebhookDelivery, error) {
+					return sqlc.WebhookDelivery{
+						ID:             id,
+						Attempts:       3,
+						ResponseStatus: sql.NullInt32{Int32: 500, Valid: true},
+						DeliveredAt:    sql.NullTime{Time: deliveredAt, Valid: true},
+					}, nil
+				},
+			},
+			TxQuerent: &mocks.TxQuerentMock{
+				CreateAuthorFunc: func(ctx context.Context, args postgres.CreateAuthorParams) (*postgres.Author, error) {
+					receivedAuthorParams = args
+					return &postgres.Author{}, nil
+				},
+				CreateBookFunc: func(ctx context.Context, args sqlc.CreateBookParams, contributors []postgres.Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
+					receivedBookParams = args
+					receivedContributors = contributors
+					return &sqlc.Book{}, nil
+				},
+			},
+		})
+		ctx := context.Background()
+
+		author, err := a.GetAuthor(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if author.Website == nil || *author.Website != website {
+			t.Errorf("expected website %q, received %v", website, author.Website)
+		}
+		if _, err := a.CreateAuthor(ctx, domain.CreateAuthorParams{Name: "a"}); err != nil {
+			t.Fatal(err)
+		}
+		if receivedAuthorParams.Website.Valid {
+			t.Errorf("expected a NULL website, received %v", receivedAuthorParams.Website)
+		}
+
+		book, err := a.GetBook(ctx, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if book.PublisherID == nil || *book.PublisherID != 5 {
+			t.Errorf("expected publisher 5, received %v", book.PublisherID)
+		}
+		if book.ISBN == nil || *book.ISBN != "9780306406157" {
+			t.Errorf("expected isbn 9780306406157, received %v", book.ISBN)
+		}
+		if book.PublishedOn == nil || book.PublishedOn.String() != "2020-03-05" {
+			t.Errorf("expected publication date 2020-03-05, received %v", book.PublishedOn)
+		}
+		if book.PageCount != nil || book.Language != nil {
+			t.Errorf("expected no page count and language, received %v and %v", book.PageCount, book.Language)
+		}
+		if _, err := a.CreateBook(ctx, domain.CreateBookParams{
+			Title:       "b",
+			PublishedOn: &domain.Date{Year: 2020, Month: time.March, Day: 5},
+		}, []domain.Contributor{{AuthorID: 1, Role: domain.ContributorIllustrator, Position: 2}}, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		expContributors := []postgres.Contributor{{AuthorID: 1, Role: "ILLUSTRATOR", Position: 2}}
+		if !reflect.DeepEqual(receivedContributors, expContributors) {
+			t.Errorf("expected contributors %v, received %v", expContributors, receivedContributors)
+		}
+		if receivedBookParams.PublisherID.Valid {
+			t.Errorf("expected a NULL publisher, received %v", receivedBookParams.PublisherID)
+		}
+		if !receivedBookParams.PublishedOn.Valid || !receivedBookParams.PublishedOn.Time.Equal(publishedOn) {
+			t.Errorf("expected publication date %v, received %v", publishedOn, receivedBookParams.PublishedOn)
+		}
+
+		delivery, err := a.RetryWebhookDelivery(ctx, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := domain.WebhookDelivery{
+			ID:             2,
+			Attempts:       3,
+			ResponseStatus: &[]int{500}[0],
+			DeliveredAt:    &deliveredAt,
+		}
+		if !reflect.DeepEqual(delivery, exp) {
+			t.Errorf("expected %#v, received %#v", exp, delivery)
+		}
+	})
+
+	t.Run("Edition conversions", func(t *testing.T) {
+		t.Parallel()
+		var receivedListParams sqlc.ListEditionsParams
+		var receivedCreateParams sqlc.CreateEditionParams
+		a := postgres.NewAdapter(&postgres.Repo{
+			Querent: &mocks.QuerentMock{
+				GetEditionFunc: func(ctx context.Context, id int64) (sqlc.Edition, error) {
+					return sqlc.Edition{
+						ID:            id,
+						BookID:        3,
+						Format:        "EBOOK",
+						PriceAmount:   sql.NullInt64{Int64: 999, Valid: true},
+						PriceCurrency: sql.NullString{String: "EUR", Valid: true},
+					}, nil
+				},
+				ListEditionsFunc: func(ctx context.Context, args sqlc.ListEditionsParams) ([]sqlc.Edition, error) {
+					receivedListParams = args
+					return nil, nil
+				},
+				CreateEditionFunc: func(ctx context.Context, args sqlc.CreateEditionParams) (sqlc.Edition, error) {
+					receivedCreateParams = args
+					return sqlc.Edition{}, nil
+				},
+			},
+		})
+		ctx := context.Background()
+
+		edition, err := a.GetEdition(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := domain.Edition{
+			ID:     1,
+			BookID: 3,
+			Format: domain.EditionEbook,
+			Price:  &domain.Price{Amount: 999, Currency: "EUR"},
+		}
+		if !reflect.DeepEqual(edition, exp) {
+			t.Errorf("expected %#v, received %#v", exp, edition)
+		}
+
+		format := domain.EditionHardcover
+		if _, err := a.ListEditions(ctx, domain.ListEditionsParams{
+			Format:      &format,
+			PublishedTo: &domain.Date{Year: 2020, Month: time.March, Day: 5},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		expParams := sqlc.ListEditionsParams{Format: "HARDCOVER", PublishedTo: "2020-03-05"}
+		if receivedListParams != expParams {
+			t.Errorf("expected %#v, received %#v", expParams, receivedListParams)
+		}
+
+		if _, err := a.CreateEdition(ctx, domain.CreateEditionParams{BookID: 3, Format: domain.EditionAudiobook}); err != nil {
+			t.Fatal(err)
+		}
+		if receivedCreateParams.PriceAmount.Valid || receivedCreateParams.PriceCurrency.Valid {
+			t.Errorf("expected a NULL price, received %v %v", receivedCreateParams.PriceAmount, receivedCreateParams.PriceCurrency)
+		}
+	})
+
+	t.Run("Deal conversions", func(t *testing.T) {
+		t.Parallel()
+		signedOn := time.Date(2020, time.March, 5, 0, 0, 0, 0, time.UTC)
+		var receivedRightsParams sqlc.ListAvailableRightsParams
+		a := postgres.NewAdapter(&postgres.Repo{
+			Querent: &mocks.QuerentMock{
+				GetDealFunc: func(ctx context.Context, id int64) (sqlc.Deal, error) {
+					return sqlc.Deal{
+						ID:              id,
+						BookID:          3,
+						PublisherID:     4,
+						AgentID:         5,
+						Territory:       "FR",
+						Language:        "fr",
+						Format:          "PAPERBACK",
+						AdvanceAmount:   1250000,
+						AdvanceCurrency: "EUR",
+						SignedOn:        sql.NullTime{Time: signedOn, Valid: true},
+						Status:          "SIGNED",
+						RoyaltyRate:     1000,
+						CommissionRate:  1500,
+					}, nil
+				},
+				ListAvailableRightsFunc: func(ctx context.Context, args sqlc.ListAvailableRightsParams) ([]sqlc.ListAvailableRightsRow, error) {
+					receivedRightsParams = args
+					return []sqlc.ListAvailableRightsRow{
+						{BookID: 2, Format: "HARDCOVER"},
+						{BookID: 2, Format: "EBOOK"},
+						{BookID: 1, Format: "AUDIOBOOK"},
+					}, nil
+				},
+			},
+		})
+		ctx := context.Background()
+
+		deal, err := a.GetDeal(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := domain.Deal{
+			ID:             1,
+			BookID:         3,
+			PublisherID:    4,
+			AgentID:        5,
+			Territory:      "FR",
+			Language:       "fr",
+			Format:         domain.EditionPaperback,
+			Advance:        domain.Money{Amount: 1250000, Currency: "EUR"},
+			SignedOn:       &domain.Date{Year: 2020, Month: time.March, Day: 5},
+			Status:         domain.DealSigned,
+			RoyaltyRate:    1000,
+			CommissionRate: 1500,
+		}
+		if !reflect.DeepEqual(deal, exp) {
+			t.Errorf("expected %#v, received %#v", exp, deal)
+		}
+
+		rights, err := a.ListAvailableRights(ctx, "DE", "de")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expParams := sqlc.ListAvailableRightsParams{Territory: "DE", Language: "de"}
+		if receivedRightsParams != expParams {
+			t.Errorf("expected %#v, received %#v", expParams, receivedRightsParams)
+		}
+		expRights := []domain.AvailableRights{
+			{BookID: 2, Formats: []domain.EditionFormat{domain.EditionHardcover, domain.EditionEbook}},
+			{BookID: 1, Formats: []domain.EditionFormat{domain.EditionAudiobook}},
+		}
+		if !reflect.DeepEqual(rights, expRights) {
+			t.Errorf("expected %v, received %v", expRights, rights)
+		}
+	})
+
+	t.Run("Royalty statement", func(t *testing.T) {
+		t.Parallel()
+		var receivedParams sqlc.ListRoyaltySalesParams
+		a := postgres.NewAdapter(&postgres.Repo{
+			Querent: &mocks.QuerentMock{
+				ListRoyaltySalesFunc: func(ctx context.Context, args sqlc.ListRoyaltySalesParams) ([]sqlc.ListRoyaltySalesRow, error) {
+					receivedParams = args
+					return []sqlc.ListRoyaltySalesRow{{
+						ID:              1,
+						DealID:          2,
+						EditionID:       3,
+						Period:          "2024-Q1",
+						Units:           100,
+						RevenueAmount:   200000,
+						RevenueCurrency: "USD",
+						BookID:          4,
+						AgentID:         5,
+						RoyaltyRate:     1000,
+						CommissionRate:  1500,
+						Authors:         2,
+					}}, nil
+				},
+			},
+		})
+		statement, err := a.GetRoyaltyStatement(context.Background(), 9, "2024-Q1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expParams := sqlc.ListRoyaltySalesParams{Period: "2024-Q1", AuthorID: 9}
+		if receivedParams != expParams {
+			t.Errorf("expected %#v, received %#v", expParams, receivedParams)
+		}
+		exp := domain.NewRoyaltyStatement(9, "2024-Q1", []domain.RoyaltySales{{
+			Sales: domain.Sales{
+				ID:        1,
+				DealID:    2,
+				EditionID: 3,
+				Period:    "2024-Q1",
+				Units:     100,
+				Revenue:   domain.Money{Amount: 200000, Currency: "USD"},
+			},
+			BookID:         4,
+			AgentID:        5,
+			RoyaltyRate:    1000,
+			CommissionRate: 1500,
+			Authors:        2,
+		}})
+		if !reflect.DeepEqual(statement, exp) {
+			t.Errorf("expected %+v, received %+v", exp, statement)
+		}
+	})
+
+	t.Run("Transition submission", func(t *testing.T) {
+		t.Parallel()
+		var receivedStatus string
+		var receivedNote sql.NullString
+		receivedAt := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+		a := postgres.NewAdapter(&postgres.Repo{
+			TxQuerent: &mocks.TxQuerentMock{
+				TransitionSubmissionFunc: func(ctx context.Context, id int64, status string, note sql.NullString) (*sqlc.Submission, error) {
+					receivedStatus, receivedNote = status, note
+					return &sqlc.Submission{
+						ID:         id,
+						AgentID:    2,
+						AuthorName: "author",
+						Title:      "title",
+						Synopsis:   "synopsis",
+						Status:     status,
+						ReceivedAt: receivedAt,
+					}, nil
+				},
+			},
+		})
+		note := "liked the first chapters"
+		submission, err := a.TransitionSubmission(context.Background(), 1, domain.SubmissionRequestedFull, &note)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if receivedStatus != postgres.SubmissionRequestedFull || receivedNote != (sql.NullString{String: note, Valid: true}) {
+			t.Errorf("wrong args: received %s, %v", receivedStatus, receivedNote)
+		}
+		exp := &domain.Submission{
+			ID:         1,
+			AgentID:    2,
+			AuthorName: "author",
+			Title:      "title",
+			Synopsis:   "synopsis",
+			Status:     domain.SubmissionRequestedFull,
+			ReceivedAt: receivedAt,
+		}
+		if !reflect.DeepEqual(submission, exp) {
+			t.Errorf("expected %+v, received %+v", exp, submission)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		testError := errors.New("test error")
+		tests := []struct {
+			name  string
+			err   error
+			check func(error) bool
+		}{
+			{"not found", sql.ErrNoRows, func(err error) bool {
+				return errors.Is(err, domain.ErrNotFound)
+			}},
+			{"agent has authors", &postgres.AgentHasAuthorsError{AgentID: 1, Authors: []domain.Author{{ID: 2}}}, func(err error) bool {
+				var e *domain.AgentHasAuthorsError
+				return errors.As(err, &e) && e.AgentID == 1 && len(e.Authors) == 1 && e.Authors[0].ID == 2
+			}},
+			{"books would be orphaned", &postgres.BooksWouldBeOrphanedError{AuthorID: 1, Books: []domain.Book{{ID: 3}}}, func(err error) bool {
+				var e *domain.BooksWouldBeOrphanedError
+				return errors.As(err, &e) && e.AuthorID == 1 && len(e.Books) == 1 && e.Books[0].ID == 3
+			}},
+			{"genre cycle", postgres.ErrGenreCycle, func(err error) bool {
+				return errors.Is(err, domain.ErrGenreCycle)
+			}},
+			{"series books mismatch", postgres.ErrSeriesBooksMismatch, func(err error) bool {
+				return errors.Is(err, domain.ErrSeriesBooksMismatch)
+			}},
+			{"series position taken", &pq.Error{Code: "23505", Constraint: "books_series_position_key"}, func(err error) bool {
+				return errors.Is(err, domain.ErrSeriesPositionTaken)
+			}},
+			{"invalid series position", &pq.Error{Code: "23514", Constraint: "books_series_position_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidSeriesPosition)
+			}},
+			{"isbn taken", &pq.Error{Code: "23505", Constraint: "books_isbn_key"}, func(err error) bool {
+				return errors.Is(err, domain.ErrISBNTaken)
+			}},
+			{"edition isbn taken", &pq.Error{Code: "23505", Constraint: "editions_isbn_key"}, func(err error) bool {
+				return errors.Is(err, domain.ErrISBNTaken)
+			}},
+			{"invalid page count", &pq.Error{Code: "23514", Constraint: "books_page_count_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidPageCount)
+			}},
+			{"invalid contributor position", &pq.Error{Code: "23514", Constraint: "book_authors_position_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidContributorPosition)
+			}},
+			{"duplicate contributor", &pq.Error{Code: "23505", Constraint: "book_authors_book_id_author_id_key"}, func(err error) bool {
+				return errors.Is(err, domain.ErrDuplicateContributor)
+			}},
+			{"invalid tag", &pq.Error{Code: "23514", Constraint: "book_tags_tag_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidTag)
+			}},
+			{"duplicate tag", &pq.Error{Code: "23505", Constraint: "book_tags_book_id_tag_key"}, func(err error) bool {
+				return errors.Is(err, domain.ErrDuplicateTag)
+			}},
+			{"primary representation", postgres.ErrPrimaryRepresentation, func(err error) bool {
+				return errors.Is(err, domain.ErrPrimaryRepresentation)
+			}},
+			{"submission transition", &postgres.SubmissionTransitionError{SubmissionID: 1, From: "DECLINED", To: "READING"}, func(err error) bool {
+				var e *domain.SubmissionTransitionError
+				return errors.As(err, &e) && e.SubmissionID == 1 && e.From == domain.SubmissionDeclined && e.To == domain.SubmissionReading
+			}},
+			{"submission not offered", postgres.ErrSubmissionNotOffered, func(err error) bool {
+				return errors.Is(err, domain.ErrSubmissionNotOffered)
+			}},
+			{"invalid representation period", &pq.Error{Code: "23514", Constraint: "representations_period_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidRepresentationPeriod)
+			}},
+			{"invalid territory", &pq.Error{Code: "23514", Constraint: "representations_territory_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidTerritory)
+			}},
+			{"invalid deal territory", &pq.Error{Code: "23514", Constraint: "deals_territory_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidTerritory)
+			}},
+			{"invalid deal signing", &pq.Error{Code: "23514", Constraint: "deals_signed_on_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidDealSigning)
+			}},
+			{"invalid royalty rate", &pq.Error{Code: "23514", Constraint: "royalty_rates_rate_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidRate)
+			}},
+			{"invalid commission rate", &pq.Error{Code: "23514", Constraint: "deals_commission_rate_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidRate)
+			}},
+			{"invalid period", &pq.Error{Code: "23514", Constraint: "sales_period_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidPeriod)
+			}},
+			{"deleted agent", &pq.Error{Code: "23514", Constraint: "representations_agent_check"}, func(err error) bool {
+				return errors.Is(err, domain.ErrAgentDeleted)
+			}},
+			{"referenced agent", &pq.Error{Code: "23503", Table: "deals", Constraint: "deals_agent_id_fkey"}, func(err error) bool {
+				var e *domain.ConstraintError
+				return errors.As(err, &e) && e.Kind == domain.ForeignKeyConstraint && e.Table == "deals" && e.Constraint == "deals_agent_id_fkey"
+			}},
+			{"duplicate genre", &pq.Error{Code: "23505", Constraint: "book_genres_book_id_genre_id_key"}, func(err error) bool {
+				var e *domain.ConstraintError
+				return errors.As(err, &e) && e.Kind == domain.UniqueConstraint
+			}},
+			{"book without authors", &pq.Error{Code: "23514", Message: "book 1 must have at least one author"}, func(err error) bool {
+				var e *domain.ConstraintError
+				return errors.As(err, &e) && e.Kind == domain.CheckConstraint && e.Error() == "book 1 must have at least one author"
+			}},
+			{"deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), func(err error) bool {
+				return errors.Is(err, domain.ErrTimeout)
+			}},
+			{"statement timeout", &pq.Error{Code: "57014"}, func(err error) bool {
+				return errors.Is(err, domain.ErrTimeout)
+			}},
+			{"other", testError, func(err error) bool {
+				return errors.Is(err, testError)
+			}},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				a := postgres.NewAdapter(&postgres.Repo{
+					TxQuerent: &mocks.TxQuerentMock{
+						DeleteAgentFunc: func(ctx context.Context, id int64, reassignAuthorsTo *int64) (*sqlc.Agent, error) {
+							return nil, tc.err
+						},
+					},
+				})
+				_, err := a.DeleteAgent(context.Background(), 1, nil)
+				if !tc.check(err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("Bulk errors", func(t *testing.T) {
+		t.Parallel()
+		a := postgres.NewAdapter(&postgres.Repo{
+			TxQuerent: &mocks.TxQuerentMock{
+				CreateBooksFunc: func(ctx context.Context, args []postgres.BulkCreateBookArgs, mode postgres.BulkMode) (*postgres.BulkBooksResult, error) {
+					if mode != postgres.BulkBestEffort {
+						t.Errorf("expected mode %s, received %s", postgres.BulkBestEffort, mode)
+					}
+					return &postgres.BulkBooksResult{
+						Committed: true,
+						Books:     []*sqlc.Book{{ID: 1}, nil},
+						Errors:    []error{nil, postgres.ErrBookWithoutAuthors},
+					}, nil
+				},
+			},
+		})
+		res, err := a.CreateBooks(context.Background(), []domain.BulkCreateBookArgs{
+			{Contributors: []domain.Contributor{{AuthorID: 1, Role: domain.ContributorAuthor, Position: 1}}},
+			{},
+		}, domain.BulkBestEffort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := &domain.BulkBooksResult{
+			Committed: true,
+			Books:     []*domain.Book{{ID: 1}, nil},
+			Errors:    []error{nil, domain.ErrBookWithoutAuthors},
+		}
+		if !reflect.DeepEqual(res, exp) {
+			t.Errorf("expected %#v, received %#v", exp, res)
+		}
+	})
+}
@@ -0,0 +1,565 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/fwojciec/litag-example/domain"         // use your own github username
+	"github.com/fwojciec/litag-example/generated/sqlc" // use your own github username
+)
+
+// BulkMode determines how a bulk operation handles items that fail.
+type BulkMode = domain.BulkMode
+
+// Bulk modes.
+const (
+	BulkAllOrNothing = domain.BulkAllOrNothing
+	BulkBestEffort   = domain.BulkBestEffort
+)
+
+// ErrBookWithoutAuthors is reported for bulk book items with no contributors.
+var ErrBookWithoutAuthors = domain.ErrBookWithoutAuthors
+
+// BulkCreateBookArgs represents a single book of a bulk create operation.
+type BulkCreateBookArgs struct {
+	Book         sqlc.CreateBookParams
+	Contributors []Contributor
+	GenreIDs     []int64
+	Tags         []string
+}
+
+// BulkUpdateBookArgs represents a single book of a bulk update operation.
+type BulkUpdateBookArgs struct {
+	Book         sqlc.UpdateBookParams
+	Contributors []Contributor
+	GenreIDs     []int64
+	Tags         []string
+}
+
+// BulkAgentsResult is the outcome of a bulk agent operation. Agents and
+// Errors are indexed like the input; for every item exactly one of them is
+// set, unless the operation was rolled back, in which case no agents are set.
+type BulkAgentsResult struct {
+	Committed bool
+	Agents    []*sqlc.Agent
+	Errors    []error
+}
+
+// BulkAuthorsResult is the outcome of a bulk author operation, see
+// BulkAgentsResult.
+type BulkAuthorsResult struct {
+	Committed bool
+	Authors   []*Author
+	Errors    []error
+}
+
+// BulkBooksResult is the outcome of a bulk book operation, see
+// BulkAgentsResult.
+type BulkBooksResult struct {
+	Committed bool
+	Books     []*sqlc.Book
+	Errors    []error
+}
+
+func (txq *txQuerentService) CreateAgents(ctx context.Context, args []sqlc.CreateAgentParams, mode BulkMode) (*BulkAgentsResult, error) {
+	res := &BulkAgentsResult{
+		Agents: make([]*sqlc.Agent, len(args)),
+		Errors: make([]error, len(args)),
+	}
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	err = runBulk(ctx, tx, res.Errors, func(idx []int) error {
+		params := sqlc.CreateAgentsParams{
+			Names:     make([]string, 0, len(idx)),
+			Emails:    make([]string, 0, len(idx)),
+			AgencyIds: make([]int64, 0, len(idx)),
+		}
+		for _, i := range idx {
+			params.Names = append(params.Names, args[i].Name)
+			params.Emails = append(params.Emails, args[i].Email)
+			params.AgencyIds = append(params.AgencyIds, nullInt64OrZero(args[i].AgencyID))
+		}
+		agents, err := q.CreateAgents(ctx, params)
+		if err != nil {
+			return err
+		}
+		if len(agents) != len(idx) {
+			return errUnexpectedRowCount
+		}
+		// ids are assigned in input order, RETURNING order is not guaranteed
+		sort.Slice(agents, func(i, j int) bool { return agents[i].ID < agents[j].ID })
+		for k, i := range idx {
+			res.Agents[i] = &agents[k]
+		}
+		return nil
+	}, func(i int) error {
+		agent, err := q.CreateAgent(ctx, args[i])
+		if err != nil {
+			return err
+		}
+		res.Agents[i] = &agent
+		return nil
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	res.Committed, err = finishBulk(tx, mode, res.Errors)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Committed {
+		res.Agents = make([]*sqlc.Agent, len(args))
+	}
+	return res, nil
+}
+
+func (txq *txQuerentService) CreateAuthors(ctx context.Context, args []CreateAuthorParams, mode BulkMode) (*BulkAuthorsResult, error) {
+	res := &BulkAuthorsResult{
+		Authors: make([]*Author, len(args)),
+		Errors:  make([]error, len(args)),
+	}
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	err = runBulk(ctx, tx, res.Errors, func(idx []int) error {
+		params := sqlc.CreateAuthorsParams{
+			Names:    make([]string, 0, len(idx)),
+			Websites: make([]string, 0, len(idx)),
+		}
+		for _, i := range idx {
+			params.Names = append(params.Names, args[i].Name)
+			// an empty website is stored as NULL, see normalizeWebsite
+			params.Websites = append(params.Websites, args[i].Website.String)
+		}
+		created, err := q.CreateAuthors(ctx, params)
+		if err != nil {
+			return err
+		}
+		if len(created) != len(idx) {
+			return errUnexpectedRowCount
+		}
+		sort.Slice(created, func(i, j int) bool { return created[i].ID < created[j].ID })
+		reps := sqlc.StartPrimaryRepresentationsParams{
+			AuthorIds: make([]int64, 0, len(idx)),
+			AgentIds:  make([]int64, 0, len(idx)),
+		}
+		for k, i := range idx {
+			reps.AuthorIds = append(reps.AuthorIds, created[k].ID)
+			reps.AgentIds = append(reps.AgentIds, args[i].AgentID)
+		}
+		err = q.StartPrimaryRepresentations(ctx, reps)
+		if err != nil {
+			return err
+		}
+		for k, i := range idx {
+			author := Author{
+				ID:        created[k].ID,
+				Name:      created[k].Name,
+				Website:   created[k].Website,
+				UpdatedAt: created[k].UpdatedAt,
+				AgentID:   args[i].AgentID,
+			}
+			err := enqueueEvent(ctx, q, EventAuthorCreated, newAuthorPayload(author))
+			if err != nil {
+				return err
+			}
+			res.Authors[i] = &author
+		}
+		return nil
+	}, func(i int) error {
+		author, err := createAuthor(ctx, q, args[i])
+		if err != nil {
+			return err
+		}
+		res.Authors[i] = author
+		return nil
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	res.Committed, err = finishBulk(tx, mode, res.Errors)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Committed {
+		res.Authors = make([]*Author, len(args))
+	}
+	return res, nil
+}
+
+func (txq *txQuerentService) CreateBooks(ctx context.Context, args []BulkCreateBookArgs, mode BulkMode) (*BulkBooksResult, error) {
+	res := &BulkBooksResult{
+		Books:  make([]*sqlc.Book, len(args)),
+		Errors: make([]error, len(args)),
+	}
+	for i, arg := range args {
+		if len(arg.Contributors) == 0 {
+			res.Errors[i] = ErrBookWithoutAuthors
+		}
+	}
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	err = runBulk(ctx, tx, res.Errors, func(idx []int) error {
+		params := sqlc.CreateBooksParams{
+			Titles:          make([]string, 0, len(idx)),
+			Descriptions:    make([]string, 0, len(idx)),
+			Covers:          make([]string, 0, len(idx)),
+			PublisherIds:    make([]int64, 0, len(idx)),
+			SeriesIds:       make([]int64, 0, len(idx)),
+			SeriesPositions: make([]int32, 0, len(idx)),
+			Isbns:           make([]string, 0, len(idx)),
+			PublishedOns:    make([]string, 0, len(idx)),
+			PageCounts:      make([]string, 0, len(idx)),
+			Languages:       make([]string, 0, len(idx)),
+		}
+		for _, i := range idx {
+			params.Titles = append(params.Titles, args[i].Book.Title)
+			params.Descriptions = append(params.Descriptions, args[i].Book.Description)
+			params.Covers = append(params.Covers, args[i].Book.Cover)
+			params.PublisherIds = append(params.PublisherIds, nullInt64OrZero(args[i].Book.PublisherID))
+			params.SeriesIds = append(params.SeriesIds, nullInt64OrZero(args[i].Book.SeriesID))
+			params.SeriesPositions = append(params.SeriesPositions, nullInt32OrZero(args[i].Book.SeriesPosition))
+			params.Isbns = append(params.Isbns, nullStringOrEmpty(args[i].Book.Isbn))
+			params.PublishedOns = append(params.PublishedOns, nullDateOrEmpty(args[i].Book.PublishedOn))
+			params.PageCounts = append(params.PageCounts, nullInt32OrEmpty(args[i].Book.PageCount))
+			params.Languages = append(params.Languages, nullStringOrEmpty(args[i].Book.Language))
+		}
+		books, err := q.CreateBooks(ctx, params)
+		if err != nil {
+			return err
+		}
+		if len(books) != len(idx) {
+			return errUnexpectedRowCount
+		}
+		sort.Slice(books, func(i, j int) bool { return books[i].ID < books[j].ID })
+		err = setBooksAuthors(ctx, q, books, idx, func(i int) []Contributor { return args[i].Contributors })
+		if err != nil {
+			return err
+		}
+		err = setBooksGenres(ctx, q, books, idx, func(i int) []int64 { return args[i].GenreIDs })
+		if err != nil {
+			return err
+		}
+		err = setBooksTags(ctx, q, books, idx, func(i int) []string { return args[i].Tags })
+		if err != nil {
+			return err
+		}
+		for k, i := range idx {
+			err := enqueueEvent(ctx, q, EventBookCreated, newBookPayload(books[k], contributorAuthorIDs(args[i].Contributors)))
+			if err != nil {
+				return err
+			}
+		}
+		for k, i := range idx {
+			res.Books[i] = &books[k]
+		}
+		return nil
+	}, func(i int) error {
+		book, err := createBook(ctx, q, args[i].Book, args[i].Contributors, args[i].GenreIDs, args[i].Tags)
+		if err != nil {
+			return err
+		}
+		res.Books[i] = book
+		return nil
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	res.Committed, err = finishBulk(tx, mode, res.Errors)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Committed {
+		res.Books = make([]*sqlc.Book, len(args))
+	}
+	return res, nil
+}
+
+func (txq *txQuerentService) UpdateBooks(ctx context.Context, args []BulkUpdateBookArgs, mode BulkMode) (*BulkBooksResult, error) {
+	res := &BulkBooksResult{
+		Books:  make([]*sqlc.Book, len(args)),
+		Errors: make([]error, len(args)),
+	}
+	for i, arg := range args {
+		if len(arg.Contributors) == 0 {
+			res.Errors[i] = ErrBookWithoutAuthors
+		}
+	}
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	err = runBulk(ctx, tx, res.Errors, func(idx []int) error {
+		params := sqlc.UpdateBooksParams{
+			Ids:             make([]int64, 0, len(idx)),
+			Titles:          make([]string, 0, len(idx)),
+			Descriptions:    make([]string, 0, len(idx)),
+			Covers:          make([]string, 0, len(idx)),
+			PublisherIds:    make([]int64, 0, len(idx)),
+			SeriesIds:       make([]int64, 0, len(idx)),
+			SeriesPositions: make([]int32, 0, len(idx)),
+			Isbns:           make([]string, 0, len(idx)),
+			PublishedOns:    make([]string, 0, len(idx)),
+			PageCounts:      make([]string, 0, len(idx)),
+			Languages:       make([]string, 0, len(idx)),
+		}
+		for _, i := range idx {
+			params.Ids = append(params.Ids, args[i].Book.ID)
+			params.Titles = append(params.Titles, args[i].Book.Title)
+			params.Descriptions = append(params.Descriptions, args[i].Book.Description)
+			params.Covers = append(params.Covers, args[i].Book.Cover)
+			params.PublisherIds = append(params.PublisherIds, nullInt64OrZero(args[i].Book.PublisherID))
+			params.SeriesIds = append(params.SeriesIds, nullInt64OrZero(args[i].Book.SeriesID))
+			params.SeriesPositions = append(params.SeriesPositions, nullInt32OrZero(args[i].Book.SeriesPosition))
+			params.Isbns = append(params.Isbns, nullStringOrEmpty(args[i].Book.Isbn))
+			params.PublishedOns = append(params.PublishedOns, nullDateOrEmpty(args[i].Book.PublishedOn))
+			params.PageCounts = append(params.PageCounts, nullInt32OrEmpty(args[i].Book.PageCount))
+			params.Languages = append(params.Languages, nullStringOrEmpty(args[i].Book.Language))
+		}
+		updated, err := q.UpdateBooks(ctx, params)
+		if err != nil {
+			return err
+		}
+		// a missing or repeated id leaves the counts unequal; the per item
+		// fallback then reports which item is at fault
+		if len(updated) != len(idx) {
+			return errUnexpectedRowCount
+		}
+		byID := make(map[int64]sqlc.Book, len(updated))
+		for _, book := range updated {
+			byID[book.ID] = book
+		}
+		books := make([]sqlc.Book, 0, len(idx))
+		for _, i := range idx {
+			books = append(books, byID[args[i].Book.ID])
+		}
+		err = q.UnsetBooksAuthors(ctx, params.Ids)
+		if err != nil {
+			return err
+		}
+		err = setBooksAuthors(ctx, q, books, idx, func(i int) []Contributor { return args[i].Contributors })
+		if err != nil {
+			return err
+		}
+		err = q.UnsetBooksGenres(ctx, params.Ids)
+		if err != nil {
+			return err
+		}
+		err = setBooksGenres(ctx, q, books, idx, func(i int) []int64 { return args[i].GenreIDs })
+		if err != nil {
+			return err
+		}
+		err = q.UnsetBooksTags(ctx, params.Ids)
+		if err != nil {
+			return err
+		}
+		err = setBooksTags(ctx, q, books, idx, func(i int) []string { return args[i].Tags })
+		if err != nil {
+			return err
+		}
+		for k, i := range idx {
+			err := enqueueEvent(ctx, q, EventBookUpdated, newBookPayload(books[k], contributorAuthorIDs(args[i].Contributors)))
+			if err != nil {
+				return err
+			}
+		}
+		for k, i := range idx {
+			res.Books[i] = &books[k]
+		}
+		return nil
+	}, func(i int) error {
+		book, err := updateBook(ctx, q, args[i].Book, args[i].Contributors, args[i].GenreIDs, args[i].Tags)
+		if err != nil {
+			return err
+		}
+		res.Books[i] = book
+		return nil
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	res.Committed, err = finishBulk(tx, mode, res.Errors)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Committed {
+		res.Books = make([]*sqlc.Book, len(args))
+	}
+	return res, nil
+}
+
+var errUnexpectedRowCount = errors.New("unexpected number of rows affected")
+
+// setBooksAuthors associates books[k] with the contributors of the input
+// item idx[k] using a single statement.
+func setBooksAuthors(ctx context.Context, q *sqlc.Queries, books []sqlc.Book, idx []int, contributors func(i int) []Contributor) error {
+	var params sqlc.SetBooksAuthorsParams
+	for k, i := range idx {
+		for _, c := range contributors(i) {
+			params.BookIds = append(params.BookIds, books[k].ID)
+			params.AuthorIds = append(params.AuthorIds, c.AuthorID)
+			params.Roles = append(params.Roles, c.Role)
+			params.Positions = append(params.Positions, c.Position)
+		}
+	}
+	return q.SetBooksAuthors(ctx, params)
+}
+
+// setBooksGenres associates each of the books with the genres of its item
+// in a single statement.
+func setBooksGenres(ctx context.Context, q *sqlc.Queries, books []sqlc.Book, idx []int, genreIDs func(i int) []int64) error {
+	var params sqlc.SetBooksGenresParams
+	for k, i := range idx {
+		for _, genreID := range genreIDs(i) {
+			params.BookIds = append(params.BookIds, books[k].ID)
+			params.GenreIds = append(params.GenreIds, genreID)
+		}
+	}
+	if len(params.BookIds) == 0 {
+		return nil
+	}
+	return q.SetBooksGenres(ctx, params)
+}
+
+// setBooksTags gives each of the books the tags of its item in a single
+// statement.
+func setBooksTags(ctx context.Context, q *sqlc.Queries, books []sqlc.Book, idx []int, tags func(i int) []string) error {
+	var params sqlc.SetBooksTagsParams
+	for k, i := range idx {
+		for _, tag := range tags(i) {
+			params.BookIds = append(params.BookIds, books[k].ID)
+			params.Tags = append(params.Tags, tag)
+		}
+	}
+	if len(params.BookIds) == 0 {
+		return nil
+	}
+	return q.SetBooksTags(ctx, params)
+}
+
+// runBulk processes the items whose errs entry is nil. It first tries all of
+// them at once using the set-based all function. If that fails, the changes
+// are rolled back and each item is retried on its own using the one function,
+// recording the error of every item that fails in errs. The returned error is
+// only set if the transaction itself can no longer be used.
+func runBulk(ctx context.Context, tx *sql.Tx, errs []error, all func(idx []int) error, one func(i int) error) error {
+	idx := make([]int, 0, len(errs))
+	for i, err := range errs {
+		if err == nil {
+			idx = append(idx, i)
+		}
+	}
+	if len(idx) == 0 {
+		return nil
+	}
+	ok, err := savepoint(ctx, tx, "bulk", func() error { return all(idx) })
+	if err != nil || ok {
+		return err
+	}
+	for _, i := range idx {
+		i := i
+		_, err := savepoint(ctx, tx, "bulk_item", func() error {
+			errs[i] = one(i)
+			return errs[i]
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// savepoint runs fn inside a savepoint and rolls back to it if fn fails. It
+// reports whether fn succeeded; the returned error is only set if the
+// savepoint itself could not be managed.
+func savepoint(ctx context.Context, tx *sql.Tx, name string, fn func() error) (bool, error) {
+	_, err := tx.ExecContext(ctx, "SAVEPOINT "+name)
+	if err != nil {
+		return false, err
+	}
+	if fn() != nil {
+		_, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		return false, err
+	}
+	_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err == nil, err
+}
+
+// finishBulk commits the transaction unless an item failed in the
+// BulkAllOrNothing mode, in which case it is rolled back. It reports whether
+// the transaction was committed.
+func finishBulk(tx *sql.Tx, mode BulkMode, errs []error) (bool, error) {
+	if mode != BulkBestEffort {
+		for _, err := range errs {
+			if err != nil {
+				tx.Rollback()
+				return false, nil
+			}
+		}
+	}
+	err := tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return true, nil
+}
+
+// nullInt64OrZero encodes NULL as 0 for the arrays of the bulk queries, which
+// cannot hold NULLs; the queries turn it back into NULL.
+func nullInt64OrZero(i sql.NullInt64) int64 {
+	if i.Valid {
+		return i.Int64
+	}
+	return 0
+}
+
+// nullInt32OrZero is nullInt64OrZero for int columns.
+func nullInt32OrZero(i sql.NullInt32) int32 {
+	if i.Valid {
+		return i.Int32
+	}
+	return 0
+}
+
+// nullInt32OrEmpty is nullInt32OrZero for int columns where 0 is a value the
+// column must reject rather than NULL; the number is passed as text.
+func nullInt32OrEmpty(i sql.NullInt32) string {
+	if i.Valid {
+		return strconv.FormatInt(int64(i.Int32), 10)
+	}
+	return ""
+}
+
+// nullStringOrEmpty is nullInt64OrZero for text columns.
+func nullStringOrEmpty(s sql.NullString) string {
+	if s.Valid {
+		return s.String
+	}
+	return ""
+}
+
+// nullDateOrEmpty is nullStringOrEmpty for date columns, which are passed as
+// YYYY-MM-DD text.
+func nullDateOrEmpty(t sql.NullTime) string {
+	if t.Valid {
+		return t.Time.Format("2006-01-02")
+	}
+	return ""
+}
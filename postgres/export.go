@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// AgentExport is an exported agent.
+type AgentExport struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AuthorExport is an exported author along with its agent.
+type AuthorExport struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	Website    *string   `json:"website"`
+	AgentID    int64     `json:"agent_id"`
+	AgentName  string    `json:"agent_name"`
+	AgentEmail string    `json:"agent_email"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// BookExport is an exported book along with the ids of its authors.
+type BookExport struct {
+	ID          int64     `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Cover       string    `json:"cover"`
+	AuthorIDs   []int64   `json:"author_ids"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DeletionExport is an exported tombstone of a deleted agent, author or book.
+// Entity is the name of its table.
+type DeletionExport struct {
+	Entity    string    `json:"entity"`
+	ID        int64     `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Exporter streams the catalog through server-side cursors, so that exports
+// never hold more than BatchSize rows in memory.
+type Exporter struct {
+	db *sql.DB
+
+	// BatchSize is the number of rows fetched from the cursor at a time.
+	BatchSize int
+}
+
+// NewExporter returns a new instance of Exporter.
+func NewExporter(db *sql.DB) *Exporter {
+	return &Exporter{db: db, BatchSize: defaultExportBatchSize}
+}
+
+const defaultExportBatchSize = 500
+
+// ExportAgents calls fn for every agent that has not been deleted, in id
+// order. If since is not zero, only agents updated at or after it are
+// exported.
+func (e *Exporter) ExportAgents(ctx context.Context, since time.Time, fn func(AgentExport) error) error {
+	return e.stream(ctx, `
+		SELECT id, name, email, updated_at
+		FROM agents
+		WHERE deleted_at IS NULL AND ($1::timestamptz IS NULL OR updated_at >= $1)
+		ORDER BY id
+	`, since, func(rows *sql.Rows) error {
+		var a AgentExport
+		if err := rows.Scan(&a.ID, &a.Name, &a.Email, &a.UpdatedAt); err != nil {
+			return err
+		}
+		return fn(a)
+	})
+}
+
+// ExportAuthors calls fn for every author, in id order. If since is not
+// zero, only authors updated at or after it are exported.
+func (e *Exporter) ExportAuthors(ctx context.Context, since time.Time, fn func(AuthorExport) error) error {
+	return e.stream(ctx, `
+		SELECT authors.id, authors.name, authors.website, agents.id, agents.name, agents.email, authors.updated_at
+		FROM authors
+		JOIN representations ON representations.author_id = authors.id
+			AND representations.is_primary AND representations.ended_at IS NULL
+		JOIN agents ON agents.id = representations.agent_id
+		WHERE $1::timestamptz IS NULL OR authors.updated_at >= $1
+		ORDER BY authors.id
+	`, since, func(rows *sql.Rows) error {
+		var a AuthorExport
+		var website sql.NullString
+		if err := rows.Scan(&a.ID, &a.Name, &website, &a.AgentID, &a.AgentName, &a.AgentEmail, &a.UpdatedAt); err != nil {
+			return err
+		}
+		a.Website = nullStringToPtr(website)
+		return fn(a)
+	})
+}
+
+// ExportBooks calls fn for every book, in id order. If since is not zero,
+// only books updated at or after it are exported.
+func (e *Exporter) ExportBooks(ctx context.Context, since time.Time, fn func(BookExport) error) error {
+	return e.stream(ctx, `
+		SELECT id, title, description, cover,
+			ARRAY(SELECT author_id FROM book_authors WHERE book_id = books.id ORDER BY position, id),
+			updated_at
+		FROM books
+		WHERE $1::timestamptz IS NULL OR updated_at >= $1
+		ORDER BY id
+	`, since, func(rows *sql.Rows) error {
+		var b BookExport
+		if err := rows.Scan(&b.ID, &b.Title, &b.Description, &b.Cover, pq.Array(&b.AuthorIDs), &b.UpdatedAt); err != nil {
+			return err
+		}
+		if b.AuthorIDs == nil {
+			b.AuthorIDs = []int64{}
+		}
+		return fn(b)
+	})
+}
+
+// ExportDeletions calls fn for every deleted agent, author and book, in
+// deletion order. If since is not zero, only records deleted at or after it
+// are exported; together with the other exports this makes incremental
+// exports complete.
+func (e *Exporter) ExportDeletions(ctx context.Context, since time.Time, fn func(DeletionExport) error) error {
+	return e.stream(ctx, `
+		SELECT entity, id, deleted_at
+		FROM deletions
+		WHERE $1::timestamptz IS NULL OR deleted_at >= $1
+		ORDER BY deleted_at, entity, id
+	`, since, func(rows *sql.Rows) error {
+		var d DeletionExport
+		if err := rows.Scan(&d.Entity, &d.ID, &d.DeletedAt); err != nil {
+			return err
+		}
+		return fn(d)
+	})
+}
+
+// stream declares a cursor for the query, which takes the since filter as
+// its only argument, and calls scan for every row fetched from it. The
+// cursor lives in a read-only, repeatable read transaction, so the export is
+// a consistent snapshot.
+func (e *Exporter) stream(ctx context.Context, query string, since time.Time, scan func(rows *sql.Rows) error) error {
+	tx, err := e.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	// the transaction is read-only, so it is rolled back even on success
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx, "DECLARE export_cursor NO SCROLL CURSOR FOR "+query, sql.NullTime{
+		Time:  since,
+		Valid: !since.IsZero(),
+	})
+	if err != nil {
+		return err
+	}
+	batchSize := e.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultExportBatchSize
+	}
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM export_cursor", batchSize)
+	for {
+		rows, err := tx.QueryContext(ctx, fetch)
+		if err != nil {
+			return err
+		}
+		n := 0
+		for rows.Next() {
+			n++
+			if err := scan(rows); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if n < batchSize {
+			return nil
+		}
+	}
+}
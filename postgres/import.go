@@ -0,0 +1,621 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/fwojciec/litag-example/generated/sqlc" // use your own github username
+	"github.com/lib/pq"
+)
+
+// Import actions reported in an ImportSummary.
+const (
+	ImportInsert = "insert"
+	ImportUpdate = "update"
+)
+
+// AgentImportRow is an agent to import, identified by its email.
+type AgentImportRow struct {
+	Line  int
+	Name  string
+	Email string
+}
+
+// AuthorImportRow is an author to import, identified by its name and the
+// email of its agent.
+type AuthorImportRow struct {
+	Line       int
+	Name       string
+	Website    sql.NullString
+	AgentEmail string
+}
+
+// BookImportRow is a book to import, identified by its title. Authors are
+// referenced by name.
+type BookImportRow struct {
+	Line        int
+	Title       string
+	Description string
+	Cover       string
+	AuthorNames []string
+}
+
+// ImportChange describes a row that inserts or updates a record.
+type ImportChange struct {
+	Line   int
+	Action string
+	Key    string
+}
+
+// ImportRowError is an error that prevented a single row from being imported.
+type ImportRowError struct {
+	Line int
+	Err  error
+}
+
+func (e *ImportRowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+// ImportSummary is the outcome of an import. Rows with errors are skipped,
+// the remaining ones are imported.
+type ImportSummary struct {
+	Inserted  int
+	Updated   int
+	Unchanged int
+	Changes   []ImportChange
+	Errors    []*ImportRowError
+}
+
+// Importer upserts agents, authors and books in bulk, matching existing
+// records by their natural keys. Rows are loaded into temporary staging
+// tables using COPY and merged with set-based statements, which is why its
+// queries are written by hand rather than generated by sqlc.
+type Importer struct {
+	db *sql.DB
+}
+
+// NewImporter returns a new instance of Importer.
+func NewImporter(db *sql.DB) *Importer {
+	return &Importer{db}
+}
+
+// ImportAgents upserts agents by email. In dry-run mode the summary is
+// computed but nothing is changed.
+func (imp *Importer) ImportAgents(ctx context.Context, rows []AgentImportRow, dryRun bool) (*ImportSummary, error) {
+	return imp.run(ctx, dryRun, func(tx *sql.Tx, s *ImportSummary) error {
+		err := execAll(ctx, tx, `
+			CREATE TEMP TABLE import_agents (
+				line INTEGER NOT NULL,
+				name TEXT NOT NULL,
+				email TEXT NOT NULL,
+				existing_id BIGINT,
+				action TEXT
+			) ON COMMIT DROP
+		`)
+		if err != nil {
+			return err
+		}
+		values := make([][]interface{}, 0, len(rows))
+		for _, row := range rows {
+			values = append(values, []interface{}{row.Line, row.Name, row.Email})
+		}
+		err = copyRows(ctx, tx, "import_agents", []string{"line", "name", "email"}, values)
+		if err != nil {
+			return err
+		}
+		err = rejectRows(ctx, tx, s, []string{"import_agents"}, `
+			SELECT line, 'duplicate of line ' || first_line
+			FROM (
+				SELECT line, min(line) OVER (PARTITION BY email) AS first_line
+				FROM import_agents
+			) d
+			WHERE line <> first_line
+		`, `
+			SELECT i.line, 'email ' || i.email || ' matches ' || count(*) || ' agents'
+			FROM import_agents i JOIN agents a ON a.email = i.email AND a.deleted_at IS NULL
+			GROUP BY i.line, i.email
+			HAVING count(*) > 1
+		`)
+		if err != nil {
+			return err
+		}
+		err = execAll(ctx, tx, `
+			UPDATE import_agents i
+			SET existing_id = a.id,
+				action = CASE WHEN a.name = i.name THEN NULL ELSE 'update' END
+			FROM agents a
+			WHERE a.email = i.email AND a.deleted_at IS NULL
+		`, `
+			UPDATE import_agents SET action = 'insert'
+			WHERE existing_id IS NULL
+		`)
+		if err != nil {
+			return err
+		}
+		err = summarize(ctx, tx, s, `SELECT line, action, email FROM import_agents ORDER BY line`)
+		if err != nil || dryRun {
+			return err
+		}
+		return execAll(ctx, tx, `
+			UPDATE agents a SET name = i.name
+			FROM import_agents i
+			WHERE a.id = i.existing_id AND i.action = 'update'
+		`, `
+			INSERT INTO agents (name, email)
+			SELECT name, email FROM import_agents
+			WHERE action = 'insert'
+			ORDER BY line
+		`)
+	})
+}
+
+// ImportAuthors upserts authors by name and agent email. The agents must
+// already exist. In dry-run mode the summary is computed but nothing is
+// changed.
+func (imp *Importer) ImportAuthors(ctx context.Context, rows []AuthorImportRow, dryRun bool) (*ImportSummary, error) {
+	return imp.run(ctx, dryRun, func(tx *sql.Tx, s *ImportSummary) error {
+		err := execAll(ctx, tx, `
+			CREATE TEMP TABLE import_authors (
+				line INTEGER NOT NULL,
+				name TEXT NOT NULL,
+				website TEXT,
+				agent_email TEXT NOT NULL,
+				agent_id BIGINT,
+				existing_id BIGINT,
+				action TEXT
+			) ON COMMIT DROP
+		`)
+		if err != nil {
+			return err
+		}
+		values := make([][]interface{}, 0, len(rows))
+		for _, row := range rows {
+			values = append(values, []interface{}{row.Line, row.Name, normalizeWebsite(row.Website), row.AgentEmail})
+		}
+		err = copyRows(ctx, tx, "import_authors", []string{"line", "name", "website", "agent_email"}, values)
+		if err != nil {
+			return err
+		}
+		err = rejectRows(ctx, tx, s, []string{"import_authors"}, `
+			SELECT line, 'duplicate of line ' || first_line
+			FROM (
+				SELECT line, min(line) OVER (PARTITION BY name, agent_email) AS first_line
+				FROM import_authors
+			) d
+			WHERE line <> first_line
+		`, `
+			SELECT line, 'unknown agent email ' || agent_email
+			FROM import_authors i
+			WHERE NOT EXISTS (SELECT 1 FROM agents a WHERE a.email = i.agent_email AND a.deleted_at IS NULL)
+		`, `
+			SELECT i.line, 'agent email ' || i.agent_email || ' matches ' || count(*) || ' agents'
+			FROM import_authors i JOIN agents a ON a.email = i.agent_email AND a.deleted_at IS NULL
+			GROUP BY i.line, i.agent_email
+			HAVING count(*) > 1
+		`)
+		if err != nil {
+			return err
+		}
+		err = execAll(ctx, tx, `
+			UPDATE import_authors i SET agent_id = a.id
+			FROM agents a
+			WHERE a.email = i.agent_email AND a.deleted_at IS NULL
+		`)
+		if err != nil {
+			return err
+		}
+		err = rejectRows(ctx, tx, s, []string{"import_authors"}, `
+			SELECT i.line, 'name and agent match ' || count(*) || ' authors'
+			FROM import_authors i
+			JOIN authors a ON a.name = i.name
+			JOIN representations r ON r.author_id = a.id AND r.is_primary AND r.ended_at IS NULL
+			WHERE r.agent_id = i.agent_id
+			GROUP BY i.line
+			HAVING count(*) > 1
+		`)
+		if err != nil {
+			return err
+		}
+		err = execAll(ctx, tx, `
+			UPDATE import_authors i
+			SET existing_id = a.id,
+				action = CASE WHEN a.website IS NOT DISTINCT FROM i.website THEN NULL ELSE 'update' END
+			FROM authors a
+			JOIN representations r ON r.author_id = a.id AND r.is_primary AND r.ended_at IS NULL
+			WHERE a.name = i.name AND r.agent_id = i.agent_id
+		`, `
+			UPDATE import_authors SET action = 'insert'
+			WHERE existing_id IS NULL
+		`)
+		if err != nil {
+			return err
+		}
+		err = summarize(ctx, tx, s, `
+			SELECT line, action, name || ' (' || agent_email || ')'
+			FROM import_authors
+			ORDER BY line
+		`)
+		if err != nil || dryRun {
+			return err
+		}
+		err = execAll(ctx, tx, `
+			UPDATE authors a SET website = i.website
+			FROM import_authors i
+			WHERE a.id = i.existing_id AND i.action = 'update'
+		`, `
+			UPDATE import_authors i SET existing_id = n.id
+			FROM (
+				SELECT line, nextval(pg_get_serial_sequence('authors', 'id')) AS id
+				FROM (SELECT line FROM import_authors WHERE action = 'insert' ORDER BY line) l
+			) n
+			WHERE i.line = n.line
+		`, `
+			INSERT INTO authors (id, name, website)
+			SELECT existing_id, name, website FROM import_authors
+			WHERE action = 'insert'
+			ORDER BY line
+		`, `
+			INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+			SELECT existing_id, agent_id, 'WORLD', CURRENT_DATE, true FROM import_authors
+			WHERE action = 'insert'
+			ORDER BY line
+		`)
+		if err != nil {
+			return err
+		}
+		return enqueueImportedAuthors(ctx, tx)
+	})
+}
+
+// ImportBooks upserts books by title. The authors must already exist and be
+// uniquely identified by their names. In dry-run mode the summary is
+// computed but nothing is changed.
+func (imp *Importer) ImportBooks(ctx context.Context, rows []BookImportRow, dryRun bool) (*ImportSummary, error) {
+	return imp.run(ctx, dryRun, func(tx *sql.Tx, s *ImportSummary) error {
+		err := execAll(ctx, tx, `
+			CREATE TEMP TABLE import_books (
+				line INTEGER NOT NULL,
+				title TEXT NOT NULL,
+				description TEXT NOT NULL,
+				cover TEXT NOT NULL,
+				existing_id BIGINT,
+				action TEXT
+			) ON COMMIT DROP
+		`, `
+			CREATE TEMP TABLE import_book_authors (
+				line INTEGER NOT NULL,
+				author_name TEXT NOT NULL,
+				position INTEGER NOT NULL,
+				author_id BIGINT
+			) ON COMMIT DROP
+		`)
+		if err != nil {
+			return err
+		}
+		values := make([][]interface{}, 0, len(rows))
+		var authorValues [][]interface{}
+		for _, row := range rows {
+			values = append(values, []interface{}{row.Line, row.Title, row.Description, row.Cover})
+			for i, name := range row.AuthorNames {
+				authorValues = append(authorValues, []interface{}{row.Line, name, i + 1})
+			}
+		}
+		err = copyRows(ctx, tx, "import_books", []string{"line", "title", "description", "cover"}, values)
+		if err != nil {
+			return err
+		}
+		err = copyRows(ctx, tx, "import_book_authors", []string{"line", "author_name", "position"}, authorValues)
+		if err != nil {
+			return err
+		}
+		tables := []string{"import_books", "import_book_authors"}
+		err = rejectRows(ctx, tx, s, tables, `
+			SELECT line, 'duplicate of line ' || first_line
+			FROM (
+				SELECT line, min(line) OVER (PARTITION BY title) AS first_line
+				FROM import_books
+			) d
+			WHERE line <> first_line
+		`, `
+			SELECT line, 'book must have at least one author'
+			FROM import_books i
+			WHERE NOT EXISTS (SELECT 1 FROM import_book_authors ba WHERE ba.line = i.line)
+		`, `
+			SELECT line, 'unknown author ' || author_name
+			FROM import_book_authors ba
+			WHERE NOT EXISTS (SELECT 1 FROM authors a WHERE a.name = ba.author_name)
+		`, `
+			SELECT ba.line, 'author name ' || ba.author_name || ' matches ' || count(DISTINCT a.id) || ' authors'
+			FROM import_book_authors ba JOIN authors a ON a.name = ba.author_name
+			GROUP BY ba.line, ba.author_name
+			HAVING count(DISTINCT a.id) > 1
+		`, `
+			SELECT i.line, 'title matches ' || count(*) || ' books'
+			FROM import_books i JOIN books b ON b.title = i.title
+			GROUP BY i.line
+			HAVING count(*) > 1
+		`)
+		if err != nil {
+			return err
+		}
+		err = execAll(ctx, tx, `
+			UPDATE import_book_authors ba SET author_id = a.id
+			FROM authors a
+			WHERE a.name = ba.author_name
+		`, `
+			UPDATE import_books i
+			SET existing_id = b.id,
+				action = CASE WHEN b.description = i.description AND b.cover = i.cover
+					AND ARRAY(
+						SELECT author_id FROM book_authors
+						WHERE book_id = b.id
+						ORDER BY position, id
+					) = ARRAY(
+						SELECT author_id FROM import_book_authors
+						WHERE line = i.line
+						GROUP BY author_id
+						ORDER BY MIN(position)
+					)
+				THEN NULL ELSE 'update' END
+			FROM books b
+			WHERE b.title = i.title
+		`, `
+			UPDATE import_books SET action = 'insert'
+			WHERE existing_id IS NULL
+		`)
+		if err != nil {
+			return err
+		}
+		err = summarize(ctx, tx, s, `SELECT line, action, title FROM import_books ORDER BY line`)
+		if err != nil || dryRun {
+			return err
+		}
+		err = execAll(ctx, tx, `
+			UPDATE books b SET description = i.description, cover = i.cover
+			FROM import_books i
+			WHERE b.id = i.existing_id AND i.action = 'update'
+		`, `
+			INSERT INTO books (title, description, cover)
+			SELECT title, description, cover FROM import_books
+			WHERE action = 'insert'
+			ORDER BY line
+		`, `
+			UPDATE import_books i SET existing_id = b.id
+			FROM books b
+			WHERE i.action = 'insert' AND b.title = i.title
+		`, `
+			DELETE FROM book_authors
+			WHERE book_id IN (SELECT existing_id FROM import_books WHERE action = 'update')
+		`, `
+			INSERT INTO book_authors (book_id, author_id, role, position)
+			SELECT i.existing_id, ba.author_id, 'AUTHOR', MIN(ba.position)
+			FROM import_books i JOIN import_book_authors ba ON ba.line = i.line
+			WHERE i.action IS NOT NULL
+			GROUP BY i.existing_id, ba.author_id
+		`)
+		if err != nil {
+			return err
+		}
+		return enqueueImportedBooks(ctx, tx)
+	})
+}
+
+func (imp *Importer) run(ctx context.Context, dryRun bool, fn func(tx *sql.Tx, s *ImportSummary) error) (*ImportSummary, error) {
+	tx, err := imp.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &ImportSummary{}
+	err = fn(tx, s)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	sort.SliceStable(s.Errors, func(i, j int) bool { return s.Errors[i].Line < s.Errors[j].Line })
+	if dryRun {
+		tx.Rollback()
+		return s, nil
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return s, nil
+}
+
+func execAll(ctx context.Context, tx *sql.Tx, queries ...string) error {
+	for _, query := range queries {
+		_, err := tx.ExecContext(ctx, query)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyRows loads the values into the table using COPY.
+func copyRows(ctx context.Context, tx *sql.Tx, table string, columns []string, values [][]interface{}) error {
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		_, err := stmt.ExecContext(ctx, v...)
+		if err != nil {
+			stmt.Close()
+			return err
+		}
+	}
+	_, err = stmt.ExecContext(ctx)
+	if err != nil {
+		stmt.Close()
+		return err
+	}
+	return stmt.Close()
+}
+
+// rejectRows runs the checks, each of which selects the line and the error
+// message of the rows it rejects, records the errors and removes the
+// rejected lines from the staging tables.
+func rejectRows(ctx context.Context, tx *sql.Tx, s *ImportSummary, tables []string, checks ...string) error {
+	var lines []int64
+	for _, check := range checks {
+		rows, err := tx.QueryContext(ctx, check)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var line int64
+			var msg string
+			if err := rows.Scan(&line, &msg); err != nil {
+				rows.Close()
+				return err
+			}
+			s.Errors = append(s.Errors, &ImportRowError{Line: int(line), Err: errors.New(msg)})
+			lines = append(lines, line)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	for _, table := range tables {
+		_, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE line = ANY($1)", pq.Array(lines))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// summarize records the changes selected by the query, which returns the
+// line, the action (NULL if unchanged) and the natural key of each row.
+func summarize(ctx context.Context, tx *sql.Tx, s *ImportSummary, query string) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c ImportChange
+		var action sql.NullString
+		if err := rows.Scan(&c.Line, &action, &c.Key); err != nil {
+			return err
+		}
+		switch action.String {
+		case ImportInsert:
+			s.Inserted++
+		case ImportUpdate:
+			s.Updated++
+		default:
+			s.Unchanged++
+			continue
+		}
+		c.Action = action.String
+		s.Changes = append(s.Changes, c)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+func enqueueImportedAuthors(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT i.action, a.id, a.name, a.website, r.agent_id
+		FROM import_authors i
+		JOIN authors a ON a.id = i.existing_id
+		JOIN representations r ON r.author_id = a.id AND r.is_primary AND r.ended_at IS NULL
+		WHERE i.action IS NOT NULL
+		ORDER BY i.line
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var actions []string
+	var authors []Author
+	for rows.Next() {
+		var action string
+		var a Author
+		if err := rows.Scan(&action, &a.ID, &a.Name, &a.Website, &a.AgentID); err != nil {
+			return err
+		}
+		actions = append(actions, action)
+		authors = append(authors, a)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	q := sqlc.New(tx)
+	for i, author := range authors {
+		eventType := EventAuthorUpdated
+		if actions[i] == ImportInsert {
+			eventType = EventAuthorCreated
+		}
+		err := enqueueEvent(ctx, q, eventType, newAuthorPayload(author))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func enqueueImportedBooks(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT i.action, b.id, b.title, b.description, b.cover, b.publisher_id,
+			ARRAY(SELECT author_id FROM book_authors WHERE book_id = b.id ORDER BY position, id)
+		FROM import_books i JOIN books b ON b.id = i.existing_id
+		WHERE i.action IS NOT NULL
+		ORDER BY i.line
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var actions []string
+	var books []sqlc.Book
+	var authorIDs [][]int64
+	for rows.Next() {
+		var action string
+		var b sqlc.Book
+		var ids []int64
+		if err := rows.Scan(&action, &b.ID, &b.Title, &b.Description, &b.Cover, &b.PublisherID, pq.Array(&ids)); err != nil {
+			return err
+		}
+		actions = append(actions, action)
+		books = append(books, b)
+		authorIDs = append(authorIDs, ids)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	q := sqlc.New(tx)
+	for i, book := range books {
+		eventType := EventBookUpdated
+		if actions[i] == ImportInsert {
+			eventType = EventBookCreated
+		}
+		err := enqueueEvent(ctx, q, eventType, newBookPayload(book, authorIDs[i]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// invalidationChannel is the NOTIFY channel cache invalidations are sent on.
+const invalidationChannel = "litag_cache_invalidations"
+
+// maxNotifyPayload keeps payloads below the 8000 byte limit of NOTIFY.
+const maxNotifyPayload = 7000
+
+// Invalidator applies the cache invalidations received from other instances.
+type Invalidator interface {
+	Invalidate(tags ...string)
+	// Purge drops everything; it is called when invalidations may have been
+	// missed while the connection was down.
+	Purge()
+}
+
+// Notifier publishes cache invalidations to all instances with NOTIFY.
+type Notifier struct {
+	db *sql.DB
+}
+
+// NewNotifier returns a new instance of Notifier.
+func NewNotifier(db *sql.DB) *Notifier {
+	return &Notifier{db: db}
+}
+
+// Publish sends the tags as one or more notifications.
+func (n *Notifier) Publish(ctx context.Context, tags []string) error {
+	for len(tags) > 0 {
+		size, i := 2, 0
+		for ; i < len(tags); i++ {
+			size += len(tags[i]) + 3
+			if size > maxNotifyPayload && i > 0 {
+				break
+			}
+		}
+		payload, err := json.Marshal(tags[:i])
+		if err != nil {
+			return err
+		}
+		if _, err := n.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", invalidationChannel, string(payload)); err != nil {
+			return err
+		}
+		tags = tags[i:]
+	}
+	return nil
+}
+
+// ListenInvalidations applies the invalidations published by any instance
+// until the context is cancelled. It uses a dedicated connection to dsn.
+func ListenInvalidations(ctx context.Context, dsn string, inv Invalidator) error {
+	l := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("postgres: invalidation listener: %s", err)
+		}
+	})
+	defer l.Close()
+	if err := l.Listen(invalidationChannel); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n := <-l.Notify:
+			// a nil notification follows a reconnection
+			if n == nil {
+				inv.Purge()
+				continue
+			}
+			var tags []string
+			if err := json.Unmarshal([]byte(n.Extra), &tags); err != nil {
+				log.Printf("postgres: invalid invalidation %q: %s", n.Extra, err)
+				continue
+			}
+			inv.Invalidate(tags...)
+		case <-time.After(90 * time.Second):
+			go l.Ping()
+		}
+	}
+}
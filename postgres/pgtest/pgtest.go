@@ -0,0 +1,173 @@
+// Package pgtest provides isolated PostgreSQL databases for tests.
+//
+// Every call to NewDB creates a uniquely named schema, applies schema.sql to
+// it and returns a connection pool whose search_path points at it, so tests
+// using it can run in parallel against a single database. The schema is
+// dropped when the test finishes; schemas left behind by interrupted runs are
+// dropped by the first NewDB call of a later run once they are an hour old.
+//
+// The database is given by the LITAG_TEST_DSN environment variable, either as
+// a connection string or as a postgres:// URL, and defaults to
+// "dbname=test_db sslmode=disable".
+package pgtest
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// SchemaPrefix starts the name of every schema created by NewDB, so that the
+// schemas left behind by interrupted test runs are easy to find.
+const SchemaPrefix = "litag_test_"
+
+const defaultDSN = "dbname=test_db sslmode=disable"
+
+// staleAfter is the age at which a schema is assumed to have been left behind
+// by an interrupted test run. It is well above the duration of a test run, so
+// that concurrent runs against the same database keep their schemas.
+const staleAfter = time.Hour
+
+var sweepOnce sync.Once
+
+// NewDB returns a connection pool to a new, migrated schema that is dropped
+// when the test and its subtests finish.
+func NewDB(t *testing.T) *sql.DB {
+	t.Helper()
+	ctx := context.Background()
+	dsn, err := connectionString(os.Getenv("LITAG_TEST_DSN"))
+	if err != nil {
+		t.Fatalf("invalid LITAG_TEST_DSN: %s", err)
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to the db: %s", err)
+	}
+	sweepOnce.Do(func() {
+		if err := dropStaleSchemas(ctx, admin, time.Now()); err != nil {
+			t.Logf("failed to drop stale schemas: %s", err)
+		}
+	})
+	schema, err := schemaName(time.Now())
+	if err != nil {
+		admin.Close()
+		t.Fatalf("failed to name the schema: %s", err)
+	}
+	if _, err := admin.ExecContext(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close()
+		t.Fatalf("failed to create schema: %s", err)
+	}
+
+	// unknown connection parameters are sent to the server as run-time
+	// settings, so every connection of the pool uses the schema
+	db, err := sql.Open("postgres", dsn+" search_path="+schema)
+	if err != nil {
+		admin.Close()
+		t.Fatalf("failed to connect to the db: %s", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		if _, err := admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("failed to drop schema %s: %s", schema, err)
+		}
+		admin.Close()
+	})
+
+	ddl, err := ioutil.ReadFile(SchemaPath())
+	if err != nil {
+		t.Fatalf("failed to read the schema: %s", err)
+	}
+	if _, err := db.ExecContext(ctx, string(ddl)); err != nil {
+		t.Fatalf("failed to apply the schema: %s", err)
+	}
+	return db
+}
+
+// connectionString returns the dsn in the key/value form, so that further
+// parameters can be appended to it. URLs are converted by pq.ParseURL.
+func connectionString(dsn string) (string, error) {
+	if dsn == "" {
+		return defaultDSN, nil
+	}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		return pq.ParseURL(dsn)
+	}
+	return dsn, nil
+}
+
+// dropStaleSchemas drops the schemas created by NewDB at least staleAfter
+// before now, as well as those whose name does not record when they were
+// created.
+func dropStaleSchemas(ctx context.Context, db *sql.DB, now time.Time) error {
+	rows, err := db.QueryContext(ctx, "SELECT nspname FROM pg_namespace WHERE left(nspname, length($1)) = $1", SchemaPrefix)
+	if err != nil {
+		return err
+	}
+	var stale []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		if created, ok := schemaCreatedAt(name); !ok || now.Sub(created) >= staleAfter {
+			stale = append(stale, name)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, name := range stale {
+		if _, err := db.ExecContext(ctx, "DROP SCHEMA IF EXISTS "+pq.QuoteIdentifier(name)+" CASCADE"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SchemaPath returns the path of schema.sql, the schema sqlc generates the
+// queries from.
+func SchemaPath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "schema.sql")
+}
+
+// schemaName returns a unique schema name recording the creation time, in
+// the form litag_test_<unix seconds>_<random hex>.
+func schemaName(now time.Time) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return SchemaPrefix + strconv.FormatInt(now.Unix(), 10) + "_" + hex.EncodeToString(b), nil
+}
+
+// schemaCreatedAt returns the creation time recorded in the name of a schema
+// created by NewDB.
+func schemaCreatedAt(name string) (time.Time, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(name, SchemaPrefix), "_", 2)
+	if len(parts) != 2 {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0), true
+}
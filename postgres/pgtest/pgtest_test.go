@@ -0,0 +1,51 @@
+package pgtest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConnectionString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		dsn  string
+		exp  string
+	}{
+		{"default", "", defaultDSN},
+		{"key/value", "dbname=other sslmode=disable", "dbname=other sslmode=disable"},
+		{"url", "postgres://user@localhost:5433/other?sslmode=disable", "dbname=other host=localhost port=5433 sslmode=disable user=user"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := connectionString(tc.dsn)
+			if err != nil {
+				t.Fatalf("expected no error, received %v", err)
+			}
+			if res != tc.exp {
+				t.Errorf("expected %q, received %q", tc.exp, res)
+			}
+		})
+	}
+}
+
+func TestSchemaName(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1577836800, 0)
+	name, err := schemaName(now)
+	if err != nil {
+		t.Fatalf("expected no error, received %v", err)
+	}
+	if !strings.HasPrefix(name, SchemaPrefix) {
+		t.Errorf("expected %q to start with %q", name, SchemaPrefix)
+	}
+	if created, ok := schemaCreatedAt(name); !ok || !created.Equal(now) {
+		t.Errorf("expected the creation time %s, received %s, %t", now, created, ok)
+	}
+	if _, ok := schemaCreatedAt(SchemaPrefix + "0123456789abcdef"); ok {
+		t.Errorf("expected no creation time in a name without one")
+	}
+}
@@ -3,7 +3,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"sort"
+	"time"
 
+	"github.com/fwojciec/litag-example/domain"         // use your own github username
 	"github.com/fwojciec/litag-example/generated/sqlc" // use your own github username
 	_ "github.com/lib/pq"                              // required
 )
@@ -12,80 +16,457 @@ import (
 type Repo struct {
 	Querent
 	TxQuerent
+
+	// Replicas serve the reads of Querent; it is nil without replicas.
+	Replicas *Replicas
+}
+
+// NewRepo returns a new instance of Repo. If replicas are given, the reads of
+// Querent are spread over them in turn while writes and all TxQuerent methods
+// use the primary db; run Replicas to keep track of their health.
+func NewRepo(db *sql.DB, replicas ...*sql.DB) *Repo {
+	return NewRepoWithConfig(db, Config{Replicas: replicas})
 }
 
-// NewRepo returns a new instance of Repo.
-func NewRepo(db *sql.DB) *Repo {
-	return &Repo{
+// Config configures a Repo created by NewRepoWithConfig.
+type Config struct {
+	// Replicas serve the reads of Querent, see NewRepo.
+	Replicas []*sql.DB
+	// QueryTimeout is the deadline of every Querent call; zero means none.
+	QueryTimeout time.Duration
+	// StatementTimeout is set as statement_timeout in the transactions of
+	// TxQuerent, rounded up to whole milliseconds; zero leaves the server
+	// default.
+	StatementTimeout time.Duration
+}
+
+// NewRepoWithConfig returns a new instance of Repo configured by cfg.
+func NewRepoWithConfig(db *sql.DB, cfg Config) *Repo {
+	repo := &Repo{
 		Querent:   sqlc.New(db),
-		TxQuerent: &txQuerentService{db},
+		TxQuerent: &txQuerentService{db: db, statementTimeout: cfg.StatementTimeout},
+	}
+	if len(cfg.Replicas) > 0 {
+		repo.Replicas = NewReplicas(cfg.Replicas...)
+		repo.Querent = &routedQuerent{Queries: sqlc.New(db), replicas: repo.Replicas}
+	}
+	if cfg.QueryTimeout > 0 {
+		repo.Querent = &timeoutQuerent{q: repo.Querent, timeout: cfg.QueryTimeout}
 	}
+	return repo
 }
 
 // Querent represents database query methods.
 type Querent interface {
+	// agency queries
+	CreateAgency(ctx context.Context, name string) (sqlc.Agency, error)
+	DeleteAgency(ctx context.Context, id int64) (sqlc.Agency, error)
+	GetAgency(ctx context.Context, id int64) (sqlc.Agency, error)
+	ListAgencies(ctx context.Context) ([]sqlc.Agency, error)
+	UpdateAgency(ctx context.Context, args sqlc.UpdateAgencyParams) (sqlc.Agency, error)
+
 	// agent queries
 	CreateAgent(ctx context.Context, args sqlc.CreateAgentParams) (sqlc.Agent, error)
-	DeleteAgent(ctx context.Context, id int64) (sqlc.Agent, error)
 	GetAgent(ctx context.Context, id int64) (sqlc.Agent, error)
 	ListAgents(ctx context.Context) ([]sqlc.Agent, error)
+	ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.Agent, error)
 	UpdateAgent(ctx context.Context, args sqlc.UpdateAgentParams) (sqlc.Agent, error)
 
 	// author queries
-	CreateAuthor(ctx context.Context, args sqlc.CreateAuthorParams) (sqlc.Author, error)
-	DeleteAuthor(ctx context.Context, id int64) (sqlc.Author, error)
-	GetAuthor(ctx context.Context, id int64) (sqlc.Author, error)
-	ListAuthors(ctx context.Context) ([]sqlc.Author, error)
-	UpdateAuthor(ctx context.Context, args sqlc.UpdateAuthorParams) (sqlc.Author, error)
-	ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Author, error)
-	ListAuthorsByBookID(ctx context.Context, bookID int64) ([]sqlc.Author, error)
+	GetAuthor(ctx context.Context, id int64) (sqlc.GetAuthorRow, error)
+	ListAuthors(ctx context.Context) ([]sqlc.ListAuthorsRow, error)
+	ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.ListAuthorsByAgencyIDRow, error)
+	ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.ListAuthorsByAgentIDRow, error)
+	ListAuthorsByBookID(ctx context.Context, bookID int64) ([]sqlc.ListAuthorsByBookIDRow, error)
+	ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.ListFormerAuthorsByAgentIDRow, error)
 
 	// book queries
-	DeleteBook(ctx context.Context, id int64) (sqlc.Book, error)
 	GetBook(ctx context.Context, id int64) (sqlc.Book, error)
+	GetBookByISBN(ctx context.Context, isbn string) (sqlc.Book, error)
 	ListBooks(ctx context.Context) ([]sqlc.Book, error)
+	ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.Book, error)
 	ListBooksByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Book, error)
+	ListBooksByGenreID(ctx context.Context, genreID int64) ([]sqlc.Book, error)
+	ListBooksInGenreTree(ctx context.Context, genreID int64) ([]sqlc.Book, error)
+	ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]sqlc.Book, error)
+	ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]sqlc.Book, error)
+	ListBooksByTag(ctx context.Context, tag string) ([]sqlc.Book, error)
+	ListContributorsByBookID(ctx context.Context, bookID int64) ([]sqlc.BookAuthor, error)
+	ListOrphanBooks(ctx context.Context) ([]sqlc.Book, error)
+	ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error)
+
+	// deal queries
+	CreateDeal(ctx context.Context, args sqlc.CreateDealParams) (sqlc.Deal, error)
+	DeleteDeal(ctx context.Context, id int64) (sqlc.Deal, error)
+	GetDeal(ctx context.Context, id int64) (sqlc.Deal, error)
+	ListAvailableRights(ctx context.Context, args sqlc.ListAvailableRightsParams) ([]sqlc.ListAvailableRightsRow, error)
+	ListDealsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Deal, error)
+	ListDealsByBookID(ctx context.Context, bookID int64) ([]sqlc.Deal, error)
+	UpdateDeal(ctx context.Context, args sqlc.UpdateDealParams) (sqlc.Deal, error)
+
+	// edition queries
+	CreateEdition(ctx context.Context, args sqlc.CreateEditionParams) (sqlc.Edition, error)
+	DeleteEdition(ctx context.Context, id int64) (sqlc.Edition, error)
+	GetEdition(ctx context.Context, id int64) (sqlc.Edition, error)
+	GetEditionByISBN(ctx context.Context, isbn string) (sqlc.Edition, error)
+	ListEditions(ctx context.Context, args sqlc.ListEditionsParams) ([]sqlc.Edition, error)
+	ListEditionsByBookID(ctx context.Context, bookID int64) ([]sqlc.Edition, error)
+	UpdateEdition(ctx context.Context, args sqlc.UpdateEditionParams) (sqlc.Edition, error)
+
+	// genre queries
+	CreateGenre(ctx context.Context, args sqlc.CreateGenreParams) (sqlc.Genre, error)
+	DeleteGenre(ctx context.Context, id int64) (sqlc.Genre, error)
+	GetGenre(ctx context.Context, id int64) (sqlc.Genre, error)
+	ListGenres(ctx context.Context) ([]sqlc.Genre, error)
+	ListGenresByBookID(ctx context.Context, bookID int64) ([]sqlc.Genre, error)
+	ListGenresByParentID(ctx context.Context, parentID int64) ([]sqlc.Genre, error)
+
+	// publisher queries
+	CreatePublisher(ctx context.Context, name string) (sqlc.Publisher, error)
+	DeletePublisher(ctx context.Context, id int64) (sqlc.Publisher, error)
+	GetPublisher(ctx context.Context, id int64) (sqlc.Publisher, error)
+	ListPublishers(ctx context.Context) ([]sqlc.Publisher, error)
+	UpdatePublisher(ctx context.Context, args sqlc.UpdatePublisherParams) (sqlc.Publisher, error)
+
+	// representation queries
+	CreateRepresentation(ctx context.Context, args sqlc.CreateRepresentationParams) (sqlc.Representation, error)
+	GetRepresentation(ctx context.Context, id int64) (sqlc.Representation, error)
+	ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Representation, error)
+
+	// royalty queries
+	DeleteRoyaltyRate(ctx context.Context, args sqlc.DeleteRoyaltyRateParams) (sqlc.RoyaltyRate, error)
+	DeleteSales(ctx context.Context, id int64) (sqlc.Sale, error)
+	ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]sqlc.RoyaltyRate, error)
+	ListRoyaltySales(ctx context.Context, args sqlc.ListRoyaltySalesParams) ([]sqlc.ListRoyaltySalesRow, error)
+	ListSalesByDealID(ctx context.Context, dealID int64) ([]sqlc.Sale, error)
+	RecordSales(ctx context.Context, args sqlc.RecordSalesParams) (sqlc.Sale, error)
+	SetRoyaltyRate(ctx context.Context, args sqlc.SetRoyaltyRateParams) (sqlc.RoyaltyRate, error)
+
+	// series queries
+	CreateSeries(ctx context.Context, title string) (sqlc.Series, error)
+	GetSeries(ctx context.Context, id int64) (sqlc.Series, error)
+	ListSeries(ctx context.Context) ([]sqlc.Series, error)
+	UpdateSeries(ctx context.Context, args sqlc.UpdateSeriesParams) (sqlc.Series, error)
+
+	// submission queries
+	CreateSubmission(ctx context.Context, args sqlc.CreateSubmissionParams) (sqlc.Submission, error)
+	GetSubmission(ctx context.Context, id int64) (sqlc.Submission, error)
+	ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]sqlc.SubmissionTransition, error)
+	ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Submission, error)
+	ListSubmissionsByAgentIDAndStatus(ctx context.Context, args sqlc.ListSubmissionsByAgentIDAndStatusParams) ([]sqlc.Submission, error)
+
+	// webhook queries
+	CreateWebhook(ctx context.Context, args sqlc.CreateWebhookParams) (sqlc.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) (sqlc.Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (sqlc.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]sqlc.Webhook, error)
+	UpdateWebhook(ctx context.Context, args sqlc.UpdateWebhookParams) (sqlc.Webhook, error)
+
+	// webhook delivery queries
+	ClaimWebhookDeliveries(ctx context.Context, args sqlc.ClaimWebhookDeliveriesParams) ([]sqlc.ClaimWebhookDeliveriesRow, error)
+	CompleteWebhookDelivery(ctx context.Context, args sqlc.CompleteWebhookDeliveryParams) error
+	FailWebhookDelivery(ctx context.Context, args sqlc.FailWebhookDeliveryParams) error
+	ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]sqlc.WebhookDelivery, error)
+	ListWebhookDeliveriesByStatus(ctx context.Context, args sqlc.ListWebhookDeliveriesByStatusParams) ([]sqlc.WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, id int64) (sqlc.WebhookDelivery, error)
 }
 
 // TxQuerent represents database query methods performed using a transaction.
+// Each method also enqueues the webhook deliveries for the change it makes in
+// the same transaction.
 type TxQuerent interface {
+	DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*sqlc.Agent, error)
+	CreateAuthor(ctx context.Context, args CreateAuthorParams) (*Author, error)
+	UpdateAuthor(ctx context.Context, args UpdateAuthorParams) (*Author, error)
+	DeleteAuthor(ctx context.Context, id int64, orphanedBooks OrphanedBooksPolicy) (*Author, error)
 	CreateBook(
 		ctx context.Context,
 		bookArgs sqlc.CreateBookParams,
-		authorIDs []int64,
+		contributors []Contributor,
+		genreIDs []int64,
+		tags []string,
 	) (*sqlc.Book, error)
 	UpdateBook(
 		ctx context.Context,
 		bookArgs sqlc.UpdateBookParams,
-		authorIDs []int64,
+		contributors []Contributor,
+		genreIDs []int64,
+		tags []string,
 	) (*sqlc.Book, error)
+	DeleteBook(ctx context.Context, id int64) (*sqlc.Book, error)
+	UpdateGenre(ctx context.Context, args sqlc.UpdateGenreParams) (*sqlc.Genre, error)
+	EndRepresentation(ctx context.Context, id int64, endedAt time.Time) (*sqlc.Representation, error)
+	ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*sqlc.Series, error)
+	DeleteSeries(ctx context.Context, id int64) (*sqlc.Series, error)
+	TransitionSubmission(ctx context.Context, id int64, status string, note sql.NullString) (*sqlc.Submission, error)
+	ConvertSubmission(ctx context.Context, id int64, cover string) (*sqlc.Book, error)
+
+	// bulk methods
+	CreateAgents(ctx context.Context, args []sqlc.CreateAgentParams, mode BulkMode) (*BulkAgentsResult, error)
+	CreateAuthors(ctx context.Context, args []CreateAuthorParams, mode BulkMode) (*BulkAuthorsResult, error)
+	CreateBooks(ctx context.Context, args []BulkCreateBookArgs, mode BulkMode) (*BulkBooksResult, error)
+	UpdateBooks(ctx context.Context, args []BulkUpdateBookArgs, mode BulkMode) (*BulkBooksResult, error)
+}
+
+// Author is an author along with the agent of its current primary
+// representation. The rows of the author queries convert to it.
+type Author struct {
+	ID        int64
+	Name      string
+	Website   sql.NullString
+	UpdatedAt time.Time
+	AgentID   int64
+}
+
+// CreateAuthorParams are the arguments of TxQuerent.CreateAuthor. The author
+// starts a primary representation by the agent.
+type CreateAuthorParams struct {
+	sqlc.CreateAuthorParams
+	AgentID int64
+}
+
+// UpdateAuthorParams are the arguments of TxQuerent.UpdateAuthor. The author
+// starts a primary representation by the agent unless it already has one.
+type UpdateAuthorParams struct {
+	sqlc.UpdateAuthorParams
+	AgentID int64
+}
+
+// AgentHasAuthorsError is returned when deleting an agent that still
+// represents authors without naming an agent to reassign them to.
+type AgentHasAuthorsError = domain.AgentHasAuthorsError
+
+// Contributor credits an author on a book in a role. Position orders the
+// contributors of the book.
+type Contributor struct {
+	AuthorID int64
+	Role     string
+	Position int32
+}
+
+// contributorAuthorIDs returns the ids of the contributors in position order.
+func contributorAuthorIDs(contributors []Contributor) []int64 {
+	sorted := append([]Contributor(nil), contributors...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Position < sorted[j].Position })
+	ids := make([]int64, 0, len(sorted))
+	for _, c := range sorted {
+		ids = append(ids, c.AuthorID)
+	}
+	return ids
 }
 
+// OrphanedBooksPolicy determines what happens to the books left without any
+// authors when an author is deleted.
+type OrphanedBooksPolicy = domain.OrphanedBooksPolicy
+
+// Orphaned books policies.
+const (
+	OrphanedBooksFail   = domain.OrphanedBooksFail
+	OrphanedBooksDelete = domain.OrphanedBooksDelete
+	OrphanedBooksKeep   = domain.OrphanedBooksKeep
+)
+
+// BooksWouldBeOrphanedError is returned when deleting an author would leave
+// books without any authors under the OrphanedBooksFail policy.
+type BooksWouldBeOrphanedError = domain.BooksWouldBeOrphanedError
+
+// ErrGenreCycle is returned when moving a genre under itself or one of its
+// subgenres.
+var ErrGenreCycle = domain.ErrGenreCycle
+
+// ErrSeriesBooksMismatch is returned when reordering a series with a list of
+// books that is not exactly the books of the series.
+var ErrSeriesBooksMismatch = domain.ErrSeriesBooksMismatch
+
+// ErrPrimaryRepresentation is returned when ending the primary representation
+// of an author, which only ends when the author changes agents.
+var ErrPrimaryRepresentation = domain.ErrPrimaryRepresentation
+
+// Submission statuses, as stored in submissions.status.
+const (
+	SubmissionReceived      = string(domain.SubmissionReceived)
+	SubmissionReading       = string(domain.SubmissionReading)
+	SubmissionRequestedFull = string(domain.SubmissionRequestedFull)
+	SubmissionOffered       = string(domain.SubmissionOffered)
+	SubmissionDeclined      = string(domain.SubmissionDeclined)
+	SubmissionWithdrawn     = string(domain.SubmissionWithdrawn)
+)
+
+// SubmissionTransitionError is returned when moving a submission to a status
+// it cannot move to from its current one.
+type SubmissionTransitionError = domain.SubmissionTransitionError
+
+// ErrSubmissionNotOffered is returned when converting a submission that has
+// not been offered representation or has already been converted into a book.
+var ErrSubmissionNotOffered = domain.ErrSubmissionNotOffered
+
 type txQuerentService struct {
-	db *sql.DB
+	db               *sql.DB
+	statementTimeout time.Duration
 }
 
-func (txq *txQuerentService) CreateBook(ctx context.Context, bookArgs sqlc.CreateBookParams, authorIDs []int64) (*sqlc.Book, error) {
-	// begin the transaction
+// begin starts a transaction with the configured statement timeout and pins
+// the request to the primary, see ReadYourWrites.
+func (txq *txQuerentService) begin(ctx context.Context) (*sql.Tx, error) {
+	pinToPrimary(ctx)
 	tx, err := txq.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	q := sqlc.New(tx)
-	book, err := q.CreateBook(ctx, bookArgs)
+	if txq.statementTimeout > 0 {
+		// round up, a statement_timeout of 0 would disable the timeout
+		ms := int64((txq.statementTimeout + time.Millisecond - 1) / time.Millisecond)
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", ms)); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+func (txq *txQuerentService) DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*sqlc.Agent, error) {
+	tx, err := txq.begin(ctx)
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
-	for _, authorID := range authorIDs {
-		err := q.SetBookAuthor(ctx, sqlc.SetBookAuthorParams{
-			BookID:   book.ID,
-			AuthorID: authorID,
+	q := sqlc.New(tx)
+	if reassignAuthorsTo != nil {
+		if *reassignAuthorsTo == id {
+			tx.Rollback()
+			return nil, fmt.Errorf("cannot reassign authors of agent %d to the same agent", id)
+		}
+		authorIDs, err := q.ReassignAuthors(ctx, sqlc.ReassignAuthorsParams{
+			ToAgentID:   *reassignAuthorsTo,
+			FromAgentID: id,
 		})
 		if err != nil {
 			tx.Rollback()
 			return nil, err
 		}
+		for _, authorID := range authorIDs {
+			author, err := q.GetAuthor(ctx, authorID)
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			err = enqueueEvent(ctx, q, EventAuthorUpdated, newAuthorPayload(Author(author)))
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+	} else {
+		authors, err := q.ListAuthorsByAgentID(ctx, id)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if len(authors) > 0 {
+			tx.Rollback()
+			res := make([]Author, len(authors))
+			for i, author := range authors {
+				res[i] = Author(author)
+			}
+			return nil, &AgentHasAuthorsError{AgentID: id, Authors: toDomainAuthors(res)}
+		}
+	}
+	agent, err := deleteOrArchiveAgent(ctx, q, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &agent, nil
+}
+
+// deleteOrArchiveAgent deletes an agent without authors. An agent that
+// representations or deals refer to is archived instead, after ending its
+// co-representations and deleting its submissions like a delete would.
+func deleteOrArchiveAgent(ctx context.Context, q *sqlc.Queries, id int64) (sqlc.Agent, error) {
+	hasHistory, err := q.AgentHasHistory(ctx, id)
+	if err != nil {
+		return sqlc.Agent{}, err
+	}
+	if !hasHistory {
+		return q.DeleteAgent(ctx, id)
+	}
+	err = q.EndRepresentationsByAgentID(ctx, id)
+	if err != nil {
+		return sqlc.Agent{}, err
+	}
+	err = q.DeleteSubmissionsByAgentID(ctx, id)
+	if err != nil {
+		return sqlc.Agent{}, err
+	}
+	return q.ArchiveAgent(ctx, id)
+}
+
+func (txq *txQuerentService) CreateBook(ctx context.Context, bookArgs sqlc.CreateBookParams, contributors []Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
+	// begin the transaction
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	book, err := createBook(ctx, sqlc.New(tx), bookArgs, contributors, genreIDs, tags)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return book, nil
+}
+
+func (txq *txQuerentService) UpdateBook(ctx context.Context, bookArgs sqlc.UpdateBookParams, contributors []Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	book, err := updateBook(ctx, sqlc.New(tx), bookArgs, contributors, genreIDs, tags)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return book, nil
+}
+
+func (txq *txQuerentService) DeleteBook(ctx context.Context, id int64) (*sqlc.Book, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	authors, err := q.ListAuthorsByBookID(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	// BookAuthors associations will cascade automatically.
+	book, err := q.DeleteBook(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	authorIDs := make([]int64, 0, len(authors))
+	for _, author := range authors {
+		authorIDs = append(authorIDs, author.ID)
+	}
+	err = enqueueEvent(ctx, q, EventBookDeleted, newBookPayload(book, authorIDs))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 	err = tx.Commit()
 	if err != nil {
@@ -95,36 +476,529 @@ func (txq *txQuerentService) CreateBook(ctx context.Context, bookArgs sqlc.Creat
 	return &book, nil
 }
 
-func (txq *txQuerentService) UpdateBook(ctx context.Context, bookArgs sqlc.UpdateBookParams, authorIDs []int64) (*sqlc.Book, error) {
-	tx, err := txq.db.BeginTx(ctx, nil)
+// UpdateGenre updates a genre, refusing to move it under itself or one of
+// its subgenres. Genre changes are serialized so that two concurrent moves
+// cannot form a cycle either.
+func (txq *txQuerentService) UpdateGenre(ctx context.Context, args sqlc.UpdateGenreParams) (*sqlc.Genre, error) {
+	tx, err := txq.begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	q := sqlc.New(tx)
-	book, err := q.UpdateBook(ctx, bookArgs)
+	err = q.LockGenres(ctx)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
-	err = q.UnsetBookAuthors(ctx, book.ID)
+	if args.ParentID.Valid {
+		ancestorIDs, err := q.ListGenreAncestorIDs(ctx, args.ParentID.Int64)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		for _, id := range ancestorIDs {
+			if id == args.ID {
+				tx.Rollback()
+				return nil, ErrGenreCycle
+			}
+		}
+	}
+	genre, err := q.UpdateGenre(ctx, args)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
-	for _, authorID := range authorIDs {
-		err := q.SetBookAuthor(ctx, sqlc.SetBookAuthorParams{
-			BookID:   book.ID,
-			AuthorID: authorID,
-		})
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &genre, nil
+}
+
+// EndRepresentation ends a co-representation of an author at endedAt.
+func (txq *txQuerentService) EndRepresentation(ctx context.Context, id int64, endedAt time.Time) (*sqlc.Representation, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	representation, err := q.GetRepresentation(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if representation.IsPrimary {
+		tx.Rollback()
+		return nil, ErrPrimaryRepresentation
+	}
+	representation, err = q.EndRepresentation(ctx, sqlc.EndRepresentationParams{ID: id, EndedAt: endedAt})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &representation, nil
+}
+
+// ReorderSeries numbers the books of the series from 1 in the order of
+// bookIDs. The series is locked so that concurrent reorders do not interleave.
+func (txq *txQuerentService) ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*sqlc.Series, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	series, err := q.GetSeriesForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	books, err := q.ListBooksBySeriesID(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !sameBooks(books, bookIDs) {
+		tx.Rollback()
+		return nil, ErrSeriesBooksMismatch
+	}
+	// the positions are only unique again once every book has moved
+	err = q.DeferSeriesPositions(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	params := sqlc.SetSeriesPositionsParams{
+		Ids:             bookIDs,
+		SeriesPositions: make([]int32, 0, len(bookIDs)),
+	}
+	for i := range bookIDs {
+		params.SeriesPositions = append(params.SeriesPositions, int32(i+1))
+	}
+	moved, err := q.SetSeriesPositions(ctx, params)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for _, book := range moved {
+		authors, err := q.ListAuthorsByBookID(ctx, book.ID)
 		if err != nil {
 			tx.Rollback()
 			return nil, err
 		}
+		authorIDs := make([]int64, 0, len(authors))
+		for _, author := range authors {
+			authorIDs = append(authorIDs, author.ID)
+		}
+		err = enqueueEvent(ctx, q, EventBookUpdated, newBookPayload(book, authorIDs))
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &series, nil
+}
+
+// DeleteSeries deletes the series, keeping its books outside of any series.
+func (txq *txQuerentService) DeleteSeries(ctx context.Context, id int64) (*sqlc.Series, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	err = q.RemoveBooksFromSeries(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	series, err := q.DeleteSeries(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &series, nil
+}
+
+// TransitionSubmission moves the submission to status, recording the
+// transition along with the note. The submission is locked so that concurrent
+// transitions are checked against the status they move from. A submission
+// converted into a book cannot move anymore.
+func (txq *txQuerentService) TransitionSubmission(ctx context.Context, id int64, status string, note sql.NullString) (*sqlc.Submission, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	submission, err := q.GetSubmissionForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	from, to := domain.SubmissionStatus(submission.Status), domain.SubmissionStatus(status)
+	if submission.BookID.Valid || !domain.CanTransitionSubmission(from, to) {
+		tx.Rollback()
+		return nil, &SubmissionTransitionError{SubmissionID: id, From: from, To: to}
+	}
+	_, err = q.CreateSubmissionTransition(ctx, sqlc.CreateSubmissionTransitionParams{
+		SubmissionID: id,
+		FromStatus:   submission.Status,
+		ToStatus:     status,
+		Note:         note,
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	submission, err = q.SetSubmissionStatus(ctx, sqlc.SetSubmissionStatusParams{ID: id, Status: status})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// ConvertSubmission turns an offered submission into a book with the given
+// cover, titled and described after the submission, by a new author
+// represented by the agent of the submission.
+func (txq *txQuerentService) ConvertSubmission(ctx context.Context, id int64, cover string) (*sqlc.Book, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	submission, err := q.GetSubmissionForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if submission.Status != SubmissionOffered || submission.BookID.Valid {
+		tx.Rollback()
+		return nil, ErrSubmissionNotOffered
+	}
+	author, err := createAuthor(ctx, q, CreateAuthorParams{
+		CreateAuthorParams: sqlc.CreateAuthorParams{
+			Name:    submission.AuthorName,
+			Website: submission.AuthorWebsite,
+		},
+		AgentID: submission.AgentID,
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	book, err := createBook(ctx, q, sqlc.CreateBookParams{
+		Title:       submission.Title,
+		Description: submission.Synopsis,
+		Cover:       cover,
+	}, []Contributor{{AuthorID: author.ID, Role: "AUTHOR", Position: 1}}, nil, nil)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	_, err = q.SetSubmissionBook(ctx, sqlc.SetSubmissionBookParams{
+		ID:     id,
+		BookID: sql.NullInt64{Int64: book.ID, Valid: true},
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return book, nil
+}
+
+// sameBooks reports whether ids lists every book exactly once.
+func sameBooks(books []sqlc.Book, ids []int64) bool {
+	if len(books) != len(ids) {
+		return false
+	}
+	remaining := make(map[int64]bool, len(books))
+	for _, book := range books {
+		remaining[book.ID] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+	return true
+}
+
+func (txq *txQuerentService) CreateAuthor(ctx context.Context, args CreateAuthorParams) (*Author, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	author, err := createAuthor(ctx, sqlc.New(tx), args)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return author, nil
+}
+
+func (txq *txQuerentService) UpdateAuthor(ctx context.Context, args UpdateAuthorParams) (*Author, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	args.Website = normalizeWebsite(args.Website)
+	_, err = q.UpdateAuthor(ctx, args.UpdateAuthorParams)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = q.StartPrimaryRepresentation(ctx, sqlc.StartPrimaryRepresentationParams{
+		AuthorID: args.ID,
+		AgentID:  args.AgentID,
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	row, err := q.GetAuthor(ctx, args.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	author := Author(row)
+	err = enqueueEvent(ctx, q, EventAuthorUpdated, newAuthorPayload(author))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
+	return &author, nil
+}
+
+func (txq *txQuerentService) DeleteAuthor(ctx context.Context, id int64, orphanedBooks OrphanedBooksPolicy) (*Author, error) {
+	tx, err := txq.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := sqlc.New(tx)
+	// Read the author before its representations cascade away.
+	row, err := q.GetAuthor(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	author := Author(row)
+	books, err := q.ListBooksOrphanedByAuthorID(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(books) > 0 {
+		switch orphanedBooks {
+		case OrphanedBooksDelete:
+			for _, book := range books {
+				_, err := q.DeleteBook(ctx, book.ID)
+				if err != nil {
+					tx.Rollback()
+					return nil, err
+				}
+				err = enqueueEvent(ctx, q, EventBookDeleted, newBookPayload(book, []int64{id}))
+				if err != nil {
+					tx.Rollback()
+					return nil, err
+				}
+			}
+		case OrphanedBooksKeep:
+			err := q.AllowOrphanedBooks(ctx)
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		default:
+			tx.Rollback()
+			return nil, &BooksWouldBeOrphanedError{AuthorID: id, Books: toDomainBooks(books)}
+		}
+	}
+	_, err = q.DeleteAuthor(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = enqueueEvent(ctx, q, EventAuthorDeleted, newAuthorPayload(author))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &author, nil
+}
+
+func createAuthor(ctx context.Context, q *sqlc.Queries, args CreateAuthorParams) (*Author, error) {
+	args.Website = normalizeWebsite(args.Website)
+	created, err := q.CreateAuthor(ctx, args.CreateAuthorParams)
+	if err != nil {
+		return nil, err
+	}
+	err = q.StartPrimaryRepresentation(ctx, sqlc.StartPrimaryRepresentationParams{
+		AuthorID: created.ID,
+		AgentID:  args.AgentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	row, err := q.GetAuthor(ctx, created.ID)
+	if err != nil {
+		return nil, err
+	}
+	author := Author(row)
+	err = enqueueEvent(ctx, q, EventAuthorCreated, newAuthorPayload(author))
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+// normalizeWebsite turns an empty website into NULL, so that authors created
+// singly, in bulk or by import are stored the same way.
+func normalizeWebsite(website sql.NullString) sql.NullString {
+	if website.String == "" {
+		return sql.NullString{}
+	}
+	return website
+}
+
+func createBook(ctx context.Context, q *sqlc.Queries, bookArgs sqlc.CreateBookParams, contributors []Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
+	book, err := q.CreateBook(ctx, bookArgs)
+	if err != nil {
+		return nil, err
+	}
+	err = setBookAuthors(ctx, q, book.ID, contributors)
+	if err != nil {
+		return nil, err
+	}
+	err = setBookGenres(ctx, q, book.ID, genreIDs)
+	if err != nil {
+		return nil, err
+	}
+	err = setBookTags(ctx, q, book.ID, tags)
+	if err != nil {
+		return nil, err
+	}
+	err = enqueueEvent(ctx, q, EventBookCreated, newBookPayload(book, contributorAuthorIDs(contributors)))
+	if err != nil {
+		return nil, err
+	}
 	return &book, nil
 }
+
+func updateBook(ctx context.Context, q *sqlc.Queries, bookArgs sqlc.UpdateBookParams, contributors []Contributor, genreIDs []int64, tags []string) (*sqlc.Book, error) {
+	book, err := q.UpdateBook(ctx, bookArgs)
+	if err != nil {
+		return nil, err
+	}
+	err = q.UnsetBookAuthors(ctx, book.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = setBookAuthors(ctx, q, book.ID, contributors)
+	if err != nil {
+		return nil, err
+	}
+	err = q.UnsetBookGenres(ctx, book.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = setBookGenres(ctx, q, book.ID, genreIDs)
+	if err != nil {
+		return nil, err
+	}
+	err = q.UnsetBookTags(ctx, book.ID)
+	if err != nil {
+		return nil, err
+	}
+	err = setBookTags(ctx, q, book.ID, tags)
+	if err != nil {
+		return nil, err
+	}
+	err = enqueueEvent(ctx, q, EventBookUpdated, newBookPayload(book, contributorAuthorIDs(contributors)))
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+func setBookAuthors(ctx context.Context, q *sqlc.Queries, bookID int64, contributors []Contributor) error {
+	for _, c := range contributors {
+		err := q.SetBookAuthor(ctx, sqlc.SetBookAuthorParams{
+			BookID:   bookID,
+			AuthorID: c.AuthorID,
+			Role:     c.Role,
+			Position: c.Position,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setBookGenres(ctx context.Context, q *sqlc.Queries, bookID int64, genreIDs []int64) error {
+	for _, genreID := range genreIDs {
+		err := q.SetBookGenre(ctx, sqlc.SetBookGenreParams{
+			BookID:  bookID,
+			GenreID: genreID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setBookTags(ctx context.Context, q *sqlc.Queries, bookID int64, tags []string) error {
+	for _, tag := range tags {
+		err := q.SetBookTag(ctx, sqlc.SetBookTagParams{
+			BookID: bookID,
+			Tag:    tag,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,11 +3,17 @@ package postgres_test
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/fwojciec/litag-example/domain"
 	"github.com/fwojciec/litag-example/generated/sqlc"
 	"github.com/fwojciec/litag-example/postgres"
+	"github.com/fwojciec/litag-example/postgres/pgtest"
+	"github.com/fwojciec/litag-example/repotest"
 )
 
 /*
@@ -19,6 +25,7 @@ import (
 */
 
 func TestQueries(t *testing.T) {
+	t.Parallel()
 
 	var (
 		testAgent1 = sqlc.Agent{
@@ -34,17 +41,17 @@ func TestQueries(t *testing.T) {
 			Name:  "test agent name updated",
 			Email: "updated@test.com",
 		}
-		testAuthor1 = sqlc.Author{
+		testAuthor1 = postgres.Author{
 			// agent 1
 			Name:    "test author name 1",
 			Website: sql.NullString{String: "https://author1.com", Valid: true},
 		}
-		testAuthor2 = sqlc.Author{
+		testAuthor2 = postgres.Author{
 			// agent 2
 			Name:    "test author name 2",
 			Website: sql.NullString{},
 		}
-		testAuthorUpdated = sqlc.Author{
+		testAuthorUpdated = postgres.Author{
 			// author 2
 			// agent 1
 			Name:    "test author name updated",
@@ -69,10 +76,30 @@ func TestQueries(t *testing.T) {
 			Description: "book description updated",
 			Cover:       "bookupdated.jpg",
 		}
+		testWebhook = sqlc.Webhook{
+			Url:        "https://example.com/hook",
+			Secret:     "secret",
+			EventTypes: []string{postgres.EventAuthorCreated, postgres.EventBookCreated},
+		}
 	)
 
 	runner(t, func(ctx context.Context, r *postgres.Repo, t *testing.T) {
 		t.Run("Create queries", func(t *testing.T) {
+			t.Run("CreateWebhook", func(t *testing.T) {
+				w, err := r.CreateWebhook(ctx, sqlc.CreateWebhookParams{
+					Url:        testWebhook.Url,
+					Secret:     testWebhook.Secret,
+					EventTypes: testWebhook.EventTypes,
+				})
+				if err != nil {
+					t.Fatalf("failed to create webhook: %s", err)
+				}
+				testWebhook.ID = w.ID
+				if !reflect.DeepEqual(testWebhook, w) {
+					t.Errorf("expected %v, received %v", testWebhook, w)
+				}
+			})
+
 			t.Run("CreateAgent 1", func(t *testing.T) {
 				a, err := r.CreateAgent(ctx, sqlc.CreateAgentParams{
 					Name:  testAgent1.Name,
@@ -82,6 +109,7 @@ func TestQueries(t *testing.T) {
 					t.Fatalf("failed to create agent: %s", err)
 				}
 				testAgent1.ID = a.ID
+				testAgent1.UpdatedAt = a.UpdatedAt
 				testAuthor1.AgentID = a.ID
 				if !reflect.DeepEqual(testAgent1, a) {
 					t.Errorf("expected %v, received %v", testAgent1, a)
@@ -97,34 +125,41 @@ func TestQueries(t *testing.T) {
 					t.Fatalf("failed to create agent: %s", err)
 				}
 				testAgent2.ID = a.ID
+				testAgent2.UpdatedAt = a.UpdatedAt
 				testAuthor2.AgentID = a.ID
 			})
 
 			t.Run("CreateAuthor 1", func(t *testing.T) {
-				a, err := r.CreateAuthor(ctx, sqlc.CreateAuthorParams{
-					Name:    testAuthor1.Name,
-					Website: testAuthor1.Website,
+				a, err := r.CreateAuthor(ctx, postgres.CreateAuthorParams{
+					CreateAuthorParams: sqlc.CreateAuthorParams{
+						Name:    testAuthor1.Name,
+						Website: testAuthor1.Website,
+					},
 					AgentID: testAuthor1.AgentID,
 				})
 				if err != nil {
 					t.Fatalf("failed to create author: %s", err)
 				}
 				testAuthor1.ID = a.ID
-				if !reflect.DeepEqual(testAuthor1, a) {
+				testAuthor1.UpdatedAt = a.UpdatedAt
+				if !reflect.DeepEqual(&testAuthor1, a) {
 					t.Errorf("expected %v, received %v", testAuthor1, a)
 				}
 			})
 
 			t.Run("CreateAuthor 2", func(t *testing.T) {
-				a, err := r.CreateAuthor(ctx, sqlc.CreateAuthorParams{
-					Name:    testAuthor2.Name,
-					Website: testAuthor2.Website,
+				a, err := r.CreateAuthor(ctx, postgres.CreateAuthorParams{
+					CreateAuthorParams: sqlc.CreateAuthorParams{
+						Name:    testAuthor2.Name,
+						Website: testAuthor2.Website,
+					},
 					AgentID: testAuthor2.AgentID,
 				})
 				if err != nil {
 					t.Fatalf("failed to create author: %s", err)
 				}
 				testAuthor2.ID = a.ID
+				testAuthor2.UpdatedAt = a.UpdatedAt
 			})
 
 			t.Run("CreateBook 1", func(t *testing.T) {
@@ -132,11 +167,12 @@ func TestQueries(t *testing.T) {
 					Title:       testBook1.Title,
 					Description: testBook1.Description,
 					Cover:       testBook1.Cover,
-				}, []int64{testAuthor1.ID, testAuthor2.ID})
+				}, credits(testAuthor1.ID, testAuthor2.ID), nil, nil)
 				if err != nil {
 					t.Fatalf("failed to create book: %s", err)
 				}
 				testBook1.ID = b.ID
+				testBook1.UpdatedAt = b.UpdatedAt
 				if !reflect.DeepEqual(&testBook1, b) {
 					t.Errorf("expected %v, received %v", testBook1, b)
 				}
@@ -147,11 +183,12 @@ func TestQueries(t *testing.T) {
 					Title:       testBook2.Title,
 					Description: testBook2.Description,
 					Cover:       testBook2.Cover,
-				}, []int64{testAuthor2.ID})
+				}, credits(testAuthor2.ID), nil, nil)
 				if err != nil {
 					t.Fatalf("failed to create book: %s", err)
 				}
 				testBook2.ID = b.ID
+				testBook2.UpdatedAt = b.UpdatedAt
 			})
 		})
 
@@ -172,8 +209,8 @@ func TestQueries(t *testing.T) {
 				if err != nil {
 					t.Fatalf("failed to list authors: %s", err)
 				}
-				exp := []sqlc.Author{testAuthor1, testAuthor2}
-				if !reflect.DeepEqual(exp, l) {
+				exp := []postgres.Author{testAuthor1, testAuthor2}
+				if !reflect.DeepEqual(exp, toAuthors(l)) {
 					t.Errorf("expected %v, received %v", exp, l)
 				}
 			})
@@ -183,8 +220,8 @@ func TestQueries(t *testing.T) {
 				if err != nil {
 					t.Fatalf("failed to list authors by agent id: %s", err)
 				}
-				exp := []sqlc.Author{testAuthor1}
-				if !reflect.DeepEqual(exp, l) {
+				exp := []postgres.Author{testAuthor1}
+				if !reflect.DeepEqual(exp, toAuthors(l)) {
 					t.Errorf("expected %v, received %v", exp, l)
 				}
 			})
@@ -205,8 +242,8 @@ func TestQueries(t *testing.T) {
 				if err != nil {
 					t.Fatalf("failed to get authors by book id: %s", err)
 				}
-				exp := []sqlc.Author{testAuthor1, testAuthor2}
-				if !reflect.DeepEqual(exp, l) {
+				exp := []postgres.Author{testAuthor1, testAuthor2}
+				if !reflect.DeepEqual(exp, toAuthors(l)) {
 					t.Errorf("expected %v, received %v", exp, l)
 				}
 			})
@@ -223,6 +260,128 @@ func TestQueries(t *testing.T) {
 			})
 		})
 
+		t.Run("Webhook delivery queries", func(t *testing.T) {
+			t.Run("ListWebhookDeliveries", func(t *testing.T) {
+				l, err := r.ListWebhookDeliveries(ctx, testWebhook.ID)
+				if err != nil {
+					t.Fatalf("failed to list webhook deliveries: %s", err)
+				}
+				// newest first: book 2, book 1, author 2, author 1
+				exp := []string{
+					postgres.EventBookCreated,
+					postgres.EventBookCreated,
+					postgres.EventAuthorCreated,
+					postgres.EventAuthorCreated,
+				}
+				if len(l) != len(exp) {
+					t.Fatalf("expected length of %d, received %d", len(exp), len(l))
+				}
+				for i, d := range l {
+					if d.EventType != exp[i] || d.Status != postgres.DeliveryPending || d.Attempts != 0 {
+						t.Errorf("unexpected delivery %v", d)
+					}
+				}
+				var event struct {
+					Type string
+					Data postgres.BookPayload
+				}
+				if err := json.Unmarshal(l[0].Payload, &event); err != nil {
+					t.Fatalf("failed to decode payload: %s", err)
+				}
+				expData := postgres.BookPayload{
+					ID:          testBook2.ID,
+					Title:       testBook2.Title,
+					Description: testBook2.Description,
+					Cover:       testBook2.Cover,
+					AuthorIDs:   []int64{testAuthor2.ID},
+				}
+				if event.Type != postgres.EventBookCreated || !reflect.DeepEqual(expData, event.Data) {
+					t.Errorf("expected %v, received %v", expData, event)
+				}
+			})
+
+			t.Run("ClaimWebhookDeliveries", func(t *testing.T) {
+				l, err := r.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+					LeaseUntil: time.Now().Add(time.Minute),
+					BatchSize:  3,
+				})
+				if err != nil {
+					t.Fatalf("failed to claim webhook deliveries: %s", err)
+				}
+				if len(l) != 3 {
+					t.Fatalf("expected length of 3, received %d", len(l))
+				}
+				for _, d := range l {
+					if d.Attempts != 1 || d.Url != testWebhook.Url || d.Secret != testWebhook.Secret {
+						t.Errorf("unexpected claimed delivery %v", d)
+					}
+				}
+				// the remaining delivery is still due, the claimed ones are leased
+				l2, err := r.ClaimWebhookDeliveries(ctx, sqlc.ClaimWebhookDeliveriesParams{
+					LeaseUntil: time.Now().Add(time.Minute),
+					BatchSize:  3,
+				})
+				if err != nil {
+					t.Fatalf("failed to claim webhook deliveries: %s", err)
+				}
+				if len(l2) != 1 {
+					t.Fatalf("expected length of 1, received %d", len(l2))
+				}
+				err = r.CompleteWebhookDelivery(ctx, sqlc.CompleteWebhookDeliveryParams{
+					ID:             l[0].ID,
+					ResponseStatus: sql.NullInt32{Int32: 200, Valid: true},
+				})
+				if err != nil {
+					t.Fatalf("failed to complete webhook delivery: %s", err)
+				}
+				err = r.FailWebhookDelivery(ctx, sqlc.FailWebhookDeliveryParams{
+					ID:            l[1].ID,
+					Status:        postgres.DeliveryDead,
+					LastError:     sql.NullString{String: "test error", Valid: true},
+					NextAttemptAt: time.Now(),
+				})
+				if err != nil {
+					t.Fatalf("failed to fail webhook delivery: %s", err)
+				}
+			})
+
+			t.Run("ListWebhookDeliveriesByStatus", func(t *testing.T) {
+				for status, n := range map[string]int{
+					postgres.DeliveryPending:   2,
+					postgres.DeliveryDelivered: 1,
+					postgres.DeliveryDead:      1,
+				} {
+					l, err := r.ListWebhookDeliveriesByStatus(ctx, sqlc.ListWebhookDeliveriesByStatusParams{
+						WebhookID: testWebhook.ID,
+						Status:    status,
+					})
+					if err != nil {
+						t.Fatalf("failed to list webhook deliveries: %s", err)
+					}
+					if len(l) != n {
+						t.Errorf("expected %d %s deliveries, received %d", n, status, len(l))
+					}
+				}
+			})
+
+			t.Run("RetryWebhookDelivery", func(t *testing.T) {
+				l, err := r.ListWebhookDeliveriesByStatus(ctx, sqlc.ListWebhookDeliveriesByStatusParams{
+					WebhookID: testWebhook.ID,
+					Status:    postgres.DeliveryDead,
+				})
+				if err != nil || len(l) != 1 {
+					t.Fatalf("failed to list dead webhook deliveries: %v", err)
+				}
+				d, err := r.RetryWebhookDelivery(ctx, l[0].ID)
+				if err != nil {
+					t.Fatalf("failed to retry webhook delivery: %s", err)
+				}
+				if d.Status != postgres.DeliveryPending || d.Attempts != 0 || d.LastError.Valid {
+					t.Errorf("unexpected delivery %v", d)
+				}
+			})
+		})
+
 		t.Run("Update queries", func(t *testing.T) {
 			t.Run("UpdateAgent", func(t *testing.T) {
 				a, err := r.UpdateAgent(ctx, sqlc.UpdateAgentParams{
@@ -234,24 +393,34 @@ func TestQueries(t *testing.T) {
 					t.Fatalf("failed to update agent: %s", err)
 				}
 				testAgentUpdated.ID = a.ID
+				testAgentUpdated.UpdatedAt = a.UpdatedAt
+				if !a.UpdatedAt.After(testAgent2.UpdatedAt) {
+					t.Errorf("expected updated_at to move past %s, received %s", testAgent2.UpdatedAt, a.UpdatedAt)
+				}
 				if !reflect.DeepEqual(testAgentUpdated, a) {
 					t.Errorf("expected %v, received %v", testAgentUpdated, a)
 				}
 			})
 
 			t.Run("UpdateAuthor", func(t *testing.T) {
-				a, err := r.UpdateAuthor(ctx, sqlc.UpdateAuthorParams{
-					ID:      testAuthor2.ID,
-					Name:    testAuthorUpdated.Name,
-					Website: testAuthorUpdated.Website,
+				a, err := r.UpdateAuthor(ctx, postgres.UpdateAuthorParams{
+					UpdateAuthorParams: sqlc.UpdateAuthorParams{
+						ID:      testAuthor2.ID,
+						Name:    testAuthorUpdated.Name,
+						Website: testAuthorUpdated.Website,
+					},
 					AgentID: testAgent1.ID,
 				})
 				if err != nil {
 					t.Fatal("failed to update agent")
 				}
 				testAuthorUpdated.ID = a.ID
+				testAuthorUpdated.UpdatedAt = a.UpdatedAt
+				if !a.UpdatedAt.After(testAuthor2.UpdatedAt) {
+					t.Errorf("expected updated_at to move past %s, received %s", testAuthor2.UpdatedAt, a.UpdatedAt)
+				}
 				testAuthorUpdated.AgentID = testAgent1.ID
-				if !reflect.DeepEqual(testAuthorUpdated, a) {
+				if !reflect.DeepEqual(&testAuthorUpdated, a) {
 					t.Errorf("expected %v, received %v", testAuthorUpdated, a)
 				}
 			})
@@ -262,11 +431,15 @@ func TestQueries(t *testing.T) {
 					Title:       testBookUpdated.Title,
 					Description: testBookUpdated.Description,
 					Cover:       testBookUpdated.Cover,
-				}, []int64{testAuthor1.ID})
+				}, credits(testAuthor1.ID), nil, nil)
 				if err != nil {
 					t.Fatalf("failed to update book: %s", err)
 				}
 				testBookUpdated.ID = b.ID
+				testBookUpdated.UpdatedAt = b.UpdatedAt
+				if !b.UpdatedAt.After(testBook1.UpdatedAt) {
+					t.Errorf("expected updated_at to move past %s, received %s", testBook1.UpdatedAt, b.UpdatedAt)
+				}
 				if !reflect.DeepEqual(&testBookUpdated, b) {
 					t.Errorf("expected %v, received %v", testBookUpdated, b)
 				}
@@ -274,8 +447,8 @@ func TestQueries(t *testing.T) {
 				if err != nil {
 					t.Fatalf("failed to list authors by book id: %s", err)
 				}
-				exp := []sqlc.Author{testAuthor1}
-				if !reflect.DeepEqual(exp, l) {
+				exp := []postgres.Author{testAuthor1}
+				if !reflect.DeepEqual(exp, toAuthors(l)) {
 					t.Errorf("expected %v, received %v", exp, l)
 				}
 			})
@@ -297,7 +470,7 @@ func TestQueries(t *testing.T) {
 				if err != nil {
 					t.Fatalf("failed to get author: %s", err)
 				}
-				if !reflect.DeepEqual(testAuthorUpdated, a) {
+				if !reflect.DeepEqual(testAuthorUpdated, postgres.Author(a)) {
 					t.Errorf("expected %v, received %v", testAuthorUpdated, a)
 				}
 			})
@@ -314,11 +487,26 @@ func TestQueries(t *testing.T) {
 		})
 
 		t.Run("Delete queries", func(t *testing.T) {
-			t.Run("DeleteAgent ForeignKey Constraint", func(t *testing.T) {
-				_, err := r.DeleteAgent(ctx, testAgent1.ID)
-				expError := `pq: update or delete on table "agents" violates foreign key constraint "authors_agent_id_fkey" on table "authors"`
-				if err.Error() != expError {
-					t.Fatalf("expected %s message, received %s", expError, err)
+			t.Run("DeleteAgent with authors", func(t *testing.T) {
+				_, err := r.DeleteAgent(ctx, testAgent1.ID, nil)
+				var agentErr *postgres.AgentHasAuthorsError
+				if !errors.As(err, &agentErr) {
+					t.Fatalf("expected AgentHasAuthorsError, received %v", err)
+				}
+				exp := []int64{testAuthor1.ID, testAuthorUpdated.ID}
+				ids := make([]int64, 0, len(agentErr.Authors))
+				for _, author := range agentErr.Authors {
+					ids = append(ids, author.ID)
+				}
+				if agentErr.AgentID != testAgent1.ID || !reflect.DeepEqual(exp, ids) {
+					t.Errorf("expected authors %v, received %v", exp, ids)
+				}
+			})
+
+			t.Run("DeleteAgent reassigning to itself", func(t *testing.T) {
+				_, err := r.DeleteAgent(ctx, testAgent1.ID, &testAgent1.ID)
+				if err == nil {
+					t.Fatal("expected an error, received nil")
 				}
 			})
 
@@ -327,7 +515,7 @@ func TestQueries(t *testing.T) {
 				if err != nil {
 					t.Fatalf("failed to delete book: %s", err)
 				}
-				if !reflect.DeepEqual(testBook2, b) {
+				if !reflect.DeepEqual(&testBook2, b) {
 					t.Errorf("expected %v, received %v", testBook2, b)
 				}
 				l1, err := r.ListAuthorsByBookID(ctx, testBook2.ID)
@@ -346,12 +534,43 @@ func TestQueries(t *testing.T) {
 				}
 			})
 
+			t.Run("DeleteAuthor orphaning books", func(t *testing.T) {
+				_, err := r.DeleteAuthor(ctx, testAuthor1.ID, postgres.OrphanedBooksFail)
+				var orphanedErr *postgres.BooksWouldBeOrphanedError
+				if !errors.As(err, &orphanedErr) {
+					t.Fatalf("expected BooksWouldBeOrphanedError, received %v", err)
+				}
+				if len(orphanedErr.Books) != 1 || orphanedErr.Books[0].ID != testBookUpdated.ID {
+					t.Errorf("expected book %d, received %v", testBookUpdated.ID, orphanedErr.Books)
+				}
+			})
+
+			t.Run("UpdateBook without authors", func(t *testing.T) {
+				_, err := r.UpdateBook(ctx, sqlc.UpdateBookParams{
+					ID:          testBookUpdated.ID,
+					Title:       testBookUpdated.Title,
+					Description: testBookUpdated.Description,
+					Cover:       testBookUpdated.Cover,
+				}, credits(), nil, nil)
+				if err == nil {
+					t.Fatalf("expected an error, received nil")
+				}
+				l, err := r.ListAuthorsByBookID(ctx, testBookUpdated.ID)
+				if err != nil {
+					t.Fatalf("failed to list authors by book id: %s", err)
+				}
+				exp := []postgres.Author{testAuthor1}
+				if !reflect.DeepEqual(exp, toAuthors(l)) {
+					t.Errorf("expected %v, received %v", exp, l)
+				}
+			})
+
 			t.Run("DeleteAuthor", func(t *testing.T) {
-				a, err := r.DeleteAuthor(ctx, testAuthor1.ID)
+				a, err := r.DeleteAuthor(ctx, testAuthor1.ID, postgres.OrphanedBooksKeep)
 				if err != nil {
 					t.Fatalf("failed to delete author: %s", err)
 				}
-				if !reflect.DeepEqual(testAuthor1, a) {
+				if !reflect.DeepEqual(&testAuthor1, a) {
 					t.Errorf("expected %v, received %v", testAuthor1, a)
 				}
 				l, err := r.ListAuthors(ctx)
@@ -361,15 +580,30 @@ func TestQueries(t *testing.T) {
 				if len(l) != 1 {
 					t.Errorf("expected length of 1, received %d", len(l))
 				}
+				l2, err := r.ListOrphanBooks(ctx)
+				if err != nil {
+					t.Fatalf("failed to list orphan books: %s", err)
+				}
+				exp := []sqlc.Book{testBookUpdated}
+				if !reflect.DeepEqual(exp, l2) {
+					t.Errorf("expected %v, received %v", exp, l2)
+				}
 			})
 
 			t.Run("DeleteAgent", func(t *testing.T) {
-				a, err := r.DeleteAgent(ctx, testAgentUpdated.ID)
+				a, err := r.DeleteAgent(ctx, testAgent1.ID, &testAgentUpdated.ID)
 				if err != nil {
 					t.Fatalf("failed to delete agent: %s", err)
 				}
-				if !reflect.DeepEqual(testAgentUpdated, a) {
-					t.Errorf("expected %v, received %v", testAgentUpdated, a)
+				// the agent is archived for the representation history of
+				// its former author
+				if !a.DeletedAt.Valid {
+					t.Errorf("expected the agent to be archived, received %v", a)
+				}
+				testAgent1.UpdatedAt = a.UpdatedAt
+				testAgent1.DeletedAt = a.DeletedAt
+				if !reflect.DeepEqual(&testAgent1, a) {
+					t.Errorf("expected %v, received %v", testAgent1, a)
 				}
 				l, err := r.ListAgents(ctx)
 				if err != nil {
@@ -378,103 +612,470 @@ func TestQueries(t *testing.T) {
 				if len(l) != 1 {
 					t.Errorf("expected length of 1, received %d", len(l))
 				}
+				l2, err := r.ListAuthorsByAgentID(ctx, testAgentUpdated.ID)
+				if err != nil {
+					t.Fatalf("failed to list authors by agent id: %s", err)
+				}
+				testAuthorUpdated.AgentID = testAgentUpdated.ID
+				if len(l2) == 1 {
+					// reassigning the author bumps its updated_at
+					testAuthorUpdated.UpdatedAt = l2[0].UpdatedAt
+				}
+				exp := []postgres.Author{testAuthorUpdated}
+				if !reflect.DeepEqual(exp, toAuthors(l2)) {
+					t.Errorf("expected %v, received %v", exp, l2)
+				}
+			})
+
+			t.Run("DeleteAgent without history", func(t *testing.T) {
+				created, err := r.CreateAgent(ctx, sqlc.CreateAgentParams{
+					Name:  "test agent name 3",
+					Email: "agent3@test.com",
+				})
+				if err != nil {
+					t.Fatalf("failed to create agent: %s", err)
+				}
+				a, err := r.DeleteAgent(ctx, created.ID, nil)
+				if err != nil {
+					t.Fatalf("failed to delete agent: %s", err)
+				}
+				if !reflect.DeepEqual(created, a) {
+					t.Errorf("expected %v, received %v", created, a)
+				}
+				if _, err := r.GetAgent(ctx, created.ID); err != sql.ErrNoRows {
+					t.Errorf("expected %v, received %v", sql.ErrNoRows, err)
+				}
 			})
 		})
 	})
 }
 
-func runner(t *testing.T, test func(context.Context, *postgres.Repo, *testing.T)) {
-	ctx := context.Background()
+func TestBulkQueries(t *testing.T) {
+	t.Parallel()
+	runner(t, func(ctx context.Context, r *postgres.Repo, t *testing.T) {
+		var (
+			agents  []*sqlc.Agent
+			authors []*postgres.Author
+			books   []*sqlc.Book
+		)
 
-	db, err := sql.Open("postgres", "dbname=test_db sslmode=disable")
-	if err != nil {
-		t.Fatalf("failed to connect to the db: %s\n", err)
-	}
+		t.Run("CreateAgents", func(t *testing.T) {
+			res, err := r.CreateAgents(ctx, []sqlc.CreateAgentParams{
+				{Name: "bulk agent 1", Email: "bulk1@test.com"},
+				{Name: "bulk agent 2", Email: "bulk2@test.com"},
+			}, postgres.BulkAllOrNothing)
+			if err != nil {
+				t.Fatalf("failed to create agents: %s", err)
+			}
+			if !res.Committed {
+				t.Errorf("expected the agents to be committed")
+			}
+			agents = res.Agents
+			if len(agents) != 2 || agents[0].Name != "bulk agent 1" || agents[1].Name != "bulk agent 2" {
+				t.Fatalf("wrong agents: %v", agents)
+			}
+			if !reflect.DeepEqual([]error{nil, nil}, res.Errors) {
+				t.Errorf("expected no errors, received %v", res.Errors)
+			}
+		})
+
+		authorArgs := func() []postgres.CreateAuthorParams {
+			return []postgres.CreateAuthorParams{
+				{CreateAuthorParams: sqlc.CreateAuthorParams{Name: "bulk author 1"}, AgentID: agents[0].ID},
+				{CreateAuthorParams: sqlc.CreateAuthorParams{Name: "bulk author 2"}, AgentID: -1},
+				{CreateAuthorParams: sqlc.CreateAuthorParams{Name: "bulk author 3", Website: sql.NullString{String: "https://a3.com", Valid: true}}, AgentID: agents[1].ID},
+			}
+		}
+
+		t.Run("CreateAuthors all or nothing", func(t *testing.T) {
+			res, err := r.CreateAuthors(ctx, authorArgs(), postgres.BulkAllOrNothing)
+			if err != nil {
+				t.Fatalf("failed to create authors: %s", err)
+			}
+			if res.Committed {
+				t.Errorf("expected the authors to be rolled back")
+			}
+			if res.Errors[0] != nil || res.Errors[1] == nil || res.Errors[2] != nil {
+				t.Errorf("expected an error for the second author only, received %v", res.Errors)
+			}
+			if !reflect.DeepEqual([]*postgres.Author{nil, nil, nil}, res.Authors) {
+				t.Errorf("expected no authors, received %v", res.Authors)
+			}
+			l, err := r.ListAuthors(ctx)
+			if err != nil {
+				t.Fatalf("failed to list authors: %s", err)
+			}
+			if len(l) != 0 {
+				t.Errorf("expected length of 0, received %d", len(l))
+			}
+		})
+
+		t.Run("CreateAuthors best effort", func(t *testing.T) {
+			res, err := r.CreateAuthors(ctx, authorArgs(), postgres.BulkBestEffort)
+			if err != nil {
+				t.Fatalf("failed to create authors: %s", err)
+			}
+			if !res.Committed {
+				t.Errorf("expected the authors to be committed")
+			}
+			if res.Errors[0] != nil || res.Errors[1] == nil || res.Errors[2] != nil {
+				t.Errorf("expected an error for the second author only, received %v", res.Errors)
+			}
+			authors = res.Authors
+			if authors[0] == nil || authors[1] != nil || authors[2] == nil {
+				t.Fatalf("wrong authors: %v", authors)
+			}
+			if authors[2].Website.String != "https://a3.com" || authors[0].Website.Valid {
+				t.Errorf("wrong websites: %v, %v", authors[0].Website, authors[2].Website)
+			}
+			l, err := r.ListAuthors(ctx)
+			if err != nil {
+				t.Fatalf("failed to list authors: %s", err)
+			}
+			if len(l) != 2 {
+				t.Errorf("expected length of 2, received %d", len(l))
+			}
+		})
+
+		t.Run("CreateBooks", func(t *testing.T) {
+			res, err := r.CreateBooks(ctx, []postgres.BulkCreateBookArgs{
+				{Book: sqlc.CreateBookParams{Title: "bulk book 1"}, Contributors: credits(authors[0].ID, authors[2].ID)},
+				{Book: sqlc.CreateBookParams{Title: "bulk book 2"}},
+				{Book: sqlc.CreateBookParams{Title: "bulk book 3"}, Contributors: credits(authors[2].ID)},
+			}, postgres.BulkBestEffort)
+			if err != nil {
+				t.Fatalf("failed to create books: %s", err)
+			}
+			if !errors.Is(res.Errors[1], postgres.ErrBookWithoutAuthors) {
+				t.Errorf("expected ErrBookWithoutAuthors, received %v", res.Errors[1])
+			}
+			books = res.Books
+			if books[0] == nil || books[1] != nil || books[2] == nil {
+				t.Fatalf("wrong books: %v", books)
+			}
+			l, err := r.ListAuthorsByBookID(ctx, books[0].ID)
+			if err != nil {
+				t.Fatalf("failed to list authors by book id: %s", err)
+			}
+			exp := []postgres.Author{*authors[0], *authors[2]}
+			if !reflect.DeepEqual(exp, toAuthors(l)) {
+				t.Errorf("expected %v, received %v", exp, l)
+			}
+		})
 
-	repo := postgres.NewRepo(db)
+		t.Run("UpdateBooks with a missing book", func(t *testing.T) {
+			res, err := r.UpdateBooks(ctx, []postgres.BulkUpdateBookArgs{
+				{Book: sqlc.UpdateBookParams{ID: books[0].ID, Title: "bulk book 1 updated"}, Contributors: credits(authors[0].ID)},
+				{Book: sqlc.UpdateBookParams{ID: -1, Title: "missing"}, Contributors: credits(authors[0].ID)},
+			}, postgres.BulkAllOrNothing)
+			if err != nil {
+				t.Fatalf("failed to update books: %s", err)
+			}
+			if res.Committed {
+				t.Errorf("expected the books to be rolled back")
+			}
+			if res.Errors[0] != nil || !errors.Is(res.Errors[1], sql.ErrNoRows) {
+				t.Errorf("expected sql.ErrNoRows for the second book only, received %v", res.Errors)
+			}
+			b, err := r.GetBook(ctx, books[0].ID)
+			if err != nil {
+				t.Fatalf("failed to get book: %s", err)
+			}
+			if !reflect.DeepEqual(*books[0], b) {
+				t.Errorf("expected %v, received %v", *books[0], b)
+			}
+		})
+
+		t.Run("UpdateBooks", func(t *testing.T) {
+			res, err := r.UpdateBooks(ctx, []postgres.BulkUpdateBookArgs{
+				{Book: sqlc.UpdateBookParams{ID: books[2].ID, Title: "bulk book 3 updated"}, Contributors: credits(authors[0].ID)},
+				{Book: sqlc.UpdateBookParams{ID: books[0].ID, Title: "bulk book 1 updated"}, Contributors: credits(authors[2].ID)},
+			}, postgres.BulkAllOrNothing)
+			if err != nil {
+				t.Fatalf("failed to update books: %s", err)
+			}
+			if !res.Committed {
+				t.Errorf("expected the books to be committed")
+			}
+			if len(res.Books) != 2 || res.Books[0].ID != books[2].ID || res.Books[1].ID != books[0].ID {
+				t.Fatalf("expected books %d and %d, received %v", books[2].ID, books[0].ID, res.Books)
+			}
+			if res.Books[0].Title != "bulk book 3 updated" || res.Books[1].Title != "bulk book 1 updated" {
+				t.Errorf("wrong titles: %v", res.Books)
+			}
+			l, err := r.ListBooksByAuthorID(ctx, authors[2].ID)
+			if err != nil {
+				t.Fatalf("failed to list books by author id: %s", err)
+			}
+			if len(l) != 1 || l[0].ID != books[0].ID {
+				t.Errorf("expected only book %d, received %v", books[0].ID, l)
+			}
+		})
+	})
+}
 
-	// create and drop schema (defer)
-	defer func() {
-		if err := dropSchema(ctx, db); err != nil {
-			t.Fatalf("failed to drop queue schema: %s\n", err)
+func TestImportQueries(t *testing.T) {
+	t.Parallel()
+	dbRunner(t, func(ctx context.Context, db *sql.DB, t *testing.T) {
+		r := postgres.NewRepo(db)
+		imp := postgres.NewImporter(db)
+
+		agents := []postgres.AgentImportRow{
+			{Line: 2, Name: "import agent 1", Email: "import1@test.com"},
+			{Line: 3, Name: "import agent 2", Email: "import2@test.com"},
+			{Line: 4, Name: "import agent 1 again", Email: "import1@test.com"},
 		}
-	}()
-	if err := createSchema(ctx, db); err != nil {
-		t.Fatalf("failed to create queue schema: %s\n", err)
-	}
 
-	test(ctx, repo, t)
+		t.Run("ImportAgents dry run", func(t *testing.T) {
+			s, err := imp.ImportAgents(ctx, agents, true)
+			if err != nil {
+				t.Fatalf("failed to import agents: %s", err)
+			}
+			exp := []postgres.ImportChange{
+				{Line: 2, Action: postgres.ImportInsert, Key: "import1@test.com"},
+				{Line: 3, Action: postgres.ImportInsert, Key: "import2@test.com"},
+			}
+			if !reflect.DeepEqual(exp, s.Changes) {
+				t.Errorf("expected %v, received %v", exp, s.Changes)
+			}
+			if len(s.Errors) != 1 || s.Errors[0].Error() != "line 4: duplicate of line 2" {
+				t.Errorf("wrong errors: %v", s.Errors)
+			}
+			l, err := r.ListAgents(ctx)
+			if err != nil {
+				t.Fatalf("failed to list agents: %s", err)
+			}
+			if len(l) != 0 {
+				t.Errorf("expected length of 0, received %d", len(l))
+			}
+		})
+
+		t.Run("ImportAgents", func(t *testing.T) {
+			s, err := imp.ImportAgents(ctx, agents[:2], false)
+			if err != nil {
+				t.Fatalf("failed to import agents: %s", err)
+			}
+			if s.Inserted != 2 || s.Updated != 0 || s.Unchanged != 0 {
+				t.Errorf("wrong summary: %v", s)
+			}
+			s, err = imp.ImportAgents(ctx, []postgres.AgentImportRow{
+				{Line: 2, Name: "import agent 1", Email: "import1@test.com"},
+				{Line: 3, Name: "import agent 2 renamed", Email: "import2@test.com"},
+			}, false)
+			if err != nil {
+				t.Fatalf("failed to import agents: %s", err)
+			}
+			if s.Inserted != 0 || s.Updated != 1 || s.Unchanged != 1 {
+				t.Errorf("wrong summary: %v", s)
+			}
+			l, err := r.ListAgents(ctx)
+			if err != nil {
+				t.Fatalf("failed to list agents: %s", err)
+			}
+			if len(l) != 2 || l[1].Name != "import agent 2 renamed" {
+				t.Errorf("wrong agents: %v", l)
+			}
+		})
+
+		t.Run("ImportAuthors", func(t *testing.T) {
+			s, err := imp.ImportAuthors(ctx, []postgres.AuthorImportRow{
+				{Line: 2, Name: "import author 1", AgentEmail: "import1@test.com"},
+				{Line: 3, Name: "import author 2", AgentEmail: "unknown@test.com"},
+				{Line: 4, Name: "import author 3", Website: sql.NullString{String: "https://a3.com", Valid: true}, AgentEmail: "import2@test.com"},
+			}, false)
+			if err != nil {
+				t.Fatalf("failed to import authors: %s", err)
+			}
+			if s.Inserted != 2 {
+				t.Errorf("expected 2 inserted authors, received %d", s.Inserted)
+			}
+			if len(s.Errors) != 1 || s.Errors[0].Error() != "line 3: unknown agent email unknown@test.com" {
+				t.Errorf("wrong errors: %v", s.Errors)
+			}
+			l, err := r.ListAuthors(ctx)
+			if err != nil {
+				t.Fatalf("failed to list authors: %s", err)
+			}
+			if len(l) != 2 {
+				t.Errorf("expected length of 2, received %d", len(l))
+			}
+		})
+
+		t.Run("ImportBooks", func(t *testing.T) {
+			rows := []postgres.BookImportRow{
+				{Line: 2, Title: "import book 1", Description: "d", Cover: "c", AuthorNames: []string{"import author 1", "import author 3"}},
+				{Line: 3, Title: "import book 2", Description: "d", Cover: "c", AuthorNames: []string{"import author 2"}},
+			}
+			s, err := imp.ImportBooks(ctx, rows, false)
+			if err != nil {
+				t.Fatalf("failed to import books: %s", err)
+			}
+			if s.Inserted != 1 {
+				t.Errorf("expected 1 inserted book, received %d", s.Inserted)
+			}
+			if len(s.Errors) != 1 || s.Errors[0].Error() != "line 3: unknown author import author 2" {
+				t.Errorf("wrong errors: %v", s.Errors)
+			}
+			rows[0].AuthorNames = []string{"import author 3"}
+			s, err = imp.ImportBooks(ctx, rows[:1], false)
+			if err != nil {
+				t.Fatalf("failed to import books: %s", err)
+			}
+			if s.Updated != 1 {
+				t.Errorf("expected 1 updated book, received %d", s.Updated)
+			}
+			l, err := r.ListBooks(ctx)
+			if err != nil {
+				t.Fatalf("failed to list books: %s", err)
+			}
+			if len(l) != 1 {
+				t.Fatalf("expected length of 1, received %d", len(l))
+			}
+			a, err := r.ListAuthorsByBookID(ctx, l[0].ID)
+			if err != nil {
+				t.Fatalf("failed to list authors by book id: %s", err)
+			}
+			if len(a) != 1 || a[0].Name != "import author 3" {
+				t.Errorf("wrong authors: %v", a)
+			}
+		})
+	})
 }
 
-func createSchema(ctx context.Context, db *sql.DB) error {
-	tx, err := db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	_, err = tx.ExecContext(ctx, `
-		CREATE TABLE IF NOT EXISTS agents (
-			id BIGSERIAL PRIMARY KEY,
-			name TEXT NOT NULL,
-			email TEXT NOT NULL
-		);
-	`)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-	_, err = tx.ExecContext(ctx, `
-		CREATE TABLE IF NOT EXISTS authors (
-			id BIGSERIAL PRIMARY KEY,
-			name TEXT NOT NULL,
-			website TEXT,
-			agent_id BIGINT NOT NULL,
-			FOREIGN KEY (agent_id) REFERENCES agents(id) 
-		);
-	`)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-	_, err = tx.ExecContext(ctx, `
-		CREATE TABLE IF NOT EXISTS books (
-			id BIGSERIAL PRIMARY KEY,
-			title TEXT NOT NULL,
-			description TEXT NOT NULL,
-			cover TEXT NOT NULL
-		);
-	`)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-	_, err = tx.ExecContext(ctx, `
-		CREATE TABLE IF NOT EXISTS book_authors (
-			id BIGSERIAL PRIMARY KEY,
-			book_id BIGINT NOT NULL,
-			author_id BIGINT NOT NULL,
-			FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
-			FOREIGN KEY (author_id) REFERENCES authors(id) ON DELETE CASCADE,
-			UNIQUE (book_id,author_id)
-		);
-	`)
-	if err != nil {
-		tx.Rollback()
-		return err
-	}
-	err = tx.Commit()
-	if err != nil {
-		tx.Rollback()
-		return err
+func TestExportQueries(t *testing.T) {
+	t.Parallel()
+	dbRunner(t, func(ctx context.Context, db *sql.DB, t *testing.T) {
+		r := postgres.NewRepo(db)
+		exp := postgres.NewExporter(db)
+		exp.BatchSize = 1
+
+		ag, err := r.CreateAgent(ctx, sqlc.CreateAgentParams{Name: "export agent", Email: "export@test.com"})
+		if err != nil {
+			t.Fatalf("failed to create agent: %s", err)
+		}
+		var authorIDs []int64
+		for _, name := range []string{"export author 1", "export author 2"} {
+			a, err := r.CreateAuthor(ctx, postgres.CreateAuthorParams{CreateAuthorParams: sqlc.CreateAuthorParams{Name: name}, AgentID: ag.ID})
+			if err != nil {
+				t.Fatalf("failed to create author: %s", err)
+			}
+			authorIDs = append(authorIDs, a.ID)
+		}
+		b, err := r.CreateBook(ctx, sqlc.CreateBookParams{Title: "export book", Description: "d", Cover: "c"}, credits(authorIDs...), nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create book: %s", err)
+		}
+
+		t.Run("ExportAuthors", func(t *testing.T) {
+			var l []postgres.AuthorExport
+			err := exp.ExportAuthors(ctx, time.Time{}, func(a postgres.AuthorExport) error {
+				l = append(l, a)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to export authors: %s", err)
+			}
+			if len(l) != 2 {
+				t.Fatalf("expected length of 2, received %d", len(l))
+			}
+			if l[0].AgentID != ag.ID || l[0].AgentEmail != "export@test.com" || l[0].Website != nil {
+				t.Errorf("wrong author: %v", l[0])
+			}
+		})
+
+		t.Run("ExportBooks", func(t *testing.T) {
+			var l []postgres.BookExport
+			err := exp.ExportBooks(ctx, time.Time{}, func(b postgres.BookExport) error {
+				l = append(l, b)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to export books: %s", err)
+			}
+			if len(l) != 1 || l[0].ID != b.ID || !reflect.DeepEqual(authorIDs, l[0].AuthorIDs) {
+				t.Errorf("wrong books: %v", l)
+			}
+		})
+
+		t.Run("ExportAgents updated since", func(t *testing.T) {
+			n := 0
+			err := exp.ExportAgents(ctx, ag.UpdatedAt.Add(time.Second), func(a postgres.AgentExport) error {
+				n++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to export agents: %s", err)
+			}
+			if n != 0 {
+				t.Errorf("expected no agents, received %d", n)
+			}
+		})
+
+		t.Run("ExportBooks updated since an author change", func(t *testing.T) {
+			since := time.Now()
+			if _, err := r.DeleteAuthor(ctx, authorIDs[1], postgres.OrphanedBooksKeep); err != nil {
+				t.Fatalf("failed to delete author: %s", err)
+			}
+			var l []postgres.BookExport
+			err := exp.ExportBooks(ctx, since, func(b postgres.BookExport) error {
+				l = append(l, b)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to export books: %s", err)
+			}
+			if len(l) != 1 || !reflect.DeepEqual(authorIDs[:1], l[0].AuthorIDs) {
+				t.Errorf("expected the book to be exported without the deleted author, received %v", l)
+			}
+
+			var d []postgres.DeletionExport
+			err = exp.ExportDeletions(ctx, since, func(e postgres.DeletionExport) error {
+				d = append(d, e)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to export deletions: %s", err)
+			}
+			if len(d) != 1 || d[0].Entity != "authors" || d[0].ID != authorIDs[1] {
+				t.Errorf("expected the deleted author to be exported, received %v", d)
+			}
+		})
+	})
+}
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+	repotest.Run(t, func(t *testing.T) domain.Repository {
+		return postgres.NewAdapter(postgres.NewRepo(pgtest.NewDB(t)))
+	})
+}
+
+func runner(t *testing.T, test func(context.Context, *postgres.Repo, *testing.T)) {
+	dbRunner(t, func(ctx context.Context, db *sql.DB, t *testing.T) {
+		test(ctx, postgres.NewRepo(db), t)
+	})
+}
+
+func dbRunner(t *testing.T, test func(context.Context, *sql.DB, *testing.T)) {
+	test(context.Background(), pgtest.NewDB(t), t)
+}
+
+// toAuthors converts the rows of an author query, which all share the layout
+// of postgres.Author.
+func toAuthors(rows interface{}) []postgres.Author {
+	v := reflect.ValueOf(rows)
+	authors := make([]postgres.Author, v.Len())
+	for i := range authors {
+		authors[i] = v.Index(i).Convert(reflect.TypeOf(postgres.Author{})).Interface().(postgres.Author)
 	}
-	return nil
+	return authors
 }
 
-func dropSchema(ctx context.Context, db *sql.DB) error {
-	_, err := db.ExecContext(ctx, `
-		DROP TABLE IF EXISTS book_authors, books, authors, agents;
-	`)
-	if err != nil {
-		return err
+// credits credits the authors on a book in order in the AUTHOR role.
+func credits(authorIDs ...int64) []postgres.Contributor {
+	contributors := make([]postgres.Contributor, 0, len(authorIDs))
+	for i, id := range authorIDs {
+		contributors = append(contributors, postgres.Contributor{AuthorID: id, Role: "AUTHOR", Position: int32(i + 1)})
 	}
-	return nil
+	return contributors
 }
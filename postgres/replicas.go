@@ -0,0 +1,496 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/fwojciec/litag-example/generated/sqlc" // use your own github username
+)
+
+// Replicas spreads reads over a set of read replicas in turn, skipping the
+// replicas that failed their last health check. When no replica is healthy
+// reads fall back to the primary.
+type Replicas struct {
+	// Interval is the time between health checks.
+	Interval time.Duration
+	// Timeout bounds the ping of a single replica.
+	Timeout time.Duration
+
+	replicas []*replica
+	next     uint32
+}
+
+type replica struct {
+	db      *sql.DB
+	q       *sqlc.Queries
+	healthy int32
+}
+
+// NewReplicas returns a new instance of Replicas with default settings. All
+// replicas are considered healthy until their first health check.
+func NewReplicas(dbs ...*sql.DB) *Replicas {
+	r := &Replicas{
+		Interval: 5 * time.Second,
+		Timeout:  time.Second,
+	}
+	for _, db := range dbs {
+		r.replicas = append(r.replicas, &replica{db: db, q: sqlc.New(db), healthy: 1})
+	}
+	return r
+}
+
+// Run checks the health of the replicas until the context is cancelled.
+func (r *Replicas) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+	for {
+		r.CheckOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce pings every replica and records whether it is healthy.
+func (r *Replicas) CheckOnce(ctx context.Context) {
+	for i, rep := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+		err := rep.db.PingContext(pingCtx)
+		cancel()
+		var healthy int32
+		if err == nil {
+			healthy = 1
+		}
+		if old := atomic.SwapInt32(&rep.healthy, healthy); old != healthy && ctx.Err() == nil {
+			if err != nil {
+				log.Printf("postgres: replica %d is unhealthy: %s", i, err)
+			} else {
+				log.Printf("postgres: replica %d is healthy again", i)
+			}
+		}
+	}
+}
+
+// pick returns the next healthy replica, or nil if there is none.
+func (r *Replicas) pick() *sqlc.Queries {
+	n := uint32(len(r.replicas))
+	if n == 0 {
+		return nil
+	}
+	start := atomic.AddUint32(&r.next, 1)
+	for i := uint32(0); i < n; i++ {
+		rep := r.replicas[(start+i)%n]
+		if atomic.LoadInt32(&rep.healthy) == 1 {
+			return rep.q
+		}
+	}
+	return nil
+}
+
+type pinKey struct{}
+
+// ReadYourWrites returns a context in which reads go to the primary once a
+// write has been performed with it, so that a request sees its own changes
+// regardless of replication lag.
+func ReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pinKey{}, new(int32))
+}
+
+// ReadFromPrimary returns a context in which reads go to the primary, for
+// reads that must not see replication lag.
+func ReadFromPrimary(ctx context.Context) context.Context {
+	pin := int32(1)
+	return context.WithValue(ctx, pinKey{}, &pin)
+}
+
+// pinToPrimary records a write in a context created by ReadYourWrites.
+func pinToPrimary(ctx context.Context) {
+	if pin, ok := ctx.Value(pinKey{}).(*int32); ok {
+		atomic.StoreInt32(pin, 1)
+	}
+}
+
+func pinnedToPrimary(ctx context.Context) bool {
+	pin, ok := ctx.Value(pinKey{}).(*int32)
+	return ok && atomic.LoadInt32(pin) == 1
+}
+
+// routedQuerent is a Querent that sends reads to the replicas and everything
+// else to the primary.
+type routedQuerent struct {
+	*sqlc.Queries
+	replicas *Replicas
+}
+
+func (q *routedQuerent) reader(ctx context.Context) *sqlc.Queries {
+	if pinnedToPrimary(ctx) {
+		return q.Queries
+	}
+	if r := q.replicas.pick(); r != nil {
+		return r
+	}
+	return q.Queries
+}
+
+func (q *routedQuerent) writer(ctx context.Context) *sqlc.Queries {
+	pinToPrimary(ctx)
+	return q.Queries
+}
+
+// agency queries
+
+func (q *routedQuerent) CreateAgency(ctx context.Context, name string) (sqlc.Agency, error) {
+	return q.writer(ctx).CreateAgency(ctx, name)
+}
+
+func (q *routedQuerent) DeleteAgency(ctx context.Context, id int64) (sqlc.Agency, error) {
+	return q.writer(ctx).DeleteAgency(ctx, id)
+}
+
+func (q *routedQuerent) GetAgency(ctx context.Context, id int64) (sqlc.Agency, error) {
+	return q.reader(ctx).GetAgency(ctx, id)
+}
+
+func (q *routedQuerent) ListAgencies(ctx context.Context) ([]sqlc.Agency, error) {
+	return q.reader(ctx).ListAgencies(ctx)
+}
+
+func (q *routedQuerent) UpdateAgency(ctx context.Context, args sqlc.UpdateAgencyParams) (sqlc.Agency, error) {
+	return q.writer(ctx).UpdateAgency(ctx, args)
+}
+
+// agent queries
+
+func (q *routedQuerent) CreateAgent(ctx context.Context, args sqlc.CreateAgentParams) (sqlc.Agent, error) {
+	return q.writer(ctx).CreateAgent(ctx, args)
+}
+
+func (q *routedQuerent) GetAgent(ctx context.Context, id int64) (sqlc.Agent, error) {
+	return q.reader(ctx).GetAgent(ctx, id)
+}
+
+func (q *routedQuerent) ListAgents(ctx context.Context) ([]sqlc.Agent, error) {
+	return q.reader(ctx).ListAgents(ctx)
+}
+
+func (q *routedQuerent) ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.Agent, error) {
+	return q.reader(ctx).ListAgentsByAgencyID(ctx, agencyID)
+}
+
+func (q *routedQuerent) UpdateAgent(ctx context.Context, args sqlc.UpdateAgentParams) (sqlc.Agent, error) {
+	return q.writer(ctx).UpdateAgent(ctx, args)
+}
+
+// author queries
+
+func (q *routedQuerent) GetAuthor(ctx context.Context, id int64) (sqlc.GetAuthorRow, error) {
+	return q.reader(ctx).GetAuthor(ctx, id)
+}
+
+func (q *routedQuerent) ListAuthors(ctx context.Context) ([]sqlc.ListAuthorsRow, error) {
+	return q.reader(ctx).ListAuthors(ctx)
+}
+
+func (q *routedQuerent) ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.ListAuthorsByAgencyIDRow, error) {
+	return q.reader(ctx).ListAuthorsByAgencyID(ctx, agencyID)
+}
+
+func (q *routedQuerent) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.ListAuthorsByAgentIDRow, error) {
+	return q.reader(ctx).ListAuthorsByAgentID(ctx, agentID)
+}
+
+func (q *routedQuerent) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]sqlc.ListAuthorsByBookIDRow, error) {
+	return q.reader(ctx).ListAuthorsByBookID(ctx, bookID)
+}
+
+func (q *routedQuerent) ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.ListFormerAuthorsByAgentIDRow, error) {
+	return q.reader(ctx).ListFormerAuthorsByAgentID(ctx, agentID)
+}
+
+// book queries
+
+func (q *routedQuerent) GetBook(ctx context.Context, id int64) (sqlc.Book, error) {
+	return q.reader(ctx).GetBook(ctx, id)
+}
+
+func (q *routedQuerent) GetBookByISBN(ctx context.Context, isbn string) (sqlc.Book, error) {
+	return q.reader(ctx).GetBookByISBN(ctx, isbn)
+}
+
+func (q *routedQuerent) ListBooks(ctx context.Context) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListBooks(ctx)
+}
+
+func (q *routedQuerent) ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListBooksByAgencyID(ctx, agencyID)
+}
+
+func (q *routedQuerent) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListBooksByAuthorID(ctx, authorID)
+}
+
+func (q *routedQuerent) ListBooksByGenreID(ctx context.Context, genreID int64) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListBooksByGenreID(ctx, genreID)
+}
+
+func (q *routedQuerent) ListBooksInGenreTree(ctx context.Context, genreID int64) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListBooksInGenreTree(ctx, genreID)
+}
+
+func (q *routedQuerent) ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListBooksByPublisherID(ctx, publisherID)
+}
+
+func (q *routedQuerent) ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListBooksBySeriesID(ctx, seriesID)
+}
+
+func (q *routedQuerent) ListBooksByTag(ctx context.Context, tag string) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListBooksByTag(ctx, tag)
+}
+
+func (q *routedQuerent) ListContributorsByBookID(ctx context.Context, bookID int64) ([]sqlc.BookAuthor, error) {
+	return q.reader(ctx).ListContributorsByBookID(ctx, bookID)
+}
+
+func (q *routedQuerent) ListOrphanBooks(ctx context.Context) ([]sqlc.Book, error) {
+	return q.reader(ctx).ListOrphanBooks(ctx)
+}
+
+func (q *routedQuerent) ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error) {
+	return q.reader(ctx).ListTagsByBookID(ctx, bookID)
+}
+
+// deal queries
+
+func (q *routedQuerent) CreateDeal(ctx context.Context, args sqlc.CreateDealParams) (sqlc.Deal, error) {
+	return q.writer(ctx).CreateDeal(ctx, args)
+}
+
+func (q *routedQuerent) DeleteDeal(ctx context.Context, id int64) (sqlc.Deal, error) {
+	return q.writer(ctx).DeleteDeal(ctx, id)
+}
+
+func (q *routedQuerent) GetDeal(ctx context.Context, id int64) (sqlc.Deal, error) {
+	return q.reader(ctx).GetDeal(ctx, id)
+}
+
+func (q *routedQuerent) ListAvailableRights(ctx context.Context, args sqlc.ListAvailableRightsParams) ([]sqlc.ListAvailableRightsRow, error) {
+	return q.reader(ctx).ListAvailableRights(ctx, args)
+}
+
+func (q *routedQuerent) ListDealsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Deal, error) {
+	return q.reader(ctx).ListDealsByAgentID(ctx, agentID)
+}
+
+func (q *routedQuerent) ListDealsByBookID(ctx context.Context, bookID int64) ([]sqlc.Deal, error) {
+	return q.reader(ctx).ListDealsByBookID(ctx, bookID)
+}
+
+func (q *routedQuerent) UpdateDeal(ctx context.Context, args sqlc.UpdateDealParams) (sqlc.Deal, error) {
+	return q.writer(ctx).UpdateDeal(ctx, args)
+}
+
+// edition queries
+
+func (q *routedQuerent) CreateEdition(ctx context.Context, args sqlc.CreateEditionParams) (sqlc.Edition, error) {
+	return q.writer(ctx).CreateEdition(ctx, args)
+}
+
+func (q *routedQuerent) DeleteEdition(ctx context.Context, id int64) (sqlc.Edition, error) {
+	return q.writer(ctx).DeleteEdition(ctx, id)
+}
+
+func (q *routedQuerent) GetEdition(ctx context.Context, id int64) (sqlc.Edition, error) {
+	return q.reader(ctx).GetEdition(ctx, id)
+}
+
+func (q *routedQuerent) GetEditionByISBN(ctx context.Context, isbn string) (sqlc.Edition, error) {
+	return q.reader(ctx).GetEditionByISBN(ctx, isbn)
+}
+
+func (q *routedQuerent) ListEditions(ctx context.Context, args sqlc.ListEditionsParams) ([]sqlc.Edition, error) {
+	return q.reader(ctx).ListEditions(ctx, args)
+}
+
+func (q *routedQuerent) ListEditionsByBookID(ctx context.Context, bookID int64) ([]sqlc.Edition, error) {
+	return q.reader(ctx).ListEditionsByBookID(ctx, bookID)
+}
+
+func (q *routedQuerent) UpdateEdition(ctx context.Context, args sqlc.UpdateEditionParams) (sqlc.Edition, error) {
+	return q.writer(ctx).UpdateEdition(ctx, args)
+}
+
+// genre queries
+
+func (q *routedQuerent) CreateGenre(ctx context.Context, args sqlc.CreateGenreParams) (sqlc.Genre, error) {
+	return q.writer(ctx).CreateGenre(ctx, args)
+}
+
+func (q *routedQuerent) DeleteGenre(ctx context.Context, id int64) (sqlc.Genre, error) {
+	return q.writer(ctx).DeleteGenre(ctx, id)
+}
+
+func (q *routedQuerent) GetGenre(ctx context.Context, id int64) (sqlc.Genre, error) {
+	return q.reader(ctx).GetGenre(ctx, id)
+}
+
+func (q *routedQuerent) ListGenres(ctx context.Context) ([]sqlc.Genre, error) {
+	return q.reader(ctx).ListGenres(ctx)
+}
+
+func (q *routedQuerent) ListGenresByBookID(ctx context.Context, bookID int64) ([]sqlc.Genre, error) {
+	return q.reader(ctx).ListGenresByBookID(ctx, bookID)
+}
+
+func (q *routedQuerent) ListGenresByParentID(ctx context.Context, parentID int64) ([]sqlc.Genre, error) {
+	return q.reader(ctx).ListGenresByParentID(ctx, parentID)
+}
+
+// publisher queries
+
+func (q *routedQuerent) CreatePublisher(ctx context.Context, name string) (sqlc.Publisher, error) {
+	return q.writer(ctx).CreatePublisher(ctx, name)
+}
+
+func (q *routedQuerent) DeletePublisher(ctx context.Context, id int64) (sqlc.Publisher, error) {
+	return q.writer(ctx).DeletePublisher(ctx, id)
+}
+
+func (q *routedQuerent) GetPublisher(ctx context.Context, id int64) (sqlc.Publisher, error) {
+	return q.reader(ctx).GetPublisher(ctx, id)
+}
+
+func (q *routedQuerent) ListPublishers(ctx context.Context) ([]sqlc.Publisher, error) {
+	return q.reader(ctx).ListPublishers(ctx)
+}
+
+func (q *routedQuerent) UpdatePublisher(ctx context.Context, args sqlc.UpdatePublisherParams) (sqlc.Publisher, error) {
+	return q.writer(ctx).UpdatePublisher(ctx, args)
+}
+
+// representation queries
+
+func (q *routedQuerent) CreateRepresentation(ctx context.Context, args sqlc.CreateRepresentationParams) (sqlc.Representation, error) {
+	return q.writer(ctx).CreateRepresentation(ctx, args)
+}
+
+func (q *routedQuerent) GetRepresentation(ctx context.Context, id int64) (sqlc.Representation, error) {
+	return q.reader(ctx).GetRepresentation(ctx, id)
+}
+
+func (q *routedQuerent) ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Representation, error) {
+	return q.reader(ctx).ListRepresentationsByAuthorID(ctx, authorID)
+}
+
+// royalty queries
+
+func (q *routedQuerent) DeleteRoyaltyRate(ctx context.Context, args sqlc.DeleteRoyaltyRateParams) (sqlc.RoyaltyRate, error) {
+	return q.writer(ctx).DeleteRoyaltyRate(ctx, args)
+}
+
+func (q *routedQuerent) DeleteSales(ctx context.Context, id int64) (sqlc.Sale, error) {
+	return q.writer(ctx).DeleteSales(ctx, id)
+}
+
+func (q *routedQuerent) ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]sqlc.RoyaltyRate, error) {
+	return q.reader(ctx).ListRoyaltyRatesByDealID(ctx, dealID)
+}
+
+func (q *routedQuerent) ListRoyaltySales(ctx context.Context, args sqlc.ListRoyaltySalesParams) ([]sqlc.ListRoyaltySalesRow, error) {
+	return q.reader(ctx).ListRoyaltySales(ctx, args)
+}
+
+func (q *routedQuerent) ListSalesByDealID(ctx context.Context, dealID int64) ([]sqlc.Sale, error) {
+	return q.reader(ctx).ListSalesByDealID(ctx, dealID)
+}
+
+func (q *routedQuerent) RecordSales(ctx context.Context, args sqlc.RecordSalesParams) (sqlc.Sale, error) {
+	return q.writer(ctx).RecordSales(ctx, args)
+}
+
+func (q *routedQuerent) SetRoyaltyRate(ctx context.Context, args sqlc.SetRoyaltyRateParams) (sqlc.RoyaltyRate, error) {
+	return q.writer(ctx).SetRoyaltyRate(ctx, args)
+}
+
+// series queries
+
+func (q *routedQuerent) CreateSeries(ctx context.Context, title string) (sqlc.Series, error) {
+	return q.writer(ctx).CreateSeries(ctx, title)
+}
+
+func (q *routedQuerent) GetSeries(ctx context.Context, id int64) (sqlc.Series, error) {
+	return q.reader(ctx).GetSeries(ctx, id)
+}
+
+func (q *routedQuerent) ListSeries(ctx context.Context) ([]sqlc.Series, error) {
+	return q.reader(ctx).ListSeries(ctx)
+}
+
+func (q *routedQuerent) UpdateSeries(ctx context.Context, args sqlc.UpdateSeriesParams) (sqlc.Series, error) {
+	return q.writer(ctx).UpdateSeries(ctx, args)
+}
+
+// submission queries
+
+func (q *routedQuerent) CreateSubmission(ctx context.Context, args sqlc.CreateSubmissionParams) (sqlc.Submission, error) {
+	return q.writer(ctx).CreateSubmission(ctx, args)
+}
+
+func (q *routedQuerent) GetSubmission(ctx context.Context, id int64) (sqlc.Submission, error) {
+	return q.reader(ctx).GetSubmission(ctx, id)
+}
+
+func (q *routedQuerent) ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]sqlc.SubmissionTransition, error) {
+	return q.reader(ctx).ListSubmissionTransitions(ctx, submissionID)
+}
+
+func (q *routedQuerent) ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Submission, error) {
+	return q.reader(ctx).ListSubmissionsByAgentID(ctx, agentID)
+}
+
+func (q *routedQuerent) ListSubmissionsByAgentIDAndStatus(ctx context.Context, args sqlc.ListSubmissionsByAgentIDAndStatusParams) ([]sqlc.Submission, error) {
+	return q.reader(ctx).ListSubmissionsByAgentIDAndStatus(ctx, args)
+}
+
+// webhook queries
+
+func (q *routedQuerent) CreateWebhook(ctx context.Context, args sqlc.CreateWebhookParams) (sqlc.Webhook, error) {
+	return q.writer(ctx).CreateWebhook(ctx, args)
+}
+
+func (q *routedQuerent) DeleteWebhook(ctx context.Context, id int64) (sqlc.Webhook, error) {
+	return q.writer(ctx).DeleteWebhook(ctx, id)
+}
+
+func (q *routedQuerent) GetWebhook(ctx context.Context, id int64) (sqlc.Webhook, error) {
+	return q.reader(ctx).GetWebhook(ctx, id)
+}
+
+func (q *routedQuerent) ListWebhooks(ctx context.Context) ([]sqlc.Webhook, error) {
+	return q.reader(ctx).ListWebhooks(ctx)
+}
+
+func (q *routedQuerent) UpdateWebhook(ctx context.Context, args sqlc.UpdateWebhookParams) (sqlc.Webhook, error) {
+	return q.writer(ctx).UpdateWebhook(ctx, args)
+}
+
+// webhook delivery queries; claiming, completing and failing deliveries is
+// left to the embedded primary Queries
+
+func (q *routedQuerent) ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]sqlc.WebhookDelivery, error) {
+	return q.reader(ctx).ListWebhookDeliveries(ctx, webhookID)
+}
+
+func (q *routedQuerent) ListWebhookDeliveriesByStatus(ctx context.Context, args sqlc.ListWebhookDeliveriesByStatusParams) ([]sqlc.WebhookDelivery, error) {
+	return q.reader(ctx).ListWebhookDeliveriesByStatus(ctx, args)
+}
+
+func (q *routedQuerent) RetryWebhookDelivery(ctx context.Context, id int64) (sqlc.WebhookDelivery, error) {
+	return q.writer(ctx).RetryWebhookDelivery(ctx, id)
+}
@@ -0,0 +1,294 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/litag-example/generated/sqlc"
+	"github.com/fwojciec/litag-example/postgres"
+)
+
+// fakeConnector opens connections that fail every statement with an error
+// naming the pool, which reveals where a query was routed.
+type fakeConnector struct {
+	name string
+	down int32
+	// block makes queries wait for their context to be done.
+	block bool
+}
+
+func (c *fakeConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	c *fakeConnector
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New(c.c.name)
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New(c.c.name)
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.c.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return nil, errors.New(c.c.name)
+}
+
+func (c *fakeConn) Ping(ctx context.Context) error {
+	if atomic.LoadInt32(&c.c.down) == 1 {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func TestReplicas(t *testing.T) {
+	t.Parallel()
+
+	type pools struct {
+		primary, replica1, replica2 *fakeConnector
+		repo                        *postgres.Repo
+	}
+	newPools := func(t *testing.T) *pools {
+		p := &pools{
+			primary:  &fakeConnector{name: "primary"},
+			replica1: &fakeConnector{name: "replica1"},
+			replica2: &fakeConnector{name: "replica2"},
+		}
+		var dbs []*sql.DB
+		for _, c := range []*fakeConnector{p.primary, p.replica1, p.replica2} {
+			db := sql.OpenDB(c)
+			t.Cleanup(func() { db.Close() })
+			dbs = append(dbs, db)
+		}
+		p.repo = postgres.NewRepo(dbs[0], dbs[1:]...)
+		return p
+	}
+	// routedTo returns the name of the pool a read was sent to.
+	routedTo := func(t *testing.T, ctx context.Context, repo *postgres.Repo) string {
+		t.Helper()
+		_, err := repo.GetAgent(ctx, 1)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		return err.Error()
+	}
+
+	t.Run("Round robin", func(t *testing.T) {
+		t.Parallel()
+		p := newPools(t)
+		first := routedTo(t, context.Background(), p.repo)
+		second := routedTo(t, context.Background(), p.repo)
+		third := routedTo(t, context.Background(), p.repo)
+		if !strings.HasPrefix(first, "replica") || !strings.HasPrefix(second, "replica") || first == second {
+			t.Errorf("expected reads to alternate between replicas, received %s and %s", first, second)
+		}
+		if third != first {
+			t.Errorf("expected the third read to go to %s, received %s", first, third)
+		}
+	})
+
+	t.Run("Health checks", func(t *testing.T) {
+		t.Parallel()
+		p := newPools(t)
+		ctx := context.Background()
+		atomic.StoreInt32(&p.replica1.down, 1)
+		p.repo.Replicas.CheckOnce(ctx)
+		for i := 0; i < 3; i++ {
+			if pool := routedTo(t, ctx, p.repo); pool != "replica2" {
+				t.Errorf("expected read to go to replica2, received %s", pool)
+			}
+		}
+		atomic.StoreInt32(&p.replica2.down, 1)
+		p.repo.Replicas.CheckOnce(ctx)
+		if pool := routedTo(t, ctx, p.repo); pool != "primary" {
+			t.Errorf("expected read to fall back to the primary, received %s", pool)
+		}
+		atomic.StoreInt32(&p.replica1.down, 0)
+		p.repo.Replicas.CheckOnce(ctx)
+		if pool := routedTo(t, ctx, p.repo); pool != "replica1" {
+			t.Errorf("expected read to go to the recovered replica1, received %s", pool)
+		}
+	})
+
+	t.Run("Writes", func(t *testing.T) {
+		t.Parallel()
+		p := newPools(t)
+		ctx := context.Background()
+		if _, err := p.repo.CreateAgent(ctx, sqlc.CreateAgentParams{}); err == nil || err.Error() != "primary" {
+			t.Errorf("expected CreateAgent to go to the primary, received %v", err)
+		}
+		if _, err := p.repo.DeleteBook(ctx, 1); err == nil || err.Error() != "primary" {
+			t.Errorf("expected DeleteBook to go to the primary, received %v", err)
+		}
+	})
+
+	t.Run("Read from primary", func(t *testing.T) {
+		t.Parallel()
+		p := newPools(t)
+		if pool := routedTo(t, postgres.ReadFromPrimary(context.Background()), p.repo); pool != "primary" {
+			t.Errorf("expected read to go to the primary, received %s", pool)
+		}
+	})
+
+	t.Run("Read your writes", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			ctx   context.Context
+			write func(ctx context.Context, repo *postgres.Repo)
+			exp   string
+		}{
+			{"querent write", postgres.ReadYourWrites(context.Background()), func(ctx context.Context, repo *postgres.Repo) {
+				repo.UpdateAgent(ctx, sqlc.UpdateAgentParams{ID: 1})
+			}, "primary"},
+			{"tx querent write", postgres.ReadYourWrites(context.Background()), func(ctx context.Context, repo *postgres.Repo) {
+				repo.DeleteBook(ctx, 1)
+			}, "primary"},
+			{"disabled", context.Background(), func(ctx context.Context, repo *postgres.Repo) {
+				repo.DeleteBook(ctx, 1)
+			}, "replica"},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				p := newPools(t)
+				if pool := routedTo(t, tc.ctx, p.repo); !strings.HasPrefix(pool, "replica") {
+					t.Errorf("expected read before the write to go to a replica, received %s", pool)
+				}
+				tc.write(tc.ctx, p.repo)
+				if pool := routedTo(t, tc.ctx, p.repo); !strings.HasPrefix(pool, tc.exp) {
+					t.Errorf("expected read after the write to go to %s, received %s", tc.exp, pool)
+				}
+			})
+		}
+	})
+}
+
+func TestQueryTimeout(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		ctx     func() (context.Context, context.CancelFunc)
+		exp     error
+	}{
+		{"query timeout", 10 * time.Millisecond, func() (context.Context, context.CancelFunc) {
+			return context.WithCancel(context.Background())
+		}, context.DeadlineExceeded},
+		{"shorter operation deadline", time.Hour, func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 10*time.Millisecond)
+		}, context.DeadlineExceeded},
+		{"canceled operation", time.Hour, func() (context.Context, context.CancelFunc) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			return ctx, cancel
+		}, context.Canceled},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db := sql.OpenDB(&fakeConnector{name: "primary", block: true})
+			defer db.Close()
+			repo := postgres.NewRepoWithConfig(db, postgres.Config{QueryTimeout: tc.timeout})
+			ctx, cancel := tc.ctx()
+			defer cancel()
+			_, err := repo.ListBooks(ctx)
+			if !errors.Is(err, tc.exp) {
+				t.Errorf("expected %v, received %v", tc.exp, err)
+			}
+		})
+	}
+}
+
+// execConnector opens connections whose transactions record the statements
+// executed in them and fail each one.
+type execConnector struct {
+	execs []string
+}
+
+func (c *execConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &execConn{c}, nil
+}
+
+func (c *execConnector) Driver() driver.Driver {
+	return nil
+}
+
+type execConn struct {
+	c *execConnector
+}
+
+func (c *execConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare")
+}
+
+func (c *execConn) Close() error {
+	return nil
+}
+
+func (c *execConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *execConn) Commit() error {
+	return nil
+}
+
+func (c *execConn) Rollback() error {
+	return nil
+}
+
+func (c *execConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.c.execs = append(c.c.execs, query)
+	return nil, errors.New("exec")
+}
+
+func TestStatementTimeout(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		exp     string
+	}{
+		{"whole milliseconds", 5 * time.Second, "SET LOCAL statement_timeout = 5000"},
+		{"rounded up", 1500 * time.Microsecond, "SET LOCAL statement_timeout = 2"},
+		{"under a millisecond", time.Microsecond, "SET LOCAL statement_timeout = 1"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			c := &execConnector{}
+			db := sql.OpenDB(c)
+			defer db.Close()
+			repo := postgres.NewRepoWithConfig(db, postgres.Config{StatementTimeout: tc.timeout})
+			repo.DeleteAgent(context.Background(), 1, nil)
+			if len(c.execs) != 1 || c.execs[0] != tc.exp {
+				t.Errorf("expected %q, received %q", tc.exp, c.execs)
+			}
+		})
+	}
+}
@@ -0,0 +1,547 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/fwojciec/litag-example/generated/sqlc" // use your own github username
+)
+
+// timeoutQuerent is a Querent that gives every call a deadline, so that a slow
+// query cannot hold on to its connection indefinitely.
+type timeoutQuerent struct {
+	q       Querent
+	timeout time.Duration
+}
+
+// agency queries
+
+func (t *timeoutQuerent) CreateAgency(ctx context.Context, name string) (sqlc.Agency, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateAgency(ctx, name)
+}
+
+func (t *timeoutQuerent) DeleteAgency(ctx context.Context, id int64) (sqlc.Agency, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.DeleteAgency(ctx, id)
+}
+
+func (t *timeoutQuerent) GetAgency(ctx context.Context, id int64) (sqlc.Agency, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetAgency(ctx, id)
+}
+
+func (t *timeoutQuerent) ListAgencies(ctx context.Context) ([]sqlc.Agency, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListAgencies(ctx)
+}
+
+func (t *timeoutQuerent) UpdateAgency(ctx context.Context, args sqlc.UpdateAgencyParams) (sqlc.Agency, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.UpdateAgency(ctx, args)
+}
+
+// agent queries
+
+func (t *timeoutQuerent) CreateAgent(ctx context.Context, args sqlc.CreateAgentParams) (sqlc.Agent, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateAgent(ctx, args)
+}
+
+func (t *timeoutQuerent) GetAgent(ctx context.Context, id int64) (sqlc.Agent, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetAgent(ctx, id)
+}
+
+func (t *timeoutQuerent) ListAgents(ctx context.Context) ([]sqlc.Agent, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListAgents(ctx)
+}
+
+func (t *timeoutQuerent) ListAgentsByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.Agent, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListAgentsByAgencyID(ctx, agencyID)
+}
+
+func (t *timeoutQuerent) UpdateAgent(ctx context.Context, args sqlc.UpdateAgentParams) (sqlc.Agent, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.UpdateAgent(ctx, args)
+}
+
+// author queries
+
+func (t *timeoutQuerent) GetAuthor(ctx context.Context, id int64) (sqlc.GetAuthorRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetAuthor(ctx, id)
+}
+
+func (t *timeoutQuerent) ListAuthors(ctx context.Context) ([]sqlc.ListAuthorsRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListAuthors(ctx)
+}
+
+func (t *timeoutQuerent) ListAuthorsByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.ListAuthorsByAgencyIDRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListAuthorsByAgencyID(ctx, agencyID)
+}
+
+func (t *timeoutQuerent) ListAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.ListAuthorsByAgentIDRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListAuthorsByAgentID(ctx, agentID)
+}
+
+func (t *timeoutQuerent) ListAuthorsByBookID(ctx context.Context, bookID int64) ([]sqlc.ListAuthorsByBookIDRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListAuthorsByBookID(ctx, bookID)
+}
+
+func (t *timeoutQuerent) ListFormerAuthorsByAgentID(ctx context.Context, agentID int64) ([]sqlc.ListFormerAuthorsByAgentIDRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListFormerAuthorsByAgentID(ctx, agentID)
+}
+
+// book queries
+
+func (t *timeoutQuerent) GetBook(ctx context.Context, id int64) (sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetBook(ctx, id)
+}
+
+func (t *timeoutQuerent) GetBookByISBN(ctx context.Context, isbn string) (sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetBookByISBN(ctx, isbn)
+}
+
+func (t *timeoutQuerent) ListBooks(ctx context.Context) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListBooks(ctx)
+}
+
+func (t *timeoutQuerent) ListBooksByAgencyID(ctx context.Context, agencyID int64) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListBooksByAgencyID(ctx, agencyID)
+}
+
+func (t *timeoutQuerent) ListBooksByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListBooksByAuthorID(ctx, authorID)
+}
+
+func (t *timeoutQuerent) ListBooksByGenreID(ctx context.Context, genreID int64) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListBooksByGenreID(ctx, genreID)
+}
+
+func (t *timeoutQuerent) ListBooksInGenreTree(ctx context.Context, genreID int64) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListBooksInGenreTree(ctx, genreID)
+}
+
+func (t *timeoutQuerent) ListBooksByPublisherID(ctx context.Context, publisherID int64) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListBooksByPublisherID(ctx, publisherID)
+}
+
+func (t *timeoutQuerent) ListBooksBySeriesID(ctx context.Context, seriesID int64) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListBooksBySeriesID(ctx, seriesID)
+}
+
+func (t *timeoutQuerent) ListBooksByTag(ctx context.Context, tag string) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListBooksByTag(ctx, tag)
+}
+
+func (t *timeoutQuerent) ListContributorsByBookID(ctx context.Context, bookID int64) ([]sqlc.BookAuthor, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListContributorsByBookID(ctx, bookID)
+}
+
+func (t *timeoutQuerent) ListOrphanBooks(ctx context.Context) ([]sqlc.Book, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListOrphanBooks(ctx)
+}
+
+func (t *timeoutQuerent) ListTagsByBookID(ctx context.Context, bookID int64) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListTagsByBookID(ctx, bookID)
+}
+
+// deal queries
+
+func (t *timeoutQuerent) CreateDeal(ctx context.Context, args sqlc.CreateDealParams) (sqlc.Deal, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateDeal(ctx, args)
+}
+
+func (t *timeoutQuerent) DeleteDeal(ctx context.Context, id int64) (sqlc.Deal, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.DeleteDeal(ctx, id)
+}
+
+func (t *timeoutQuerent) GetDeal(ctx context.Context, id int64) (sqlc.Deal, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetDeal(ctx, id)
+}
+
+func (t *timeoutQuerent) ListAvailableRights(ctx context.Context, args sqlc.ListAvailableRightsParams) ([]sqlc.ListAvailableRightsRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListAvailableRights(ctx, args)
+}
+
+func (t *timeoutQuerent) ListDealsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Deal, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListDealsByAgentID(ctx, agentID)
+}
+
+func (t *timeoutQuerent) ListDealsByBookID(ctx context.Context, bookID int64) ([]sqlc.Deal, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListDealsByBookID(ctx, bookID)
+}
+
+func (t *timeoutQuerent) UpdateDeal(ctx context.Context, args sqlc.UpdateDealParams) (sqlc.Deal, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.UpdateDeal(ctx, args)
+}
+
+// edition queries
+
+func (t *timeoutQuerent) CreateEdition(ctx context.Context, args sqlc.CreateEditionParams) (sqlc.Edition, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateEdition(ctx, args)
+}
+
+func (t *timeoutQuerent) DeleteEdition(ctx context.Context, id int64) (sqlc.Edition, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.DeleteEdition(ctx, id)
+}
+
+func (t *timeoutQuerent) GetEdition(ctx context.Context, id int64) (sqlc.Edition, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetEdition(ctx, id)
+}
+
+func (t *timeoutQuerent) GetEditionByISBN(ctx context.Context, isbn string) (sqlc.Edition, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetEditionByISBN(ctx, isbn)
+}
+
+func (t *timeoutQuerent) ListEditions(ctx context.Context, args sqlc.ListEditionsParams) ([]sqlc.Edition, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListEditions(ctx, args)
+}
+
+func (t *timeoutQuerent) ListEditionsByBookID(ctx context.Context, bookID int64) ([]sqlc.Edition, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListEditionsByBookID(ctx, bookID)
+}
+
+func (t *timeoutQuerent) UpdateEdition(ctx context.Context, args sqlc.UpdateEditionParams) (sqlc.Edition, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.UpdateEdition(ctx, args)
+}
+
+// genre queries
+
+func (t *timeoutQuerent) CreateGenre(ctx context.Context, args sqlc.CreateGenreParams) (sqlc.Genre, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateGenre(ctx, args)
+}
+
+func (t *timeoutQuerent) DeleteGenre(ctx context.Context, id int64) (sqlc.Genre, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.DeleteGenre(ctx, id)
+}
+
+func (t *timeoutQuerent) GetGenre(ctx context.Context, id int64) (sqlc.Genre, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetGenre(ctx, id)
+}
+
+func (t *timeoutQuerent) ListGenres(ctx context.Context) ([]sqlc.Genre, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListGenres(ctx)
+}
+
+func (t *timeoutQuerent) ListGenresByBookID(ctx context.Context, bookID int64) ([]sqlc.Genre, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListGenresByBookID(ctx, bookID)
+}
+
+func (t *timeoutQuerent) ListGenresByParentID(ctx context.Context, parentID int64) ([]sqlc.Genre, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListGenresByParentID(ctx, parentID)
+}
+
+// publisher queries
+
+func (t *timeoutQuerent) CreatePublisher(ctx context.Context, name string) (sqlc.Publisher, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreatePublisher(ctx, name)
+}
+
+func (t *timeoutQuerent) DeletePublisher(ctx context.Context, id int64) (sqlc.Publisher, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.DeletePublisher(ctx, id)
+}
+
+func (t *timeoutQuerent) GetPublisher(ctx context.Context, id int64) (sqlc.Publisher, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetPublisher(ctx, id)
+}
+
+func (t *timeoutQuerent) ListPublishers(ctx context.Context) ([]sqlc.Publisher, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListPublishers(ctx)
+}
+
+func (t *timeoutQuerent) UpdatePublisher(ctx context.Context, args sqlc.UpdatePublisherParams) (sqlc.Publisher, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.UpdatePublisher(ctx, args)
+}
+
+// representation queries
+
+func (t *timeoutQuerent) CreateRepresentation(ctx context.Context, args sqlc.CreateRepresentationParams) (sqlc.Representation, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateRepresentation(ctx, args)
+}
+
+func (t *timeoutQuerent) GetRepresentation(ctx context.Context, id int64) (sqlc.Representation, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetRepresentation(ctx, id)
+}
+
+func (t *timeoutQuerent) ListRepresentationsByAuthorID(ctx context.Context, authorID int64) ([]sqlc.Representation, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListRepresentationsByAuthorID(ctx, authorID)
+}
+
+// royalty queries
+
+func (t *timeoutQuerent) DeleteRoyaltyRate(ctx context.Context, args sqlc.DeleteRoyaltyRateParams) (sqlc.RoyaltyRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.DeleteRoyaltyRate(ctx, args)
+}
+
+func (t *timeoutQuerent) DeleteSales(ctx context.Context, id int64) (sqlc.Sale, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.DeleteSales(ctx, id)
+}
+
+func (t *timeoutQuerent) ListRoyaltyRatesByDealID(ctx context.Context, dealID int64) ([]sqlc.RoyaltyRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListRoyaltyRatesByDealID(ctx, dealID)
+}
+
+func (t *timeoutQuerent) ListRoyaltySales(ctx context.Context, args sqlc.ListRoyaltySalesParams) ([]sqlc.ListRoyaltySalesRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListRoyaltySales(ctx, args)
+}
+
+func (t *timeoutQuerent) ListSalesByDealID(ctx context.Context, dealID int64) ([]sqlc.Sale, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListSalesByDealID(ctx, dealID)
+}
+
+func (t *timeoutQuerent) RecordSales(ctx context.Context, args sqlc.RecordSalesParams) (sqlc.Sale, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.RecordSales(ctx, args)
+}
+
+func (t *timeoutQuerent) SetRoyaltyRate(ctx context.Context, args sqlc.SetRoyaltyRateParams) (sqlc.RoyaltyRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.SetRoyaltyRate(ctx, args)
+}
+
+// series queries
+
+func (t *timeoutQuerent) CreateSeries(ctx context.Context, title string) (sqlc.Series, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateSeries(ctx, title)
+}
+
+func (t *timeoutQuerent) GetSeries(ctx context.Context, id int64) (sqlc.Series, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetSeries(ctx, id)
+}
+
+func (t *timeoutQuerent) ListSeries(ctx context.Context) ([]sqlc.Series, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListSeries(ctx)
+}
+
+func (t *timeoutQuerent) UpdateSeries(ctx context.Context, args sqlc.UpdateSeriesParams) (sqlc.Series, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.UpdateSeries(ctx, args)
+}
+
+// submission queries
+
+func (t *timeoutQuerent) CreateSubmission(ctx context.Context, args sqlc.CreateSubmissionParams) (sqlc.Submission, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateSubmission(ctx, args)
+}
+
+func (t *timeoutQuerent) GetSubmission(ctx context.Context, id int64) (sqlc.Submission, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetSubmission(ctx, id)
+}
+
+func (t *timeoutQuerent) ListSubmissionTransitions(ctx context.Context, submissionID int64) ([]sqlc.SubmissionTransition, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListSubmissionTransitions(ctx, submissionID)
+}
+
+func (t *timeoutQuerent) ListSubmissionsByAgentID(ctx context.Context, agentID int64) ([]sqlc.Submission, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListSubmissionsByAgentID(ctx, agentID)
+}
+
+func (t *timeoutQuerent) ListSubmissionsByAgentIDAndStatus(ctx context.Context, args sqlc.ListSubmissionsByAgentIDAndStatusParams) ([]sqlc.Submission, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListSubmissionsByAgentIDAndStatus(ctx, args)
+}
+
+// webhook queries
+
+func (t *timeoutQuerent) CreateWebhook(ctx context.Context, args sqlc.CreateWebhookParams) (sqlc.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CreateWebhook(ctx, args)
+}
+
+func (t *timeoutQuerent) DeleteWebhook(ctx context.Context, id int64) (sqlc.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.DeleteWebhook(ctx, id)
+}
+
+func (t *timeoutQuerent) GetWebhook(ctx context.Context, id int64) (sqlc.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.GetWebhook(ctx, id)
+}
+
+func (t *timeoutQuerent) ListWebhooks(ctx context.Context) ([]sqlc.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListWebhooks(ctx)
+}
+
+func (t *timeoutQuerent) UpdateWebhook(ctx context.Context, args sqlc.UpdateWebhookParams) (sqlc.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.UpdateWebhook(ctx, args)
+}
+
+// webhook delivery queries
+
+func (t *timeoutQuerent) ClaimWebhookDeliveries(ctx context.Context, args sqlc.ClaimWebhookDeliveriesParams) ([]sqlc.ClaimWebhookDeliveriesRow, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ClaimWebhookDeliveries(ctx, args)
+}
+
+func (t *timeoutQuerent) CompleteWebhookDelivery(ctx context.Context, args sqlc.CompleteWebhookDeliveryParams) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.CompleteWebhookDelivery(ctx, args)
+}
+
+func (t *timeoutQuerent) FailWebhookDelivery(ctx context.Context, args sqlc.FailWebhookDeliveryParams) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.FailWebhookDelivery(ctx, args)
+}
+
+func (t *timeoutQuerent) ListWebhookDeliveries(ctx context.Context, webhookID int64) ([]sqlc.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListWebhookDeliveries(ctx, webhookID)
+}
+
+func (t *timeoutQuerent) ListWebhookDeliveriesByStatus(ctx context.Context, args sqlc.ListWebhookDeliveriesByStatusParams) ([]sqlc.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.ListWebhookDeliveriesByStatus(ctx, args)
+}
+
+func (t *timeoutQuerent) RetryWebhookDelivery(ctx context.Context, id int64) (sqlc.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.q.RetryWebhookDelivery(ctx, id)
+}
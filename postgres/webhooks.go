@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain"         // use your own github username
+	"github.com/fwojciec/litag-example/generated/sqlc" // use your own github username
+)
+
+// Webhook event types enqueued by the TxQuerent write methods.
+const (
+	EventAuthorCreated = domain.EventAuthorCreated
+	EventAuthorUpdated = domain.EventAuthorUpdated
+	EventAuthorDeleted = domain.EventAuthorDeleted
+	EventBookCreated   = domain.EventBookCreated
+	EventBookUpdated   = domain.EventBookUpdated
+	EventBookDeleted   = domain.EventBookDeleted
+)
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   = domain.DeliveryPending
+	DeliveryDelivered = domain.DeliveryDelivered
+	DeliveryDead      = domain.DeliveryDead
+)
+
+// WebhookEvent is the JSON document delivered to webhook subscribers.
+type WebhookEvent = domain.WebhookEvent
+
+// AuthorPayload is the webhook representation of an author.
+type AuthorPayload = domain.AuthorPayload
+
+// BookPayload is the webhook representation of a book.
+type BookPayload = domain.BookPayload
+
+func newAuthorPayload(a Author) AuthorPayload {
+	return domain.NewAuthorPayload(toDomainAuthor(a))
+}
+
+func newBookPayload(b sqlc.Book, authorIDs []int64) BookPayload {
+	return domain.NewBookPayload(toDomainBook(b), authorIDs)
+}
+
+// enqueueEvent queues a delivery of the event for every webhook subscribed to
+// its type. It must be called with the Queries of the transaction performing
+// the change, so that the deliveries are only recorded if the change commits.
+func enqueueEvent(ctx context.Context, q *sqlc.Queries, eventType string, data interface{}) error {
+	payload, err := json.Marshal(WebhookEvent{
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	return q.EnqueueWebhookDeliveries(ctx, sqlc.EnqueueWebhookDeliveriesParams{
+		EventType: eventType,
+		Payload:   payload,
+	})
+}
+
+func nullStringToPtr(ns sql.NullString) *string {
+	if ns.Valid {
+		s := ns.String
+		return &s
+	}
+	return nil
+}
+
+func nullInt64ToPtr(ni sql.NullInt64) *int64 {
+	if ni.Valid {
+		i := ni.Int64
+		return &i
+	}
+	return nil
+}
+
+func nullInt32ToIntPtr(ni sql.NullInt32) *int {
+	if ni.Valid {
+		i := int(ni.Int32)
+		return &i
+	}
+	return nil
+}
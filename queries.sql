@@ -4,43 +4,108 @@ WHERE id = $1;
 
 -- name: ListAgents :many
 SELECT * FROM agents
+WHERE deleted_at IS NULL
+ORDER BY name;
+
+-- name: ListAgentsByAgencyID :many
+SELECT * FROM agents
+WHERE agency_id = sqlc.arg(agency_id)::bigint AND deleted_at IS NULL
 ORDER BY name;
 
 -- name: CreateAgent :one
-INSERT INTO agents (name, email)
-VALUES ($1, $2)
+INSERT INTO agents (name, email, agency_id)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CreateAgents :many
+INSERT INTO agents (name, email, agency_id)
+SELECT u.name, u.email, NULLIF(u.agency_id, 0)
+FROM unnest(sqlc.arg(names)::text[], sqlc.arg(emails)::text[], sqlc.arg(agency_ids)::bigint[])
+WITH ORDINALITY AS u(name, email, agency_id, ord)
+ORDER BY u.ord
 RETURNING *;
 
 -- name: UpdateAgent :one
 UPDATE agents
-SET name = $2, email = $3
-WHERE id = $1
+SET name = $2, email = $3, agency_id = $4
+WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: DeleteAgent :one
 DELETE FROM agents
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: AgentHasHistory :one
+SELECT EXISTS (SELECT 1 FROM representations WHERE agent_id = $1)
+    OR EXISTS (SELECT 1 FROM deals WHERE agent_id = $1);
+
+-- name: ArchiveAgent :one
+UPDATE agents
+SET deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: EndRepresentationsByAgentID :exec
+UPDATE representations
+SET ended_at = GREATEST(started_at, CURRENT_DATE)
+WHERE agent_id = $1 AND ended_at IS NULL;
+
+-- name: DeleteSubmissionsByAgentID :exec
+DELETE FROM submissions
+WHERE agent_id = $1;
+
+-- name: ReassignAuthors :many
+INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+SELECT author_id, sqlc.arg(to_agent_id), 'WORLD', CURRENT_DATE, true FROM representations
+WHERE agent_id = sqlc.arg(from_agent_id) AND is_primary AND ended_at IS NULL
+ORDER BY author_id
+RETURNING author_id;
+
 -- name: GetAuthor :one
-SELECT * FROM authors
-WHERE id = $1;
+SELECT authors.*, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+WHERE authors.id = $1;
 
 -- name: ListAuthors :many
-SELECT * FROM authors
-ORDER BY name;
+SELECT authors.*, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+ORDER BY authors.name;
 
 -- name: CreateAuthor :one
-INSERT INTO authors (name, website, agent_id)
-VALUES ($1, $2, $3)
+INSERT INTO authors (name, website)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: CreateAuthors :many
+INSERT INTO authors (name, website)
+SELECT u.name, NULLIF(u.website, '')
+FROM unnest(sqlc.arg(names)::text[], sqlc.arg(websites)::text[])
+WITH ORDINALITY AS u(name, website, ord)
+ORDER BY u.ord
 RETURNING *;
 
 -- name: UpdateAuthor :one
 UPDATE authors
-SET name = $2, website = $3, agent_id = $4
+SET name = $2, website = $3
 WHERE id = $1
 RETURNING *;
 
+-- name: StartPrimaryRepresentation :exec
+INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+SELECT $1, $2, 'WORLD', CURRENT_DATE, true
+WHERE NOT EXISTS (
+    SELECT 1 FROM representations
+    WHERE author_id = $1 AND agent_id = $2 AND is_primary AND ended_at IS NULL
+);
+
+-- name: StartPrimaryRepresentations :exec
+INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+SELECT u.author_id, u.agent_id, 'WORLD', CURRENT_DATE, true
+FROM unnest(sqlc.arg(author_ids)::bigint[], sqlc.arg(agent_ids)::bigint[]) AS u(author_id, agent_id);
+
 -- name: DeleteAuthor :one
 DELETE FROM authors
 WHERE id = $1
@@ -50,42 +115,614 @@ RETURNING *;
 SELECT * FROM books
 WHERE id = $1;
 
+-- name: GetBookByISBN :one
+SELECT * FROM books
+WHERE isbn = sqlc.arg(isbn)::text;
+
 -- name: ListBooks :many
 SELECT * FROM books
 ORDER BY title;
 
 -- name: CreateBook :one
-INSERT INTO books (title, description, cover)
-VALUES ($1, $2, $3)
+INSERT INTO books (title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: CreateBooks :many
+INSERT INTO books (title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language)
+SELECT u.title, u.description, u.cover, NULLIF(u.publisher_id, 0), NULLIF(u.series_id, 0), NULLIF(u.series_position, 0),
+    NULLIF(u.isbn, ''), NULLIF(u.published_on, '')::date, NULLIF(u.page_count, '')::int, NULLIF(u.language, '')
+FROM unnest(sqlc.arg(titles)::text[], sqlc.arg(descriptions)::text[], sqlc.arg(covers)::text[], sqlc.arg(publisher_ids)::bigint[], sqlc.arg(series_ids)::bigint[], sqlc.arg(series_positions)::int[],
+    sqlc.arg(isbns)::text[], sqlc.arg(published_ons)::text[], sqlc.arg(page_counts)::text[], sqlc.arg(languages)::text[])
+WITH ORDINALITY AS u(title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language, ord)
+ORDER BY u.ord
 RETURNING *;
 
 -- name: UpdateBook :one
 UPDATE books
-SET title = $2, description = $3, cover = $4
+SET title = $2, description = $3, cover = $4, publisher_id = $5, series_id = $6, series_position = $7,
+    isbn = $8, published_on = $9, page_count = $10, language = $11
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateBooks :many
+UPDATE books
+SET title = u.title, description = u.description, cover = u.cover, publisher_id = NULLIF(u.publisher_id, 0),
+    series_id = NULLIF(u.series_id, 0), series_position = NULLIF(u.series_position, 0),
+    isbn = NULLIF(u.isbn, ''), published_on = NULLIF(u.published_on, '')::date, page_count = NULLIF(u.page_count, '')::int, language = NULLIF(u.language, '')
+FROM unnest(sqlc.arg(ids)::bigint[], sqlc.arg(titles)::text[], sqlc.arg(descriptions)::text[], sqlc.arg(covers)::text[], sqlc.arg(publisher_ids)::bigint[], sqlc.arg(series_ids)::bigint[], sqlc.arg(series_positions)::int[],
+    sqlc.arg(isbns)::text[], sqlc.arg(published_ons)::text[], sqlc.arg(page_counts)::text[], sqlc.arg(languages)::text[])
+AS u(id, title, description, cover, publisher_id, series_id, series_position, isbn, published_on, page_count, language)
+WHERE books.id = u.id
+RETURNING books.*;
+
 -- name: DeleteBook :one
 DELETE FROM books
 WHERE id = $1
 RETURNING *;
 
--- name: SetBookAuthor :exec
-INSERT INTO book_authors (book_id, author_id)
+-- name: GetEdition :one
+SELECT * FROM editions
+WHERE id = $1;
+
+-- name: GetEditionByISBN :one
+SELECT * FROM editions
+WHERE isbn = sqlc.arg(isbn)::text;
+
+-- name: ListEditions :many
+SELECT * FROM editions
+WHERE (sqlc.arg(book_id)::bigint = 0 OR book_id = sqlc.arg(book_id)::bigint)
+AND (sqlc.arg(format)::text = '' OR format = sqlc.arg(format)::text)
+AND (sqlc.arg(published_from)::text = '' OR published_on >= sqlc.arg(published_from)::text::date)
+AND (sqlc.arg(published_to)::text = '' OR published_on <= sqlc.arg(published_to)::text::date)
+ORDER BY id;
+
+-- name: ListEditionsByBookID :many
+SELECT * FROM editions
+WHERE book_id = $1
+ORDER BY id;
+
+-- name: CreateEdition :one
+INSERT INTO editions (book_id, format, isbn, published_on, price_amount, price_currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: UpdateEdition :one
+UPDATE editions
+SET book_id = $2, format = $3, isbn = $4, published_on = $5, price_amount = $6, price_currency = $7
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteEdition :one
+DELETE FROM editions
+WHERE id = $1
+RETURNING *;
+
+-- name: GetDeal :one
+SELECT * FROM deals
+WHERE id = $1;
+
+-- name: ListDealsByBookID :many
+SELECT * FROM deals
+WHERE book_id = $1
+ORDER BY id;
+
+-- name: ListDealsByAgentID :many
+SELECT * FROM deals
+WHERE agent_id = $1
+ORDER BY id;
+
+-- name: CreateDeal :one
+INSERT INTO deals (book_id, publisher_id, agent_id, territory, language, format, advance_amount, advance_currency, signed_on, status, royalty_rate, commission_rate)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: UpdateDeal :one
+UPDATE deals
+SET book_id = $2, publisher_id = $3, agent_id = $4, territory = $5, language = $6, format = $7,
+    advance_amount = $8, advance_currency = $9, signed_on = $10, status = $11, royalty_rate = $12, commission_rate = $13
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteDeal :one
+DELETE FROM deals
+WHERE id = $1
+RETURNING *;
+
+-- name: ListAvailableRights :many
+SELECT books.id AS book_id, formats.format::text AS format
+FROM books, unnest(ARRAY['HARDCOVER', 'PAPERBACK', 'EBOOK', 'AUDIOBOOK']) WITH ORDINALITY AS formats(format, ord)
+WHERE NOT EXISTS (
+    SELECT 1 FROM deals
+    WHERE deals.book_id = books.id AND deals.format = formats.format AND deals.status <> 'TERMINATED'
+        AND (deals.territory IN ('WORLD', sqlc.arg(territory)::text) OR sqlc.arg(territory)::text = 'WORLD')
+        AND deals.language = sqlc.arg(language)::text
+)
+ORDER BY books.title, books.id, formats.ord;
+
+-- name: ListRoyaltyRatesByDealID :many
+SELECT * FROM royalty_rates
+WHERE deal_id = $1
+ORDER BY edition_id;
+
+-- name: SetRoyaltyRate :one
+INSERT INTO royalty_rates (deal_id, edition_id, rate)
+VALUES ($1, $2, $3)
+ON CONFLICT (deal_id, edition_id) DO UPDATE SET rate = EXCLUDED.rate
+RETURNING *;
+
+-- name: DeleteRoyaltyRate :one
+DELETE FROM royalty_rates
+WHERE deal_id = $1 AND edition_id = $2
+RETURNING *;
+
+-- name: ListSalesByDealID :many
+SELECT * FROM sales
+WHERE deal_id = $1
+ORDER BY period, edition_id;
+
+-- name: RecordSales :one
+INSERT INTO sales (deal_id, edition_id, period, units, revenue_amount, revenue_currency)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (deal_id, edition_id, period) DO UPDATE
+SET units = EXCLUDED.units, revenue_amount = EXCLUDED.revenue_amount, revenue_currency = EXCLUDED.revenue_currency
+RETURNING *;
+
+-- name: DeleteSales :one
+DELETE FROM sales
+WHERE id = $1
+RETURNING *;
+
+-- name: ListRoyaltySales :many
+SELECT sales.*, deals.book_id, deals.agent_id,
+    COALESCE(royalty_rates.rate, deals.royalty_rate)::int AS royalty_rate,
+    deals.commission_rate,
+    (SELECT count(*) FROM book_authors
+     WHERE book_authors.book_id = deals.book_id AND book_authors.role = 'AUTHOR')::int AS authors
+FROM sales
+JOIN deals ON deals.id = sales.deal_id
+LEFT JOIN royalty_rates ON royalty_rates.deal_id = sales.deal_id AND royalty_rates.edition_id = sales.edition_id
+WHERE sales.period = sqlc.arg(period)::text AND EXISTS (
+    SELECT 1 FROM book_authors
+    WHERE book_authors.book_id = deals.book_id AND book_authors.author_id = sqlc.arg(author_id)::bigint
+        AND book_authors.role = 'AUTHOR'
+)
+ORDER BY sales.id;
+
+-- name: GetSubmission :one
+SELECT * FROM submissions
+WHERE id = $1;
+
+-- name: GetSubmissionForUpdate :one
+SELECT * FROM submissions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListSubmissionsByAgentID :many
+SELECT * FROM submissions
+WHERE agent_id = $1
+ORDER BY received_at, id;
+
+-- name: ListSubmissionsByAgentIDAndStatus :many
+SELECT * FROM submissions
+WHERE agent_id = $1 AND status = $2
+ORDER BY received_at, id;
+
+-- name: CreateSubmission :one
+INSERT INTO submissions (agent_id, author_name, author_website, title, synopsis)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: SetSubmissionStatus :one
+UPDATE submissions
+SET status = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: SetSubmissionBook :one
+UPDATE submissions
+SET book_id = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ListSubmissionTransitions :many
+SELECT * FROM submission_transitions
+WHERE submission_id = $1
+ORDER BY transitioned_at, id;
+
+-- name: CreateSubmissionTransition :one
+INSERT INTO submission_transitions (submission_id, from_status, to_status, note)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetAgency :one
+SELECT * FROM agencies
+WHERE id = $1;
+
+-- name: ListAgencies :many
+SELECT * FROM agencies
+ORDER BY name;
+
+-- name: CreateAgency :one
+INSERT INTO agencies (name)
+VALUES ($1)
+RETURNING *;
+
+-- name: UpdateAgency :one
+UPDATE agencies
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAgency :one
+DELETE FROM agencies
+WHERE id = $1
+RETURNING *;
+
+-- name: ListAuthorsByAgencyID :many
+SELECT authors.*, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+JOIN agents ON agents.id = representations.agent_id
+WHERE agents.agency_id = sqlc.arg(agency_id)::bigint
+ORDER BY authors.name;
+
+-- name: ListBooksByAgencyID :many
+SELECT * FROM books
+WHERE EXISTS (
+    SELECT 1 FROM book_authors, representations, agents
+    WHERE book_authors.book_id = books.id AND representations.author_id = book_authors.author_id
+        AND representations.is_primary AND representations.ended_at IS NULL
+        AND agents.id = representations.agent_id AND agents.agency_id = sqlc.arg(agency_id)::bigint
+)
+ORDER BY title;
+
+-- name: GetPublisher :one
+SELECT * FROM publishers
+WHERE id = $1;
+
+-- name: ListPublishers :many
+SELECT * FROM publishers
+ORDER BY name;
+
+-- name: CreatePublisher :one
+INSERT INTO publishers (name)
+VALUES ($1)
+RETURNING *;
+
+-- name: UpdatePublisher :one
+UPDATE publishers
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeletePublisher :one
+DELETE FROM publishers
+WHERE id = $1
+RETURNING *;
+
+-- name: ListBooksByPublisherID :many
+SELECT * FROM books
+WHERE publisher_id = sqlc.arg(publisher_id)::bigint
+ORDER BY title;
+
+-- name: GetSeries :one
+SELECT * FROM series
+WHERE id = $1;
+
+-- name: GetSeriesForUpdate :one
+SELECT * FROM series
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListSeries :many
+SELECT * FROM series
+ORDER BY title;
+
+-- name: CreateSeries :one
+INSERT INTO series (title)
+VALUES ($1)
+RETURNING *;
+
+-- name: UpdateSeries :one
+UPDATE series
+SET title = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSeries :one
+DELETE FROM series
+WHERE id = $1
+RETURNING *;
+
+-- name: ListBooksBySeriesID :many
+SELECT * FROM books
+WHERE series_id = sqlc.arg(series_id)::bigint
+ORDER BY series_position;
+
+-- name: RemoveBooksFromSeries :exec
+UPDATE books
+SET series_id = NULL, series_position = NULL
+WHERE series_id = sqlc.arg(series_id)::bigint;
+
+-- name: DeferSeriesPositions :exec
+SET CONSTRAINTS books_series_position_key DEFERRED;
+
+-- name: SetSeriesPositions :many
+UPDATE books
+SET series_position = u.series_position
+FROM unnest(sqlc.arg(ids)::bigint[], sqlc.arg(series_positions)::int[]) AS u(id, series_position)
+WHERE books.id = u.id
+RETURNING books.*;
+
+-- name: GetGenre :one
+SELECT * FROM genres
+WHERE id = $1;
+
+-- name: ListGenres :many
+SELECT * FROM genres
+ORDER BY name;
+
+-- name: ListGenresByParentID :many
+SELECT * FROM genres
+WHERE parent_id = sqlc.arg(parent_id)::bigint
+ORDER BY name;
+
+-- name: ListGenresByBookID :many
+SELECT genres.* FROM genres, book_genres
+WHERE genres.id = book_genres.genre_id AND book_genres.book_id = $1
+ORDER BY genres.name;
+
+-- name: ListGenreAncestorIDs :many
+WITH RECURSIVE ancestors AS (
+    SELECT genres.id, genres.parent_id FROM genres
+    WHERE genres.id = sqlc.arg(genre_id)::bigint
+    UNION
+    SELECT genres.id, genres.parent_id FROM genres, ancestors
+    WHERE genres.id = ancestors.parent_id
+)
+SELECT genres.id FROM genres
+WHERE genres.id IN (SELECT ancestors.id FROM ancestors);
+
+-- name: LockGenres :exec
+LOCK TABLE genres IN SHARE ROW EXCLUSIVE MODE;
+
+-- name: CreateGenre :one
+INSERT INTO genres (name, parent_id)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateGenre :one
+UPDATE genres
+SET name = $2, parent_id = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteGenre :one
+DELETE FROM genres
+WHERE id = $1
+RETURNING *;
+
+-- name: ListBooksByGenreID :many
+SELECT books.* FROM books, book_genres
+WHERE books.id = book_genres.book_id AND book_genres.genre_id = $1
+ORDER BY books.title;
+
+-- name: ListBooksInGenreTree :many
+WITH RECURSIVE subgenres AS (
+    SELECT genres.id FROM genres
+    WHERE genres.id = sqlc.arg(genre_id)::bigint
+    UNION
+    SELECT genres.id FROM genres, subgenres
+    WHERE genres.parent_id = subgenres.id
+)
+SELECT books.* FROM books
+WHERE EXISTS (
+    SELECT 1 FROM book_genres, subgenres
+    WHERE book_genres.book_id = books.id AND book_genres.genre_id = subgenres.id
+)
+ORDER BY books.title;
+
+-- name: SetBookGenre :exec
+INSERT INTO book_genres (book_id, genre_id)
 VALUES ($1, $2);
 
+-- name: SetBooksGenres :exec
+INSERT INTO book_genres (book_id, genre_id)
+SELECT unnest(sqlc.arg(book_ids)::bigint[]), unnest(sqlc.arg(genre_ids)::bigint[]);
+
+-- name: UnsetBookGenres :exec
+DELETE FROM book_genres
+WHERE book_id = $1;
+
+-- name: UnsetBooksGenres :exec
+DELETE FROM book_genres
+WHERE book_id = ANY(sqlc.arg(book_ids)::bigint[]);
+
+-- name: ListTagsByBookID :many
+SELECT tag FROM book_tags
+WHERE book_id = $1
+ORDER BY tag;
+
+-- name: ListBooksByTag :many
+SELECT books.* FROM books, book_tags
+WHERE books.id = book_tags.book_id AND book_tags.tag = $1
+ORDER BY books.title;
+
+-- name: SetBookTag :exec
+INSERT INTO book_tags (book_id, tag)
+VALUES ($1, $2);
+
+-- name: SetBooksTags :exec
+INSERT INTO book_tags (book_id, tag)
+SELECT unnest(sqlc.arg(book_ids)::bigint[]), unnest(sqlc.arg(tags)::text[]);
+
+-- name: UnsetBookTags :exec
+DELETE FROM book_tags
+WHERE book_id = $1;
+
+-- name: UnsetBooksTags :exec
+DELETE FROM book_tags
+WHERE book_id = ANY(sqlc.arg(book_ids)::bigint[]);
+
+-- name: SetBookAuthor :exec
+INSERT INTO book_authors (book_id, author_id, role, position)
+VALUES ($1, $2, $3, $4);
+
+-- name: SetBooksAuthors :exec
+INSERT INTO book_authors (book_id, author_id, role, position)
+SELECT * FROM unnest(sqlc.arg(book_ids)::bigint[], sqlc.arg(author_ids)::bigint[], sqlc.arg(roles)::text[], sqlc.arg(positions)::int[]);
+
 -- name: UnsetBookAuthors :exec
 DELETE FROM book_authors
 WHERE book_id = $1;
 
+-- name: UnsetBooksAuthors :exec
+DELETE FROM book_authors
+WHERE book_id = ANY(sqlc.arg(book_ids)::bigint[]);
+
 -- name: ListAuthorsByAgentID :many
-SELECT authors.* FROM authors, agents
-WHERE agents.id = authors.agent_id AND authors.agent_id = $1;
+SELECT authors.*, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+WHERE representations.agent_id = $1
+ORDER BY authors.id;
+
+-- name: ListFormerAuthorsByAgentID :many
+SELECT authors.*, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+WHERE EXISTS (
+    SELECT 1 FROM representations
+    WHERE representations.author_id = authors.id AND representations.agent_id = $1
+) AND NOT EXISTS (
+    SELECT 1 FROM representations
+    WHERE representations.author_id = authors.id AND representations.agent_id = $1
+        AND representations.ended_at IS NULL
+)
+ORDER BY authors.name;
+
+-- name: GetRepresentation :one
+SELECT * FROM representations
+WHERE id = $1;
+
+-- name: ListRepresentationsByAuthorID :many
+SELECT * FROM representations
+WHERE author_id = $1
+ORDER BY started_at, id;
+
+-- name: CreateRepresentation :one
+INSERT INTO representations (author_id, agent_id, territory, started_at, is_primary)
+VALUES ($1, $2, $3, $4, false)
+RETURNING *;
+
+-- name: EndRepresentation :one
+UPDATE representations
+SET ended_at = sqlc.arg(ended_at)::date
+WHERE id = sqlc.arg(id)
+RETURNING *;
 
 -- name: ListBooksByAuthorID :many
 SELECT books.* FROM books, book_authors
 WHERE books.id = book_authors.book_id AND book_authors.author_id = $1;
 
 -- name: ListAuthorsByBookID :many
-SELECT authors.* FROM authors, book_authors
-WHERE authors.id = book_authors.author_id AND book_authors.book_id = $1;
+SELECT authors.*, representations.agent_id FROM authors
+JOIN representations ON representations.author_id = authors.id
+    AND representations.is_primary AND representations.ended_at IS NULL
+JOIN book_authors ON book_authors.author_id = authors.id
+WHERE book_authors.book_id = $1
+ORDER BY book_authors.position, book_authors.id;
+
+-- name: ListContributorsByBookID :many
+SELECT * FROM book_authors
+WHERE book_id = $1
+ORDER BY position, id;
+
+-- name: ListBooksOrphanedByAuthorID :many
+SELECT books.* FROM books, book_authors
+WHERE books.id = book_authors.book_id AND book_authors.author_id = $1
+AND NOT EXISTS (
+    SELECT 1 FROM book_authors others
+    WHERE others.book_id = books.id AND others.author_id <> $1
+)
+ORDER BY books.title;
+
+-- name: ListOrphanBooks :many
+SELECT * FROM books
+WHERE NOT EXISTS (
+    SELECT 1 FROM book_authors
+    WHERE book_authors.book_id = books.id
+)
+ORDER BY title;
+
+-- name: AllowOrphanedBooks :exec
+SELECT set_config('litag.allow_orphaned_books', 'on', true);
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks
+WHERE id = $1;
+
+-- name: ListWebhooks :many
+SELECT * FROM webhooks
+ORDER BY id;
+
+-- name: CreateWebhook :one
+INSERT INTO webhooks (url, secret, event_types)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateWebhook :one
+UPDATE webhooks
+SET url = $2, secret = $3, event_types = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWebhook :one
+DELETE FROM webhooks
+WHERE id = $1
+RETURNING *;
+
+-- name: EnqueueWebhookDeliveries :exec
+INSERT INTO webhook_deliveries (webhook_id, event_type, payload)
+SELECT webhooks.id, sqlc.arg(event_type)::text, sqlc.arg(payload)::jsonb FROM webhooks
+WHERE sqlc.arg(event_type)::text = ANY(webhooks.event_types);
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY id DESC;
+
+-- name: ListWebhookDeliveriesByStatus :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1 AND status = $2
+ORDER BY id DESC;
+
+-- name: RetryWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, last_error = NULL, next_attempt_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET attempts = webhook_deliveries.attempts + 1, next_attempt_at = sqlc.arg(lease_until)::timestamptz
+FROM webhooks
+WHERE webhooks.id = webhook_deliveries.webhook_id AND webhook_deliveries.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= now()
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg(batch_size)::int
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING webhook_deliveries.*, webhooks.url, webhooks.secret;
+
+-- name: CompleteWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = 'delivered', response_status = $2, last_error = NULL, delivered_at = now()
+WHERE id = $1;
+
+-- name: FailWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = $2, response_status = $3, last_error = $4, next_attempt_at = $5
+WHERE id = $1;
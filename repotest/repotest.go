@@ -0,0 +1,1900 @@
+// Package repotest provides a conformance test suite for implementations of
+// domain.Repository, so that alternative stores can be verified to behave
+// like the PostgreSQL one.
+//
+// A backend runs the suite from its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) domain.Repository {
+//			return memory.New()
+//		})
+//	}
+package repotest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain" // use your own github username
+)
+
+// Factory returns an empty repo for a single test. It can use t.Cleanup to
+// release the repo once the test is done.
+type Factory func(t *testing.T) domain.Repository
+
+// Run runs the conformance suite, calling newRepo for every test. The tests
+// run sequentially, so the factory may reuse a single database.
+func Run(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		test func(ctx context.Context, t *testing.T, r domain.Repository)
+	}{
+		{"Ordering", testOrdering},
+		{"Not found", testNotFound},
+		{"Foreign key violations", testForeignKeyViolations},
+		{"Cascades", testCascades},
+		{"CreateBook rollback", testCreateBookRollback},
+		{"UpdateBook rollback", testUpdateBookRollback},
+		{"Author websites", testAuthorWebsites},
+		{"Publishers", testPublishers},
+		{"Agencies", testAgencies},
+		{"Genres", testGenres},
+		{"Tags", testTags},
+		{"Series", testSeries},
+		{"Bibliographic", testBibliographic},
+		{"Editions", testEditions},
+		{"Contributors", testContributors},
+		{"Representations", testRepresentations},
+		{"Deals", testDeals},
+		{"Royalties", testRoyalties},
+		{"Submissions", testSubmissions},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tc.test(context.Background(), t, newRepo(t))
+		})
+	}
+}
+
+// fixture holds the ids of the records created by newFixture. Names are
+// chosen so that the creation order differs from the name order.
+type fixture struct {
+	agentB, agentA   int64
+	authorB, authorA int64
+	bookB, bookA     int64
+}
+
+// newFixture creates two agents, each representing one author, and two
+// books: bookB written by both authors and bookA by authorB alone.
+func newFixture(ctx context.Context, t *testing.T, r domain.Repository) fixture {
+	t.Helper()
+	var f fixture
+	for _, a := range []struct {
+		id    *int64
+		name  string
+		email string
+	}{
+		{&f.agentB, "Agent B", "b@agents.test"},
+		{&f.agentA, "Agent A", "a@agents.test"},
+	} {
+		agent, err := r.CreateAgent(ctx, domain.CreateAgentParams{Name: a.name, Email: a.email})
+		if err != nil {
+			t.Fatalf("failed to create agent: %s", err)
+		}
+		*a.id = agent.ID
+	}
+	for _, a := range []struct {
+		id      *int64
+		name    string
+		agentID int64
+	}{
+		{&f.authorB, "Author B", f.agentB},
+		{&f.authorA, "Author A", f.agentA},
+	} {
+		author, err := r.CreateAuthor(ctx, domain.CreateAuthorParams{Name: a.name, AgentID: a.agentID})
+		if err != nil {
+			t.Fatalf("failed to create author: %s", err)
+		}
+		*a.id = author.ID
+	}
+	for _, b := range []struct {
+		id        *int64
+		title     string
+		authorIDs []int64
+	}{
+		{&f.bookB, "Book B", []int64{f.authorA, f.authorB}},
+		{&f.bookA, "Book A", []int64{f.authorB}},
+	} {
+		book, err := r.CreateBook(ctx, domain.CreateBookParams{
+			Title:       b.title,
+			Description: "Description",
+			Cover:       "cover.jpg",
+		}, credits(b.authorIDs...), nil, nil)
+		if err != nil {
+			t.Fatalf("failed to create book: %s", err)
+		}
+		*b.id = book.ID
+	}
+	return f
+}
+
+func testOrdering(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	agents, err := r.ListAgents(ctx)
+	if err != nil {
+		t.Fatalf("failed to list agents: %s", err)
+	}
+	checkIDs(t, "agents", agentIDs(agents), f.agentA, f.agentB)
+
+	authors, err := r.ListAuthors(ctx)
+	if err != nil {
+		t.Fatalf("failed to list authors: %s", err)
+	}
+	checkIDs(t, "authors", authorIDs(authors), f.authorA, f.authorB)
+
+	books, err := r.ListBooks(ctx)
+	if err != nil {
+		t.Fatalf("failed to list books: %s", err)
+	}
+	checkIDs(t, "books", bookIDs(books), f.bookA, f.bookB)
+
+	// deleting authorA leaves no orphans, authorB orphans both books once the
+	// orphans are kept
+	for _, id := range []int64{f.authorA, f.authorB} {
+		_, err := r.DeleteAuthor(ctx, id, domain.OrphanedBooksKeep)
+		if err != nil {
+			t.Fatalf("failed to delete author: %s", err)
+		}
+	}
+	orphans, err := r.ListOrphanBooks(ctx)
+	if err != nil {
+		t.Fatalf("failed to list orphan books: %s", err)
+	}
+	checkIDs(t, "orphan books", bookIDs(orphans), f.bookA, f.bookB)
+}
+
+func testNotFound(ctx context.Context, t *testing.T, r domain.Repository) {
+	const missing = 1 << 40
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"GetAgent", func() error { _, err := r.GetAgent(ctx, missing); return err }},
+		{"GetAuthor", func() error { _, err := r.GetAuthor(ctx, missing); return err }},
+		{"GetBook", func() error { _, err := r.GetBook(ctx, missing); return err }},
+		{"GetBookByISBN", func() error { _, err := r.GetBookByISBN(ctx, "9780000000002"); return err }},
+		{"GetPublisher", func() error { _, err := r.GetPublisher(ctx, missing); return err }},
+		{"GetAgency", func() error { _, err := r.GetAgency(ctx, missing); return err }},
+		{"UpdateAgency", func() error {
+			_, err := r.UpdateAgency(ctx, domain.UpdateAgencyParams{ID: missing, Name: "x"})
+			return err
+		}},
+		{"DeleteAgency", func() error { _, err := r.DeleteAgency(ctx, missing); return err }},
+		{"GetGenre", func() error { _, err := r.GetGenre(ctx, missing); return err }},
+		{"GetSeries", func() error { _, err := r.GetSeries(ctx, missing); return err }},
+		{"ReorderSeries", func() error { _, err := r.ReorderSeries(ctx, missing, nil); return err }},
+		{"DeleteSeries", func() error { _, err := r.DeleteSeries(ctx, missing); return err }},
+		{"GetEdition", func() error { _, err := r.GetEdition(ctx, missing); return err }},
+		{"GetEditionByISBN", func() error { _, err := r.GetEditionByISBN(ctx, "9780000000002"); return err }},
+		{"UpdateEdition", func() error {
+			_, err := r.UpdateEdition(ctx, domain.UpdateEditionParams{ID: missing, Format: "EBOOK"})
+			return err
+		}},
+		{"DeleteEdition", func() error { _, err := r.DeleteEdition(ctx, missing); return err }},
+		{"UpdateAgent", func() error {
+			_, err := r.UpdateAgent(ctx, domain.UpdateAgentParams{ID: missing, Name: "x", Email: "x"})
+			return err
+		}},
+		{"DeleteBook", func() error { _, err := r.DeleteBook(ctx, missing); return err }},
+		{"DeleteAuthor", func() error { _, err := r.DeleteAuthor(ctx, missing, domain.OrphanedBooksFail); return err }},
+		{"DeleteAgent", func() error { _, err := r.DeleteAgent(ctx, missing, nil); return err }},
+		{"GetDeal", func() error { _, err := r.GetDeal(ctx, missing); return err }},
+		{"UpdateDeal", func() error {
+			_, err := r.UpdateDeal(ctx, domain.UpdateDealParams{ID: missing, Territory: "FR", Language: "fr", Format: "EBOOK"})
+			return err
+		}},
+		{"DeleteDeal", func() error { _, err := r.DeleteDeal(ctx, missing); return err }},
+		{"DeleteRoyaltyRate", func() error { _, err := r.DeleteRoyaltyRate(ctx, missing, missing); return err }},
+		{"DeleteSales", func() error { _, err := r.DeleteSales(ctx, missing); return err }},
+		{"EndRepresentation", func() error {
+			_, err := r.EndRepresentation(ctx, missing, domain.DateOf(time.Now()))
+			return err
+		}},
+		{"GetSubmission", func() error { _, err := r.GetSubmission(ctx, missing); return err }},
+		{"TransitionSubmission", func() error {
+			_, err := r.TransitionSubmission(ctx, missing, domain.SubmissionReading, nil)
+			return err
+		}},
+		{"ConvertSubmission", func() error { _, err := r.ConvertSubmission(ctx, missing, "cover.jpg"); return err }},
+	}
+	for _, tc := range tests {
+		if err := tc.call(); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("%s: expected domain.ErrNotFound, received %v", tc.name, err)
+		}
+	}
+	authors, err := r.ListAuthorsByBookID(ctx, missing)
+	if err != nil || len(authors) != 0 {
+		t.Errorf("ListAuthorsByBookID: expected no authors, received %v, %v", authors, err)
+	}
+	books, err := r.ListBooksByAuthorID(ctx, missing)
+	if err != nil || len(books) != 0 {
+		t.Errorf("ListBooksByAuthorID: expected no books, received %v, %v", books, err)
+	}
+	contributors, err := r.ListContributorsByBookID(ctx, missing)
+	if err != nil || len(contributors) != 0 {
+		t.Errorf("ListContributorsByBookID: expected no contributors, received %v, %v", contributors, err)
+	}
+}
+
+func testForeignKeyViolations(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	const missing = 1 << 40
+
+	if _, err := r.CreateAuthor(ctx, domain.CreateAuthorParams{Name: "Author C", AgentID: missing}); err == nil {
+		t.Errorf("CreateAuthor: expected an error for an unknown agent")
+	}
+	if _, err := r.UpdateAuthor(ctx, domain.UpdateAuthorParams{ID: f.authorA, Name: "Author A", AgentID: missing}); err == nil {
+		t.Errorf("UpdateAuthor: expected an error for an unknown agent")
+	}
+	if _, err := r.DeleteAgent(ctx, f.agentA, nil); err == nil {
+		t.Errorf("DeleteAgent: expected an error for an agent with authors")
+	}
+
+	authors, err := r.ListAuthors(ctx)
+	if err != nil {
+		t.Fatalf("failed to list authors: %s", err)
+	}
+	checkIDs(t, "authors", authorIDs(authors), f.authorA, f.authorB)
+	author, err := r.GetAuthor(ctx, f.authorA)
+	if err != nil {
+		t.Fatalf("failed to get author: %s", err)
+	}
+	if author.AgentID != f.agentA {
+		t.Errorf("expected agent %d, received %d", f.agentA, author.AgentID)
+	}
+	if _, err := r.GetAgent(ctx, f.agentA); err != nil {
+		t.Errorf("expected the agent to remain, received %v", err)
+	}
+}
+
+func testCascades(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	// deleting a book removes its associations with authors
+	if _, err := r.DeleteBook(ctx, f.bookB); err != nil {
+		t.Fatalf("failed to delete book: %s", err)
+	}
+	books, err := r.ListBooksByAuthorID(ctx, f.authorA)
+	if err != nil {
+		t.Fatalf("failed to list books by author id: %s", err)
+	}
+	checkIDs(t, "books of authorA", bookIDs(books))
+
+	// deleting an author removes its associations with books
+	if _, err := r.DeleteAuthor(ctx, f.authorB, domain.OrphanedBooksKeep); err != nil {
+		t.Fatalf("failed to delete author: %s", err)
+	}
+	authors, err := r.ListAuthorsByBookID(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to list authors by book id: %s", err)
+	}
+	checkIDs(t, "authors of bookA", authorIDs(authors))
+
+	// deleting an agent after reassigning its authors keeps the authors, and
+	// the agent is archived for their representation history
+	if _, err := r.DeleteAgent(ctx, f.agentA, &f.agentB); err != nil {
+		t.Fatalf("failed to delete agent: %s", err)
+	}
+	authors, err = r.ListAuthorsByAgentID(ctx, f.agentB)
+	if err != nil {
+		t.Fatalf("failed to list authors by agent id: %s", err)
+	}
+	checkIDs(t, "authors of agentB", authorIDs(authors), f.authorA)
+	reps, err := r.ListRepresentationsByAuthorID(ctx, f.authorA)
+	if err != nil {
+		t.Fatalf("failed to list representations by author id: %s", err)
+	}
+	if len(reps) != 2 || reps[0].AgentID != f.agentA || reps[0].EndedAt == nil || reps[1].AgentID != f.agentB {
+		t.Errorf("expected the ended representation by agentA to remain, received %v", reps)
+	}
+	agent, err := r.GetAgent(ctx, f.agentA)
+	if err != nil {
+		t.Fatalf("failed to get agent: %s", err)
+	}
+	if agent.DeletedAt == nil {
+		t.Errorf("expected the agent to be deleted, received %v", agent)
+	}
+	agents, err := r.ListAgents(ctx)
+	if err != nil {
+		t.Fatalf("failed to list agents: %s", err)
+	}
+	checkIDs(t, "agents", agentIDs(agents), f.agentB)
+
+	// a deleted agent takes on no new work and cannot be deleted again
+	if _, err := r.UpdateAuthor(ctx, domain.UpdateAuthorParams{ID: f.authorA, Name: "Author A", AgentID: f.agentA}); err != domain.ErrAgentDeleted {
+		t.Errorf("UpdateAuthor: expected %v, received %v", domain.ErrAgentDeleted, err)
+	}
+	if _, err := r.UpdateAgent(ctx, domain.UpdateAgentParams{ID: f.agentA, Name: "Agent A"}); err != domain.ErrNotFound {
+		t.Errorf("UpdateAgent: expected %v, received %v", domain.ErrNotFound, err)
+	}
+	if _, err := r.DeleteAgent(ctx, f.agentA, nil); err != domain.ErrNotFound {
+		t.Errorf("DeleteAgent: expected %v, received %v", domain.ErrNotFound, err)
+	}
+
+	// an agent without any history is deleted outright
+	agentC, err := r.CreateAgent(ctx, domain.CreateAgentParams{Name: "Agent C", Email: "c@agents.test"})
+	if err != nil {
+		t.Fatalf("failed to create agent: %s", err)
+	}
+	if _, err := r.DeleteAgent(ctx, agentC.ID, nil); err != nil {
+		t.Fatalf("failed to delete agent: %s", err)
+	}
+	if _, err := r.GetAgent(ctx, agentC.ID); err != domain.ErrNotFound {
+		t.Errorf("expected %v, received %v", domain.ErrNotFound, err)
+	}
+}
+
+func testCreateBookRollback(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	const missing = 1 << 40
+	args := domain.CreateBookParams{Title: "Book C", Description: "Description", Cover: "cover.jpg"}
+
+	tests := []struct {
+		name      string
+		authorIDs []int64
+	}{
+		{"unknown author", []int64{f.authorA, missing}},
+		{"repeated author", []int64{f.authorA, f.authorA}},
+		{"no authors", nil},
+	}
+	for _, tc := range tests {
+		if _, err := r.CreateBook(ctx, args, credits(tc.authorIDs...), nil, nil); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+	books, err := r.ListBooks(ctx)
+	if err != nil {
+		t.Fatalf("failed to list books: %s", err)
+	}
+	checkIDs(t, "books", bookIDs(books), f.bookA, f.bookB)
+	books, err = r.ListBooksByAuthorID(ctx, f.authorA)
+	if err != nil {
+		t.Fatalf("failed to list books by author id: %s", err)
+	}
+	checkIDs(t, "books of authorA", bookIDs(books), f.bookB)
+}
+
+func testUpdateBookRollback(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	const missing = 1 << 40
+	args := domain.UpdateBookParams{ID: f.bookA, Title: "Book A updated", Description: "Description", Cover: "cover.jpg"}
+
+	tests := []struct {
+		name      string
+		authorIDs []int64
+	}{
+		{"unknown author", []int64{f.authorA, missing}},
+		{"repeated author", []int64{f.authorA, f.authorA}},
+		{"no authors", nil},
+	}
+	for _, tc := range tests {
+		if _, err := r.UpdateBook(ctx, args, credits(tc.authorIDs...), nil, nil); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+	book, err := r.GetBook(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to get book: %s", err)
+	}
+	if book.Title != "Book A" {
+		t.Errorf("expected title %q, received %q", "Book A", book.Title)
+	}
+	authors, err := r.ListAuthorsByBookID(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to list authors by book id: %s", err)
+	}
+	checkIDs(t, "authors of bookA", authorIDs(authors), f.authorB)
+
+	// a valid update replaces the authors
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorA), nil, nil); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	authors, err = r.ListAuthorsByBookID(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to list authors by book id: %s", err)
+	}
+	checkIDs(t, "authors of bookA", authorIDs(authors), f.authorA)
+}
+
+func testAuthorWebsites(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	empty := new(string)
+
+	// an empty website is stored as NULL whether the author is created
+	// singly or in bulk, including the per-item fallback after a failure
+	single, err := r.CreateAuthor(ctx, domain.CreateAuthorParams{Name: "Single", Website: empty, AgentID: f.agentA})
+	if err != nil {
+		t.Fatalf("failed to create author: %s", err)
+	}
+	if single.Website != nil {
+		t.Errorf("expected an empty website to be stored as NULL, received %q", *single.Website)
+	}
+	for _, mode := range []domain.BulkMode{domain.BulkAllOrNothing, domain.BulkBestEffort} {
+		args := []domain.CreateAuthorParams{{Name: "Bulk", Website: empty, AgentID: f.agentA}}
+		if mode == domain.BulkBestEffort {
+			args = append(args, domain.CreateAuthorParams{Name: "Invalid", AgentID: -1})
+		}
+		res, err := r.CreateAuthors(ctx, args, mode)
+		if err != nil {
+			t.Fatalf("failed to create authors: %s", err)
+		}
+		if !res.Committed || res.Authors[0] == nil {
+			t.Fatalf("expected the %s bulk create to commit the first author, received %v", mode, res.Errors)
+		}
+		if website := res.Authors[0].Website; website != nil {
+			t.Errorf("expected the %s bulk create to store an empty website as NULL, received %q", mode, *website)
+		}
+	}
+
+	// the same holds for updates
+	updated, err := r.UpdateAuthor(ctx, domain.UpdateAuthorParams{ID: f.authorA, Name: "Author A", Website: empty, AgentID: f.agentA})
+	if err != nil {
+		t.Fatalf("failed to update author: %s", err)
+	}
+	if updated.Website != nil {
+		t.Errorf("expected an empty website to be stored as NULL, received %q", *updated.Website)
+	}
+}
+
+func testPublishers(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	const missing = 1 << 40
+
+	publisherB, err := r.CreatePublisher(ctx, domain.CreatePublisherParams{Name: "Publisher B"})
+	if err != nil {
+		t.Fatalf("failed to create publisher: %s", err)
+	}
+	publisherA, err := r.CreatePublisher(ctx, domain.CreatePublisherParams{Name: "Publisher A"})
+	if err != nil {
+		t.Fatalf("failed to create publisher: %s", err)
+	}
+	publishers, err := r.ListPublishers(ctx)
+	if err != nil {
+		t.Fatalf("failed to list publishers: %s", err)
+	}
+	checkIDs(t, "publishers", publisherIDs(publishers), publisherA.ID, publisherB.ID)
+
+	// books reference existing publishers only
+	args := domain.UpdateBookParams{ID: f.bookA, Title: "Book A", Description: "Description", Cover: "cover.jpg"}
+	args.PublisherID = int64Ptr(missing)
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorB), nil, nil); err == nil {
+		t.Errorf("UpdateBook: expected an error for an unknown publisher")
+	}
+	args.PublisherID = &publisherB.ID
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorB), nil, nil); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	book, err := r.CreateBook(ctx, domain.CreateBookParams{
+		Title:       "Book C",
+		Description: "Description",
+		Cover:       "cover.jpg",
+		PublisherID: &publisherB.ID,
+	}, credits(f.authorA), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create book: %s", err)
+	}
+	books, err := r.ListBooksByPublisherID(ctx, publisherB.ID)
+	if err != nil {
+		t.Fatalf("failed to list books by publisher id: %s", err)
+	}
+	checkIDs(t, "books of publisherB", bookIDs(books), f.bookA, book.ID)
+
+	// deleting a publisher keeps its books without a publisher
+	if _, err := r.DeletePublisher(ctx, publisherB.ID); err != nil {
+		t.Fatalf("failed to delete publisher: %s", err)
+	}
+	bookA, err := r.GetBook(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to get book: %s", err)
+	}
+	if bookA.PublisherID != nil {
+		t.Errorf("expected no publisher, received %d", *bookA.PublisherID)
+	}
+	books, err = r.ListBooksByPublisherID(ctx, publisherB.ID)
+	if err != nil {
+		t.Fatalf("failed to list books by publisher id: %s", err)
+	}
+	checkIDs(t, "books of publisherB", bookIDs(books))
+}
+
+func testAgencies(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	const missing = 1 << 40
+
+	agencyB, err := r.CreateAgency(ctx, domain.CreateAgencyParams{Name: "Agency B"})
+	if err != nil {
+		t.Fatalf("failed to create agency: %s", err)
+	}
+	agencyA, err := r.CreateAgency(ctx, domain.CreateAgencyParams{Name: "Agency A"})
+	if err != nil {
+		t.Fatalf("failed to create agency: %s", err)
+	}
+	agencies, err := r.ListAgencies(ctx)
+	if err != nil {
+		t.Fatalf("failed to list agencies: %s", err)
+	}
+	checkIDs(t, "agencies", agencyIDs(agencies), agencyA.ID, agencyB.ID)
+
+	// agents belong to existing agencies only
+	args := domain.UpdateAgentParams{ID: f.agentA, Name: "Agent A", Email: "a@agents.test"}
+	args.AgencyID = int64Ptr(missing)
+	if _, err := r.UpdateAgent(ctx, args); err == nil {
+		t.Errorf("UpdateAgent: expected an error for an unknown agency")
+	}
+	args.AgencyID = &agencyB.ID
+	if _, err := r.UpdateAgent(ctx, args); err != nil {
+		t.Fatalf("failed to update agent: %s", err)
+	}
+	agentC, err := r.CreateAgent(ctx, domain.CreateAgentParams{
+		Name:     "Agent C",
+		Email:    "c@agents.test",
+		AgencyID: &agencyB.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create agent: %s", err)
+	}
+	agents, err := r.ListAgentsByAgencyID(ctx, agencyB.ID)
+	if err != nil {
+		t.Fatalf("failed to list agents by agency id: %s", err)
+	}
+	checkIDs(t, "agents of agencyB", agentIDs(agents), f.agentA, agentC.ID)
+
+	// authorA is represented by agentA, so agencyB reaches bookB through
+	// authorA but not bookA, which authorB wrote alone
+	authors, err := r.ListAuthorsByAgencyID(ctx, agencyB.ID)
+	if err != nil {
+		t.Fatalf("failed to list authors by agency id: %s", err)
+	}
+	checkIDs(t, "authors of agencyB", authorIDs(authors), f.authorA)
+	books, err := r.ListBooksByAgencyID(ctx, agencyB.ID)
+	if err != nil {
+		t.Fatalf("failed to list books by agency id: %s", err)
+	}
+	checkIDs(t, "books of agencyB", bookIDs(books), f.bookB)
+	books, err = r.ListBooksByAgencyID(ctx, agencyA.ID)
+	if err != nil {
+		t.Fatalf("failed to list books by agency id: %s", err)
+	}
+	checkIDs(t, "books of agencyA", bookIDs(books))
+
+	// deleting an agency keeps its agents without an agency
+	if _, err := r.DeleteAgency(ctx, agencyB.ID); err != nil {
+		t.Fatalf("failed to delete agency: %s", err)
+	}
+	agentA, err := r.GetAgent(ctx, f.agentA)
+	if err != nil {
+		t.Fatalf("failed to get agent: %s", err)
+	}
+	if agentA.AgencyID != nil {
+		t.Errorf("expected no agency, received %d", *agentA.AgencyID)
+	}
+	agents, err = r.ListAgentsByAgencyID(ctx, agencyB.ID)
+	if err != nil {
+		t.Fatalf("failed to list agents by agency id: %s", err)
+	}
+	checkIDs(t, "agents of agencyB", agentIDs(agents))
+}
+
+func testGenres(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	const missing = 1 << 40
+
+	// fiction > fantasy > epic fantasy
+	fiction, err := r.CreateGenre(ctx, domain.CreateGenreParams{Name: "Fiction"})
+	if err != nil {
+		t.Fatalf("failed to create genre: %s", err)
+	}
+	fantasy, err := r.CreateGenre(ctx, domain.CreateGenreParams{
+		Name:     "Fantasy",
+		ParentID: &fiction.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create genre: %s", err)
+	}
+	epic, err := r.CreateGenre(ctx, domain.CreateGenreParams{
+		Name:     "Epic Fantasy",
+		ParentID: &fantasy.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create genre: %s", err)
+	}
+	if _, err := r.CreateGenre(ctx, domain.CreateGenreParams{
+		Name:     "Unknown",
+		ParentID: int64Ptr(missing),
+	}); err == nil {
+		t.Errorf("CreateGenre: expected an error for an unknown parent")
+	}
+	genres, err := r.ListGenres(ctx)
+	if err != nil {
+		t.Fatalf("failed to list genres: %s", err)
+	}
+	checkIDs(t, "genres", genreIDs(genres), epic.ID, fantasy.ID, fiction.ID)
+	genres, err = r.ListGenresByParentID(ctx, fiction.ID)
+	if err != nil {
+		t.Fatalf("failed to list genres by parent id: %s", err)
+	}
+	checkIDs(t, "subgenres of fiction", genreIDs(genres), fantasy.ID)
+
+	// a genre cannot be moved under itself or its subgenres
+	for _, parentID := range []int64{fiction.ID, fantasy.ID, epic.ID} {
+		_, err := r.UpdateGenre(ctx, domain.UpdateGenreParams{
+			ID:       fiction.ID,
+			Name:     "Fiction",
+			ParentID: int64Ptr(parentID),
+		})
+		if !errors.Is(err, domain.ErrGenreCycle) {
+			t.Errorf("UpdateGenre: expected ErrGenreCycle for parent %d, received %v", parentID, err)
+		}
+	}
+
+	// bookA is epic fantasy, bookB is fiction and fantasy
+	args := domain.UpdateBookParams{ID: f.bookA, Title: "Book A", Description: "Description", Cover: "cover.jpg"}
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorB), []int64{epic.ID, missing}, nil); err == nil {
+		t.Errorf("UpdateBook: expected an error for an unknown genre")
+	}
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorB), []int64{epic.ID}, nil); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	args = domain.UpdateBookParams{ID: f.bookB, Title: "Book B", Description: "Description", Cover: "cover.jpg"}
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorA, f.authorB), []int64{fiction.ID, fantasy.ID}, nil); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	genres, err = r.ListGenresByBookID(ctx, f.bookB)
+	if err != nil {
+		t.Fatalf("failed to list genres by book id: %s", err)
+	}
+	checkIDs(t, "genres of bookB", genreIDs(genres), fantasy.ID, fiction.ID)
+	books, err := r.ListBooksByGenreID(ctx, fantasy.ID, false)
+	if err != nil {
+		t.Fatalf("failed to list books by genre id: %s", err)
+	}
+	checkIDs(t, "books of fantasy", bookIDs(books), f.bookB)
+	books, err = r.ListBooksByGenreID(ctx, fiction.ID, true)
+	if err != nil {
+		t.Fatalf("failed to list books by genre id with subgenres: %s", err)
+	}
+	checkIDs(t, "books in fiction tree", bookIDs(books), f.bookA, f.bookB)
+
+	// genres with subgenres cannot be deleted, deleting a leaf removes its
+	// associations with books
+	if _, err := r.DeleteGenre(ctx, fantasy.ID); err == nil {
+		t.Errorf("DeleteGenre: expected an error for a genre with subgenres")
+	}
+	if _, err := r.DeleteGenre(ctx, epic.ID); err != nil {
+		t.Fatalf("failed to delete genre: %s", err)
+	}
+	genres, err = r.ListGenresByBookID(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to list genres by book id: %s", err)
+	}
+	checkIDs(t, "genres of bookA", genreIDs(genres))
+}
+
+func testTags(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	args := domain.UpdateBookParams{ID: f.bookA, Title: "Book A", Description: "Description", Cover: "cover.jpg"}
+	invalid := []struct {
+		name string
+		tags []string
+		err  error
+	}{
+		{"empty", []string{""}, domain.ErrInvalidTag},
+		{"padded", []string{" dragons"}, domain.ErrInvalidTag},
+		{"duplicate", []string{"dragons", "dragons"}, domain.ErrDuplicateTag},
+	}
+	for _, tc := range invalid {
+		if _, err := r.UpdateBook(ctx, args, credits(f.authorB), nil, tc.tags); err != tc.err {
+			t.Errorf("UpdateBook: expected %v for %s, received %v", tc.err, tc.name, err)
+		}
+	}
+
+	// bookA is about dragons and quests, bookB about dragons
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorB), nil, []string{"quests", "dragons"}); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	args = domain.UpdateBookParams{ID: f.bookB, Title: "Book B", Description: "Description", Cover: "cover.jpg"}
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorA, f.authorB), nil, []string{"dragons"}); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	tags, err := r.ListTagsByBookID(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to list tags by book id: %s", err)
+	}
+	if exp := []string{"dragons", "quests"}; !reflect.DeepEqual(tags, exp) {
+		t.Errorf("wrong tags of bookA: expected %v, received %v", exp, tags)
+	}
+	books, err := r.ListBooksByTag(ctx, "dragons")
+	if err != nil {
+		t.Fatalf("failed to list books by tag: %s", err)
+	}
+	checkIDs(t, "books about dragons", bookIDs(books), f.bookA, f.bookB)
+
+	// updating a book replaces its tags
+	if _, err := r.UpdateBook(ctx, args, credits(f.authorA, f.authorB), nil, []string{"quests"}); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	books, err = r.ListBooksByTag(ctx, "dragons")
+	if err != nil {
+		t.Fatalf("failed to list books by tag: %s", err)
+	}
+	checkIDs(t, "books about dragons", bookIDs(books), f.bookA)
+
+	// the bulk operations set the tags of each book
+	created, err := r.CreateBooks(ctx, []domain.BulkCreateBookArgs{
+		{Book: domain.CreateBookParams{Title: "Book C"}, Contributors: credits(f.authorA), Tags: []string{"dragons"}},
+		{Book: domain.CreateBookParams{Title: "Book D"}, Contributors: credits(f.authorA), Tags: []string{"dragons", "dragons"}},
+	}, domain.BulkBestEffort)
+	if err != nil {
+		t.Fatalf("failed to create books: %s", err)
+	}
+	if created.Errors[0] != nil || created.Books[0] == nil {
+		t.Fatalf("CreateBooks: expected a book, received %v", created.Errors[0])
+	}
+	if created.Errors[1] != domain.ErrDuplicateTag {
+		t.Errorf("CreateBooks: expected %v, received %v", domain.ErrDuplicateTag, created.Errors[1])
+	}
+	books, err = r.ListBooksByTag(ctx, "dragons")
+	if err != nil {
+		t.Fatalf("failed to list books by tag: %s", err)
+	}
+	checkIDs(t, "books about dragons", bookIDs(books), f.bookA, created.Books[0].ID)
+
+	// deleting a book deletes its tags
+	if _, err := r.DeleteBook(ctx, f.bookA); err != nil {
+		t.Fatalf("failed to delete book: %s", err)
+	}
+	tags, err = r.ListTagsByBookID(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to list tags by book id: %s", err)
+	}
+	if len(tags) != 0 {
+		t.Errorf("expected no tags of a deleted book, received %v", tags)
+	}
+}
+
+func testSeries(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	series, err := r.CreateSeries(ctx, domain.CreateSeriesParams{Title: "Series"})
+	if err != nil {
+		t.Fatalf("failed to create series: %s", err)
+	}
+	inSeries := func(id int64, title string, position int) domain.UpdateBookParams {
+		return domain.UpdateBookParams{
+			ID:             id,
+			Title:          title,
+			Description:    "Description",
+			Cover:          "cover.jpg",
+			SeriesID:       &series.ID,
+			SeriesPosition: intPtr(position),
+		}
+	}
+	if _, err := r.UpdateBook(ctx, inSeries(f.bookA, "Book A", 1), credits(f.authorB), nil, nil); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	if _, err := r.UpdateBook(ctx, inSeries(f.bookB, "Book B", 2), credits(f.authorA), nil, nil); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+
+	// positions are positive, unique within the series and set together with
+	// the series
+	invalid := []struct {
+		name string
+		args domain.CreateBookParams
+	}{
+		{"taken position", domain.CreateBookParams{SeriesID: &series.ID, SeriesPosition: intPtr(2)}},
+		{"zero position", domain.CreateBookParams{SeriesID: &series.ID, SeriesPosition: intPtr(0)}},
+		{"no position", domain.CreateBookParams{SeriesID: &series.ID}},
+		{"no series", domain.CreateBookParams{SeriesPosition: intPtr(3)}},
+	}
+	for _, tc := range invalid {
+		if _, err := r.CreateBook(ctx, tc.args, credits(f.authorA), nil, nil); err == nil {
+			t.Errorf("CreateBook: expected an error for %s", tc.name)
+		}
+	}
+	books, err := r.ListBooksBySeriesID(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("failed to list books by series id: %s", err)
+	}
+	checkIDs(t, "books of the series", bookIDs(books), f.bookA, f.bookB)
+
+	// reordering takes every book of the series exactly once
+	for _, ids := range [][]int64{{f.bookB}, {f.bookB, f.bookB}, {f.bookB, f.bookA, f.bookA}} {
+		if _, err := r.ReorderSeries(ctx, series.ID, ids); !errors.Is(err, domain.ErrSeriesBooksMismatch) {
+			t.Errorf("ReorderSeries %v: expected ErrSeriesBooksMismatch, received %v", ids, err)
+		}
+	}
+	if _, err := r.ReorderSeries(ctx, series.ID, []int64{f.bookB, f.bookA}); err != nil {
+		t.Fatalf("failed to reorder series: %s", err)
+	}
+	books, err = r.ListBooksBySeriesID(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("failed to list books by series id: %s", err)
+	}
+	checkIDs(t, "books of the reordered series", bookIDs(books), f.bookB, f.bookA)
+
+	// deleting a series keeps its books outside of any series
+	if _, err := r.DeleteSeries(ctx, series.ID); err != nil {
+		t.Fatalf("failed to delete series: %s", err)
+	}
+	bookA, err := r.GetBook(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to get book: %s", err)
+	}
+	if bookA.SeriesID != nil || bookA.SeriesPosition != nil {
+		t.Errorf("expected no series, received %v at %v", bookA.SeriesID, bookA.SeriesPosition)
+	}
+}
+
+func testBibliographic(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	isbn := domain.ISBN("9780306406157")
+	publishedOn := domain.Date{Year: 2020, Month: time.March, Day: 5}
+	language := domain.LanguageCode("en")
+	book, err := r.UpdateBook(ctx, domain.UpdateBookParams{
+		ID:          f.bookA,
+		Title:       "Book A",
+		Description: "Description",
+		Cover:       "cover.jpg",
+		ISBN:        &isbn,
+		PublishedOn: &publishedOn,
+		PageCount:   intPtr(320),
+		Language:    &language,
+	}, credits(f.authorB), nil, nil)
+	if err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	received, err := r.GetBookByISBN(ctx, "9780306406157")
+	if err != nil {
+		t.Fatalf("failed to get book by isbn: %s", err)
+	}
+	if received.ID != book.ID || received.PageCount == nil || *received.PageCount != 320 ||
+		received.Language == nil || *received.Language != language {
+		t.Errorf("expected %v, received %v", book, received)
+	}
+	if received.PublishedOn == nil || *received.PublishedOn != publishedOn {
+		t.Errorf("expected publication date %v, received %v", publishedOn, received.PublishedOn)
+	}
+
+	// ISBNs are unique, page counts positive and languages two letter codes
+	invalid := []struct {
+		name string
+		args domain.CreateBookParams
+	}{
+		{"taken isbn", domain.CreateBookParams{ISBN: &isbn}},
+		{"isbn-10", domain.CreateBookParams{ISBN: isbnPtr("0306406152")}},
+		{"zero page count", domain.CreateBookParams{PageCount: intPtr(0)}},
+		{"language name", domain.CreateBookParams{Language: languagePtr("English")}},
+	}
+	for _, tc := range invalid {
+		if _, err := r.CreateBook(ctx, tc.args, credits(f.authorA), nil, nil); err == nil {
+			t.Errorf("CreateBook: expected an error for %s", tc.name)
+		}
+	}
+
+	// the bulk operations reject a zero page count too rather than storing
+	// no page count
+	created, err := r.CreateBooks(ctx, []domain.BulkCreateBookArgs{
+		{Book: domain.CreateBookParams{Title: "Book C", PageCount: intPtr(0)}, Contributors: credits(f.authorA)},
+		{Book: domain.CreateBookParams{Title: "Book D", PageCount: intPtr(200)}, Contributors: credits(f.authorA)},
+	}, domain.BulkBestEffort)
+	if err != nil {
+		t.Fatalf("failed to create books: %s", err)
+	}
+	if created.Errors[0] != domain.ErrInvalidPageCount || created.Books[0] != nil {
+		t.Errorf("CreateBooks: expected %v, received %v", domain.ErrInvalidPageCount, created.Errors[0])
+	}
+	if created.Errors[1] != nil || created.Books[1] == nil || created.Books[1].PageCount == nil || *created.Books[1].PageCount != 200 {
+		t.Errorf("CreateBooks: expected a book with 200 pages, received %v, %v", created.Books[1], created.Errors[1])
+	}
+	updated, err := r.UpdateBooks(ctx, []domain.BulkUpdateBookArgs{
+		{Book: domain.UpdateBookParams{ID: f.bookB, Title: "Book B", PageCount: intPtr(0)}, Contributors: credits(f.authorA, f.authorB)},
+	}, domain.BulkBestEffort)
+	if err != nil {
+		t.Fatalf("failed to update books: %s", err)
+	}
+	if updated.Errors[0] != domain.ErrInvalidPageCount {
+		t.Errorf("UpdateBooks: expected %v, received %v", domain.ErrInvalidPageCount, updated.Errors[0])
+	}
+
+	// a book keeps its own ISBN when updated
+	if _, err := r.UpdateBook(ctx, domain.UpdateBookParams{
+		ID:    f.bookA,
+		Title: "Book A, Revised",
+		ISBN:  &isbn,
+	}, credits(f.authorB), nil, nil); err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+}
+
+func testEditions(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	date := func(year int, month time.Month, day int) *domain.Date {
+		return &domain.Date{Year: year, Month: month, Day: day}
+	}
+	var ids []int64
+	for _, args := range []domain.CreateEditionParams{
+		{
+			BookID:      f.bookA,
+			Format:      "HARDCOVER",
+			ISBN:        isbnPtr("9780306406157"),
+			PublishedOn: date(2019, time.May, 1),
+			Price:       &domain.Price{Amount: 2500, Currency: "USD"},
+		},
+		{BookID: f.bookA, Format: "EBOOK"},
+		{BookID: f.bookB, Format: "PAPERBACK", PublishedOn: date(2020, time.June, 1)},
+		{BookID: f.bookB, Format: "HARDCOVER", PublishedOn: date(2018, time.July, 1)},
+	} {
+		edition, err := r.CreateEdition(ctx, args)
+		if err != nil {
+			t.Fatalf("failed to create edition: %s", err)
+		}
+		ids = append(ids, edition.ID)
+	}
+
+	editions, err := r.ListEditionsByBookID(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to list editions by book id: %s", err)
+	}
+	checkIDs(t, "editions of the book", editionIDs(editions), ids[0], ids[1])
+	edition, err := r.GetEditionByISBN(ctx, "9780306406157")
+	if err != nil {
+		t.Fatalf("failed to get edition by isbn: %s", err)
+	}
+	checkIDs(t, "edition by isbn", []int64{edition.ID}, ids[0])
+
+	hardcover := domain.EditionHardcover
+	filters := []struct {
+		name string
+		args domain.ListEditionsParams
+		ids  []int64
+	}{
+		{"none", domain.ListEditionsParams{}, ids},
+		{"book", domain.ListEditionsParams{BookID: &f.bookB}, ids[2:]},
+		{"format", domain.ListEditionsParams{Format: &hardcover}, []int64{ids[0], ids[3]}},
+		{"published from", domain.ListEditionsParams{PublishedFrom: date(2019, time.May, 1)}, []int64{ids[0], ids[2]}},
+		{"published to", domain.ListEditionsParams{PublishedTo: date(2019, time.May, 1)}, []int64{ids[0], ids[3]}},
+		{"all", domain.ListEditionsParams{
+			BookID:        &f.bookB,
+			Format:        &hardcover,
+			PublishedFrom: date(2018, time.January, 1),
+			PublishedTo:   date(2018, time.December, 31),
+		}, ids[3:]},
+	}
+	for _, tc := range filters {
+		editions, err := r.ListEditions(ctx, tc.args)
+		if err != nil {
+			t.Fatalf("failed to list editions: %s", err)
+		}
+		checkIDs(t, "editions filtered by "+tc.name, editionIDs(editions), tc.ids...)
+	}
+
+	invalid := []struct {
+		name string
+		args domain.CreateEditionParams
+	}{
+		{"unknown book", domain.CreateEditionParams{BookID: 1 << 40, Format: "EBOOK"}},
+		{"unknown format", domain.CreateEditionParams{BookID: f.bookA, Format: "SCROLL"}},
+		{"taken isbn", domain.CreateEditionParams{BookID: f.bookB, Format: "EBOOK", ISBN: isbnPtr("9780306406157")}},
+		{"price without currency", domain.CreateEditionParams{BookID: f.bookB, Format: "EBOOK", Price: &domain.Price{Amount: 100}}},
+		{"negative price", domain.CreateEditionParams{BookID: f.bookB, Format: "EBOOK", Price: &domain.Price{Amount: -1, Currency: "USD"}}},
+	}
+	for _, tc := range invalid {
+		if _, err := r.CreateEdition(ctx, tc.args); err == nil {
+			t.Errorf("CreateEdition: expected an error for %s", tc.name)
+		}
+	}
+
+	// an edition can move to another book, and goes away with its book
+	if _, err := r.UpdateEdition(ctx, domain.UpdateEditionParams{ID: ids[1], BookID: f.bookB, Format: "EBOOK"}); err != nil {
+		t.Fatalf("failed to update edition: %s", err)
+	}
+	if _, err := r.DeleteBook(ctx, f.bookB); err != nil {
+		t.Fatalf("failed to delete book: %s", err)
+	}
+	editions, err = r.ListEditions(ctx, domain.ListEditionsParams{})
+	if err != nil {
+		t.Fatalf("failed to list editions: %s", err)
+	}
+	checkIDs(t, "editions after deleting the book", editionIDs(editions), ids[0])
+}
+
+func testContributors(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	args := domain.UpdateBookParams{ID: f.bookB, Title: "Book B", Description: "Description", Cover: "cover.jpg"}
+
+	// authors are listed in position order regardless of the input order
+	_, err := r.UpdateBook(ctx, args, []domain.Contributor{
+		{AuthorID: f.authorA, Role: "TRANSLATOR", Position: 2},
+		{AuthorID: f.authorB, Role: "AUTHOR", Position: 1},
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to update book: %s", err)
+	}
+	authors, err := r.ListAuthorsByBookID(ctx, f.bookB)
+	if err != nil {
+		t.Fatalf("failed to list authors by book id: %s", err)
+	}
+	checkIDs(t, "authors of bookB", authorIDs(authors), f.authorB, f.authorA)
+	contributors, err := r.ListContributorsByBookID(ctx, f.bookB)
+	if err != nil {
+		t.Fatalf("failed to list contributors by book id: %s", err)
+	}
+	if len(contributors) != 2 ||
+		contributors[0].AuthorID != f.authorB || contributors[0].Role != "AUTHOR" || contributors[0].Position != 1 ||
+		contributors[1].AuthorID != f.authorA || contributors[1].Role != "TRANSLATOR" || contributors[1].Position != 2 {
+		t.Errorf("unexpected contributors of bookB: %v", contributors)
+	}
+
+	// roles are limited and positions positive
+	invalid := []struct {
+		name         string
+		contributors []domain.Contributor
+	}{
+		{"unknown role", []domain.Contributor{{AuthorID: f.authorA, Role: "GHOSTWRITER", Position: 1}}},
+		{"zero position", []domain.Contributor{{AuthorID: f.authorA, Role: "AUTHOR", Position: 0}}},
+	}
+	for _, tc := range invalid {
+		if _, err := r.UpdateBook(ctx, args, tc.contributors, nil, nil); err == nil {
+			t.Errorf("UpdateBook: expected an error for %s", tc.name)
+		}
+	}
+	contributors, err = r.ListContributorsByBookID(ctx, f.bookB)
+	if err != nil {
+		t.Fatalf("failed to list contributors by book id: %s", err)
+	}
+	if len(contributors) != 2 {
+		t.Errorf("expected the contributors of bookB to be kept, received %v", contributors)
+	}
+}
+
+func testRepresentations(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+	startedAt := domain.Date{Year: 2020, Month: time.January, Day: 1}
+	dayBefore := domain.Date{Year: 2019, Month: time.December, Day: 31}
+	yearAfter := domain.Date{Year: 2021, Month: time.January, Day: 1}
+
+	// creating an author starts a current worldwide primary representation
+	representations, err := r.ListRepresentationsByAuthorID(ctx, f.authorA)
+	if err != nil {
+		t.Fatalf("failed to list representations: %s", err)
+	}
+	if len(representations) != 1 || representations[0].AgentID != f.agentA ||
+		!representations[0].Primary || representations[0].Territory != "WORLD" || representations[0].EndedAt != nil {
+		t.Fatalf("unexpected representations of authorA: %v", representations)
+	}
+	primary := representations[0]
+	if _, err := r.EndRepresentation(ctx, primary.ID, domain.DateOf(time.Now())); err != domain.ErrPrimaryRepresentation {
+		t.Errorf("EndRepresentation: expected ErrPrimaryRepresentation, received %v", err)
+	}
+
+	// co-representations are never primary and check their territory and period
+	co, err := r.CreateRepresentation(ctx, domain.CreateRepresentationParams{
+		AuthorID:  f.authorA,
+		AgentID:   f.agentB,
+		Territory: "FR",
+		StartedAt: startedAt,
+	})
+	if err != nil {
+		t.Fatalf("failed to create representation: %s", err)
+	}
+	if co.Primary || co.EndedAt != nil {
+		t.Errorf("expected a current co-representation, received %v", co)
+	}
+	if _, err := r.CreateRepresentation(ctx, domain.CreateRepresentationParams{
+		AuthorID:  f.authorA,
+		AgentID:   f.agentB,
+		Territory: "France",
+		StartedAt: startedAt,
+	}); err == nil {
+		t.Errorf("CreateRepresentation: expected an error for an invalid territory")
+	}
+	if _, err := r.EndRepresentation(ctx, co.ID, dayBefore); err == nil {
+		t.Errorf("EndRepresentation: expected an error for an end before the start")
+	}
+
+	// changing the agent ends the primary representation and the
+	// co-representation by the new agent, and starts a new primary one
+	_, err = r.UpdateAuthor(ctx, domain.UpdateAuthorParams{ID: f.authorA, Name: "Author A", AgentID: f.agentB})
+	if err != nil {
+		t.Fatalf("failed to update author: %s", err)
+	}
+	representations, err = r.ListRepresentationsByAuthorID(ctx, f.authorA)
+	if err != nil {
+		t.Fatalf("failed to list representations: %s", err)
+	}
+	if len(representations) != 3 ||
+		representations[0].ID != co.ID || representations[0].EndedAt == nil ||
+		representations[1].ID != primary.ID || representations[1].EndedAt == nil ||
+		representations[2].AgentID != f.agentB || !representations[2].Primary || representations[2].EndedAt != nil {
+		t.Errorf("unexpected representations of authorA: %v", representations)
+	}
+	// the agent of an author is the one of its current primary representation
+	author, err := r.GetAuthor(ctx, f.authorA)
+	if err != nil {
+		t.Fatalf("failed to get author: %s", err)
+	}
+	if author.AgentID != f.agentB {
+		t.Errorf("expected agent %d, received %d", f.agentB, author.AgentID)
+	}
+	authors, err := r.ListAuthorsByAgentID(ctx, f.agentB)
+	if err != nil {
+		t.Fatalf("failed to list authors by agent id: %s", err)
+	}
+	checkIDs(t, "authors of agentB", authorIDs(authors), f.authorB, f.authorA)
+
+	// updating an author without changing the agent keeps the history
+	_, err = r.UpdateAuthor(ctx, domain.UpdateAuthorParams{ID: f.authorA, Name: "Author A.", AgentID: f.agentB})
+	if err != nil {
+		t.Fatalf("failed to update author: %s", err)
+	}
+	representations, err = r.ListRepresentationsByAuthorID(ctx, f.authorA)
+	if err != nil {
+		t.Fatalf("failed to list representations: %s", err)
+	}
+	if len(representations) != 3 {
+		t.Errorf("expected 3 representations of authorA, received %v", representations)
+	}
+
+	former, err := r.ListFormerAuthorsByAgentID(ctx, f.agentA)
+	if err != nil {
+		t.Fatalf("failed to list former authors: %s", err)
+	}
+	checkIDs(t, "former authors of agentA", authorIDs(former), f.authorA)
+	former, err = r.ListFormerAuthorsByAgentID(ctx, f.agentB)
+	if err != nil {
+		t.Fatalf("failed to list former authors: %s", err)
+	}
+	checkIDs(t, "former authors of agentB", authorIDs(former))
+
+	// a new co-representation by the former agent makes it current again
+	co, err = r.CreateRepresentation(ctx, domain.CreateRepresentationParams{
+		AuthorID:  f.authorA,
+		AgentID:   f.agentA,
+		Territory: "DE",
+		StartedAt: startedAt,
+	})
+	if err != nil {
+		t.Fatalf("failed to create representation: %s", err)
+	}
+	former, err = r.ListFormerAuthorsByAgentID(ctx, f.agentA)
+	if err != nil {
+		t.Fatalf("failed to list former authors: %s", err)
+	}
+	checkIDs(t, "former authors of agentA", authorIDs(former))
+	ended, err := r.EndRepresentation(ctx, co.ID, yearAfter)
+	if err != nil {
+		t.Fatalf("failed to end representation: %s", err)
+	}
+	if ended.EndedAt == nil || *ended.EndedAt != yearAfter {
+		t.Errorf("expected the representation to end a year after it started, received %v", ended.EndedAt)
+	}
+	former, err = r.ListFormerAuthorsByAgentID(ctx, f.agentA)
+	if err != nil {
+		t.Fatalf("failed to list former authors: %s", err)
+	}
+	checkIDs(t, "former authors of agentA", authorIDs(former), f.authorA)
+
+	// deleting the author removes its representations
+	if _, err := r.DeleteAuthor(ctx, f.authorA, domain.OrphanedBooksKeep); err != nil {
+		t.Fatalf("failed to delete author: %s", err)
+	}
+	representations, err = r.ListRepresentationsByAuthorID(ctx, f.authorA)
+	if err != nil || len(representations) != 0 {
+		t.Errorf("expected no representations of a deleted author, received %v, %v", representations, err)
+	}
+}
+
+func testDeals(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	publisher, err := r.CreatePublisher(ctx, domain.CreatePublisherParams{Name: "Publisher"})
+	if err != nil {
+		t.Fatalf("failed to create publisher: %s", err)
+	}
+	signedOn := &domain.Date{Year: 2021, Month: time.June, Day: 1}
+	var ids []int64
+	for _, args := range []domain.CreateDealParams{
+		{
+			BookID:      f.bookA,
+			PublisherID: publisher.ID,
+			AgentID:     f.agentA,
+			Territory:   "FR",
+			Language:    "fr",
+			Format:      "PAPERBACK",
+			Advance:     domain.Money{Amount: 1500000, Currency: "EUR"},
+			SignedOn:    signedOn,
+			Status:      "SIGNED",
+		},
+		{
+			BookID:      f.bookA,
+			PublisherID: publisher.ID,
+			AgentID:     f.agentA,
+			Territory:   "WORLD",
+			Language:    "en",
+			Format:      "EBOOK",
+			Advance:     domain.Money{Currency: "USD"},
+			Status:      "NEGOTIATING",
+		},
+		{
+			BookID:      f.bookB,
+			PublisherID: publisher.ID,
+			AgentID:     f.agentB,
+			Territory:   "DE",
+			Language:    "de",
+			Format:      "HARDCOVER",
+			Advance:     domain.Money{Amount: 500000, Currency: "EUR"},
+			Status:      "NEGOTIATING",
+		},
+	} {
+		deal, err := r.CreateDeal(ctx, args)
+		if err != nil {
+			t.Fatalf("failed to create deal: %s", err)
+		}
+		ids = append(ids, deal.ID)
+	}
+
+	deals, err := r.ListDealsByBookID(ctx, f.bookA)
+	if err != nil {
+		t.Fatalf("failed to list deals by book id: %s", err)
+	}
+	checkIDs(t, "deals of bookA", dealIDs(deals), ids[0], ids[1])
+	deals, err = r.ListDealsByAgentID(ctx, f.agentB)
+	if err != nil {
+		t.Fatalf("failed to list deals by agent id: %s", err)
+	}
+	checkIDs(t, "deals of agentB", dealIDs(deals), ids[2])
+
+	// rights held by a deal in the territory, or worldwide, are not available;
+	// asking for WORLD rights excludes deals in any territory
+	all := []domain.EditionFormat{"HARDCOVER", "PAPERBACK", "EBOOK", "AUDIOBOOK"}
+	rights := func(bookA, bookB []domain.EditionFormat) []domain.AvailableRights {
+		return []domain.AvailableRights{
+			{BookID: f.bookA, Formats: bookA},
+			{BookID: f.bookB, Formats: bookB},
+		}
+	}
+	available := []struct {
+		name      string
+		territory string
+		language  domain.LanguageCode
+		rights    []domain.AvailableRights
+	}{
+		{"FR/fr", "FR", "fr", rights(
+			[]domain.EditionFormat{"HARDCOVER", "EBOOK", "AUDIOBOOK"}, all,
+		)},
+		{"FR/en", "FR", "en", rights(
+			[]domain.EditionFormat{"HARDCOVER", "PAPERBACK", "AUDIOBOOK"}, all,
+		)},
+		{"WORLD/de", "WORLD", "de", rights(
+			all, []domain.EditionFormat{"PAPERBACK", "EBOOK", "AUDIOBOOK"},
+		)},
+		{"IT/it", "IT", "it", rights(all, all)},
+	}
+	for _, tc := range available {
+		received, err := r.ListAvailableRights(ctx, tc.territory, tc.language)
+		if err != nil {
+			t.Fatalf("failed to list available rights: %s", err)
+		}
+		if !reflect.DeepEqual(received, tc.rights) {
+			t.Errorf("available rights for %s: expected %v, received %v", tc.name, tc.rights, received)
+		}
+	}
+
+	// terminating a deal releases its rights
+	if _, err := r.UpdateDeal(ctx, domain.UpdateDealParams{
+		ID:          ids[0],
+		BookID:      f.bookA,
+		PublisherID: publisher.ID,
+		AgentID:     f.agentA,
+		Territory:   "FR",
+		Language:    "fr",
+		Format:      "PAPERBACK",
+		Advance:     domain.Money{Amount: 1500000, Currency: "EUR"},
+		SignedOn:    signedOn,
+		Status:      "TERMINATED",
+	}); err != nil {
+		t.Fatalf("failed to update deal: %s", err)
+	}
+	received, err := r.ListAvailableRights(ctx, "FR", "fr")
+	if err != nil {
+		t.Fatalf("failed to list available rights: %s", err)
+	}
+	if exp := rights(all, all); !reflect.DeepEqual(received, exp) {
+		t.Errorf("available rights after termination: expected %v, received %v", exp, received)
+	}
+
+	valid := domain.CreateDealParams{
+		BookID:      f.bookB,
+		PublisherID: publisher.ID,
+		AgentID:     f.agentB,
+		Territory:   "FR",
+		Language:    "fr",
+		Format:      "EBOOK",
+		Advance:     domain.Money{Currency: "EUR"},
+		Status:      "NEGOTIATING",
+	}
+	invalid := []struct {
+		name   string
+		modify func(args *domain.CreateDealParams)
+	}{
+		{"unknown publisher", func(args *domain.CreateDealParams) { args.PublisherID = 1 << 40 }},
+		{"unknown territory", func(args *domain.CreateDealParams) { args.Territory = "France" }},
+		{"unknown format", func(args *domain.CreateDealParams) { args.Format = "SCROLL" }},
+		{"negative advance", func(args *domain.CreateDealParams) { args.Advance.Amount = -1 }},
+		{"unknown status", func(args *domain.CreateDealParams) { args.Status = "PENDING" }},
+		{"signed without a date", func(args *domain.CreateDealParams) { args.Status = "SIGNED" }},
+		{"negotiating with a date", func(args *domain.CreateDealParams) { args.SignedOn = signedOn }},
+		{"negative royalty rate", func(args *domain.CreateDealParams) { args.RoyaltyRate = -1 }},
+		{"commission over 100%", func(args *domain.CreateDealParams) { args.CommissionRate = 10001 }},
+	}
+	for _, tc := range invalid {
+		args := valid
+		tc.modify(&args)
+		if _, err := r.CreateDeal(ctx, args); err == nil {
+			t.Errorf("CreateDeal: expected an error for %s", tc.name)
+		}
+	}
+
+	// a publisher with deals can't be deleted, while deleting a book removes
+	// its deals
+	if _, err := r.DeletePublisher(ctx, publisher.ID); err == nil {
+		t.Errorf("DeletePublisher: expected an error for a publisher with deals")
+	}
+	if _, err := r.DeleteBook(ctx, f.bookA); err != nil {
+		t.Fatalf("failed to delete book: %s", err)
+	}
+	deals, err = r.ListDealsByAgentID(ctx, f.agentA)
+	if err != nil || len(deals) != 0 {
+		t.Errorf("expected no deals of a deleted book, received %v, %v", deals, err)
+	}
+	if _, err := r.DeleteDeal(ctx, ids[2]); err != nil {
+		t.Fatalf("failed to delete deal: %s", err)
+	}
+	if _, err := r.DeletePublisher(ctx, publisher.ID); err != nil {
+		t.Errorf("failed to delete publisher without deals: %s", err)
+	}
+}
+
+func testRoyalties(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	publisher, err := r.CreatePublisher(ctx, domain.CreatePublisherParams{Name: "Publisher"})
+	if err != nil {
+		t.Fatalf("failed to create publisher: %s", err)
+	}
+	var dealB, dealBEbook, dealA int64
+	for _, d := range []struct {
+		id             *int64
+		bookID         int64
+		agentID        int64
+		format         domain.EditionFormat
+		royaltyRate    int
+		commissionRate int
+	}{
+		{&dealB, f.bookB, f.agentA, "HARDCOVER", 1000, 1500},
+		{&dealBEbook, f.bookB, f.agentA, "EBOOK", 1000, 1500},
+		{&dealA, f.bookA, f.agentB, "HARDCOVER", 800, 2000},
+	} {
+		deal, err := r.CreateDeal(ctx, domain.CreateDealParams{
+			BookID:         d.bookID,
+			PublisherID:    publisher.ID,
+			AgentID:        d.agentID,
+			Territory:      "WORLD",
+			Language:       "en",
+			Format:         d.format,
+			Advance:        domain.Money{Currency: "USD"},
+			Status:         "NEGOTIATING",
+			RoyaltyRate:    d.royaltyRate,
+			CommissionRate: d.commissionRate,
+		})
+		if err != nil {
+			t.Fatalf("failed to create deal: %s", err)
+		}
+		*d.id = deal.ID
+	}
+	var editions []int64
+	for _, args := range []domain.CreateEditionParams{
+		{BookID: f.bookB, Format: "HARDCOVER"},
+		{BookID: f.bookB, Format: "EBOOK"},
+		{BookID: f.bookA, Format: "HARDCOVER"},
+	} {
+		edition, err := r.CreateEdition(ctx, args)
+		if err != nil {
+			t.Fatalf("failed to create edition: %s", err)
+		}
+		editions = append(editions, edition.ID)
+	}
+
+	// setting a rate again replaces it
+	for _, rate := range []int{2500, 2000} {
+		if _, err := r.SetRoyaltyRate(ctx, dealBEbook, editions[1], rate); err != nil {
+			t.Fatalf("failed to set royalty rate: %s", err)
+		}
+	}
+	rates, err := r.ListRoyaltyRatesByDealID(ctx, dealBEbook)
+	if err != nil {
+		t.Fatalf("failed to list royalty rates: %s", err)
+	}
+	if len(rates) != 1 || rates[0].Rate != 2000 {
+		t.Errorf("expected a single rate of 2000, received %v", rates)
+	}
+
+	record := func(dealID, editionID int64, period string, units int, revenue int64) int64 {
+		t.Helper()
+		sales, err := r.RecordSales(ctx, domain.RecordSalesParams{
+			DealID:    dealID,
+			EditionID: editionID,
+			Period:    period,
+			Units:     units,
+			Revenue:   domain.Money{Amount: revenue, Currency: "USD"},
+		})
+		if err != nil {
+			t.Fatalf("failed to record sales: %s", err)
+		}
+		return sales.ID
+	}
+	s1 := record(dealB, editions[0], "2024-Q1", 100, 200000)
+	s2 := record(dealBEbook, editions[1], "2024-Q1", 50, 50000)
+	s3 := record(dealA, editions[2], "2024-Q1", 10, 10000)
+	s4 := record(dealB, editions[0], "2024-Q2", 80, 160000)
+	// recording the sales of a quarter again replaces them
+	if id := record(dealB, editions[0], "2024-Q1", 120, 240000); id != s1 {
+		t.Errorf("expected the sales %d to be replaced, received %d", s1, id)
+	}
+	sales, err := r.ListSalesByDealID(ctx, dealB)
+	if err != nil {
+		t.Fatalf("failed to list sales: %s", err)
+	}
+	checkIDs(t, "sales of dealB", salesIDs(sales), s1, s4)
+	if sales[0].Units != 120 || sales[0].Revenue.Amount != 240000 {
+		t.Errorf("expected the recorded sales to be replaced, received %v", sales[0])
+	}
+
+	usd := domain.Money{Currency: "USD"}
+	invalid := []struct {
+		name string
+		args domain.RecordSalesParams
+	}{
+		{"unknown deal", domain.RecordSalesParams{DealID: 1 << 40, EditionID: editions[0], Period: "2024-Q1", Revenue: usd}},
+		{"unknown period", domain.RecordSalesParams{DealID: dealB, EditionID: editions[0], Period: "2024-03", Revenue: usd}},
+		{"negative units", domain.RecordSalesParams{DealID: dealB, EditionID: editions[0], Period: "2024-Q3", Units: -1, Revenue: usd}},
+		{"unknown currency", domain.RecordSalesParams{DealID: dealB, EditionID: editions[0], Period: "2024-Q3", Revenue: domain.Money{Currency: "usd"}}},
+	}
+	for _, tc := range invalid {
+		if _, err := r.RecordSales(ctx, tc.args); err == nil {
+			t.Errorf("RecordSales: expected an error for %s", tc.name)
+		}
+	}
+	if _, err := r.SetRoyaltyRate(ctx, dealB, editions[0], 10001); err == nil {
+		t.Errorf("SetRoyaltyRate: expected an error for a rate over 100%%")
+	}
+
+	// royalty rates and sales are for an edition of the book and format of
+	// the deal
+	mismatched := []struct {
+		name      string
+		dealID    int64
+		editionID int64
+	}{
+		{"an edition in another format", dealB, editions[1]},
+		{"an edition of another book", dealB, editions[2]},
+	}
+	for _, tc := range mismatched {
+		if _, err := r.SetRoyaltyRate(ctx, tc.dealID, tc.editionID, 1000); err != domain.ErrEditionNotInDeal {
+			t.Errorf("SetRoyaltyRate: expected ErrEditionNotInDeal for %s, received %v", tc.name, err)
+		}
+		_, err := r.RecordSales(ctx, domain.RecordSalesParams{DealID: tc.dealID, EditionID: tc.editionID, Period: "2024-Q1", Revenue: usd})
+		if err != domain.ErrEditionNotInDeal {
+			t.Errorf("RecordSales: expected ErrEditionNotInDeal for %s, received %v", tc.name, err)
+		}
+	}
+	// and deals and editions with sales keep their book and format
+	deal, err := r.GetDeal(ctx, dealB)
+	if err != nil {
+		t.Fatalf("failed to get deal: %s", err)
+	}
+	_, err = r.UpdateDeal(ctx, domain.UpdateDealParams{
+		ID:             deal.ID,
+		BookID:         deal.BookID,
+		PublisherID:    deal.PublisherID,
+		AgentID:        deal.AgentID,
+		Territory:      deal.Territory,
+		Language:       deal.Language,
+		Format:         "AUDIOBOOK",
+		Advance:        deal.Advance,
+		SignedOn:       deal.SignedOn,
+		Status:         deal.Status,
+		RoyaltyRate:    deal.RoyaltyRate,
+		CommissionRate: deal.CommissionRate,
+	})
+	if err != domain.ErrEditionNotInDeal {
+		t.Errorf("UpdateDeal: expected ErrEditionNotInDeal, received %v", err)
+	}
+	if _, err := r.UpdateEdition(ctx, domain.UpdateEditionParams{ID: editions[0], BookID: f.bookA, Format: "HARDCOVER"}); err != domain.ErrEditionNotInDeal {
+		t.Errorf("UpdateEdition: expected ErrEditionNotInDeal, received %v", err)
+	}
+	sales, err = r.ListSalesByDealID(ctx, dealB)
+	if err != nil {
+		t.Fatalf("failed to list sales: %s", err)
+	}
+	checkIDs(t, "sales of dealB", salesIDs(sales), s1, s4)
+
+	// authorA wrote bookB with authorB, who also wrote bookA alone
+	// royaltySales returns the sales on the lines of the statement of the
+	// author, along with the terms they earn royalties on
+	royaltySales := func(authorID int64) []domain.RoyaltySales {
+		t.Helper()
+		statement, err := r.GetRoyaltyStatement(ctx, authorID, "2024-Q1")
+		if err != nil {
+			t.Fatalf("failed to get royalty statement: %s", err)
+		}
+		var rows []domain.RoyaltySales
+		for _, line := range statement.Lines {
+			rows = append(rows, domain.RoyaltySales{
+				Sales:          line.Sales,
+				BookID:         line.BookID,
+				AgentID:        line.AgentID,
+				RoyaltyRate:    line.RoyaltyRate,
+				CommissionRate: line.CommissionRate,
+				Authors:        line.Authors,
+			})
+		}
+		return rows
+	}
+	sold := func(id, dealID, editionID int64, units int, revenue int64) domain.Sales {
+		return domain.Sales{
+			ID:        id,
+			DealID:    dealID,
+			EditionID: editionID,
+			Period:    "2024-Q1",
+			Units:     units,
+			Revenue:   domain.Money{Amount: revenue, Currency: "USD"},
+		}
+	}
+	exp := []domain.RoyaltySales{
+		{
+			Sales:  sold(s1, dealB, editions[0], 120, 240000),
+			BookID: f.bookB, AgentID: f.agentA, RoyaltyRate: 1000, CommissionRate: 1500, Authors: 2,
+		},
+		{
+			Sales:  sold(s2, dealBEbook, editions[1], 50, 50000),
+			BookID: f.bookB, AgentID: f.agentA, RoyaltyRate: 2000, CommissionRate: 1500, Authors: 2,
+		},
+		{
+			Sales:  sold(s3, dealA, editions[2], 10, 10000),
+			BookID: f.bookA, AgentID: f.agentB, RoyaltyRate: 800, CommissionRate: 2000, Authors: 1,
+		},
+	}
+	if rows := royaltySales(f.authorA); !reflect.DeepEqual(rows, exp[:2]) {
+		t.Errorf("royalty sales of authorA: expected %v, received %v", exp[:2], rows)
+	}
+	if rows := royaltySales(f.authorB); !reflect.DeepEqual(rows, exp) {
+		t.Errorf("royalty sales of authorB: expected %v, received %v", exp, rows)
+	}
+
+	// without its own rate an edition earns the rate of the deal
+	if _, err := r.DeleteRoyaltyRate(ctx, dealBEbook, editions[1]); err != nil {
+		t.Fatalf("failed to delete royalty rate: %s", err)
+	}
+	if rows := royaltySales(f.authorA); len(rows) != 2 || rows[1].RoyaltyRate != 1000 {
+		t.Errorf("expected the rate of the deal, received %v", rows)
+	}
+
+	// sales go with their edition and their deal
+	if _, err := r.DeleteEdition(ctx, editions[1]); err != nil {
+		t.Fatalf("failed to delete edition: %s", err)
+	}
+	if _, err := r.DeleteDeal(ctx, dealA); err != nil {
+		t.Fatalf("failed to delete deal: %s", err)
+	}
+	if _, err := r.DeleteSales(ctx, s4); err != nil {
+		t.Fatalf("failed to delete sales: %s", err)
+	}
+	rows := royaltySales(f.authorB)
+	if len(rows) != 1 || rows[0].ID != s1 {
+		t.Errorf("royalty sales of authorB: expected only %d, received %v", s1, rows)
+	}
+	sales, err = r.ListSalesByDealID(ctx, dealB)
+	if err != nil {
+		t.Fatalf("failed to list sales: %s", err)
+	}
+	checkIDs(t, "sales of dealB", salesIDs(sales), s1)
+}
+
+func testSubmissions(ctx context.Context, t *testing.T, r domain.Repository) {
+	f := newFixture(ctx, t, r)
+
+	submit := func(agentID int64, title string) domain.Submission {
+		t.Helper()
+		submission, err := r.CreateSubmission(ctx, domain.CreateSubmissionParams{
+			AgentID:       agentID,
+			AuthorName:    "Debut Author",
+			AuthorWebsite: stringPtr("https://debut.test"),
+			Title:         title,
+			Synopsis:      "Synopsis",
+		})
+		if err != nil {
+			t.Fatalf("failed to create submission: %s", err)
+		}
+		return submission
+	}
+	s1 := submit(f.agentA, "Manuscript 1")
+	s2 := submit(f.agentA, "Manuscript 2")
+	s3 := submit(f.agentB, "Manuscript 3")
+	if s1.Status != domain.SubmissionReceived || s1.BookID != nil {
+		t.Errorf("expected a received submission without a book, received %v", s1)
+	}
+	if _, err := r.CreateSubmission(ctx, domain.CreateSubmissionParams{AgentID: 1 << 40, AuthorName: "x", Title: "x"}); err == nil {
+		t.Error("CreateSubmission: expected an error for an unknown agent")
+	}
+	submissions, err := r.ListSubmissionsByAgentID(ctx, f.agentA)
+	if err != nil {
+		t.Fatalf("failed to list submissions: %s", err)
+	}
+	checkIDs(t, "submissions of agentA", submissionIDs(submissions), s1.ID, s2.ID)
+
+	move := func(id int64, status domain.SubmissionStatus, note string) error {
+		t.Helper()
+		var notePtr *string
+		if note != "" {
+			notePtr = &note
+		}
+		_, err := r.TransitionSubmission(ctx, id, status, notePtr)
+		return err
+	}
+	for _, status := range []domain.SubmissionStatus{domain.SubmissionReading, domain.SubmissionRequestedFull, domain.SubmissionOffered} {
+		if err := move(s1.ID, status, "moved to "+string(status)); err != nil {
+			t.Fatalf("failed to move submission to %s: %s", status, err)
+		}
+	}
+	transitions, err := r.ListSubmissionTransitions(ctx, s1.ID)
+	if err != nil {
+		t.Fatalf("failed to list transitions: %s", err)
+	}
+	var moves [][2]domain.SubmissionStatus
+	for _, tr := range transitions {
+		moves = append(moves, [2]domain.SubmissionStatus{tr.From, tr.To})
+		if tr.Note == nil || *tr.Note != "moved to "+string(tr.To) {
+			t.Errorf("wrong note of the transition to %s: %v", tr.To, tr.Note)
+		}
+	}
+	expMoves := [][2]domain.SubmissionStatus{
+		{domain.SubmissionReceived, domain.SubmissionReading},
+		{domain.SubmissionReading, domain.SubmissionRequestedFull},
+		{domain.SubmissionRequestedFull, domain.SubmissionOffered},
+	}
+	if !reflect.DeepEqual(moves, expMoves) {
+		t.Errorf("transitions: expected %v, received %v", expMoves, moves)
+	}
+
+	// declined and withdrawn submissions are final and offers are not skipped
+	if err := move(s2.ID, domain.SubmissionDeclined, ""); err != nil {
+		t.Fatalf("failed to decline submission: %s", err)
+	}
+	invalid := []struct {
+		name   string
+		id     int64
+		status domain.SubmissionStatus
+	}{
+		{"from declined", s2.ID, domain.SubmissionReading},
+		{"skipping reading", s3.ID, domain.SubmissionOffered},
+		{"back from offered", s1.ID, domain.SubmissionReading},
+		{"to received", s3.ID, domain.SubmissionReceived},
+	}
+	for _, tc := range invalid {
+		var transitionErr *domain.SubmissionTransitionError
+		if err := move(tc.id, tc.status, ""); !errors.As(err, &transitionErr) {
+			t.Errorf("TransitionSubmission %s: expected a *domain.SubmissionTransitionError, received %v", tc.name, err)
+		}
+	}
+	declined, err := r.ListSubmissionsByAgentIDAndStatus(ctx, domain.ListSubmissionsByAgentIDAndStatusParams{
+		AgentID: f.agentA,
+		Status:  domain.SubmissionDeclined,
+	})
+	if err != nil {
+		t.Fatalf("failed to list submissions: %s", err)
+	}
+	checkIDs(t, "declined submissions of agentA", submissionIDs(declined), s2.ID)
+
+	// only offered submissions become books, once
+	for _, id := range []int64{s2.ID, s3.ID} {
+		if _, err := r.ConvertSubmission(ctx, id, "cover.jpg"); err != domain.ErrSubmissionNotOffered {
+			t.Errorf("ConvertSubmission %d: expected domain.ErrSubmissionNotOffered, received %v", id, err)
+		}
+	}
+	book, err := r.ConvertSubmission(ctx, s1.ID, "cover.jpg")
+	if err != nil {
+		t.Fatalf("failed to convert submission: %s", err)
+	}
+	if book.Title != s1.Title || book.Description != s1.Synopsis || book.Cover != "cover.jpg" {
+		t.Errorf("expected a book after the submission, received %v", book)
+	}
+	authors, err := r.ListAuthorsByBookID(ctx, book.ID)
+	if err != nil {
+		t.Fatalf("failed to list authors: %s", err)
+	}
+	if len(authors) != 1 || authors[0].Name != s1.AuthorName || authors[0].Website == nil ||
+		*authors[0].Website != *s1.AuthorWebsite || authors[0].AgentID != f.agentA {
+		t.Errorf("expected the author of the submission represented by agentA, received %v", authors)
+	}
+	s1, err = r.GetSubmission(ctx, s1.ID)
+	if err != nil {
+		t.Fatalf("failed to get submission: %s", err)
+	}
+	if s1.BookID == nil || *s1.BookID != book.ID {
+		t.Errorf("expected the submission to be book %d, received %v", book.ID, s1.BookID)
+	}
+	if _, err := r.ConvertSubmission(ctx, s1.ID, "cover.jpg"); err != domain.ErrSubmissionNotOffered {
+		t.Errorf("ConvertSubmission again: expected domain.ErrSubmissionNotOffered, received %v", err)
+	}
+	var transitionErr *domain.SubmissionTransitionError
+	if err := move(s1.ID, domain.SubmissionWithdrawn, ""); !errors.As(err, &transitionErr) {
+		t.Errorf("TransitionSubmission after converting: expected a *domain.SubmissionTransitionError, received %v", err)
+	}
+
+	// submissions outlive their book and go with their agent
+	if _, err := r.DeleteBook(ctx, book.ID); err != nil {
+		t.Fatalf("failed to delete book: %s", err)
+	}
+	if s1, err = r.GetSubmission(ctx, s1.ID); err != nil || s1.BookID != nil {
+		t.Errorf("expected the submission without a book, received %v, %v", s1, err)
+	}
+	agent, err := r.CreateAgent(ctx, domain.CreateAgentParams{Name: "Agent C", Email: "c@agents.test"})
+	if err != nil {
+		t.Fatalf("failed to create agent: %s", err)
+	}
+	s4 := submit(agent.ID, "Manuscript 4")
+	if err := move(s4.ID, domain.SubmissionReading, ""); err != nil {
+		t.Fatalf("failed to move submission: %s", err)
+	}
+	if _, err := r.DeleteAgent(ctx, agent.ID, nil); err != nil {
+		t.Fatalf("failed to delete agent: %s", err)
+	}
+	if _, err := r.GetSubmission(ctx, s4.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected the submission to be deleted, received %v", err)
+	}
+	if transitions, err := r.ListSubmissionTransitions(ctx, s4.ID); err != nil || len(transitions) != 0 {
+		t.Errorf("expected the transitions to be deleted, received %v, %v", transitions, err)
+	}
+}
+
+func checkIDs(t *testing.T, what string, received []int64, expected ...int64) {
+	t.Helper()
+	equal := len(received) == len(expected)
+	for i := 0; equal && i < len(received); i++ {
+		equal = received[i] == expected[i]
+	}
+	if !equal {
+		t.Errorf("%s: expected ids %v, received %v", what, expected, received)
+	}
+}
+
+func agencyIDs(agencies []domain.Agency) []int64 {
+	ids := make([]int64, 0, len(agencies))
+	for _, a := range agencies {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func agentIDs(agents []domain.Agent) []int64 {
+	ids := make([]int64, 0, len(agents))
+	for _, a := range agents {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+// credits credits the authors on a book in order in the AUTHOR role.
+func credits(authorIDs ...int64) []domain.Contributor {
+	contributors := make([]domain.Contributor, 0, len(authorIDs))
+	for i, id := range authorIDs {
+		contributors = append(contributors, domain.Contributor{AuthorID: id, Role: domain.ContributorAuthor, Position: i + 1})
+	}
+	return contributors
+}
+
+func authorIDs(authors []domain.Author) []int64 {
+	ids := make([]int64, 0, len(authors))
+	for _, a := range authors {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func bookIDs(books []domain.Book) []int64 {
+	ids := make([]int64, 0, len(books))
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+func dealIDs(deals []domain.Deal) []int64 {
+	ids := make([]int64, 0, len(deals))
+	for _, d := range deals {
+		ids = append(ids, d.ID)
+	}
+	return ids
+}
+
+func editionIDs(editions []domain.Edition) []int64 {
+	ids := make([]int64, 0, len(editions))
+	for _, e := range editions {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func genreIDs(genres []domain.Genre) []int64 {
+	ids := make([]int64, 0, len(genres))
+	for _, g := range genres {
+		ids = append(ids, g.ID)
+	}
+	return ids
+}
+
+func publisherIDs(publishers []domain.Publisher) []int64 {
+	ids := make([]int64, 0, len(publishers))
+	for _, p := range publishers {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func salesIDs(sales []domain.Sales) []int64 {
+	ids := make([]int64, 0, len(sales))
+	for _, s := range sales {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func submissionIDs(submissions []domain.Submission) []int64 {
+	ids := make([]int64, 0, len(submissions))
+	for _, s := range submissions {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func isbnPtr(s string) *domain.ISBN {
+	isbn := domain.ISBN(s)
+	return &isbn
+}
+
+func languagePtr(s string) *domain.LanguageCode {
+	language := domain.LanguageCode(s)
+	return &language
+}
@@ -0,0 +1,28 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fwojciec/litag-example/domain" // update the username
+	"github.com/vektah/gqlparser/gqlerror"
+)
+
+// CodeTimeout is the extensions code of errors caused by an operation, or
+// any of its queries, running out of time.
+const CodeTimeout = "TIMEOUT"
+
+// ErrorPresenter presents errors like graphql.DefaultErrorPresenter, adding
+// a code to the extensions of the errors clients may want to handle.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if errors.Is(err, domain.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		gqlErr.Message = domain.ErrTimeout.Error()
+		if gqlErr.Extensions == nil {
+			gqlErr.Extensions = map[string]interface{}{}
+		}
+		gqlErr.Extensions["code"] = CodeTimeout
+	}
+	return gqlErr
+}
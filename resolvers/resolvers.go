@@ -5,16 +5,22 @@ package resolvers
 
 import (
 	"context"
-	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
 
+	"github.com/fwojciec/litag-example/domain"           // update the username
 	"github.com/fwojciec/litag-example/generated/gqlgen" // update the username
-	"github.com/fwojciec/litag-example/generated/sqlc"   // update the username
-	"github.com/fwojciec/litag-example/postgres"         // update the username
 )
 
 // Resolver connects individual resolvers with the datalayer.
 type Resolver struct {
-	Repo *postgres.Repo
+	Repo domain.Repository
+}
+
+// Agency resolver resolves Agency related data.
+func (r *Resolver) Agency() gqlgen.AgencyResolver {
+	return &agencyResolver{r}
 }
 
 // Agent resolver resolves Agent related data.
@@ -27,39 +33,148 @@ func (r *Resolver) Author() gqlgen.AuthorResolver {
 	return &authorResolver{r}
 }
 
+// AvailableRights resolver resolves AvailableRights related data.
+func (r *Resolver) AvailableRights() gqlgen.AvailableRightsResolver {
+	return &availableRightsResolver{r}
+}
+
 // Book resolver resolves Agent related data.
 func (r *Resolver) Book() gqlgen.BookResolver {
 	return &bookResolver{r}
 }
 
+// Contributor resolver resolves Contributor related data.
+func (r *Resolver) Contributor() gqlgen.ContributorResolver {
+	return &contributorResolver{r}
+}
+
+// Deal resolver resolves Deal related data.
+func (r *Resolver) Deal() gqlgen.DealResolver {
+	return &dealResolver{r}
+}
+
+// Edition resolver resolves Edition related data.
+func (r *Resolver) Edition() gqlgen.EditionResolver {
+	return &editionResolver{r}
+}
+
+// Genre resolver resolves Genre related data.
+func (r *Resolver) Genre() gqlgen.GenreResolver {
+	return &genreResolver{r}
+}
+
 // Mutation resolver resolves Agent related data.
 func (r *Resolver) Mutation() gqlgen.MutationResolver {
 	return &mutationResolver{r}
 }
 
+// Publisher resolver resolves Publisher related data.
+func (r *Resolver) Publisher() gqlgen.PublisherResolver {
+	return &publisherResolver{r}
+}
+
 // Query resolver resolves Agent related data.
 func (r *Resolver) Query() gqlgen.QueryResolver {
 	return &queryResolver{r}
 }
 
+// Representation resolver resolves Representation related data.
+func (r *Resolver) Representation() gqlgen.RepresentationResolver {
+	return &representationResolver{r}
+}
+
+// RoyaltyLine resolver resolves RoyaltyLine related data.
+func (r *Resolver) RoyaltyLine() gqlgen.RoyaltyLineResolver {
+	return &royaltyLineResolver{r}
+}
+
+// RoyaltyRate resolver resolves RoyaltyRate related data.
+func (r *Resolver) RoyaltyRate() gqlgen.RoyaltyRateResolver {
+	return &royaltyRateResolver{r}
+}
+
+// RoyaltyStatement resolver resolves RoyaltyStatement related data.
+func (r *Resolver) RoyaltyStatement() gqlgen.RoyaltyStatementResolver {
+	return &royaltyStatementResolver{r}
+}
+
+// Sales resolver resolves Sales related data.
+func (r *Resolver) Sales() gqlgen.SalesResolver {
+	return &salesResolver{r}
+}
+
+// Series resolver resolves Series related data.
+func (r *Resolver) Series() gqlgen.SeriesResolver {
+	return &seriesResolver{r}
+}
+
+// Submission resolver resolves Submission related data.
+func (r *Resolver) Submission() gqlgen.SubmissionResolver {
+	return &submissionResolver{r}
+}
+
+// Webhook resolver resolves Webhook related data.
+func (r *Resolver) Webhook() gqlgen.WebhookResolver {
+	return &webhookResolver{r}
+}
+
+// WebhookDelivery resolver resolves WebhookDelivery related data.
+func (r *Resolver) WebhookDelivery() gqlgen.WebhookDeliveryResolver {
+	return &webhookDeliveryResolver{r}
+}
+
+type agencyResolver struct{ *Resolver }
+
+func (r *agencyResolver) Agents(ctx context.Context, obj *domain.Agency) ([]domain.Agent, error) {
+	return r.Repo.ListAgentsByAgencyID(ctx, obj.ID)
+}
+
+func (r *agencyResolver) Authors(ctx context.Context, obj *domain.Agency) ([]domain.Author, error) {
+	return r.Repo.ListAuthorsByAgencyID(ctx, obj.ID)
+}
+
+func (r *agencyResolver) Books(ctx context.Context, obj *domain.Agency) ([]domain.Book, error) {
+	return r.Repo.ListBooksByAgencyID(ctx, obj.ID)
+}
+
 type agentResolver struct{ *Resolver }
 
-func (r *agentResolver) Authors(ctx context.Context, obj *sqlc.Agent) ([]sqlc.Author, error) {
+func (r *agentResolver) Agency(ctx context.Context, obj *domain.Agent) (*domain.Agency, error) {
+	if obj.AgencyID == nil {
+		return nil, nil
+	}
+	agency, err := r.Repo.GetAgency(ctx, *obj.AgencyID)
+	if err != nil {
+		return nil, err
+	}
+	return &agency, nil
+}
+
+func (r *agentResolver) Authors(ctx context.Context, obj *domain.Agent) ([]domain.Author, error) {
 	return r.Repo.ListAuthorsByAgentID(ctx, obj.ID)
 }
 
-type authorResolver struct{ *Resolver }
+func (r *agentResolver) FormerAuthors(ctx context.Context, obj *domain.Agent) ([]domain.Author, error) {
+	return r.Repo.ListFormerAuthorsByAgentID(ctx, obj.ID)
+}
+
+func (r *agentResolver) Deals(ctx context.Context, obj *domain.Agent) ([]domain.Deal, error) {
+	return r.Repo.ListDealsByAgentID(ctx, obj.ID)
+}
 
-func (r *authorResolver) Website(ctx context.Context, obj *sqlc.Author) (*string, error) {
-	var w string
-	if obj.Website.Valid {
-		w = obj.Website.String
-		return &w, nil
+func (r *agentResolver) Submissions(ctx context.Context, obj *domain.Agent, status *domain.SubmissionStatus) ([]domain.Submission, error) {
+	if status != nil {
+		return r.Repo.ListSubmissionsByAgentIDAndStatus(ctx, domain.ListSubmissionsByAgentIDAndStatusParams{
+			AgentID: obj.ID,
+			Status:  *status,
+		})
 	}
-	return nil, nil
+	return r.Repo.ListSubmissionsByAgentID(ctx, obj.ID)
 }
 
-func (r *authorResolver) Agent(ctx context.Context, obj *sqlc.Author) (*sqlc.Agent, error) {
+type authorResolver struct{ *Resolver }
+
+func (r *authorResolver) Agent(ctx context.Context, obj *domain.Author) (*domain.Agent, error) {
 	agent, err := r.Repo.GetAgent(ctx, obj.AgentID)
 	if err != nil {
 		return nil, err
@@ -67,149 +182,1239 @@ func (r *authorResolver) Agent(ctx context.Context, obj *sqlc.Author) (*sqlc.Age
 	return &agent, nil
 }
 
-func (r *authorResolver) Books(ctx context.Context, obj *sqlc.Author) ([]sqlc.Book, error) {
+func (r *authorResolver) Representations(ctx context.Context, obj *domain.Author) ([]domain.Representation, error) {
+	return r.Repo.ListRepresentationsByAuthorID(ctx, obj.ID)
+}
+
+func (r *authorResolver) Books(ctx context.Context, obj *domain.Author) ([]domain.Book, error) {
 	return r.Repo.ListBooksByAuthorID(ctx, obj.ID)
 }
 
+type availableRightsResolver struct{ *Resolver }
+
+func (r *availableRightsResolver) Book(ctx context.Context, obj *domain.AvailableRights) (*domain.Book, error) {
+	book, err := r.Repo.GetBook(ctx, obj.BookID)
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
 type bookResolver struct{ *Resolver }
 
-func (r *bookResolver) Authors(ctx context.Context, obj *sqlc.Book) ([]sqlc.Author, error) {
+func (r *bookResolver) Publisher(ctx context.Context, obj *domain.Book) (*domain.Publisher, error) {
+	if obj.PublisherID == nil {
+		return nil, nil
+	}
+	publisher, err := r.Repo.GetPublisher(ctx, *obj.PublisherID)
+	if err != nil {
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+func (r *bookResolver) Series(ctx context.Context, obj *domain.Book) (*domain.Series, error) {
+	if obj.SeriesID == nil {
+		return nil, nil
+	}
+	series, err := r.Repo.GetSeries(ctx, *obj.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *bookResolver) Authors(ctx context.Context, obj *domain.Book) ([]domain.Author, error) {
 	return r.Repo.ListAuthorsByBookID(ctx, obj.ID)
 }
 
-type mutationResolver struct{ *Resolver }
+func (r *bookResolver) Contributors(ctx context.Context, obj *domain.Book) ([]domain.Contributor, error) {
+	return r.Repo.ListContributorsByBookID(ctx, obj.ID)
+}
 
-func (r *mutationResolver) CreateAgent(ctx context.Context, data gqlgen.CreateUpdateAgentInput) (*sqlc.Agent, error) {
-	agent, err := r.Repo.CreateAgent(ctx, sqlc.CreateAgentParams{
-		Name:  data.Name,
-		Email: data.Email,
-	})
+func (r *bookResolver) Genres(ctx context.Context, obj *domain.Book) ([]domain.Genre, error) {
+	return r.Repo.ListGenresByBookID(ctx, obj.ID)
+}
+
+func (r *bookResolver) Tags(ctx context.Context, obj *domain.Book) ([]string, error) {
+	return r.Repo.ListTagsByBookID(ctx, obj.ID)
+}
+
+func (r *bookResolver) Editions(ctx context.Context, obj *domain.Book) ([]domain.Edition, error) {
+	return r.Repo.ListEditionsByBookID(ctx, obj.ID)
+}
+
+func (r *bookResolver) Deals(ctx context.Context, obj *domain.Book) ([]domain.Deal, error) {
+	return r.Repo.ListDealsByBookID(ctx, obj.ID)
+}
+
+type contributorResolver struct{ *Resolver }
+
+func (r *contributorResolver) Author(ctx context.Context, obj *domain.Contributor) (*domain.Author, error) {
+	author, err := r.Repo.GetAuthor(ctx, obj.AuthorID)
 	if err != nil {
 		return nil, err
 	}
-	return &agent, nil
+	return &author, nil
 }
 
-func (r *mutationResolver) UpdateAgent(ctx context.Context, id int64, data gqlgen.CreateUpdateAgentInput) (*sqlc.Agent, error) {
-	agent, err := r.Repo.UpdateAgent(ctx, sqlc.UpdateAgentParams{
-		ID:    id,
-		Name:  data.Name,
-		Email: data.Email,
-	})
+type dealResolver struct{ *Resolver }
+
+func (r *dealResolver) Book(ctx context.Context, obj *domain.Deal) (*domain.Book, error) {
+	book, err := r.Repo.GetBook(ctx, obj.BookID)
 	if err != nil {
 		return nil, err
 	}
-	return &agent, nil
+	return &book, nil
+}
+
+func (r *dealResolver) Publisher(ctx context.Context, obj *domain.Deal) (*domain.Publisher, error) {
+	publisher, err := r.Repo.GetPublisher(ctx, obj.PublisherID)
+	if err != nil {
+		return nil, err
+	}
+	return &publisher, nil
 }
 
-func (r *mutationResolver) DeleteAgent(ctx context.Context, id int64) (*sqlc.Agent, error) {
-	agent, err := r.Repo.DeleteAgent(ctx, id)
+func (r *dealResolver) Agent(ctx context.Context, obj *domain.Deal) (*domain.Agent, error) {
+	agent, err := r.Repo.GetAgent(ctx, obj.AgentID)
 	if err != nil {
 		return nil, err
 	}
 	return &agent, nil
 }
 
-func (r *mutationResolver) CreateAuthor(ctx context.Context, data gqlgen.CreateUpdateAuthorInput) (*sqlc.Author, error) {
-	author, err := r.Repo.CreateAuthor(ctx, sqlc.CreateAuthorParams{
-		Name:    data.Name,
-		Website: stringPtrToNullString(data.Website),
-		AgentID: data.AgentID,
-	})
+func (r *dealResolver) RoyaltyRates(ctx context.Context, obj *domain.Deal) ([]domain.RoyaltyRate, error) {
+	return r.Repo.ListRoyaltyRatesByDealID(ctx, obj.ID)
+}
+
+func (r *dealResolver) Sales(ctx context.Context, obj *domain.Deal) ([]domain.Sales, error) {
+	return r.Repo.ListSalesByDealID(ctx, obj.ID)
+}
+
+type editionResolver struct{ *Resolver }
+
+func (r *editionResolver) Book(ctx context.Context, obj *domain.Edition) (*domain.Book, error) {
+	book, err := r.Repo.GetBook(ctx, obj.BookID)
 	if err != nil {
 		return nil, err
 	}
-	return &author, nil
+	return &book, nil
 }
 
-func (r *mutationResolver) UpdateAuthor(ctx context.Context, id int64, data gqlgen.CreateUpdateAuthorInput) (*sqlc.Author, error) {
-	author, err := r.Repo.UpdateAuthor(ctx, sqlc.UpdateAuthorParams{
-		ID:      id,
-		Name:    data.Name,
-		Website: stringPtrToNullString(data.Website),
-		AgentID: data.AgentID,
-	})
+type genreResolver struct{ *Resolver }
+
+func (r *genreResolver) Parent(ctx context.Context, obj *domain.Genre) (*domain.Genre, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+	genre, err := r.Repo.GetGenre(ctx, *obj.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *genreResolver) Children(ctx context.Context, obj *domain.Genre) ([]domain.Genre, error) {
+	return r.Repo.ListGenresByParentID(ctx, obj.ID)
+}
+
+func (r *genreResolver) Books(ctx context.Context, obj *domain.Genre, includeSubgenres *bool) ([]domain.Book, error) {
+	return r.Repo.ListBooksByGenreID(ctx, obj.ID, includeSubgenres != nil && *includeSubgenres)
+}
+
+type publisherResolver struct{ *Resolver }
+
+func (r *publisherResolver) Books(ctx context.Context, obj *domain.Publisher) ([]domain.Book, error) {
+	return r.Repo.ListBooksByPublisherID(ctx, obj.ID)
+}
+
+type representationResolver struct{ *Resolver }
+
+func (r *representationResolver) Author(ctx context.Context, obj *domain.Representation) (*domain.Author, error) {
+	author, err := r.Repo.GetAuthor(ctx, obj.AuthorID)
 	if err != nil {
 		return nil, err
 	}
 	return &author, nil
 }
 
-func (r *mutationResolver) DeleteAuthor(ctx context.Context, id int64) (*sqlc.Author, error) {
-	author, err := r.Repo.DeleteAuthor(ctx, id)
+func (r *representationResolver) Agent(ctx context.Context, obj *domain.Representation) (*domain.Agent, error) {
+	agent, err := r.Repo.GetAgent(ctx, obj.AgentID)
+	if err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+type royaltyLineResolver struct{ *Resolver }
+
+func (r *royaltyLineResolver) Book(ctx context.Context, obj *domain.RoyaltyLine) (*domain.Book, error) {
+	book, err := r.Repo.GetBook(ctx, obj.BookID)
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+type royaltyRateResolver struct{ *Resolver }
+
+func (r *royaltyRateResolver) Deal(ctx context.Context, obj *domain.RoyaltyRate) (*domain.Deal, error) {
+	deal, err := r.Repo.GetDeal(ctx, obj.DealID)
+	if err != nil {
+		return nil, err
+	}
+	return &deal, nil
+}
+
+func (r *royaltyRateResolver) Edition(ctx context.Context, obj *domain.RoyaltyRate) (*domain.Edition, error) {
+	edition, err := r.Repo.GetEdition(ctx, obj.EditionID)
+	if err != nil {
+		return nil, err
+	}
+	return &edition, nil
+}
+
+type royaltyStatementResolver struct{ *Resolver }
+
+func (r *royaltyStatementResolver) Author(ctx context.Context, obj *domain.RoyaltyStatement) (*domain.Author, error) {
+	author, err := r.Repo.GetAuthor(ctx, obj.AuthorID)
 	if err != nil {
 		return nil, err
 	}
 	return &author, nil
 }
 
-func (r *mutationResolver) CreateBook(ctx context.Context, data gqlgen.CreateUpdateBookInput) (*sqlc.Book, error) {
-	return r.Repo.CreateBook(ctx, sqlc.CreateBookParams{
-		Title:       data.Title,
-		Description: data.Description,
-		Cover:       data.Cover,
-	}, data.AuthorIDs)
+func (r *royaltyStatementResolver) Csv(ctx context.Context, obj *domain.RoyaltyStatement) (string, error) {
+	var buf strings.Builder
+	if err := obj.WriteCSV(&buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
 }
 
-func (r *mutationResolver) UpdateBook(ctx context.Context, id int64, data gqlgen.CreateUpdateBookInput) (*sqlc.Book, error) {
-	return r.Repo.UpdateBook(ctx, sqlc.UpdateBookParams{
-		ID:          id,
-		Title:       data.Title,
-		Description: data.Description,
-		Cover:       data.Cover,
-	}, data.AuthorIDs)
+type salesResolver struct{ *Resolver }
+
+func (r *salesResolver) Deal(ctx context.Context, obj *domain.Sales) (*domain.Deal, error) {
+	deal, err := r.Repo.GetDeal(ctx, obj.DealID)
+	if err != nil {
+		return nil, err
+	}
+	return &deal, nil
+}
+
+func (r *salesResolver) Edition(ctx context.Context, obj *domain.Sales) (*domain.Edition, error) {
+	edition, err := r.Repo.GetEdition(ctx, obj.EditionID)
+	if err != nil {
+		return nil, err
+	}
+	return &edition, nil
+}
+
+type seriesResolver struct{ *Resolver }
+
+func (r *seriesResolver) Books(ctx context.Context, obj *domain.Series) ([]domain.Book, error) {
+	return r.Repo.ListBooksBySeriesID(ctx, obj.ID)
+}
+
+type submissionResolver struct{ *Resolver }
+
+func (r *submissionResolver) Agent(ctx context.Context, obj *domain.Submission) (*domain.Agent, error) {
+	agent, err := r.Repo.GetAgent(ctx, obj.AgentID)
+	if err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+func (r *submissionResolver) Transitions(ctx context.Context, obj *domain.Submission) ([]domain.SubmissionTransition, error) {
+	return r.Repo.ListSubmissionTransitions(ctx, obj.ID)
 }
 
-func (r *mutationResolver) DeleteBook(ctx context.Context, id int64) (*sqlc.Book, error) {
-	// BookAuthors associations will cascade automatically.
-	book, err := r.Repo.DeleteBook(ctx, id)
+func (r *submissionResolver) Book(ctx context.Context, obj *domain.Submission) (*domain.Book, error) {
+	if obj.BookID == nil {
+		return nil, nil
+	}
+	book, err := r.Repo.GetBook(ctx, *obj.BookID)
 	if err != nil {
 		return nil, err
 	}
 	return &book, nil
 }
 
-type queryResolver struct{ *Resolver }
+type webhookResolver struct{ *Resolver }
 
-func (r *queryResolver) Agent(ctx context.Context, id int64) (*sqlc.Agent, error) {
-	agent, err := r.Repo.GetAgent(ctx, id)
+func (r *webhookResolver) Deliveries(ctx context.Context, obj *domain.Webhook, status *string) ([]domain.WebhookDelivery, error) {
+	return listWebhookDeliveries(ctx, r.Repo, obj.ID, status)
+}
+
+type webhookDeliveryResolver struct{ *Resolver }
+
+func (r *webhookDeliveryResolver) Webhook(ctx context.Context, obj *domain.WebhookDelivery) (*domain.Webhook, error) {
+	webhook, err := r.Repo.GetWebhook(ctx, obj.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookDeliveryResolver) Payload(ctx context.Context, obj *domain.WebhookDelivery) (string, error) {
+	return string(obj.Payload), nil
+}
+
+type mutationResolver struct{ *Resolver }
+
+func (r *mutationResolver) CreateAgency(ctx context.Context, data gqlgen.CreateUpdateAgencyInput) (*domain.Agency, error) {
+	agency, err := r.Repo.CreateAgency(ctx, domain.CreateAgencyParams{
+		Name: data.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &agency, nil
+}
+
+func (r *mutationResolver) UpdateAgency(ctx context.Context, id int64, data gqlgen.CreateUpdateAgencyInput) (*domain.Agency, error) {
+	agency, err := r.Repo.UpdateAgency(ctx, domain.UpdateAgencyParams{
+		ID:   id,
+		Name: data.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &agency, nil
+}
+
+func (r *mutationResolver) DeleteAgency(ctx context.Context, id int64) (*domain.Agency, error) {
+	// The agents of the agency are kept without an agency.
+	agency, err := r.Repo.DeleteAgency(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &agency, nil
+}
+
+func (r *mutationResolver) CreateAgent(ctx context.Context, data gqlgen.CreateUpdateAgentInput) (*domain.Agent, error) {
+	agent, err := r.Repo.CreateAgent(ctx, domain.CreateAgentParams{
+		Name:     data.Name,
+		Email:    data.Email,
+		AgencyID: data.AgencyID,
+	})
 	if err != nil {
 		return nil, err
 	}
 	return &agent, nil
 }
 
-func (r *queryResolver) Agents(ctx context.Context) ([]sqlc.Agent, error) {
-	return r.Repo.ListAgents(ctx)
+func (r *mutationResolver) UpdateAgent(ctx context.Context, id int64, data gqlgen.CreateUpdateAgentInput) (*domain.Agent, error) {
+	agent, err := r.Repo.UpdateAgent(ctx, domain.UpdateAgentParams{
+		ID:       id,
+		Name:     data.Name,
+		Email:    data.Email,
+		AgencyID: data.AgencyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &agent, nil
 }
 
-func (r *queryResolver) Author(ctx context.Context, id int64) (*sqlc.Author, error) {
-	author, err := r.Repo.GetAuthor(ctx, id)
+func (r *mutationResolver) DeleteAgent(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+	return r.Repo.DeleteAgent(ctx, id, reassignAuthorsTo)
+}
+
+func (r *mutationResolver) CreateAgents(ctx context.Context, data []gqlgen.CreateUpdateAgentInput, mode *domain.BulkMode) (*gqlgen.BulkAgentsPayload, error) {
+	args := make([]domain.CreateAgentParams, 0, len(data))
+	for _, d := range data {
+		args = append(args, domain.CreateAgentParams{
+			Name:     d.Name,
+			Email:    d.Email,
+			AgencyID: d.AgencyID,
+		})
+	}
+	res, err := r.Repo.CreateAgents(ctx, args, bulkMode(mode))
 	if err != nil {
 		return nil, err
 	}
-	return &author, nil
+	payload := &gqlgen.BulkAgentsPayload{
+		Committed: res.Committed,
+		Results:   make([]gqlgen.BulkAgentResult, 0, len(res.Agents)),
+	}
+	for i, agent := range res.Agents {
+		payload.Results = append(payload.Results, gqlgen.BulkAgentResult{
+			Agent: agent,
+			Error: errorToStringPtr(res.Errors[i]),
+		})
+	}
+	return payload, nil
+}
+
+func (r *mutationResolver) CreateAuthor(ctx context.Context, data gqlgen.CreateUpdateAuthorInput) (*domain.Author, error) {
+	return r.Repo.CreateAuthor(ctx, domain.CreateAuthorParams{
+		Name:    data.Name,
+		Website: data.Website,
+		AgentID: data.AgentID,
+	})
 }
 
-func (r *queryResolver) Authors(ctx context.Context) ([]sqlc.Author, error) {
-	return r.Repo.ListAuthors(ctx)
+func (r *mutationResolver) UpdateAuthor(ctx context.Context, id int64, data gqlgen.CreateUpdateAuthorInput) (*domain.Author, error) {
+	return r.Repo.UpdateAuthor(ctx, domain.UpdateAuthorParams{
+		ID:      id,
+		Name:    data.Name,
+		Website: data.Website,
+		AgentID: data.AgentID,
+	})
 }
 
-func (r *queryResolver) Book(ctx context.Context, id int64) (*sqlc.Book, error) {
-	book, err := r.Repo.GetBook(ctx, id)
+func (r *mutationResolver) DeleteAuthor(ctx context.Context, id int64, orphanedBooks *domain.OrphanedBooksPolicy) (*domain.Author, error) {
+	policy := domain.OrphanedBooksFail
+	if orphanedBooks != nil {
+		policy = *orphanedBooks
+	}
+	return r.Repo.DeleteAuthor(ctx, id, policy)
+}
+
+func (r *mutationResolver) CreateAuthors(ctx context.Context, data []gqlgen.CreateUpdateAuthorInput, mode *domain.BulkMode) (*gqlgen.BulkAuthorsPayload, error) {
+	args := make([]domain.CreateAuthorParams, 0, len(data))
+	for _, d := range data {
+		args = append(args, domain.CreateAuthorParams{
+			Name:    d.Name,
+			Website: d.Website,
+			AgentID: d.AgentID,
+		})
+	}
+	res, err := r.Repo.CreateAuthors(ctx, args, bulkMode(mode))
 	if err != nil {
 		return nil, err
 	}
-	return &book, nil
+	payload := &gqlgen.BulkAuthorsPayload{
+		Committed: res.Committed,
+		Results:   make([]gqlgen.BulkAuthorResult, 0, len(res.Authors)),
+	}
+	for i, author := range res.Authors {
+		payload.Results = append(payload.Results, gqlgen.BulkAuthorResult{
+			Author: author,
+			Error:  errorToStringPtr(res.Errors[i]),
+		})
+	}
+	return payload, nil
+}
+
+func (r *mutationResolver) CreateBook(ctx context.Context, data gqlgen.CreateUpdateBookInput) (*domain.Book, error) {
+	contributors, err := toDomainContributors(data)
+	if err != nil {
+		return nil, err
+	}
+	return r.Repo.CreateBook(ctx, domain.CreateBookParams{
+		Title:          data.Title,
+		Description:    data.Description,
+		Cover:          data.Cover,
+		PublisherID:    data.PublisherID,
+		SeriesID:       data.SeriesID,
+		SeriesPosition: data.SeriesPosition,
+		ISBN:           data.Isbn,
+		PublishedOn:    data.PublishedOn,
+		PageCount:      data.PageCount,
+		Language:       data.Language,
+	}, contributors, data.GenreIDs, data.Tags)
+}
+
+func (r *mutationResolver) UpdateBook(ctx context.Context, id int64, data gqlgen.CreateUpdateBookInput) (*domain.Book, error) {
+	contributors, err := toDomainContributors(data)
+	if err != nil {
+		return nil, err
+	}
+	return r.Repo.UpdateBook(ctx, domain.UpdateBookParams{
+		ID:             id,
+		Title:          data.Title,
+		Description:    data.Description,
+		Cover:          data.Cover,
+		PublisherID:    data.PublisherID,
+		SeriesID:       data.SeriesID,
+		SeriesPosition: data.SeriesPosition,
+		ISBN:           data.Isbn,
+		PublishedOn:    data.PublishedOn,
+		PageCount:      data.PageCount,
+		Language:       data.Language,
+	}, contributors, data.GenreIDs, data.Tags)
+}
+
+func (r *mutationResolver) DeleteBook(ctx context.Context, id int64) (*domain.Book, error) {
+	return r.Repo.DeleteBook(ctx, id)
+}
+
+func (r *mutationResolver) CreateBooks(ctx context.Context, data []gqlgen.CreateUpdateBookInput, mode *domain.BulkMode) (*gqlgen.BulkBooksPayload, error) {
+	args := make([]domain.BulkCreateBookArgs, 0, len(data))
+	for _, d := range data {
+		contributors, err := toDomainContributors(d)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, domain.BulkCreateBookArgs{
+			Book: domain.CreateBookParams{
+				Title:          d.Title,
+				Description:    d.Description,
+				Cover:          d.Cover,
+				PublisherID:    d.PublisherID,
+				SeriesID:       d.SeriesID,
+				SeriesPosition: d.SeriesPosition,
+				ISBN:           d.Isbn,
+				PublishedOn:    d.PublishedOn,
+				PageCount:      d.PageCount,
+				Language:       d.Language,
+			},
+			Contributors: contributors,
+			GenreIDs:     d.GenreIDs,
+			Tags:         d.Tags,
+		})
+	}
+	res, err := r.Repo.CreateBooks(ctx, args, bulkMode(mode))
+	if err != nil {
+		return nil, err
+	}
+	return newBulkBooksPayload(res), nil
 }
 
-func (r *queryResolver) Books(ctx context.Context) ([]sqlc.Book, error) {
-	return r.Repo.ListBooks(ctx)
+func (r *mutationResolver) UpdateBooks(ctx context.Context, data []gqlgen.BulkUpdateBookInput, mode *domain.BulkMode) (*gqlgen.BulkBooksPayload, error) {
+	args := make([]domain.BulkUpdateBookArgs, 0, len(data))
+	for _, d := range data {
+		contributors, err := toDomainContributors(*d.Data)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, domain.BulkUpdateBookArgs{
+			Book: domain.UpdateBookParams{
+				ID:             d.ID,
+				Title:          d.Data.Title,
+				Description:    d.Data.Description,
+				Cover:          d.Data.Cover,
+				PublisherID:    d.Data.PublisherID,
+				SeriesID:       d.Data.SeriesID,
+				SeriesPosition: d.Data.SeriesPosition,
+				ISBN:           d.Data.Isbn,
+				PublishedOn:    d.Data.PublishedOn,
+				PageCount:      d.Data.PageCount,
+				Language:       d.Data.Language,
+			},
+			Contributors: contributors,
+			GenreIDs:     d.Data.GenreIDs,
+			Tags:         d.Data.Tags,
+		})
+	}
+	res, err := r.Repo.UpdateBooks(ctx, args, bulkMode(mode))
+	if err != nil {
+		return nil, err
+	}
+	return newBulkBooksPayload(res), nil
+}
+
+func (r *mutationResolver) CreateDeal(ctx context.Context, data gqlgen.CreateUpdateDealInput) (*domain.Deal, error) {
+	if !isTerritory(data.Territory) {
+		return nil, fmt.Errorf("invalid territory: %q", data.Territory)
+	}
+	deal, err := r.Repo.CreateDeal(ctx, domain.CreateDealParams{
+		BookID:         data.BookID,
+		PublisherID:    data.PublisherID,
+		AgentID:        data.AgentID,
+		Territory:      data.Territory,
+		Language:       data.Language,
+		Format:         data.Format,
+		Advance:        data.Advance,
+		SignedOn:       data.SignedOn,
+		Status:         dealStatus(data.Status),
+		RoyaltyRate:    data.RoyaltyRate,
+		CommissionRate: data.CommissionRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deal, nil
+}
+
+func (r *mutationResolver) UpdateDeal(ctx context.Context, id int64, data gqlgen.CreateUpdateDealInput) (*domain.Deal, error) {
+	if !isTerritory(data.Territory) {
+		return nil, fmt.Errorf("invalid territory: %q", data.Territory)
+	}
+	deal, err := r.Repo.UpdateDeal(ctx, domain.UpdateDealParams{
+		ID:             id,
+		BookID:         data.BookID,
+		PublisherID:    data.PublisherID,
+		AgentID:        data.AgentID,
+		Territory:      data.Territory,
+		Language:       data.Language,
+		Format:         data.Format,
+		Advance:        data.Advance,
+		SignedOn:       data.SignedOn,
+		Status:         dealStatus(data.Status),
+		RoyaltyRate:    data.RoyaltyRate,
+		CommissionRate: data.CommissionRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deal, nil
+}
+
+func (r *mutationResolver) DeleteDeal(ctx context.Context, id int64) (*domain.Deal, error) {
+	deal, err := r.Repo.DeleteDeal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &deal, nil
+}
+
+func (r *mutationResolver) SetRoyaltyRate(ctx context.Context, dealID int64, editionID int64, rate int) (*domain.RoyaltyRate, error) {
+	royaltyRate, err := r.Repo.SetRoyaltyRate(ctx, dealID, editionID, rate)
+	if err != nil {
+		return nil, err
+	}
+	return &royaltyRate, nil
+}
+
+func (r *mutationResolver) DeleteRoyaltyRate(ctx context.Context, dealID int64, editionID int64) (*domain.RoyaltyRate, error) {
+	royaltyRate, err := r.Repo.DeleteRoyaltyRate(ctx, dealID, editionID)
+	if err != nil {
+		return nil, err
+	}
+	return &royaltyRate, nil
+}
+
+func (r *mutationResolver) RecordSales(ctx context.Context, data gqlgen.RecordSalesInput) (*domain.Sales, error) {
+	if !isPeriod(data.Period) {
+		return nil, fmt.Errorf("invalid period: %q", data.Period)
+	}
+	sales, err := r.Repo.RecordSales(ctx, domain.RecordSalesParams{
+		DealID:    data.DealID,
+		EditionID: data.EditionID,
+		Period:    data.Period,
+		Units:     data.Units,
+		Revenue:   data.Revenue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sales, nil
+}
+
+func (r *mutationResolver) DeleteSales(ctx context.Context, id int64) (*domain.Sales, error) {
+	sales, err := r.Repo.DeleteSales(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &sales, nil
+}
+
+func (r *mutationResolver) CreateEdition(ctx context.Context, data gqlgen.CreateUpdateEditionInput) (*domain.Edition, error) {
+	if err := validateEditionInput(data); err != nil {
+		return nil, err
+	}
+	edition, err := r.Repo.CreateEdition(ctx, domain.CreateEditionParams{
+		BookID:      data.BookID,
+		Format:      data.Format,
+		ISBN:        data.Isbn,
+		PublishedOn: data.PublishedOn,
+		Price:       toDomainPrice(data.Price),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &edition, nil
+}
+
+func (r *mutationResolver) UpdateEdition(ctx context.Context, id int64, data gqlgen.CreateUpdateEditionInput) (*domain.Edition, error) {
+	if err := validateEditionInput(data); err != nil {
+		return nil, err
+	}
+	edition, err := r.Repo.UpdateEdition(ctx, domain.UpdateEditionParams{
+		ID:          id,
+		BookID:      data.BookID,
+		Format:      data.Format,
+		ISBN:        data.Isbn,
+		PublishedOn: data.PublishedOn,
+		Price:       toDomainPrice(data.Price),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &edition, nil
+}
+
+func (r *mutationResolver) DeleteEdition(ctx context.Context, id int64) (*domain.Edition, error) {
+	edition, err := r.Repo.DeleteEdition(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &edition, nil
+}
+
+func (r *mutationResolver) CreateGenre(ctx context.Context, data gqlgen.CreateUpdateGenreInput) (*domain.Genre, error) {
+	genre, err := r.Repo.CreateGenre(ctx, domain.CreateGenreParams{
+		Name:     data.Name,
+		ParentID: data.ParentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *mutationResolver) UpdateGenre(ctx context.Context, id int64, data gqlgen.CreateUpdateGenreInput) (*domain.Genre, error) {
+	return r.Repo.UpdateGenre(ctx, domain.UpdateGenreParams{
+		ID:       id,
+		Name:     data.Name,
+		ParentID: data.ParentID,
+	})
+}
+
+func (r *mutationResolver) DeleteGenre(ctx context.Context, id int64) (*domain.Genre, error) {
+	// Genres with subgenres cannot be deleted; book associations cascade.
+	genre, err := r.Repo.DeleteGenre(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *mutationResolver) CreatePublisher(ctx context.Context, data gqlgen.CreateUpdatePublisherInput) (*domain.Publisher, error) {
+	publisher, err := r.Repo.CreatePublisher(ctx, domain.CreatePublisherParams{
+		Name: data.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+func (r *mutationResolver) UpdatePublisher(ctx context.Context, id int64, data gqlgen.CreateUpdatePublisherInput) (*domain.Publisher, error) {
+	publisher, err := r.Repo.UpdatePublisher(ctx, domain.UpdatePublisherParams{
+		ID:   id,
+		Name: data.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+func (r *mutationResolver) DeletePublisher(ctx context.Context, id int64) (*domain.Publisher, error) {
+	// The books of the publisher are kept without a publisher.
+	publisher, err := r.Repo.DeletePublisher(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+func (r *mutationResolver) CreateRepresentation(ctx context.Context, data gqlgen.CreateRepresentationInput) (*domain.Representation, error) {
+	if !isTerritory(data.Territory) {
+		return nil, fmt.Errorf("invalid territory: %q", data.Territory)
+	}
+	representation, err := r.Repo.CreateRepresentation(ctx, domain.CreateRepresentationParams{
+		AuthorID:  data.AuthorID,
+		AgentID:   data.AgentID,
+		Territory: data.Territory,
+		StartedAt: data.StartedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &representation, nil
+}
+
+func (r *mutationResolver) EndRepresentation(ctx context.Context, id int64, endedAt domain.Date) (*domain.Representation, error) {
+	representation, err := r.Repo.EndRepresentation(ctx, id, endedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &representation, nil
+}
+
+func (r *mutationResolver) CreateSeries(ctx context.Context, data gqlgen.CreateUpdateSeriesInput) (*domain.Series, error) {
+	series, err := r.Repo.CreateSeries(ctx, domain.CreateSeriesParams{
+		Title: data.Title,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *mutationResolver) UpdateSeries(ctx context.Context, id int64, data gqlgen.CreateUpdateSeriesInput) (*domain.Series, error) {
+	series, err := r.Repo.UpdateSeries(ctx, domain.UpdateSeriesParams{
+		ID:    id,
+		Title: data.Title,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *mutationResolver) DeleteSeries(ctx context.Context, id int64) (*domain.Series, error) {
+	return r.Repo.DeleteSeries(ctx, id)
+}
+
+func (r *mutationResolver) ReorderSeries(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+	return r.Repo.ReorderSeries(ctx, id, bookIDs)
+}
+
+func (r *mutationResolver) CreateSubmission(ctx context.Context, data gqlgen.CreateSubmissionInput) (*domain.Submission, error) {
+	submission, err := r.Repo.CreateSubmission(ctx, domain.CreateSubmissionParams{
+		AgentID:       data.AgentID,
+		AuthorName:    data.AuthorName,
+		AuthorWebsite: data.AuthorWebsite,
+		Title:         data.Title,
+		Synopsis:      data.Synopsis,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *mutationResolver) TransitionSubmission(ctx context.Context, id int64, status domain.SubmissionStatus, note *string) (*domain.Submission, error) {
+	return r.Repo.TransitionSubmission(ctx, id, status, note)
+}
+
+func (r *mutationResolver) ConvertSubmission(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+	return r.Repo.ConvertSubmission(ctx, id, cover)
+}
+
+func (r *mutationResolver) CreateWebhook(ctx context.Context, data gqlgen.CreateUpdateWebhookInput) (*domain.Webhook, error) {
+	if err := validateWebhookInput(data); err != nil {
+		return nil, err
+	}
+	webhook, err := r.Repo.CreateWebhook(ctx, domain.CreateWebhookParams{
+		URL:        data.URL,
+		Secret:     data.Secret,
+		EventTypes: data.EventTypes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *mutationResolver) UpdateWebhook(ctx context.Context, id int64, data gqlgen.CreateUpdateWebhookInput) (*domain.Webhook, error) {
+	if err := validateWebhookInput(data); err != nil {
+		return nil, err
+	}
+	webhook, err := r.Repo.UpdateWebhook(ctx, domain.UpdateWebhookParams{
+		ID:         id,
+		URL:        data.URL,
+		Secret:     data.Secret,
+		EventTypes: data.EventTypes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *mutationResolver) DeleteWebhook(ctx context.Context, id int64) (*domain.Webhook, error) {
+	// WebhookDeliveries will cascade automatically.
+	webhook, err := r.Repo.DeleteWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *mutationResolver) RetryWebhookDelivery(ctx context.Context, id int64) (*domain.WebhookDelivery, error) {
+	delivery, err := r.Repo.RetryWebhookDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+type queryResolver struct{ *Resolver }
+
+func (r *queryResolver) Agency(ctx context.Context, id int64) (*domain.Agency, error) {
+	agency, err := r.Repo.GetAgency(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &agency, nil
+}
+
+func (r *queryResolver) Agencies(ctx context.Context) ([]domain.Agency, error) {
+	return r.Repo.ListAgencies(ctx)
+}
+
+func (r *queryResolver) Agent(ctx context.Context, id int64) (*domain.Agent, error) {
+	agent, err := r.Repo.GetAgent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+func (r *queryResolver) Agents(ctx context.Context, filter *gqlgen.AgentFilter) ([]domain.Agent, error) {
+	if filter == nil || filter.AgencyID == nil {
+		return r.Repo.ListAgents(ctx)
+	}
+	return r.Repo.ListAgentsByAgencyID(ctx, *filter.AgencyID)
+}
+
+func (r *queryResolver) Author(ctx context.Context, id int64) (*domain.Author, error) {
+	author, err := r.Repo.GetAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+func (r *queryResolver) Authors(ctx context.Context, filter *gqlgen.AuthorFilter) ([]domain.Author, error) {
+	if filter == nil || filter.AgencyID == nil {
+		return r.Repo.ListAuthors(ctx)
+	}
+	return r.Repo.ListAuthorsByAgencyID(ctx, *filter.AgencyID)
+}
+
+func (r *queryResolver) Book(ctx context.Context, id int64) (*domain.Book, error) {
+	book, err := r.Repo.GetBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+func (r *queryResolver) BookByIsbn(ctx context.Context, isbn domain.ISBN) (*domain.Book, error) {
+	book, err := r.Repo.GetBookByISBN(ctx, isbn)
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+func (r *queryResolver) Books(ctx context.Context, filter *gqlgen.BookFilter) ([]domain.Book, error) {
+	if filter == nil || filter.GenreID == nil && filter.Tag == nil && filter.AgencyID == nil {
+		return r.Repo.ListBooks(ctx)
+	}
+	// every field that is set narrows down the books of the previous ones
+	var books []domain.Book
+	narrowed := false
+	narrow := func(matching []domain.Book, err error) error {
+		if err != nil {
+			return err
+		}
+		if narrowed {
+			matching = intersectBooks(books, matching)
+		}
+		books, narrowed = matching, true
+		return nil
+	}
+	if filter.GenreID != nil {
+		includeSubgenres := filter.IncludeSubgenres != nil && *filter.IncludeSubgenres
+		if err := narrow(r.Repo.ListBooksByGenreID(ctx, *filter.GenreID, includeSubgenres)); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Tag != nil {
+		if err := narrow(r.Repo.ListBooksByTag(ctx, *filter.Tag)); err != nil {
+			return nil, err
+		}
+	}
+	if filter.AgencyID != nil {
+		if err := narrow(r.Repo.ListBooksByAgencyID(ctx, *filter.AgencyID)); err != nil {
+			return nil, err
+		}
+	}
+	return books, nil
+}
+
+func (r *queryResolver) OrphanBooks(ctx context.Context) ([]domain.Book, error) {
+	return r.Repo.ListOrphanBooks(ctx)
+}
+
+func (r *queryResolver) Deal(ctx context.Context, id int64) (*domain.Deal, error) {
+	deal, err := r.Repo.GetDeal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &deal, nil
+}
+
+func (r *queryResolver) AvailableRights(ctx context.Context, territory string, language domain.LanguageCode) ([]domain.AvailableRights, error) {
+	if !isTerritory(territory) {
+		return nil, fmt.Errorf("invalid territory: %q", territory)
+	}
+	return r.Repo.ListAvailableRights(ctx, territory, language)
+}
+
+func (r *queryResolver) RoyaltyStatement(ctx context.Context, authorID int64, period string) (*domain.RoyaltyStatement, error) {
+	if !isPeriod(period) {
+		return nil, fmt.Errorf("invalid period: %q", period)
+	}
+	statement, err := r.Repo.GetRoyaltyStatement(ctx, authorID, period)
+	if err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+func (r *queryResolver) Edition(ctx context.Context, id int64) (*domain.Edition, error) {
+	edition, err := r.Repo.GetEdition(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &edition, nil
+}
+
+func (r *queryResolver) EditionByIsbn(ctx context.Context, isbn domain.ISBN) (*domain.Edition, error) {
+	edition, err := r.Repo.GetEditionByISBN(ctx, isbn)
+	if err != nil {
+		return nil, err
+	}
+	return &edition, nil
+}
+
+func (r *queryResolver) Editions(ctx context.Context, filter *gqlgen.EditionFilter) ([]domain.Edition, error) {
+	if filter == nil {
+		filter = &gqlgen.EditionFilter{}
+	}
+	return r.Repo.ListEditions(ctx, domain.ListEditionsParams{
+		BookID:        filter.BookID,
+		Format:        filter.Format,
+		PublishedFrom: filter.PublishedFrom,
+		PublishedTo:   filter.PublishedTo,
+	})
+}
+
+func (r *queryResolver) Genre(ctx context.Context, id int64) (*domain.Genre, error) {
+	genre, err := r.Repo.GetGenre(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &genre, nil
+}
+
+func (r *queryResolver) Genres(ctx context.Context) ([]domain.Genre, error) {
+	return r.Repo.ListGenres(ctx)
+}
+
+func (r *queryResolver) Publisher(ctx context.Context, id int64) (*domain.Publisher, error) {
+	publisher, err := r.Repo.GetPublisher(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+func (r *queryResolver) Publishers(ctx context.Context) ([]domain.Publisher, error) {
+	return r.Repo.ListPublishers(ctx)
+}
+
+func (r *queryResolver) Series(ctx context.Context, id int64) (*domain.Series, error) {
+	series, err := r.Repo.GetSeries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *queryResolver) AllSeries(ctx context.Context) ([]domain.Series, error) {
+	return r.Repo.ListSeries(ctx)
+}
+
+func (r *queryResolver) Submission(ctx context.Context, id int64) (*domain.Submission, error) {
+	submission, err := r.Repo.GetSubmission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *queryResolver) Webhook(ctx context.Context, id int64) (*domain.Webhook, error) {
+	webhook, err := r.Repo.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *queryResolver) Webhooks(ctx context.Context) ([]domain.Webhook, error) {
+	return r.Repo.ListWebhooks(ctx)
+}
+
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, webhookID int64, status *string) ([]domain.WebhookDelivery, error) {
+	return listWebhookDeliveries(ctx, r.Repo, webhookID, status)
+}
+
+func listWebhookDeliveries(ctx context.Context, repo domain.Repository, webhookID int64, status *string) ([]domain.WebhookDelivery, error) {
+	if status != nil {
+		return repo.ListWebhookDeliveriesByStatus(ctx, domain.ListWebhookDeliveriesByStatusParams{
+			WebhookID: webhookID,
+			Status:    *status,
+		})
+	}
+	return repo.ListWebhookDeliveries(ctx, webhookID)
+}
+
+func validateWebhookInput(data gqlgen.CreateUpdateWebhookInput) error {
+	u, err := url.Parse(data.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook url: %q", data.URL)
+	}
+	if data.Secret == "" {
+		return fmt.Errorf("webhook secret must not be empty")
+	}
+	if len(data.EventTypes) == 0 {
+		return fmt.Errorf("webhook must subscribe to at least one event type")
+	}
+	for _, eventType := range data.EventTypes {
+		if !isWebhookEventType(eventType) {
+			return fmt.Errorf("unknown webhook event type: %q", eventType)
+		}
+	}
+	return nil
+}
+
+func validateEditionInput(data gqlgen.CreateUpdateEditionInput) error {
+	if data.Price == nil {
+		return nil
+	}
+	if data.Price.Amount < 0 {
+		return fmt.Errorf("price amount must not be negative")
+	}
+	if !isCurrencyCode(data.Price.Currency) {
+		return fmt.Errorf("invalid ISO 4217 currency code: %q", data.Price.Currency)
+	}
+	return nil
+}
+
+// isCurrencyCode reports whether s has the form of an ISO 4217 code.
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isTerritory reports whether s is WORLD or has the form of an ISO 3166-1
+// alpha-2 code.
+func isTerritory(s string) bool {
+	if s == "WORLD" {
+		return true
+	}
+	if len(s) != 2 {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isPeriod reports whether s is a quarter such as 2024-Q1.
+func isPeriod(s string) bool {
+	if len(s) != 7 || s[4:6] != "-Q" || s[6] < '1' || s[6] > '4' {
+		return false
+	}
+	for _, c := range s[:4] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func toDomainPrice(p *gqlgen.PriceInput) *domain.Price {
+	if p == nil {
+		return nil
+	}
+	return &domain.Price{Amount: p.Amount, Currency: p.Currency}
+}
+
+// toDomainContributors returns the contributors of the book input, crediting
+// authorIDs in order in the AUTHOR role.
+func toDomainContributors(data gqlgen.CreateUpdateBookInput) ([]domain.Contributor, error) {
+	if data.AuthorIDs != nil && data.Contributors != nil {
+		return nil, fmt.Errorf("give either authorIDs or contributors, not both")
+	}
+	contributors := make([]domain.Contributor, 0, len(data.AuthorIDs)+len(data.Contributors))
+	for i, authorID := range data.AuthorIDs {
+		contributors = append(contributors, domain.Contributor{
+			AuthorID: authorID,
+			Role:     domain.ContributorAuthor,
+			Position: i + 1,
+		})
+	}
+	for _, c := range data.Contributors {
+		contributors = append(contributors, domain.Contributor{
+			AuthorID: c.AuthorID,
+			Role:     c.Role,
+			Position: c.Position,
+		})
+	}
+	return contributors, nil
+}
+
+// intersectBooks returns the books that are also in others, in the order of
+// books.
+func intersectBooks(books, others []domain.Book) []domain.Book {
+	ids := make(map[int64]bool, len(others))
+	for _, b := range others {
+		ids[b.ID] = true
+	}
+	res := make([]domain.Book, 0, len(books))
+	for _, b := range books {
+		if ids[b.ID] {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+func isWebhookEventType(eventType string) bool {
+	for _, et := range domain.WebhookEventTypes {
+		if et == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func bulkMode(mode *domain.BulkMode) domain.BulkMode {
+	if mode == nil {
+		return domain.BulkAllOrNothing
+	}
+	return *mode
+}
+
+func dealStatus(status *domain.DealStatus) domain.DealStatus {
+	if status == nil {
+		return domain.DealNegotiating
+	}
+	return *status
+}
+
+func newBulkBooksPayload(res *domain.BulkBooksResult) *gqlgen.BulkBooksPayload {
+	payload := &gqlgen.BulkBooksPayload{
+		Committed: res.Committed,
+		Results:   make([]gqlgen.BulkBookResult, 0, len(res.Books)),
+	}
+	for i, book := range res.Books {
+		payload.Results = append(payload.Results, gqlgen.BulkBookResult{
+			Book:  book,
+			Error: errorToStringPtr(res.Errors[i]),
+		})
+	}
+	return payload
 }
 
-func stringPtrToNullString(s *string) sql.NullString {
-	if s != nil {
-		return sql.NullString{String: *s, Valid: true}
+func errorToStringPtr(err error) *string {
+	if err == nil {
+		return nil
 	}
-	return sql.NullString{}
+	s := err.Error()
+	return &s
 }
@@ -2,49 +2,314 @@ package resolvers_test
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/fwojciec/litag-example/domain"
 	"github.com/fwojciec/litag-example/generated/gqlgen"
 	"github.com/fwojciec/litag-example/generated/mocks"
-	"github.com/fwojciec/litag-example/generated/sqlc"
-	"github.com/fwojciec/litag-example/postgres"
 	"github.com/fwojciec/litag-example/resolvers"
 )
 
 var (
-	testAgent   = &sqlc.Agent{ID: 99}
-	testAuthor1 = &sqlc.Author{
+	testAgency  = &domain.Agency{ID: 12, Name: "test agency"}
+	testAgent   = &domain.Agent{ID: 99, AgencyID: int64Ptr(12)}
+	testAuthor1 = &domain.Author{
 		ID:      99,
 		Name:    "test name 1",
-		Website: sql.NullString{String: "https://t.com", Valid: true},
+		Website: stringPtr("https://t.com"),
 		AgentID: 22,
 	}
-	testAuthor2 = &sqlc.Author{
+	testAuthor2 = &domain.Author{
 		ID:      199,
 		Name:    "test name 2",
-		Website: sql.NullString{},
+		Website: nil,
 		AgentID: 122,
 	}
-	testBook = &sqlc.Book{
-		ID:          88,
-		Title:       "test title 1",
-		Description: "test description 1",
-		Cover:       "cover1.jpg",
+	testBook = &domain.Book{
+		ID:             88,
+		Title:          "test title 1",
+		Description:    "test description 1",
+		Cover:          "cover1.jpg",
+		PublisherID:    int64Ptr(55),
+		SeriesID:       int64Ptr(33),
+		SeriesPosition: intPtr(2),
+		ISBN:           isbnPtr("9780306406157"),
+		PublishedOn:    &domain.Date{Year: 2020, Month: time.March, Day: 5},
+		PageCount:      intPtr(320),
+		Language:       languageCodePtr("en"),
+	}
+	testPublisher = &domain.Publisher{
+		ID:   55,
+		Name: "test publisher",
+	}
+	testSeries = &domain.Series{
+		ID:    33,
+		Title: "test series",
+	}
+	testSubmission = &domain.Submission{
+		ID:            144,
+		AgentID:       99,
+		AuthorName:    "test author",
+		AuthorWebsite: stringPtr("https://a.com"),
+		Title:         "test manuscript",
+		Synopsis:      "test synopsis",
+		Status:        domain.SubmissionOffered,
+		BookID:        int64Ptr(88),
+	}
+	testContributor = &domain.Contributor{
+		AuthorID: 199,
+		Role:     domain.ContributorTranslator,
+		Position: 2,
+	}
+	testRepresentation = &domain.Representation{
+		ID:        11,
+		AuthorID:  99,
+		AgentID:   122,
+		Territory: "FR",
+		StartedAt: domain.Date{Year: 2020, Month: time.January, Day: 1},
+	}
+	testEdition = &domain.Edition{
+		ID:          66,
+		BookID:      88,
+		Format:      domain.EditionHardcover,
+		ISBN:        isbnPtr("9780306406157"),
+		PublishedOn: &domain.Date{Year: 2019, Month: time.May, Day: 1},
+		Price:       &domain.Price{Amount: 2500, Currency: "USD"},
+	}
+	testAvailableRights = &domain.AvailableRights{
+		BookID:  88,
+		Formats: []domain.EditionFormat{domain.EditionHardcover, domain.EditionEbook},
+	}
+	testDeal = &domain.Deal{
+		ID:             111,
+		BookID:         88,
+		PublisherID:    55,
+		AgentID:        99,
+		Territory:      "DE",
+		Language:       "de",
+		Format:         domain.EditionPaperback,
+		Advance:        domain.Money{Amount: 1500000, Currency: "EUR"},
+		SignedOn:       &domain.Date{Year: 2021, Month: time.June, Day: 1},
+		Status:         domain.DealSigned,
+		RoyaltyRate:    1000,
+		CommissionRate: 1500,
+	}
+	testRoyaltyRate = &domain.RoyaltyRate{
+		DealID:    111,
+		EditionID: 66,
+		Rate:      1200,
+	}
+	testSales = &domain.Sales{
+		ID:        131,
+		DealID:    111,
+		EditionID: 66,
+		Period:    "2024-Q1",
+		Units:     100,
+		Revenue:   domain.Money{Amount: 200000, Currency: "EUR"},
+	}
+	testRoyaltyStatement = &domain.RoyaltyStatement{
+		AuthorID: 99,
+		Period:   "2024-Q1",
+		Lines: []domain.RoyaltyLine{{
+			Sales:          *testSales,
+			BookID:         88,
+			AgentID:        99,
+			RoyaltyRate:    1000,
+			Royalty:        domain.Money{Amount: 20000, Currency: "EUR"},
+			Authors:        2,
+			Share:          domain.Money{Amount: 10000, Currency: "EUR"},
+			CommissionRate: 1500,
+			Commission:     domain.Money{Amount: 1500, Currency: "EUR"},
+			Net:            domain.Money{Amount: 8500, Currency: "EUR"},
+		}},
+	}
+	testGenre = &domain.Genre{
+		ID:       44,
+		Name:     "test genre",
+		ParentID: int64Ptr(43),
+	}
+	testWebhook = &domain.Webhook{
+		ID:         77,
+		URL:        "https://example.com/hook",
+		Secret:     "secret",
+		EventTypes: []string{domain.EventBookCreated, domain.EventAuthorDeleted},
+	}
+	testWebhookDelivery1 = &domain.WebhookDelivery{
+		ID:             66,
+		WebhookID:      77,
+		Payload:        []byte(`{"type":"book.created"}`),
+		ResponseStatus: intPtr(500),
+		LastError:      stringPtr("unexpected response status"),
+	}
+	testWebhookDelivery2 = &domain.WebhookDelivery{
+		ID:        166,
+		WebhookID: 177,
+		Payload:   []byte(`{}`),
 	}
 	testError = errors.New("test error")
 )
 
+func TestAgencyResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Agents", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			agency *domain.Agency
+			err    error
+		}{
+			{"valid", testAgency, nil},
+			{"error", testAgency, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgencyID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListAgentsByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Agent, error) {
+							receivedAgencyID = agencyID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Agency().Agents(context.Background(), tc.agency)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAgencyID != tc.agency.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.agency.ID, receivedAgencyID)
+				}
+			})
+		}
+	})
+
+	t.Run("Authors", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			agency *domain.Agency
+			err    error
+		}{
+			{"valid", testAgency, nil},
+			{"error", testAgency, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgencyID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListAuthorsByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Author, error) {
+							receivedAgencyID = agencyID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Agency().Authors(context.Background(), tc.agency)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAgencyID != tc.agency.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.agency.ID, receivedAgencyID)
+				}
+			})
+		}
+	})
+
+	t.Run("Books", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			agency *domain.Agency
+			err    error
+		}{
+			{"valid", testAgency, nil},
+			{"error", testAgency, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgencyID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListBooksByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Book, error) {
+							receivedAgencyID = agencyID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Agency().Books(context.Background(), tc.agency)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAgencyID != tc.agency.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.agency.ID, receivedAgencyID)
+				}
+			})
+		}
+	})
+}
+
 func TestAgentResolver(t *testing.T) {
 	t.Parallel()
+	t.Run("Agency", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			agent *domain.Agent
+			err   error
+			calls int
+		}{
+			{"valid", testAgent, nil, 1},
+			{"error", testAgent, testError, 1},
+			{"no agency", &domain.Agent{ID: 1}, nil, 0},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgencyID int64
+				mock := &mocks.RepositoryMock{
+					GetAgencyFunc: func(ctx context.Context, id int64) (domain.Agency, error) {
+						receivedAgencyID = id
+						return domain.Agency{}, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				agency, err := r.Agent().Agency(context.Background(), tc.agent)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if calls := len(mock.GetAgencyCalls()); calls != tc.calls {
+					t.Fatalf("expected %d calls, received %d", tc.calls, calls)
+				}
+				if tc.calls == 0 {
+					if agency != nil {
+						t.Errorf("expected no agency, received %v", agency)
+					}
+					return
+				}
+				if receivedAgencyID != *tc.agent.AgencyID {
+					t.Errorf("wrong id: expected %d, received %d", *tc.agent.AgencyID, receivedAgencyID)
+				}
+			})
+		}
+	})
+
 	t.Run("Authors", func(t *testing.T) {
 		t.Parallel()
 
 		tests := []struct {
 			name  string
-			agent *sqlc.Agent
+			agent *domain.Agent
 			err   error
 		}{
 			{"valid", testAgent, nil},
@@ -56,12 +321,10 @@ func TestAgentResolver(t *testing.T) {
 				t.Parallel()
 				var receivedAgentID int64
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							ListAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]sqlc.Author, error) {
-								receivedAgentID = agentID
-								return nil, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						ListAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Author, error) {
+							receivedAgentID = agentID
+							return nil, tc.err
 						},
 					},
 				}
@@ -75,47 +338,131 @@ func TestAgentResolver(t *testing.T) {
 			})
 		}
 	})
-}
 
-func TestAuthorResolver(t *testing.T) {
-	t.Parallel()
+	t.Run("FormerAuthors", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name  string
+			agent *domain.Agent
+			err   error
+		}{
+			{"valid", testAgent, nil},
+			{"error", testAgent, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgentID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListFormerAuthorsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Author, error) {
+							receivedAgentID = agentID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Agent().FormerAuthors(context.Background(), tc.agent)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAgentID != tc.agent.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.agent.ID, receivedAgentID)
+				}
+			})
+		}
+	})
+
+	t.Run("Deals", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			agent *domain.Agent
+			err   error
+		}{
+			{"valid", testAgent, nil},
+			{"error", testAgent, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgentID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListDealsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Deal, error) {
+							receivedAgentID = agentID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Agent().Deals(context.Background(), tc.agent)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAgentID != tc.agent.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.agent.ID, receivedAgentID)
+				}
+			})
+		}
+	})
 
-	t.Run("Website", func(t *testing.T) {
+	t.Run("Submissions", func(t *testing.T) {
 		t.Parallel()
+		reading := domain.SubmissionReading
 		tests := []struct {
 			name   string
-			author *sqlc.Author
+			status *domain.SubmissionStatus
+			err    error
 		}{
-			{"has website", testAuthor1},
-			{"has no website", testAuthor2},
+			{"valid", nil, nil},
+			{"valid with status", &reading, nil},
+			{"error", nil, testError},
+			{"error with status", &reading, testError},
 		}
 		for _, tc := range tests {
 			tc := tc
 			t.Run(tc.name, func(t *testing.T) {
 				t.Parallel()
-				r := &resolvers.Resolver{}
-				res, err := r.Author().Website(context.Background(), tc.author)
-				if err != nil {
-					t.Errorf("expected no error, received: %v", err)
+				var receivedAgentID int64
+				var receivedStatus domain.SubmissionStatus
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListSubmissionsByAgentIDFunc: func(ctx context.Context, agentID int64) ([]domain.Submission, error) {
+							receivedAgentID = agentID
+							return nil, tc.err
+						},
+						ListSubmissionsByAgentIDAndStatusFunc: func(ctx context.Context, args domain.ListSubmissionsByAgentIDAndStatusParams) ([]domain.Submission, error) {
+							receivedAgentID = args.AgentID
+							receivedStatus = args.Status
+							return nil, tc.err
+						},
+					},
 				}
-				if !tc.author.Website.Valid && res != nil {
-					t.Errorf("expected result to be nil, received: %v", res)
+				_, err := r.Agent().Submissions(context.Background(), testAgent, tc.status)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
 				}
-				if tc.author.Website.Valid && res == nil {
-					t.Fatalf("expected pointer not to be nil")
+				if receivedAgentID != testAgent.ID {
+					t.Errorf("wrong id: expected %d, received %d", testAgent.ID, receivedAgentID)
 				}
-				if tc.author.Website.Valid && tc.author.Website.String != *res {
-					t.Errorf("expected %s, received %s", *res, tc.author.Website.String)
+				if tc.status != nil && receivedStatus != *tc.status {
+					t.Errorf("wrong status: expected %s, received %s", *tc.status, receivedStatus)
 				}
 			})
 		}
 	})
+}
+
+func TestAuthorResolver(t *testing.T) {
+	t.Parallel()
 
 	t.Run("Agent", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name   string
-			author *sqlc.Author
+			author *domain.Author
 			err    error
 		}{
 			{"valid", testAuthor1, nil},
@@ -127,12 +474,10 @@ func TestAuthorResolver(t *testing.T) {
 				t.Parallel()
 				var receivedAgentID int64
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							GetAgentFunc: func(ctx context.Context, id int64) (sqlc.Agent, error) {
-								receivedAgentID = id
-								return sqlc.Agent{}, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						GetAgentFunc: func(ctx context.Context, id int64) (domain.Agent, error) {
+							receivedAgentID = id
+							return domain.Agent{}, tc.err
 						},
 					},
 				}
@@ -151,7 +496,7 @@ func TestAuthorResolver(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name   string
-			author *sqlc.Author
+			author *domain.Author
 			err    error
 		}{
 			{"valid", testAuthor1, nil},
@@ -163,12 +508,10 @@ func TestAuthorResolver(t *testing.T) {
 				t.Parallel()
 				var receivedAuthorID int64
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							ListBooksByAuthorIDFunc: func(ctx context.Context, authorID int64) ([]sqlc.Book, error) {
-								receivedAuthorID = authorID
-								return nil, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						ListBooksByAuthorIDFunc: func(ctx context.Context, authorID int64) ([]domain.Book, error) {
+							receivedAuthorID = authorID
+							return nil, tc.err
 						},
 					},
 				}
@@ -182,6 +525,77 @@ func TestAuthorResolver(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Representations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			author *domain.Author
+			err    error
+		}{
+			{"valid", testAuthor1, nil},
+			{"error", testAuthor2, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAuthorID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListRepresentationsByAuthorIDFunc: func(ctx context.Context, authorID int64) ([]domain.Representation, error) {
+							receivedAuthorID = authorID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Author().Representations(context.Background(), tc.author)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAuthorID != tc.author.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.author.ID, receivedAuthorID)
+				}
+			})
+		}
+	})
+}
+
+func TestAvailableRightsResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Book", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			rights *domain.AvailableRights
+			err    error
+		}{
+			{"valid", testAvailableRights, nil},
+			{"error", testAvailableRights, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetBookFunc: func(ctx context.Context, id int64) (domain.Book, error) {
+							receivedBookID = id
+							return domain.Book{}, tc.err
+						},
+					},
+				}
+				_, err := r.AvailableRights().Book(context.Background(), tc.rights)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.rights.BookID {
+					t.Errorf("wrong id: expected %d, received %d", tc.rights.BookID, receivedBookID)
+				}
+			})
+		}
+	})
 }
 
 func TestBookResolver(t *testing.T) {
@@ -190,7 +604,7 @@ func TestBookResolver(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name string
-			book *sqlc.Book
+			book *domain.Book
 			err  error
 		}{
 			{"valid", testBook, nil},
@@ -202,12 +616,10 @@ func TestBookResolver(t *testing.T) {
 				t.Parallel()
 				var receivedBookID int64
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							ListAuthorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]sqlc.Author, error) {
-								receivedBookID = bookID
-								return nil, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						ListAuthorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Author, error) {
+							receivedBookID = bookID
+							return nil, tc.err
 						},
 					},
 				}
@@ -221,379 +633,3965 @@ func TestBookResolver(t *testing.T) {
 			})
 		}
 	})
-}
 
-func TestMutationResolver(t *testing.T) {
-	t.Parallel()
-
-	t.Run("Agent mutations", func(t *testing.T) {
+	t.Run("Publisher", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name  string
-			agent *sqlc.Agent
+			book  *domain.Book
 			err   error
+			calls int
 		}{
-			{"valid", testAgent, nil},
-			{"error", testAgent, testError},
+			{"valid", testBook, nil, 1},
+			{"error", testBook, testError, 1},
+			{"no publisher", &domain.Book{ID: 1}, nil, 0},
 		}
-
-		t.Run("CreateAgent", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedCreateAgentParams sqlc.CreateAgentParams
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							Querent: &mocks.QuerentMock{
-								CreateAgentFunc: func(ctx context.Context, args sqlc.CreateAgentParams) (sqlc.Agent, error) {
-									receivedCreateAgentParams = args
-									return sqlc.Agent{}, tc.err
-								},
-							},
-						},
-					}
-					_, err := r.Mutation().CreateAgent(context.Background(), gqlgen.CreateUpdateAgentInput{
-						Name:  tc.agent.Name,
-						Email: tc.agent.Email,
-					})
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					exp := sqlc.CreateAgentParams{
-						Name:  tc.agent.Name,
-						Email: tc.agent.Email,
-					}
-					if !reflect.DeepEqual(receivedCreateAgentParams, exp) {
-						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateAgentParams)
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedPublisherID int64
+				mock := &mocks.RepositoryMock{
+					GetPublisherFunc: func(ctx context.Context, id int64) (domain.Publisher, error) {
+						receivedPublisherID = id
+						return domain.Publisher{}, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				publisher, err := r.Book().Publisher(context.Background(), tc.book)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if calls := len(mock.GetPublisherCalls()); calls != tc.calls {
+					t.Fatalf("expected %d calls, received %d", tc.calls, calls)
+				}
+				if tc.calls == 0 {
+					if publisher != nil {
+						t.Errorf("expected no publisher, received %v", publisher)
 					}
-				})
-			}
-		})
+					return
+				}
+				if receivedPublisherID != *tc.book.PublisherID {
+					t.Errorf("wrong id: expected %d, received %d", *tc.book.PublisherID, receivedPublisherID)
+				}
+			})
+		}
+	})
 
-		t.Run("UpdateAgent", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedUpdateAgentParams sqlc.UpdateAgentParams
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							Querent: &mocks.QuerentMock{
-								UpdateAgentFunc: func(ctx context.Context, args sqlc.UpdateAgentParams) (sqlc.Agent, error) {
-									receivedUpdateAgentParams = args
-									return sqlc.Agent{}, tc.err
-								},
-							},
-						},
-					}
-					_, err := r.Mutation().UpdateAgent(context.Background(), tc.agent.ID, gqlgen.CreateUpdateAgentInput{
-						Name:  tc.agent.Name,
-						Email: tc.agent.Email,
-					})
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					exp := sqlc.UpdateAgentParams{
-						ID:    tc.agent.ID,
-						Name:  tc.agent.Name,
-						Email: tc.agent.Email,
-					}
-					if !reflect.DeepEqual(receivedUpdateAgentParams, exp) {
-						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateAgentParams)
+	t.Run("Series", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			book  *domain.Book
+			err   error
+			calls int
+		}{
+			{"valid", testBook, nil, 1},
+			{"error", testBook, testError, 1},
+			{"no series", &domain.Book{ID: 1}, nil, 0},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedSeriesID int64
+				mock := &mocks.RepositoryMock{
+					GetSeriesFunc: func(ctx context.Context, id int64) (domain.Series, error) {
+						receivedSeriesID = id
+						return domain.Series{}, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				series, err := r.Book().Series(context.Background(), tc.book)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if calls := len(mock.GetSeriesCalls()); calls != tc.calls {
+					t.Fatalf("expected %d calls, received %d", tc.calls, calls)
+				}
+				if tc.calls == 0 {
+					if series != nil {
+						t.Errorf("expected no series, received %v", series)
 					}
-				})
-			}
-		})
+					return
+				}
+				if receivedSeriesID != *tc.book.SeriesID {
+					t.Errorf("wrong id: expected %d, received %d", *tc.book.SeriesID, receivedSeriesID)
+				}
+			})
+		}
+	})
 
-		t.Run("DeleteAgent", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedAgentID int64
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							Querent: &mocks.QuerentMock{
-								DeleteAgentFunc: func(ctx context.Context, id int64) (sqlc.Agent, error) {
-									receivedAgentID = id
-									return sqlc.Agent{}, tc.err
-								},
-							},
+	t.Run("Genres", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			book *domain.Book
+			err  error
+		}{
+			{"valid", testBook, nil},
+			{"error", testBook, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListGenresByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Genre, error) {
+							receivedBookID = bookID
+							return nil, tc.err
 						},
-					}
-					_, err := r.Mutation().DeleteAgent(context.Background(), tc.agent.ID)
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					if receivedAgentID != tc.agent.ID {
-						t.Errorf("wrong id: expected %d, received %d", tc.agent.ID, receivedAgentID)
-					}
-				})
-			}
-		})
+					},
+				}
+				_, err := r.Book().Genres(context.Background(), tc.book)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.book.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.book.ID, receivedBookID)
+				}
+			})
+		}
 	})
 
-	t.Run("Author mutations", func(t *testing.T) {
+	t.Run("Tags", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
-			name   string
-			author *sqlc.Author
-			err    error
+			name string
+			book *domain.Book
+			err  error
 		}{
-			{"valid", testAuthor1, nil},
-			{"valid null website", testAuthor2, nil},
-			{"error", testAuthor1, testError},
+			{"valid", testBook, nil},
+			{"error", testBook, testError},
 		}
-
-		t.Run("CreateAuthor", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedCreateAuthorParams sqlc.CreateAuthorParams
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							Querent: &mocks.QuerentMock{
-								CreateAuthorFunc: func(ctx context.Context, args sqlc.CreateAuthorParams) (sqlc.Author, error) {
-									receivedCreateAuthorParams = args
-									return sqlc.Author{}, tc.err
-								},
-							},
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListTagsByBookIDFunc: func(ctx context.Context, bookID int64) ([]string, error) {
+							receivedBookID = bookID
+							return nil, tc.err
 						},
-					}
-					_, err := r.Mutation().CreateAuthor(context.Background(), gqlgen.CreateUpdateAuthorInput{
-						Name:    tc.author.Name,
-						Website: nullStringToPointer(tc.author.Website),
-						AgentID: tc.author.AgentID,
-					})
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					exp := sqlc.CreateAuthorParams{
-						Name:    tc.author.Name,
-						Website: tc.author.Website,
-						AgentID: tc.author.AgentID,
-					}
-					if !reflect.DeepEqual(receivedCreateAuthorParams, exp) {
-						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateAuthorParams)
-					}
-				})
-			}
-		})
+					},
+				}
+				_, err := r.Book().Tags(context.Background(), tc.book)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.book.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.book.ID, receivedBookID)
+				}
+			})
+		}
+	})
 
-		t.Run("UpdateAuthor", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedUpdateAuthorParams sqlc.UpdateAuthorParams
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							Querent: &mocks.QuerentMock{
-								UpdateAuthorFunc: func(ctx context.Context, args sqlc.UpdateAuthorParams) (sqlc.Author, error) {
-									receivedUpdateAuthorParams = args
-									return sqlc.Author{}, tc.err
-								},
-							},
+	t.Run("Editions", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			book *domain.Book
+			err  error
+		}{
+			{"valid", testBook, nil},
+			{"error", testBook, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListEditionsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Edition, error) {
+							receivedBookID = bookID
+							return nil, tc.err
 						},
-					}
-					_, err := r.Mutation().UpdateAuthor(context.Background(), tc.author.ID, gqlgen.CreateUpdateAuthorInput{
-						Name:    tc.author.Name,
-						Website: nullStringToPointer(tc.author.Website),
-						AgentID: tc.author.AgentID,
-					})
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					exp := sqlc.UpdateAuthorParams{
-						ID:      tc.author.ID,
-						Name:    tc.author.Name,
-						Website: tc.author.Website,
-						AgentID: tc.author.AgentID,
-					}
-					if !reflect.DeepEqual(receivedUpdateAuthorParams, exp) {
-						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateAuthorParams)
-					}
-				})
-			}
-		})
+					},
+				}
+				_, err := r.Book().Editions(context.Background(), tc.book)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.book.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.book.ID, receivedBookID)
+				}
+			})
+		}
+	})
 
-		t.Run("DeleteAuthor", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedAuthorID int64
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							Querent: &mocks.QuerentMock{
-								DeleteAuthorFunc: func(ctx context.Context, id int64) (sqlc.Author, error) {
-									receivedAuthorID = id
-									return sqlc.Author{}, tc.err
-								},
-							},
+	t.Run("Deals", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			book *domain.Book
+			err  error
+		}{
+			{"valid", testBook, nil},
+			{"error", testBook, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListDealsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Deal, error) {
+							receivedBookID = bookID
+							return nil, tc.err
 						},
-					}
-					_, err := r.Mutation().DeleteAuthor(context.Background(), tc.author.ID)
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					if receivedAuthorID != tc.author.ID {
-						t.Errorf("wrong id: expected %d, received %d", tc.author.ID, receivedAuthorID)
-					}
-				})
-			}
-		})
+					},
+				}
+				_, err := r.Book().Deals(context.Background(), tc.book)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.book.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.book.ID, receivedBookID)
+				}
+			})
+		}
+	})
+
+	t.Run("Contributors", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			book *domain.Book
+			err  error
+		}{
+			{"valid", testBook, nil},
+			{"error", testBook, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListContributorsByBookIDFunc: func(ctx context.Context, bookID int64) ([]domain.Contributor, error) {
+							receivedBookID = bookID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Book().Contributors(context.Background(), tc.book)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.book.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.book.ID, receivedBookID)
+				}
+			})
+		}
+	})
+}
+
+func TestContributorResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Author", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name        string
+			contributor *domain.Contributor
+			err         error
+		}{
+			{"valid", testContributor, nil},
+			{"error", testContributor, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAuthorID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAuthorFunc: func(ctx context.Context, id int64) (domain.Author, error) {
+							receivedAuthorID = id
+							return domain.Author{}, tc.err
+						},
+					},
+				}
+				_, err := r.Contributor().Author(context.Background(), tc.contributor)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAuthorID != tc.contributor.AuthorID {
+					t.Errorf("wrong id: expected %d, received %d", tc.contributor.AuthorID, receivedAuthorID)
+				}
+			})
+		}
+	})
+}
+
+func TestDealResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Book", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			deal *domain.Deal
+			err  error
+		}{
+			{"valid", testDeal, nil},
+			{"error", testDeal, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetBookFunc: func(ctx context.Context, id int64) (domain.Book, error) {
+							receivedBookID = id
+							return domain.Book{}, tc.err
+						},
+					},
+				}
+				_, err := r.Deal().Book(context.Background(), tc.deal)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.deal.BookID {
+					t.Errorf("wrong id: expected %d, received %d", tc.deal.BookID, receivedBookID)
+				}
+			})
+		}
+	})
+
+	t.Run("Publisher", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			deal *domain.Deal
+			err  error
+		}{
+			{"valid", testDeal, nil},
+			{"error", testDeal, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedPublisherID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetPublisherFunc: func(ctx context.Context, id int64) (domain.Publisher, error) {
+							receivedPublisherID = id
+							return domain.Publisher{}, tc.err
+						},
+					},
+				}
+				_, err := r.Deal().Publisher(context.Background(), tc.deal)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedPublisherID != tc.deal.PublisherID {
+					t.Errorf("wrong id: expected %d, received %d", tc.deal.PublisherID, receivedPublisherID)
+				}
+			})
+		}
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			deal *domain.Deal
+			err  error
+		}{
+			{"valid", testDeal, nil},
+			{"error", testDeal, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgentID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAgentFunc: func(ctx context.Context, id int64) (domain.Agent, error) {
+							receivedAgentID = id
+							return domain.Agent{}, tc.err
+						},
+					},
+				}
+				_, err := r.Deal().Agent(context.Background(), tc.deal)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAgentID != tc.deal.AgentID {
+					t.Errorf("wrong id: expected %d, received %d", tc.deal.AgentID, receivedAgentID)
+				}
+			})
+		}
+	})
+
+	t.Run("RoyaltyRates", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			deal *domain.Deal
+			err  error
+		}{
+			{"valid", testDeal, nil},
+			{"error", testDeal, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedDealID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListRoyaltyRatesByDealIDFunc: func(ctx context.Context, dealID int64) ([]domain.RoyaltyRate, error) {
+							receivedDealID = dealID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Deal().RoyaltyRates(context.Background(), tc.deal)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedDealID != tc.deal.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.deal.ID, receivedDealID)
+				}
+			})
+		}
+	})
+
+	t.Run("Sales", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			deal *domain.Deal
+			err  error
+		}{
+			{"valid", testDeal, nil},
+			{"error", testDeal, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedDealID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListSalesByDealIDFunc: func(ctx context.Context, dealID int64) ([]domain.Sales, error) {
+							receivedDealID = dealID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Deal().Sales(context.Background(), tc.deal)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedDealID != tc.deal.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.deal.ID, receivedDealID)
+				}
+			})
+		}
+	})
+}
+
+func TestEditionResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Book", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name    string
+			edition *domain.Edition
+			err     error
+		}{
+			{"valid", testEdition, nil},
+			{"error", testEdition, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetBookFunc: func(ctx context.Context, id int64) (domain.Book, error) {
+							receivedBookID = id
+							return domain.Book{}, tc.err
+						},
+					},
+				}
+				_, err := r.Edition().Book(context.Background(), tc.edition)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.edition.BookID {
+					t.Errorf("wrong id: expected %d, received %d", tc.edition.BookID, receivedBookID)
+				}
+			})
+		}
+	})
+}
+
+func TestGenreResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Parent", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			genre *domain.Genre
+			err   error
+			calls int
+		}{
+			{"valid", testGenre, nil, 1},
+			{"error", testGenre, testError, 1},
+			{"no parent", &domain.Genre{ID: 1}, nil, 0},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedGenreID int64
+				mock := &mocks.RepositoryMock{
+					GetGenreFunc: func(ctx context.Context, id int64) (domain.Genre, error) {
+						receivedGenreID = id
+						return domain.Genre{}, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				parent, err := r.Genre().Parent(context.Background(), tc.genre)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if calls := len(mock.GetGenreCalls()); calls != tc.calls {
+					t.Fatalf("expected %d calls, received %d", tc.calls, calls)
+				}
+				if tc.calls == 0 {
+					if parent != nil {
+						t.Errorf("expected no parent, received %v", parent)
+					}
+					return
+				}
+				if receivedGenreID != *tc.genre.ParentID {
+					t.Errorf("wrong id: expected %d, received %d", *tc.genre.ParentID, receivedGenreID)
+				}
+			})
+		}
+	})
+
+	t.Run("Children", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			genre *domain.Genre
+			err   error
+		}{
+			{"valid", testGenre, nil},
+			{"error", testGenre, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedParentID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListGenresByParentIDFunc: func(ctx context.Context, parentID int64) ([]domain.Genre, error) {
+							receivedParentID = parentID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Genre().Children(context.Background(), tc.genre)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedParentID != tc.genre.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.genre.ID, receivedParentID)
+				}
+			})
+		}
+	})
+
+	t.Run("Books", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name             string
+			includeSubgenres *bool
+			expSubgenres     bool
+			err              error
+		}{
+			{"valid", nil, false, nil},
+			{"include subgenres", boolPtr(true), true, nil},
+			{"exclude subgenres", boolPtr(false), false, nil},
+			{"error", nil, false, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedGenreID int64
+				var receivedSubgenres bool
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListBooksByGenreIDFunc: func(ctx context.Context, genreID int64, includeSubgenres bool) ([]domain.Book, error) {
+							receivedGenreID = genreID
+							receivedSubgenres = includeSubgenres
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Genre().Books(context.Background(), testGenre, tc.includeSubgenres)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedGenreID != testGenre.ID {
+					t.Errorf("wrong id: expected %d, received %d", testGenre.ID, receivedGenreID)
+				}
+				if receivedSubgenres != tc.expSubgenres {
+					t.Errorf("wrong includeSubgenres: expected %t, received %t", tc.expSubgenres, receivedSubgenres)
+				}
+			})
+		}
+	})
+}
+
+func TestPublisherResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Books", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name      string
+			publisher *domain.Publisher
+			err       error
+		}{
+			{"valid", testPublisher, nil},
+			{"error", testPublisher, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedPublisherID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListBooksByPublisherIDFunc: func(ctx context.Context, publisherID int64) ([]domain.Book, error) {
+							receivedPublisherID = publisherID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Publisher().Books(context.Background(), tc.publisher)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedPublisherID != tc.publisher.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.publisher.ID, receivedPublisherID)
+				}
+			})
+		}
+	})
+}
+
+func TestRepresentationResolver(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		representation *domain.Representation
+		err            error
+	}{
+		{"valid", testRepresentation, nil},
+		{"error", testRepresentation, testError},
+	}
+
+	t.Run("Author", func(t *testing.T) {
+		t.Parallel()
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAuthorID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAuthorFunc: func(ctx context.Context, id int64) (domain.Author, error) {
+							receivedAuthorID = id
+							return domain.Author{}, tc.err
+						},
+					},
+				}
+				_, err := r.Representation().Author(context.Background(), tc.representation)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAuthorID != tc.representation.AuthorID {
+					t.Errorf("wrong id: expected %d, received %d", tc.representation.AuthorID, receivedAuthorID)
+				}
+			})
+		}
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		t.Parallel()
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgentID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAgentFunc: func(ctx context.Context, id int64) (domain.Agent, error) {
+							receivedAgentID = id
+							return domain.Agent{}, tc.err
+						},
+					},
+				}
+				_, err := r.Representation().Agent(context.Background(), tc.representation)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAgentID != tc.representation.AgentID {
+					t.Errorf("wrong id: expected %d, received %d", tc.representation.AgentID, receivedAgentID)
+				}
+			})
+		}
+	})
+}
+
+func TestRoyaltyLineResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Book", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			line *domain.RoyaltyLine
+			err  error
+		}{
+			{"valid", &testRoyaltyStatement.Lines[0], nil},
+			{"error", &testRoyaltyStatement.Lines[0], testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetBookFunc: func(ctx context.Context, id int64) (domain.Book, error) {
+							receivedBookID = id
+							return domain.Book{}, tc.err
+						},
+					},
+				}
+				_, err := r.RoyaltyLine().Book(context.Background(), tc.line)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedBookID != tc.line.BookID {
+					t.Errorf("wrong id: expected %d, received %d", tc.line.BookID, receivedBookID)
+				}
+			})
+		}
+	})
+}
+
+func TestRoyaltyRateResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Deal", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			rate *domain.RoyaltyRate
+			err  error
+		}{
+			{"valid", testRoyaltyRate, nil},
+			{"error", testRoyaltyRate, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedDealID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetDealFunc: func(ctx context.Context, id int64) (domain.Deal, error) {
+							receivedDealID = id
+							return domain.Deal{}, tc.err
+						},
+					},
+				}
+				_, err := r.RoyaltyRate().Deal(context.Background(), tc.rate)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedDealID != tc.rate.DealID {
+					t.Errorf("wrong id: expected %d, received %d", tc.rate.DealID, receivedDealID)
+				}
+			})
+		}
+	})
+
+	t.Run("Edition", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			rate *domain.RoyaltyRate
+			err  error
+		}{
+			{"valid", testRoyaltyRate, nil},
+			{"error", testRoyaltyRate, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedEditionID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetEditionFunc: func(ctx context.Context, id int64) (domain.Edition, error) {
+							receivedEditionID = id
+							return domain.Edition{}, tc.err
+						},
+					},
+				}
+				_, err := r.RoyaltyRate().Edition(context.Background(), tc.rate)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedEditionID != tc.rate.EditionID {
+					t.Errorf("wrong id: expected %d, received %d", tc.rate.EditionID, receivedEditionID)
+				}
+			})
+		}
+	})
+}
+
+func TestRoyaltyStatementResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Author", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name      string
+			statement *domain.RoyaltyStatement
+			err       error
+		}{
+			{"valid", testRoyaltyStatement, nil},
+			{"error", testRoyaltyStatement, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAuthorID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAuthorFunc: func(ctx context.Context, id int64) (domain.Author, error) {
+							receivedAuthorID = id
+							return domain.Author{}, tc.err
+						},
+					},
+				}
+				_, err := r.RoyaltyStatement().Author(context.Background(), tc.statement)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAuthorID != tc.statement.AuthorID {
+					t.Errorf("wrong id: expected %d, received %d", tc.statement.AuthorID, receivedAuthorID)
+				}
+			})
+		}
+	})
+
+	t.Run("Csv", func(t *testing.T) {
+		t.Parallel()
+		r := &resolvers.Resolver{}
+		csv, err := r.RoyaltyStatement().Csv(context.Background(), testRoyaltyStatement)
+		if err != nil {
+			t.Fatalf("expected no error, received %v", err)
+		}
+		exp := "sales_id,deal_id,edition_id,book_id,agent_id,period,units,currency," +
+			"revenue,royalty_rate,royalty,authors,share,commission_rate,commission,net\n" +
+			"131,111,66,88,99,2024-Q1,100,EUR,2000.00,1000,200.00,2,100.00,1500,15.00,85.00\n"
+		if csv != exp {
+			t.Errorf("expected %q, received %q", exp, csv)
+		}
+	})
+}
+
+func TestSalesResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Deal", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			sales *domain.Sales
+			err   error
+		}{
+			{"valid", testSales, nil},
+			{"error", testSales, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedDealID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetDealFunc: func(ctx context.Context, id int64) (domain.Deal, error) {
+							receivedDealID = id
+							return domain.Deal{}, tc.err
+						},
+					},
+				}
+				_, err := r.Sales().Deal(context.Background(), tc.sales)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedDealID != tc.sales.DealID {
+					t.Errorf("wrong id: expected %d, received %d", tc.sales.DealID, receivedDealID)
+				}
+			})
+		}
+	})
+
+	t.Run("Edition", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			sales *domain.Sales
+			err   error
+		}{
+			{"valid", testSales, nil},
+			{"error", testSales, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedEditionID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetEditionFunc: func(ctx context.Context, id int64) (domain.Edition, error) {
+							receivedEditionID = id
+							return domain.Edition{}, tc.err
+						},
+					},
+				}
+				_, err := r.Sales().Edition(context.Background(), tc.sales)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedEditionID != tc.sales.EditionID {
+					t.Errorf("wrong id: expected %d, received %d", tc.sales.EditionID, receivedEditionID)
+				}
+			})
+		}
+	})
+}
+
+func TestSeriesResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Books", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			series *domain.Series
+			err    error
+		}{
+			{"valid", testSeries, nil},
+			{"error", testSeries, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedSeriesID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListBooksBySeriesIDFunc: func(ctx context.Context, seriesID int64) ([]domain.Book, error) {
+							receivedSeriesID = seriesID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Series().Books(context.Background(), tc.series)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedSeriesID != tc.series.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.series.ID, receivedSeriesID)
+				}
+			})
+		}
+	})
+}
+
+func TestSubmissionResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Agent", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name       string
+			submission *domain.Submission
+			err        error
+		}{
+			{"valid", testSubmission, nil},
+			{"error", testSubmission, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgentID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAgentFunc: func(ctx context.Context, id int64) (domain.Agent, error) {
+							receivedAgentID = id
+							return domain.Agent{}, tc.err
+						},
+					},
+				}
+				_, err := r.Submission().Agent(context.Background(), tc.submission)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedAgentID != tc.submission.AgentID {
+					t.Errorf("wrong id: expected %d, received %d", tc.submission.AgentID, receivedAgentID)
+				}
+			})
+		}
+	})
+
+	t.Run("Transitions", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name       string
+			submission *domain.Submission
+			err        error
+		}{
+			{"valid", testSubmission, nil},
+			{"error", testSubmission, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedSubmissionID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListSubmissionTransitionsFunc: func(ctx context.Context, submissionID int64) ([]domain.SubmissionTransition, error) {
+							receivedSubmissionID = submissionID
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Submission().Transitions(context.Background(), tc.submission)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedSubmissionID != tc.submission.ID {
+					t.Errorf("wrong id: expected %d, received %d", tc.submission.ID, receivedSubmissionID)
+				}
+			})
+		}
+	})
+
+	t.Run("Book", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name       string
+			submission *domain.Submission
+			err        error
+			calls      int
+		}{
+			{"valid", testSubmission, nil, 1},
+			{"error", testSubmission, testError, 1},
+			{"not converted", &domain.Submission{ID: 1, Status: domain.SubmissionOffered}, nil, 0},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedBookID int64
+				mock := &mocks.RepositoryMock{
+					GetBookFunc: func(ctx context.Context, id int64) (domain.Book, error) {
+						receivedBookID = id
+						return domain.Book{}, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				book, err := r.Submission().Book(context.Background(), tc.submission)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if calls := len(mock.GetBookCalls()); calls != tc.calls {
+					t.Fatalf("expected %d calls, received %d", tc.calls, calls)
+				}
+				if tc.calls == 0 {
+					if book != nil {
+						t.Errorf("expected no book, received %v", book)
+					}
+					return
+				}
+				if receivedBookID != *tc.submission.BookID {
+					t.Errorf("wrong id: expected %d, received %d", *tc.submission.BookID, receivedBookID)
+				}
+			})
+		}
+	})
+}
+
+func TestWebhookResolver(t *testing.T) {
+	t.Parallel()
+	t.Run("Deliveries", func(t *testing.T) {
+		t.Parallel()
+		pending := domain.DeliveryPending
+		tests := []struct {
+			name   string
+			status *string
+			err    error
+		}{
+			{"valid", nil, nil},
+			{"valid with status", &pending, nil},
+			{"error", nil, testError},
+			{"error with status", &pending, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedWebhookID int64
+				var receivedStatus string
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListWebhookDeliveriesFunc: func(ctx context.Context, webhookID int64) ([]domain.WebhookDelivery, error) {
+							receivedWebhookID = webhookID
+							return nil, tc.err
+						},
+						ListWebhookDeliveriesByStatusFunc: func(ctx context.Context, args domain.ListWebhookDeliveriesByStatusParams) ([]domain.WebhookDelivery, error) {
+							receivedWebhookID = args.WebhookID
+							receivedStatus = args.Status
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Webhook().Deliveries(context.Background(), testWebhook, tc.status)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedWebhookID != testWebhook.ID {
+					t.Errorf("wrong id: expected %d, received %d", testWebhook.ID, receivedWebhookID)
+				}
+				if tc.status != nil && receivedStatus != *tc.status {
+					t.Errorf("wrong status: expected %s, received %s", *tc.status, receivedStatus)
+				}
+			})
+		}
+	})
+}
+
+func TestWebhookDeliveryResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Webhook", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name     string
+			delivery *domain.WebhookDelivery
+			err      error
+		}{
+			{"valid", testWebhookDelivery1, nil},
+			{"error", testWebhookDelivery2, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedWebhookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetWebhookFunc: func(ctx context.Context, id int64) (domain.Webhook, error) {
+							receivedWebhookID = id
+							return domain.Webhook{}, tc.err
+						},
+					},
+				}
+				_, err := r.WebhookDelivery().Webhook(context.Background(), tc.delivery)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedWebhookID != tc.delivery.WebhookID {
+					t.Errorf("wrong id: expected %d, received %d", tc.delivery.WebhookID, receivedWebhookID)
+				}
+			})
+		}
+	})
+
+	t.Run("Payload", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name     string
+			delivery *domain.WebhookDelivery
+		}{
+			{"event", testWebhookDelivery1},
+			{"empty object", testWebhookDelivery2},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				r := &resolvers.Resolver{}
+				payload, err := r.WebhookDelivery().Payload(context.Background(), tc.delivery)
+				if err != nil || payload != string(tc.delivery.Payload) {
+					t.Errorf("expected payload %s, received %s (%v)", tc.delivery.Payload, payload, err)
+				}
+			})
+		}
+	})
+}
+
+func TestMutationResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Agency mutations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			agency *domain.Agency
+			err    error
+		}{
+			{"valid", testAgency, nil},
+			{"error", testAgency, testError},
+		}
+
+		t.Run("CreateAgency", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedCreateAgencyParams domain.CreateAgencyParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateAgencyFunc: func(ctx context.Context, args domain.CreateAgencyParams) (domain.Agency, error) {
+								receivedCreateAgencyParams = args
+								return domain.Agency{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().CreateAgency(context.Background(), gqlgen.CreateUpdateAgencyInput{
+						Name: tc.agency.Name,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.CreateAgencyParams{Name: tc.agency.Name}
+					if receivedCreateAgencyParams != exp {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateAgencyParams)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateAgency", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedUpdateAgencyParams domain.UpdateAgencyParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdateAgencyFunc: func(ctx context.Context, args domain.UpdateAgencyParams) (domain.Agency, error) {
+								receivedUpdateAgencyParams = args
+								return domain.Agency{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().UpdateAgency(context.Background(), tc.agency.ID, gqlgen.CreateUpdateAgencyInput{
+						Name: tc.agency.Name,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.UpdateAgencyParams{ID: tc.agency.ID, Name: tc.agency.Name}
+					if receivedUpdateAgencyParams != exp {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateAgencyParams)
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteAgency", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedAgencyID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeleteAgencyFunc: func(ctx context.Context, id int64) (domain.Agency, error) {
+								receivedAgencyID = id
+								return domain.Agency{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeleteAgency(context.Background(), tc.agency.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedAgencyID != tc.agency.ID {
+						t.Errorf("wrong id: expected %d, received %d", tc.agency.ID, receivedAgencyID)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Agent mutations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			agent *domain.Agent
+			err   error
+		}{
+			{"valid", testAgent, nil},
+			{"error", testAgent, testError},
+		}
+
+		t.Run("CreateAgent", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedCreateAgentParams domain.CreateAgentParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateAgentFunc: func(ctx context.Context, args domain.CreateAgentParams) (domain.Agent, error) {
+								receivedCreateAgentParams = args
+								return domain.Agent{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().CreateAgent(context.Background(), gqlgen.CreateUpdateAgentInput{
+						Name:     tc.agent.Name,
+						Email:    tc.agent.Email,
+						AgencyID: tc.agent.AgencyID,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.CreateAgentParams{
+						Name:     tc.agent.Name,
+						Email:    tc.agent.Email,
+						AgencyID: tc.agent.AgencyID,
+					}
+					if !reflect.DeepEqual(receivedCreateAgentParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateAgentParams)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateAgent", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedUpdateAgentParams domain.UpdateAgentParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdateAgentFunc: func(ctx context.Context, args domain.UpdateAgentParams) (domain.Agent, error) {
+								receivedUpdateAgentParams = args
+								return domain.Agent{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().UpdateAgent(context.Background(), tc.agent.ID, gqlgen.CreateUpdateAgentInput{
+						Name:     tc.agent.Name,
+						Email:    tc.agent.Email,
+						AgencyID: tc.agent.AgencyID,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.UpdateAgentParams{
+						ID:       tc.agent.ID,
+						Name:     tc.agent.Name,
+						Email:    tc.agent.Email,
+						AgencyID: tc.agent.AgencyID,
+					}
+					if !reflect.DeepEqual(receivedUpdateAgentParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateAgentParams)
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteAgent", func(t *testing.T) {
+			t.Parallel()
+			var reassignTo int64 = 101
+			for _, tc := range tests {
+				for _, reassign := range []*int64{nil, &reassignTo} {
+					tc, reassign := tc, reassign
+					name := tc.name
+					if reassign != nil {
+						name += " with reassignment"
+					}
+					t.Run(name, func(t *testing.T) {
+						t.Parallel()
+						var receivedAgentID int64
+						var receivedReassignTo *int64
+						r := &resolvers.Resolver{
+							Repo: &mocks.RepositoryMock{
+								DeleteAgentFunc: func(ctx context.Context, id int64, reassignAuthorsTo *int64) (*domain.Agent, error) {
+									receivedAgentID = id
+									receivedReassignTo = reassignAuthorsTo
+									return nil, tc.err
+								},
+							},
+						}
+						_, err := r.Mutation().DeleteAgent(context.Background(), tc.agent.ID, reassign)
+						if !errors.Is(err, tc.err) {
+							t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+						}
+						if receivedAgentID != tc.agent.ID {
+							t.Errorf("wrong id: expected %d, received %d", tc.agent.ID, receivedAgentID)
+						}
+						if receivedReassignTo != reassign {
+							t.Errorf("wrong reassignment: expected %v, received %v", reassign, receivedReassignTo)
+						}
+					})
+				}
+			}
+		})
+	})
+
+	t.Run("Author mutations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			author *domain.Author
+			err    error
+		}{
+			{"valid", testAuthor1, nil},
+			{"valid null website", testAuthor2, nil},
+			{"error", testAuthor1, testError},
+		}
+
+		t.Run("CreateAuthor", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedCreateAuthorParams domain.CreateAuthorParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateAuthorFunc: func(ctx context.Context, args domain.CreateAuthorParams) (*domain.Author, error) {
+								receivedCreateAuthorParams = args
+								return nil, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().CreateAuthor(context.Background(), gqlgen.CreateUpdateAuthorInput{
+						Name:    tc.author.Name,
+						Website: tc.author.Website,
+						AgentID: tc.author.AgentID,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.CreateAuthorParams{
+						Name:    tc.author.Name,
+						Website: tc.author.Website,
+						AgentID: tc.author.AgentID,
+					}
+					if !reflect.DeepEqual(receivedCreateAuthorParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateAuthorParams)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateAuthor", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedUpdateAuthorParams domain.UpdateAuthorParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdateAuthorFunc: func(ctx context.Context, args domain.UpdateAuthorParams) (*domain.Author, error) {
+								receivedUpdateAuthorParams = args
+								return nil, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().UpdateAuthor(context.Background(), tc.author.ID, gqlgen.CreateUpdateAuthorInput{
+						Name:    tc.author.Name,
+						Website: tc.author.Website,
+						AgentID: tc.author.AgentID,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.UpdateAuthorParams{
+						ID:      tc.author.ID,
+						Name:    tc.author.Name,
+						Website: tc.author.Website,
+						AgentID: tc.author.AgentID,
+					}
+					if !reflect.DeepEqual(receivedUpdateAuthorParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateAuthorParams)
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteAuthor", func(t *testing.T) {
+			t.Parallel()
+			keep := domain.OrphanedBooksKeep
+			policies := []struct {
+				name   string
+				policy *domain.OrphanedBooksPolicy
+				exp    domain.OrphanedBooksPolicy
+			}{
+				{"default policy", nil, domain.OrphanedBooksFail},
+				{"keep policy", &keep, domain.OrphanedBooksKeep},
+			}
+			for _, tc := range tests {
+				for _, pc := range policies {
+					tc, pc := tc, pc
+					t.Run(tc.name+" with "+pc.name, func(t *testing.T) {
+						t.Parallel()
+						var receivedAuthorID int64
+						var receivedPolicy domain.OrphanedBooksPolicy
+						r := &resolvers.Resolver{
+							Repo: &mocks.RepositoryMock{
+								DeleteAuthorFunc: func(ctx context.Context, id int64, orphanedBooks domain.OrphanedBooksPolicy) (*domain.Author, error) {
+									receivedAuthorID = id
+									receivedPolicy = orphanedBooks
+									return nil, tc.err
+								},
+							},
+						}
+						_, err := r.Mutation().DeleteAuthor(context.Background(), tc.author.ID, pc.policy)
+						if !errors.Is(err, tc.err) {
+							t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+						}
+						if receivedAuthorID != tc.author.ID {
+							t.Errorf("wrong id: expected %d, received %d", tc.author.ID, receivedAuthorID)
+						}
+						if receivedPolicy != pc.exp {
+							t.Errorf("wrong policy: expected %s, received %s", pc.exp, receivedPolicy)
+						}
+					})
+				}
+			}
+		})
+	})
+
+	t.Run("Book mutations", func(t *testing.T) {
+		t.Parallel()
+		contributors := []gqlgen.ContributorInput{
+			{AuthorID: testAuthor2.ID, Role: domain.ContributorIllustrator, Position: 2},
+			{AuthorID: testAuthor1.ID, Role: domain.ContributorAuthor, Position: 1},
+		}
+		tests := []struct {
+			name            string
+			book            *domain.Book
+			authors         []int64
+			contributors    []gqlgen.ContributorInput
+			genres          []int64
+			tags            []string
+			expContributors []domain.Contributor
+			err             error
+		}{
+			{"valid", testBook, []int64{testAuthor1.ID, testAuthor2.ID}, nil, []int64{testGenre.ID}, []string{"dragons"}, []domain.Contributor{
+				{AuthorID: testAuthor1.ID, Role: domain.ContributorAuthor, Position: 1},
+				{AuthorID: testAuthor2.ID, Role: domain.ContributorAuthor, Position: 2},
+			}, nil},
+			{"contributors", testBook, nil, contributors, nil, nil, []domain.Contributor{
+				{AuthorID: testAuthor2.ID, Role: domain.ContributorIllustrator, Position: 2},
+				{AuthorID: testAuthor1.ID, Role: domain.ContributorAuthor, Position: 1},
+			}, nil},
+			{"error", testBook, []int64{testAuthor1.ID}, nil, nil, nil, []domain.Contributor{
+				{AuthorID: testAuthor1.ID, Role: domain.ContributorAuthor, Position: 1},
+			}, testError},
+		}
+
+		t.Run("CreateBook", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedCreateBookParams domain.CreateBookParams
+					var receivedContributors []domain.Contributor
+					var receivedGenreIDs []int64
+					var receivedTags []string
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateBookFunc: func(ctx context.Context, args domain.CreateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+								receivedCreateBookParams = args
+								receivedContributors = contributors
+								receivedGenreIDs = genreIDs
+								receivedTags = tags
+								return nil, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().CreateBook(context.Background(), gqlgen.CreateUpdateBookInput{
+						Title:          tc.book.Title,
+						Description:    tc.book.Description,
+						Cover:          tc.book.Cover,
+						PublisherID:    tc.book.PublisherID,
+						SeriesID:       tc.book.SeriesID,
+						SeriesPosition: tc.book.SeriesPosition,
+						Isbn:           tc.book.ISBN,
+						PublishedOn:    tc.book.PublishedOn,
+						PageCount:      tc.book.PageCount,
+						Language:       tc.book.Language,
+						AuthorIDs:      tc.authors,
+						Contributors:   tc.contributors,
+						GenreIDs:       tc.genres,
+						Tags:           tc.tags,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.CreateBookParams{
+						Title:          tc.book.Title,
+						Description:    tc.book.Description,
+						Cover:          tc.book.Cover,
+						PublisherID:    tc.book.PublisherID,
+						SeriesID:       tc.book.SeriesID,
+						SeriesPosition: tc.book.SeriesPosition,
+						ISBN:           tc.book.ISBN,
+						PublishedOn:    tc.book.PublishedOn,
+						PageCount:      tc.book.PageCount,
+						Language:       tc.book.Language,
+					}
+					if !reflect.DeepEqual(receivedCreateBookParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateBookParams)
+					}
+					if !reflect.DeepEqual(receivedContributors, tc.expContributors) {
+						t.Errorf("wrong contributors: expected %v, received %v", tc.expContributors, receivedContributors)
+					}
+					if !reflect.DeepEqual(receivedGenreIDs, tc.genres) {
+						t.Errorf("wrong genre ids: expected %v, received %v", tc.genres, receivedGenreIDs)
+					}
+					if !reflect.DeepEqual(receivedTags, tc.tags) {
+						t.Errorf("wrong tags: expected %v, received %v", tc.tags, receivedTags)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateBook", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedUpdateBookParams domain.UpdateBookParams
+					var receivedContributors []domain.Contributor
+					var receivedGenreIDs []int64
+					var receivedTags []string
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdateBookFunc: func(ctx context.Context, args domain.UpdateBookParams, contributors []domain.Contributor, genreIDs []int64, tags []string) (*domain.Book, error) {
+								receivedUpdateBookParams = args
+								receivedContributors = contributors
+								receivedGenreIDs = genreIDs
+								receivedTags = tags
+								return nil, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().UpdateBook(context.Background(), tc.book.ID, gqlgen.CreateUpdateBookInput{
+						Title:          tc.book.Title,
+						Description:    tc.book.Description,
+						Cover:          tc.book.Cover,
+						PublisherID:    tc.book.PublisherID,
+						SeriesID:       tc.book.SeriesID,
+						SeriesPosition: tc.book.SeriesPosition,
+						Isbn:           tc.book.ISBN,
+						PublishedOn:    tc.book.PublishedOn,
+						PageCount:      tc.book.PageCount,
+						Language:       tc.book.Language,
+						AuthorIDs:      tc.authors,
+						Contributors:   tc.contributors,
+						GenreIDs:       tc.genres,
+						Tags:           tc.tags,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.UpdateBookParams{
+						ID:             tc.book.ID,
+						Title:          tc.book.Title,
+						Description:    tc.book.Description,
+						Cover:          tc.book.Cover,
+						PublisherID:    tc.book.PublisherID,
+						SeriesID:       tc.book.SeriesID,
+						SeriesPosition: tc.book.SeriesPosition,
+						ISBN:           tc.book.ISBN,
+						PublishedOn:    tc.book.PublishedOn,
+						PageCount:      tc.book.PageCount,
+						Language:       tc.book.Language,
+					}
+					if !reflect.DeepEqual(receivedUpdateBookParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateBookParams)
+					}
+					if !reflect.DeepEqual(receivedContributors, tc.expContributors) {
+						t.Errorf("wrong contributors: expected %v, received %v", tc.expContributors, receivedContributors)
+					}
+					if !reflect.DeepEqual(receivedGenreIDs, tc.genres) {
+						t.Errorf("wrong genre ids: expected %v, received %v", tc.genres, receivedGenreIDs)
+					}
+					if !reflect.DeepEqual(receivedTags, tc.tags) {
+						t.Errorf("wrong tags: expected %v, received %v", tc.tags, receivedTags)
+					}
+				})
+			}
+		})
+
+		t.Run("authorIDs and contributors", func(t *testing.T) {
+			t.Parallel()
+			mock := &mocks.RepositoryMock{}
+			r := &resolvers.Resolver{Repo: mock}
+			_, err := r.Mutation().CreateBook(context.Background(), gqlgen.CreateUpdateBookInput{
+				Title:        testBook.Title,
+				AuthorIDs:    []int64{testAuthor1.ID},
+				Contributors: contributors,
+			})
+			if err == nil {
+				t.Error("expected a validation error, received nil")
+			}
+			if calls := mock.CreateBookCalls(); len(calls) != 0 {
+				t.Errorf("expected no calls, received %d", len(calls))
+			}
+		})
+
+		t.Run("DeleteBook", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedBookID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeleteBookFunc: func(ctx context.Context, id int64) (*domain.Book, error) {
+								receivedBookID = id
+								return nil, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeleteBook(context.Background(), tc.book.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedBookID != tc.book.ID {
+						t.Errorf("wrong id: expected %d, received %d", tc.book.ID, receivedBookID)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Deal mutations", func(t *testing.T) {
+		t.Parallel()
+		status := testDeal.Status
+		input := gqlgen.CreateUpdateDealInput{
+			BookID:         testDeal.BookID,
+			PublisherID:    testDeal.PublisherID,
+			AgentID:        testDeal.AgentID,
+			Territory:      testDeal.Territory,
+			Language:       testDeal.Language,
+			Format:         testDeal.Format,
+			Advance:        testDeal.Advance,
+			SignedOn:       testDeal.SignedOn,
+			Status:         &status,
+			RoyaltyRate:    testDeal.RoyaltyRate,
+			CommissionRate: testDeal.CommissionRate,
+		}
+		tests := []struct {
+			name  string
+			input gqlgen.CreateUpdateDealInput
+			err   error
+			calls int
+		}{
+			{"valid", input, nil, 1},
+			{"error", input, testError, 1},
+			{"world rights", gqlgen.CreateUpdateDealInput{
+				BookID:      1,
+				PublisherID: 1,
+				AgentID:     1,
+				Territory:   "WORLD",
+				Language:    "en",
+				Format:      domain.EditionEbook,
+			}, nil, 1},
+			{"invalid territory", gqlgen.CreateUpdateDealInput{
+				BookID:      1,
+				PublisherID: 1,
+				AgentID:     1,
+				Territory:   "Germany",
+				Language:    "de",
+				Format:      domain.EditionEbook,
+			}, nil, 0},
+		}
+		expStatus := func(input gqlgen.CreateUpdateDealInput) domain.DealStatus {
+			if input.Status == nil {
+				return domain.DealNegotiating
+			}
+			return *input.Status
+		}
+
+		t.Run("CreateDeal", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						CreateDealFunc: func(ctx context.Context, args domain.CreateDealParams) (domain.Deal, error) {
+							return domain.Deal{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().CreateDeal(context.Background(), tc.input)
+					if tc.calls == 0 {
+						if err == nil {
+							t.Error("expected a validation error, received nil")
+						}
+					} else if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.CreateDealCalls()
+					if len(calls) != tc.calls {
+						t.Fatalf("expected %d calls, received %d", tc.calls, len(calls))
+					}
+					if tc.calls == 0 {
+						return
+					}
+					exp := domain.CreateDealParams{
+						BookID:         tc.input.BookID,
+						PublisherID:    tc.input.PublisherID,
+						AgentID:        tc.input.AgentID,
+						Territory:      tc.input.Territory,
+						Language:       tc.input.Language,
+						Format:         tc.input.Format,
+						Advance:        tc.input.Advance,
+						SignedOn:       tc.input.SignedOn,
+						Status:         expStatus(tc.input),
+						RoyaltyRate:    tc.input.RoyaltyRate,
+						CommissionRate: tc.input.CommissionRate,
+					}
+					if !reflect.DeepEqual(calls[0].Args, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, calls[0].Args)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateDeal", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						UpdateDealFunc: func(ctx context.Context, args domain.UpdateDealParams) (domain.Deal, error) {
+							return domain.Deal{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().UpdateDeal(context.Background(), testDeal.ID, tc.input)
+					if tc.calls == 0 {
+						if err == nil {
+							t.Error("expected a validation error, received nil")
+						}
+					} else if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.UpdateDealCalls()
+					if len(calls) != tc.calls {
+						t.Fatalf("expected %d calls, received %d", tc.calls, len(calls))
+					}
+					if tc.calls == 0 {
+						return
+					}
+					exp := domain.UpdateDealParams{
+						ID:             testDeal.ID,
+						BookID:         tc.input.BookID,
+						PublisherID:    tc.input.PublisherID,
+						AgentID:        tc.input.AgentID,
+						Territory:      tc.input.Territory,
+						Language:       tc.input.Language,
+						Format:         tc.input.Format,
+						Advance:        tc.input.Advance,
+						SignedOn:       tc.input.SignedOn,
+						Status:         expStatus(tc.input),
+						RoyaltyRate:    tc.input.RoyaltyRate,
+						CommissionRate: tc.input.CommissionRate,
+					}
+					if !reflect.DeepEqual(calls[0].Args, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, calls[0].Args)
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteDeal", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range []struct {
+				name string
+				err  error
+			}{
+				{"valid", nil},
+				{"error", testError},
+			} {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedDealID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeleteDealFunc: func(ctx context.Context, id int64) (domain.Deal, error) {
+								receivedDealID = id
+								return domain.Deal{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeleteDeal(context.Background(), testDeal.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedDealID != testDeal.ID {
+						t.Errorf("wrong id: expected %d, received %d", testDeal.ID, receivedDealID)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Royalty mutations", func(t *testing.T) {
+		t.Parallel()
+
+		t.Run("SetRoyaltyRate", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range []struct {
+				name string
+				err  error
+			}{
+				{"valid", nil},
+				{"error", testError},
+			} {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						SetRoyaltyRateFunc: func(ctx context.Context, dealID int64, editionID int64, rate int) (domain.RoyaltyRate, error) {
+							return domain.RoyaltyRate{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().SetRoyaltyRate(context.Background(), testRoyaltyRate.DealID, testRoyaltyRate.EditionID, testRoyaltyRate.Rate)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.SetRoyaltyRateCalls()
+					if len(calls) != 1 {
+						t.Fatalf("expected 1 call, received %d", len(calls))
+					}
+					if calls[0].DealID != testRoyaltyRate.DealID || calls[0].EditionID != testRoyaltyRate.EditionID || calls[0].Rate != testRoyaltyRate.Rate {
+						t.Errorf("wrong args: expected %v, received %v", *testRoyaltyRate, calls[0])
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteRoyaltyRate", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range []struct {
+				name string
+				err  error
+			}{
+				{"valid", nil},
+				{"error", testError},
+			} {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						DeleteRoyaltyRateFunc: func(ctx context.Context, dealID int64, editionID int64) (domain.RoyaltyRate, error) {
+							return domain.RoyaltyRate{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().DeleteRoyaltyRate(context.Background(), testRoyaltyRate.DealID, testRoyaltyRate.EditionID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.DeleteRoyaltyRateCalls()
+					if len(calls) != 1 {
+						t.Fatalf("expected 1 call, received %d", len(calls))
+					}
+					if calls[0].DealID != testRoyaltyRate.DealID || calls[0].EditionID != testRoyaltyRate.EditionID {
+						t.Errorf("wrong args: expected %v, received %v", *testRoyaltyRate, calls[0])
+					}
+				})
+			}
+		})
+
+		t.Run("RecordSales", func(t *testing.T) {
+			t.Parallel()
+			input := gqlgen.RecordSalesInput{
+				DealID:    testSales.DealID,
+				EditionID: testSales.EditionID,
+				Period:    testSales.Period,
+				Units:     testSales.Units,
+				Revenue:   testSales.Revenue,
+			}
+			invalid := input
+			invalid.Period = "2024-Q5"
+			for _, tc := range []struct {
+				name  string
+				input gqlgen.RecordSalesInput
+				err   error
+				calls int
+			}{
+				{"valid", input, nil, 1},
+				{"error", input, testError, 1},
+				{"invalid period", invalid, nil, 0},
+			} {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						RecordSalesFunc: func(ctx context.Context, args domain.RecordSalesParams) (domain.Sales, error) {
+							return domain.Sales{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().RecordSales(context.Background(), tc.input)
+					if tc.calls == 0 {
+						if err == nil {
+							t.Error("expected a validation error, received nil")
+						}
+					} else if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.RecordSalesCalls()
+					if len(calls) != tc.calls {
+						t.Fatalf("expected %d calls, received %d", tc.calls, len(calls))
+					}
+					if tc.calls == 0 {
+						return
+					}
+					exp := domain.RecordSalesParams{
+						DealID:    tc.input.DealID,
+						EditionID: tc.input.EditionID,
+						Period:    tc.input.Period,
+						Units:     tc.input.Units,
+						Revenue:   tc.input.Revenue,
+					}
+					if calls[0].Args != exp {
+						t.Errorf("wrong params: expected %v, received %v", exp, calls[0].Args)
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteSales", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range []struct {
+				name string
+				err  error
+			}{
+				{"valid", nil},
+				{"error", testError},
+			} {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedSalesID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeleteSalesFunc: func(ctx context.Context, id int64) (domain.Sales, error) {
+								receivedSalesID = id
+								return domain.Sales{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeleteSales(context.Background(), testSales.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedSalesID != testSales.ID {
+						t.Errorf("wrong id: expected %d, received %d", testSales.ID, receivedSalesID)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Edition mutations", func(t *testing.T) {
+		t.Parallel()
+		input := gqlgen.CreateUpdateEditionInput{
+			BookID:      testEdition.BookID,
+			Format:      testEdition.Format,
+			Isbn:        testEdition.ISBN,
+			PublishedOn: testEdition.PublishedOn,
+			Price:       &gqlgen.PriceInput{Amount: testEdition.Price.Amount, Currency: testEdition.Price.Currency},
+		}
+		tests := []struct {
+			name  string
+			input gqlgen.CreateUpdateEditionInput
+			err   error
+			calls int
+		}{
+			{"valid", input, nil, 1},
+			{"error", input, testError, 1},
+			{"no price", gqlgen.CreateUpdateEditionInput{BookID: 1, Format: domain.EditionEbook}, nil, 1},
+			{"negative price", gqlgen.CreateUpdateEditionInput{
+				BookID: 1,
+				Format: domain.EditionEbook,
+				Price:  &gqlgen.PriceInput{Amount: -1, Currency: "USD"},
+			}, nil, 0},
+			{"invalid currency", gqlgen.CreateUpdateEditionInput{
+				BookID: 1,
+				Format: domain.EditionEbook,
+				Price:  &gqlgen.PriceInput{Amount: 1, Currency: "usd"},
+			}, nil, 0},
+		}
+		expPrice := func(input gqlgen.CreateUpdateEditionInput) *domain.Price {
+			if input.Price == nil {
+				return nil
+			}
+			return &domain.Price{Amount: input.Price.Amount, Currency: input.Price.Currency}
+		}
+
+		t.Run("CreateEdition", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						CreateEditionFunc: func(ctx context.Context, args domain.CreateEditionParams) (domain.Edition, error) {
+							return domain.Edition{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().CreateEdition(context.Background(), tc.input)
+					if tc.calls == 0 {
+						if err == nil {
+							t.Error("expected a validation error, received nil")
+						}
+					} else if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.CreateEditionCalls()
+					if len(calls) != tc.calls {
+						t.Fatalf("expected %d calls, received %d", tc.calls, len(calls))
+					}
+					if tc.calls == 0 {
+						return
+					}
+					exp := domain.CreateEditionParams{
+						BookID:      tc.input.BookID,
+						Format:      tc.input.Format,
+						ISBN:        tc.input.Isbn,
+						PublishedOn: tc.input.PublishedOn,
+						Price:       expPrice(tc.input),
+					}
+					if !reflect.DeepEqual(calls[0].Args, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, calls[0].Args)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateEdition", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						UpdateEditionFunc: func(ctx context.Context, args domain.UpdateEditionParams) (domain.Edition, error) {
+							return domain.Edition{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().UpdateEdition(context.Background(), testEdition.ID, tc.input)
+					if tc.calls == 0 {
+						if err == nil {
+							t.Error("expected a validation error, received nil")
+						}
+					} else if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.UpdateEditionCalls()
+					if len(calls) != tc.calls {
+						t.Fatalf("expected %d calls, received %d", tc.calls, len(calls))
+					}
+					if tc.calls == 0 {
+						return
+					}
+					exp := domain.UpdateEditionParams{
+						ID:          testEdition.ID,
+						BookID:      tc.input.BookID,
+						Format:      tc.input.Format,
+						ISBN:        tc.input.Isbn,
+						PublishedOn: tc.input.PublishedOn,
+						Price:       expPrice(tc.input),
+					}
+					if !reflect.DeepEqual(calls[0].Args, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, calls[0].Args)
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteEdition", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range []struct {
+				name string
+				err  error
+			}{
+				{"valid", nil},
+				{"error", testError},
+			} {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedEditionID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeleteEditionFunc: func(ctx context.Context, id int64) (domain.Edition, error) {
+								receivedEditionID = id
+								return domain.Edition{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeleteEdition(context.Background(), testEdition.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedEditionID != testEdition.ID {
+						t.Errorf("wrong id: expected %d, received %d", testEdition.ID, receivedEditionID)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Genre mutations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			genre *domain.Genre
+			err   error
+		}{
+			{"valid", testGenre, nil},
+			{"error", testGenre, testError},
+		}
+
+		t.Run("CreateGenre", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedCreateGenreParams domain.CreateGenreParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateGenreFunc: func(ctx context.Context, args domain.CreateGenreParams) (domain.Genre, error) {
+								receivedCreateGenreParams = args
+								return domain.Genre{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().CreateGenre(context.Background(), gqlgen.CreateUpdateGenreInput{
+						Name:     tc.genre.Name,
+						ParentID: tc.genre.ParentID,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.CreateGenreParams{Name: tc.genre.Name, ParentID: tc.genre.ParentID}
+					if !reflect.DeepEqual(receivedCreateGenreParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateGenreParams)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateGenre", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedUpdateGenreParams domain.UpdateGenreParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdateGenreFunc: func(ctx context.Context, args domain.UpdateGenreParams) (*domain.Genre, error) {
+								receivedUpdateGenreParams = args
+								return nil, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().UpdateGenre(context.Background(), tc.genre.ID, gqlgen.CreateUpdateGenreInput{
+						Name:     tc.genre.Name,
+						ParentID: tc.genre.ParentID,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.UpdateGenreParams{ID: tc.genre.ID, Name: tc.genre.Name, ParentID: tc.genre.ParentID}
+					if !reflect.DeepEqual(receivedUpdateGenreParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateGenreParams)
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteGenre", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedGenreID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeleteGenreFunc: func(ctx context.Context, id int64) (domain.Genre, error) {
+								receivedGenreID = id
+								return domain.Genre{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeleteGenre(context.Background(), tc.genre.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedGenreID != tc.genre.ID {
+						t.Errorf("wrong id: expected %d, received %d", tc.genre.ID, receivedGenreID)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Publisher mutations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name      string
+			publisher *domain.Publisher
+			err       error
+		}{
+			{"valid", testPublisher, nil},
+			{"error", testPublisher, testError},
+		}
+
+		t.Run("CreatePublisher", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedCreatePublisherParams domain.CreatePublisherParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreatePublisherFunc: func(ctx context.Context, args domain.CreatePublisherParams) (domain.Publisher, error) {
+								receivedCreatePublisherParams = args
+								return domain.Publisher{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().CreatePublisher(context.Background(), gqlgen.CreateUpdatePublisherInput{
+						Name: tc.publisher.Name,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.CreatePublisherParams{Name: tc.publisher.Name}
+					if receivedCreatePublisherParams != exp {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreatePublisherParams)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdatePublisher", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedUpdatePublisherParams domain.UpdatePublisherParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdatePublisherFunc: func(ctx context.Context, args domain.UpdatePublisherParams) (domain.Publisher, error) {
+								receivedUpdatePublisherParams = args
+								return domain.Publisher{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().UpdatePublisher(context.Background(), tc.publisher.ID, gqlgen.CreateUpdatePublisherInput{
+						Name: tc.publisher.Name,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.UpdatePublisherParams{ID: tc.publisher.ID, Name: tc.publisher.Name}
+					if receivedUpdatePublisherParams != exp {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdatePublisherParams)
+					}
+				})
+			}
+		})
+
+		t.Run("DeletePublisher", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedPublisherID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeletePublisherFunc: func(ctx context.Context, id int64) (domain.Publisher, error) {
+								receivedPublisherID = id
+								return domain.Publisher{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeletePublisher(context.Background(), tc.publisher.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedPublisherID != tc.publisher.ID {
+						t.Errorf("wrong id: expected %d, received %d", tc.publisher.ID, receivedPublisherID)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Representation mutations", func(t *testing.T) {
+		t.Parallel()
+		input := gqlgen.CreateRepresentationInput{
+			AuthorID:  testRepresentation.AuthorID,
+			AgentID:   testRepresentation.AgentID,
+			Territory: testRepresentation.Territory,
+			StartedAt: testRepresentation.StartedAt,
+		}
+		world := input
+		world.Territory = "WORLD"
+		invalid := input
+		invalid.Territory = "France"
+
+		t.Run("CreateRepresentation", func(t *testing.T) {
+			t.Parallel()
+			tests := []struct {
+				name  string
+				input gqlgen.CreateRepresentationInput
+				err   error
+				calls int
+			}{
+				{"valid", input, nil, 1},
+				{"worldwide", world, nil, 1},
+				{"error", input, testError, 1},
+				{"invalid territory", invalid, nil, 0},
+			}
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						CreateRepresentationFunc: func(ctx context.Context, args domain.CreateRepresentationParams) (domain.Representation, error) {
+							return domain.Representation{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().CreateRepresentation(context.Background(), tc.input)
+					if tc.calls == 0 {
+						if err == nil {
+							t.Error("expected a validation error, received nil")
+						}
+					} else if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.CreateRepresentationCalls()
+					if len(calls) != tc.calls {
+						t.Fatalf("expected %d calls, received %d", tc.calls, len(calls))
+					}
+					if tc.calls == 0 {
+						return
+					}
+					exp := domain.CreateRepresentationParams{
+						AuthorID:  tc.input.AuthorID,
+						AgentID:   tc.input.AgentID,
+						Territory: tc.input.Territory,
+						StartedAt: tc.input.StartedAt,
+					}
+					if !reflect.DeepEqual(calls[0].Args, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, calls[0].Args)
+					}
+				})
+			}
+		})
+
+		t.Run("EndRepresentation", func(t *testing.T) {
+			t.Parallel()
+			tests := []struct {
+				name string
+				err  error
+			}{
+				{"valid", nil},
+				{"error", testError},
+			}
+			endedAt := domain.Date{Year: 2021, Month: time.June, Day: 30}
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						EndRepresentationFunc: func(ctx context.Context, id int64, endedAt domain.Date) (domain.Representation, error) {
+							return domain.Representation{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().EndRepresentation(context.Background(), testRepresentation.ID, endedAt)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.EndRepresentationCalls()
+					if len(calls) != 1 {
+						t.Fatalf("expected 1 call, received %d", len(calls))
+					}
+					if calls[0].ID != testRepresentation.ID || calls[0].EndedAt != endedAt {
+						t.Errorf("wrong args: expected %d, %v, received %d, %v", testRepresentation.ID, endedAt, calls[0].ID, calls[0].EndedAt)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Series mutations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			series *domain.Series
+			err    error
+		}{
+			{"valid", testSeries, nil},
+			{"error", testSeries, testError},
+		}
+
+		t.Run("CreateSeries", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedCreateSeriesParams domain.CreateSeriesParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateSeriesFunc: func(ctx context.Context, args domain.CreateSeriesParams) (domain.Series, error) {
+								receivedCreateSeriesParams = args
+								return domain.Series{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().CreateSeries(context.Background(), gqlgen.CreateUpdateSeriesInput{
+						Title: tc.series.Title,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.CreateSeriesParams{Title: tc.series.Title}
+					if receivedCreateSeriesParams != exp {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateSeriesParams)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateSeries", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedUpdateSeriesParams domain.UpdateSeriesParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdateSeriesFunc: func(ctx context.Context, args domain.UpdateSeriesParams) (domain.Series, error) {
+								receivedUpdateSeriesParams = args
+								return domain.Series{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().UpdateSeries(context.Background(), tc.series.ID, gqlgen.CreateUpdateSeriesInput{
+						Title: tc.series.Title,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.UpdateSeriesParams{ID: tc.series.ID, Title: tc.series.Title}
+					if receivedUpdateSeriesParams != exp {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateSeriesParams)
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteSeries", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedSeriesID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeleteSeriesFunc: func(ctx context.Context, id int64) (*domain.Series, error) {
+								receivedSeriesID = id
+								return nil, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeleteSeries(context.Background(), tc.series.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedSeriesID != tc.series.ID {
+						t.Errorf("wrong id: expected %d, received %d", tc.series.ID, receivedSeriesID)
+					}
+				})
+			}
+		})
+
+		t.Run("ReorderSeries", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedSeriesID int64
+					var receivedBookIDs []int64
+					bookIDs := []int64{89, testBook.ID}
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							ReorderSeriesFunc: func(ctx context.Context, id int64, bookIDs []int64) (*domain.Series, error) {
+								receivedSeriesID = id
+								receivedBookIDs = bookIDs
+								return nil, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().ReorderSeries(context.Background(), tc.series.ID, bookIDs)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedSeriesID != tc.series.ID {
+						t.Errorf("wrong id: expected %d, received %d", tc.series.ID, receivedSeriesID)
+					}
+					if !reflect.DeepEqual(receivedBookIDs, bookIDs) {
+						t.Errorf("wrong book ids: expected %v, received %v", bookIDs, receivedBookIDs)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Submission mutations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name       string
+			submission *domain.Submission
+			err        error
+		}{
+			{"valid", testSubmission, nil},
+			{"error", testSubmission, testError},
+		}
+
+		t.Run("CreateSubmission", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						CreateSubmissionFunc: func(ctx context.Context, args domain.CreateSubmissionParams) (domain.Submission, error) {
+							return domain.Submission{}, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().CreateSubmission(context.Background(), gqlgen.CreateSubmissionInput{
+						AgentID:       tc.submission.AgentID,
+						AuthorName:    tc.submission.AuthorName,
+						AuthorWebsite: tc.submission.AuthorWebsite,
+						Title:         tc.submission.Title,
+						Synopsis:      tc.submission.Synopsis,
+					})
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.CreateSubmissionCalls()
+					if len(calls) != 1 {
+						t.Fatalf("expected 1 call, received %d", len(calls))
+					}
+					exp := domain.CreateSubmissionParams{
+						AgentID:       tc.submission.AgentID,
+						AuthorName:    tc.submission.AuthorName,
+						AuthorWebsite: tc.submission.AuthorWebsite,
+						Title:         tc.submission.Title,
+						Synopsis:      tc.submission.Synopsis,
+					}
+					if !reflect.DeepEqual(calls[0].Args, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, calls[0].Args)
+					}
+				})
+			}
+		})
+
+		t.Run("TransitionSubmission", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						TransitionSubmissionFunc: func(ctx context.Context, id int64, status domain.SubmissionStatus, note *string) (*domain.Submission, error) {
+							return nil, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					note := stringPtr("test note")
+					_, err := r.Mutation().TransitionSubmission(context.Background(), tc.submission.ID, domain.SubmissionDeclined, note)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.TransitionSubmissionCalls()
+					if len(calls) != 1 {
+						t.Fatalf("expected 1 call, received %d", len(calls))
+					}
+					if calls[0].ID != tc.submission.ID || calls[0].Status != domain.SubmissionDeclined || calls[0].Note != note {
+						t.Errorf("wrong args: expected %d, %s, %v, received %d, %s, %v", tc.submission.ID, domain.SubmissionDeclined, note, calls[0].ID, calls[0].Status, calls[0].Note)
+					}
+				})
+			}
+		})
+
+		t.Run("ConvertSubmission", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					mock := &mocks.RepositoryMock{
+						ConvertSubmissionFunc: func(ctx context.Context, id int64, cover string) (*domain.Book, error) {
+							return nil, tc.err
+						},
+					}
+					r := &resolvers.Resolver{Repo: mock}
+					_, err := r.Mutation().ConvertSubmission(context.Background(), tc.submission.ID, "cover.jpg")
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					calls := mock.ConvertSubmissionCalls()
+					if len(calls) != 1 {
+						t.Fatalf("expected 1 call, received %d", len(calls))
+					}
+					if calls[0].ID != tc.submission.ID || calls[0].Cover != "cover.jpg" {
+						t.Errorf("wrong args: expected %d, %q, received %d, %q", tc.submission.ID, "cover.jpg", calls[0].ID, calls[0].Cover)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Bulk mutations", func(t *testing.T) {
+		t.Parallel()
+		bestEffort := domain.BulkBestEffort
+		itemErr := errors.New("item error")
+		tests := []struct {
+			name    string
+			mode    *domain.BulkMode
+			expMode domain.BulkMode
+			err     error
+		}{
+			{"default mode", nil, domain.BulkAllOrNothing, nil},
+			{"best effort mode", &bestEffort, domain.BulkBestEffort, nil},
+			{"error", nil, domain.BulkAllOrNothing, testError},
+		}
+		errorMessage := itemErr.Error()
+
+		t.Run("CreateAgents", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedArgs []domain.CreateAgentParams
+					var receivedMode domain.BulkMode
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateAgentsFunc: func(ctx context.Context, args []domain.CreateAgentParams, mode domain.BulkMode) (*domain.BulkAgentsResult, error) {
+								receivedArgs = args
+								receivedMode = mode
+								if tc.err != nil {
+									return nil, tc.err
+								}
+								return &domain.BulkAgentsResult{
+									Committed: true,
+									Agents:    []*domain.Agent{testAgent, nil},
+									Errors:    []error{nil, itemErr},
+								}, nil
+							},
+						},
+					}
+					res, err := r.Mutation().CreateAgents(context.Background(), []gqlgen.CreateUpdateAgentInput{
+						{Name: "agent 1", Email: "agent1@example.com"},
+						{Name: "agent 2", Email: "agent2@example.com"},
+					}, tc.mode)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					expArgs := []domain.CreateAgentParams{
+						{Name: "agent 1", Email: "agent1@example.com"},
+						{Name: "agent 2", Email: "agent2@example.com"},
+					}
+					if !reflect.DeepEqual(receivedArgs, expArgs) {
+						t.Errorf("wrong args: expected %v, received %v", expArgs, receivedArgs)
+					}
+					if receivedMode != tc.expMode {
+						t.Errorf("wrong mode: expected %s, received %s", tc.expMode, receivedMode)
+					}
+					if tc.err != nil {
+						return
+					}
+					exp := &gqlgen.BulkAgentsPayload{
+						Committed: true,
+						Results: []gqlgen.BulkAgentResult{
+							{Agent: testAgent},
+							{Error: &errorMessage},
+						},
+					}
+					if !reflect.DeepEqual(res, exp) {
+						t.Errorf("wrong payload: expected %v, received %v", exp, res)
+					}
+				})
+			}
+		})
+
+		t.Run("CreateAuthors", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedArgs []domain.CreateAuthorParams
+					var receivedMode domain.BulkMode
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateAuthorsFunc: func(ctx context.Context, args []domain.CreateAuthorParams, mode domain.BulkMode) (*domain.BulkAuthorsResult, error) {
+								receivedArgs = args
+								receivedMode = mode
+								if tc.err != nil {
+									return nil, tc.err
+								}
+								return &domain.BulkAuthorsResult{
+									Authors: []*domain.Author{nil, nil},
+									Errors:  []error{itemErr, nil},
+								}, nil
+							},
+						},
+					}
+					res, err := r.Mutation().CreateAuthors(context.Background(), []gqlgen.CreateUpdateAuthorInput{
+						{Name: testAuthor1.Name, Website: testAuthor1.Website, AgentID: testAuthor1.AgentID},
+						{Name: testAuthor2.Name, Website: testAuthor2.Website, AgentID: testAuthor2.AgentID},
+					}, tc.mode)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					expArgs := []domain.CreateAuthorParams{
+						{Name: testAuthor1.Name, Website: testAuthor1.Website, AgentID: testAuthor1.AgentID},
+						{Name: testAuthor2.Name, Website: testAuthor2.Website, AgentID: testAuthor2.AgentID},
+					}
+					if !reflect.DeepEqual(receivedArgs, expArgs) {
+						t.Errorf("wrong args: expected %v, received %v", expArgs, receivedArgs)
+					}
+					if receivedMode != tc.expMode {
+						t.Errorf("wrong mode: expected %s, received %s", tc.expMode, receivedMode)
+					}
+					if tc.err != nil {
+						return
+					}
+					exp := &gqlgen.BulkAuthorsPayload{
+						Results: []gqlgen.BulkAuthorResult{
+							{Error: &errorMessage},
+							{},
+						},
+					}
+					if !reflect.DeepEqual(res, exp) {
+						t.Errorf("wrong payload: expected %v, received %v", exp, res)
+					}
+				})
+			}
+		})
+
+		t.Run("CreateBooks", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedArgs []domain.BulkCreateBookArgs
+					var receivedMode domain.BulkMode
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateBooksFunc: func(ctx context.Context, args []domain.BulkCreateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+								receivedArgs = args
+								receivedMode = mode
+								if tc.err != nil {
+									return nil, tc.err
+								}
+								return &domain.BulkBooksResult{
+									Committed: true,
+									Books:     []*domain.Book{testBook},
+									Errors:    []error{nil},
+								}, nil
+							},
+						},
+					}
+					res, err := r.Mutation().CreateBooks(context.Background(), []gqlgen.CreateUpdateBookInput{{
+						Title:       testBook.Title,
+						Description: testBook.Description,
+						Cover:       testBook.Cover,
+						AuthorIDs:   []int64{testAuthor1.ID, testAuthor2.ID},
+						GenreIDs:    []int64{testGenre.ID},
+						Tags:        []string{"dragons"},
+					}}, tc.mode)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					expArgs := []domain.BulkCreateBookArgs{{
+						Book: domain.CreateBookParams{
+							Title:       testBook.Title,
+							Description: testBook.Description,
+							Cover:       testBook.Cover,
+						},
+						Contributors: []domain.Contributor{
+							{AuthorID: testAuthor1.ID, Role: domain.ContributorAuthor, Position: 1},
+							{AuthorID: testAuthor2.ID, Role: domain.ContributorAuthor, Position: 2},
+						},
+						GenreIDs: []int64{testGenre.ID},
+						Tags:     []string{"dragons"},
+					}}
+					if !reflect.DeepEqual(receivedArgs, expArgs) {
+						t.Errorf("wrong args: expected %v, received %v", expArgs, receivedArgs)
+					}
+					if receivedMode != tc.expMode {
+						t.Errorf("wrong mode: expected %s, received %s", tc.expMode, receivedMode)
+					}
+					if tc.err != nil {
+						return
+					}
+					exp := &gqlgen.BulkBooksPayload{
+						Committed: true,
+						Results:   []gqlgen.BulkBookResult{{Book: testBook}},
+					}
+					if !reflect.DeepEqual(res, exp) {
+						t.Errorf("wrong payload: expected %v, received %v", exp, res)
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateBooks", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedArgs []domain.BulkUpdateBookArgs
+					var receivedMode domain.BulkMode
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdateBooksFunc: func(ctx context.Context, args []domain.BulkUpdateBookArgs, mode domain.BulkMode) (*domain.BulkBooksResult, error) {
+								receivedArgs = args
+								receivedMode = mode
+								if tc.err != nil {
+									return nil, tc.err
+								}
+								return &domain.BulkBooksResult{
+									Books:  []*domain.Book{nil},
+									Errors: []error{itemErr},
+								}, nil
+							},
+						},
+					}
+					res, err := r.Mutation().UpdateBooks(context.Background(), []gqlgen.BulkUpdateBookInput{{
+						ID: testBook.ID,
+						Data: &gqlgen.CreateUpdateBookInput{
+							Title:       testBook.Title,
+							Description: testBook.Description,
+							Cover:       testBook.Cover,
+							AuthorIDs:   []int64{testAuthor1.ID},
+							Tags:        []string{"dragons"},
+						},
+					}}, tc.mode)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					expArgs := []domain.BulkUpdateBookArgs{{
+						Book: domain.UpdateBookParams{
+							ID:          testBook.ID,
+							Title:       testBook.Title,
+							Description: testBook.Description,
+							Cover:       testBook.Cover,
+						},
+						Contributors: []domain.Contributor{
+							{AuthorID: testAuthor1.ID, Role: domain.ContributorAuthor, Position: 1},
+						},
+						Tags: []string{"dragons"},
+					}}
+					if !reflect.DeepEqual(receivedArgs, expArgs) {
+						t.Errorf("wrong args: expected %v, received %v", expArgs, receivedArgs)
+					}
+					if receivedMode != tc.expMode {
+						t.Errorf("wrong mode: expected %s, received %s", tc.expMode, receivedMode)
+					}
+					if tc.err != nil {
+						return
+					}
+					exp := &gqlgen.BulkBooksPayload{
+						Results: []gqlgen.BulkBookResult{{Error: &errorMessage}},
+					}
+					if !reflect.DeepEqual(res, exp) {
+						t.Errorf("wrong payload: expected %v, received %v", exp, res)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Webhook mutations", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name  string
+			input gqlgen.CreateUpdateWebhookInput
+			err   error
+		}{
+			{"valid", gqlgen.CreateUpdateWebhookInput{URL: testWebhook.URL, Secret: testWebhook.Secret, EventTypes: testWebhook.EventTypes}, nil},
+			{"error", gqlgen.CreateUpdateWebhookInput{URL: testWebhook.URL, Secret: testWebhook.Secret, EventTypes: testWebhook.EventTypes}, testError},
+		}
+		invalid := []struct {
+			name  string
+			input gqlgen.CreateUpdateWebhookInput
+		}{
+			{"invalid url", gqlgen.CreateUpdateWebhookInput{URL: "ftp://example.com", Secret: "s", EventTypes: []string{domain.EventBookCreated}}},
+			{"empty secret", gqlgen.CreateUpdateWebhookInput{URL: testWebhook.URL, EventTypes: []string{domain.EventBookCreated}}},
+			{"no event types", gqlgen.CreateUpdateWebhookInput{URL: testWebhook.URL, Secret: "s"}},
+			{"unknown event type", gqlgen.CreateUpdateWebhookInput{URL: testWebhook.URL, Secret: "s", EventTypes: []string{"agent.created"}}},
+		}
+
+		t.Run("CreateWebhook", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedCreateWebhookParams domain.CreateWebhookParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							CreateWebhookFunc: func(ctx context.Context, args domain.CreateWebhookParams) (domain.Webhook, error) {
+								receivedCreateWebhookParams = args
+								return domain.Webhook{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().CreateWebhook(context.Background(), tc.input)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.CreateWebhookParams{
+						URL:        tc.input.URL,
+						Secret:     tc.input.Secret,
+						EventTypes: tc.input.EventTypes,
+					}
+					if !reflect.DeepEqual(receivedCreateWebhookParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateWebhookParams)
+					}
+				})
+			}
+			for _, tc := range invalid {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{},
+					}
+					_, err := r.Mutation().CreateWebhook(context.Background(), tc.input)
+					if err == nil {
+						t.Errorf("expected an error, received nil")
+					}
+				})
+			}
+		})
+
+		t.Run("UpdateWebhook", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedUpdateWebhookParams domain.UpdateWebhookParams
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							UpdateWebhookFunc: func(ctx context.Context, args domain.UpdateWebhookParams) (domain.Webhook, error) {
+								receivedUpdateWebhookParams = args
+								return domain.Webhook{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().UpdateWebhook(context.Background(), testWebhook.ID, tc.input)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					exp := domain.UpdateWebhookParams{
+						ID:         testWebhook.ID,
+						URL:        tc.input.URL,
+						Secret:     tc.input.Secret,
+						EventTypes: tc.input.EventTypes,
+					}
+					if !reflect.DeepEqual(receivedUpdateWebhookParams, exp) {
+						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateWebhookParams)
+					}
+				})
+			}
+			for _, tc := range invalid {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{},
+					}
+					_, err := r.Mutation().UpdateWebhook(context.Background(), testWebhook.ID, tc.input)
+					if err == nil {
+						t.Errorf("expected an error, received nil")
+					}
+				})
+			}
+		})
+
+		t.Run("DeleteWebhook", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedWebhookID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							DeleteWebhookFunc: func(ctx context.Context, id int64) (domain.Webhook, error) {
+								receivedWebhookID = id
+								return domain.Webhook{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().DeleteWebhook(context.Background(), testWebhook.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedWebhookID != testWebhook.ID {
+						t.Errorf("wrong id: expected %d, received %d", testWebhook.ID, receivedWebhookID)
+					}
+				})
+			}
+		})
+
+		t.Run("RetryWebhookDelivery", func(t *testing.T) {
+			t.Parallel()
+			for _, tc := range tests {
+				tc := tc
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+					var receivedDeliveryID int64
+					r := &resolvers.Resolver{
+						Repo: &mocks.RepositoryMock{
+							RetryWebhookDeliveryFunc: func(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+								receivedDeliveryID = id
+								return domain.WebhookDelivery{}, tc.err
+							},
+						},
+					}
+					_, err := r.Mutation().RetryWebhookDelivery(context.Background(), testWebhookDelivery1.ID)
+					if !errors.Is(err, tc.err) {
+						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+					}
+					if receivedDeliveryID != testWebhookDelivery1.ID {
+						t.Errorf("wrong id: expected %d, received %d", testWebhookDelivery1.ID, receivedDeliveryID)
+					}
+				})
+			}
+		})
+	})
+}
+
+func TestQueryResolver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Agency", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			id   int64
+			err  error
+		}{
+			{"valid", testAgency.ID, nil},
+			{"error", testAgency.ID, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAgencyFunc: func(ctx context.Context, id int64) (domain.Agency, error) {
+							receivedID = id
+							return domain.Agency{}, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Agency(context.Background(), tc.id)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedID != tc.id {
+					t.Errorf("wrong id: expected %d, received %d", tc.id, receivedID)
+				}
+			})
+		}
+	})
+
+	t.Run("Agencies", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			err  error
+		}{
+			{"valid", nil},
+			{"error", testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListAgenciesFunc: func(ctx context.Context) ([]domain.Agency, error) {
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Agencies(context.Background())
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+			})
+		}
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			id   int64
+			err  error
+		}{
+			{"valid", testAgent.ID, nil},
+			{"error", testAgent.ID, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAgentFunc: func(ctx context.Context, id int64) (domain.Agent, error) {
+							receivedID = id
+							return domain.Agent{}, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Agent(context.Background(), tc.id)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedID != tc.id {
+					t.Errorf("wrong id: expected %d, received %d", tc.id, receivedID)
+				}
+			})
+		}
+	})
+
+	t.Run("Agents", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name     string
+			filter   *gqlgen.AgentFilter
+			byAgency bool
+			err      error
+		}{
+			{"valid", nil, false, nil},
+			{"error", nil, false, testError},
+			{"empty filter", &gqlgen.AgentFilter{}, false, nil},
+			{"agency", &gqlgen.AgentFilter{AgencyID: &testAgency.ID}, true, nil},
+			{"agency error", &gqlgen.AgentFilter{AgencyID: &testAgency.ID}, true, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgencyID int64
+				mock := &mocks.RepositoryMock{
+					ListAgentsFunc: func(ctx context.Context) ([]domain.Agent, error) {
+						return nil, tc.err
+					},
+					ListAgentsByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Agent, error) {
+						receivedAgencyID = agencyID
+						return nil, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				_, err := r.Query().Agents(context.Background(), tc.filter)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if calls := len(mock.ListAgentsByAgencyIDCalls()); (calls == 1) != tc.byAgency {
+					t.Fatalf("unexpected number of ListAgentsByAgencyID calls: %d", calls)
+				}
+				if tc.byAgency && receivedAgencyID != testAgency.ID {
+					t.Errorf("wrong id: expected %d, received %d", testAgency.ID, receivedAgencyID)
+				}
+			})
+		}
+	})
+
+	t.Run("Author", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			id   int64
+			err  error
+		}{
+			{"valid", testAuthor1.ID, nil},
+			{"error", testAuthor1.ID, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetAuthorFunc: func(ctx context.Context, id int64) (domain.Author, error) {
+							receivedID = id
+							return domain.Author{}, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Author(context.Background(), tc.id)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedID != tc.id {
+					t.Errorf("wrong id: expected %d, received %d", tc.id, receivedID)
+				}
+			})
+		}
+	})
+
+	t.Run("Authors", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name     string
+			filter   *gqlgen.AuthorFilter
+			byAgency bool
+			err      error
+		}{
+			{"valid", nil, false, nil},
+			{"error", nil, false, testError},
+			{"empty filter", &gqlgen.AuthorFilter{}, false, nil},
+			{"agency", &gqlgen.AuthorFilter{AgencyID: &testAgency.ID}, true, nil},
+			{"agency error", &gqlgen.AuthorFilter{AgencyID: &testAgency.ID}, true, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedAgencyID int64
+				mock := &mocks.RepositoryMock{
+					ListAuthorsFunc: func(ctx context.Context) ([]domain.Author, error) {
+						return nil, tc.err
+					},
+					ListAuthorsByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Author, error) {
+						receivedAgencyID = agencyID
+						return nil, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				_, err := r.Query().Authors(context.Background(), tc.filter)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if calls := len(mock.ListAuthorsByAgencyIDCalls()); (calls == 1) != tc.byAgency {
+					t.Fatalf("unexpected number of ListAuthorsByAgencyID calls: %d", calls)
+				}
+				if tc.byAgency && receivedAgencyID != testAgency.ID {
+					t.Errorf("wrong id: expected %d, received %d", testAgency.ID, receivedAgencyID)
+				}
+			})
+		}
+	})
+
+	t.Run("Book", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			id   int64
+			err  error
+		}{
+			{"valid", testBook.ID, nil},
+			{"error", testBook.ID, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetBookFunc: func(ctx context.Context, id int64) (domain.Book, error) {
+							receivedID = id
+							return domain.Book{}, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Book(context.Background(), tc.id)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedID != tc.id {
+					t.Errorf("wrong id: expected %d, received %d", tc.id, receivedID)
+				}
+			})
+		}
+	})
+
+	t.Run("BookByIsbn", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			isbn domain.ISBN
+			err  error
+		}{
+			{"valid", *testBook.ISBN, nil},
+			{"error", *testBook.ISBN, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedISBN domain.ISBN
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetBookByISBNFunc: func(ctx context.Context, isbn domain.ISBN) (domain.Book, error) {
+							receivedISBN = isbn
+							return domain.Book{}, tc.err
+						},
+					},
+				}
+				_, err := r.Query().BookByIsbn(context.Background(), tc.isbn)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedISBN != tc.isbn {
+					t.Errorf("wrong isbn: expected %q, received %q", tc.isbn, receivedISBN)
+				}
+			})
+		}
+	})
+
+	t.Run("Books", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name         string
+			filter       *gqlgen.BookFilter
+			byGenre      bool
+			expSubgenres bool
+			byTag        bool
+			byAgency     bool
+			err          error
+		}{
+			{"valid", nil, false, false, false, false, nil},
+			{"error", nil, false, false, false, false, testError},
+			{"empty filter", &gqlgen.BookFilter{}, false, false, false, false, nil},
+			{"genre", &gqlgen.BookFilter{GenreID: &testGenre.ID}, true, false, false, false, nil},
+			{"genre with subgenres", &gqlgen.BookFilter{GenreID: &testGenre.ID, IncludeSubgenres: boolPtr(true)}, true, true, false, false, nil},
+			{"genre error", &gqlgen.BookFilter{GenreID: &testGenre.ID}, true, false, false, false, testError},
+			{"tag", &gqlgen.BookFilter{Tag: stringPtr("dragons")}, false, false, true, false, nil},
+			{"tag error", &gqlgen.BookFilter{Tag: stringPtr("dragons")}, false, false, true, false, testError},
+			{"agency", &gqlgen.BookFilter{AgencyID: &testAgency.ID}, false, false, false, true, nil},
+			{"agency error", &gqlgen.BookFilter{AgencyID: &testAgency.ID}, false, false, false, true, testError},
+			{"genre and agency", &gqlgen.BookFilter{GenreID: &testGenre.ID, AgencyID: &testAgency.ID}, true, false, false, true, nil},
+			{"genre, tag and agency", &gqlgen.BookFilter{GenreID: &testGenre.ID, Tag: stringPtr("dragons"), AgencyID: &testAgency.ID}, true, false, true, true, nil},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedGenreID, receivedAgencyID int64
+				var receivedSubgenres bool
+				var receivedTag string
+				mock := &mocks.RepositoryMock{
+					ListBooksFunc: func(ctx context.Context) ([]domain.Book, error) {
+						return nil, tc.err
+					},
+					ListBooksByGenreIDFunc: func(ctx context.Context, genreID int64, includeSubgenres bool) ([]domain.Book, error) {
+						receivedGenreID = genreID
+						receivedSubgenres = includeSubgenres
+						return nil, tc.err
+					},
+					ListBooksByTagFunc: func(ctx context.Context, tag string) ([]domain.Book, error) {
+						receivedTag = tag
+						return nil, tc.err
+					},
+					ListBooksByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Book, error) {
+						receivedAgencyID = agencyID
+						return nil, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				_, err := r.Query().Books(context.Background(), tc.filter)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if calls := len(mock.ListBooksByGenreIDCalls()); (calls == 1) != tc.byGenre {
+					t.Fatalf("unexpected number of ListBooksByGenreID calls: %d", calls)
+				}
+				if calls := len(mock.ListBooksByTagCalls()); (calls == 1) != tc.byTag {
+					t.Fatalf("unexpected number of ListBooksByTag calls: %d", calls)
+				}
+				if calls := len(mock.ListBooksByAgencyIDCalls()); (calls == 1) != tc.byAgency {
+					t.Fatalf("unexpected number of ListBooksByAgencyID calls: %d", calls)
+				}
+				if tc.byGenre && receivedGenreID != testGenre.ID {
+					t.Errorf("wrong id: expected %d, received %d", testGenre.ID, receivedGenreID)
+				}
+				if tc.byGenre && receivedSubgenres != tc.expSubgenres {
+					t.Errorf("wrong includeSubgenres: expected %t, received %t", tc.expSubgenres, receivedSubgenres)
+				}
+				if tc.byTag && receivedTag != "dragons" {
+					t.Errorf("wrong tag: expected %q, received %q", "dragons", receivedTag)
+				}
+				if tc.byAgency && receivedAgencyID != testAgency.ID {
+					t.Errorf("wrong id: expected %d, received %d", testAgency.ID, receivedAgencyID)
+				}
+			})
+		}
+	})
+
+	t.Run("Books intersection", func(t *testing.T) {
+		t.Parallel()
+		mock := &mocks.RepositoryMock{
+			ListBooksByGenreIDFunc: func(ctx context.Context, genreID int64, includeSubgenres bool) ([]domain.Book, error) {
+				return []domain.Book{{ID: 1}, {ID: 2}, {ID: 3}}, nil
+			},
+			ListBooksByTagFunc: func(ctx context.Context, tag string) ([]domain.Book, error) {
+				return []domain.Book{{ID: 3}, {ID: 4}}, nil
+			},
+			ListBooksByAgencyIDFunc: func(ctx context.Context, agencyID int64) ([]domain.Book, error) {
+				return []domain.Book{{ID: 3}, {ID: 2}, {ID: 4}}, nil
+			},
+		}
+		r := &resolvers.Resolver{Repo: mock}
+		books, err := r.Query().Books(context.Background(), &gqlgen.BookFilter{GenreID: &testGenre.ID, AgencyID: &testAgency.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := []domain.Book{{ID: 2}, {ID: 3}}
+		if !reflect.DeepEqual(books, exp) {
+			t.Errorf("wrong books: expected %v, received %v", exp, books)
+		}
+		books, err = r.Query().Books(context.Background(), &gqlgen.BookFilter{GenreID: &testGenre.ID, Tag: stringPtr("dragons"), AgencyID: &testAgency.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp = []domain.Book{{ID: 3}}
+		if !reflect.DeepEqual(books, exp) {
+			t.Errorf("wrong books: expected %v, received %v", exp, books)
+		}
+	})
+
+	t.Run("OrphanBooks", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			err  error
+		}{
+			{"valid", nil},
+			{"error", testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListOrphanBooksFunc: func(ctx context.Context) ([]domain.Book, error) {
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Query().OrphanBooks(context.Background())
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+			})
+		}
+	})
+
+	t.Run("Deal", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			id   int64
+			err  error
+		}{
+			{"valid", testDeal.ID, nil},
+			{"error", testDeal.ID, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetDealFunc: func(ctx context.Context, id int64) (domain.Deal, error) {
+							receivedID = id
+							return domain.Deal{}, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Deal(context.Background(), tc.id)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedID != tc.id {
+					t.Errorf("wrong id: expected %d, received %d", tc.id, receivedID)
+				}
+			})
+		}
+	})
+
+	t.Run("AvailableRights", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name      string
+			territory string
+			language  domain.LanguageCode
+			err       error
+			calls     int
+		}{
+			{"valid", "FR", "fr", nil, 1},
+			{"world", "WORLD", "en", nil, 1},
+			{"error", "FR", "fr", testError, 1},
+			{"invalid territory", "France", "fr", nil, 0},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				mock := &mocks.RepositoryMock{
+					ListAvailableRightsFunc: func(ctx context.Context, territory string, language domain.LanguageCode) ([]domain.AvailableRights, error) {
+						return nil, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				_, err := r.Query().AvailableRights(context.Background(), tc.territory, tc.language)
+				if tc.calls == 0 {
+					if err == nil {
+						t.Error("expected a validation error, received nil")
+					}
+				} else if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				calls := mock.ListAvailableRightsCalls()
+				if len(calls) != tc.calls {
+					t.Fatalf("expected %d calls, received %d", tc.calls, len(calls))
+				}
+				if tc.calls == 0 {
+					return
+				}
+				if calls[0].Territory != tc.territory || calls[0].Language != tc.language {
+					t.Errorf("wrong args: expected %q %q, received %q %q", tc.territory, tc.language, calls[0].Territory, calls[0].Language)
+				}
+			})
+		}
+	})
+
+	t.Run("RoyaltyStatement", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name   string
+			period string
+			err    error
+			calls  int
+		}{
+			{"valid", "2024-Q1", nil, 1},
+			{"error", "2024-Q1", testError, 1},
+			{"invalid period", "2024-03", nil, 0},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				mock := &mocks.RepositoryMock{
+					GetRoyaltyStatementFunc: func(ctx context.Context, authorID int64, period string) (domain.RoyaltyStatement, error) {
+						return domain.RoyaltyStatement{}, tc.err
+					},
+				}
+				r := &resolvers.Resolver{Repo: mock}
+				_, err := r.Query().RoyaltyStatement(context.Background(), testAuthor1.ID, tc.period)
+				if tc.calls == 0 {
+					if err == nil {
+						t.Error("expected a validation error, received nil")
+					}
+				} else if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				calls := mock.GetRoyaltyStatementCalls()
+				if len(calls) != tc.calls {
+					t.Fatalf("expected %d calls, received %d", tc.calls, len(calls))
+				}
+				if tc.calls == 0 {
+					return
+				}
+				if calls[0].AuthorID != testAuthor1.ID || calls[0].Period != tc.period {
+					t.Errorf("wrong args: expected %d %q, received %d %q", testAuthor1.ID, tc.period, calls[0].AuthorID, calls[0].Period)
+				}
+			})
+		}
+	})
+	t.Run("Edition", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			id   int64
+			err  error
+		}{
+			{"valid", testEdition.ID, nil},
+			{"error", testEdition.ID, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetEditionFunc: func(ctx context.Context, id int64) (domain.Edition, error) {
+							receivedID = id
+							return domain.Edition{}, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Edition(context.Background(), tc.id)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedID != tc.id {
+					t.Errorf("wrong id: expected %d, received %d", tc.id, receivedID)
+				}
+			})
+		}
 	})
 
-	t.Run("Book mutations", func(t *testing.T) {
+	t.Run("EditionByIsbn", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
-			name    string
-			book    *sqlc.Book
-			authors []int64
-			err     error
+			name string
+			isbn domain.ISBN
+			err  error
 		}{
-			{"valid", testBook, []int64{testAuthor1.ID, testAuthor2.ID}, nil},
-			{"error", testBook, []int64{testAuthor1.ID}, testError},
+			{"valid", *testEdition.ISBN, nil},
+			{"error", *testEdition.ISBN, testError},
 		}
-
-		t.Run("CreateBook", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedCreateBookParams sqlc.CreateBookParams
-					var receivedAuthorIDs []int64
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							TxQuerent: &mocks.TxQuerentMock{
-								CreateBookFunc: func(ctx context.Context, args sqlc.CreateBookParams, authorIDs []int64) (*sqlc.Book, error) {
-									receivedCreateBookParams = args
-									receivedAuthorIDs = authorIDs
-									return nil, tc.err
-								},
-							},
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedISBN domain.ISBN
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetEditionByISBNFunc: func(ctx context.Context, isbn domain.ISBN) (domain.Edition, error) {
+							receivedISBN = isbn
+							return domain.Edition{}, tc.err
 						},
-					}
-					_, err := r.Mutation().CreateBook(context.Background(), gqlgen.CreateUpdateBookInput{
-						Title:       tc.book.Title,
-						Description: tc.book.Description,
-						Cover:       tc.book.Cover,
-						AuthorIDs:   tc.authors,
-					})
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					exp := sqlc.CreateBookParams{
-						Title:       tc.book.Title,
-						Description: tc.book.Description,
-						Cover:       tc.book.Cover,
-					}
-					if !reflect.DeepEqual(receivedCreateBookParams, exp) {
-						t.Errorf("wrong params: expected %v, received %v", exp, receivedCreateBookParams)
-					}
-					if !reflect.DeepEqual(receivedAuthorIDs, tc.authors) {
-						t.Errorf("wrong ids: expected %v, received %v", tc.authors, receivedAuthorIDs)
-					}
-				})
-			}
-		})
+					},
+				}
+				_, err := r.Query().EditionByIsbn(context.Background(), tc.isbn)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedISBN != tc.isbn {
+					t.Errorf("wrong isbn: expected %q, received %q", tc.isbn, receivedISBN)
+				}
+			})
+		}
+	})
 
-		t.Run("UpdateBook", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedUpdateBookParams sqlc.UpdateBookParams
-					var receivedAuthorIDs []int64
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							TxQuerent: &mocks.TxQuerentMock{
-								UpdateBookFunc: func(ctx context.Context, args sqlc.UpdateBookParams, authorIDs []int64) (*sqlc.Book, error) {
-									receivedUpdateBookParams = args
-									receivedAuthorIDs = authorIDs
-									return nil, tc.err
-								},
-							},
+	t.Run("Editions", func(t *testing.T) {
+		t.Parallel()
+		format := domain.EditionPaperback
+		tests := []struct {
+			name   string
+			filter *gqlgen.EditionFilter
+			exp    domain.ListEditionsParams
+			err    error
+		}{
+			{"no filter", nil, domain.ListEditionsParams{}, nil},
+			{"filter", &gqlgen.EditionFilter{
+				BookID:        int64Ptr(testBook.ID),
+				Format:        &format,
+				PublishedFrom: testEdition.PublishedOn,
+			}, domain.ListEditionsParams{
+				BookID:        int64Ptr(testBook.ID),
+				Format:        &format,
+				PublishedFrom: testEdition.PublishedOn,
+			}, nil},
+			{"error", nil, domain.ListEditionsParams{}, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedParams domain.ListEditionsParams
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListEditionsFunc: func(ctx context.Context, args domain.ListEditionsParams) ([]domain.Edition, error) {
+							receivedParams = args
+							return nil, tc.err
 						},
-					}
-					_, err := r.Mutation().UpdateBook(context.Background(), tc.book.ID, gqlgen.CreateUpdateBookInput{
-						Title:       tc.book.Title,
-						Description: tc.book.Description,
-						Cover:       tc.book.Cover,
-						AuthorIDs:   tc.authors,
-					})
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					exp := sqlc.UpdateBookParams{
-						ID:          tc.book.ID,
-						Title:       tc.book.Title,
-						Description: tc.book.Description,
-						Cover:       tc.book.Cover,
-					}
-					if !reflect.DeepEqual(receivedUpdateBookParams, exp) {
-						t.Errorf("wrong params: expected %v, received %v", exp, receivedUpdateBookParams)
-					}
-					if !reflect.DeepEqual(receivedAuthorIDs, tc.authors) {
-						t.Errorf("wrong ids: expected %v, received %v", tc.authors, receivedAuthorIDs)
-					}
-				})
-			}
-		})
+					},
+				}
+				_, err := r.Query().Editions(context.Background(), tc.filter)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if !reflect.DeepEqual(receivedParams, tc.exp) {
+					t.Errorf("wrong params: expected %v, received %v", tc.exp, receivedParams)
+				}
+			})
+		}
+	})
 
-		t.Run("DeleteBook", func(t *testing.T) {
-			t.Parallel()
-			for _, tc := range tests {
-				tc := tc
-				t.Run(tc.name, func(t *testing.T) {
-					t.Parallel()
-					var receivedBookID int64
-					r := &resolvers.Resolver{
-						Repo: &postgres.Repo{
-							Querent: &mocks.QuerentMock{
-								DeleteBookFunc: func(ctx context.Context, id int64) (sqlc.Book, error) {
-									receivedBookID = id
-									return sqlc.Book{}, tc.err
-								},
-							},
+	t.Run("Genre", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			id   int64
+			err  error
+		}{
+			{"valid", testGenre.ID, nil},
+			{"error", testGenre.ID, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetGenreFunc: func(ctx context.Context, id int64) (domain.Genre, error) {
+							receivedID = id
+							return domain.Genre{}, tc.err
 						},
-					}
-					_, err := r.Mutation().DeleteBook(context.Background(), tc.book.ID)
-					if !errors.Is(err, tc.err) {
-						t.Errorf("wrong error: expected %v, received %v", tc.err, err)
-					}
-					if receivedBookID != tc.book.ID {
-						t.Errorf("wrong id: expected %d, received %d", tc.book.ID, receivedBookID)
-					}
-				})
-			}
-		})
+					},
+				}
+				_, err := r.Query().Genre(context.Background(), tc.id)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedID != tc.id {
+					t.Errorf("wrong id: expected %d, received %d", tc.id, receivedID)
+				}
+			})
+		}
 	})
-}
 
-func TestQueryResolver(t *testing.T) {
-	t.Parallel()
+	t.Run("Genres", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			err  error
+		}{
+			{"valid", nil},
+			{"error", testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListGenresFunc: func(ctx context.Context) ([]domain.Genre, error) {
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Genres(context.Background())
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+			})
+		}
+	})
 
-	t.Run("Agent", func(t *testing.T) {
+	t.Run("Publisher", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name string
 			id   int64
 			err  error
 		}{
-			{"valid", testAgent.ID, nil},
-			{"error", testAgent.ID, testError},
+			{"valid", testPublisher.ID, nil},
+			{"error", testPublisher.ID, testError},
 		}
 		for _, tc := range tests {
 			tc := tc
@@ -601,16 +4599,14 @@ func TestQueryResolver(t *testing.T) {
 				t.Parallel()
 				var receivedID int64
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							GetAgentFunc: func(ctx context.Context, id int64) (sqlc.Agent, error) {
-								receivedID = id
-								return sqlc.Agent{}, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						GetPublisherFunc: func(ctx context.Context, id int64) (domain.Publisher, error) {
+							receivedID = id
+							return domain.Publisher{}, tc.err
 						},
 					},
 				}
-				_, err := r.Query().Agent(context.Background(), tc.id)
+				_, err := r.Query().Publisher(context.Background(), tc.id)
 				if !errors.Is(err, tc.err) {
 					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
 				}
@@ -621,7 +4617,7 @@ func TestQueryResolver(t *testing.T) {
 		}
 	})
 
-	t.Run("Agents", func(t *testing.T) {
+	t.Run("Publishers", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name string
@@ -635,15 +4631,13 @@ func TestQueryResolver(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				t.Parallel()
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							ListAgentsFunc: func(ctx context.Context) ([]sqlc.Agent, error) {
-								return nil, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						ListPublishersFunc: func(ctx context.Context) ([]domain.Publisher, error) {
+							return nil, tc.err
 						},
 					},
 				}
-				_, err := r.Query().Agents(context.Background())
+				_, err := r.Query().Publishers(context.Background())
 				if !errors.Is(err, tc.err) {
 					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
 				}
@@ -651,15 +4645,15 @@ func TestQueryResolver(t *testing.T) {
 		}
 	})
 
-	t.Run("Author", func(t *testing.T) {
+	t.Run("Series", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name string
 			id   int64
 			err  error
 		}{
-			{"valid", testAuthor1.ID, nil},
-			{"error", testAuthor1.ID, testError},
+			{"valid", testSeries.ID, nil},
+			{"error", testSeries.ID, testError},
 		}
 		for _, tc := range tests {
 			tc := tc
@@ -667,16 +4661,14 @@ func TestQueryResolver(t *testing.T) {
 				t.Parallel()
 				var receivedID int64
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							GetAuthorFunc: func(ctx context.Context, id int64) (sqlc.Author, error) {
-								receivedID = id
-								return sqlc.Author{}, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						GetSeriesFunc: func(ctx context.Context, id int64) (domain.Series, error) {
+							receivedID = id
+							return domain.Series{}, tc.err
 						},
 					},
 				}
-				_, err := r.Query().Author(context.Background(), tc.id)
+				_, err := r.Query().Series(context.Background(), tc.id)
 				if !errors.Is(err, tc.err) {
 					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
 				}
@@ -687,7 +4679,7 @@ func TestQueryResolver(t *testing.T) {
 		}
 	})
 
-	t.Run("Authors", func(t *testing.T) {
+	t.Run("AllSeries", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name string
@@ -701,15 +4693,13 @@ func TestQueryResolver(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				t.Parallel()
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							ListAuthorsFunc: func(ctx context.Context) ([]sqlc.Author, error) {
-								return nil, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						ListSeriesFunc: func(ctx context.Context) ([]domain.Series, error) {
+							return nil, tc.err
 						},
 					},
 				}
-				_, err := r.Query().Authors(context.Background())
+				_, err := r.Query().AllSeries(context.Background())
 				if !errors.Is(err, tc.err) {
 					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
 				}
@@ -717,15 +4707,15 @@ func TestQueryResolver(t *testing.T) {
 		}
 	})
 
-	t.Run("Book", func(t *testing.T) {
+	t.Run("Submission", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name string
 			id   int64
 			err  error
 		}{
-			{"valid", testBook.ID, nil},
-			{"error", testBook.ID, testError},
+			{"valid", testSubmission.ID, nil},
+			{"error", testSubmission.ID, testError},
 		}
 		for _, tc := range tests {
 			tc := tc
@@ -733,16 +4723,14 @@ func TestQueryResolver(t *testing.T) {
 				t.Parallel()
 				var receivedID int64
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							GetBookFunc: func(ctx context.Context, id int64) (sqlc.Book, error) {
-								receivedID = id
-								return sqlc.Book{}, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						GetSubmissionFunc: func(ctx context.Context, id int64) (domain.Submission, error) {
+							receivedID = id
+							return domain.Submission{}, tc.err
 						},
 					},
 				}
-				_, err := r.Query().Book(context.Background(), tc.id)
+				_, err := r.Query().Submission(context.Background(), tc.id)
 				if !errors.Is(err, tc.err) {
 					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
 				}
@@ -753,7 +4741,41 @@ func TestQueryResolver(t *testing.T) {
 		}
 	})
 
-	t.Run("Books", func(t *testing.T) {
+	t.Run("Webhook", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name string
+			id   int64
+			err  error
+		}{
+			{"valid", testWebhook.ID, nil},
+			{"error", testWebhook.ID, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						GetWebhookFunc: func(ctx context.Context, id int64) (domain.Webhook, error) {
+							receivedID = id
+							return domain.Webhook{}, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Webhook(context.Background(), tc.id)
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+				if receivedID != tc.id {
+					t.Errorf("wrong id: expected %d, received %d", tc.id, receivedID)
+				}
+			})
+		}
+	})
+
+	t.Run("Webhooks", func(t *testing.T) {
 		t.Parallel()
 		tests := []struct {
 			name string
@@ -767,27 +4789,108 @@ func TestQueryResolver(t *testing.T) {
 			t.Run(tc.name, func(t *testing.T) {
 				t.Parallel()
 				r := &resolvers.Resolver{
-					Repo: &postgres.Repo{
-						Querent: &mocks.QuerentMock{
-							ListBooksFunc: func(ctx context.Context) ([]sqlc.Book, error) {
-								return nil, tc.err
-							},
+					Repo: &mocks.RepositoryMock{
+						ListWebhooksFunc: func(ctx context.Context) ([]domain.Webhook, error) {
+							return nil, tc.err
+						},
+					},
+				}
+				_, err := r.Query().Webhooks(context.Background())
+				if !errors.Is(err, tc.err) {
+					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
+				}
+			})
+		}
+	})
+
+	t.Run("WebhookDeliveries", func(t *testing.T) {
+		t.Parallel()
+		dead := domain.DeliveryDead
+		tests := []struct {
+			name   string
+			status *string
+			err    error
+		}{
+			{"valid", nil, nil},
+			{"valid with status", &dead, nil},
+			{"error", &dead, testError},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				var receivedWebhookID int64
+				r := &resolvers.Resolver{
+					Repo: &mocks.RepositoryMock{
+						ListWebhookDeliveriesFunc: func(ctx context.Context, webhookID int64) ([]domain.WebhookDelivery, error) {
+							receivedWebhookID = webhookID
+							return nil, tc.err
+						},
+						ListWebhookDeliveriesByStatusFunc: func(ctx context.Context, args domain.ListWebhookDeliveriesByStatusParams) ([]domain.WebhookDelivery, error) {
+							receivedWebhookID = args.WebhookID
+							return nil, tc.err
 						},
 					},
 				}
-				_, err := r.Query().Books(context.Background())
+				_, err := r.Query().WebhookDeliveries(context.Background(), testWebhook.ID, tc.status)
 				if !errors.Is(err, tc.err) {
 					t.Errorf("wrong error: expected %v, received %v", tc.err, err)
 				}
+				if receivedWebhookID != testWebhook.ID {
+					t.Errorf("wrong id: expected %d, received %d", testWebhook.ID, receivedWebhookID)
+				}
 			})
 		}
 	})
 }
 
-func nullStringToPointer(ns sql.NullString) *string {
-	var s *string
-	if ns.Valid {
-		s = &ns.String
+func stringPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func isbnPtr(i domain.ISBN) *domain.ISBN {
+	return &i
+}
+
+func languageCodePtr(l domain.LanguageCode) *domain.LanguageCode {
+	return &l
+}
+
+func TestErrorPresenter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		err     error
+		message string
+		code    interface{}
+	}{
+		{"repository timeout", domain.ErrTimeout, "operation timed out", "TIMEOUT"},
+		{"operation deadline", fmt.Errorf("list books: %w", context.DeadlineExceeded), "operation timed out", "TIMEOUT"},
+		{"other", testError, testError.Error(), nil},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			res := resolvers.ErrorPresenter(context.Background(), tc.err)
+			if res.Message != tc.message {
+				t.Errorf("expected message %q, received %q", tc.message, res.Message)
+			}
+			if code := res.Extensions["code"]; code != tc.code {
+				t.Errorf("expected code %v, received %v", tc.code, code)
+			}
+		})
 	}
-	return s
 }
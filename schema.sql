@@ -1,29 +1,580 @@
+-- The schema of the catalog. It creates the database from scratch and does
+-- not migrate databases created from earlier versions of it: re-applying it
+-- leaves existing tables as they are, so a database from an earlier version
+-- has to be recreated.
+
+CREATE TABLE IF NOT EXISTS agencies (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL
+);
+
+-- Deleting an agent that representations or deals refer to archives it
+-- instead: deleted_at is set, the agent no longer appears in lists and cannot
+-- be given new authors, representations, deals or submissions, but its
+-- history keeps referring to it (see check_agent_not_deleted).
 CREATE TABLE IF NOT EXISTS agents (
     id BIGSERIAL PRIMARY KEY,
     name TEXT NOT NULL,
-    email TEXT NOT NULL
+    email TEXT NOT NULL,
+    agency_id BIGINT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ,
+    FOREIGN KEY (agency_id) REFERENCES agencies(id) ON DELETE SET NULL
 );
 
 CREATE TABLE IF NOT EXISTS authors (
     id BIGSERIAL PRIMARY KEY,
     name TEXT NOT NULL,
     website TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Representations record which agents represented an author, where and when.
+-- An author has exactly one current primary representation, whose agent is
+-- the agent of the author. Agents that are part of the history of an author
+-- are archived rather than deleted. Territory is WORLD or an ISO 3166-1
+-- alpha-2 country code.
+CREATE TABLE IF NOT EXISTS representations (
+    id BIGSERIAL PRIMARY KEY,
+    author_id BIGINT NOT NULL,
     agent_id BIGINT NOT NULL,
-    FOREIGN KEY (agent_id) REFERENCES agents(id) 
+    territory TEXT NOT NULL,
+    started_at DATE NOT NULL,
+    ended_at DATE,
+    is_primary BOOLEAN NOT NULL,
+    FOREIGN KEY (author_id) REFERENCES authors(id) ON DELETE CASCADE,
+    FOREIGN KEY (agent_id) REFERENCES agents(id) ON DELETE RESTRICT,
+    CONSTRAINT representations_territory_check CHECK (territory ~ '^(WORLD|[A-Z]{2})$'),
+    CONSTRAINT representations_period_check CHECK (ended_at >= started_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS representations_current_primary_key
+ON representations (author_id) WHERE is_primary AND ended_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS publishers (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS series (
+    id BIGSERIAL PRIMARY KEY,
+    title TEXT NOT NULL
+);
+
+-- A book belongs to at most one series, at a position unique within it. The
+-- uniqueness is deferrable so that a series can be reordered in place. ISBNs
+-- are stored in their 13 digit form and language is an ISO 639-1 code.
 CREATE TABLE IF NOT EXISTS books (
     id BIGSERIAL PRIMARY KEY,
     title TEXT NOT NULL,
     description TEXT NOT NULL,
-    cover TEXT NOT NULL
+    cover TEXT NOT NULL,
+    publisher_id BIGINT,
+    series_id BIGINT,
+    series_position INT,
+    isbn TEXT,
+    published_on DATE,
+    page_count INT,
+    language TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (publisher_id) REFERENCES publishers(id) ON DELETE SET NULL,
+    FOREIGN KEY (series_id) REFERENCES series(id),
+    CONSTRAINT books_series_position_check
+        CHECK ((series_id IS NULL) = (series_position IS NULL) AND series_position > 0),
+    CONSTRAINT books_series_position_key
+        UNIQUE (series_id, series_position) DEFERRABLE INITIALLY IMMEDIATE,
+    CONSTRAINT books_isbn_key UNIQUE (isbn),
+    CONSTRAINT books_isbn_check CHECK (isbn ~ '^97[89][0-9]{10}$'),
+    CONSTRAINT books_page_count_check CHECK (page_count > 0),
+    CONSTRAINT books_language_check CHECK (language ~ '^[a-z]{2}$')
 );
 
+-- An author is credited on a book once, in a role. Position orders the
+-- contributors of a book, starting from 1.
 CREATE TABLE IF NOT EXISTS book_authors (
     id BIGSERIAL PRIMARY KEY,
     book_id BIGINT NOT NULL,
     author_id BIGINT NOT NULL,
+    role TEXT NOT NULL,
+    position INT NOT NULL,
     FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
     FOREIGN KEY (author_id) REFERENCES authors(id) ON DELETE CASCADE,
-    UNIQUE (book_id,author_id)
-);
\ No newline at end of file
+    UNIQUE (book_id,author_id),
+    CONSTRAINT book_authors_role_check
+        CHECK (role IN ('AUTHOR', 'ILLUSTRATOR', 'TRANSLATOR', 'EDITOR')),
+    CONSTRAINT book_authors_position_check CHECK (position > 0)
+);
+
+-- Genres form a tree; a genre with subgenres cannot be deleted.
+CREATE TABLE IF NOT EXISTS genres (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    parent_id BIGINT,
+    FOREIGN KEY (parent_id) REFERENCES genres(id),
+    CHECK (parent_id <> id)
+);
+
+CREATE TABLE IF NOT EXISTS book_genres (
+    id BIGSERIAL PRIMARY KEY,
+    book_id BIGINT NOT NULL,
+    genre_id BIGINT NOT NULL,
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+    FOREIGN KEY (genre_id) REFERENCES genres(id) ON DELETE CASCADE,
+    UNIQUE (book_id,genre_id)
+);
+
+-- Tags are free-form subjects of a book, next to its genres.
+CREATE TABLE IF NOT EXISTS book_tags (
+    id BIGSERIAL PRIMARY KEY,
+    book_id BIGINT NOT NULL,
+    tag TEXT NOT NULL,
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+    UNIQUE (book_id,tag),
+    CONSTRAINT book_tags_tag_check CHECK (tag <> '' AND tag = btrim(tag))
+);
+
+CREATE INDEX IF NOT EXISTS book_tags_tag_idx ON book_tags (tag);
+
+-- An edition is a published form of a book. Prices are in the minor units of
+-- an ISO 4217 currency.
+CREATE TABLE IF NOT EXISTS editions (
+    id BIGSERIAL PRIMARY KEY,
+    book_id BIGINT NOT NULL,
+    format TEXT NOT NULL,
+    isbn TEXT,
+    published_on DATE,
+    price_amount BIGINT,
+    price_currency TEXT,
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+    CONSTRAINT editions_format_check
+        CHECK (format IN ('HARDCOVER', 'PAPERBACK', 'EBOOK', 'AUDIOBOOK')),
+    CONSTRAINT editions_isbn_key UNIQUE (isbn),
+    CONSTRAINT editions_isbn_check CHECK (isbn ~ '^97[89][0-9]{10}$'),
+    CONSTRAINT editions_price_check
+        CHECK ((price_amount IS NULL) = (price_currency IS NULL) AND price_amount >= 0 AND price_currency ~ '^[A-Z]{3}$')
+);
+
+-- A deal sells the rights to a book in a territory, language and format to a
+-- publisher, through the agent of its authors. Territory is WORLD or an ISO
+-- 3166-1 alpha-2 country code, language an ISO 639-1 code and the advance is
+-- in the minor units of an ISO 4217 currency. Deals under negotiation have no
+-- signing date and signed deals have one; deals go with their book, keep
+-- their publisher from being deleted and archive their agent instead. The royalty rate on sales and
+-- the commission of the agent on royalties are in basis points.
+CREATE TABLE IF NOT EXISTS deals (
+    id BIGSERIAL PRIMARY KEY,
+    book_id BIGINT NOT NULL,
+    publisher_id BIGINT NOT NULL,
+    agent_id BIGINT NOT NULL,
+    territory TEXT NOT NULL,
+    language TEXT NOT NULL,
+    format TEXT NOT NULL,
+    advance_amount BIGINT NOT NULL,
+    advance_currency TEXT NOT NULL,
+    signed_on DATE,
+    status TEXT NOT NULL,
+    royalty_rate INT NOT NULL,
+    commission_rate INT NOT NULL,
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE CASCADE,
+    FOREIGN KEY (publisher_id) REFERENCES publishers(id),
+    FOREIGN KEY (agent_id) REFERENCES agents(id),
+    CONSTRAINT deals_territory_check CHECK (territory ~ '^(WORLD|[A-Z]{2})$'),
+    CONSTRAINT deals_language_check CHECK (language ~ '^[a-z]{2}$'),
+    CONSTRAINT deals_format_check
+        CHECK (format IN ('HARDCOVER', 'PAPERBACK', 'EBOOK', 'AUDIOBOOK')),
+    CONSTRAINT deals_advance_check
+        CHECK (advance_amount >= 0 AND advance_currency ~ '^[A-Z]{3}$'),
+    CONSTRAINT deals_status_check
+        CHECK (status IN ('NEGOTIATING', 'SIGNED', 'TERMINATED')),
+    CONSTRAINT deals_signed_on_check
+        CHECK ((status <> 'NEGOTIATING' OR signed_on IS NULL) AND (status <> 'SIGNED' OR signed_on IS NOT NULL)),
+    CONSTRAINT deals_royalty_rate_check CHECK (royalty_rate BETWEEN 0 AND 10000),
+    CONSTRAINT deals_commission_rate_check CHECK (commission_rate BETWEEN 0 AND 10000)
+);
+
+-- A royalty rate overrides the rate of its deal for the sales of an edition,
+-- which like sales must be of the book and format of the deal (see
+-- check_edition_in_deal).
+CREATE TABLE IF NOT EXISTS royalty_rates (
+    id BIGSERIAL PRIMARY KEY,
+    deal_id BIGINT NOT NULL,
+    edition_id BIGINT NOT NULL,
+    rate INT NOT NULL,
+    FOREIGN KEY (deal_id) REFERENCES deals(id) ON DELETE CASCADE,
+    FOREIGN KEY (edition_id) REFERENCES editions(id) ON DELETE CASCADE,
+    UNIQUE (deal_id,edition_id),
+    CONSTRAINT royalty_rates_rate_check CHECK (rate BETWEEN 0 AND 10000)
+);
+
+-- Sales record the units of an edition sold under a deal in a quarter, such
+-- as 2024-Q1, and the revenue from them in the minor units of an ISO 4217
+-- currency.
+CREATE TABLE IF NOT EXISTS sales (
+    id BIGSERIAL PRIMARY KEY,
+    deal_id BIGINT NOT NULL,
+    edition_id BIGINT NOT NULL,
+    period TEXT NOT NULL,
+    units INT NOT NULL,
+    revenue_amount BIGINT NOT NULL,
+    revenue_currency TEXT NOT NULL,
+    FOREIGN KEY (deal_id) REFERENCES deals(id) ON DELETE CASCADE,
+    FOREIGN KEY (edition_id) REFERENCES editions(id) ON DELETE CASCADE,
+    CONSTRAINT sales_period_key UNIQUE (deal_id,edition_id,period),
+    CONSTRAINT sales_period_check CHECK (period ~ '^[0-9]{4}-Q[1-4]$'),
+    CONSTRAINT sales_units_check CHECK (units >= 0),
+    CONSTRAINT sales_revenue_check
+        CHECK (revenue_amount >= 0 AND revenue_currency ~ '^[A-Z]{3}$')
+);
+
+-- A submission is a manuscript an author sent to an agent, before there is a
+-- book. Its status only moves along the transitions allowed by
+-- domain.CanTransitionSubmission, each recorded with its time and an
+-- optional note. An offered submission is converted into a book once, after
+-- which book_id is set; submissions go with their agent.
+CREATE TABLE IF NOT EXISTS submissions (
+    id BIGSERIAL PRIMARY KEY,
+    agent_id BIGINT NOT NULL,
+    author_name TEXT NOT NULL,
+    author_website TEXT,
+    title TEXT NOT NULL,
+    synopsis TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'RECEIVED',
+    received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    book_id BIGINT,
+    FOREIGN KEY (agent_id) REFERENCES agents(id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books(id) ON DELETE SET NULL,
+    CONSTRAINT submissions_status_check
+        CHECK (status IN ('RECEIVED', 'READING', 'REQUESTED_FULL', 'OFFERED', 'DECLINED', 'WITHDRAWN')),
+    CONSTRAINT submissions_book_check CHECK (book_id IS NULL OR status = 'OFFERED')
+);
+
+CREATE TABLE IF NOT EXISTS submission_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    submission_id BIGINT NOT NULL,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    note TEXT,
+    transitioned_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    FOREIGN KEY (submission_id) REFERENCES submissions(id) ON DELETE CASCADE
+);
+
+-- Keeps updated_at current, it is used to export recent changes only.
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at := now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS agents_updated_at ON agents;
+CREATE TRIGGER agents_updated_at
+BEFORE UPDATE ON agents
+FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+DROP TRIGGER IF EXISTS authors_updated_at ON authors;
+CREATE TRIGGER authors_updated_at
+BEFORE UPDATE ON authors
+FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+DROP TRIGGER IF EXISTS books_updated_at ON books;
+CREATE TRIGGER books_updated_at
+BEFORE UPDATE ON books
+FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+
+-- The authors, genres and tags of a book count as changes to the book, and the
+-- name and email of an agent as changes to its authors, which are exported
+-- along with them.
+CREATE OR REPLACE FUNCTION touch_book() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE books SET updated_at = now() WHERE id = OLD.book_id;
+    END IF;
+    IF TG_OP = 'INSERT' OR NEW.book_id <> OLD.book_id THEN
+        UPDATE books SET updated_at = now() WHERE id = NEW.book_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS book_authors_touch_book ON book_authors;
+CREATE TRIGGER book_authors_touch_book
+AFTER INSERT OR UPDATE OR DELETE ON book_authors
+FOR EACH ROW EXECUTE PROCEDURE touch_book();
+
+DROP TRIGGER IF EXISTS book_genres_touch_book ON book_genres;
+CREATE TRIGGER book_genres_touch_book
+AFTER INSERT OR UPDATE OR DELETE ON book_genres
+FOR EACH ROW EXECUTE PROCEDURE touch_book();
+
+DROP TRIGGER IF EXISTS book_tags_touch_book ON book_tags;
+CREATE TRIGGER book_tags_touch_book
+AFTER INSERT OR UPDATE OR DELETE ON book_tags
+FOR EACH ROW EXECUTE PROCEDURE touch_book();
+
+CREATE OR REPLACE FUNCTION touch_agent_authors() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE authors SET updated_at = now()
+    WHERE id IN (
+        SELECT author_id FROM representations
+        WHERE agent_id = NEW.id AND is_primary AND ended_at IS NULL
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS agents_touch_authors ON agents;
+CREATE TRIGGER agents_touch_authors
+AFTER UPDATE OF name, email ON agents
+FOR EACH ROW WHEN (OLD.name <> NEW.name OR OLD.email <> NEW.email)
+EXECUTE PROCEDURE touch_agent_authors();
+
+-- Deleted agents, authors and books leave a tombstone, so that exports of
+-- recent changes can report them.
+CREATE TABLE IF NOT EXISTS deletions (
+    entity TEXT NOT NULL,
+    id BIGINT NOT NULL,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity, id)
+);
+
+CREATE INDEX IF NOT EXISTS deletions_deleted_at_idx ON deletions (deleted_at);
+
+CREATE OR REPLACE FUNCTION record_deletion() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO deletions (entity, id) VALUES (TG_TABLE_NAME, OLD.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS agents_deletion ON agents;
+CREATE TRIGGER agents_deletion
+AFTER DELETE ON agents
+FOR EACH ROW EXECUTE PROCEDURE record_deletion();
+
+DROP TRIGGER IF EXISTS agents_archive_deletion ON agents;
+CREATE TRIGGER agents_archive_deletion
+AFTER UPDATE OF deleted_at ON agents
+FOR EACH ROW WHEN (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL)
+EXECUTE PROCEDURE record_deletion();
+
+DROP TRIGGER IF EXISTS authors_deletion ON authors;
+CREATE TRIGGER authors_deletion
+AFTER DELETE ON authors
+FOR EACH ROW EXECUTE PROCEDURE record_deletion();
+
+DROP TRIGGER IF EXISTS books_deletion ON books;
+CREATE TRIGGER books_deletion
+AFTER DELETE ON books
+FOR EACH ROW EXECUTE PROCEDURE record_deletion();
+
+-- Starting a primary representation changes the agent of the author: it ends
+-- the previous primary representation and any co-representation by the new
+-- agent.
+CREATE OR REPLACE FUNCTION start_primary_representation() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE representations SET ended_at = GREATEST(started_at, NEW.started_at)
+    WHERE author_id = NEW.author_id AND ended_at IS NULL
+        AND (is_primary OR agent_id = NEW.agent_id);
+    UPDATE authors SET updated_at = now() WHERE id = NEW.author_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS representations_start_primary ON representations;
+CREATE TRIGGER representations_start_primary
+BEFORE INSERT ON representations
+FOR EACH ROW WHEN (NEW.is_primary)
+EXECUTE PROCEDURE start_primary_representation();
+
+-- Every author must keep a current primary representation. The check is
+-- deferred to the end of the transaction so that an author can be created
+-- before its representation.
+CREATE OR REPLACE FUNCTION check_author_has_agent() RETURNS TRIGGER AS $$
+DECLARE
+    target_author_id BIGINT;
+BEGIN
+    IF TG_TABLE_NAME = 'authors' THEN
+        target_author_id := NEW.id;
+    ELSE
+        target_author_id := OLD.author_id;
+    END IF;
+    IF EXISTS (SELECT 1 FROM authors WHERE id = target_author_id)
+        AND NOT EXISTS (
+            SELECT 1 FROM representations
+            WHERE author_id = target_author_id AND is_primary AND ended_at IS NULL
+        ) THEN
+        RAISE EXCEPTION 'author % must have a current primary representation', target_author_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS authors_have_agent ON authors;
+CREATE CONSTRAINT TRIGGER authors_have_agent
+AFTER INSERT ON authors
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE PROCEDURE check_author_has_agent();
+
+DROP TRIGGER IF EXISTS representations_keep_agent ON representations;
+CREATE CONSTRAINT TRIGGER representations_keep_agent
+AFTER UPDATE OR DELETE ON representations
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE PROCEDURE check_author_has_agent();
+
+-- Every book must keep at least one author. The check is deferred to the end
+-- of the transaction so that authors can be replaced within it, and can be
+-- waived for a single transaction with:
+--   SELECT set_config('litag.allow_orphaned_books', 'on', true);
+CREATE OR REPLACE FUNCTION check_book_has_authors() RETURNS TRIGGER AS $$
+DECLARE
+    target_book_id BIGINT;
+BEGIN
+    IF TG_TABLE_NAME = 'books' THEN
+        target_book_id := NEW.id;
+    ELSE
+        target_book_id := OLD.book_id;
+    END IF;
+    IF current_setting('litag.allow_orphaned_books', true) = 'on' THEN
+        RETURN NULL;
+    END IF;
+    IF EXISTS (SELECT 1 FROM books WHERE id = target_book_id)
+        AND NOT EXISTS (SELECT 1 FROM book_authors WHERE book_id = target_book_id) THEN
+        RAISE EXCEPTION 'book % must have at least one author', target_book_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS books_have_authors ON books;
+CREATE CONSTRAINT TRIGGER books_have_authors
+AFTER INSERT ON books
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE PROCEDURE check_book_has_authors();
+
+DROP TRIGGER IF EXISTS book_authors_not_empty ON book_authors;
+CREATE CONSTRAINT TRIGGER book_authors_not_empty
+AFTER UPDATE OR DELETE ON book_authors
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE PROCEDURE check_book_has_authors();
+
+-- Royalty rates and sales are for an edition of the book of the deal in the
+-- format of the deal. Unknown deals and editions are left to the foreign keys.
+CREATE OR REPLACE FUNCTION check_edition_in_deal() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM deals, editions
+        WHERE deals.id = NEW.deal_id AND editions.id = NEW.edition_id
+            AND (editions.book_id <> deals.book_id OR editions.format <> deals.format)
+    ) THEN
+        RAISE EXCEPTION 'edition % is not of the book and format of deal %', NEW.edition_id, NEW.deal_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = TG_TABLE_NAME || '_edition_check';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS royalty_rates_edition_in_deal ON royalty_rates;
+CREATE TRIGGER royalty_rates_edition_in_deal
+BEFORE INSERT OR UPDATE OF deal_id, edition_id ON royalty_rates
+FOR EACH ROW EXECUTE PROCEDURE check_edition_in_deal();
+
+DROP TRIGGER IF EXISTS sales_edition_in_deal ON sales;
+CREATE TRIGGER sales_edition_in_deal
+BEFORE INSERT OR UPDATE OF deal_id, edition_id ON sales
+FOR EACH ROW EXECUTE PROCEDURE check_edition_in_deal();
+
+-- Deals and editions with royalty rates or sales cannot change book or format
+-- in a way that would break the above.
+CREATE OR REPLACE FUNCTION check_deal_editions() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM royalty_rates
+        JOIN deals ON deals.id = royalty_rates.deal_id
+        JOIN editions ON editions.id = royalty_rates.edition_id
+        WHERE (CASE TG_TABLE_NAME WHEN 'deals' THEN deals.id ELSE editions.id END) = NEW.id
+            AND (editions.book_id <> deals.book_id OR editions.format <> deals.format)
+    ) THEN
+        RAISE EXCEPTION 'the royalty rates of % % are for editions of another book or format', TG_TABLE_NAME, NEW.id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'royalty_rates_edition_check';
+    END IF;
+    IF EXISTS (
+        SELECT 1 FROM sales
+        JOIN deals ON deals.id = sales.deal_id
+        JOIN editions ON editions.id = sales.edition_id
+        WHERE (CASE TG_TABLE_NAME WHEN 'deals' THEN deals.id ELSE editions.id END) = NEW.id
+            AND (editions.book_id <> deals.book_id OR editions.format <> deals.format)
+    ) THEN
+        RAISE EXCEPTION 'the sales of % % are for editions of another book or format', TG_TABLE_NAME, NEW.id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'sales_edition_check';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS deals_keep_editions ON deals;
+CREATE TRIGGER deals_keep_editions
+AFTER UPDATE OF book_id, format ON deals
+FOR EACH ROW EXECUTE PROCEDURE check_deal_editions();
+
+DROP TRIGGER IF EXISTS editions_keep_deals ON editions;
+CREATE TRIGGER editions_keep_deals
+AFTER UPDATE OF book_id, format ON editions
+FOR EACH ROW EXECUTE PROCEDURE check_deal_editions();
+
+-- Archived agents keep their history but take on no new work. Unknown agents
+-- are left to the foreign keys.
+CREATE OR REPLACE FUNCTION check_agent_not_deleted() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.agent_id = OLD.agent_id THEN
+        RETURN NEW;
+    END IF;
+    IF EXISTS (SELECT 1 FROM agents WHERE id = NEW.agent_id AND deleted_at IS NOT NULL) THEN
+        RAISE EXCEPTION 'agent % has been deleted', NEW.agent_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = TG_TABLE_NAME || '_agent_check';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS representations_agent_not_deleted ON representations;
+CREATE TRIGGER representations_agent_not_deleted
+BEFORE INSERT OR UPDATE OF agent_id ON representations
+FOR EACH ROW EXECUTE PROCEDURE check_agent_not_deleted();
+
+DROP TRIGGER IF EXISTS deals_agent_not_deleted ON deals;
+CREATE TRIGGER deals_agent_not_deleted
+BEFORE INSERT OR UPDATE OF agent_id ON deals
+FOR EACH ROW EXECUTE PROCEDURE check_agent_not_deleted();
+
+DROP TRIGGER IF EXISTS submissions_agent_not_deleted ON submissions;
+CREATE TRIGGER submissions_agent_not_deleted
+BEFORE INSERT OR UPDATE OF agent_id ON submissions
+FOR EACH ROW EXECUTE PROCEDURE check_agent_not_deleted();
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ,
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    CHECK (status IN ('pending', 'delivered', 'dead'))
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx
+ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
\ No newline at end of file
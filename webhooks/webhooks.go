@@ -0,0 +1,209 @@
+// Package webhooks delivers queued webhook events to subscribers.
+//
+// Every delivery is a POST request with the JSON event as the body and the
+// following headers:
+//
+//	X-Litag-Event      the event type, e.g. "book.created"
+//	X-Litag-Delivery   the id of the delivery, stable across retries
+//	X-Litag-Timestamp  unix time at which the request was signed
+//	X-Litag-Signature  "sha256=" followed by the hex encoded HMAC-SHA256 of
+//	                   "<timestamp>.<body>" keyed with the webhook secret
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain" // update the username
+)
+
+// Request headers set on every delivery.
+const (
+	HeaderEvent     = "X-Litag-Event"
+	HeaderDelivery  = "X-Litag-Delivery"
+	HeaderTimestamp = "X-Litag-Timestamp"
+	HeaderSignature = "X-Litag-Signature"
+)
+
+// Store represents the datalayer methods used by the Dispatcher.
+type Store interface {
+	ClaimWebhookDeliveries(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error)
+	CompleteWebhookDelivery(ctx context.Context, args domain.CompleteWebhookDeliveryParams) error
+	FailWebhookDelivery(ctx context.Context, args domain.FailWebhookDeliveryParams) error
+}
+
+// Dispatcher periodically claims pending deliveries and sends them to their
+// webhooks, retrying failures with exponential backoff until MaxAttempts is
+// reached, at which point the delivery is dead-lettered.
+type Dispatcher struct {
+	Store  Store
+	Client *http.Client
+
+	// Interval is the time between polls for pending deliveries.
+	Interval time.Duration
+	// BatchSize is the maximum number of deliveries claimed per poll.
+	BatchSize int
+	// Lease is how long a claimed delivery is hidden from other dispatchers.
+	// Requests still in flight once three quarters of it have passed are
+	// given up, leaving the rest of the lease to record their outcome.
+	Lease time.Duration
+	// MaxAttempts is the number of attempts after which a delivery is dead.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry; it doubles on every
+	// subsequent attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	now func() time.Time
+}
+
+// NewDispatcher returns a new instance of Dispatcher with default settings.
+func NewDispatcher(store Store) *Dispatcher {
+	return &Dispatcher{
+		Store:       store,
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		Interval:    time.Second,
+		BatchSize:   20,
+		Lease:       time.Minute,
+		MaxAttempts: 10,
+		MinBackoff:  10 * time.Second,
+		MaxBackoff:  time.Hour,
+		now:         time.Now,
+	}
+}
+
+// Run dispatches deliveries until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DispatchOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("webhooks: dispatch failed: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce claims a single batch of due deliveries and sends them
+// concurrently. A delivery whose outcome cannot be recorded is logged and
+// claimed again once its lease expires. It returns the number of deliveries
+// whose outcome was recorded.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	rows, err := d.Store.ClaimWebhookDeliveries(ctx, domain.ClaimWebhookDeliveriesParams{
+		LeaseUntil: d.now().Add(d.Lease),
+		BatchSize:  d.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+	var (
+		wg      sync.WaitGroup
+		handled int64
+	)
+	for _, row := range rows {
+		wg.Add(1)
+		go func(row domain.ClaimedWebhookDelivery) {
+			defer wg.Done()
+			if err := d.deliver(ctx, row); err != nil {
+				log.Printf("webhooks: failed to record delivery %d: %s", row.ID, err)
+				return
+			}
+			atomic.AddInt64(&handled, 1)
+		}(row)
+	}
+	wg.Wait()
+	return int(handled), nil
+}
+
+// deliver sends the delivery and records the outcome. The request is given
+// up before the lease of the delivery runs out, so that no other dispatcher
+// claims it while it is still in flight.
+func (d *Dispatcher) deliver(ctx context.Context, row domain.ClaimedWebhookDelivery) error {
+	sendCtx, cancel := context.WithTimeout(ctx, d.Lease*3/4)
+	status, err := d.send(sendCtx, row)
+	cancel()
+	if err == nil {
+		return d.Store.CompleteWebhookDelivery(ctx, domain.CompleteWebhookDeliveryParams{
+			ID:             row.ID,
+			ResponseStatus: status,
+		})
+	}
+	args := domain.FailWebhookDeliveryParams{
+		ID:            row.ID,
+		Status:        domain.DeliveryPending,
+		LastError:     err.Error(),
+		NextAttemptAt: d.now().Add(d.backoff(row.Attempts)),
+	}
+	if status != 0 {
+		args.ResponseStatus = &status
+	}
+	if row.Attempts >= d.MaxAttempts {
+		args.Status = domain.DeliveryDead
+	}
+	return d.Store.FailWebhookDelivery(ctx, args)
+}
+
+// send posts the delivery and returns the response status code, which is
+// zero if no response was received.
+func (d *Dispatcher) send(ctx context.Context, row domain.ClaimedWebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, row.URL, bytes.NewReader(row.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, row.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(row.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(row.Secret, timestamp, row.Payload))
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(res.Body, 1<<16))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected response status: %s", res.Status)
+	}
+	return res.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt of a delivery that has
+// already been attempted the given number of times.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.MinBackoff
+	for i := 1; i < attempts && delay < d.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.MaxBackoff {
+		delay = d.MaxBackoff
+	}
+	return delay
+}
+
+// Sign returns the value of the signature header for a payload sent at the
+// given unix timestamp.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
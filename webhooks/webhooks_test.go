@@ -0,0 +1,262 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fwojciec/litag-example/domain"
+	"github.com/fwojciec/litag-example/generated/mocks"
+	"github.com/fwojciec/litag-example/webhooks"
+)
+
+func TestDispatcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful delivery", func(t *testing.T) {
+		t.Parallel()
+		payload := json.RawMessage(`{"type":"book.created","data":{"id":1}}`)
+		var (
+			receivedBody    []byte
+			receivedHeaders http.Header
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedBody, _ = ioutil.ReadAll(r.Body)
+			receivedHeaders = r.Header
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		var completed domain.CompleteWebhookDeliveryParams
+		store := &mocks.StoreMock{
+			ClaimWebhookDeliveriesFunc: func(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+				return []domain.ClaimedWebhookDelivery{{
+					WebhookDelivery: domain.WebhookDelivery{
+						ID:        7,
+						EventType: domain.EventBookCreated,
+						Payload:   payload,
+						Attempts:  1,
+					},
+					URL:    srv.URL,
+					Secret: "s3cret",
+				}}, nil
+			},
+			CompleteWebhookDeliveryFunc: func(ctx context.Context, args domain.CompleteWebhookDeliveryParams) error {
+				completed = args
+				return nil
+			},
+		}
+		d := webhooks.NewDispatcher(store)
+		n, err := d.DispatchOnce(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, received %v", err)
+		}
+		if n != 1 {
+			t.Errorf("expected 1 delivery, received %d", n)
+		}
+		if string(receivedBody) != string(payload) {
+			t.Errorf("expected body %s, received %s", payload, receivedBody)
+		}
+		if h := receivedHeaders.Get(webhooks.HeaderEvent); h != domain.EventBookCreated {
+			t.Errorf("expected event header %s, received %s", domain.EventBookCreated, h)
+		}
+		if h := receivedHeaders.Get(webhooks.HeaderDelivery); h != "7" {
+			t.Errorf("expected delivery header 7, received %s", h)
+		}
+		timestamp, err := strconv.ParseInt(receivedHeaders.Get(webhooks.HeaderTimestamp), 10, 64)
+		if err != nil {
+			t.Fatalf("invalid timestamp header: %s", err)
+		}
+		if exp := webhooks.Sign("s3cret", timestamp, receivedBody); receivedHeaders.Get(webhooks.HeaderSignature) != exp {
+			t.Errorf("expected signature %s, received %s", exp, receivedHeaders.Get(webhooks.HeaderSignature))
+		}
+		if completed.ID != 7 || completed.ResponseStatus != http.StatusNoContent {
+			t.Errorf("wrong completion: %v", completed)
+		}
+		if len(store.FailWebhookDeliveryCalls()) != 0 {
+			t.Errorf("expected no failures to be recorded")
+		}
+	})
+
+	t.Run("failed delivery", func(t *testing.T) {
+		t.Parallel()
+		tests := []struct {
+			name     string
+			attempts int
+			status   string
+			backoff  time.Duration
+		}{
+			{"first attempt", 1, domain.DeliveryPending, time.Second},
+			{"third attempt", 3, domain.DeliveryPending, 4 * time.Second},
+			{"backoff capped", 5, domain.DeliveryPending, 10 * time.Second},
+			{"last attempt", 6, domain.DeliveryDead, 10 * time.Second},
+		}
+		for _, tc := range tests {
+			tc := tc
+			t.Run(tc.name, func(t *testing.T) {
+				t.Parallel()
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}))
+				defer srv.Close()
+				var failed domain.FailWebhookDeliveryParams
+				store := &mocks.StoreMock{
+					ClaimWebhookDeliveriesFunc: func(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+						return []domain.ClaimedWebhookDelivery{claimed(3, tc.attempts, srv.URL)}, nil
+					},
+					FailWebhookDeliveryFunc: func(ctx context.Context, args domain.FailWebhookDeliveryParams) error {
+						failed = args
+						return nil
+					},
+				}
+				d := webhooks.NewDispatcher(store)
+				d.MaxAttempts = 6
+				d.MinBackoff = time.Second
+				d.MaxBackoff = 10 * time.Second
+				before := time.Now()
+				if _, err := d.DispatchOnce(context.Background()); err != nil {
+					t.Fatalf("expected no error, received %v", err)
+				}
+				after := time.Now()
+				if failed.ID != 3 {
+					t.Errorf("wrong id: expected 3, received %d", failed.ID)
+				}
+				if failed.Status != tc.status {
+					t.Errorf("wrong status: expected %s, received %s", tc.status, failed.Status)
+				}
+				if failed.ResponseStatus == nil || *failed.ResponseStatus != http.StatusInternalServerError {
+					t.Errorf("wrong response status: %v", failed.ResponseStatus)
+				}
+				if failed.LastError == "" {
+					t.Errorf("expected last error to be recorded")
+				}
+				if failed.NextAttemptAt.Before(before.Add(tc.backoff)) || failed.NextAttemptAt.After(after.Add(tc.backoff)) {
+					t.Errorf("expected next attempt %s after now, received %s", tc.backoff, failed.NextAttemptAt.Sub(before))
+				}
+				if len(store.CompleteWebhookDeliveryCalls()) != 0 {
+					t.Errorf("expected no completions to be recorded")
+				}
+			})
+		}
+	})
+
+	t.Run("unreachable receiver", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.NotFoundHandler())
+		url := srv.URL
+		srv.Close()
+
+		var failed domain.FailWebhookDeliveryParams
+		store := &mocks.StoreMock{
+			ClaimWebhookDeliveriesFunc: func(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+				return []domain.ClaimedWebhookDelivery{claimed(5, 1, url)}, nil
+			},
+			FailWebhookDeliveryFunc: func(ctx context.Context, args domain.FailWebhookDeliveryParams) error {
+				failed = args
+				return nil
+			},
+		}
+		if _, err := webhooks.NewDispatcher(store).DispatchOnce(context.Background()); err != nil {
+			t.Fatalf("expected no error, received %v", err)
+		}
+		if failed.Status != domain.DeliveryPending || failed.ResponseStatus != nil || failed.LastError == "" {
+			t.Errorf("wrong failure: %v", failed)
+		}
+	})
+
+	t.Run("failed store call", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		store := &mocks.StoreMock{
+			ClaimWebhookDeliveriesFunc: func(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+				return []domain.ClaimedWebhookDelivery{
+					claimed(1, 1, srv.URL),
+					claimed(2, 1, srv.URL),
+					claimed(3, 1, srv.URL),
+				}, nil
+			},
+			CompleteWebhookDeliveryFunc: func(ctx context.Context, args domain.CompleteWebhookDeliveryParams) error {
+				if args.ID == 2 {
+					return errors.New("connection reset")
+				}
+				return nil
+			},
+		}
+		n, err := webhooks.NewDispatcher(store).DispatchOnce(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, received %v", err)
+		}
+		if n != 2 {
+			t.Errorf("expected 2 handled deliveries, received %d", n)
+		}
+		if calls := len(store.CompleteWebhookDeliveryCalls()); calls != 3 {
+			t.Errorf("expected all 3 deliveries to be completed, received %d", calls)
+		}
+	})
+
+	t.Run("slow receiver", func(t *testing.T) {
+		t.Parallel()
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer srv.Close()
+		defer close(release)
+
+		var failed domain.FailWebhookDeliveryParams
+		store := &mocks.StoreMock{
+			ClaimWebhookDeliveriesFunc: func(ctx context.Context, args domain.ClaimWebhookDeliveriesParams) ([]domain.ClaimedWebhookDelivery, error) {
+				return []domain.ClaimedWebhookDelivery{claimed(4, 1, srv.URL)}, nil
+			},
+			FailWebhookDeliveryFunc: func(ctx context.Context, args domain.FailWebhookDeliveryParams) error {
+				failed = args
+				return nil
+			},
+		}
+		d := webhooks.NewDispatcher(store)
+		d.Lease = 200 * time.Millisecond
+		start := time.Now()
+		n, err := d.DispatchOnce(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, received %v", err)
+		}
+		if elapsed := time.Since(start); elapsed >= d.Lease {
+			t.Errorf("expected the request to be given up within the lease, took %s", elapsed)
+		}
+		if n != 1 || failed.ID != 4 || failed.Status != domain.DeliveryPending || failed.LastError == "" {
+			t.Errorf("expected the delivery to be failed, received %d, %v", n, failed)
+		}
+	})
+}
+
+// claimed returns a delivery of an empty payload to the URL, claimed for the
+// given attempt.
+func claimed(id int64, attempts int, url string) domain.ClaimedWebhookDelivery {
+	return domain.ClaimedWebhookDelivery{
+		WebhookDelivery: domain.WebhookDelivery{ID: id, Payload: json.RawMessage(`{}`), Attempts: attempts},
+		URL:             url,
+	}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+	// echo -n '1577836800.{"a":1}' | openssl dgst -sha256 -hmac secret
+	exp := "sha256=45ed514fb67a0344f38db088c4f2879e0bd79e2e2e05d8472937b87ecdc15b27"
+	res := webhooks.Sign("secret", 1577836800, []byte(`{"a":1}`))
+	if res != exp {
+		t.Errorf("expected %s, received %s", exp, res)
+	}
+}